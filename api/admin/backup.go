@@ -0,0 +1,125 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/backup"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+var (
+	errNoTargetDir = errors.New("argument 'targetDir' not provided")
+)
+
+// BackupConfig describes the database that is backed up by CreateBackup
+type BackupConfig struct {
+	NodeVersion     string
+	DatabaseVersion string
+	NetworkID       uint32
+}
+
+// heightBlock is a block that reports its height
+type heightBlock interface {
+	Height() uint64
+}
+
+type trackedChain struct {
+	ctx *snow.Context
+	vm  interface{}
+}
+
+// chainTracker keeps track of the chains running on this node, so that their
+// state can be recorded in backups
+type chainTracker struct {
+	lock sync.Mutex
+	// Key: Chain's ID
+	// Value: The chain
+	chains map[[32]byte]trackedChain
+}
+
+func newChainTracker() *chainTracker {
+	return &chainTracker{chains: make(map[[32]byte]trackedChain)}
+}
+
+// RegisterChain implements the chains.Registrant interface
+func (c *chainTracker) RegisterChain(ctx *snow.Context, vm interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.chains[ctx.ChainID.Key()] = trackedChain{ctx: ctx, vm: vm}
+}
+
+// states returns the current state of every tracked chain, sorted by chain ID
+func (c *chainTracker) states() []backup.ChainState {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	states := make([]backup.ChainState, 0, len(c.chains))
+	for _, chain := range c.chains {
+		state := backup.ChainState{ChainID: chain.ctx.ChainID.String()}
+		if vm, ok := chain.vm.(block.ChainVM); ok {
+			chain.ctx.Lock.RLock()
+			lastAcceptedID := vm.LastAccepted()
+			state.LastAccepted = lastAcceptedID.String()
+			// Not every block exposes its height
+			if lastAccepted, err := vm.GetBlock(lastAcceptedID); err == nil {
+				if blk, ok := lastAccepted.(heightBlock); ok {
+					state.Height = blk.Height()
+				}
+			}
+			chain.ctx.Lock.RUnlock()
+		}
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].ChainID < states[j].ChainID })
+	return states
+}
+
+// CreateBackupArgs are the arguments for calling CreateBackup
+type CreateBackupArgs struct {
+	// TargetDir is the directory the backup is written to. It must not exist
+	// or be empty.
+	TargetDir string `json:"targetDir"`
+}
+
+// CreateBackupReply is the response from calling CreateBackup
+type CreateBackupReply struct {
+	Manifest backup.Manifest `json:"manifest"`
+}
+
+// CreateBackup writes a consistent snapshot of the node's database to the
+// target directory, without stopping the node. The backup can be restored
+// with the restore command.
+func (service *Admin) CreateBackup(_ *http.Request, args *CreateBackupArgs, reply *CreateBackupReply) error {
+	service.log.Info("Admin: CreateBackup called with TargetDir: %s", args.TargetDir)
+
+	if args.TargetDir == "" {
+		return errNoTargetDir
+	}
+
+	reply.Manifest = backup.Manifest{
+		NodeVersion:     service.backupConfig.NodeVersion,
+		DatabaseVersion: service.backupConfig.DatabaseVersion,
+		NetworkID:       service.backupConfig.NetworkID,
+		Timestamp:       time.Now().Unix(),
+		Chains:          service.chains.states(),
+	}
+	if err := backup.Create(service.db, args.TargetDir, &reply.Manifest); err != nil {
+		return err
+	}
+
+	service.log.Info("Admin: created backup of %d keys with checksum %s in %s",
+		reply.Manifest.NumKeys,
+		reply.Manifest.Checksum,
+		args.TargetDir,
+	)
+	return nil
+}
@@ -0,0 +1,91 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/utils/constants"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+// StartNetworkCaptureArgs are the arguments for calling StartNetworkCapture
+type StartNetworkCaptureArgs struct {
+	// NodeIDs of the peers whose messages are recorded. If empty, the messages
+	// of every peer are.
+	NodeIDs []string `json:"nodeIDs"`
+	// Ops of the messages recorded, such as "get" or "chits". If empty,
+	// messages with every op are.
+	Ops []string `json:"ops"`
+	// MaxFileSize is the size, in bytes, a capture file grows to before the
+	// next one is started. If 0, a default is used.
+	MaxFileSize cjson.Uint64 `json:"maxFileSize"`
+	// MaxFiles is the number of capture files kept. If 0, a default is used.
+	MaxFiles cjson.Uint32 `json:"maxFiles"`
+}
+
+// StartNetworkCaptureReply are the results from calling StartNetworkCapture
+type StartNetworkCaptureReply struct {
+	api.SuccessResponse
+	// Dir is the directory capture files are written to
+	Dir string `json:"dir"`
+}
+
+// StartNetworkCapture records the messages this node sends and receives to
+// rotating capture files, replacing the capture in progress, if any
+func (service *Admin) StartNetworkCapture(_ *http.Request, args *StartNetworkCaptureArgs, reply *StartNetworkCaptureReply) error {
+	service.log.Info("Admin: StartNetworkCapture called with NodeIDs: %v, Ops: %v", args.NodeIDs, args.Ops)
+
+	nodeIDs := ids.ShortSet{}
+	for _, nodeIDStr := range args.NodeIDs {
+		nodeID, err := ids.ShortFromPrefixedString(nodeIDStr, constants.NodeIDPrefix)
+		if err != nil {
+			return fmt.Errorf("couldn't parse nodeID %q: %w", nodeIDStr, err)
+		}
+		nodeIDs.Add(nodeID)
+	}
+	ops, err := network.ParseOps(args.Ops)
+	if err != nil {
+		return err
+	}
+
+	err = service.net.StartCapture(network.CaptureConfig{
+		Dir:         service.captureDir,
+		NodeIDs:     nodeIDs,
+		Ops:         ops,
+		MaxFileSize: int64(args.MaxFileSize),
+		MaxFiles:    int(args.MaxFiles),
+	})
+	if err != nil {
+		return err
+	}
+	reply.Dir = service.captureDir
+	reply.Success = true
+	return nil
+}
+
+// StopNetworkCaptureReply are the results from calling StopNetworkCapture
+type StopNetworkCaptureReply struct {
+	api.SuccessResponse
+	// Files are the capture files that were kept, oldest first
+	Files []string `json:"files"`
+}
+
+// StopNetworkCapture stops recording network messages
+func (service *Admin) StopNetworkCapture(_ *http.Request, _ *struct{}, reply *StopNetworkCaptureReply) error {
+	service.log.Info("Admin: StopNetworkCapture called")
+
+	files, err := service.net.StopCapture()
+	if err != nil {
+		return err
+	}
+	reply.Files = files
+	reply.Success = true
+	return nil
+}
@@ -9,6 +9,28 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 )
 
+// restoreChainAliases re-adds the chain aliases persisted by AliasChain
+func (service *Admin) restoreChainAliases() error {
+	iter := service.aliasDB.NewIterator()
+	defer iter.Release()
+
+	for iter.Next() {
+		alias := string(iter.Key())
+		chainID, err := ids.ToID(iter.Value())
+		if err != nil {
+			return err
+		}
+		if err := service.chainManager.Alias(chainID, alias); err != nil {
+			service.log.Warn("couldn't restore alias %s of chain %s: %s", alias, chainID, err)
+			continue
+		}
+		if err := service.httpServer.AddAliases("bc/"+chainID.String(), "bc/"+alias); err != nil {
+			service.log.Warn("couldn't restore API alias %s of chain %s: %s", alias, chainID, err)
+		}
+	}
+	return iter.Error()
+}
+
 // GetChainAliasesArgs are the arguments for Admin.GetChainAliases API call
 type GetChainAliasesArgs struct{ ChainID string }
 
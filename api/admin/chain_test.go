@@ -0,0 +1,191 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/rpc/v2"
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var (
+	errUnknownChain = errors.New("unknown chain")
+	errAliasTaken   = errors.New("alias already in use")
+)
+
+// testChainManager is a chain manager that runs the chains in [chainIDs]
+type testChainManager struct {
+	chains.MockManager
+
+	chainIDs ids.Set
+	aliases  map[string]ids.ID
+}
+
+func newTestChainManager(chainIDs ...ids.ID) *testChainManager {
+	m := &testChainManager{aliases: make(map[string]ids.ID)}
+	m.chainIDs.Add(chainIDs...)
+	return m
+}
+
+func (m *testChainManager) Lookup(alias string) (ids.ID, error) {
+	if chainID, ok := m.aliases[alias]; ok {
+		return chainID, nil
+	}
+	if chainID, err := ids.FromString(alias); err == nil && m.chainIDs.Contains(chainID) {
+		return chainID, nil
+	}
+	return ids.ID{}, errUnknownChain
+}
+
+func (m *testChainManager) Alias(chainID ids.ID, alias string) error {
+	if !m.chainIDs.Contains(chainID) {
+		return errUnknownChain
+	}
+	if _, ok := m.aliases[alias]; ok {
+		return errAliasTaken
+	}
+	m.aliases[alias] = chainID
+	return nil
+}
+
+func (m *testChainManager) Aliases(chainID ids.ID) []string {
+	aliases := []string(nil)
+	for alias, aliasedID := range m.aliases {
+		if aliasedID.Equals(chainID) {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+type testChainService struct{}
+
+func (*testChainService) Ping(_ *http.Request, _ *struct{}, reply *api.SuccessResponse) error {
+	reply.Success = true
+	return nil
+}
+
+// newTestAdmin starts an API server with the admin API and the API of the
+// chain [chainID], storing the admin API's state in [db]
+func newTestAdmin(t *testing.T, chainManager chains.Manager, chainID ids.ID, db database.Database) *api.Server {
+	server := &api.Server{}
+	if err := server.Initialize(logging.NoLog{}, logging.NoFactory{}, "localhost", 0, false, "", memdb.New(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	handler, err := NewService(
+		logging.NoLog{},
+		logging.NoFactory{},
+		chainManager,
+		nil,
+		nil,
+		"",
+		"",
+		"",
+		server,
+		db,
+		nil,
+		BackupConfig{},
+	)
+	if err != nil {
+		t.Fatalf("couldn't create the admin API: %s", err)
+	}
+	if err := server.AddRoute(handler, new(sync.RWMutex), "admin", "", logging.NoLog{}); err != nil {
+		t.Fatal(err)
+	}
+
+	chainServer := rpc.NewServer()
+	chainServer.RegisterCodec(json2.NewCodec(), "application/json")
+	if err := chainServer.RegisterService(&testChainService{}, "chain"); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.AddRoute(&common.HTTPHandler{Handler: chainServer}, new(sync.RWMutex), "bc/"+chainID.String(), "", logging.NoLog{}); err != nil {
+		t.Fatal(err)
+	}
+	return server
+}
+
+// call calls [method] of the API at [path] of [server]
+func call(server *api.Server, path, method string, args, reply interface{}) error {
+	requestBody, err := json2.EncodeClientRequest(method, args)
+	if err != nil {
+		return err
+	}
+	request := httptest.NewRequest("POST", "/ext/"+path, bytes.NewReader(requestBody))
+	request.Header.Set("Content-Type", "application/json")
+	writer := httptest.NewRecorder()
+	server.Handler().ServeHTTP(writer, request)
+	return json2.DecodeClientResponse(writer.Body, reply)
+}
+
+func TestAliasChainPersisted(t *testing.T) {
+	chainID := ids.Empty.Prefix(1)
+	db := memdb.New()
+
+	server := newTestAdmin(t, newTestChainManager(chainID), chainID, db)
+	reply := api.SuccessResponse{}
+	if err := call(server, "admin", "admin.aliasChain", &AliasChainArgs{Chain: chainID.String(), Alias: "test"}, &reply); err != nil {
+		t.Fatalf("couldn't alias the chain: %s", err)
+	}
+	if !reply.Success {
+		t.Fatalf("aliasing the chain should have succeeded")
+	}
+
+	// restart the node with the same database
+	chainManager := newTestChainManager(chainID)
+	server = newTestAdmin(t, chainManager, chainID, db)
+
+	if aliasedID, err := chainManager.Lookup("test"); err != nil || !aliasedID.Equals(chainID) {
+		t.Fatalf("the alias should have been restored, got %s, %v", aliasedID, err)
+	}
+	aliases := GetChainAliasesReply{}
+	if err := call(server, "admin", "admin.getChainAliases", &GetChainAliasesArgs{ChainID: chainID.String()}, &aliases); err != nil {
+		t.Fatal(err)
+	}
+	if len(aliases.Aliases) != 1 || aliases.Aliases[0] != "test" {
+		t.Fatalf("expected the alias test, got %v", aliases.Aliases)
+	}
+	pingReply := api.SuccessResponse{}
+	if err := call(server, "bc/test", "chain.Ping", &struct{}{}, &pingReply); err != nil || !pingReply.Success {
+		t.Fatalf("the chain's API should be served at its alias, got %v", err)
+	}
+}
+
+func TestRestoreAliasOfUnknownChain(t *testing.T) {
+	chainID := ids.Empty.Prefix(1)
+	unknownChainID := ids.Empty.Prefix(2)
+	db := memdb.New()
+	aliasDB := prefixdb.New([]byte("chain aliases"), db)
+	if err := aliasDB.Put([]byte("known"), chainID.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := aliasDB.Put([]byte("unknown"), unknownChainID.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	chainManager := newTestChainManager(chainID)
+	newTestAdmin(t, chainManager, chainID, db)
+
+	if aliasedID, err := chainManager.Lookup("known"); err != nil || !aliasedID.Equals(chainID) {
+		t.Fatalf("the alias of the known chain should have been restored, got %s, %v", aliasedID, err)
+	}
+	if _, err := chainManager.Lookup("unknown"); err == nil {
+		t.Fatalf("the alias of the unknown chain shouldn't have been restored")
+	}
+}
@@ -0,0 +1,196 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+// Client for the Avalanche Admin API Endpoint
+type Client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a Client for interacting with the Admin API Endpoint
+func NewClient(uri string, requestTimeout time.Duration, options ...rpc.Option) *Client {
+	return &Client{
+		requester: rpc.NewEndpointRequester(uri, "/ext/admin", "admin", requestTimeout, options...),
+	}
+}
+
+// StartCPUProfiler starts a CPU profile and returns the path, on the node,
+// it's written to
+func (c *Client) StartCPUProfiler() (string, error) {
+	res := &ProfileReply{}
+	err := c.requester.SendRequest("startCPUProfiler", struct{}{}, res)
+	return res.Path, err
+}
+
+// StopCPUProfiler stops the running CPU profile and returns the path, on the
+// node, it was written to
+func (c *Client) StopCPUProfiler() (string, error) {
+	res := &ProfileReply{}
+	err := c.requester.SendRequest("stopCPUProfiler", struct{}{}, res)
+	return res.Path, err
+}
+
+// MemoryProfile captures a heap profile and returns the path, on the node, it
+// was written to
+func (c *Client) MemoryProfile() (string, error) {
+	res := &ProfileReply{}
+	err := c.requester.SendRequest("memoryProfile", struct{}{}, res)
+	return res.Path, err
+}
+
+// LockProfile captures a mutex profile and returns the path, on the node, it
+// was written to
+func (c *Client) LockProfile() (string, error) {
+	res := &ProfileReply{}
+	err := c.requester.SendRequest("lockProfile", struct{}{}, res)
+	return res.Path, err
+}
+
+// Alias aliases the API endpoint [endpoint] to [alias]
+func (c *Client) Alias(endpoint, alias string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("alias", &AliasArgs{
+		Endpoint: endpoint,
+		Alias:    alias,
+	}, res)
+	return res.Success, err
+}
+
+// AliasChain aliases the chain [chain] to [alias]. The alias persists across
+// restarts of the node.
+func (c *Client) AliasChain(chain, alias string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("aliasChain", &AliasChainArgs{
+		Chain: chain,
+		Alias: alias,
+	}, res)
+	return res.Success, err
+}
+
+// GetChainAliases returns the aliases of the chain with ID [chainID]
+func (c *Client) GetChainAliases(chainID string) ([]string, error) {
+	res := &GetChainAliasesReply{}
+	err := c.requester.SendRequest("getChainAliases", &GetChainAliasesArgs{
+		ChainID: chainID,
+	}, res)
+	return res.Aliases, err
+}
+
+// CompactDatabase compacts the databases of the chain [chain], or the node's
+// entire database if [chain] is empty
+func (c *Client) CompactDatabase(chain string) (*CompactDatabaseReply, error) {
+	res := &CompactDatabaseReply{}
+	err := c.requester.SendRequest("compactDatabase", &CompactDatabaseArgs{
+		Chain: chain,
+	}, res)
+	return res, err
+}
+
+// BanPeer disconnects the peer [peer], identified by either its node ID or its
+// IP, and prevents it from connecting to the node for [duration], in
+// seconds, or until it's unbanned if [duration] is 0
+func (c *Client) BanPeer(peer PeerArgs, duration uint64) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("banPeer", &BanPeerArgs{
+		PeerArgs: peer,
+		Duration: cjson.Uint64(duration),
+	}, res)
+	return res.Success, err
+}
+
+// UnbanPeer lifts the ban of the peer [peer]. Returns false if it wasn't
+// banned.
+func (c *Client) UnbanPeer(peer PeerArgs) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("unbanPeer", &peer, res)
+	return res.Success, err
+}
+
+// ListBannedPeers returns the node's bans that haven't expired
+func (c *Client) ListBannedPeers() ([]network.Ban, error) {
+	res := &ListBannedPeersReply{}
+	err := c.requester.SendRequest("listBannedPeers", struct{}{}, res)
+	return res.Bans, err
+}
+
+// GetConsensusParameters returns the consensus parameters the chain [chain],
+// identified by its ID or alias, is running with
+func (c *Client) GetConsensusParameters(chain string) (*ConsensusParametersReply, error) {
+	res := &ConsensusParametersReply{}
+	err := c.requester.SendRequest("getConsensusParameters", &ChainArgs{
+		Chain: chain,
+	}, res)
+	return res, err
+}
+
+// SetConsensusParameters changes the consensus parameters of the chain
+// [chain] that can change while it's running. Parameters in [config] that are
+// 0 keep their value. Returns the parameters the chain is running with.
+func (c *Client) SetConsensusParameters(chain string, config chains.ConsensusConfig) (*ConsensusParametersReply, error) {
+	res := &ConsensusParametersReply{}
+	err := c.requester.SendRequest("setConsensusParameters", &SetConsensusParametersArgs{
+		ChainArgs:       ChainArgs{Chain: chain},
+		ConsensusConfig: config,
+	}, res)
+	return res, err
+}
+
+// GetConsensusGraph returns the vertices the Avalanche chain [chain],
+// identified by its ID or alias, is processing
+func (c *Client) GetConsensusGraph(chain string) (*ConsensusGraphReply, error) {
+	res := &ConsensusGraphReply{}
+	err := c.requester.SendRequest("getConsensusGraph", &ChainArgs{
+		Chain: chain,
+	}, res)
+	return res, err
+}
+
+// StartNetworkCapture records the messages the node exchanges with the peers
+// [nodeIDs], with the ops [ops], to capture files. If [nodeIDs] is empty,
+// messages with every peer are recorded, and if [ops] is empty, messages with
+// every op are. Returns the directory the capture files are written to.
+func (c *Client) StartNetworkCapture(nodeIDs []string, ops []string, maxFileSize uint64, maxFiles uint32) (string, error) {
+	res := &StartNetworkCaptureReply{}
+	err := c.requester.SendRequest("startNetworkCapture", &StartNetworkCaptureArgs{
+		NodeIDs:     nodeIDs,
+		Ops:         ops,
+		MaxFileSize: cjson.Uint64(maxFileSize),
+		MaxFiles:    cjson.Uint32(maxFiles),
+	}, res)
+	return res.Dir, err
+}
+
+// StopNetworkCapture stops recording network messages, and returns the
+// capture files that were kept
+func (c *Client) StopNetworkCapture() ([]string, error) {
+	res := &StopNetworkCaptureReply{}
+	err := c.requester.SendRequest("stopNetworkCapture", struct{}{}, res)
+	return res.Files, err
+}
+
+// ListEndpoints returns every HTTP route registered with the node
+func (c *Client) ListEndpoints() ([]Endpoint, error) {
+	res := &ListEndpointsReply{}
+	err := c.requester.SendRequest("listEndpoints", struct{}{}, res)
+	return res.Endpoints, err
+}
+
+// GetAPIDescriptor returns a description of every JSON-RPC service registered
+// with the node
+func (c *Client) GetAPIDescriptor() (*GetAPIDescriptorReply, error) {
+	res := &GetAPIDescriptorReply{}
+	err := c.requester.SendRequest("getAPIDescriptor", struct{}{}, res)
+	return res, err
+}
@@ -0,0 +1,105 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/chains"
+
+	avcon "github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+)
+
+// ChainArgs identify a chain by its ID or alias
+type ChainArgs struct {
+	Chain string `json:"chain"`
+}
+
+// SetConsensusParametersArgs are the arguments for calling
+// SetConsensusParameters. Parameters that are 0 keep their value.
+type SetConsensusParametersArgs struct {
+	ChainArgs
+	chains.ConsensusConfig
+}
+
+// ConsensusParametersReply are the consensus parameters a chain is running
+// with
+type ConsensusParametersReply struct {
+	chains.ConsensusConfig
+}
+
+func newConsensusParametersReply(params avcon.Parameters) ConsensusParametersReply {
+	return ConsensusParametersReply{chains.ConsensusConfig{
+		K:                 params.K,
+		Alpha:             params.Alpha,
+		BetaVirtuous:      params.BetaVirtuous,
+		BetaRogue:         params.BetaRogue,
+		ConcurrentRepolls: params.ConcurrentRepolls,
+		MaxK:              params.MaxK,
+		Parents:           params.Parents,
+		BatchSize:         params.BatchSize,
+		MaxBatchSize:      params.MaxBatchSize,
+	}}
+}
+
+// GetConsensusParameters returns the consensus parameters a chain is running
+// with
+func (service *Admin) GetConsensusParameters(_ *http.Request, args *ChainArgs, reply *ConsensusParametersReply) error {
+	service.log.Info("Admin: GetConsensusParameters called with Chain: %s", args.Chain)
+
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return fmt.Errorf("couldn't find chain %s: %w", args.Chain, err)
+	}
+	params, err := service.chainManager.ConsensusParameters(chainID)
+	if err != nil {
+		return err
+	}
+	*reply = newConsensusParametersReply(params)
+	return nil
+}
+
+// SetConsensusParameters changes the sample size, the largest sample size polls
+// adapt to, the number of concurrent polls, and, for Avalanche chains, the
+// number of parents and the batch sizes of a running chain. The changes last
+// until the node restarts. Alpha and the betas can only be set in the chain's
+// config file.
+func (service *Admin) SetConsensusParameters(_ *http.Request, args *SetConsensusParametersArgs, reply *ConsensusParametersReply) error {
+	service.log.Info("Admin: SetConsensusParameters called with Chain: %s, K: %d, MaxK: %d, ConcurrentRepolls: %d, Parents: %d, BatchSize: %d, MaxBatchSize: %d",
+		args.Chain, args.K, args.MaxK, args.ConcurrentRepolls, args.Parents, args.BatchSize, args.MaxBatchSize)
+
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return fmt.Errorf("couldn't find chain %s: %w", args.Chain, err)
+	}
+	params, err := service.chainManager.SetConsensusParameters(chainID, args.ConsensusConfig)
+	if err != nil {
+		return err
+	}
+	*reply = newConsensusParametersReply(params)
+	return nil
+}
+
+// ConsensusGraphReply are the vertices an Avalanche chain is processing
+type ConsensusGraphReply struct {
+	avcon.Graph
+}
+
+// GetConsensusGraph returns the vertices an Avalanche chain is processing,
+// along with the conflicts, preferences and confidences of their transactions
+func (service *Admin) GetConsensusGraph(_ *http.Request, args *ChainArgs, reply *ConsensusGraphReply) error {
+	service.log.Info("Admin: GetConsensusGraph called with Chain: %s", args.Chain)
+
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return fmt.Errorf("couldn't find chain %s: %w", args.Chain, err)
+	}
+	graph, err := service.chainManager.ConsensusGraph(chainID)
+	if err != nil {
+		return err
+	}
+	reply.Graph = graph
+	return nil
+}
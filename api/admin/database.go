@@ -0,0 +1,92 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+// CompactDatabaseArgs are the arguments for calling CompactDatabase
+type CompactDatabaseArgs struct {
+	// Chain is the ID or alias of the chain whose databases are compacted. If
+	// empty, the node's entire database is compacted.
+	// Since prefixed databases are stored under a hash of their prefix, the
+	// databases a VM creates within its own database are only compacted with
+	// the entire database.
+	Chain string `json:"chain"`
+}
+
+// CompactDatabaseReply is the response from calling CompactDatabase.
+// The sizes are omitted if the database can't report its size.
+type CompactDatabaseReply struct {
+	Duration       time.Duration `json:"duration"`
+	SizeBefore     *cjson.Uint64 `json:"sizeBefore,omitempty"`
+	SizeAfter      *cjson.Uint64 `json:"sizeAfter,omitempty"`
+	ReclaimedBytes *cjson.Uint64 `json:"reclaimedBytes,omitempty"`
+}
+
+// CompactDatabase compacts the node's database, or only the databases of a
+// chain, discarding deleted and overwritten values
+func (service *Admin) CompactDatabase(_ *http.Request, args *CompactDatabaseArgs, reply *CompactDatabaseReply) error {
+	service.log.Info("Admin: CompactDatabase called with Chain: %s", args.Chain)
+
+	dbs := []database.Database{service.db}
+	if args.Chain != "" {
+		chainID, err := service.chainManager.Lookup(args.Chain)
+		if err != nil {
+			return fmt.Errorf("couldn't find chain %s: %w", args.Chain, err)
+		}
+		dbs, err = service.chainManager.Databases(chainID)
+		if err != nil {
+			return err
+		}
+	}
+
+	sizeBefore, sizeKnown := approximateSize(dbs)
+
+	start := time.Now()
+	for _, db := range dbs {
+		if err := db.Compact(nil, nil); err != nil {
+			return fmt.Errorf("couldn't compact database: %w", err)
+		}
+	}
+	reply.Duration = time.Since(start)
+
+	if sizeAfter, ok := approximateSize(dbs); sizeKnown && ok {
+		reclaimed := uint64(0)
+		if sizeBefore > sizeAfter {
+			reclaimed = sizeBefore - sizeAfter
+		}
+		reply.SizeBefore = (*cjson.Uint64)(&sizeBefore)
+		reply.SizeAfter = (*cjson.Uint64)(&sizeAfter)
+		reply.ReclaimedBytes = (*cjson.Uint64)(&reclaimed)
+	}
+
+	service.log.Info("Admin: compacted database in %s", reply.Duration)
+	return nil
+}
+
+// approximateSize returns the total approximate size of [dbs] and true, or
+// false if the size of any of them is unknown
+func approximateSize(dbs []database.Database) (uint64, bool) {
+	total := uint64(0)
+	for _, db := range dbs {
+		sizer, ok := db.(database.Sizer)
+		if !ok {
+			return 0, false
+		}
+		size, err := sizer.ApproximateSize(nil, nil)
+		if err != nil {
+			return 0, false
+		}
+		total += size
+	}
+	return total, true
+}
@@ -0,0 +1,113 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"net/http"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+// Endpoint describes an HTTP route served by the node
+type Endpoint struct {
+	// URL of the endpoint, relative to the node's HTTP address
+	URL string `json:"url"`
+	// Aliases of the URL
+	Aliases []string `json:"aliases"`
+	// ChainID of the chain serving the endpoint, if any
+	ChainID string `json:"chainID,omitempty"`
+	// HandlerType is the Go type of the endpoint's handler, e.g. *json.Server
+	HandlerType string `json:"handlerType"`
+	// LockOption is the lock of the chain or service the handler grabs while
+	// serving a request
+	LockOption string `json:"lockOption"`
+	// AuthRequired is true if requests to the endpoint must include an auth
+	// token
+	AuthRequired bool `json:"authRequired"`
+}
+
+// ListEndpointsReply are the results from calling ListEndpoints
+type ListEndpointsReply struct {
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+// ListEndpoints returns every HTTP route registered with the node, including
+// the routes of chains and their aliases
+func (service *Admin) ListEndpoints(_ *http.Request, _ *struct{}, reply *ListEndpointsReply) error {
+	service.log.Info("Admin: ListEndpoints called")
+
+	routes := service.httpServer.Routes()
+	reply.Endpoints = make([]Endpoint, len(routes))
+	for i, route := range routes {
+		endpoint := Endpoint{
+			URL:          route.URL,
+			Aliases:      route.Aliases,
+			HandlerType:  route.HandlerType,
+			LockOption:   route.LockOption.String(),
+			AuthRequired: route.AuthRequired,
+		}
+		if !route.ChainID.IsZero() {
+			endpoint.ChainID = route.ChainID.String()
+		}
+		reply.Endpoints[i] = endpoint
+	}
+	return nil
+}
+
+// EndpointDescription describes the JSON-RPC services served at an endpoint
+type EndpointDescription struct {
+	// URL of the endpoint, relative to the node's HTTP address
+	URL string `json:"url"`
+	// Aliases of the URL
+	Aliases []string `json:"aliases"`
+	// ChainID of the chain serving the endpoint, if any
+	ChainID string `json:"chainID,omitempty"`
+	// Version of the API the endpoint serves
+	Version cjson.Uint32 `json:"version"`
+	// Services served at the endpoint
+	Services []cjson.ServiceDescription `json:"services"`
+}
+
+// GetAPIDescriptorReply are the results from calling GetAPIDescriptor
+type GetAPIDescriptorReply struct {
+	// Endpoints that serve JSON-RPC services, sorted by URL
+	Endpoints []EndpointDescription `json:"endpoints"`
+	// Definitions of the structs the services' params and results reference,
+	// by the key they're referenced by
+	Definitions map[string]*cjson.TypeDescription `json:"definitions"`
+}
+
+// GetAPIDescriptor returns a machine-readable description of every JSON-RPC
+// service registered with the node: its methods, and the JSON schemas of
+// their params and results, derived from the Go structs they're unmarshalled
+// into. Endpoints whose handlers can't describe themselves, such as those of
+// plugin VMs, are omitted.
+func (service *Admin) GetAPIDescriptor(_ *http.Request, _ *struct{}, reply *GetAPIDescriptorReply) error {
+	service.log.Info("Admin: GetAPIDescriptor called")
+
+	reply.Endpoints = []EndpointDescription{}
+	reply.Definitions = make(map[string]*cjson.TypeDescription)
+	for _, route := range service.httpServer.Routes() {
+		if route.Describer == nil {
+			continue
+		}
+		description := route.Describer.Describe()
+		endpoint := EndpointDescription{
+			URL:      route.URL,
+			Aliases:  route.Aliases,
+			Version:  cjson.Uint32(route.Version),
+			Services: description.Services,
+		}
+		if !route.ChainID.IsZero() {
+			endpoint.ChainID = route.ChainID.String()
+		}
+		reply.Endpoints = append(reply.Endpoints, endpoint)
+		// Structs are keyed by their package path and name, so structs of the
+		// same key have the same definition
+		for key, definition := range description.Definitions {
+			reply.Definitions[key] = definition
+		}
+	}
+	return nil
+}
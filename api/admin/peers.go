@@ -0,0 +1,104 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/utils/constants"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+var errNoPeer = errors.New("need to specify exactly one of nodeID and ip")
+
+// PeerArgs identify a peer by its node ID or by its IP
+type PeerArgs struct {
+	NodeID string `json:"nodeID"`
+	IP     string `json:"ip"`
+}
+
+// parse returns the node ID or IP that [args] identify. Exactly one of them
+// is non-nil.
+func (args *PeerArgs) parse() (*ids.ShortID, net.IP, error) {
+	switch {
+	case (args.NodeID == "") == (args.IP == ""):
+		return nil, nil, errNoPeer
+	case args.NodeID != "":
+		nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't parse nodeID %q: %w", args.NodeID, err)
+		}
+		return &nodeID, nil, nil
+	default:
+		ip := net.ParseIP(args.IP)
+		if ip == nil {
+			return nil, nil, fmt.Errorf("couldn't parse ip %q", args.IP)
+		}
+		return nil, ip, nil
+	}
+}
+
+// BanPeerArgs are the arguments for calling BanPeer
+type BanPeerArgs struct {
+	PeerArgs
+	// Duration of the ban, in seconds. If 0, the peer is banned until it's
+	// unbanned.
+	Duration cjson.Uint64 `json:"duration"`
+}
+
+// BanPeer disconnects a peer and prevents it from connecting to this node
+func (service *Admin) BanPeer(_ *http.Request, args *BanPeerArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: BanPeer called with NodeID: %s, IP: %s, Duration: %d", args.NodeID, args.IP, args.Duration)
+
+	nodeID, ip, err := args.parse()
+	if err != nil {
+		return err
+	}
+	duration := time.Duration(args.Duration) * time.Second
+	if nodeID != nil {
+		service.net.BanNodeID(*nodeID, duration)
+	} else {
+		service.net.BanIP(ip, duration)
+	}
+	reply.Success = true
+	return nil
+}
+
+// UnbanPeer lifts the ban of a peer. Success is false if the peer wasn't
+// banned.
+func (service *Admin) UnbanPeer(_ *http.Request, args *PeerArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: UnbanPeer called with NodeID: %s, IP: %s", args.NodeID, args.IP)
+
+	nodeID, ip, err := args.parse()
+	if err != nil {
+		return err
+	}
+	if nodeID != nil {
+		reply.Success = service.net.UnbanNodeID(*nodeID)
+	} else {
+		reply.Success = service.net.UnbanIP(ip)
+	}
+	return nil
+}
+
+// ListBannedPeersReply are the results from calling ListBannedPeers
+type ListBannedPeersReply struct {
+	Bans []network.Ban `json:"bans"`
+}
+
+// ListBannedPeers returns the bans that haven't expired
+func (service *Admin) ListBannedPeers(_ *http.Request, _ *struct{}, reply *ListBannedPeersReply) error {
+	service.log.Info("Admin: ListBannedPeers called")
+
+	reply.Bans = service.net.Bans()
+	return nil
+}
@@ -6,8 +6,10 @@ package admin
 import (
 	"errors"
 	"os"
+	"path/filepath"
 	"runtime"
 	"runtime/pprof"
+	"sync"
 )
 
 const (
@@ -25,66 +27,90 @@ var (
 )
 
 // Performance provides helper methods for measuring the current performance of
-// the system
-type Performance struct{ cpuProfileFile *os.File }
+// the system. Profiles are written to [dir].
+type Performance struct {
+	dir string
+
+	lock           sync.Mutex
+	cpuProfileFile *os.File
+}
+
+// create the file [name] in the profile directory
+func (p *Performance) create(name string) (*os.File, error) {
+	if err := os.MkdirAll(p.dir, 0750); err != nil {
+		return nil, err
+	}
+	return os.Create(filepath.Join(p.dir, name))
+}
+
+// StartCPUProfiler starts measuring the cpu utilization of this node and
+// returns the path the profile is written to
+func (p *Performance) StartCPUProfiler() (string, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
 
-// StartCPUProfiler starts measuring the cpu utilization of this node
-func (p *Performance) StartCPUProfiler() error {
 	if p.cpuProfileFile != nil {
-		return errCPUProfilerRunning
+		return "", errCPUProfilerRunning
 	}
 
-	file, err := os.Create(cpuProfileFile)
+	file, err := p.create(cpuProfileFile)
 	if err != nil {
-		return err
+		return "", err
 	}
 	if err := pprof.StartCPUProfile(file); err != nil {
 		_ = file.Close() // Return the original error
-		return err
+		return "", err
 	}
 	runtime.SetMutexProfileFraction(1)
 
 	p.cpuProfileFile = file
-	return nil
+	return file.Name(), nil
 }
 
-// StopCPUProfiler stops measuring the cpu utilization of this node
-func (p *Performance) StopCPUProfiler() error {
+// StopCPUProfiler stops measuring the cpu utilization of this node and returns
+// the path the profile was written to
+func (p *Performance) StopCPUProfiler() (string, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
 	if p.cpuProfileFile == nil {
-		return errCPUProfilerNotRunning
+		return "", errCPUProfilerNotRunning
 	}
 
 	pprof.StopCPUProfile()
+	path := p.cpuProfileFile.Name()
 	err := p.cpuProfileFile.Close()
 	p.cpuProfileFile = nil
-	return err
+	return path, err
 }
 
-// MemoryProfile dumps the current memory utilization of this node
-func (p *Performance) MemoryProfile() error {
-	file, err := os.Create(memProfileFile)
+// MemoryProfile dumps the current memory utilization of this node and returns
+// the path it was written to
+func (p *Performance) MemoryProfile() (string, error) {
+	file, err := p.create(memProfileFile)
 	if err != nil {
-		return err
+		return "", err
 	}
 	runtime.GC() // get up-to-date statistics
 	if err := pprof.WriteHeapProfile(file); err != nil {
 		_ = file.Close() // Return the original error
-		return err
+		return "", err
 	}
-	return file.Close()
+	return file.Name(), file.Close()
 }
 
-// LockProfile dumps the current lock statistics of this node
-func (p *Performance) LockProfile() error {
-	file, err := os.Create(lockProfileFile)
+// LockProfile dumps the current lock statistics of this node and returns the
+// path they were written to
+func (p *Performance) LockProfile() (string, error) {
+	file, err := p.create(lockProfileFile)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	profile := pprof.Lookup("mutex")
 	if err := profile.WriteTo(file, 1); err != nil {
 		_ = file.Close() // Return the original error
-		return err
+		return "", err
 	}
-	return file.Close()
+	return file.Name(), file.Close()
 }
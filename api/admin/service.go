@@ -5,15 +5,22 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
-
-	"github.com/gorilla/rpc/v2"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/drops"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
 
 	cjson "github.com/ava-labs/avalanchego/utils/json"
 )
@@ -27,61 +34,118 @@ const (
 
 var (
 	errAliasTooLong = errors.New("alias length is too long")
+	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
 )
 
 // Admin is the API service for node admin management
 type Admin struct {
 	log          logging.Logger
+	logFactory   logging.Factory
 	performance  Performance
 	chainManager chains.Manager
+	vmManager    vms.Manager
+	net          network.Network
+	pluginDir    string
+	captureDir   string
 	httpServer   *api.Server
+	db           database.Database
+	aliasDB      database.Database
+	dropLog      *drops.Log
+	backupConfig BackupConfig
+	chains       *chainTracker
 }
 
 // NewService returns a new admin API service
-func NewService(log logging.Logger, chainManager chains.Manager, httpServer *api.Server) (*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+func NewService(
+	log logging.Logger,
+	logFactory logging.Factory,
+	chainManager chains.Manager,
+	vmManager vms.Manager,
+	net network.Network,
+	pluginDir string,
+	profileDir string,
+	captureDir string,
+	httpServer *api.Server,
+	db database.Database,
+	dropLog *drops.Log,
+	backupConfig BackupConfig,
+) (*common.HTTPHandler, error) {
+	newServer := cjson.NewServer()
 	codec := cjson.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
-	if err := newServer.RegisterService(&Admin{
+	chains := newChainTracker()
+	service := &Admin{
 		log:          log,
+		logFactory:   logFactory,
+		performance:  Performance{dir: profileDir},
 		chainManager: chainManager,
+		vmManager:    vmManager,
+		net:          net,
+		pluginDir:    pluginDir,
+		captureDir:   captureDir,
 		httpServer:   httpServer,
-	}, "admin"); err != nil {
+		db:           db,
+		dropLog:      dropLog,
+		backupConfig: backupConfig,
+		chains:       chains,
+		aliasDB:      prefixdb.New([]byte("chain aliases"), db),
+	}
+	if err := service.restoreChainAliases(); err != nil {
+		return nil, err
+	}
+	if err := newServer.RegisterService(service, "admin"); err != nil {
 		return nil, err
 	}
+	chainManager.AddRegistrant(chains)
 	return &common.HTTPHandler{Handler: newServer}, nil
 }
 
-// StartCPUProfiler starts a cpu profile writing to the specified file
-func (service *Admin) StartCPUProfiler(_ *http.Request, _ *struct{}, reply *api.SuccessResponse) error {
+// ProfileReply are the results from calling a profiling method
+type ProfileReply struct {
+	api.SuccessResponse
+	// Path of the file the profile is written to
+	Path string `json:"path"`
+}
+
+// StartCPUProfiler starts a cpu profile writing to the profile directory
+func (service *Admin) StartCPUProfiler(_ *http.Request, _ *struct{}, reply *ProfileReply) error {
 	service.log.Info("Admin: StartCPUProfiler called")
-	reply.Success = true
-	return service.performance.StartCPUProfiler()
+
+	path, err := service.performance.StartCPUProfiler()
+	reply.Success = err == nil
+	reply.Path = path
+	return err
 }
 
 // StopCPUProfiler stops the cpu profile
-func (service *Admin) StopCPUProfiler(_ *http.Request, _ *struct{}, reply *api.SuccessResponse) error {
+func (service *Admin) StopCPUProfiler(_ *http.Request, _ *struct{}, reply *ProfileReply) error {
 	service.log.Info("Admin: StopCPUProfiler called")
 
-	reply.Success = true
-	return service.performance.StopCPUProfiler()
+	path, err := service.performance.StopCPUProfiler()
+	reply.Success = err == nil
+	reply.Path = path
+	return err
 }
 
-// MemoryProfile runs a memory profile writing to the specified file
-func (service *Admin) MemoryProfile(_ *http.Request, _ *struct{}, reply *api.SuccessResponse) error {
+// MemoryProfile runs a memory profile writing to the profile directory
+func (service *Admin) MemoryProfile(_ *http.Request, _ *struct{}, reply *ProfileReply) error {
 	service.log.Info("Admin: MemoryProfile called")
 
-	reply.Success = true
-	return service.performance.MemoryProfile()
+	path, err := service.performance.MemoryProfile()
+	reply.Success = err == nil
+	reply.Path = path
+	return err
 }
 
-// LockProfile runs a mutex profile writing to the specified file
-func (service *Admin) LockProfile(_ *http.Request, _ *struct{}, reply *api.SuccessResponse) error {
+// LockProfile runs a mutex profile writing to the profile directory
+func (service *Admin) LockProfile(_ *http.Request, _ *struct{}, reply *ProfileReply) error {
 	service.log.Info("Admin: LockProfile called")
 
-	reply.Success = true
-	return service.performance.LockProfile()
+	path, err := service.performance.LockProfile()
+	reply.Success = err == nil
+	reply.Path = path
+	return err
 }
 
 // AliasArgs are the arguments for calling Alias
@@ -108,7 +172,8 @@ type AliasChainArgs struct {
 	Alias string `json:"alias"`
 }
 
-// AliasChain attempts to alias a chain to a new name
+// AliasChain attempts to alias a chain to a new name. The alias is persisted
+// so that it's restored when the node restarts.
 func (service *Admin) AliasChain(_ *http.Request, args *AliasChainArgs, reply *api.SuccessResponse) error {
 	service.log.Info("Admin: AliasChain called with Chain: %s, Alias: %s", args.Chain, args.Alias)
 
@@ -123,6 +188,9 @@ func (service *Admin) AliasChain(_ *http.Request, args *AliasChainArgs, reply *a
 	if err := service.chainManager.Alias(chainID, args.Alias); err != nil {
 		return err
 	}
+	if err := service.aliasDB.Put([]byte(args.Alias), chainID.Bytes()); err != nil {
+		return err
+	}
 
 	reply.Success = true
 	return service.httpServer.AddAliasesWithReadLock("bc/"+chainID.String(), "bc/"+args.Alias)
@@ -136,3 +204,188 @@ func (service *Admin) Stacktrace(_ *http.Request, _ *struct{}, reply *api.Succes
 	stacktrace := []byte(logging.Stacktrace{Global: true}.String())
 	return ioutil.WriteFile(stacktraceFile, stacktrace, 0600)
 }
+
+// DroppedMessage describes a message that was dropped by this node
+type DroppedMessage struct {
+	Time    time.Time `json:"time"`
+	NodeID  string    `json:"nodeID"`
+	Op      string    `json:"op"`
+	ChainID string    `json:"chainID,omitempty"`
+	Reason  string    `json:"reason"`
+}
+
+// GetDroppedMessagesReply are the results from calling GetDroppedMessages
+type GetDroppedMessagesReply struct {
+	// Number of messages dropped since the node started, by reason
+	Counts map[string]cjson.Uint64 `json:"counts"`
+	// Most recently dropped messages, oldest first
+	Recent []DroppedMessage `json:"recent"`
+}
+
+// GetDroppedMessages returns the number of messages this node has dropped for
+// each reason and the most recently dropped messages
+func (service *Admin) GetDroppedMessages(_ *http.Request, _ *struct{}, reply *GetDroppedMessagesReply) error {
+	service.log.Info("Admin: GetDroppedMessages called")
+
+	reply.Counts = make(map[string]cjson.Uint64)
+	for reason, count := range service.dropLog.Counts() {
+		reply.Counts[string(reason)] = cjson.Uint64(count)
+	}
+
+	recent := service.dropLog.Recent()
+	reply.Recent = make([]DroppedMessage, len(recent))
+	for i, drop := range recent {
+		reply.Recent[i] = DroppedMessage{
+			Time:   drop.Time,
+			NodeID: drop.NodeID.PrefixedString(constants.NodeIDPrefix),
+			Op:     drop.Op,
+			Reason: string(drop.Reason),
+		}
+		if !drop.ChainID.IsZero() {
+			reply.Recent[i].ChainID = drop.ChainID.String()
+		}
+	}
+	return nil
+}
+
+// SetLoggerLevelArgs are the arguments for calling SetLoggerLevel
+type SetLoggerLevelArgs struct {
+	// Name of the logger to update, as returned by GetLoggerLevels. If empty,
+	// all loggers are updated.
+	LoggerName string `json:"loggerName"`
+	// LogLevel to set, such as "debug". Left unchanged if empty.
+	LogLevel string `json:"logLevel"`
+	// DisplayLevel to set, such as "info". Left unchanged if empty.
+	DisplayLevel string `json:"displayLevel"`
+}
+
+// SetLoggerLevel sets the log level and/or display level of a logger, or of
+// all loggers if no logger is named
+func (service *Admin) SetLoggerLevel(_ *http.Request, args *SetLoggerLevelArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: SetLoggerLevel called with LoggerName: %q, LogLevel: %q, DisplayLevel: %q",
+		args.LoggerName, args.LogLevel, args.DisplayLevel)
+
+	if args.LogLevel == "" && args.DisplayLevel == "" {
+		return errNoLogLevel
+	}
+
+	var (
+		logLevel, displayLevel logging.Level
+		err                    error
+	)
+	if args.LogLevel != "" {
+		if logLevel, err = logging.ToLevel(args.LogLevel); err != nil {
+			return fmt.Errorf("couldn't parse log level: %w", err)
+		}
+	}
+	if args.DisplayLevel != "" {
+		if displayLevel, err = logging.ToLevel(args.DisplayLevel); err != nil {
+			return fmt.Errorf("couldn't parse display level: %w", err)
+		}
+	}
+
+	loggerNames := []string{args.LoggerName}
+	if args.LoggerName == "" {
+		loggerNames = service.logFactory.GetLoggerNames()
+	}
+	for _, name := range loggerNames {
+		if args.LogLevel != "" {
+			if err := service.logFactory.SetLogLevel(name, logLevel); err != nil {
+				return err
+			}
+		}
+		if args.DisplayLevel != "" {
+			if err := service.logFactory.SetDisplayLevel(name, displayLevel); err != nil {
+				return err
+			}
+		}
+	}
+
+	reply.Success = true
+	return nil
+}
+
+// LogAndDisplayLevels are the levels of a logger
+type LogAndDisplayLevels struct {
+	LogLevel     string `json:"logLevel"`
+	DisplayLevel string `json:"displayLevel"`
+}
+
+// GetLoggerLevelsArgs are the arguments for calling GetLoggerLevels
+type GetLoggerLevelsArgs struct {
+	// Name of the logger to report. If empty, all loggers are reported.
+	LoggerName string `json:"loggerName"`
+}
+
+// GetLoggerLevelsReply are the results from calling GetLoggerLevels
+type GetLoggerLevelsReply struct {
+	LoggerLevels map[string]LogAndDisplayLevels `json:"loggerLevels"`
+}
+
+// GetLoggerLevels returns the log level and display level of a logger, or of
+// all loggers if no logger is named
+func (service *Admin) GetLoggerLevels(_ *http.Request, args *GetLoggerLevelsArgs, reply *GetLoggerLevelsReply) error {
+	service.log.Info("Admin: GetLoggerLevels called with LoggerName: %q", args.LoggerName)
+
+	loggerNames := []string{args.LoggerName}
+	if args.LoggerName == "" {
+		loggerNames = service.logFactory.GetLoggerNames()
+	}
+
+	reply.LoggerLevels = make(map[string]LogAndDisplayLevels, len(loggerNames))
+	for _, name := range loggerNames {
+		logLevel, err := service.logFactory.GetLogLevel(name)
+		if err != nil {
+			return err
+		}
+		displayLevel, err := service.logFactory.GetDisplayLevel(name)
+		if err != nil {
+			return err
+		}
+		reply.LoggerLevels[name] = LogAndDisplayLevels{
+			LogLevel:     logLevel.Name(),
+			DisplayLevel: displayLevel.Name(),
+		}
+	}
+	return nil
+}
+
+// LoadVMsReply are the results from calling LoadVMs
+type LoadVMsReply struct {
+	// NewVMs maps the ID of each newly registered VM to its aliases
+	NewVMs map[string][]string `json:"newVMs"`
+	// FailedVMs maps the path of each plugin that couldn't be registered to
+	// the reason why
+	FailedVMs map[string]string `json:"failedVMs,omitempty"`
+}
+
+// LoadVMs registers the VM plugins in the plugin directory that were installed
+// since the node started
+func (service *Admin) LoadVMs(_ *http.Request, _ *struct{}, reply *LoadVMsReply) error {
+	service.log.Info("Admin: LoadVMs called")
+
+	loaded, failed, err := rpcchainvm.LoadPlugins(service.vmManager, service.pluginDir)
+	if err != nil {
+		return err
+	}
+
+	reply.NewVMs = make(map[string][]string, len(loaded))
+	for path, vmID := range loaded {
+		service.log.Info("registered VM %s from plugin %s", vmID, path)
+		aliases := []string{}
+		for _, alias := range service.vmManager.Aliases(vmID) {
+			// The string representation of a VM's ID is also an alias
+			if alias != vmID.String() {
+				aliases = append(aliases, alias)
+			}
+		}
+		reply.NewVMs[vmID.String()] = aliases
+	}
+	if len(failed) > 0 {
+		reply.FailedVMs = make(map[string]string, len(failed))
+		for path, err := range failed {
+			reply.FailedVMs[path] = err.Error()
+		}
+	}
+	return nil
+}
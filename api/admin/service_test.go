@@ -0,0 +1,104 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms"
+)
+
+var errUnknownVM = errors.New("unknown VM")
+
+// testVMManager is a VM manager that doesn't start the VMs it registers
+type testVMManager struct {
+	vms.Manager
+
+	registered ids.Set
+	aliases    map[string]ids.ID
+}
+
+func (m *testVMManager) GetVMFactory(vmID ids.ID) (vms.VMFactory, error) {
+	if !m.registered.Contains(vmID) {
+		return nil, errUnknownVM
+	}
+	return nil, nil
+}
+
+func (m *testVMManager) RegisterVMFactory(vmID ids.ID, _ vms.VMFactory) error {
+	m.registered.Add(vmID)
+	return nil
+}
+
+func (m *testVMManager) Lookup(alias string) (ids.ID, error) {
+	if vmID, ok := m.aliases[alias]; ok {
+		return vmID, nil
+	}
+	return ids.ID{}, errUnknownVM
+}
+
+func (m *testVMManager) Aliases(vmID ids.ID) []string {
+	aliases := []string{vmID.String()}
+	for alias, aliasedID := range m.aliases {
+		if aliasedID.Equals(vmID) {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+func TestLoadVMs(t *testing.T) {
+	newID := ids.Empty.Prefix(1)
+	registeredID := ids.Empty.Prefix(2)
+
+	dir, err := ioutil.TempDir("", "plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"new", "registered", "unknown"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	vmManager := &testVMManager{aliases: map[string]ids.ID{
+		"new":        newID,
+		"registered": registeredID,
+	}}
+	vmManager.registered.Add(registeredID)
+	service := &Admin{log: logging.NoLog{}, vmManager: vmManager, pluginDir: dir}
+
+	reply := LoadVMsReply{}
+	if err := service.LoadVMs(nil, nil, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string][]string{newID.String(): {"new"}}; !reflect.DeepEqual(expected, reply.NewVMs) {
+		t.Fatalf("expected new VMs %v, got %v", expected, reply.NewVMs)
+	}
+	if _, ok := reply.FailedVMs[filepath.Join(dir, "unknown")]; !ok || len(reply.FailedVMs) != 1 {
+		t.Fatalf("only the plugin that isn't named after a VM should have failed, got %v", reply.FailedVMs)
+	}
+
+	// every plugin is already registered, or still can't be
+	reply = LoadVMsReply{}
+	if err := service.LoadVMs(nil, nil, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.NewVMs) != 0 || len(reply.FailedVMs) != 1 {
+		t.Fatalf("no new VMs should have been registered, got %v and %v", reply.NewVMs, reply.FailedVMs)
+	}
+
+	service.pluginDir = filepath.Join(dir, "missing")
+	if err := service.LoadVMs(nil, nil, &LoadVMsReply{}); err == nil {
+		t.Fatal("loading VMs from a plugin directory that doesn't exist should have failed")
+	}
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
@@ -12,8 +13,11 @@ import (
 
 	jwt "github.com/dgrijalva/jwt-go"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/utils/password"
 	"github.com/ava-labs/avalanchego/utils/timer"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
 )
 
 const (
@@ -34,6 +38,12 @@ var (
 	errWrongPassword      = errors.New("incorrect password")
 	errInvalidTokenFormat = errors.New("token is invalid format")
 	errSamePassword       = errors.New("new password can't be same as old password")
+	errExpiryInPast       = errors.New("token expiry must be in the future")
+	errInvalidToken       = errors.New("invalid auth token. Is it expired?")
+	errWrongClaims        = errors.New("expected auth token's claims to be type endpointClaims but is different type")
+	errRevokedToken       = errors.New("the provided auth token was revoked")
+	errEndpointNotAllowed = errors.New("the provided auth token does not allow access to this endpoint")
+	errMethodNotAllowed   = errors.New("the provided auth token does not allow calling this method")
 )
 
 // Auth handles HTTP API authorization for this node
@@ -41,6 +51,10 @@ type Auth struct {
 	Enabled  bool          // True iff API calls need auth token
 	Password password.Hash // Hash of the password. Can be changed via API call.
 
+	// Persists the revoked tokens across restarts. If nil, revoked tokens are
+	// only kept in memory.
+	DB database.Database
+
 	lock    sync.RWMutex // Prevent race condition when accessing password
 	clock   timer.Clock  // Tells the time. Can be faked for testing
 	revoked []string     // List of tokens that have been revoked
@@ -54,6 +68,37 @@ type endpointClaims struct {
 	// If endpoints has an element "*", allows access to all API endpoints
 	// In this case, "*" should be the only element of [endpoints]
 	Endpoints []string
+
+	// If non-empty, each element is a JSON-RPC method that the token allows
+	// calling, e.g. "info.getNodeID", or a service all of whose methods the
+	// token allows calling, e.g. "health.*"
+	Methods []string `json:",omitempty"`
+}
+
+// allowsMethod returns true if the claims allow calling [method]
+func (c *endpointClaims) allowsMethod(method string) bool {
+	if len(c.Methods) == 0 {
+		return true
+	}
+	for _, allowed := range c.Methods {
+		if allowed == method {
+			return true
+		}
+		if strings.HasSuffix(allowed, ".*") && strings.HasPrefix(method, allowed[:len(allowed)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsEndpoint returns true if the claims allow access to the API at [path]
+func (c *endpointClaims) allowsEndpoint(path string) bool {
+	for _, endpoint := range c.Endpoints {
+		if endpoint == "*" || strings.HasSuffix(path, endpoint) {
+			return true
+		}
+	}
+	return false
 }
 
 // getTokenKey returns the key to use when making and parsing tokens
@@ -79,11 +124,24 @@ func getToken(r *http.Request) (string, error) {
 // that the API's path ends with an element of [endpoints]
 // If one of the elements of [endpoints] is "*", allows access to all APIs
 func (auth *Auth) newToken(password string, endpoints []string) (string, error) {
+	return auth.newScopedToken(password, endpoints, nil, time.Time{})
+}
+
+// newScopedToken is like newToken, but the token only allows calling the
+// methods in [methods], if non-empty, and expires at [expiresAt], or after
+// [TokenLifespan] if [expiresAt] is the zero value
+func (auth *Auth) newScopedToken(password string, endpoints, methods []string, expiresAt time.Time) (string, error) {
 	auth.lock.RLock()
 	defer auth.lock.RUnlock()
 	if !auth.Password.Check(password) {
 		return "", errWrongPassword
 	}
+	now := auth.clock.Time()
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(TokenLifespan)
+	} else if !expiresAt.After(now) {
+		return "", errExpiryInPast
+	}
 	canAccessAll := false
 	for _, endpoint := range endpoints {
 		if endpoint == "*" {
@@ -93,8 +151,9 @@ func (auth *Auth) newToken(password string, endpoints []string) (string, error)
 	}
 	claims := endpointClaims{
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: auth.clock.Time().Add(TokenLifespan).Unix(),
+			ExpiresAt: expiresAt.Unix(),
 		},
+		Methods: methods,
 	}
 	if canAccessAll {
 		claims.Endpoints = []string{"*"}
@@ -120,16 +179,80 @@ func (auth *Auth) revokeToken(tokenStr string, password string) error {
 	}
 
 	// See if token is well-formed and signature is right
-	token, err := jwt.Parse(tokenStr, auth.getTokenKey)
+	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, auth.getTokenKey)
 	if err != nil {
 		return err
 	}
 
 	// Only need to revoke if the token is valid
-	if token.Valid {
-		auth.revoked = append(auth.revoked, tokenStr)
+	if !token.Valid {
+		return nil
 	}
-	return nil
+	auth.revoked = append(auth.revoked, tokenStr)
+	if auth.DB == nil {
+		return nil
+	}
+
+	// Remember when the token expires so that it can be forgotten after then
+	claims, ok := token.Claims.(*endpointClaims)
+	if !ok {
+		return nil
+	}
+	expiresAt := make([]byte, 8)
+	binary.BigEndian.PutUint64(expiresAt, uint64(claims.ExpiresAt))
+	return auth.DB.Put([]byte(tokenStr), expiresAt)
+}
+
+// RestoreRevokedTokens loads the tokens revoked before the node restarted from
+// [auth.DB], and deletes those that have expired since
+func (auth *Auth) RestoreRevokedTokens() error {
+	auth.lock.Lock()
+	defer auth.lock.Unlock()
+
+	if auth.DB == nil {
+		return nil
+	}
+
+	now := auth.clock.Unix()
+	batch := auth.DB.NewBatch()
+	iter := auth.DB.NewIterator()
+	defer iter.Release()
+
+	for iter.Next() {
+		if value := iter.Value(); len(value) == 8 && binary.BigEndian.Uint64(value) > now {
+			auth.revoked = append(auth.revoked, string(iter.Key()))
+			continue
+		}
+		if err := batch.Delete(iter.Key()); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// clearRevokedTokens deletes the persisted revoked tokens.
+// Assumes [auth.lock] is held.
+func (auth *Auth) clearRevokedTokens() error {
+	if auth.DB == nil {
+		return nil
+	}
+
+	batch := auth.DB.NewBatch()
+	iter := auth.DB.NewIterator()
+	defer iter.Release()
+
+	for iter.Next() {
+		if err := batch.Delete(iter.Key()); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
 }
 
 // Change the password required to create and revoke tokens.
@@ -158,6 +281,55 @@ func (auth *Auth) changePassword(oldPassword, newPassword string) error {
 	// All the revoked tokens are now invalid; no need to mark specifically as
 	// revoked.
 	auth.revoked = nil
+	return auth.clearRevokedTokens()
+}
+
+// parseToken returns the claims of the token whose string repr. is
+// [tokenStr], if the token is valid, unexpired and not revoked
+func (auth *Auth) parseToken(tokenStr string) (*endpointClaims, error) {
+	auth.lock.RLock()
+	defer auth.lock.RUnlock()
+
+	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, auth.getTokenKey)
+	if err != nil { // Probably because signature wrong
+		return nil, fmt.Errorf("invalid auth token: %s", err)
+	}
+	if !token.Valid { // Check that token isn't expired
+		return nil, errInvalidToken
+	}
+	claims, ok := token.Claims.(*endpointClaims)
+	if !ok {
+		return nil, errWrongClaims
+	}
+	for _, revokedToken := range auth.revoked { // Make sure this token wasn't revoked
+		if revokedToken == tokenStr {
+			return nil, errRevokedToken
+		}
+	}
+	return claims, nil
+}
+
+// Authorize returns nil if the auth token in the header of [r] allows calling
+// [method] on the API at [path], e.g. /ext/bc/X. If auth tokens aren't in use,
+// every call is allowed.
+func (auth *Auth) Authorize(r *http.Request, path, method string) error {
+	if !auth.Enabled {
+		return nil
+	}
+	tokenStr, err := getToken(r)
+	if err != nil {
+		return err
+	}
+	claims, err := auth.parseToken(tokenStr)
+	if err != nil {
+		return err
+	}
+	if !claims.allowsEndpoint(path) {
+		return errEndpointNotAllowed
+	}
+	if !claims.allowsMethod(method) {
+		return fmt.Errorf("%w: %q", errMethodNotAllowed, method)
+	}
 	return nil
 }
 
@@ -195,61 +367,44 @@ func (auth *Auth) WrapHandler(h http.Handler) http.Handler {
 			return
 		}
 
-		auth.lock.RLock()
-		token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, auth.getTokenKey)
-		auth.lock.RUnlock()
-
-		if err != nil { // Probably because signature wrong
-			w.WriteHeader(http.StatusUnauthorized)
-			// Error is intentionally dropped here as there is nothing left to
-			// do with it.
-			_, _ = io.WriteString(w, fmt.Sprintf("invalid auth token: %s", err))
-			return
-		}
-		if !token.Valid { // Check that token isn't expired
+		claims, err := auth.parseToken(tokenStr)
+		if err != nil {
 			w.WriteHeader(http.StatusUnauthorized)
 			// Error is intentionally dropped here as there is nothing left to
 			// do with it.
-			_, _ = io.WriteString(w, "invalid auth token. Is it expired?")
+			_, _ = io.WriteString(w, err.Error())
 			return
 		}
 
 		// Make sure this token gives access to the requested endpoint
-		claims, ok := token.Claims.(*endpointClaims)
-		if !ok {
+		if !claims.allowsEndpoint(r.URL.Path) {
 			w.WriteHeader(http.StatusUnauthorized)
 			// Error is intentionally dropped here as there is nothing left to
 			// do with it.
-			_, _ = io.WriteString(w, "expected auth token's claims to be type endpointClaims but is different type")
-			return
-		}
-		canAccess := false // true iff the token authorizes access to the API
-		for _, endpoint := range claims.Endpoints {
-			if endpoint == "*" || strings.HasSuffix(r.URL.Path, endpoint) {
-				canAccess = true
-				break
-			}
-		}
-		if !canAccess {
-			w.WriteHeader(http.StatusUnauthorized)
-			// Error is intentionally dropped here as there is nothing left to
-			// do with it.
-			_, _ = io.WriteString(w, "the provided auth token does not allow access to this endpoint")
+			_, _ = io.WriteString(w, errEndpointNotAllowed.Error())
 			return
 		}
 
-		auth.lock.RLock()
-		for _, revokedToken := range auth.revoked { // Make sure this token wasn't revoked
-			if revokedToken == tokenStr {
+		// Make sure this token allows calling the requested methods
+		if len(claims.Methods) > 0 {
+			methods, err := cjson.GetMethods(r)
+			if err != nil || len(methods) == 0 {
 				w.WriteHeader(http.StatusUnauthorized)
-				// Error is intentionally dropped here as there is nothing left
-				// to do with it.
-				_, _ = io.WriteString(w, "the provided auth token was revoked")
-				auth.lock.RUnlock()
+				// Error is intentionally dropped here as there is nothing
+				// left to do with it.
+				_, _ = io.WriteString(w, "the provided auth token only allows JSON-RPC calls to specific methods")
 				return
 			}
+			for _, method := range methods {
+				if !claims.allowsMethod(method) {
+					w.WriteHeader(http.StatusUnauthorized)
+					// Error is intentionally dropped here as there is nothing
+					// left to do with it.
+					_, _ = io.WriteString(w, fmt.Sprintf("the provided auth token does not allow calling %q", method))
+					return
+				}
+			}
 		}
-		auth.lock.RUnlock()
 
 		h.ServeHTTP(w, r) // Authorization successful
 	})
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +12,7 @@ import (
 
 	jwt "github.com/dgrijalva/jwt-go"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/utils/password"
 )
 
@@ -379,3 +381,164 @@ func TestWrapHandlerAuthDisabled(t *testing.T) {
 		}
 	}
 }
+
+func TestWrapHandlerScopedMethods(t *testing.T) {
+	auth := Auth{
+		Enabled:  true,
+		Password: hashedPassword,
+	}
+
+	// Make a token that only allows read-only calls
+	tokenStr, err := auth.newScopedToken(testPassword, []string{"*"}, []string{"info.*", "health.getLiveness"}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrappedHandler := auth.WrapHandler(dummyHandler)
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"jsonrpc":"2.0","id":1,"method":"info.getNodeID"}`, http.StatusOK},
+		{`{"jsonrpc":"2.0","id":1,"method":"health.getLiveness"}`, http.StatusOK},
+		{`[{"method":"info.peers"},{"method":"info.getNetworkID"}]`, http.StatusOK},
+		{`{"jsonrpc":"2.0","id":1,"method":"keystore.exportUser"}`, http.StatusUnauthorized},
+		{`{"jsonrpc":"2.0","id":1,"method":"infox.getNodeID"}`, http.StatusUnauthorized},
+		{`[{"method":"info.peers"},{"method":"admin.stacktrace"}]`, http.StatusUnauthorized},
+		{``, http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650/ext/info", strings.NewReader(test.body))
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+		rr := httptest.NewRecorder()
+		wrappedHandler.ServeHTTP(rr, req)
+		if rr.Code != test.code {
+			t.Fatalf("expected code %d for %s but got %d", test.code, test.body, rr.Code)
+		}
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	auth := Auth{
+		Enabled:  true,
+		Password: hashedPassword,
+	}
+
+	// Make a token that only allows scheduling tasks and sending on the X-Chain
+	tokenStr, err := auth.newScopedToken(testPassword, []string{"/ext/scheduler", "/ext/bc/X"}, []string{"scheduler.*", "avm.send"}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650/ext/scheduler", strings.NewReader(""))
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+
+	tests := []struct {
+		path   string
+		method string
+		err    error
+	}{
+		{"/ext/bc/X", "avm.send", nil},
+		{"/ext/bc/X", "avm.exportKey", errMethodNotAllowed},
+		{"/ext/bc/P", "avm.send", errEndpointNotAllowed},
+		{"/ext/admin", "admin.stacktrace", errEndpointNotAllowed},
+	}
+	for _, test := range tests {
+		if err := auth.Authorize(req, test.path, test.method); !errors.Is(err, test.err) {
+			t.Fatalf("expected %v calling %s on %s but got %v", test.err, test.method, test.path, err)
+		}
+	}
+
+	// A revoked token doesn't authorize anything
+	if err := auth.revokeToken(tokenStr, testPassword); err != nil {
+		t.Fatal(err)
+	}
+	if err := auth.Authorize(req, "/ext/bc/X", "avm.send"); err != errRevokedToken {
+		t.Fatalf("expected %s but got %v", errRevokedToken, err)
+	}
+
+	// Without a token nothing is authorized, unless auth is disabled
+	req = httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650/ext/scheduler", strings.NewReader(""))
+	if err := auth.Authorize(req, "/ext/bc/X", "avm.send"); err != ErrNoToken {
+		t.Fatalf("expected %s but got %v", ErrNoToken, err)
+	}
+	auth.Enabled = false
+	if err := auth.Authorize(req, "/ext/bc/X", "avm.send"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewScopedTokenExpiry(t *testing.T) {
+	auth := Auth{
+		Enabled:  true,
+		Password: hashedPassword,
+	}
+	now := time.Now()
+	auth.clock.Set(now)
+
+	if _, err := auth.newScopedToken(testPassword, []string{"*"}, nil, now.Add(-time.Minute)); err == nil {
+		t.Fatal("should have failed because the expiry is in the past")
+	}
+
+	expiresAt := now.Add(time.Minute)
+	tokenStr, err := auth.newScopedToken(testPassword, []string{"*"}, nil, expiresAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, auth.getTokenKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims := token.Claims.(*endpointClaims); claims.ExpiresAt != expiresAt.Unix() {
+		t.Fatalf("expected token to expire at %d but expires at %d", expiresAt.Unix(), claims.ExpiresAt)
+	}
+}
+
+func TestRestoreRevokedTokens(t *testing.T) {
+	db := memdb.New()
+	auth := Auth{
+		Enabled:  true,
+		Password: hashedPassword,
+		DB:       db,
+	}
+
+	tokenStr, err := auth.newToken(testPassword, []string{"*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := auth.revokeToken(tokenStr, testPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a restart
+	restarted := Auth{
+		Enabled:  true,
+		Password: hashedPassword,
+		DB:       db,
+	}
+	if err := restarted.RestoreRevokedTokens(); err != nil {
+		t.Fatal(err)
+	}
+	if len(restarted.revoked) != 1 || restarted.revoked[0] != tokenStr {
+		t.Fatal("revoked token should have been restored")
+	}
+
+	// Once the token expires, it's forgotten
+	expired := Auth{
+		Enabled:  true,
+		Password: hashedPassword,
+		DB:       db,
+	}
+	expired.clock.Set(time.Now().Add(2 * TokenLifespan))
+	if err := expired.RestoreRevokedTokens(); err != nil {
+		t.Fatal(err)
+	}
+	if len(expired.revoked) != 0 {
+		t.Fatal("expired revoked token shouldn't have been restored")
+	}
+	if has, err := db.Has([]byte(tokenStr)); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatal("expired revoked token should have been deleted")
+	}
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/rpc"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+// Client for the Avalanche Auth API Endpoint
+type Client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a Client for interacting with the Auth API Endpoint
+func NewClient(uri string, requestTimeout time.Duration, options ...rpc.Option) *Client {
+	return &Client{
+		requester: rpc.NewEndpointRequester(uri, "/ext/"+Endpoint, Endpoint, requestTimeout, options...),
+	}
+}
+
+// NewToken returns a token that allows access to [endpoints]. If [methods] is
+// non-empty, the token only allows calling those methods. If [expiresAt] is
+// the zero value, the token expires after [TokenLifespan].
+func (c *Client) NewToken(password string, endpoints, methods []string, expiresAt time.Time) (string, error) {
+	args := &NewTokenArgs{
+		Password:  Password{Password: password},
+		Endpoints: endpoints,
+		Methods:   methods,
+	}
+	if !expiresAt.IsZero() {
+		args.ExpiresAt = cjson.Uint64(expiresAt.Unix())
+	}
+	res := &Token{}
+	err := c.requester.SendRequest("newToken", args, res)
+	return res.Token, err
+}
+
+// RevokeToken revokes [token]
+func (c *Client) RevokeToken(password, token string) (bool, error) {
+	res := &Success{}
+	err := c.requester.SendRequest("revokeToken", &RevokeTokenArgs{
+		Password: Password{Password: password},
+		Token:    Token{Token: token},
+	}, res)
+	return res.Success, err
+}
+
+// ChangePassword changes the password required to create and revoke tokens
+func (c *Client) ChangePassword(oldPassword, newPassword string) (bool, error) {
+	res := &Success{}
+	err := c.requester.SendRequest("changePassword", &ChangePasswordArgs{
+		OldPassword: oldPassword,
+		NewPassword: newPassword,
+	}, res)
+	return res.Success, err
+}
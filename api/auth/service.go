@@ -4,8 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-
-	"github.com/gorilla/rpc/v2"
+	"time"
 
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -15,6 +14,7 @@ import (
 
 const (
 	maxEndpoints = 128
+	maxMethods   = 128
 )
 
 var (
@@ -30,7 +30,7 @@ type Service struct {
 
 // NewService returns a new auth API service
 func NewService(log logging.Logger, auth *Auth) *common.HTTPHandler {
-	newServer := rpc.NewServer()
+	newServer := cjson.NewServer()
 	codec := cjson.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -58,11 +58,19 @@ type NewTokenArgs struct {
 	// allows access to all API endpoints
 	// [Endpoints] must have between 1 and [maxEndpoints] elements
 	Endpoints []string `json:"endpoints"`
+	// If non-empty, the JSON-RPC methods that may be called with this token
+	// e.g. if methods is ["info.*", "health.getLiveness"] then the token
+	// holder can call any method of the info API and health.getLiveness
+	// [Methods] must have at most [maxMethods] elements
+	Methods []string `json:"methods"`
+	// Unix time at which the token expires. If 0, the token expires after
+	// [TokenLifespan].
+	ExpiresAt cjson.Uint64 `json:"expiresAt"`
 }
 
 // Token ...
 type Token struct {
-	Token string `json:"token"` // The new token. Expires in [TokenLifespan].
+	Token string `json:"token"` // The new token
 }
 
 // NewToken returns a new token
@@ -75,7 +83,15 @@ func (s *Service) NewToken(_ *http.Request, args *NewTokenArgs, reply *Token) er
 		return fmt.Errorf("argument 'endpoints' must have between %d and %d elements, but has %d",
 			1, maxEndpoints, l)
 	}
-	token, err := s.newToken(args.Password.Password, args.Endpoints)
+	if l := len(args.Methods); l > maxMethods {
+		return fmt.Errorf("argument 'methods' must have at most %d elements, but has %d",
+			maxMethods, l)
+	}
+	expiresAt := time.Time{}
+	if args.ExpiresAt != 0 {
+		expiresAt = time.Unix(int64(args.ExpiresAt), 0)
+	}
+	token, err := s.newScopedToken(args.Password.Password, args.Endpoints, args.Methods, expiresAt)
 	reply.Token = token
 	return err
 }
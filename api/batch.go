@@ -0,0 +1,142 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/rpc/v2/json2"
+)
+
+// maxBatchSize is the maximum number of calls in a JSON-RPC batch
+const maxBatchSize = 1024
+
+// batchError is a JSON-RPC response that reports an error
+type batchError struct {
+	Version string          `json:"jsonrpc"`
+	Error   *json2.Error    `json:"error"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// batchMiddleware wraps a handler. Requests that are a JSON-RPC batch of calls
+// are split into one request per call, each of which is served by [handler],
+// and the responses are returned together. Other requests are served by
+// [handler] as is.
+func batchMiddleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Body == nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body = bytes.TrimSpace(body)
+		if len(body) == 0 || body[0] != '[' {
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		calls := []json.RawMessage(nil)
+		if err := json.Unmarshal(body, &calls); err != nil {
+			writeBatchError(w, json2.E_PARSE, fmt.Sprintf("couldn't parse batch: %s", err))
+			return
+		}
+		switch {
+		case len(calls) == 0:
+			writeBatchError(w, json2.E_INVALID_REQ, "empty batch")
+			return
+		case len(calls) > maxBatchSize:
+			writeBatchError(w, json2.E_INVALID_REQ, fmt.Sprintf("batch has %d calls but the maximum is %d", len(calls), maxBatchSize))
+			return
+		}
+
+		responses := make([]json.RawMessage, len(calls))
+		for i, call := range calls {
+			responses[i] = serveBatchCall(handler, r, call)
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(w).Encode(responses)
+	})
+}
+
+// serveBatchCall serves [call], which is part of the batch [r], with
+// [handler] and returns the response
+func serveBatchCall(handler http.Handler, r *http.Request, call json.RawMessage) json.RawMessage {
+	callRequest := r.Clone(r.Context())
+	callRequest.Body = ioutil.NopCloser(bytes.NewReader(call))
+	callRequest.ContentLength = int64(len(call))
+
+	recorder := &responseBuffer{header: make(http.Header)}
+	handler.ServeHTTP(recorder, callRequest)
+
+	response := bytes.TrimSpace(recorder.body.Bytes())
+	if recorder.status < http.StatusBadRequest && json.Valid(response) {
+		return response
+	}
+
+	// The handler didn't respond with a JSON-RPC response, so its response is
+	// reported as an error of the call
+	id := struct {
+		ID json.RawMessage `json:"id"`
+	}{}
+	_ = json.Unmarshal(call, &id)
+	if len(id.ID) == 0 {
+		id.ID = json.RawMessage("null")
+	}
+	message := string(response)
+	if message == "" {
+		message = http.StatusText(recorder.status)
+	}
+	errResponse, _ := json.Marshal(batchError{
+		Version: "2.0",
+		Error: &json2.Error{
+			Code:    json2.E_SERVER,
+			Message: message,
+		},
+		ID: id.ID,
+	})
+	return errResponse
+}
+
+func writeBatchError(w http.ResponseWriter, code json2.ErrorCode, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(batchError{
+		Version: "2.0",
+		Error: &json2.Error{
+			Code:    code,
+			Message: message,
+		},
+		ID: json.RawMessage("null"),
+	})
+}
+
+// responseBuffer is an http.ResponseWriter that buffers the response
+type responseBuffer struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *responseBuffer) Header() http.Header { return b.header }
+
+func (b *responseBuffer) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+	return b.body.Write(p)
+}
@@ -0,0 +1,108 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/rpc/v2"
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	arpc "github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var errOdd = errors.New("odd")
+
+type EchoService struct{}
+
+type EchoArgs struct {
+	Value int `json:"value"`
+}
+
+type EchoReply struct {
+	Value int `json:"value"`
+}
+
+// Echo returns the value it's called with, or an error if it's odd
+func (*EchoService) Echo(_ *http.Request, args *EchoArgs, reply *EchoReply) error {
+	if args.Value%2 == 1 {
+		return errOdd
+	}
+	reply.Value = args.Value
+	return nil
+}
+
+func TestBatch(t *testing.T) {
+	s := Server{}
+	if err := s.Initialize(logging.NoLog{}, logging.NoFactory{}, "localhost", 8080, false, "", memdb.New(), 0); err != nil {
+		t.Fatal(err)
+	}
+	rpcServer := rpc.NewServer()
+	rpcServer.RegisterCodec(json2.NewCodec(), "application/json")
+	if err := rpcServer.RegisterService(&EchoService{}, "echo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddRoute(&common.HTTPHandler{Handler: rpcServer}, new(sync.RWMutex), "echo", "", logging.NoLog{}); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(s.router)
+	defer httpServer.Close()
+
+	requester := arpc.NewEndpointRequester(httpServer.URL, "/ext/echo", "echo", time.Second)
+
+	replies := make([]EchoReply, 3)
+	calls := []*arpc.BatchCall{
+		arpc.NewBatchCall("Echo", &EchoArgs{Value: 0}, &replies[0]),
+		arpc.NewBatchCall("Echo", &EchoArgs{Value: 1}, &replies[1]),
+		arpc.NewBatchCall("Echo", &EchoArgs{Value: 2}, &replies[2]),
+	}
+	if err := requester.SendBatch(calls); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case calls[0].Err != nil:
+		t.Fatal(calls[0].Err)
+	case calls[1].Err == nil:
+		t.Fatal("call with an odd value should have failed")
+	case calls[2].Err != nil:
+		t.Fatal(calls[2].Err)
+	case replies[2].Value != 2:
+		t.Fatalf("expected 2 but got %d", replies[2].Value)
+	}
+
+	// Single calls are still served
+	reply := EchoReply{}
+	if err := requester.SendRequest("Echo", &EchoArgs{Value: 4}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Value != 4 {
+		t.Fatalf("expected 4 but got %d", reply.Value)
+	}
+}
+
+func TestBatchUnknownMethod(t *testing.T) {
+	handler := batchMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	httpServer := httptest.NewServer(handler)
+	defer httpServer.Close()
+
+	requester := arpc.NewEndpointRequester(httpServer.URL, "", "echo", time.Second)
+	calls := []*arpc.BatchCall{arpc.NewBatchCall("echo", &EchoArgs{}, &EchoReply{})}
+	if err := requester.SendBatch(calls); err != nil {
+		t.Fatal(err)
+	}
+	if calls[0].Err == nil {
+		t.Fatal("call should have failed")
+	}
+}
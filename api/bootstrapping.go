@@ -0,0 +1,85 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+// Bootstrapping phases reported in NotBootstrappedResponse
+const (
+	FetchingPhase  = "fetching"
+	ExecutingPhase = "executing"
+	DonePhase      = "done"
+)
+
+// BootstrapProgress describes how far along bootstrapping a chain is
+type BootstrapProgress struct {
+	// Phase is FetchingPhase until every container has been fetched, then
+	// ExecutingPhase, and DonePhase once the chain is done bootstrapping
+	Phase    string       `json:"phase"`
+	Fetched  cjson.Uint64 `json:"fetched"`
+	Executed cjson.Uint64 `json:"executed"`
+	// ETA is an estimate of how much longer bootstrapping will take, such as
+	// "1m30s". It's omitted if unknown, which it is until containers are
+	// executed.
+	ETA string `json:"eta,omitempty"`
+	// Rate is the number of containers fetched per second recently, or the
+	// number executed per second once executing
+	Rate cjson.Float32 `json:"rate"`
+	// SinceProgress is how long ago a container was last fetched or executed,
+	// such as "5s". It's omitted if none have been. A chain whose SinceProgress
+	// keeps growing is stuck, rather than slow.
+	SinceProgress string `json:"sinceProgress,omitempty"`
+}
+
+// NewBootstrapProgress returns the description of [progress]
+func NewBootstrapProgress(progress snow.BootstrapProgress) BootstrapProgress {
+	reply := BootstrapProgress{
+		Phase:    FetchingPhase,
+		Fetched:  cjson.Uint64(progress.Fetched),
+		Executed: cjson.Uint64(progress.Executed),
+		Rate:     cjson.Float32(progress.Rate),
+	}
+	if progress.Executing {
+		reply.Phase = ExecutingPhase
+	}
+	if progress.ETA > 0 {
+		reply.ETA = progress.ETA.Round(time.Second).String()
+	}
+	if !progress.LastProgress.IsZero() {
+		reply.SinceProgress = time.Since(progress.LastProgress).Round(time.Second).String()
+	}
+	return reply
+}
+
+// NotBootstrappedResponse is the body of the 503 response to calls to a chain
+// that isn't done bootstrapping
+type NotBootstrappedResponse struct {
+	Error   string `json:"error"`
+	ChainID ids.ID `json:"chainID"`
+	BootstrapProgress
+}
+
+// writeNotBootstrapped responds to a call to the chain of [ctx], which isn't
+// done bootstrapping, with its bootstrap progress
+func writeNotBootstrapped(w http.ResponseWriter, ctx *snow.Context) {
+	response := NotBootstrappedResponse{
+		Error:             "API call rejected because chain is not done bootstrapping",
+		ChainID:           ctx.ChainID,
+		BootstrapProgress: NewBootstrapProgress(ctx.BootstrapProgress()),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	// Doesn't matter if there's an error while writing. They'll get the StatusServiceUnavailable code.
+	_ = json.NewEncoder(w).Encode(response)
+}
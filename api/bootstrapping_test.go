@@ -0,0 +1,72 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow"
+)
+
+func TestRejectMiddlewareReportsProgress(t *testing.T) {
+	ctx := snow.DefaultContextTest()
+	ctx.BootstrapFetched(10)
+	ctx.BootstrapExecuting()
+	ctx.BootstrapExecuted()
+
+	called := false
+	handler := rejectMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true }), ctx)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/ext/bc/X", nil))
+	if called {
+		t.Fatal("calls shouldn't be handled before the chain is done bootstrapping")
+	}
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d but got %d", http.StatusServiceUnavailable, w.Code)
+	}
+	response := NotBootstrappedResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Phase != ExecutingPhase || response.Fetched != 10 || response.Executed != 1 {
+		t.Fatalf("unexpected progress %+v", response)
+	}
+
+	ctx.Bootstrapped()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/ext/bc/X", nil))
+	if !called {
+		t.Fatal("calls should be handled once the chain is done bootstrapping")
+	}
+}
+
+func TestNewBootstrapProgress(t *testing.T) {
+	progress := NewBootstrapProgress(snow.BootstrapProgress{})
+	if progress.Phase != FetchingPhase || progress.SinceProgress != "" || progress.ETA != "" {
+		t.Fatalf("unexpected progress before any containers were fetched %+v", progress)
+	}
+
+	progress = NewBootstrapProgress(snow.BootstrapProgress{
+		Fetched:      10,
+		Executed:     4,
+		Executing:    true,
+		ETA:          90 * time.Second,
+		Rate:         2,
+		LastProgress: time.Now().Add(-time.Minute),
+	})
+	switch {
+	case progress.Phase != ExecutingPhase:
+		t.Fatalf("expected phase %s but got %s", ExecutingPhase, progress.Phase)
+	case progress.ETA != "1m30s":
+		t.Fatalf("expected ETA 1m30s but got %s", progress.ETA)
+	case progress.Rate != 2:
+		t.Fatalf("expected rate 2 but got %v", progress.Rate)
+	case progress.SinceProgress != "1m0s":
+		t.Fatalf("expected progress 1m0s ago but got %s", progress.SinceProgress)
+	}
+}
@@ -0,0 +1,48 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"compress/gzip"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/handlers"
+)
+
+// DefaultCompressionLevel is the level responses are compressed at by default
+const DefaultCompressionLevel = 6
+
+var errInvalidCompressionLevel = errors.New("compression level must be in [0, 9]")
+
+// SetCompressionLevel sets the level, in [0, 9], responses are compressed at
+// for clients that accept gzip or deflate encoded responses. If [level] is 0,
+// responses aren't compressed. Must be called before the server is
+// dispatched.
+func (s *Server) SetCompressionLevel(level int) error {
+	if level < gzip.NoCompression || level > gzip.BestCompression {
+		return errInvalidCompressionLevel
+	}
+	s.compressionLevel = level
+	return nil
+}
+
+// compressMiddleware wraps a handler. If the server compresses responses, the
+// responses to clients that accept gzip or deflate encoded responses are
+// compressed. Responses to requests to upgrade the connection, such as
+// WebSocket handshakes, aren't compressed, as the connection is taken over by
+// the handler.
+func (s *Server) compressMiddleware(handler http.Handler) http.Handler {
+	if s.compressionLevel == gzip.NoCompression {
+		return handler
+	}
+	compressed := handlers.CompressHandlerLevel(handler, s.compressionLevel)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		compressed.ServeHTTP(w, r)
+	})
+}
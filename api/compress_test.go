@@ -0,0 +1,81 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompressMiddleware(t *testing.T) {
+	s := Server{}
+	if err := s.SetCompressionLevel(DefaultCompressionLevel); err != nil {
+		t.Fatal(err)
+	}
+	body := strings.Repeat("utxo", 1024)
+	handler := s.compressMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/ext/bc/X", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if encoding := w.Header().Get("Content-Encoding"); encoding != "gzip" {
+		t.Fatalf("expected gzip encoding but got %q", encoding)
+	}
+	if w.Body.Len() >= len(body) {
+		t.Fatalf("response wasn't compressed")
+	}
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decompressed, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decompressed) != body {
+		t.Fatal("decompressed response doesn't match")
+	}
+
+	// Responses to clients that don't accept compression and to upgrade
+	// requests aren't compressed
+	r = httptest.NewRequest(http.MethodPost, "/ext/bc/X", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if encoding := w.Header().Get("Content-Encoding"); encoding != "" || w.Body.String() != body {
+		t.Fatalf("response shouldn't have been compressed but was encoded with %q", encoding)
+	}
+	r = httptest.NewRequest(http.MethodGet, "/ext/events", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	r.Header.Set("Upgrade", "websocket")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if encoding := w.Header().Get("Content-Encoding"); encoding != "" {
+		t.Fatalf("upgrade response shouldn't have been compressed but was encoded with %q", encoding)
+	}
+}
+
+func TestSetCompressionLevel(t *testing.T) {
+	s := Server{}
+	if err := s.SetCompressionLevel(10); err == nil {
+		t.Fatal("should have failed due to an invalid level")
+	}
+	if err := s.SetCompressionLevel(0); err != nil {
+		t.Fatal(err)
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	r := httptest.NewRequest(http.MethodPost, "/ext/info", nil)
+	r.Header.Set("Accept-Encoding", "deflate")
+	w := httptest.NewRecorder()
+	s.compressMiddleware(handler).ServeHTTP(w, r)
+	if encoding := w.Header().Get("Content-Encoding"); encoding != "" {
+		t.Fatalf("response shouldn't have been compressed but was encoded with %q", encoding)
+	}
+}
@@ -0,0 +1,47 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RequestContext returns the context of [r]. The context is done when the
+// client disconnects or the request times out. If [r] is nil, as it is when an
+// API method is called directly, a context that is never done is returned.
+func RequestContext(r *http.Request) context.Context {
+	if r == nil {
+		return context.Background()
+	}
+	return r.Context()
+}
+
+// timeoutMiddleware wraps a handler. The context of each request passed to the
+// handler is done after [timeout]. If [timeout] is 0, requests don't time out.
+func timeoutMiddleware(handler http.Handler, timeout time.Duration) http.Handler {
+	if timeout <= 0 {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// writeContextErr writes back an error if the context of [r] is done. Returns
+// true if an error was written.
+func writeContextErr(w http.ResponseWriter, r *http.Request) bool {
+	err := r.Context().Err()
+	if err == nil {
+		return false
+	}
+	w.WriteHeader(http.StatusServiceUnavailable)
+	// Doesn't matter if there's an error while writing. They'll get the StatusServiceUnavailable code.
+	_, _ = w.Write([]byte(fmt.Sprintf("API call abandoned: %s", err)))
+	return true
+}
@@ -0,0 +1,49 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	hasDeadline := false
+	handler := timeoutMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, hasDeadline = RequestContext(r).Deadline()
+	}), time.Second)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+	if !hasDeadline {
+		t.Fatal("request context should have a deadline")
+	}
+}
+
+func TestLockMiddlewareAbandonsCanceledRequest(t *testing.T) {
+	called := false
+	handler, err := lockMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}), common.ReadLock, &sync.RWMutex{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	request := httptest.NewRequest(http.MethodPost, "/", nil).WithContext(ctx)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if called {
+		t.Fatal("handler shouldn't be called after the request was canceled")
+	}
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d but got %d", http.StatusServiceUnavailable, recorder.Code)
+	}
+}
@@ -0,0 +1,79 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/rs/cors"
+)
+
+// CORSConfig determines which cross-origin requests browsers may send to the
+// server
+type CORSConfig struct {
+	// AllowedOrigins that may send requests. "*" allows every origin, and an
+	// origin may contain one "*" wildcard, e.g. https://*.example.com. If
+	// empty, no origin may send requests.
+	AllowedOrigins []string
+	// AllowedMethods are the HTTP methods cross-origin requests may use. If
+	// empty, GET, POST and HEAD may be used.
+	AllowedMethods []string
+	// AllowedHeaders are the headers cross-origin requests may set. "*"
+	// allows every header. Origin is always allowed.
+	AllowedHeaders []string
+}
+
+// DefaultCORSConfig allows simple requests from every origin
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodHead},
+		AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With"},
+	}
+}
+
+// OriginAllowed returns true if requests from [origin] are allowed. Origins
+// are compared case insensitively, as they are by the CORS middleware.
+func (c CORSConfig) OriginAllowed(origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, allowed := range c.AllowedOrigins {
+		allowed = strings.ToLower(allowed)
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+		i := strings.IndexByte(allowed, '*')
+		if i < 0 {
+			continue
+		}
+		prefix, suffix := allowed[:i], allowed[i+1:]
+		if len(origin) >= len(prefix)+len(suffix) &&
+			strings.HasPrefix(origin, prefix) &&
+			strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetCORS sets which cross-origin requests browsers may send to the server.
+// Must be called before the server is dispatched.
+func (s *Server) SetCORS(config CORSConfig) {
+	s.corsConfig = config
+}
+
+// corsMiddleware wraps a handler. It answers preflight requests and adds CORS
+// headers to the responses of cross-origin requests.
+func (s *Server) corsMiddleware(handler http.Handler) http.Handler {
+	options := cors.Options{
+		AllowedOrigins: s.corsConfig.AllowedOrigins,
+		AllowedMethods: s.corsConfig.AllowedMethods,
+		AllowedHeaders: s.corsConfig.AllowedHeaders,
+	}
+	if len(options.AllowedOrigins) == 0 {
+		// Otherwise, every origin would be allowed
+		options.AllowOriginFunc = func(string) bool { return false }
+	}
+	return cors.New(options).Handler(handler)
+}
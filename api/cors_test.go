@@ -0,0 +1,90 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORS(t *testing.T) {
+	s := Server{}
+	s.SetCORS(CORSConfig{
+		AllowedOrigins: []string{"https://wallet.example.com"},
+		AllowedMethods: []string{http.MethodPost},
+		AllowedHeaders: []string{"Content-Type", "Authorization"},
+	})
+	handler := s.corsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	preflight := func(origin, method, headers string) http.Header {
+		r := httptest.NewRequest(http.MethodOptions, "/ext/info", nil)
+		r.Header.Set("Origin", origin)
+		r.Header.Set("Access-Control-Request-Method", method)
+		r.Header.Set("Access-Control-Request-Headers", headers)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Header()
+	}
+
+	headers := preflight("https://wallet.example.com", http.MethodPost, "Authorization")
+	if origin := headers.Get("Access-Control-Allow-Origin"); origin != "https://wallet.example.com" {
+		t.Fatalf("expected origin to be allowed but got %q", origin)
+	}
+	if allowed := headers.Get("Access-Control-Allow-Headers"); allowed != "Authorization" {
+		t.Fatalf("expected Authorization header to be allowed but got %q", allowed)
+	}
+	if origin := preflight("https://evil.example.com", http.MethodPost, "").Get("Access-Control-Allow-Origin"); origin != "" {
+		t.Fatalf("origin shouldn't have been allowed but got %q", origin)
+	}
+	if origin := preflight("https://wallet.example.com", http.MethodPut, "").Get("Access-Control-Allow-Origin"); origin != "" {
+		t.Fatalf("method shouldn't have been allowed but got %q", origin)
+	}
+	if origin := preflight("https://wallet.example.com", http.MethodPost, "X-Other").Get("Access-Control-Allow-Origin"); origin != "" {
+		t.Fatalf("header shouldn't have been allowed but got %q", origin)
+	}
+}
+
+func TestCORSNoOrigins(t *testing.T) {
+	s := Server{}
+	s.SetCORS(CORSConfig{})
+	handler := s.corsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	r := httptest.NewRequest(http.MethodPost, "/ext/info", nil)
+	r.Header.Set("Origin", "https://wallet.example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if origin := w.Header().Get("Access-Control-Allow-Origin"); origin != "" {
+		t.Fatalf("origin shouldn't have been allowed but got %q", origin)
+	}
+}
+
+func TestCORSOriginAllowed(t *testing.T) {
+	config := CORSConfig{
+		AllowedOrigins: []string{"https://wallet.example.com", "https://*.example.org"},
+	}
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"https://wallet.example.com", true},
+		{"HTTPS://Wallet.Example.com", true},
+		{"https://other.example.com", false},
+		{"https://app.example.org", true},
+		{"https://example.org", false},
+		{"http://app.example.org", false},
+	}
+	for _, test := range tests {
+		if allowed := config.OriginAllowed(test.origin); allowed != test.allowed {
+			t.Fatalf("expected origin %q allowed to be %v", test.origin, test.allowed)
+		}
+	}
+
+	if (CORSConfig{}).OriginAllowed("https://wallet.example.com") {
+		t.Fatal("no origin should be allowed")
+	}
+	if !DefaultCORSConfig().OriginAllowed("https://wallet.example.com") {
+		t.Fatal("every origin should be allowed")
+	}
+}
@@ -0,0 +1,156 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// Time allowed to write a message to the peer.
+	writeWait = 10 * time.Second
+
+	// Time allowed to read the next pong message from the peer.
+	pongWait = 60 * time.Second
+
+	// Send pings to peer with this period. Must be less than pongWait.
+	pingPeriod = (pongWait * 9) / 10
+
+	// Maximum message size allowed from peer.
+	maxMessageSize = 64 * 1024 // bytes
+
+	// Maximum number of pending messages to send to a peer.
+	maxPendingMessages = 1024 // messages
+)
+
+// connection is a WebSocket connection to a client
+type connection struct {
+	s *Service
+
+	// The websocket connection.
+	conn *websocket.Conn
+
+	// Chains the connection is subscribed to. Guarded by the service's lock.
+	chains map[[32]byte]struct{}
+
+	sendLock sync.RWMutex
+	closed   bool
+	// Buffered channel of outbound messages.
+	send chan interface{}
+}
+
+func newConnection(s *Service, conn *websocket.Conn) *connection {
+	return &connection{
+		s:      s,
+		conn:   conn,
+		chains: make(map[[32]byte]struct{}),
+		send:   make(chan interface{}, maxPendingMessages),
+	}
+}
+
+// trySend queues [msg] to be sent. Returns false if too many messages are
+// pending.
+func (c *connection) trySend(msg interface{}) bool {
+	c.sendLock.RLock()
+	defer c.sendLock.RUnlock()
+
+	if c.closed {
+		return true
+	}
+	select {
+	case c.send <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// close stops the write pump once the pending messages are sent
+func (c *connection) close() {
+	c.sendLock.Lock()
+	defer c.sendLock.Unlock()
+
+	if !c.closed {
+		c.closed = true
+		close(c.send)
+	}
+}
+
+// readPump reads subscriptions from the websocket connection.
+//
+// The application runs readPump in a per-connection goroutine. The application
+// ensures that there is at most one reader on a connection by executing all
+// reads from this goroutine.
+func (c *connection) readPump() {
+	defer func() {
+		c.s.removeConnection(c)
+		c.close()
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	// SetReadDeadline returns an error if the connection is corrupted
+	if err := c.conn.SetReadDeadline(time.Now().Add(pongWait)); err != nil {
+		return
+	}
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		sub := &Subscription{}
+		if err := c.conn.ReadJSON(sub); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				c.s.log.Debug("unexpected close of events connection: %s", err)
+			}
+			return
+		}
+		if err := c.s.subscribe(c, sub); err != nil {
+			c.trySend(&Error{Subscription: sub, Error: err.Error()})
+		}
+	}
+}
+
+// writePump writes events to the websocket connection.
+//
+// A goroutine running writePump is started for each connection. The
+// application ensures that there is at most one writer to a connection by
+// executing all writes from this goroutine.
+func (c *connection) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		// close is called by both the writePump and the readPump so one of them
+		// will always error
+		_ = c.conn.Close()
+	}()
+	for {
+		select {
+		case message, ok := <-c.send:
+			if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				c.s.log.Debug("failed to set the write deadline, closing the connection due to %s", err)
+				return
+			}
+			if !ok {
+				// The read pump closed the channel. Attempt to close the
+				// connection gracefully.
+				_ = c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteJSON(message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				c.s.log.Debug("failed to set the write deadline, closing the connection due to %s", err)
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
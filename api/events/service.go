@@ -0,0 +1,319 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// Endpoint the service is served at, relative to /ext
+	Endpoint = "events"
+
+	// Accepted is the type of events sent when a container is accepted
+	Accepted = "accepted"
+	// Rejected is the type of events sent when a container is rejected
+	Rejected = "rejected"
+
+	// Size of the ws read buffer
+	readBufferSize = 1024
+
+	// Size of the ws write buffer
+	writeBufferSize = 1024
+)
+
+var (
+	errNoChain          = errors.New("chainID must be provided")
+	errUnknownEventType = errors.New("unknown event type")
+)
+
+// Subscription is sent by a client to subscribe to, or unsubscribe from, the
+// events of a chain. A subscription to a chain replaces the client's previous
+// subscription to it.
+type Subscription struct {
+	// ChainID, or alias, of the chain the subscription is to
+	ChainID string `json:"chainID"`
+	// Events to subscribe to: accepted, rejected or both. If empty, both are
+	// subscribed to.
+	Events []string `json:"events"`
+	// Addresses to filter containers by, e.g. X-avax1... If non-empty, only
+	// containers that contain one of the addresses, such as transactions that
+	// send outputs to them, are sent.
+	Addresses []string `json:"addresses"`
+	// AssetIDs to filter containers by. If non-empty, only containers that
+	// contain one of the asset IDs are sent.
+	AssetIDs []string `json:"assetIDs"`
+	// Unsubscribe is true if the client is unsubscribing from the chain
+	Unsubscribe bool `json:"unsubscribe"`
+}
+
+// Event is sent to clients when a container they are subscribed to is
+// accepted or rejected
+type Event struct {
+	ChainID     ids.ID          `json:"chainID"`
+	Type        string          `json:"type"`
+	ContainerID ids.ID          `json:"containerID"`
+	Container   formatting.CB58 `json:"container"`
+}
+
+// Error is sent to clients when a subscription they sent is invalid
+type Error struct {
+	Subscription *Subscription `json:"subscription"`
+	Error        string        `json:"error"`
+}
+
+// filter determines which of a chain's events a client is sent
+type filter struct {
+	accepted, rejected bool
+	// If non-empty, containers must contain one of the patterns
+	patterns [][]byte
+}
+
+// matches returns true if an event of [eventType] about [container] passes
+// the filter
+func (f *filter) matches(eventType string, container []byte) bool {
+	if (eventType == Accepted && !f.accepted) || (eventType == Rejected && !f.rejected) {
+		return false
+	}
+	if len(f.patterns) == 0 {
+		return true
+	}
+	for _, pattern := range f.patterns {
+		if bytes.Contains(container, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// Service sends the containers each chain accepts and rejects to the
+// WebSocket clients subscribed to the chain. It must be registered with the
+// decision dispatcher to receive events.
+type Service struct {
+	log          logging.Logger
+	chainManager chains.Manager
+	upgrader     websocket.Upgrader
+
+	lock sync.RWMutex
+	// Key: Chain ID
+	// Value: The connections subscribed to the chain
+	chains map[[32]byte]map[*connection]*filter
+}
+
+// NewService returns a service that resolves chain aliases with
+// [chainManager]. Browsers may only connect from the origins [corsConfig]
+// allows.
+func NewService(log logging.Logger, chainManager chains.Manager, corsConfig api.CORSConfig) *Service {
+	return &Service{
+		log:          log,
+		chainManager: chainManager,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  readBufferSize,
+			WriteBufferSize: writeBufferSize,
+			CheckOrigin:     checkOrigin(corsConfig),
+		},
+		chains: make(map[[32]byte]map[*connection]*filter),
+	}
+}
+
+// checkOrigin returns a function that allows connections from clients that
+// aren't browsers, from the node's own origin, and from the origins
+// [corsConfig] allows
+func checkOrigin(corsConfig api.CORSConfig) func(*http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+			return true
+		}
+		return corsConfig.OriginAllowed(origin)
+	}
+}
+
+// Handler returns the handler serving the service
+func (s *Service) Handler() *common.HTTPHandler {
+	return &common.HTTPHandler{LockOptions: common.NoLock, Handler: s}
+}
+
+// ServeHTTP upgrades the connection to a WebSocket over which subscriptions
+// are received and events are sent
+func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wsConn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Debug("failed to upgrade events connection: %s", err)
+		return
+	}
+	conn := newConnection(s, wsConn)
+	go conn.writePump()
+	go conn.readPump()
+}
+
+// Accept implements the triggers.Acceptor interface
+func (s *Service) Accept(ctx *snow.Context, containerID ids.ID, container []byte) error {
+	s.publish(ctx.ChainID, Accepted, containerID, container)
+	return nil
+}
+
+// Reject implements the triggers.Rejector interface
+func (s *Service) Reject(ctx *snow.Context, containerID ids.ID, container []byte) error {
+	s.publish(ctx.ChainID, Rejected, containerID, container)
+	return nil
+}
+
+// publish sends an event to the connections subscribed to it. Events aren't
+// sent to connections with too many pending messages.
+func (s *Service) publish(chainID ids.ID, eventType string, containerID ids.ID, container []byte) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	conns := s.chains[chainID.Key()]
+	if len(conns) == 0 {
+		return
+	}
+	event := &Event{
+		ChainID:     chainID,
+		Type:        eventType,
+		ContainerID: containerID,
+		Container:   formatting.CB58{Bytes: container},
+	}
+	for conn, f := range conns {
+		if f.matches(eventType, container) && !conn.trySend(event) {
+			s.log.Verbo("dropping event to subscribed connection due to too many pending messages")
+		}
+	}
+}
+
+// subscribe applies [sub], sent by [conn]
+func (s *Service) subscribe(conn *connection, sub *Subscription) error {
+	if sub.ChainID == "" {
+		return errNoChain
+	}
+	chainID, err := s.chainManager.Lookup(sub.ChainID)
+	if err != nil {
+		chainID, err = ids.FromString(sub.ChainID)
+		if err != nil {
+			return fmt.Errorf("couldn't find chain %q", sub.ChainID)
+		}
+	}
+	key := chainID.Key()
+
+	if sub.Unsubscribe {
+		s.unsubscribe(conn, key)
+		return nil
+	}
+
+	f, err := newFilter(sub)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	conns, ok := s.chains[key]
+	if !ok {
+		conns = make(map[*connection]*filter)
+		s.chains[key] = conns
+	}
+	conns[conn] = f
+	conn.chains[key] = struct{}{}
+	return nil
+}
+
+// unsubscribe [conn] from the chain with ID [key]
+func (s *Service) unsubscribe(conn *connection, key [32]byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(conn.chains, key)
+	conns := s.chains[key]
+	delete(conns, conn)
+	if len(conns) == 0 {
+		delete(s.chains, key)
+	}
+}
+
+// removeConnection unsubscribes [conn] from every chain
+func (s *Service) removeConnection(conn *connection) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for key := range conn.chains {
+		conns := s.chains[key]
+		delete(conns, conn)
+		if len(conns) == 0 {
+			delete(s.chains, key)
+		}
+	}
+	conn.chains = nil
+}
+
+// newFilter returns the filter [sub] describes
+func newFilter(sub *Subscription) (*filter, error) {
+	f := &filter{}
+	if len(sub.Events) == 0 {
+		f.accepted = true
+		f.rejected = true
+	}
+	for _, eventType := range sub.Events {
+		switch eventType {
+		case Accepted:
+			f.accepted = true
+		case Rejected:
+			f.rejected = true
+		default:
+			return nil, fmt.Errorf("%w %q", errUnknownEventType, eventType)
+		}
+	}
+	for _, addrStr := range sub.Addresses {
+		addr, err := parseAddress(addrStr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+		}
+		f.patterns = append(f.patterns, addr)
+	}
+	for _, assetIDStr := range sub.AssetIDs {
+		assetID, err := ids.FromString(assetIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse asset ID %q: %w", assetIDStr, err)
+		}
+		f.patterns = append(f.patterns, assetID.Bytes())
+	}
+	return f, nil
+}
+
+// parseAddress returns the bytes of [addrStr], which may be a bech32 address,
+// with or without a chain prefix, or a cb58 short ID
+func parseAddress(addrStr string) ([]byte, error) {
+	if strings.Contains(addrStr, "-") {
+		_, _, addr, err := formatting.ParseAddress(addrStr)
+		return addr, err
+	}
+	if _, addr, err := formatting.ParseBech32(addrStr); err == nil {
+		return addr, nil
+	}
+	addr, err := ids.ShortFromString(addrStr)
+	if err != nil {
+		return nil, err
+	}
+	return addr.Bytes(), nil
+}
@@ -0,0 +1,168 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+type message struct {
+	Event
+	Error string `json:"error"`
+}
+
+// subscribe sends [sub] over [conn] and waits for it to be applied
+func subscribe(t *testing.T, conn *websocket.Conn, sub *Subscription) {
+	if err := conn.WriteJSON(sub); err != nil {
+		t.Fatal(err)
+	}
+	// Subscriptions are applied in order, so once the invalid subscription is
+	// rejected, [sub] has been applied
+	if err := conn.WriteJSON(&Subscription{}); err != nil {
+		t.Fatal(err)
+	}
+	if msg := read(t, conn); msg.Error == "" {
+		t.Fatalf("expected an error but got %+v", msg)
+	}
+}
+
+func read(t *testing.T, conn *websocket.Conn) *message {
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	msg := &message{}
+	if err := conn.ReadJSON(msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func TestService(t *testing.T) {
+	service := NewService(logging.NoLog{}, chains.MockManager{}, api.DefaultCORSConfig())
+	server := httptest.NewServer(service)
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx := snow.DefaultContextTest()
+	ctx.ChainID = ids.Empty.Prefix(1)
+	otherCtx := snow.DefaultContextTest()
+	otherCtx.ChainID = ids.Empty.Prefix(2)
+
+	addr := ids.NewShortID([20]byte{1, 2, 3})
+	subscribe(t, conn, &Subscription{
+		ChainID:   ctx.ChainID.String(),
+		Events:    []string{Accepted},
+		Addresses: []string{addr.String()},
+	})
+
+	matching := append([]byte{0xff}, addr.Bytes()...)
+	if err := service.Reject(ctx, ids.Empty.Prefix(3), matching); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Accept(otherCtx, ids.Empty.Prefix(4), matching); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Accept(ctx, ids.Empty.Prefix(5), []byte{0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Accept(ctx, ids.Empty.Prefix(6), matching); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the last event passes the subscription's filters
+	msg := read(t, conn)
+	switch {
+	case msg.Type != Accepted:
+		t.Fatalf("expected an accepted event but got %q", msg.Type)
+	case !msg.ChainID.Equals(ctx.ChainID):
+		t.Fatalf("expected chain %s but got %s", ctx.ChainID, msg.ChainID)
+	case !msg.ContainerID.Equals(ids.Empty.Prefix(6)):
+		t.Fatalf("unexpected container %s", msg.ContainerID)
+	case !bytes.Equal(msg.Container.Bytes, matching):
+		t.Fatalf("expected container %x but got %x", matching, msg.Container.Bytes)
+	}
+
+	subscribe(t, conn, &Subscription{ChainID: ctx.ChainID.String(), Unsubscribe: true})
+	service.lock.RLock()
+	numChains := len(service.chains)
+	service.lock.RUnlock()
+	if numChains != 0 {
+		t.Fatalf("expected no subscriptions but got %d", numChains)
+	}
+}
+
+func TestNewFilter(t *testing.T) {
+	if _, err := newFilter(&Subscription{Events: []string{"issued"}}); err == nil {
+		t.Fatal("should have failed due to an unknown event type")
+	}
+	if _, err := newFilter(&Subscription{Addresses: []string{"X-not an address"}}); err == nil {
+		t.Fatal("should have failed due to an invalid address")
+	}
+	if _, err := newFilter(&Subscription{AssetIDs: []string{"not an ID"}}); err == nil {
+		t.Fatal("should have failed due to an invalid asset ID")
+	}
+
+	assetID := ids.Empty.Prefix(1)
+	f, err := newFilter(&Subscription{AssetIDs: []string{assetID.String()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.matches(Rejected, assetID.Bytes()) {
+		t.Fatal("filter should have matched a container with the asset ID")
+	}
+	if f.matches(Accepted, ids.Empty.Bytes()) {
+		t.Fatal("filter shouldn't have matched a container without the asset ID")
+	}
+}
+
+func TestServiceCheckOrigin(t *testing.T) {
+	service := NewService(logging.NoLog{}, chains.MockManager{}, api.CORSConfig{
+		AllowedOrigins: []string{"https://*.example.com"},
+	})
+	server := httptest.NewServer(service)
+	defer server.Close()
+
+	dial := func(origin string) error {
+		header := http.Header{}
+		if origin != "" {
+			header.Set("Origin", origin)
+		}
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), header)
+		if err == nil {
+			conn.Close()
+		}
+		return err
+	}
+
+	if err := dial(""); err != nil {
+		t.Fatalf("clients that aren't browsers should be allowed but got: %s", err)
+	}
+	if err := dial(server.URL); err != nil {
+		t.Fatalf("the node's own origin should be allowed but got: %s", err)
+	}
+	if err := dial("https://wallet.example.com"); err != nil {
+		t.Fatalf("an allowed origin should be allowed but got: %s", err)
+	}
+	if err := dial("https://evil.example.org"); err == nil {
+		t.Fatal("an origin that isn't allowed shouldn't be allowed")
+	}
+}
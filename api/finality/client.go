@@ -0,0 +1,31 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package finality
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+// Client for the finality API endpoint of a chain
+type Client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a Client for interacting with the finality API endpoint of
+// the chain [chain], which can be the chain's ID or an alias
+func NewClient(uri, chain string, requestTimeout time.Duration, options ...rpc.Option) *Client {
+	return &Client{
+		requester: rpc.NewEndpointRequester(uri, "/ext/bc/"+chain+Endpoint, "finality", requestTimeout, options...),
+	}
+}
+
+// GetLastAccepted returns the vertex or block the chain accepted most
+// recently, along with its height, its epoch and the time the node accepted it
+func (c *Client) GetLastAccepted() (*GetLastAcceptedReply, error) {
+	res := &GetLastAcceptedReply{}
+	err := c.requester.SendRequest("getLastAccepted", struct{}{}, res)
+	return res, err
+}
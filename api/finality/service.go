@@ -0,0 +1,77 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package finality
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// Endpoint is the extension, relative to a chain's base URL, that the
+// finality API is served on
+const Endpoint = "/finality"
+
+// Finality is the API service, served on every chain, that reports how far
+// along a chain has finalized, regardless of the chain's VM or consensus
+type Finality struct {
+	log          logging.Logger
+	chainManager chains.Manager
+	chainID      ids.ID
+}
+
+// NewService returns a new finality API service for the chain [chainID]
+func NewService(log logging.Logger, chainManager chains.Manager, chainID ids.ID) (*common.HTTPHandler, error) {
+	newServer := json.NewServer()
+	codec := json.NewCodec()
+	newServer.RegisterCodec(codec, "application/json")
+	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	service := &Finality{
+		log:          log,
+		chainManager: chainManager,
+		chainID:      chainID,
+	}
+	if err := newServer.RegisterService(service, "finality"); err != nil {
+		return nil, err
+	}
+	return &common.HTTPHandler{LockOptions: common.NoLock, Handler: newServer}, nil
+}
+
+// GetLastAcceptedReply is the vertex or block the chain accepted most recently
+type GetLastAcceptedReply struct {
+	// ID of the container
+	ContainerID ids.ID `json:"containerID"`
+	// Height of the container. Omitted if the chain's blocks don't report
+	// their height.
+	Height *json.Uint64 `json:"height,omitempty"`
+	// Epoch the container was issued in
+	Epoch json.Uint32 `json:"epoch"`
+	// Time this node accepted the container, or the time the chain started if
+	// the container was accepted before then
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// GetLastAccepted returns the vertex or block the chain accepted most
+// recently, along with its height, its epoch and the time this node accepted it
+func (service *Finality) GetLastAccepted(_ *http.Request, _ *struct{}, reply *GetLastAcceptedReply) error {
+	service.log.Debug("Finality: GetLastAccepted called on chain %s", service.chainID)
+
+	lastAccepted, err := service.chainManager.LastAccepted(service.chainID)
+	if err != nil {
+		return err
+	}
+	reply.ContainerID = lastAccepted.ID
+	if lastAccepted.HasHeight {
+		height := json.Uint64(lastAccepted.Height)
+		reply.Height = &height
+	}
+	reply.Epoch = json.Uint32(lastAccepted.Epoch)
+	reply.Timestamp = lastAccepted.Timestamp
+	return nil
+}
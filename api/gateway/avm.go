@@ -0,0 +1,105 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/gateway/avmproto"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/avm"
+)
+
+// defaultAVMChain is the chain AVM calls are sent to if none is specified
+const defaultAVMChain = "X"
+
+// avmServer serves the AVM API over gRPC
+type avmServer struct{ gateway *Gateway }
+
+// call calls [method] of the AVM chain [chain]
+func (s *avmServer) call(ctx context.Context, chain, method string, args, reply interface{}) error {
+	if chain == "" {
+		chain = defaultAVMChain
+	}
+	return s.gateway.call(ctx, "bc/"+chain, method, args, reply)
+}
+
+func (s *avmServer) IssueTx(ctx context.Context, req *avmproto.IssueTxRequest) (*avmproto.IssueTxResponse, error) {
+	reply := api.JSONTxID{}
+	args := api.FormattedTx{
+		Tx:       formatting.Hex{}.ConvertBytes(req.Tx),
+		Encoding: formatting.HexEncoding,
+	}
+	if err := s.call(ctx, req.Chain, "avm.issueTx", &args, &reply); err != nil {
+		return nil, err
+	}
+	return &avmproto.IssueTxResponse{TxID: reply.TxID.String()}, nil
+}
+
+func (s *avmServer) GetTxStatus(ctx context.Context, req *avmproto.GetTxStatusRequest) (*avmproto.GetTxStatusResponse, error) {
+	txID, err := ids.FromString(req.TxID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "couldn't parse txID: %s", err)
+	}
+	reply := avm.GetTxStatusReply{}
+	if err := s.call(ctx, req.Chain, "avm.getTxStatus", &api.JSONTxID{TxID: txID}, &reply); err != nil {
+		return nil, err
+	}
+	return &avmproto.GetTxStatusResponse{Status: reply.Status.String()}, nil
+}
+
+func (s *avmServer) GetTx(ctx context.Context, req *avmproto.GetTxRequest) (*avmproto.GetTxResponse, error) {
+	txID, err := ids.FromString(req.TxID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "couldn't parse txID: %s", err)
+	}
+	reply := api.FormattedTx{}
+	args := api.GetTxArgs{TxID: txID, Encoding: formatting.HexEncoding}
+	if err := s.call(ctx, req.Chain, "avm.getTx", &args, &reply); err != nil {
+		return nil, err
+	}
+	tx, err := formatting.Hex{}.ConvertString(reply.Tx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "couldn't decode tx: %s", err)
+	}
+	return &avmproto.GetTxResponse{Tx: tx}, nil
+}
+
+func (s *avmServer) GetBalance(ctx context.Context, req *avmproto.GetBalanceRequest) (*avmproto.GetBalanceResponse, error) {
+	reply := avm.GetBalanceReply{}
+	args := avm.GetBalanceArgs{Address: req.Address, AssetID: req.AssetID}
+	if err := s.call(ctx, req.Chain, "avm.getBalance", &args, &reply); err != nil {
+		return nil, err
+	}
+	utxoIDs := make([]*avmproto.UTXOID, len(reply.UTXOIDs))
+	for i, utxoID := range reply.UTXOIDs {
+		utxoIDs[i] = &avmproto.UTXOID{
+			TxID:        utxoID.TxID.String(),
+			OutputIndex: utxoID.OutputIndex,
+		}
+	}
+	return &avmproto.GetBalanceResponse{
+		Balance: uint64(reply.Balance),
+		UtxoIDs: utxoIDs,
+	}, nil
+}
+
+func (s *avmServer) GetAssetDescription(ctx context.Context, req *avmproto.GetAssetDescriptionRequest) (*avmproto.GetAssetDescriptionResponse, error) {
+	reply := avm.GetAssetDescriptionReply{}
+	args := avm.GetAssetDescriptionArgs{AssetID: req.AssetID}
+	if err := s.call(ctx, req.Chain, "avm.getAssetDescription", &args, &reply); err != nil {
+		return nil, err
+	}
+	return &avmproto.GetAssetDescriptionResponse{
+		AssetID:      reply.AssetID.String(),
+		Name:         reply.Name,
+		Symbol:       reply.Symbol,
+		Denomination: uint32(reply.Denomination),
+	}, nil
+}
@@ -0,0 +1,815 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: avm.proto
+
+package avmproto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type IssueTxRequest struct {
+	Chain                string   `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
+	Tx                   []byte   `protobuf:"bytes,2,opt,name=tx,proto3" json:"tx,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssueTxRequest) Reset()         { *m = IssueTxRequest{} }
+func (m *IssueTxRequest) String() string { return proto.CompactTextString(m) }
+func (*IssueTxRequest) ProtoMessage()    {}
+func (*IssueTxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fabcb3b289c170c, []int{0}
+}
+
+func (m *IssueTxRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssueTxRequest.Unmarshal(m, b)
+}
+func (m *IssueTxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssueTxRequest.Marshal(b, m, deterministic)
+}
+func (m *IssueTxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssueTxRequest.Merge(m, src)
+}
+func (m *IssueTxRequest) XXX_Size() int {
+	return xxx_messageInfo_IssueTxRequest.Size(m)
+}
+func (m *IssueTxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssueTxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssueTxRequest proto.InternalMessageInfo
+
+func (m *IssueTxRequest) GetChain() string {
+	if m != nil {
+		return m.Chain
+	}
+	return ""
+}
+
+func (m *IssueTxRequest) GetTx() []byte {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+type IssueTxResponse struct {
+	TxID                 string   `protobuf:"bytes,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssueTxResponse) Reset()         { *m = IssueTxResponse{} }
+func (m *IssueTxResponse) String() string { return proto.CompactTextString(m) }
+func (*IssueTxResponse) ProtoMessage()    {}
+func (*IssueTxResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fabcb3b289c170c, []int{1}
+}
+
+func (m *IssueTxResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssueTxResponse.Unmarshal(m, b)
+}
+func (m *IssueTxResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssueTxResponse.Marshal(b, m, deterministic)
+}
+func (m *IssueTxResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssueTxResponse.Merge(m, src)
+}
+func (m *IssueTxResponse) XXX_Size() int {
+	return xxx_messageInfo_IssueTxResponse.Size(m)
+}
+func (m *IssueTxResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssueTxResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssueTxResponse proto.InternalMessageInfo
+
+func (m *IssueTxResponse) GetTxID() string {
+	if m != nil {
+		return m.TxID
+	}
+	return ""
+}
+
+type GetTxStatusRequest struct {
+	Chain                string   `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
+	TxID                 string   `protobuf:"bytes,2,opt,name=txID,proto3" json:"txID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxStatusRequest) Reset()         { *m = GetTxStatusRequest{} }
+func (m *GetTxStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTxStatusRequest) ProtoMessage()    {}
+func (*GetTxStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fabcb3b289c170c, []int{2}
+}
+
+func (m *GetTxStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxStatusRequest.Unmarshal(m, b)
+}
+func (m *GetTxStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTxStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxStatusRequest.Merge(m, src)
+}
+func (m *GetTxStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTxStatusRequest.Size(m)
+}
+func (m *GetTxStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxStatusRequest proto.InternalMessageInfo
+
+func (m *GetTxStatusRequest) GetChain() string {
+	if m != nil {
+		return m.Chain
+	}
+	return ""
+}
+
+func (m *GetTxStatusRequest) GetTxID() string {
+	if m != nil {
+		return m.TxID
+	}
+	return ""
+}
+
+type GetTxStatusResponse struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxStatusResponse) Reset()         { *m = GetTxStatusResponse{} }
+func (m *GetTxStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTxStatusResponse) ProtoMessage()    {}
+func (*GetTxStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fabcb3b289c170c, []int{3}
+}
+
+func (m *GetTxStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxStatusResponse.Unmarshal(m, b)
+}
+func (m *GetTxStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTxStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxStatusResponse.Merge(m, src)
+}
+func (m *GetTxStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTxStatusResponse.Size(m)
+}
+func (m *GetTxStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxStatusResponse proto.InternalMessageInfo
+
+func (m *GetTxStatusResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type GetTxRequest struct {
+	Chain                string   `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
+	TxID                 string   `protobuf:"bytes,2,opt,name=txID,proto3" json:"txID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxRequest) Reset()         { *m = GetTxRequest{} }
+func (m *GetTxRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTxRequest) ProtoMessage()    {}
+func (*GetTxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fabcb3b289c170c, []int{4}
+}
+
+func (m *GetTxRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxRequest.Unmarshal(m, b)
+}
+func (m *GetTxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxRequest.Merge(m, src)
+}
+func (m *GetTxRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTxRequest.Size(m)
+}
+func (m *GetTxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxRequest proto.InternalMessageInfo
+
+func (m *GetTxRequest) GetChain() string {
+	if m != nil {
+		return m.Chain
+	}
+	return ""
+}
+
+func (m *GetTxRequest) GetTxID() string {
+	if m != nil {
+		return m.TxID
+	}
+	return ""
+}
+
+type GetTxResponse struct {
+	Tx                   []byte   `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxResponse) Reset()         { *m = GetTxResponse{} }
+func (m *GetTxResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTxResponse) ProtoMessage()    {}
+func (*GetTxResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fabcb3b289c170c, []int{5}
+}
+
+func (m *GetTxResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxResponse.Unmarshal(m, b)
+}
+func (m *GetTxResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTxResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxResponse.Merge(m, src)
+}
+func (m *GetTxResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTxResponse.Size(m)
+}
+func (m *GetTxResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxResponse proto.InternalMessageInfo
+
+func (m *GetTxResponse) GetTx() []byte {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+type GetBalanceRequest struct {
+	Chain                string   `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	AssetID              string   `protobuf:"bytes,3,opt,name=assetID,proto3" json:"assetID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBalanceRequest) Reset()         { *m = GetBalanceRequest{} }
+func (m *GetBalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBalanceRequest) ProtoMessage()    {}
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fabcb3b289c170c, []int{6}
+}
+
+func (m *GetBalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBalanceRequest.Unmarshal(m, b)
+}
+func (m *GetBalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBalanceRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBalanceRequest.Merge(m, src)
+}
+func (m *GetBalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBalanceRequest.Size(m)
+}
+func (m *GetBalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBalanceRequest proto.InternalMessageInfo
+
+func (m *GetBalanceRequest) GetChain() string {
+	if m != nil {
+		return m.Chain
+	}
+	return ""
+}
+
+func (m *GetBalanceRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *GetBalanceRequest) GetAssetID() string {
+	if m != nil {
+		return m.AssetID
+	}
+	return ""
+}
+
+type UTXOID struct {
+	TxID                 string   `protobuf:"bytes,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	OutputIndex          uint32   `protobuf:"varint,2,opt,name=outputIndex,proto3" json:"outputIndex,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UTXOID) Reset()         { *m = UTXOID{} }
+func (m *UTXOID) String() string { return proto.CompactTextString(m) }
+func (*UTXOID) ProtoMessage()    {}
+func (*UTXOID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fabcb3b289c170c, []int{7}
+}
+
+func (m *UTXOID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UTXOID.Unmarshal(m, b)
+}
+func (m *UTXOID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UTXOID.Marshal(b, m, deterministic)
+}
+func (m *UTXOID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UTXOID.Merge(m, src)
+}
+func (m *UTXOID) XXX_Size() int {
+	return xxx_messageInfo_UTXOID.Size(m)
+}
+func (m *UTXOID) XXX_DiscardUnknown() {
+	xxx_messageInfo_UTXOID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UTXOID proto.InternalMessageInfo
+
+func (m *UTXOID) GetTxID() string {
+	if m != nil {
+		return m.TxID
+	}
+	return ""
+}
+
+func (m *UTXOID) GetOutputIndex() uint32 {
+	if m != nil {
+		return m.OutputIndex
+	}
+	return 0
+}
+
+type GetBalanceResponse struct {
+	Balance              uint64    `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	UtxoIDs              []*UTXOID `protobuf:"bytes,2,rep,name=utxoIDs,proto3" json:"utxoIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GetBalanceResponse) Reset()         { *m = GetBalanceResponse{} }
+func (m *GetBalanceResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBalanceResponse) ProtoMessage()    {}
+func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fabcb3b289c170c, []int{8}
+}
+
+func (m *GetBalanceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBalanceResponse.Unmarshal(m, b)
+}
+func (m *GetBalanceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBalanceResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBalanceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBalanceResponse.Merge(m, src)
+}
+func (m *GetBalanceResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBalanceResponse.Size(m)
+}
+func (m *GetBalanceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBalanceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBalanceResponse proto.InternalMessageInfo
+
+func (m *GetBalanceResponse) GetBalance() uint64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *GetBalanceResponse) GetUtxoIDs() []*UTXOID {
+	if m != nil {
+		return m.UtxoIDs
+	}
+	return nil
+}
+
+type GetAssetDescriptionRequest struct {
+	Chain                string   `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
+	AssetID              string   `protobuf:"bytes,2,opt,name=assetID,proto3" json:"assetID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAssetDescriptionRequest) Reset()         { *m = GetAssetDescriptionRequest{} }
+func (m *GetAssetDescriptionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAssetDescriptionRequest) ProtoMessage()    {}
+func (*GetAssetDescriptionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fabcb3b289c170c, []int{9}
+}
+
+func (m *GetAssetDescriptionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAssetDescriptionRequest.Unmarshal(m, b)
+}
+func (m *GetAssetDescriptionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAssetDescriptionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAssetDescriptionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAssetDescriptionRequest.Merge(m, src)
+}
+func (m *GetAssetDescriptionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAssetDescriptionRequest.Size(m)
+}
+func (m *GetAssetDescriptionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAssetDescriptionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAssetDescriptionRequest proto.InternalMessageInfo
+
+func (m *GetAssetDescriptionRequest) GetChain() string {
+	if m != nil {
+		return m.Chain
+	}
+	return ""
+}
+
+func (m *GetAssetDescriptionRequest) GetAssetID() string {
+	if m != nil {
+		return m.AssetID
+	}
+	return ""
+}
+
+type GetAssetDescriptionResponse struct {
+	AssetID              string   `protobuf:"bytes,1,opt,name=assetID,proto3" json:"assetID,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol               string   `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Denomination         uint32   `protobuf:"varint,4,opt,name=denomination,proto3" json:"denomination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAssetDescriptionResponse) Reset()         { *m = GetAssetDescriptionResponse{} }
+func (m *GetAssetDescriptionResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAssetDescriptionResponse) ProtoMessage()    {}
+func (*GetAssetDescriptionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fabcb3b289c170c, []int{10}
+}
+
+func (m *GetAssetDescriptionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAssetDescriptionResponse.Unmarshal(m, b)
+}
+func (m *GetAssetDescriptionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAssetDescriptionResponse.Marshal(b, m, deterministic)
+}
+func (m *GetAssetDescriptionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAssetDescriptionResponse.Merge(m, src)
+}
+func (m *GetAssetDescriptionResponse) XXX_Size() int {
+	return xxx_messageInfo_GetAssetDescriptionResponse.Size(m)
+}
+func (m *GetAssetDescriptionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAssetDescriptionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAssetDescriptionResponse proto.InternalMessageInfo
+
+func (m *GetAssetDescriptionResponse) GetAssetID() string {
+	if m != nil {
+		return m.AssetID
+	}
+	return ""
+}
+
+func (m *GetAssetDescriptionResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetAssetDescriptionResponse) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *GetAssetDescriptionResponse) GetDenomination() uint32 {
+	if m != nil {
+		return m.Denomination
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*IssueTxRequest)(nil), "avmproto.IssueTxRequest")
+	proto.RegisterType((*IssueTxResponse)(nil), "avmproto.IssueTxResponse")
+	proto.RegisterType((*GetTxStatusRequest)(nil), "avmproto.GetTxStatusRequest")
+	proto.RegisterType((*GetTxStatusResponse)(nil), "avmproto.GetTxStatusResponse")
+	proto.RegisterType((*GetTxRequest)(nil), "avmproto.GetTxRequest")
+	proto.RegisterType((*GetTxResponse)(nil), "avmproto.GetTxResponse")
+	proto.RegisterType((*GetBalanceRequest)(nil), "avmproto.GetBalanceRequest")
+	proto.RegisterType((*UTXOID)(nil), "avmproto.UTXOID")
+	proto.RegisterType((*GetBalanceResponse)(nil), "avmproto.GetBalanceResponse")
+	proto.RegisterType((*GetAssetDescriptionRequest)(nil), "avmproto.GetAssetDescriptionRequest")
+	proto.RegisterType((*GetAssetDescriptionResponse)(nil), "avmproto.GetAssetDescriptionResponse")
+}
+
+func init() { proto.RegisterFile("avm.proto", fileDescriptor_0fabcb3b289c170c) }
+
+var fileDescriptor_0fabcb3b289c170c = []byte{
+	// 462 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xdf, 0x6b, 0x13, 0x41,
+	0x10, 0xe6, 0x2e, 0x69, 0x62, 0x27, 0x69, 0xd5, 0xa9, 0xd4, 0xf3, 0x5a, 0x31, 0x2c, 0x16, 0x82,
+	0x60, 0x1e, 0x2a, 0x48, 0x9f, 0x02, 0x95, 0x83, 0x70, 0xa2, 0x08, 0x67, 0x14, 0x11, 0x7c, 0xd8,
+	0x24, 0x0b, 0x06, 0x7a, 0xbb, 0x31, 0xbb, 0x57, 0xce, 0xbf, 0xc0, 0x7f, 0xd9, 0x47, 0xb9, 0xbd,
+	0xd9, 0x26, 0x6b, 0xaf, 0x2d, 0x7d, 0xbb, 0x99, 0xf9, 0xbe, 0x6f, 0x7e, 0xec, 0x77, 0xb0, 0xcb,
+	0x2f, 0xf3, 0xd1, 0x6a, 0xad, 0x8c, 0xc2, 0x07, 0xfc, 0x32, 0xb7, 0x5f, 0xec, 0x2d, 0xec, 0xa7,
+	0x5a, 0x17, 0x62, 0x5a, 0x66, 0xe2, 0x57, 0x21, 0xb4, 0xc1, 0x27, 0xb0, 0x33, 0xff, 0xc9, 0x97,
+	0x32, 0x0a, 0x06, 0xc1, 0x70, 0x37, 0xab, 0x03, 0xdc, 0x87, 0xd0, 0x94, 0x51, 0x38, 0x08, 0x86,
+	0xfd, 0x2c, 0x34, 0x25, 0x3b, 0x81, 0x87, 0x57, 0x3c, 0xbd, 0x52, 0x52, 0x0b, 0x44, 0x68, 0x9b,
+	0x32, 0x4d, 0x88, 0x67, 0xbf, 0xd9, 0x18, 0x70, 0x22, 0xcc, 0xb4, 0xfc, 0x6c, 0xb8, 0x29, 0xf4,
+	0xed, 0x2d, 0x1c, 0x3f, 0xdc, 0xe2, 0xbf, 0x86, 0x03, 0x8f, 0x4f, 0xad, 0x0e, 0xa1, 0xa3, 0x6d,
+	0x86, 0x14, 0x28, 0x62, 0x67, 0xd0, 0xb7, 0xf0, 0xfb, 0x37, 0x7a, 0x01, 0x7b, 0xc4, 0xa4, 0x16,
+	0xf5, 0xc2, 0xc1, 0xd5, 0xc2, 0x3f, 0xe0, 0xf1, 0x44, 0x98, 0x77, 0xfc, 0x82, 0xcb, 0xb9, 0xb8,
+	0x5d, 0x3f, 0x82, 0x2e, 0x5f, 0x2c, 0xd6, 0x42, 0x6b, 0x6a, 0xe1, 0x42, 0x5b, 0xd1, 0x5a, 0x98,
+	0x34, 0x89, 0x5a, 0x54, 0xa9, 0x43, 0x36, 0x86, 0xce, 0x97, 0xe9, 0xb7, 0x4f, 0x69, 0xd2, 0x74,
+	0x46, 0x1c, 0x40, 0x4f, 0x15, 0x66, 0x55, 0x98, 0x54, 0x2e, 0x44, 0xfd, 0x0c, 0x7b, 0xd9, 0x76,
+	0x8a, 0x7d, 0x07, 0xdc, 0x1e, 0x8f, 0x96, 0x88, 0xa0, 0x3b, 0xab, 0x53, 0x56, 0xae, 0x9d, 0xb9,
+	0x10, 0x5f, 0x41, 0xb7, 0x30, 0xa5, 0x4a, 0x93, 0x6a, 0xc6, 0xd6, 0xb0, 0x77, 0xfa, 0x68, 0xe4,
+	0x3c, 0x31, 0xaa, 0x07, 0xc9, 0x1c, 0x80, 0x7d, 0x80, 0x78, 0x22, 0xcc, 0x79, 0x35, 0x69, 0x22,
+	0xf4, 0x7c, 0xbd, 0x5c, 0x99, 0xa5, 0x92, 0x77, 0xdf, 0x80, 0x36, 0x0d, 0xfd, 0x4d, 0xff, 0x04,
+	0x70, 0xd4, 0x28, 0xb7, 0x99, 0xd9, 0x31, 0x03, 0x8f, 0x59, 0x5d, 0x46, 0xf2, 0x5c, 0xb8, 0x77,
+	0xab, 0xbe, 0xad, 0x13, 0x7e, 0xe7, 0x33, 0x75, 0x41, 0x07, 0xa5, 0x08, 0x19, 0xf4, 0x17, 0x42,
+	0xaa, 0x7c, 0x29, 0x79, 0xa5, 0x1e, 0xb5, 0xed, 0xc9, 0xbc, 0xdc, 0xe9, 0xdf, 0x10, 0x5a, 0xe7,
+	0x5f, 0x3f, 0xe2, 0x18, 0xba, 0xe4, 0x65, 0x8c, 0x36, 0x57, 0xf0, 0x7f, 0x8b, 0xf8, 0x59, 0x43,
+	0x85, 0x26, 0x7e, 0x0f, 0xbd, 0x2d, 0x93, 0xe2, 0xf1, 0x06, 0x79, 0xdd, 0xfb, 0xf1, 0xf3, 0x1b,
+	0xaa, 0xa4, 0x75, 0x06, 0x3b, 0x36, 0x8d, 0x87, 0xff, 0xe1, 0x1c, 0xff, 0xe9, 0xb5, 0x3c, 0x31,
+	0x27, 0x00, 0x1b, 0x07, 0xe0, 0x91, 0x07, 0xf3, 0x6d, 0x1b, 0x1f, 0x37, 0x17, 0x49, 0x68, 0x06,
+	0x07, 0x0d, 0xef, 0x83, 0x2f, 0x3d, 0xd2, 0x0d, 0x6e, 0x88, 0x4f, 0xee, 0x40, 0xd5, 0x3d, 0x66,
+	0x1d, 0x0b, 0x79, 0xf3, 0x6f, 0x00, 0x50, 0x1f, 0x1d, 0x94, 0x94, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// AVMClient is the client API for AVM service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AVMClient interface {
+	IssueTx(ctx context.Context, in *IssueTxRequest, opts ...grpc.CallOption) (*IssueTxResponse, error)
+	GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error)
+	GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error)
+	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
+	GetAssetDescription(ctx context.Context, in *GetAssetDescriptionRequest, opts ...grpc.CallOption) (*GetAssetDescriptionResponse, error)
+}
+
+type aVMClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAVMClient(cc grpc.ClientConnInterface) AVMClient {
+	return &aVMClient{cc}
+}
+
+func (c *aVMClient) IssueTx(ctx context.Context, in *IssueTxRequest, opts ...grpc.CallOption) (*IssueTxResponse, error) {
+	out := new(IssueTxResponse)
+	err := c.cc.Invoke(ctx, "/avmproto.AVM/IssueTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aVMClient) GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error) {
+	out := new(GetTxStatusResponse)
+	err := c.cc.Invoke(ctx, "/avmproto.AVM/GetTxStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aVMClient) GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error) {
+	out := new(GetTxResponse)
+	err := c.cc.Invoke(ctx, "/avmproto.AVM/GetTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aVMClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error) {
+	out := new(GetBalanceResponse)
+	err := c.cc.Invoke(ctx, "/avmproto.AVM/GetBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aVMClient) GetAssetDescription(ctx context.Context, in *GetAssetDescriptionRequest, opts ...grpc.CallOption) (*GetAssetDescriptionResponse, error) {
+	out := new(GetAssetDescriptionResponse)
+	err := c.cc.Invoke(ctx, "/avmproto.AVM/GetAssetDescription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AVMServer is the server API for AVM service.
+type AVMServer interface {
+	IssueTx(context.Context, *IssueTxRequest) (*IssueTxResponse, error)
+	GetTxStatus(context.Context, *GetTxStatusRequest) (*GetTxStatusResponse, error)
+	GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error)
+	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
+	GetAssetDescription(context.Context, *GetAssetDescriptionRequest) (*GetAssetDescriptionResponse, error)
+}
+
+// UnimplementedAVMServer can be embedded to have forward compatible implementations.
+type UnimplementedAVMServer struct {
+}
+
+func (*UnimplementedAVMServer) IssueTx(ctx context.Context, req *IssueTxRequest) (*IssueTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueTx not implemented")
+}
+func (*UnimplementedAVMServer) GetTxStatus(ctx context.Context, req *GetTxStatusRequest) (*GetTxStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxStatus not implemented")
+}
+func (*UnimplementedAVMServer) GetTx(ctx context.Context, req *GetTxRequest) (*GetTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTx not implemented")
+}
+func (*UnimplementedAVMServer) GetBalance(ctx context.Context, req *GetBalanceRequest) (*GetBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (*UnimplementedAVMServer) GetAssetDescription(ctx context.Context, req *GetAssetDescriptionRequest) (*GetAssetDescriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAssetDescription not implemented")
+}
+
+func RegisterAVMServer(s *grpc.Server, srv AVMServer) {
+	s.RegisterService(&_AVM_serviceDesc, srv)
+}
+
+func _AVM_IssueTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AVMServer).IssueTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/avmproto.AVM/IssueTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AVMServer).IssueTx(ctx, req.(*IssueTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AVM_GetTxStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AVMServer).GetTxStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/avmproto.AVM/GetTxStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AVMServer).GetTxStatus(ctx, req.(*GetTxStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AVM_GetTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AVMServer).GetTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/avmproto.AVM/GetTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AVMServer).GetTx(ctx, req.(*GetTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AVM_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AVMServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/avmproto.AVM/GetBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AVMServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AVM_GetAssetDescription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssetDescriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AVMServer).GetAssetDescription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/avmproto.AVM/GetAssetDescription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AVMServer).GetAssetDescription(ctx, req.(*GetAssetDescriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AVM_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "avmproto.AVM",
+	HandlerType: (*AVMServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IssueTx",
+			Handler:    _AVM_IssueTx_Handler,
+		},
+		{
+			MethodName: "GetTxStatus",
+			Handler:    _AVM_GetTxStatus_Handler,
+		},
+		{
+			MethodName: "GetTx",
+			Handler:    _AVM_GetTx_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _AVM_GetBalance_Handler,
+		},
+		{
+			MethodName: "GetAssetDescription",
+			Handler:    _AVM_GetAssetDescription_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "avm.proto",
+}
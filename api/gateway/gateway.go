@@ -0,0 +1,163 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/rpc/v2/json2"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/gateway/avmproto"
+	"github.com/ava-labs/avalanchego/api/gateway/healthproto"
+	"github.com/ava-labs/avalanchego/api/gateway/infoproto"
+	"github.com/ava-labs/avalanchego/api/gateway/platformproto"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// Gateway serves the info, health, AVM and platform APIs over gRPC and
+// gRPC-web. Each gRPC call is served by calling the corresponding JSON-RPC
+// method, so the APIs behave identically regardless of how they're called.
+type Gateway struct {
+	log logging.Logger
+	// Serves the JSON-RPC APIs. Requests passed to it are subject to the same
+	// authorization and rate limits as requests from HTTP clients.
+	apiHandler http.Handler
+	cors       api.CORSConfig
+	server     *grpc.Server
+}
+
+// New returns a gateway that serves gRPC calls by passing JSON-RPC requests
+// to [apiHandler]. Browsers may make gRPC-web calls from the origins [cors]
+// allows.
+func New(log logging.Logger, apiHandler http.Handler, cors api.CORSConfig) *Gateway {
+	g := &Gateway{
+		log:        log,
+		apiHandler: apiHandler,
+		cors:       cors,
+		server:     grpc.NewServer(),
+	}
+	infoproto.RegisterInfoServer(g.server, &infoServer{gateway: g})
+	healthproto.RegisterHealthServer(g.server, &healthServer{gateway: g})
+	avmproto.RegisterAVMServer(g.server, &avmServer{gateway: g})
+	platformproto.RegisterPlatformServer(g.server, &platformServer{gateway: g})
+	return g
+}
+
+// Dispatch starts serving gRPC, over cleartext HTTP/2, and gRPC-web at
+// [listenAddress]
+func (g *Gateway) Dispatch(listenAddress string) error {
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return err
+	}
+	g.log.Info("gRPC gateway listening on %q", listenAddress)
+	return http.Serve(listener, h2c.NewHandler(g, &http2.Server{}))
+}
+
+// DispatchTLS starts serving gRPC and gRPC-web at [listenAddress] with the
+// provided TLS certificate
+func (g *Gateway) DispatchTLS(listenAddress, certFile, keyFile string) error {
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return err
+	}
+	g.log.Info("gRPC gateway listening with TLS on %q", listenAddress)
+	return http.ServeTLS(listener, g, certFile, keyFile)
+}
+
+// ServeHTTP serves gRPC calls made over HTTP/2 and gRPC-web calls
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case isGRPCWeb(r) || r.Method == http.MethodOptions:
+		g.corsMiddleware(http.HandlerFunc(g.serveGRPCWeb)).ServeHTTP(w, r)
+	case r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc"):
+		g.server.ServeHTTP(w, r)
+	default:
+		http.Error(w, "expected a gRPC or gRPC-web request", http.StatusUnsupportedMediaType)
+	}
+}
+
+// call calls [method] of the JSON-RPC API at /ext/[endpoint] with [args] and
+// decodes the result into [reply]. The auth token and address of the gRPC
+// client are passed along with the call.
+func (g *Gateway) call(ctx context.Context, endpoint, method string, args, reply interface{}) error {
+	body, err := json2.EncodeClientRequest(method, args)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "couldn't encode arguments: %s", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/ext/"+endpoint, bytes.NewReader(body))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, value := range md.Get("authorization") {
+			req.Header.Add("Authorization", value)
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		req.RemoteAddr = p.Addr.String()
+	}
+
+	w := &responseRecorder{header: make(http.Header), status: http.StatusOK}
+	g.apiHandler.ServeHTTP(w, req)
+	if w.status < 200 || w.status > 299 {
+		return status.Error(statusCode(w.status), strings.TrimSpace(w.body.String()))
+	}
+	if err := json2.DecodeClientResponse(&w.body, reply); err != nil {
+		if jsonErr, ok := err.(*json2.Error); ok {
+			return status.Error(codes.Unknown, jsonErr.Message)
+		}
+		return status.Errorf(codes.Internal, "couldn't decode response: %s", err)
+	}
+	return nil
+}
+
+// statusCode returns the gRPC status code corresponding to the HTTP status
+// [httpStatus]
+func statusCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// responseRecorder records the response to a JSON-RPC call
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header { return r.header }
+
+func (r *responseRecorder) Write(b []byte) (int, error) { return r.body.Write(b) }
+
+func (r *responseRecorder) WriteHeader(status int) { r.status = status }
@@ -0,0 +1,148 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/gateway/infoproto"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const nodeID = "NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg"
+
+// apiHandler serves info.getNodeID, which requires the token "token", and
+// fails every other method
+func apiHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("invalid auth token"))
+			return
+		}
+		request := struct {
+			Method string `json:"method"`
+			ID     uint64 `json:"id"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Error(err)
+			return
+		}
+		if r.URL.Path != "/ext/info" || request.Method != "info.getNodeID" {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32000,"message":"method failed"},"id":0}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":{"nodeID":"` + nodeID + `"},"id":0}`))
+	})
+}
+
+func TestGRPC(t *testing.T) {
+	g := New(logging.NoLog{}, apiHandler(t), api.DefaultCORSConfig())
+	server := httptest.NewServer(h2c.NewHandler(g, &http2.Server{}))
+	defer server.Close()
+
+	conn, err := grpc.Dial(strings.TrimPrefix(server.URL, "http://"), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := infoproto.NewInfoClient(conn)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+	reply, err := client.GetNodeID(ctx, &infoproto.GetNodeIDRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.NodeID != nodeID {
+		t.Fatalf("expected node ID %s but got %s", nodeID, reply.NodeID)
+	}
+
+	_, err = client.GetNetworkName(ctx, &infoproto.GetNetworkNameRequest{})
+	if code := status.Code(err); code != codes.Unknown || status.Convert(err).Message() != "method failed" {
+		t.Fatalf("expected the method's error but got %v", err)
+	}
+
+	_, err = client.GetNodeID(context.Background(), &infoproto.GetNodeIDRequest{})
+	if code := status.Code(err); code != codes.Unauthenticated {
+		t.Fatalf("expected code %s but got %v", codes.Unauthenticated, err)
+	}
+}
+
+func TestGRPCWeb(t *testing.T) {
+	g := New(logging.NoLog{}, apiHandler(t), api.DefaultCORSConfig())
+	server := httptest.NewServer(g)
+	defer server.Close()
+
+	message, err := proto.Marshal(&infoproto.GetNodeIDRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := make([]byte, 5, 5+len(message))
+	binary.BigEndian.PutUint32(body[1:], uint32(len(message)))
+	body = append(body, message...)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/infoproto.Info/GetNodeID", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", grpcWebProtoContentType)
+	req.Header.Set("Authorization", "Bearer token")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if contentType := resp.Header.Get("Content-Type"); contentType != grpcWebProtoContentType {
+		t.Fatalf("unexpected content type %q", contentType)
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The response is a message frame followed by a trailer frame
+	frames := [][]byte(nil)
+	flags := []byte(nil)
+	for len(respBody) > 0 {
+		if len(respBody) < 5 {
+			t.Fatalf("truncated frame %x", respBody)
+		}
+		length := binary.BigEndian.Uint32(respBody[1:5])
+		flags = append(flags, respBody[0])
+		frames = append(frames, respBody[5:5+length])
+		respBody = respBody[5+length:]
+	}
+	if len(frames) != 2 || flags[0] != 0 || flags[1] != trailerFrameFlag {
+		t.Fatalf("unexpected frames with flags %v", flags)
+	}
+	reply := &infoproto.GetNodeIDResponse{}
+	if err := proto.Unmarshal(frames[0], reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.NodeID != nodeID {
+		t.Fatalf("expected node ID %s but got %s", nodeID, reply.NodeID)
+	}
+	if trailers := string(frames[1]); !strings.Contains(trailers, "grpc-status: 0\r\n") {
+		t.Fatalf("expected status 0 in trailers %q", trailers)
+	}
+}
@@ -0,0 +1,49 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ava-labs/avalanchego/api/gateway/healthproto"
+	"github.com/ava-labs/avalanchego/api/health"
+)
+
+const healthEndpoint = "health"
+
+// healthServer serves the health API over gRPC
+type healthServer struct{ gateway *Gateway }
+
+func (s *healthServer) GetLiveness(ctx context.Context, _ *healthproto.GetLivenessRequest) (*healthproto.GetLivenessResponse, error) {
+	reply := health.GetLivenessReply{}
+	if err := s.gateway.call(ctx, healthEndpoint, "health.getLiveness", &health.GetLivenessArgs{}, &reply); err != nil {
+		return nil, err
+	}
+	checks := make(map[string]*healthproto.CheckResult, len(reply.Checks))
+	for name, result := range reply.Checks {
+		check := &healthproto.CheckResult{
+			Healthy:            result.Healthy,
+			Message:            result.Message,
+			Timestamp:          result.Timestamp.UnixNano(),
+			Duration:           int64(result.Duration),
+			ContiguousFailures: result.ContiguousFailures,
+		}
+		if result.Details != nil {
+			// The details were decoded from JSON, so they can be encoded
+			details, err := json.Marshal(result.Details)
+			if err == nil {
+				check.Details = string(details)
+			}
+		}
+		if result.TimeOfFirstFailure != nil {
+			check.TimeOfFirstFailure = result.TimeOfFirstFailure.UnixNano()
+		}
+		checks[name] = check
+	}
+	return &healthproto.GetLivenessResponse{
+		Healthy: reply.Healthy,
+		Checks:  checks,
+	}, nil
+}
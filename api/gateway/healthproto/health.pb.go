@@ -0,0 +1,303 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: health.proto
+
+package healthproto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetLivenessRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLivenessRequest) Reset()         { *m = GetLivenessRequest{} }
+func (m *GetLivenessRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLivenessRequest) ProtoMessage()    {}
+func (*GetLivenessRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fdbebe66dda7cb29, []int{0}
+}
+
+func (m *GetLivenessRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLivenessRequest.Unmarshal(m, b)
+}
+func (m *GetLivenessRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLivenessRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLivenessRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLivenessRequest.Merge(m, src)
+}
+func (m *GetLivenessRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLivenessRequest.Size(m)
+}
+func (m *GetLivenessRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLivenessRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLivenessRequest proto.InternalMessageInfo
+
+type CheckResult struct {
+	Healthy              bool     `protobuf:"varint,1,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Details              string   `protobuf:"bytes,3,opt,name=details,proto3" json:"details,omitempty"`
+	Timestamp            int64    `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Duration             int64    `protobuf:"varint,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	ContiguousFailures   int64    `protobuf:"varint,6,opt,name=contiguousFailures,proto3" json:"contiguousFailures,omitempty"`
+	TimeOfFirstFailure   int64    `protobuf:"varint,7,opt,name=timeOfFirstFailure,proto3" json:"timeOfFirstFailure,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckResult) Reset()         { *m = CheckResult{} }
+func (m *CheckResult) String() string { return proto.CompactTextString(m) }
+func (*CheckResult) ProtoMessage()    {}
+func (*CheckResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fdbebe66dda7cb29, []int{1}
+}
+
+func (m *CheckResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResult.Unmarshal(m, b)
+}
+func (m *CheckResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResult.Marshal(b, m, deterministic)
+}
+func (m *CheckResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResult.Merge(m, src)
+}
+func (m *CheckResult) XXX_Size() int {
+	return xxx_messageInfo_CheckResult.Size(m)
+}
+func (m *CheckResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResult proto.InternalMessageInfo
+
+func (m *CheckResult) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *CheckResult) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *CheckResult) GetDetails() string {
+	if m != nil {
+		return m.Details
+	}
+	return ""
+}
+
+func (m *CheckResult) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *CheckResult) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *CheckResult) GetContiguousFailures() int64 {
+	if m != nil {
+		return m.ContiguousFailures
+	}
+	return 0
+}
+
+func (m *CheckResult) GetTimeOfFirstFailure() int64 {
+	if m != nil {
+		return m.TimeOfFirstFailure
+	}
+	return 0
+}
+
+type GetLivenessResponse struct {
+	Healthy              bool                    `protobuf:"varint,1,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	Checks               map[string]*CheckResult `protobuf:"bytes,2,rep,name=checks,proto3" json:"checks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetLivenessResponse) Reset()         { *m = GetLivenessResponse{} }
+func (m *GetLivenessResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLivenessResponse) ProtoMessage()    {}
+func (*GetLivenessResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fdbebe66dda7cb29, []int{2}
+}
+
+func (m *GetLivenessResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLivenessResponse.Unmarshal(m, b)
+}
+func (m *GetLivenessResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLivenessResponse.Marshal(b, m, deterministic)
+}
+func (m *GetLivenessResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLivenessResponse.Merge(m, src)
+}
+func (m *GetLivenessResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLivenessResponse.Size(m)
+}
+func (m *GetLivenessResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLivenessResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLivenessResponse proto.InternalMessageInfo
+
+func (m *GetLivenessResponse) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *GetLivenessResponse) GetChecks() map[string]*CheckResult {
+	if m != nil {
+		return m.Checks
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetLivenessRequest)(nil), "healthproto.GetLivenessRequest")
+	proto.RegisterType((*CheckResult)(nil), "healthproto.CheckResult")
+	proto.RegisterType((*GetLivenessResponse)(nil), "healthproto.GetLivenessResponse")
+	proto.RegisterMapType((map[string]*CheckResult)(nil), "healthproto.GetLivenessResponse.ChecksEntry")
+}
+
+func init() { proto.RegisterFile("health.proto", fileDescriptor_fdbebe66dda7cb29) }
+
+var fileDescriptor_fdbebe66dda7cb29 = []byte{
+	// 318 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0x31, 0x4f, 0xfb, 0x30,
+	0x10, 0xc5, 0xe5, 0xe6, 0xdf, 0xb4, 0xbd, 0xfc, 0x07, 0x64, 0x18, 0xac, 0x0a, 0x89, 0xa8, 0x53,
+	0x06, 0x94, 0xa1, 0x2c, 0x88, 0x15, 0x28, 0x0c, 0x48, 0x20, 0xb3, 0xb1, 0x99, 0xf6, 0x68, 0xad,
+	0xa6, 0x49, 0xc9, 0xd9, 0x95, 0xfa, 0x15, 0xf9, 0x3e, 0xec, 0xc8, 0x76, 0x0b, 0xa9, 0x28, 0xb0,
+	0xf9, 0xdd, 0xef, 0xd9, 0xbe, 0x7b, 0x07, 0xff, 0x67, 0xa8, 0x0a, 0x33, 0xcb, 0x97, 0x75, 0x65,
+	0x2a, 0x9e, 0x04, 0xe5, 0xc5, 0xe0, 0x08, 0xf8, 0x0d, 0x9a, 0x3b, 0xbd, 0xc2, 0x12, 0x89, 0x24,
+	0xbe, 0x5a, 0x24, 0x33, 0x78, 0x67, 0x90, 0x5c, 0xce, 0x70, 0x3c, 0x97, 0x48, 0xb6, 0x30, 0x5c,
+	0x40, 0x27, 0x5c, 0x5a, 0x0b, 0x96, 0xb2, 0xac, 0x2b, 0xb7, 0xd2, 0x91, 0x05, 0x12, 0xa9, 0x29,
+	0x8a, 0x56, 0xca, 0xb2, 0x9e, 0xdc, 0x4a, 0x47, 0x26, 0x68, 0x94, 0x2e, 0x48, 0x44, 0x81, 0x6c,
+	0x24, 0x3f, 0x86, 0x9e, 0xd1, 0x0b, 0x24, 0xa3, 0x16, 0x4b, 0xf1, 0x2f, 0x65, 0x59, 0x24, 0xbf,
+	0x0a, 0xbc, 0x0f, 0xdd, 0x89, 0xad, 0x95, 0xd1, 0x55, 0x29, 0xda, 0x1e, 0x7e, 0x6a, 0x9e, 0x03,
+	0x1f, 0x57, 0xa5, 0xd1, 0x53, 0x5b, 0x59, 0x1a, 0x29, 0x5d, 0xd8, 0x1a, 0x49, 0xc4, 0xde, 0xb5,
+	0x87, 0x38, 0xbf, 0x7b, 0xf8, 0xfe, 0x65, 0xa4, 0x6b, 0x32, 0x9b, 0xb2, 0xe8, 0x04, 0xff, 0x77,
+	0x32, 0x78, 0x63, 0x70, 0xb8, 0x13, 0x07, 0x2d, 0xab, 0x92, 0xf0, 0x97, 0xf9, 0xaf, 0x20, 0x1e,
+	0xbb, 0xa0, 0x48, 0xb4, 0xd2, 0x28, 0x4b, 0x86, 0xa7, 0x79, 0x23, 0xdd, 0x7c, 0xcf, 0x5b, 0xb9,
+	0xcf, 0x95, 0xae, 0x4b, 0x53, 0xaf, 0xe5, 0xe6, 0x6e, 0xff, 0x11, 0x92, 0x46, 0x99, 0x1f, 0x40,
+	0x34, 0xc7, 0xf0, 0x55, 0x4f, 0xba, 0x23, 0xcf, 0xa1, 0xbd, 0x52, 0x85, 0x0d, 0x21, 0x27, 0x43,
+	0xb1, 0xf3, 0x4b, 0x63, 0x53, 0x32, 0xd8, 0x2e, 0x5a, 0xe7, 0x6c, 0xf8, 0x04, 0xf1, 0xad, 0x77,
+	0xf1, 0x07, 0x48, 0x1a, 0x9d, 0xf0, 0x93, 0x9f, 0x7b, 0xf4, 0xeb, 0xef, 0xa7, 0x7f, 0x0d, 0xf1,
+	0x1c, 0x7b, 0x74, 0xf6, 0x31, 0x00, 0x04, 0x67, 0x30, 0x51, 0x5a, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// HealthClient is the client API for Health service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HealthClient interface {
+	GetLiveness(ctx context.Context, in *GetLivenessRequest, opts ...grpc.CallOption) (*GetLivenessResponse, error)
+}
+
+type healthClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHealthClient(cc grpc.ClientConnInterface) HealthClient {
+	return &healthClient{cc}
+}
+
+func (c *healthClient) GetLiveness(ctx context.Context, in *GetLivenessRequest, opts ...grpc.CallOption) (*GetLivenessResponse, error) {
+	out := new(GetLivenessResponse)
+	err := c.cc.Invoke(ctx, "/healthproto.Health/GetLiveness", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HealthServer is the server API for Health service.
+type HealthServer interface {
+	GetLiveness(context.Context, *GetLivenessRequest) (*GetLivenessResponse, error)
+}
+
+// UnimplementedHealthServer can be embedded to have forward compatible implementations.
+type UnimplementedHealthServer struct {
+}
+
+func (*UnimplementedHealthServer) GetLiveness(ctx context.Context, req *GetLivenessRequest) (*GetLivenessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLiveness not implemented")
+}
+
+func RegisterHealthServer(s *grpc.Server, srv HealthServer) {
+	s.RegisterService(&_Health_serviceDesc, srv)
+}
+
+func _Health_GetLiveness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLivenessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealthServer).GetLiveness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/healthproto.Health/GetLiveness",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealthServer).GetLiveness(ctx, req.(*GetLivenessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Health_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "healthproto.Health",
+	HandlerType: (*HealthServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLiveness",
+			Handler:    _Health_GetLiveness_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "health.proto",
+}
@@ -0,0 +1,91 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/api/gateway/infoproto"
+	"github.com/ava-labs/avalanchego/api/info"
+)
+
+const infoEndpoint = "info"
+
+// infoServer serves the info API over gRPC
+type infoServer struct{ gateway *Gateway }
+
+func (s *infoServer) GetNodeVersion(ctx context.Context, _ *infoproto.GetNodeVersionRequest) (*infoproto.GetNodeVersionResponse, error) {
+	reply := info.GetNodeVersionReply{}
+	if err := s.gateway.call(ctx, infoEndpoint, "info.getNodeVersion", struct{}{}, &reply); err != nil {
+		return nil, err
+	}
+	return &infoproto.GetNodeVersionResponse{
+		Version:         reply.Version,
+		GitCommit:       reply.GitCommit,
+		DatabaseVersion: reply.DatabaseVersion,
+	}, nil
+}
+
+func (s *infoServer) GetNodeID(ctx context.Context, _ *infoproto.GetNodeIDRequest) (*infoproto.GetNodeIDResponse, error) {
+	reply := info.GetNodeIDReply{}
+	if err := s.gateway.call(ctx, infoEndpoint, "info.getNodeID", struct{}{}, &reply); err != nil {
+		return nil, err
+	}
+	return &infoproto.GetNodeIDResponse{NodeID: reply.NodeID}, nil
+}
+
+func (s *infoServer) GetNetworkID(ctx context.Context, _ *infoproto.GetNetworkIDRequest) (*infoproto.GetNetworkIDResponse, error) {
+	reply := info.GetNetworkIDReply{}
+	if err := s.gateway.call(ctx, infoEndpoint, "info.getNetworkID", struct{}{}, &reply); err != nil {
+		return nil, err
+	}
+	return &infoproto.GetNetworkIDResponse{NetworkID: uint32(reply.NetworkID)}, nil
+}
+
+func (s *infoServer) GetNetworkName(ctx context.Context, _ *infoproto.GetNetworkNameRequest) (*infoproto.GetNetworkNameResponse, error) {
+	reply := info.GetNetworkNameReply{}
+	if err := s.gateway.call(ctx, infoEndpoint, "info.getNetworkName", struct{}{}, &reply); err != nil {
+		return nil, err
+	}
+	return &infoproto.GetNetworkNameResponse{NetworkName: reply.NetworkName}, nil
+}
+
+func (s *infoServer) GetBlockchainID(ctx context.Context, req *infoproto.GetBlockchainIDRequest) (*infoproto.GetBlockchainIDResponse, error) {
+	reply := info.GetBlockchainIDReply{}
+	args := info.GetBlockchainIDArgs{Alias: req.Alias}
+	if err := s.gateway.call(ctx, infoEndpoint, "info.getBlockchainID", &args, &reply); err != nil {
+		return nil, err
+	}
+	return &infoproto.GetBlockchainIDResponse{BlockchainID: reply.BlockchainID}, nil
+}
+
+func (s *infoServer) IsBootstrapped(ctx context.Context, req *infoproto.IsBootstrappedRequest) (*infoproto.IsBootstrappedResponse, error) {
+	reply := info.IsBootstrappedResponse{}
+	args := info.IsBootstrappedArgs{Chain: req.Chain}
+	if err := s.gateway.call(ctx, infoEndpoint, "info.isBootstrapped", &args, &reply); err != nil {
+		return nil, err
+	}
+	return &infoproto.IsBootstrappedResponse{IsBootstrapped: reply.IsBootstrapped}, nil
+}
+
+func (s *infoServer) Peers(ctx context.Context, req *infoproto.PeersRequest) (*infoproto.PeersResponse, error) {
+	reply := info.PeersReply{}
+	args := info.PeersArgs{NodeIDs: req.NodeIDs}
+	if err := s.gateway.call(ctx, infoEndpoint, "info.peers", &args, &reply); err != nil {
+		return nil, err
+	}
+	peers := make([]*infoproto.Peer, len(reply.Peers))
+	for i, peer := range reply.Peers {
+		peers[i] = &infoproto.Peer{
+			Ip:             peer.IP,
+			PublicIP:       peer.PublicIP,
+			NodeID:         peer.ID,
+			Version:        peer.Version,
+			LastSent:       peer.LastSent.Unix(),
+			LastReceived:   peer.LastReceived.Unix(),
+			ObservedUptime: float32(peer.ObservedUptime),
+		}
+	}
+	return &infoproto.PeersResponse{Peers: peers}, nil
+}
@@ -0,0 +1,997 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: info.proto
+
+package infoproto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetNodeVersionRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNodeVersionRequest) Reset()         { *m = GetNodeVersionRequest{} }
+func (m *GetNodeVersionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetNodeVersionRequest) ProtoMessage()    {}
+func (*GetNodeVersionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{0}
+}
+
+func (m *GetNodeVersionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNodeVersionRequest.Unmarshal(m, b)
+}
+func (m *GetNodeVersionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNodeVersionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetNodeVersionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeVersionRequest.Merge(m, src)
+}
+func (m *GetNodeVersionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetNodeVersionRequest.Size(m)
+}
+func (m *GetNodeVersionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeVersionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeVersionRequest proto.InternalMessageInfo
+
+type GetNodeVersionResponse struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	GitCommit            string   `protobuf:"bytes,2,opt,name=gitCommit,proto3" json:"gitCommit,omitempty"`
+	DatabaseVersion      string   `protobuf:"bytes,3,opt,name=databaseVersion,proto3" json:"databaseVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNodeVersionResponse) Reset()         { *m = GetNodeVersionResponse{} }
+func (m *GetNodeVersionResponse) String() string { return proto.CompactTextString(m) }
+func (*GetNodeVersionResponse) ProtoMessage()    {}
+func (*GetNodeVersionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{1}
+}
+
+func (m *GetNodeVersionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNodeVersionResponse.Unmarshal(m, b)
+}
+func (m *GetNodeVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNodeVersionResponse.Marshal(b, m, deterministic)
+}
+func (m *GetNodeVersionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeVersionResponse.Merge(m, src)
+}
+func (m *GetNodeVersionResponse) XXX_Size() int {
+	return xxx_messageInfo_GetNodeVersionResponse.Size(m)
+}
+func (m *GetNodeVersionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeVersionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeVersionResponse proto.InternalMessageInfo
+
+func (m *GetNodeVersionResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *GetNodeVersionResponse) GetGitCommit() string {
+	if m != nil {
+		return m.GitCommit
+	}
+	return ""
+}
+
+func (m *GetNodeVersionResponse) GetDatabaseVersion() string {
+	if m != nil {
+		return m.DatabaseVersion
+	}
+	return ""
+}
+
+type GetNodeIDRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNodeIDRequest) Reset()         { *m = GetNodeIDRequest{} }
+func (m *GetNodeIDRequest) String() string { return proto.CompactTextString(m) }
+func (*GetNodeIDRequest) ProtoMessage()    {}
+func (*GetNodeIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{2}
+}
+
+func (m *GetNodeIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNodeIDRequest.Unmarshal(m, b)
+}
+func (m *GetNodeIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNodeIDRequest.Marshal(b, m, deterministic)
+}
+func (m *GetNodeIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeIDRequest.Merge(m, src)
+}
+func (m *GetNodeIDRequest) XXX_Size() int {
+	return xxx_messageInfo_GetNodeIDRequest.Size(m)
+}
+func (m *GetNodeIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeIDRequest proto.InternalMessageInfo
+
+type GetNodeIDResponse struct {
+	NodeID               string   `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNodeIDResponse) Reset()         { *m = GetNodeIDResponse{} }
+func (m *GetNodeIDResponse) String() string { return proto.CompactTextString(m) }
+func (*GetNodeIDResponse) ProtoMessage()    {}
+func (*GetNodeIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{3}
+}
+
+func (m *GetNodeIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNodeIDResponse.Unmarshal(m, b)
+}
+func (m *GetNodeIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNodeIDResponse.Marshal(b, m, deterministic)
+}
+func (m *GetNodeIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeIDResponse.Merge(m, src)
+}
+func (m *GetNodeIDResponse) XXX_Size() int {
+	return xxx_messageInfo_GetNodeIDResponse.Size(m)
+}
+func (m *GetNodeIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeIDResponse proto.InternalMessageInfo
+
+func (m *GetNodeIDResponse) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+type GetNetworkIDRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNetworkIDRequest) Reset()         { *m = GetNetworkIDRequest{} }
+func (m *GetNetworkIDRequest) String() string { return proto.CompactTextString(m) }
+func (*GetNetworkIDRequest) ProtoMessage()    {}
+func (*GetNetworkIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{4}
+}
+
+func (m *GetNetworkIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNetworkIDRequest.Unmarshal(m, b)
+}
+func (m *GetNetworkIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNetworkIDRequest.Marshal(b, m, deterministic)
+}
+func (m *GetNetworkIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNetworkIDRequest.Merge(m, src)
+}
+func (m *GetNetworkIDRequest) XXX_Size() int {
+	return xxx_messageInfo_GetNetworkIDRequest.Size(m)
+}
+func (m *GetNetworkIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNetworkIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNetworkIDRequest proto.InternalMessageInfo
+
+type GetNetworkIDResponse struct {
+	NetworkID            uint32   `protobuf:"varint,1,opt,name=networkID,proto3" json:"networkID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNetworkIDResponse) Reset()         { *m = GetNetworkIDResponse{} }
+func (m *GetNetworkIDResponse) String() string { return proto.CompactTextString(m) }
+func (*GetNetworkIDResponse) ProtoMessage()    {}
+func (*GetNetworkIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{5}
+}
+
+func (m *GetNetworkIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNetworkIDResponse.Unmarshal(m, b)
+}
+func (m *GetNetworkIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNetworkIDResponse.Marshal(b, m, deterministic)
+}
+func (m *GetNetworkIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNetworkIDResponse.Merge(m, src)
+}
+func (m *GetNetworkIDResponse) XXX_Size() int {
+	return xxx_messageInfo_GetNetworkIDResponse.Size(m)
+}
+func (m *GetNetworkIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNetworkIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNetworkIDResponse proto.InternalMessageInfo
+
+func (m *GetNetworkIDResponse) GetNetworkID() uint32 {
+	if m != nil {
+		return m.NetworkID
+	}
+	return 0
+}
+
+type GetNetworkNameRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNetworkNameRequest) Reset()         { *m = GetNetworkNameRequest{} }
+func (m *GetNetworkNameRequest) String() string { return proto.CompactTextString(m) }
+func (*GetNetworkNameRequest) ProtoMessage()    {}
+func (*GetNetworkNameRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{6}
+}
+
+func (m *GetNetworkNameRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNetworkNameRequest.Unmarshal(m, b)
+}
+func (m *GetNetworkNameRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNetworkNameRequest.Marshal(b, m, deterministic)
+}
+func (m *GetNetworkNameRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNetworkNameRequest.Merge(m, src)
+}
+func (m *GetNetworkNameRequest) XXX_Size() int {
+	return xxx_messageInfo_GetNetworkNameRequest.Size(m)
+}
+func (m *GetNetworkNameRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNetworkNameRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNetworkNameRequest proto.InternalMessageInfo
+
+type GetNetworkNameResponse struct {
+	NetworkName          string   `protobuf:"bytes,1,opt,name=networkName,proto3" json:"networkName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNetworkNameResponse) Reset()         { *m = GetNetworkNameResponse{} }
+func (m *GetNetworkNameResponse) String() string { return proto.CompactTextString(m) }
+func (*GetNetworkNameResponse) ProtoMessage()    {}
+func (*GetNetworkNameResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{7}
+}
+
+func (m *GetNetworkNameResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNetworkNameResponse.Unmarshal(m, b)
+}
+func (m *GetNetworkNameResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNetworkNameResponse.Marshal(b, m, deterministic)
+}
+func (m *GetNetworkNameResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNetworkNameResponse.Merge(m, src)
+}
+func (m *GetNetworkNameResponse) XXX_Size() int {
+	return xxx_messageInfo_GetNetworkNameResponse.Size(m)
+}
+func (m *GetNetworkNameResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNetworkNameResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNetworkNameResponse proto.InternalMessageInfo
+
+func (m *GetNetworkNameResponse) GetNetworkName() string {
+	if m != nil {
+		return m.NetworkName
+	}
+	return ""
+}
+
+type GetBlockchainIDRequest struct {
+	Alias                string   `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockchainIDRequest) Reset()         { *m = GetBlockchainIDRequest{} }
+func (m *GetBlockchainIDRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockchainIDRequest) ProtoMessage()    {}
+func (*GetBlockchainIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{8}
+}
+
+func (m *GetBlockchainIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockchainIDRequest.Unmarshal(m, b)
+}
+func (m *GetBlockchainIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockchainIDRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockchainIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockchainIDRequest.Merge(m, src)
+}
+func (m *GetBlockchainIDRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockchainIDRequest.Size(m)
+}
+func (m *GetBlockchainIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockchainIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockchainIDRequest proto.InternalMessageInfo
+
+func (m *GetBlockchainIDRequest) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+type GetBlockchainIDResponse struct {
+	BlockchainID         string   `protobuf:"bytes,1,opt,name=blockchainID,proto3" json:"blockchainID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockchainIDResponse) Reset()         { *m = GetBlockchainIDResponse{} }
+func (m *GetBlockchainIDResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockchainIDResponse) ProtoMessage()    {}
+func (*GetBlockchainIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{9}
+}
+
+func (m *GetBlockchainIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockchainIDResponse.Unmarshal(m, b)
+}
+func (m *GetBlockchainIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockchainIDResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlockchainIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockchainIDResponse.Merge(m, src)
+}
+func (m *GetBlockchainIDResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlockchainIDResponse.Size(m)
+}
+func (m *GetBlockchainIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockchainIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockchainIDResponse proto.InternalMessageInfo
+
+func (m *GetBlockchainIDResponse) GetBlockchainID() string {
+	if m != nil {
+		return m.BlockchainID
+	}
+	return ""
+}
+
+type IsBootstrappedRequest struct {
+	Chain                string   `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IsBootstrappedRequest) Reset()         { *m = IsBootstrappedRequest{} }
+func (m *IsBootstrappedRequest) String() string { return proto.CompactTextString(m) }
+func (*IsBootstrappedRequest) ProtoMessage()    {}
+func (*IsBootstrappedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{10}
+}
+
+func (m *IsBootstrappedRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IsBootstrappedRequest.Unmarshal(m, b)
+}
+func (m *IsBootstrappedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IsBootstrappedRequest.Marshal(b, m, deterministic)
+}
+func (m *IsBootstrappedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IsBootstrappedRequest.Merge(m, src)
+}
+func (m *IsBootstrappedRequest) XXX_Size() int {
+	return xxx_messageInfo_IsBootstrappedRequest.Size(m)
+}
+func (m *IsBootstrappedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IsBootstrappedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IsBootstrappedRequest proto.InternalMessageInfo
+
+func (m *IsBootstrappedRequest) GetChain() string {
+	if m != nil {
+		return m.Chain
+	}
+	return ""
+}
+
+type IsBootstrappedResponse struct {
+	IsBootstrapped       bool     `protobuf:"varint,1,opt,name=isBootstrapped,proto3" json:"isBootstrapped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IsBootstrappedResponse) Reset()         { *m = IsBootstrappedResponse{} }
+func (m *IsBootstrappedResponse) String() string { return proto.CompactTextString(m) }
+func (*IsBootstrappedResponse) ProtoMessage()    {}
+func (*IsBootstrappedResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{11}
+}
+
+func (m *IsBootstrappedResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IsBootstrappedResponse.Unmarshal(m, b)
+}
+func (m *IsBootstrappedResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IsBootstrappedResponse.Marshal(b, m, deterministic)
+}
+func (m *IsBootstrappedResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IsBootstrappedResponse.Merge(m, src)
+}
+func (m *IsBootstrappedResponse) XXX_Size() int {
+	return xxx_messageInfo_IsBootstrappedResponse.Size(m)
+}
+func (m *IsBootstrappedResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IsBootstrappedResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IsBootstrappedResponse proto.InternalMessageInfo
+
+func (m *IsBootstrappedResponse) GetIsBootstrapped() bool {
+	if m != nil {
+		return m.IsBootstrapped
+	}
+	return false
+}
+
+type PeersRequest struct {
+	NodeIDs              []string `protobuf:"bytes,1,rep,name=nodeIDs,proto3" json:"nodeIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeersRequest) Reset()         { *m = PeersRequest{} }
+func (m *PeersRequest) String() string { return proto.CompactTextString(m) }
+func (*PeersRequest) ProtoMessage()    {}
+func (*PeersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{12}
+}
+
+func (m *PeersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeersRequest.Unmarshal(m, b)
+}
+func (m *PeersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeersRequest.Marshal(b, m, deterministic)
+}
+func (m *PeersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeersRequest.Merge(m, src)
+}
+func (m *PeersRequest) XXX_Size() int {
+	return xxx_messageInfo_PeersRequest.Size(m)
+}
+func (m *PeersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeersRequest proto.InternalMessageInfo
+
+func (m *PeersRequest) GetNodeIDs() []string {
+	if m != nil {
+		return m.NodeIDs
+	}
+	return nil
+}
+
+type Peer struct {
+	Ip                   string   `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	PublicIP             string   `protobuf:"bytes,2,opt,name=publicIP,proto3" json:"publicIP,omitempty"`
+	NodeID               string   `protobuf:"bytes,3,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	Version              string   `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	LastSent             int64    `protobuf:"varint,5,opt,name=lastSent,proto3" json:"lastSent,omitempty"`
+	LastReceived         int64    `protobuf:"varint,6,opt,name=lastReceived,proto3" json:"lastReceived,omitempty"`
+	ObservedUptime       float32  `protobuf:"fixed32,7,opt,name=observedUptime,proto3" json:"observedUptime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Peer) Reset()         { *m = Peer{} }
+func (m *Peer) String() string { return proto.CompactTextString(m) }
+func (*Peer) ProtoMessage()    {}
+func (*Peer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{13}
+}
+
+func (m *Peer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Peer.Unmarshal(m, b)
+}
+func (m *Peer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Peer.Marshal(b, m, deterministic)
+}
+func (m *Peer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Peer.Merge(m, src)
+}
+func (m *Peer) XXX_Size() int {
+	return xxx_messageInfo_Peer.Size(m)
+}
+func (m *Peer) XXX_DiscardUnknown() {
+	xxx_messageInfo_Peer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Peer proto.InternalMessageInfo
+
+func (m *Peer) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+func (m *Peer) GetPublicIP() string {
+	if m != nil {
+		return m.PublicIP
+	}
+	return ""
+}
+
+func (m *Peer) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *Peer) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Peer) GetLastSent() int64 {
+	if m != nil {
+		return m.LastSent
+	}
+	return 0
+}
+
+func (m *Peer) GetLastReceived() int64 {
+	if m != nil {
+		return m.LastReceived
+	}
+	return 0
+}
+
+func (m *Peer) GetObservedUptime() float32 {
+	if m != nil {
+		return m.ObservedUptime
+	}
+	return 0
+}
+
+type PeersResponse struct {
+	Peers                []*Peer  `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeersResponse) Reset()         { *m = PeersResponse{} }
+func (m *PeersResponse) String() string { return proto.CompactTextString(m) }
+func (*PeersResponse) ProtoMessage()    {}
+func (*PeersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{14}
+}
+
+func (m *PeersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeersResponse.Unmarshal(m, b)
+}
+func (m *PeersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeersResponse.Marshal(b, m, deterministic)
+}
+func (m *PeersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeersResponse.Merge(m, src)
+}
+func (m *PeersResponse) XXX_Size() int {
+	return xxx_messageInfo_PeersResponse.Size(m)
+}
+func (m *PeersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeersResponse proto.InternalMessageInfo
+
+func (m *PeersResponse) GetPeers() []*Peer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetNodeVersionRequest)(nil), "infoproto.GetNodeVersionRequest")
+	proto.RegisterType((*GetNodeVersionResponse)(nil), "infoproto.GetNodeVersionResponse")
+	proto.RegisterType((*GetNodeIDRequest)(nil), "infoproto.GetNodeIDRequest")
+	proto.RegisterType((*GetNodeIDResponse)(nil), "infoproto.GetNodeIDResponse")
+	proto.RegisterType((*GetNetworkIDRequest)(nil), "infoproto.GetNetworkIDRequest")
+	proto.RegisterType((*GetNetworkIDResponse)(nil), "infoproto.GetNetworkIDResponse")
+	proto.RegisterType((*GetNetworkNameRequest)(nil), "infoproto.GetNetworkNameRequest")
+	proto.RegisterType((*GetNetworkNameResponse)(nil), "infoproto.GetNetworkNameResponse")
+	proto.RegisterType((*GetBlockchainIDRequest)(nil), "infoproto.GetBlockchainIDRequest")
+	proto.RegisterType((*GetBlockchainIDResponse)(nil), "infoproto.GetBlockchainIDResponse")
+	proto.RegisterType((*IsBootstrappedRequest)(nil), "infoproto.IsBootstrappedRequest")
+	proto.RegisterType((*IsBootstrappedResponse)(nil), "infoproto.IsBootstrappedResponse")
+	proto.RegisterType((*PeersRequest)(nil), "infoproto.PeersRequest")
+	proto.RegisterType((*Peer)(nil), "infoproto.Peer")
+	proto.RegisterType((*PeersResponse)(nil), "infoproto.PeersResponse")
+}
+
+func init() { proto.RegisterFile("info.proto", fileDescriptor_f140d5b28dddb141) }
+
+var fileDescriptor_f140d5b28dddb141 = []byte{
+	// 558 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x55, 0xbe, 0x93, 0x69, 0x9a, 0xc0, 0xd2, 0x24, 0x96, 0x89, 0x20, 0x59, 0x09, 0x14, 0x09,
+	0x91, 0x43, 0x41, 0x1c, 0x2a, 0x21, 0xa1, 0x82, 0x40, 0xb9, 0x94, 0xca, 0xa8, 0x88, 0xab, 0x13,
+	0x6f, 0x61, 0xd5, 0xc4, 0x6b, 0xbc, 0xdb, 0x20, 0xf1, 0xd3, 0xf8, 0x0d, 0xfc, 0x28, 0xb4, 0xeb,
+	0xf1, 0xd7, 0x3a, 0xe4, 0xe6, 0x79, 0xf3, 0xe6, 0xcd, 0x8c, 0x77, 0x1e, 0x00, 0x0f, 0x6f, 0xc5,
+	0x32, 0x8a, 0x85, 0x12, 0xa4, 0xa7, 0xbf, 0xcd, 0x27, 0x9d, 0xc0, 0xe8, 0x13, 0x53, 0x57, 0x22,
+	0x60, 0x5f, 0x59, 0x2c, 0xb9, 0x08, 0x3d, 0xf6, 0xf3, 0x9e, 0x49, 0x45, 0x7f, 0xc3, 0xd8, 0x4e,
+	0xc8, 0x48, 0x84, 0x92, 0x11, 0x07, 0x3a, 0xfb, 0x04, 0x72, 0x6a, 0xb3, 0xda, 0xa2, 0xe7, 0xa5,
+	0x21, 0x99, 0x42, 0xef, 0x3b, 0x57, 0xef, 0xc5, 0x6e, 0xc7, 0x95, 0x53, 0x37, 0xb9, 0x1c, 0x20,
+	0x0b, 0x18, 0x06, 0xbe, 0xf2, 0xd7, 0xbe, 0x4c, 0x25, 0x9d, 0x86, 0xe1, 0xd8, 0x30, 0x25, 0xf0,
+	0x00, 0x7b, 0xaf, 0x3e, 0xa4, 0xf3, 0xbc, 0x80, 0x87, 0x05, 0x0c, 0x47, 0x19, 0x43, 0x3b, 0x34,
+	0x08, 0x4e, 0x82, 0x11, 0x1d, 0xc1, 0x23, 0x4d, 0x66, 0xea, 0x97, 0x88, 0xef, 0x72, 0x8d, 0xd7,
+	0x70, 0x56, 0x86, 0x51, 0x66, 0x0a, 0xbd, 0x30, 0x05, 0x8d, 0xd2, 0xa9, 0x97, 0x03, 0xe9, 0x2f,
+	0x4a, 0xe2, 0x2b, 0x7f, 0xc7, 0x52, 0xb9, 0x0b, 0x18, 0xdb, 0x09, 0x14, 0x9c, 0xc1, 0x49, 0x98,
+	0xc3, 0x38, 0x5c, 0x11, 0xa2, 0x4b, 0x53, 0x7b, 0xb9, 0x15, 0x9b, 0xbb, 0xcd, 0x0f, 0x9f, 0x87,
+	0xd9, 0x90, 0xe4, 0x0c, 0x5a, 0xfe, 0x96, 0xfb, 0x12, 0xab, 0x92, 0x80, 0xbe, 0x85, 0x49, 0x85,
+	0x8f, 0xcd, 0x28, 0xf4, 0xd7, 0x05, 0x1c, 0xeb, 0x4a, 0x18, 0x7d, 0x09, 0xa3, 0x95, 0xbc, 0x14,
+	0x42, 0x49, 0x15, 0xfb, 0x51, 0xc4, 0x82, 0x42, 0x37, 0xc3, 0x49, 0xbb, 0x99, 0x80, 0xbe, 0x83,
+	0xb1, 0x4d, 0xc7, 0x66, 0xcf, 0x61, 0xc0, 0x4b, 0x19, 0x53, 0xd8, 0xf5, 0x2c, 0x94, 0x2e, 0xa0,
+	0x7f, 0xcd, 0x58, 0x2c, 0xd3, 0x3e, 0x0e, 0x74, 0x92, 0xb7, 0xd1, 0x7b, 0x35, 0xf4, 0xd1, 0x60,
+	0x48, 0xff, 0xd6, 0xa0, 0xa9, 0xa9, 0x64, 0x00, 0x75, 0x1e, 0xe1, 0x1c, 0x75, 0x1e, 0x11, 0x17,
+	0xba, 0xd1, 0xfd, 0x7a, 0xcb, 0x37, 0xab, 0x6b, 0x3c, 0xa6, 0x2c, 0x2e, 0x3c, 0x7c, 0xa3, 0xf8,
+	0xf0, 0xc5, 0xdb, 0x6c, 0x96, 0x6f, 0xd3, 0x85, 0xee, 0xd6, 0x97, 0xea, 0x0b, 0x0b, 0x95, 0xd3,
+	0x9a, 0xd5, 0x16, 0x0d, 0x2f, 0x8b, 0xf5, 0x1f, 0xd4, 0xdf, 0x1e, 0xdb, 0x30, 0xbe, 0x67, 0x81,
+	0xd3, 0x36, 0xf9, 0x12, 0xa6, 0x17, 0x17, 0x6b, 0xc9, 0xe2, 0x3d, 0x0b, 0x6e, 0x22, 0xc5, 0x77,
+	0xcc, 0xe9, 0xcc, 0x6a, 0x8b, 0xba, 0x67, 0xa1, 0xf4, 0x0d, 0x9c, 0xe2, 0xe2, 0xf8, 0xc7, 0x9e,
+	0x41, 0x2b, 0xd2, 0x80, 0xd9, 0xfb, 0xe4, 0x7c, 0xb8, 0xcc, 0xcc, 0xb7, 0xd4, 0x44, 0x2f, 0xc9,
+	0x9e, 0xff, 0x69, 0x42, 0x73, 0x15, 0xde, 0x0a, 0x72, 0x03, 0x83, 0xb2, 0xf1, 0xc8, 0xac, 0x50,
+	0x72, 0xd0, 0xac, 0xee, 0xfc, 0x08, 0x03, 0xc7, 0xf8, 0x08, 0xbd, 0xcc, 0x3f, 0xe4, 0x71, 0x95,
+	0x9f, 0x1d, 0xa0, 0x3b, 0x3d, 0x9c, 0x44, 0x9d, 0xcf, 0xd0, 0x2f, 0x7a, 0x88, 0x3c, 0xb1, 0xd8,
+	0x96, 0xe7, 0xdc, 0xa7, 0xff, 0xcd, 0xa3, 0x20, 0xee, 0x9b, 0x7b, 0xa3, 0xb2, 0x6f, 0xc5, 0x79,
+	0xee, 0xfc, 0x08, 0x03, 0x65, 0xbf, 0xc1, 0xd0, 0x32, 0x0c, 0xb1, 0xaa, 0x0e, 0x98, 0xcf, 0xa5,
+	0xc7, 0x28, 0xf9, 0xc0, 0x65, 0x73, 0x94, 0x06, 0x3e, 0x68, 0x33, 0x77, 0x7e, 0x84, 0x81, 0xb2,
+	0x17, 0xd0, 0x32, 0x87, 0x43, 0x26, 0xd6, 0x85, 0xa4, 0x1e, 0x72, 0x9d, 0x6a, 0x22, 0xa9, 0x5d,
+	0xb7, 0x0d, 0xf8, 0xea, 0xdf, 0x00, 0xbd, 0xd3, 0x56, 0xf7, 0xe5, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// InfoClient is the client API for Info service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type InfoClient interface {
+	GetNodeVersion(ctx context.Context, in *GetNodeVersionRequest, opts ...grpc.CallOption) (*GetNodeVersionResponse, error)
+	GetNodeID(ctx context.Context, in *GetNodeIDRequest, opts ...grpc.CallOption) (*GetNodeIDResponse, error)
+	GetNetworkID(ctx context.Context, in *GetNetworkIDRequest, opts ...grpc.CallOption) (*GetNetworkIDResponse, error)
+	GetNetworkName(ctx context.Context, in *GetNetworkNameRequest, opts ...grpc.CallOption) (*GetNetworkNameResponse, error)
+	GetBlockchainID(ctx context.Context, in *GetBlockchainIDRequest, opts ...grpc.CallOption) (*GetBlockchainIDResponse, error)
+	IsBootstrapped(ctx context.Context, in *IsBootstrappedRequest, opts ...grpc.CallOption) (*IsBootstrappedResponse, error)
+	Peers(ctx context.Context, in *PeersRequest, opts ...grpc.CallOption) (*PeersResponse, error)
+}
+
+type infoClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInfoClient(cc grpc.ClientConnInterface) InfoClient {
+	return &infoClient{cc}
+}
+
+func (c *infoClient) GetNodeVersion(ctx context.Context, in *GetNodeVersionRequest, opts ...grpc.CallOption) (*GetNodeVersionResponse, error) {
+	out := new(GetNodeVersionResponse)
+	err := c.cc.Invoke(ctx, "/infoproto.Info/GetNodeVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoClient) GetNodeID(ctx context.Context, in *GetNodeIDRequest, opts ...grpc.CallOption) (*GetNodeIDResponse, error) {
+	out := new(GetNodeIDResponse)
+	err := c.cc.Invoke(ctx, "/infoproto.Info/GetNodeID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoClient) GetNetworkID(ctx context.Context, in *GetNetworkIDRequest, opts ...grpc.CallOption) (*GetNetworkIDResponse, error) {
+	out := new(GetNetworkIDResponse)
+	err := c.cc.Invoke(ctx, "/infoproto.Info/GetNetworkID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoClient) GetNetworkName(ctx context.Context, in *GetNetworkNameRequest, opts ...grpc.CallOption) (*GetNetworkNameResponse, error) {
+	out := new(GetNetworkNameResponse)
+	err := c.cc.Invoke(ctx, "/infoproto.Info/GetNetworkName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoClient) GetBlockchainID(ctx context.Context, in *GetBlockchainIDRequest, opts ...grpc.CallOption) (*GetBlockchainIDResponse, error) {
+	out := new(GetBlockchainIDResponse)
+	err := c.cc.Invoke(ctx, "/infoproto.Info/GetBlockchainID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoClient) IsBootstrapped(ctx context.Context, in *IsBootstrappedRequest, opts ...grpc.CallOption) (*IsBootstrappedResponse, error) {
+	out := new(IsBootstrappedResponse)
+	err := c.cc.Invoke(ctx, "/infoproto.Info/IsBootstrapped", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *infoClient) Peers(ctx context.Context, in *PeersRequest, opts ...grpc.CallOption) (*PeersResponse, error) {
+	out := new(PeersResponse)
+	err := c.cc.Invoke(ctx, "/infoproto.Info/Peers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InfoServer is the server API for Info service.
+type InfoServer interface {
+	GetNodeVersion(context.Context, *GetNodeVersionRequest) (*GetNodeVersionResponse, error)
+	GetNodeID(context.Context, *GetNodeIDRequest) (*GetNodeIDResponse, error)
+	GetNetworkID(context.Context, *GetNetworkIDRequest) (*GetNetworkIDResponse, error)
+	GetNetworkName(context.Context, *GetNetworkNameRequest) (*GetNetworkNameResponse, error)
+	GetBlockchainID(context.Context, *GetBlockchainIDRequest) (*GetBlockchainIDResponse, error)
+	IsBootstrapped(context.Context, *IsBootstrappedRequest) (*IsBootstrappedResponse, error)
+	Peers(context.Context, *PeersRequest) (*PeersResponse, error)
+}
+
+// UnimplementedInfoServer can be embedded to have forward compatible implementations.
+type UnimplementedInfoServer struct {
+}
+
+func (*UnimplementedInfoServer) GetNodeVersion(ctx context.Context, req *GetNodeVersionRequest) (*GetNodeVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeVersion not implemented")
+}
+func (*UnimplementedInfoServer) GetNodeID(ctx context.Context, req *GetNodeIDRequest) (*GetNodeIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeID not implemented")
+}
+func (*UnimplementedInfoServer) GetNetworkID(ctx context.Context, req *GetNetworkIDRequest) (*GetNetworkIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetworkID not implemented")
+}
+func (*UnimplementedInfoServer) GetNetworkName(ctx context.Context, req *GetNetworkNameRequest) (*GetNetworkNameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetworkName not implemented")
+}
+func (*UnimplementedInfoServer) GetBlockchainID(ctx context.Context, req *GetBlockchainIDRequest) (*GetBlockchainIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockchainID not implemented")
+}
+func (*UnimplementedInfoServer) IsBootstrapped(ctx context.Context, req *IsBootstrappedRequest) (*IsBootstrappedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsBootstrapped not implemented")
+}
+func (*UnimplementedInfoServer) Peers(ctx context.Context, req *PeersRequest) (*PeersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Peers not implemented")
+}
+
+func RegisterInfoServer(s *grpc.Server, srv InfoServer) {
+	s.RegisterService(&_Info_serviceDesc, srv)
+}
+
+func _Info_GetNodeVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).GetNodeVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/infoproto.Info/GetNodeVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).GetNodeVersion(ctx, req.(*GetNodeVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Info_GetNodeID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).GetNodeID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/infoproto.Info/GetNodeID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).GetNodeID(ctx, req.(*GetNodeIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Info_GetNetworkID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNetworkIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).GetNetworkID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/infoproto.Info/GetNetworkID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).GetNetworkID(ctx, req.(*GetNetworkIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Info_GetNetworkName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNetworkNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).GetNetworkName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/infoproto.Info/GetNetworkName",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).GetNetworkName(ctx, req.(*GetNetworkNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Info_GetBlockchainID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockchainIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).GetBlockchainID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/infoproto.Info/GetBlockchainID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).GetBlockchainID(ctx, req.(*GetBlockchainIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Info_IsBootstrapped_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsBootstrappedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).IsBootstrapped(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/infoproto.Info/IsBootstrapped",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).IsBootstrapped(ctx, req.(*IsBootstrappedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Info_Peers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServer).Peers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/infoproto.Info/Peers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServer).Peers(ctx, req.(*PeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Info_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "infoproto.Info",
+	HandlerType: (*InfoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetNodeVersion",
+			Handler:    _Info_GetNodeVersion_Handler,
+		},
+		{
+			MethodName: "GetNodeID",
+			Handler:    _Info_GetNodeID_Handler,
+		},
+		{
+			MethodName: "GetNetworkID",
+			Handler:    _Info_GetNetworkID_Handler,
+		},
+		{
+			MethodName: "GetNetworkName",
+			Handler:    _Info_GetNetworkName_Handler,
+		},
+		{
+			MethodName: "GetBlockchainID",
+			Handler:    _Info_GetBlockchainID_Handler,
+		},
+		{
+			MethodName: "IsBootstrapped",
+			Handler:    _Info_IsBootstrapped_Handler,
+		},
+		{
+			MethodName: "Peers",
+			Handler:    _Info_Peers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "info.proto",
+}
@@ -0,0 +1,94 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/gateway/platformproto"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+const platformEndpoint = "bc/P"
+
+// platformServer serves the platform API over gRPC
+type platformServer struct{ gateway *Gateway }
+
+func (s *platformServer) GetHeight(ctx context.Context, _ *platformproto.GetHeightRequest) (*platformproto.GetHeightResponse, error) {
+	reply := platformvm.GetHeightResponse{}
+	if err := s.gateway.call(ctx, platformEndpoint, "platform.getHeight", struct{}{}, &reply); err != nil {
+		return nil, err
+	}
+	return &platformproto.GetHeightResponse{Height: uint64(reply.Height)}, nil
+}
+
+func (s *platformServer) GetBalance(ctx context.Context, req *platformproto.GetBalanceRequest) (*platformproto.GetBalanceResponse, error) {
+	reply := platformvm.GetBalanceResponse{}
+	if err := s.gateway.call(ctx, platformEndpoint, "platform.getBalance", &api.JSONAddress{Address: req.Address}, &reply); err != nil {
+		return nil, err
+	}
+	return &platformproto.GetBalanceResponse{
+		Balance:            uint64(reply.Balance),
+		Unlocked:           uint64(reply.Unlocked),
+		LockedStakeable:    uint64(reply.LockedStakeable),
+		LockedNotStakeable: uint64(reply.LockedNotStakeable),
+	}, nil
+}
+
+func (s *platformServer) IssueTx(ctx context.Context, req *platformproto.IssueTxRequest) (*platformproto.IssueTxResponse, error) {
+	reply := api.JSONTxID{}
+	args := api.FormattedTx{
+		Tx:       formatting.Hex{}.ConvertBytes(req.Tx),
+		Encoding: formatting.HexEncoding,
+	}
+	if err := s.gateway.call(ctx, platformEndpoint, "platform.issueTx", &args, &reply); err != nil {
+		return nil, err
+	}
+	return &platformproto.IssueTxResponse{TxID: reply.TxID.String()}, nil
+}
+
+func (s *platformServer) GetTx(ctx context.Context, req *platformproto.GetTxRequest) (*platformproto.GetTxResponse, error) {
+	txID, err := ids.FromString(req.TxID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "couldn't parse txID: %s", err)
+	}
+	reply := api.FormattedTx{}
+	args := api.GetTxArgs{TxID: txID, Encoding: formatting.HexEncoding}
+	if err := s.gateway.call(ctx, platformEndpoint, "platform.getTx", &args, &reply); err != nil {
+		return nil, err
+	}
+	tx, err := formatting.Hex{}.ConvertString(reply.Tx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "couldn't decode tx: %s", err)
+	}
+	return &platformproto.GetTxResponse{Tx: tx}, nil
+}
+
+func (s *platformServer) GetTxStatus(ctx context.Context, req *platformproto.GetTxStatusRequest) (*platformproto.GetTxStatusResponse, error) {
+	txID, err := ids.FromString(req.TxID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "couldn't parse txID: %s", err)
+	}
+	reply := platformvm.Status(0)
+	args := platformvm.GetTxStatusArgs{TxID: txID}
+	if err := s.gateway.call(ctx, platformEndpoint, "platform.getTxStatus", &args, &reply); err != nil {
+		return nil, err
+	}
+	return &platformproto.GetTxStatusResponse{Status: reply.String()}, nil
+}
+
+func (s *platformServer) GetBlockchainStatus(ctx context.Context, req *platformproto.GetBlockchainStatusRequest) (*platformproto.GetBlockchainStatusResponse, error) {
+	reply := platformvm.GetBlockchainStatusReply{}
+	args := platformvm.GetBlockchainStatusArgs{BlockchainID: req.BlockchainID}
+	if err := s.gateway.call(ctx, platformEndpoint, "platform.getBlockchainStatus", &args, &reply); err != nil {
+		return nil, err
+	}
+	return &platformproto.GetBlockchainStatusResponse{Status: reply.Status.String()}, nil
+}
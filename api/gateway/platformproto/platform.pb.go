@@ -0,0 +1,816 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: platform.proto
+
+package platformproto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetHeightRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetHeightRequest) Reset()         { *m = GetHeightRequest{} }
+func (m *GetHeightRequest) String() string { return proto.CompactTextString(m) }
+func (*GetHeightRequest) ProtoMessage()    {}
+func (*GetHeightRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_918f3d50bfb447e4, []int{0}
+}
+
+func (m *GetHeightRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHeightRequest.Unmarshal(m, b)
+}
+func (m *GetHeightRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHeightRequest.Marshal(b, m, deterministic)
+}
+func (m *GetHeightRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHeightRequest.Merge(m, src)
+}
+func (m *GetHeightRequest) XXX_Size() int {
+	return xxx_messageInfo_GetHeightRequest.Size(m)
+}
+func (m *GetHeightRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHeightRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHeightRequest proto.InternalMessageInfo
+
+type GetHeightResponse struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetHeightResponse) Reset()         { *m = GetHeightResponse{} }
+func (m *GetHeightResponse) String() string { return proto.CompactTextString(m) }
+func (*GetHeightResponse) ProtoMessage()    {}
+func (*GetHeightResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_918f3d50bfb447e4, []int{1}
+}
+
+func (m *GetHeightResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHeightResponse.Unmarshal(m, b)
+}
+func (m *GetHeightResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHeightResponse.Marshal(b, m, deterministic)
+}
+func (m *GetHeightResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHeightResponse.Merge(m, src)
+}
+func (m *GetHeightResponse) XXX_Size() int {
+	return xxx_messageInfo_GetHeightResponse.Size(m)
+}
+func (m *GetHeightResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHeightResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHeightResponse proto.InternalMessageInfo
+
+func (m *GetHeightResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type GetBalanceRequest struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBalanceRequest) Reset()         { *m = GetBalanceRequest{} }
+func (m *GetBalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBalanceRequest) ProtoMessage()    {}
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_918f3d50bfb447e4, []int{2}
+}
+
+func (m *GetBalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBalanceRequest.Unmarshal(m, b)
+}
+func (m *GetBalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBalanceRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBalanceRequest.Merge(m, src)
+}
+func (m *GetBalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBalanceRequest.Size(m)
+}
+func (m *GetBalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBalanceRequest proto.InternalMessageInfo
+
+func (m *GetBalanceRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type GetBalanceResponse struct {
+	Balance              uint64   `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	Unlocked             uint64   `protobuf:"varint,2,opt,name=unlocked,proto3" json:"unlocked,omitempty"`
+	LockedStakeable      uint64   `protobuf:"varint,3,opt,name=lockedStakeable,proto3" json:"lockedStakeable,omitempty"`
+	LockedNotStakeable   uint64   `protobuf:"varint,4,opt,name=lockedNotStakeable,proto3" json:"lockedNotStakeable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBalanceResponse) Reset()         { *m = GetBalanceResponse{} }
+func (m *GetBalanceResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBalanceResponse) ProtoMessage()    {}
+func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_918f3d50bfb447e4, []int{3}
+}
+
+func (m *GetBalanceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBalanceResponse.Unmarshal(m, b)
+}
+func (m *GetBalanceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBalanceResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBalanceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBalanceResponse.Merge(m, src)
+}
+func (m *GetBalanceResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBalanceResponse.Size(m)
+}
+func (m *GetBalanceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBalanceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBalanceResponse proto.InternalMessageInfo
+
+func (m *GetBalanceResponse) GetBalance() uint64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *GetBalanceResponse) GetUnlocked() uint64 {
+	if m != nil {
+		return m.Unlocked
+	}
+	return 0
+}
+
+func (m *GetBalanceResponse) GetLockedStakeable() uint64 {
+	if m != nil {
+		return m.LockedStakeable
+	}
+	return 0
+}
+
+func (m *GetBalanceResponse) GetLockedNotStakeable() uint64 {
+	if m != nil {
+		return m.LockedNotStakeable
+	}
+	return 0
+}
+
+type IssueTxRequest struct {
+	Tx                   []byte   `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssueTxRequest) Reset()         { *m = IssueTxRequest{} }
+func (m *IssueTxRequest) String() string { return proto.CompactTextString(m) }
+func (*IssueTxRequest) ProtoMessage()    {}
+func (*IssueTxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_918f3d50bfb447e4, []int{4}
+}
+
+func (m *IssueTxRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssueTxRequest.Unmarshal(m, b)
+}
+func (m *IssueTxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssueTxRequest.Marshal(b, m, deterministic)
+}
+func (m *IssueTxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssueTxRequest.Merge(m, src)
+}
+func (m *IssueTxRequest) XXX_Size() int {
+	return xxx_messageInfo_IssueTxRequest.Size(m)
+}
+func (m *IssueTxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssueTxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssueTxRequest proto.InternalMessageInfo
+
+func (m *IssueTxRequest) GetTx() []byte {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+type IssueTxResponse struct {
+	TxID                 string   `protobuf:"bytes,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssueTxResponse) Reset()         { *m = IssueTxResponse{} }
+func (m *IssueTxResponse) String() string { return proto.CompactTextString(m) }
+func (*IssueTxResponse) ProtoMessage()    {}
+func (*IssueTxResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_918f3d50bfb447e4, []int{5}
+}
+
+func (m *IssueTxResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssueTxResponse.Unmarshal(m, b)
+}
+func (m *IssueTxResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssueTxResponse.Marshal(b, m, deterministic)
+}
+func (m *IssueTxResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssueTxResponse.Merge(m, src)
+}
+func (m *IssueTxResponse) XXX_Size() int {
+	return xxx_messageInfo_IssueTxResponse.Size(m)
+}
+func (m *IssueTxResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssueTxResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssueTxResponse proto.InternalMessageInfo
+
+func (m *IssueTxResponse) GetTxID() string {
+	if m != nil {
+		return m.TxID
+	}
+	return ""
+}
+
+type GetTxRequest struct {
+	TxID                 string   `protobuf:"bytes,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxRequest) Reset()         { *m = GetTxRequest{} }
+func (m *GetTxRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTxRequest) ProtoMessage()    {}
+func (*GetTxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_918f3d50bfb447e4, []int{6}
+}
+
+func (m *GetTxRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxRequest.Unmarshal(m, b)
+}
+func (m *GetTxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxRequest.Merge(m, src)
+}
+func (m *GetTxRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTxRequest.Size(m)
+}
+func (m *GetTxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxRequest proto.InternalMessageInfo
+
+func (m *GetTxRequest) GetTxID() string {
+	if m != nil {
+		return m.TxID
+	}
+	return ""
+}
+
+type GetTxResponse struct {
+	Tx                   []byte   `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxResponse) Reset()         { *m = GetTxResponse{} }
+func (m *GetTxResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTxResponse) ProtoMessage()    {}
+func (*GetTxResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_918f3d50bfb447e4, []int{7}
+}
+
+func (m *GetTxResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxResponse.Unmarshal(m, b)
+}
+func (m *GetTxResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTxResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxResponse.Merge(m, src)
+}
+func (m *GetTxResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTxResponse.Size(m)
+}
+func (m *GetTxResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxResponse proto.InternalMessageInfo
+
+func (m *GetTxResponse) GetTx() []byte {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+type GetTxStatusRequest struct {
+	TxID                 string   `protobuf:"bytes,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxStatusRequest) Reset()         { *m = GetTxStatusRequest{} }
+func (m *GetTxStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTxStatusRequest) ProtoMessage()    {}
+func (*GetTxStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_918f3d50bfb447e4, []int{8}
+}
+
+func (m *GetTxStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxStatusRequest.Unmarshal(m, b)
+}
+func (m *GetTxStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTxStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxStatusRequest.Merge(m, src)
+}
+func (m *GetTxStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTxStatusRequest.Size(m)
+}
+func (m *GetTxStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxStatusRequest proto.InternalMessageInfo
+
+func (m *GetTxStatusRequest) GetTxID() string {
+	if m != nil {
+		return m.TxID
+	}
+	return ""
+}
+
+type GetTxStatusResponse struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxStatusResponse) Reset()         { *m = GetTxStatusResponse{} }
+func (m *GetTxStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTxStatusResponse) ProtoMessage()    {}
+func (*GetTxStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_918f3d50bfb447e4, []int{9}
+}
+
+func (m *GetTxStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxStatusResponse.Unmarshal(m, b)
+}
+func (m *GetTxStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTxStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxStatusResponse.Merge(m, src)
+}
+func (m *GetTxStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTxStatusResponse.Size(m)
+}
+func (m *GetTxStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxStatusResponse proto.InternalMessageInfo
+
+func (m *GetTxStatusResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type GetBlockchainStatusRequest struct {
+	BlockchainID         string   `protobuf:"bytes,1,opt,name=blockchainID,proto3" json:"blockchainID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockchainStatusRequest) Reset()         { *m = GetBlockchainStatusRequest{} }
+func (m *GetBlockchainStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockchainStatusRequest) ProtoMessage()    {}
+func (*GetBlockchainStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_918f3d50bfb447e4, []int{10}
+}
+
+func (m *GetBlockchainStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockchainStatusRequest.Unmarshal(m, b)
+}
+func (m *GetBlockchainStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockchainStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockchainStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockchainStatusRequest.Merge(m, src)
+}
+func (m *GetBlockchainStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockchainStatusRequest.Size(m)
+}
+func (m *GetBlockchainStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockchainStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockchainStatusRequest proto.InternalMessageInfo
+
+func (m *GetBlockchainStatusRequest) GetBlockchainID() string {
+	if m != nil {
+		return m.BlockchainID
+	}
+	return ""
+}
+
+type GetBlockchainStatusResponse struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockchainStatusResponse) Reset()         { *m = GetBlockchainStatusResponse{} }
+func (m *GetBlockchainStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockchainStatusResponse) ProtoMessage()    {}
+func (*GetBlockchainStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_918f3d50bfb447e4, []int{11}
+}
+
+func (m *GetBlockchainStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockchainStatusResponse.Unmarshal(m, b)
+}
+func (m *GetBlockchainStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockchainStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlockchainStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockchainStatusResponse.Merge(m, src)
+}
+func (m *GetBlockchainStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlockchainStatusResponse.Size(m)
+}
+func (m *GetBlockchainStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockchainStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockchainStatusResponse proto.InternalMessageInfo
+
+func (m *GetBlockchainStatusResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*GetHeightRequest)(nil), "platformproto.GetHeightRequest")
+	proto.RegisterType((*GetHeightResponse)(nil), "platformproto.GetHeightResponse")
+	proto.RegisterType((*GetBalanceRequest)(nil), "platformproto.GetBalanceRequest")
+	proto.RegisterType((*GetBalanceResponse)(nil), "platformproto.GetBalanceResponse")
+	proto.RegisterType((*IssueTxRequest)(nil), "platformproto.IssueTxRequest")
+	proto.RegisterType((*IssueTxResponse)(nil), "platformproto.IssueTxResponse")
+	proto.RegisterType((*GetTxRequest)(nil), "platformproto.GetTxRequest")
+	proto.RegisterType((*GetTxResponse)(nil), "platformproto.GetTxResponse")
+	proto.RegisterType((*GetTxStatusRequest)(nil), "platformproto.GetTxStatusRequest")
+	proto.RegisterType((*GetTxStatusResponse)(nil), "platformproto.GetTxStatusResponse")
+	proto.RegisterType((*GetBlockchainStatusRequest)(nil), "platformproto.GetBlockchainStatusRequest")
+	proto.RegisterType((*GetBlockchainStatusResponse)(nil), "platformproto.GetBlockchainStatusResponse")
+}
+
+func init() { proto.RegisterFile("platform.proto", fileDescriptor_918f3d50bfb447e4) }
+
+var fileDescriptor_918f3d50bfb447e4 = []byte{
+	// 415 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xdf, 0xce, 0xd2, 0x40,
+	0x10, 0xc5, 0x03, 0x1f, 0x7e, 0xc0, 0xc8, 0x1f, 0x1d, 0x12, 0xd3, 0x14, 0x15, 0xd8, 0xc4, 0x04,
+	0x35, 0xf4, 0x42, 0xe3, 0xbd, 0x21, 0x26, 0xc0, 0x0d, 0xd1, 0xc2, 0x0b, 0x6c, 0x61, 0x15, 0x42,
+	0x6d, 0x91, 0xdd, 0x26, 0x7d, 0x20, 0xdf, 0x53, 0xc3, 0x76, 0xda, 0xd2, 0xba, 0x34, 0xde, 0x75,
+	0x66, 0x7e, 0x39, 0xd3, 0x9d, 0x73, 0xa0, 0x77, 0xf6, 0xb9, 0xfa, 0x1e, 0x5e, 0x7e, 0x3a, 0xe7,
+	0x4b, 0xa8, 0x42, 0xec, 0xa6, 0xb5, 0x2e, 0x19, 0xc2, 0xb3, 0x85, 0x50, 0x4b, 0x71, 0xfc, 0x71,
+	0x50, 0xae, 0xf8, 0x15, 0x09, 0xa9, 0xd8, 0x7b, 0x78, 0x7e, 0xd3, 0x93, 0xe7, 0x30, 0x90, 0x02,
+	0x5f, 0xc0, 0xe3, 0x41, 0x77, 0xac, 0xda, 0xb8, 0x36, 0x6d, 0xb8, 0x54, 0xb1, 0x99, 0x86, 0xe7,
+	0xdc, 0xe7, 0xc1, 0x4e, 0x90, 0x02, 0x5a, 0xd0, 0xe4, 0xfb, 0xfd, 0x45, 0x48, 0xa9, 0xe9, 0xb6,
+	0x9b, 0x96, 0xec, 0x77, 0x0d, 0xf0, 0x96, 0x27, 0x75, 0x0b, 0x9a, 0x5e, 0xd2, 0x22, 0xf9, 0xb4,
+	0x44, 0x1b, 0x5a, 0x51, 0xe0, 0x87, 0xbb, 0x93, 0xd8, 0x5b, 0x75, 0x3d, 0xca, 0x6a, 0x9c, 0x42,
+	0x3f, 0xf9, 0xda, 0x28, 0x7e, 0x12, 0xdc, 0xf3, 0x85, 0xf5, 0xa0, 0x91, 0x72, 0x1b, 0x1d, 0xc0,
+	0xa4, 0xb5, 0x0e, 0x55, 0x0e, 0x37, 0x34, 0x6c, 0x98, 0xb0, 0x31, 0xf4, 0x56, 0x52, 0x46, 0x62,
+	0x1b, 0xa7, 0x4f, 0xea, 0x41, 0x5d, 0xc5, 0xfa, 0xe7, 0x3a, 0x6e, 0x5d, 0xc5, 0xec, 0x0d, 0xf4,
+	0x33, 0x82, 0x1e, 0x81, 0xd0, 0x50, 0xf1, 0xea, 0x0b, 0x3d, 0x59, 0x7f, 0x33, 0x06, 0x9d, 0x85,
+	0x50, 0xb9, 0x8c, 0x89, 0x19, 0x41, 0x97, 0x18, 0x12, 0x2a, 0xef, 0x9a, 0xea, 0x9b, 0x6d, 0xe3,
+	0x8d, 0xe2, 0x2a, 0x92, 0x55, 0x52, 0x33, 0x18, 0x14, 0xc8, 0xdc, 0x3c, 0xa9, 0x3b, 0x04, 0x53,
+	0xc5, 0x3e, 0x83, 0x7d, 0x35, 0xe3, 0x7a, 0x80, 0xdd, 0x81, 0x1f, 0x83, 0xe2, 0x02, 0x06, 0x1d,
+	0x2f, 0x1b, 0x65, 0x8b, 0x0a, 0x3d, 0xf6, 0x09, 0x86, 0x46, 0x85, 0xea, 0xc5, 0x1f, 0xfe, 0x3c,
+	0x40, 0xeb, 0x2b, 0x05, 0x11, 0xd7, 0xd0, 0xce, 0xf2, 0x86, 0x23, 0xa7, 0x10, 0x50, 0xa7, 0x9c,
+	0x4e, 0x7b, 0x7c, 0x1f, 0xa0, 0xa5, 0xdf, 0x00, 0xf2, 0x88, 0xa1, 0x81, 0x2f, 0xa6, 0xd5, 0x9e,
+	0x54, 0x10, 0x24, 0xb9, 0x84, 0x26, 0xb9, 0x8d, 0xaf, 0x4a, 0x74, 0x31, 0x27, 0xf6, 0xeb, 0x7b,
+	0x63, 0x52, 0x9a, 0xc3, 0x13, 0xed, 0x10, 0x0e, 0xff, 0xdd, 0x9a, 0xab, 0xbc, 0x34, 0x0f, 0x49,
+	0x63, 0x0b, 0x4f, 0x6f, 0x5c, 0xc6, 0x89, 0x09, 0x2e, 0x58, 0x69, 0xb3, 0x2a, 0x84, 0x54, 0x7d,
+	0x18, 0x18, 0xac, 0xc4, 0xb7, 0x86, 0xeb, 0x98, 0x03, 0x63, 0xbf, 0xfb, 0x1f, 0x34, 0xd9, 0xe6,
+	0x3d, 0x6a, 0xe4, 0xe3, 0xdf, 0x01, 0x00, 0xf0, 0x89, 0x5a, 0x39, 0xa0, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// PlatformClient is the client API for Platform service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PlatformClient interface {
+	GetHeight(ctx context.Context, in *GetHeightRequest, opts ...grpc.CallOption) (*GetHeightResponse, error)
+	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
+	IssueTx(ctx context.Context, in *IssueTxRequest, opts ...grpc.CallOption) (*IssueTxResponse, error)
+	GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error)
+	GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error)
+	GetBlockchainStatus(ctx context.Context, in *GetBlockchainStatusRequest, opts ...grpc.CallOption) (*GetBlockchainStatusResponse, error)
+}
+
+type platformClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPlatformClient(cc grpc.ClientConnInterface) PlatformClient {
+	return &platformClient{cc}
+}
+
+func (c *platformClient) GetHeight(ctx context.Context, in *GetHeightRequest, opts ...grpc.CallOption) (*GetHeightResponse, error) {
+	out := new(GetHeightResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error) {
+	out := new(GetBalanceResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) IssueTx(ctx context.Context, in *IssueTxRequest, opts ...grpc.CallOption) (*IssueTxResponse, error) {
+	out := new(IssueTxResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/IssueTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error) {
+	out := new(GetTxResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error) {
+	out := new(GetTxStatusResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetTxStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetBlockchainStatus(ctx context.Context, in *GetBlockchainStatusRequest, opts ...grpc.CallOption) (*GetBlockchainStatusResponse, error) {
+	out := new(GetBlockchainStatusResponse)
+	err := c.cc.Invoke(ctx, "/platformproto.Platform/GetBlockchainStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlatformServer is the server API for Platform service.
+type PlatformServer interface {
+	GetHeight(context.Context, *GetHeightRequest) (*GetHeightResponse, error)
+	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
+	IssueTx(context.Context, *IssueTxRequest) (*IssueTxResponse, error)
+	GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error)
+	GetTxStatus(context.Context, *GetTxStatusRequest) (*GetTxStatusResponse, error)
+	GetBlockchainStatus(context.Context, *GetBlockchainStatusRequest) (*GetBlockchainStatusResponse, error)
+}
+
+// UnimplementedPlatformServer can be embedded to have forward compatible implementations.
+type UnimplementedPlatformServer struct {
+}
+
+func (*UnimplementedPlatformServer) GetHeight(ctx context.Context, req *GetHeightRequest) (*GetHeightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeight not implemented")
+}
+func (*UnimplementedPlatformServer) GetBalance(ctx context.Context, req *GetBalanceRequest) (*GetBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (*UnimplementedPlatformServer) IssueTx(ctx context.Context, req *IssueTxRequest) (*IssueTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueTx not implemented")
+}
+func (*UnimplementedPlatformServer) GetTx(ctx context.Context, req *GetTxRequest) (*GetTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTx not implemented")
+}
+func (*UnimplementedPlatformServer) GetTxStatus(ctx context.Context, req *GetTxStatusRequest) (*GetTxStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxStatus not implemented")
+}
+func (*UnimplementedPlatformServer) GetBlockchainStatus(ctx context.Context, req *GetBlockchainStatusRequest) (*GetBlockchainStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockchainStatus not implemented")
+}
+
+func RegisterPlatformServer(s *grpc.Server, srv PlatformServer) {
+	s.RegisterService(&_Platform_serviceDesc, srv)
+}
+
+func _Platform_GetHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetHeight(ctx, req.(*GetHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_IssueTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IssueTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/IssueTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IssueTx(ctx, req.(*IssueTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetTx(ctx, req.(*GetTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetTxStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetTxStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetTxStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetTxStatus(ctx, req.(*GetTxStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetBlockchainStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockchainStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetBlockchainStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformproto.Platform/GetBlockchainStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetBlockchainStatus(ctx, req.(*GetBlockchainStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Platform_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "platformproto.Platform",
+	HandlerType: (*PlatformServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetHeight",
+			Handler:    _Platform_GetHeight_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _Platform_GetBalance_Handler,
+		},
+		{
+			MethodName: "IssueTx",
+			Handler:    _Platform_IssueTx_Handler,
+		},
+		{
+			MethodName: "GetTx",
+			Handler:    _Platform_GetTx_Handler,
+		},
+		{
+			MethodName: "GetTxStatus",
+			Handler:    _Platform_GetTxStatus_Handler,
+		},
+		{
+			MethodName: "GetBlockchainStatus",
+			Handler:    _Platform_GetBlockchainStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "platform.proto",
+}
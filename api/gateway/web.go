@@ -0,0 +1,144 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"encoding/binary"
+	"net/http"
+	"strings"
+
+	"github.com/rs/cors"
+)
+
+const (
+	grpcWebContentType      = "application/grpc-web"
+	grpcWebProtoContentType = "application/grpc-web+proto"
+	grpcProtoContentType    = "application/grpc+proto"
+
+	// Flag of a gRPC-web frame holding trailers rather than a message
+	trailerFrameFlag = 0x80
+)
+
+// isGRPCWeb returns true if [r] is a gRPC-web call. Only the binary format is
+// supported.
+func isGRPCWeb(r *http.Request) bool {
+	contentType := r.Header.Get("Content-Type")
+	return r.Method == http.MethodPost &&
+		(contentType == grpcWebContentType || strings.HasPrefix(contentType, grpcWebProtoContentType))
+}
+
+// corsMiddleware wraps a handler. It answers preflight requests and adds CORS
+// headers, including those gRPC-web clients need, to the responses of
+// cross-origin requests.
+func (g *Gateway) corsMiddleware(handler http.Handler) http.Handler {
+	allowedHeaders := append([]string(nil), g.cors.AllowedHeaders...)
+	allowedHeaders = append(allowedHeaders, "Content-Type", "Authorization", "X-Grpc-Web", "X-User-Agent", "Grpc-Timeout")
+	options := cors.Options{
+		AllowedOrigins: g.cors.AllowedOrigins,
+		AllowedMethods: []string{http.MethodPost},
+		AllowedHeaders: allowedHeaders,
+		ExposedHeaders: []string{"Grpc-Status", "Grpc-Message"},
+	}
+	if len(options.AllowedOrigins) == 0 {
+		// Otherwise, every origin would be allowed
+		options.AllowOriginFunc = func(string) bool { return false }
+	}
+	return cors.New(options).Handler(handler)
+}
+
+// serveGRPCWeb serves a gRPC-web call by passing it to the gRPC server as a
+// gRPC call. Requests and messages are framed identically, but gRPC-web sends
+// trailers in the response body, as HTTP/1.1 doesn't support them.
+func (g *Gateway) serveGRPCWeb(w http.ResponseWriter, r *http.Request) {
+	if !isGRPCWeb(r) {
+		http.Error(w, "expected a gRPC-web request", http.StatusUnsupportedMediaType)
+		return
+	}
+	r = r.Clone(r.Context())
+	r.ProtoMajor = 2
+	r.ProtoMinor = 0
+	r.Header.Set("Content-Type", grpcProtoContentType)
+
+	webWriter := &webResponseWriter{
+		writer: w,
+		header: make(http.Header),
+	}
+	g.server.ServeHTTP(webWriter, r)
+	webWriter.finish()
+}
+
+// webResponseWriter converts the response to a gRPC call into the response
+// to a gRPC-web call
+type webResponseWriter struct {
+	writer http.ResponseWriter
+	// Headers set by the gRPC server. Trailers are set after the headers are
+	// written.
+	header      http.Header
+	wroteHeader bool
+	// Names of the trailers declared before the headers were written
+	trailers []string
+}
+
+func (w *webResponseWriter) Header() http.Header { return w.header }
+
+func (w *webResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	header := w.writer.Header()
+	for key, values := range w.header {
+		switch {
+		case key == "Trailer":
+			w.trailers = append(w.trailers, values...)
+		case strings.HasPrefix(key, http.TrailerPrefix):
+		default:
+			header[key] = values
+		}
+	}
+	header.Set("Content-Type", grpcWebProtoContentType)
+	w.writer.WriteHeader(status)
+}
+
+func (w *webResponseWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.writer.Write(b)
+}
+
+func (w *webResponseWriter) Flush() {
+	w.WriteHeader(http.StatusOK)
+	if flusher, ok := w.writer.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// finish writes the trailers set by the gRPC server in a trailer frame
+func (w *webResponseWriter) finish() {
+	w.WriteHeader(http.StatusOK)
+
+	trailers := strings.Builder{}
+	for _, key := range w.trailers {
+		for _, value := range w.header[http.CanonicalHeaderKey(key)] {
+			trailers.WriteString(strings.ToLower(key) + ": " + value + "\r\n")
+		}
+	}
+	for key, values := range w.header {
+		if !strings.HasPrefix(key, http.TrailerPrefix) {
+			continue
+		}
+		key = strings.ToLower(strings.TrimPrefix(key, http.TrailerPrefix))
+		for _, value := range values {
+			trailers.WriteString(key + ": " + value + "\r\n")
+		}
+	}
+
+	frame := make([]byte, 5, 5+trailers.Len())
+	frame[0] = trailerFrameFlag
+	binary.BigEndian.PutUint32(frame[1:], uint32(trailers.Len()))
+	frame = append(frame, trailers.String()...)
+	// Doesn't matter if there's an error while writing. The client will fail
+	// to read the status.
+	_, _ = w.writer.Write(frame)
+}
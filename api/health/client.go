@@ -0,0 +1,112 @@
+// (c) 2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var (
+	errInvalidBackoff = errors.New("initial delay must be positive and no greater than the max delay")
+)
+
+// Client for the Avalanche Health API Endpoint
+type Client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a Client for interacting with the Health API Endpoint
+func NewClient(uri string, requestTimeout time.Duration, options ...rpc.Option) *Client {
+	return &Client{
+		requester: rpc.NewEndpointRequester(uri, "/ext/health", "health", requestTimeout, options...),
+	}
+}
+
+// GetLiveness returns the most recent result of each of the node's health
+// checks
+func (c *Client) GetLiveness() (*GetLivenessReply, error) {
+	return c.getLiveness(context.Background())
+}
+
+// getLiveness is like GetLiveness, but the request is cancelled once [ctx] is
+// done
+func (c *Client) getLiveness(ctx context.Context) (*GetLivenessReply, error) {
+	res := &GetLivenessReply{}
+	err := c.requester.SendRequestContext(ctx, "getLiveness", &GetLivenessArgs{}, res)
+	return res, err
+}
+
+// IsHealthy returns true if all of the node's health checks are passing
+func (c *Client) IsHealthy() (bool, error) {
+	res, err := c.GetLiveness()
+	if err != nil {
+		return false, err
+	}
+	return res.Healthy, nil
+}
+
+// GetCheck returns the most recent result of the health check named [name]
+func (c *Client) GetCheck(name string) (CheckResult, error) {
+	res, err := c.GetLiveness()
+	if err != nil {
+		return CheckResult{}, err
+	}
+	result, ok := res.Checks[name]
+	if !ok {
+		return CheckResult{}, fmt.Errorf("no health check named %q", name)
+	}
+	return result, nil
+}
+
+// AwaitHealthy polls the node until it's healthy or [ctx] is done. The delay
+// between polls starts at [initialDelay] and grows exponentially, with jitter,
+// up to [maxDelay]. If the node never becomes healthy, the last health report
+// received, if any, is returned along with an error naming the failing checks.
+func (c *Client) AwaitHealthy(ctx context.Context, initialDelay, maxDelay time.Duration) (*GetLivenessReply, error) {
+	if initialDelay <= 0 || maxDelay < initialDelay {
+		return nil, errInvalidBackoff
+	}
+
+	var (
+		lastReply *GetLivenessReply
+		lastErr   error
+		delay     = initialDelay
+	)
+	for {
+		reply, err := c.getLiveness(ctx)
+		if err == nil && reply.Healthy {
+			return reply, nil
+		}
+		if err == nil {
+			lastReply = reply
+		}
+		lastErr = err
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if lastErr != nil {
+				return lastReply, fmt.Errorf("%w while awaiting health, last request failed with: %s", ctx.Err(), lastErr)
+			}
+			return lastReply, fmt.Errorf("%w while awaiting health, failing checks: %v", ctx.Err(), lastReply.Failing())
+		case <-timer.C:
+		}
+
+		// Jitter spreads out the polls of clients started at the same time.
+		// This doesn't require cryptographically secure random number
+		// generation.
+		delay = time.Duration(float64(delay) * (1 + rand.Float64())) // #nosec G404
+		if delay > maxDelay {
+			// set the delay to [.75, 1) * maxDelay
+			delay = time.Duration(float64(maxDelay) * (3 + rand.Float64()) / 4) // #nosec G404
+		}
+	}
+}
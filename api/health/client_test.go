@@ -0,0 +1,101 @@
+// (c) 2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newLivenessServer returns a server that reports the node as healthy once it
+// has been polled [unhealthyPolls] times
+func newLivenessServer(unhealthyPolls int32) (*httptest.Server, *int32) {
+	polls := new(int32)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		healthy := atomic.AddInt32(polls, 1) > unhealthyPolls
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":0,"result":{"checks":{"router":{"healthy":%t}},"healthy":%t}}`, healthy, healthy)
+	}))
+	return server, polls
+}
+
+func TestAwaitHealthy(t *testing.T) {
+	server, polls := newLivenessServer(2)
+	defer server.Close()
+
+	client := NewClient(server.URL, time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reply, err := client.AwaitHealthy(ctx, time.Millisecond, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reply.Healthy {
+		t.Fatal("reply should be healthy")
+	}
+	if numPolls := atomic.LoadInt32(polls); numPolls != 3 {
+		t.Fatalf("expected 3 polls but got %d", numPolls)
+	}
+}
+
+func TestAwaitHealthyTimeout(t *testing.T) {
+	server, _ := newLivenessServer(1 << 30)
+	defer server.Close()
+
+	client := NewClient(server.URL, time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	reply, err := client.AwaitHealthy(ctx, time.Millisecond, 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded but got %v", err)
+	}
+	if reply == nil {
+		t.Fatal("should have returned the last health report")
+	}
+	if failing := reply.Failing(); len(failing) != 1 || failing[0] != "router" {
+		t.Fatalf("wrong failing checks %v", failing)
+	}
+}
+
+func TestAwaitHealthyCancelsHungRequest(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL, time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.AwaitHealthy(ctx, time.Millisecond, 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded but got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("hung request should have been cancelled with the context, but took %s", elapsed)
+	}
+}
+
+func TestAwaitHealthyInvalidBackoff(t *testing.T) {
+	client := NewClient("http://localhost", time.Second)
+	if _, err := client.AwaitHealthy(context.Background(), 0, time.Second); err == nil {
+		t.Fatal("should have errored due to a non-positive initial delay")
+	}
+	if _, err := client.AwaitHealthy(context.Background(), time.Second, time.Millisecond); err == nil {
+		t.Fatal("should have errored due to a max delay less than the initial delay")
+	}
+}
@@ -0,0 +1,216 @@
+// (c) 2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os/exec"
+	"sync"
+	"time"
+
+	health "github.com/AppsFlyer/go-sundheit"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+const (
+	// DefaultExternalCheckTimeout is the default amount of time an external
+	// check may run before it fails
+	DefaultExternalCheckTimeout = 10 * time.Second
+
+	// maxOutputLen is the max number of bytes of a command's output, or an
+	// HTTP response's body, reported by an external check
+	maxOutputLen = 256
+)
+
+var (
+	errNoCheckName        = errors.New("external health check must have a name")
+	errNoCheckTarget      = errors.New("external health check must specify exactly one of a command or a URL")
+	errNegativeThreshold  = errors.New("external health check failure threshold can't be negative")
+	errNonPositivePeriod  = errors.New("external health check period must be positive")
+	errNonPositiveTimeout = errors.New("external health check timeout must be positive")
+)
+
+// ExternalCheckConfig describes a health check that executes a command or
+// probes an HTTP URL
+type ExternalCheckConfig struct {
+	// Name of the check in the health API's results
+	Name string `json:"name"`
+	// Command, followed by its arguments, that passes if it exits with status 0
+	Command []string `json:"command"`
+	// URL that passes if a GET request to it returns a 2xx status
+	URL string `json:"url"`
+	// Timeout of each execution, such as "5s". Defaults to
+	// [DefaultExternalCheckTimeout].
+	Timeout string `json:"timeout"`
+	// Period between executions, such as "30s". Defaults to the period of the
+	// node's other health checks.
+	Period string `json:"period"`
+	// FailureThreshold is the number of consecutive failed executions before
+	// the check is reported as unhealthy. Defaults to 1.
+	FailureThreshold int `json:"failureThreshold"`
+}
+
+// ParseExternalCheckConfigs parses a JSON list of external health checks
+func ParseExternalCheckConfigs(configBytes []byte) ([]ExternalCheckConfig, error) {
+	configs := []ExternalCheckConfig(nil)
+	if err := json.Unmarshal(configBytes, &configs); err != nil {
+		return nil, err
+	}
+	for _, config := range configs {
+		if _, err := config.parse(); err != nil {
+			return nil, fmt.Errorf("invalid health check %q: %w", config.Name, err)
+		}
+	}
+	return configs, nil
+}
+
+// parse returns the external check described by this config
+func (c ExternalCheckConfig) parse() (*externalCheck, error) {
+	check := &externalCheck{
+		name:             c.Name,
+		command:          c.Command,
+		url:              c.URL,
+		timeout:          DefaultExternalCheckTimeout,
+		period:           constants.DefaultHealthCheckExecutionPeriod,
+		failureThreshold: c.FailureThreshold,
+	}
+	switch {
+	case c.Name == "":
+		return nil, errNoCheckName
+	case (len(c.Command) == 0) == (c.URL == ""):
+		return nil, errNoCheckTarget
+	case c.FailureThreshold < 0:
+		return nil, errNegativeThreshold
+	case c.FailureThreshold == 0:
+		check.failureThreshold = 1
+	}
+	if c.Timeout != "" {
+		timeout, err := time.ParseDuration(c.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		if timeout <= 0 {
+			return nil, errNonPositiveTimeout
+		}
+		check.timeout = timeout
+	}
+	if c.Period != "" {
+		period, err := time.ParseDuration(c.Period)
+		if err != nil {
+			return nil, err
+		}
+		if period <= 0 {
+			return nil, errNonPositivePeriod
+		}
+		check.period = period
+	}
+	return check, nil
+}
+
+// RegisterExternalCheck adds a check that executes the command or probes the
+// URL described by [config]
+func (h *Health) RegisterExternalCheck(config ExternalCheckConfig) error {
+	check, err := config.parse()
+	if err != nil {
+		return err
+	}
+	return h.health.RegisterCheck(&health.Config{
+		InitialDelay:    h.initialDelay,
+		ExecutionPeriod: check.period,
+		Check:           check,
+	})
+}
+
+// externalCheck is a check that executes a command or probes a URL. It only
+// fails once [failureThreshold] consecutive executions have failed.
+type externalCheck struct {
+	name             string
+	command          []string
+	url              string
+	timeout          time.Duration
+	period           time.Duration
+	failureThreshold int
+
+	lock                sync.Mutex
+	consecutiveFailures int
+}
+
+// Name implements the checks.Check interface
+func (c *externalCheck) Name() string { return c.name }
+
+// Execute implements the checks.Check interface
+func (c *externalCheck) Execute() (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var (
+		details = map[string]interface{}{}
+		err     error
+	)
+	if len(c.command) != 0 {
+		err = c.runCommand(ctx, details)
+	} else {
+		err = c.probeURL(ctx, details)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err == nil {
+		c.consecutiveFailures = 0
+		return details, nil
+	}
+
+	c.consecutiveFailures++
+	details["consecutiveFailures"] = c.consecutiveFailures
+	details["lastError"] = err.Error()
+	if c.consecutiveFailures < c.failureThreshold {
+		return details, nil
+	}
+	return details, err
+}
+
+func (c *externalCheck) runCommand(ctx context.Context, details map[string]interface{}) error {
+	output, err := exec.CommandContext(ctx, c.command[0], c.command[1:]...).CombinedOutput()
+	details["output"] = truncate(output)
+	if ctx.Err() != nil {
+		return fmt.Errorf("command timed out after %s", c.timeout)
+	}
+	return err
+}
+
+func (c *externalCheck) probeURL(ctx context.Context, details map[string]interface{}) error {
+	request, err := http.NewRequest(http.MethodGet, c.url, nil)
+	if err != nil {
+		return err
+	}
+	response, err := http.DefaultClient.Do(request.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, _ := ioutil.ReadAll(io.LimitReader(response.Body, maxOutputLen))
+	details["status"] = response.StatusCode
+	details["output"] = truncate(body)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("probe returned status %d", response.StatusCode)
+	}
+	return nil
+}
+
+// truncate returns [output] as a string of at most [maxOutputLen] bytes
+func truncate(output []byte) string {
+	if len(output) > maxOutputLen {
+		output = output[:maxOutputLen]
+	}
+	return string(output)
+}
@@ -0,0 +1,69 @@
+// (c) 2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExternalCheckFailureThreshold(t *testing.T) {
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	check, err := ExternalCheckConfig{
+		Name:             "indexer",
+		URL:              server.URL,
+		FailureThreshold: 2,
+	}.parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := check.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	healthy = false
+	if _, err := check.Execute(); err != nil {
+		t.Fatalf("check shouldn't fail before reaching the failure threshold: %s", err)
+	}
+	if _, err := check.Execute(); err == nil {
+		t.Fatalf("check should fail after reaching the failure threshold")
+	}
+
+	healthy = true
+	if _, err := check.Execute(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseExternalCheckConfigs(t *testing.T) {
+	configs, err := ParseExternalCheckConfigs([]byte(`[{"name":"disk","command":["true"],"timeout":"1s","period":"30s"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 1 || configs[0].Name != "disk" {
+		t.Fatalf("unexpected configs %v", configs)
+	}
+
+	invalid := []string{
+		`[{"command":["true"]}]`,
+		`[{"name":"none"}]`,
+		`[{"name":"both","command":["true"],"url":"http://localhost"}]`,
+		`[{"name":"timeout","command":["true"],"timeout":"-1s"}]`,
+		`[{"name":"threshold","command":["true"],"failureThreshold":-1}]`,
+	}
+	for _, config := range invalid {
+		if _, err := ParseExternalCheckConfigs([]byte(config)); err == nil {
+			t.Fatalf("should have failed to parse %s", config)
+		}
+	}
+}
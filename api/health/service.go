@@ -5,11 +5,11 @@ package health
 
 import (
 	"net/http"
+	"sort"
 	"time"
 
 	health "github.com/AppsFlyer/go-sundheit"
 	"github.com/AppsFlyer/go-sundheit/checks"
-	"github.com/gorilla/rpc/v2"
 
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -23,16 +23,22 @@ type Health struct {
 	log logging.Logger
 	// performs the underlying health checks
 	health health.Health
+	// how long checks wait after they're registered before they're first run
+	initialDelay time.Duration
 }
 
 // NewService creates a new Health service
 func NewService(log logging.Logger) *Health {
-	return &Health{log, health.New()}
+	return &Health{
+		log:          log,
+		health:       health.New(),
+		initialDelay: constants.DefaultHealthCheckInitialDelay,
+	}
 }
 
 // Handler returns an HTTPHandler providing RPC access to the Health service
 func (h *Health) Handler() (*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer := json.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -78,10 +84,16 @@ func (h *Health) RegisterMonotonicCheckFunc(name string, checkFn func() (interfa
 	return h.RegisterCheck(check)
 }
 
+// RegisterHealthCheck adds a check with default options named [name] that
+// calls [checkFn] to evaluate health
+func (h *Health) RegisterHealthCheck(name string, checkFn func() (interface{}, error)) error {
+	return h.RegisterCheck(NewCheck(name, checkFn))
+}
+
 // RegisterCheck adds the given Check
 func (h *Health) RegisterCheck(c checks.Check) error {
 	return h.health.RegisterCheck(&health.Config{
-		InitialDelay:    constants.DefaultHealthCheckInitialDelay,
+		InitialDelay:    h.initialDelay,
 		ExecutionPeriod: constants.DefaultHealthCheckExecutionPeriod,
 		Check:           c,
 	})
@@ -90,15 +102,69 @@ func (h *Health) RegisterCheck(c checks.Check) error {
 // GetLivenessArgs are the arguments for GetLiveness
 type GetLivenessArgs struct{}
 
+// CheckResult is the most recent result of a health check
+type CheckResult struct {
+	// Healthy is true if the check passed
+	Healthy bool `json:"healthy"`
+	// Message is the error the check failed with. Empty if the check passed.
+	Message string `json:"message,omitempty"`
+	// Details are additional information reported by the check
+	Details interface{} `json:"details,omitempty"`
+	// Timestamp is when the check was last run
+	Timestamp time.Time `json:"timestamp"`
+	// Duration is how long the check took to run
+	Duration time.Duration `json:"duration"`
+	// ContiguousFailures is the number of times in a row the check has failed
+	ContiguousFailures int64 `json:"contiguousFailures"`
+	// TimeOfFirstFailure is when the check started failing. Nil if the check
+	// passed.
+	TimeOfFirstFailure *time.Time `json:"timeOfFirstFailure,omitempty"`
+}
+
+// newCheckResult converts [result] into a CheckResult
+func newCheckResult(result health.Result) CheckResult {
+	checkResult := CheckResult{
+		Healthy:            result.IsHealthy(),
+		Details:            result.Details,
+		Timestamp:          result.Timestamp,
+		Duration:           result.Duration,
+		ContiguousFailures: result.ContiguousFailures,
+		TimeOfFirstFailure: result.TimeOfFirstFailure,
+	}
+	if result.Error != nil {
+		checkResult.Message = result.Error.Error()
+	}
+	return checkResult
+}
+
 // GetLivenessReply is the response for GetLiveness
 type GetLivenessReply struct {
-	Checks  map[string]health.Result `json:"checks"`
-	Healthy bool                     `json:"healthy"`
+	// Checks maps the name of each registered health check to its most recent
+	// result
+	Checks  map[string]CheckResult `json:"checks"`
+	Healthy bool                   `json:"healthy"`
+}
+
+// Failing returns the names, sorted, of the checks that are failing
+func (r *GetLivenessReply) Failing() []string {
+	failing := []string(nil)
+	for name, result := range r.Checks {
+		if !result.Healthy {
+			failing = append(failing, name)
+		}
+	}
+	sort.Strings(failing)
+	return failing
 }
 
 // GetLiveness returns a summation of the health of the node
 func (h *Health) GetLiveness(_ *http.Request, _ *GetLivenessArgs, reply *GetLivenessReply) error {
 	h.log.Info("Health: GetLiveness called")
-	reply.Checks, reply.Healthy = h.health.Results()
+	results, healthy := h.health.Results()
+	reply.Checks = make(map[string]CheckResult, len(results))
+	for name, result := range results {
+		reply.Checks[name] = newCheckResult(result)
+	}
+	reply.Healthy = healthy
 	return nil
 }
@@ -0,0 +1,93 @@
+// (c) 2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	health "github.com/AppsFlyer/go-sundheit"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestNewCheckResult(t *testing.T) {
+	now := time.Now()
+	result := newCheckResult(health.Result{
+		Details:            "details",
+		Error:              errors.New("check failed"),
+		Timestamp:          now,
+		Duration:           time.Second,
+		ContiguousFailures: 2,
+		TimeOfFirstFailure: &now,
+	})
+	if result.Healthy {
+		t.Fatal("failing check should be unhealthy")
+	}
+	if result.Message != "check failed" {
+		t.Fatalf("wrong message %q", result.Message)
+	}
+	if result.Details != "details" || !result.Timestamp.Equal(now) || result.Duration != time.Second || result.ContiguousFailures != 2 {
+		t.Fatalf("wrong result %+v", result)
+	}
+
+	result = newCheckResult(health.Result{Timestamp: now})
+	if !result.Healthy || result.Message != "" {
+		t.Fatalf("passing check should be healthy without a message but got %+v", result)
+	}
+}
+
+func TestGetLivenessReplyFailing(t *testing.T) {
+	reply := GetLivenessReply{
+		Checks: map[string]CheckResult{
+			"router":   {Healthy: false},
+			"database": {Healthy: true},
+			"X":        {Healthy: false},
+		},
+	}
+	failing := reply.Failing()
+	if len(failing) != 2 || failing[0] != "X" || failing[1] != "router" {
+		t.Fatalf("wrong failing checks %v", failing)
+	}
+}
+
+func TestRegisterHealthCheck(t *testing.T) {
+	h := NewService(logging.NoLog{})
+	h.initialDelay = 0
+
+	if err := h.RegisterHealthCheck("passing", func() (interface{}, error) { return "details", nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.RegisterHealthCheck("failing", func() (interface{}, error) { return nil, errors.New("check failed") }); err != nil {
+		t.Fatal(err)
+	}
+
+	// wait for both checks to run
+	reply := GetLivenessReply{}
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if err := h.GetLiveness(nil, nil, &reply); err != nil {
+			t.Fatal(err)
+		}
+		if reply.Checks["passing"].Healthy && reply.Checks["failing"].ContiguousFailures > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the checks didn't run: %+v", reply.Checks)
+		}
+	}
+
+	if reply.Healthy {
+		t.Fatal("the node should be unhealthy while a check fails")
+	}
+	if details := reply.Checks["passing"].Details; details != "details" {
+		t.Fatalf("wrong details %v", details)
+	}
+	if message := reply.Checks["failing"].Message; message != "check failed" {
+		t.Fatalf("wrong message %q", message)
+	}
+	if failing := reply.Failing(); len(failing) != 1 || failing[0] != "failing" {
+		t.Fatalf("wrong failing checks %v", failing)
+	}
+}
@@ -0,0 +1,153 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+// Client for the Avalanche Info API Endpoint
+type Client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a Client for interacting with the Info API Endpoint
+func NewClient(uri string, requestTimeout time.Duration, options ...rpc.Option) *Client {
+	return &Client{
+		requester: rpc.NewEndpointRequester(uri, "/ext/info", "info", requestTimeout, options...),
+	}
+}
+
+// GetNodeVersion returns the version of the node, and of its database, RPC
+// protocol and VMs
+func (c *Client) GetNodeVersion() (*GetNodeVersionReply, error) {
+	res := &GetNodeVersionReply{}
+	err := c.requester.SendRequest("getNodeVersion", struct{}{}, res)
+	return res, err
+}
+
+// GetVMs returns the VMs registered with the node
+func (c *Client) GetVMs() ([]VM, error) {
+	res := &GetVMsReply{}
+	err := c.requester.SendRequest("getVMs", struct{}{}, res)
+	return res.VMs, err
+}
+
+// GetNodeID returns the node's ID
+func (c *Client) GetNodeID() (string, error) {
+	res := &GetNodeIDReply{}
+	err := c.requester.SendRequest("getNodeID", struct{}{}, res)
+	return res.NodeID, err
+}
+
+// GetNetworkID returns the ID of the network the node is running on
+func (c *Client) GetNetworkID() (uint32, error) {
+	res := &GetNetworkIDReply{}
+	err := c.requester.SendRequest("getNetworkID", struct{}{}, res)
+	return uint32(res.NetworkID), err
+}
+
+// GetNetworkName returns the name of the network the node is running on
+func (c *Client) GetNetworkName() (string, error) {
+	res := &GetNetworkNameReply{}
+	err := c.requester.SendRequest("getNetworkName", struct{}{}, res)
+	return res.NetworkName, err
+}
+
+// GetBlockchainID returns the ID of the blockchain aliased by [alias]
+func (c *Client) GetBlockchainID(alias string) (string, error) {
+	res := &GetBlockchainIDReply{}
+	err := c.requester.SendRequest("getBlockchainID", &GetBlockchainIDArgs{
+		Alias: alias,
+	}, res)
+	return res.BlockchainID, err
+}
+
+// GetBlockchainIDs returns the ID of the blockchain aliased by each alias in
+// [aliases], fetched in a single batch
+func (c *Client) GetBlockchainIDs(aliases []string) ([]string, error) {
+	replies := make([]GetBlockchainIDReply, len(aliases))
+	calls := make([]*rpc.BatchCall, len(aliases))
+	for i, alias := range aliases {
+		calls[i] = rpc.NewBatchCall("getBlockchainID", &GetBlockchainIDArgs{Alias: alias}, &replies[i])
+	}
+	if err := c.requester.SendBatch(calls); err != nil {
+		return nil, err
+	}
+	if err := rpc.FirstErr(calls); err != nil {
+		return nil, err
+	}
+
+	blockchainIDs := make([]string, len(replies))
+	for i, reply := range replies {
+		blockchainIDs[i] = reply.BlockchainID
+	}
+	return blockchainIDs, nil
+}
+
+// Peers returns the peers the node is connected to. If [nodeIDs] is
+// non-empty, only those peers are returned. If [subnetID] is non-zero, only
+// validators of that subnet are returned.
+func (c *Client) Peers(nodeIDs []string, subnetID ids.ID) ([]Peer, error) {
+	res := &PeersReply{}
+	err := c.requester.SendRequest("peers", &PeersArgs{
+		NodeIDs:  nodeIDs,
+		SubnetID: subnetID,
+	}, res)
+	return res.Peers, err
+}
+
+// IsBootstrapped returns true if the chain [chain] is done bootstrapping
+func (c *Client) IsBootstrapped(chain string) (bool, error) {
+	res := &IsBootstrappedResponse{}
+	err := c.requester.SendRequest("isBootstrapped", &IsBootstrappedArgs{
+		Chain: chain,
+	}, res)
+	return res.IsBootstrapped, err
+}
+
+// GetBootstrapProgress returns how far along bootstrapping the chain [chain]
+// is
+func (c *Client) GetBootstrapProgress(chain string) (*GetBootstrapProgressResponse, error) {
+	res := &GetBootstrapProgressResponse{}
+	err := c.requester.SendRequest("getBootstrapProgress", &IsBootstrappedArgs{
+		Chain: chain,
+	}, res)
+	return res, err
+}
+
+// AreBootstrapped returns whether each chain the node runs is done
+// bootstrapping
+func (c *Client) AreBootstrapped() (*AreBootstrappedResponse, error) {
+	res := &AreBootstrappedResponse{}
+	err := c.requester.SendRequest("areBootstrapped", struct{}{}, res)
+	return res, err
+}
+
+// Uptime returns this node's uptime as observed by the Primary Network's
+// validators
+func (c *Client) Uptime() (*UptimeResponse, error) {
+	res := &UptimeResponse{}
+	err := c.requester.SendRequest("uptime", struct{}{}, res)
+	return res, err
+}
+
+// GetTxFee returns the transaction fees, in nAVAX, of the network the node is
+// running on
+func (c *Client) GetTxFee() (*GetTxFeeResponse, error) {
+	res := &GetTxFeeResponse{}
+	err := c.requester.SendRequest("getTxFee", struct{}{}, res)
+	return res, err
+}
+
+// GetNATStatus returns the ports the node keeps mapped on its router, and
+// whether peers have been able to connect to it
+func (c *Client) GetNATStatus() (*GetNATStatusReply, error) {
+	res := &GetNATStatusReply{}
+	err := c.requester.SendRequest("getNATStatus", struct{}{}, res)
+	return res, err
+}
@@ -0,0 +1,85 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+// PortMapping describes a port the node keeps mapped on its router
+type PortMapping struct {
+	Protocol     string      `json:"protocol"`
+	InternalPort json.Uint16 `json:"internalPort"`
+	// ExternalPort is the port the router mapped, which may differ from the
+	// port the node requested
+	ExternalPort json.Uint16 `json:"externalPort"`
+	Description  string      `json:"description"`
+	// Mapped is true if the router mapped the port the last time it was asked
+	Mapped bool `json:"mapped"`
+	// Verified is true if the router reported the mapping after mapping it
+	Verified bool `json:"verified"`
+	// LastRenewed is when the port was last mapped, if it was
+	LastRenewed *time.Time `json:"lastRenewed,omitempty"`
+	// Expiry is when the mapping expires unless it's renewed, if it expires
+	Expiry *time.Time `json:"expiry,omitempty"`
+	// Error is the error the last attempt to map or verify the port failed
+	// with, if it failed
+	Error string `json:"error,omitempty"`
+}
+
+// GetNATStatusReply are the results from calling GetNATStatus
+type GetNATStatusReply struct {
+	// Router is the protocol of the router ports are mapped on: upnp, nat-pmp
+	// or none
+	Router   string        `json:"router"`
+	Mappings []PortMapping `json:"mappings"`
+	// Reachable is true if a peer at a public IP has connected to the node,
+	// which shows that the node is reachable at its public IP
+	Reachable bool `json:"reachable"`
+	// LastInboundConnection is when a peer at a public IP last connected to
+	// the node, if one has
+	LastInboundConnection *time.Time `json:"lastInboundConnection,omitempty"`
+}
+
+// GetNATStatus returns the ports the node keeps mapped on its router for NAT
+// traversal, and whether peers have been able to connect to the node
+func (service *Info) GetNATStatus(_ *http.Request, _ *struct{}, reply *GetNATStatusReply) error {
+	service.log.Info("Info: GetNATStatus called")
+
+	reply.Router = "none"
+	reply.Mappings = []PortMapping{}
+	if service.portMapper != nil {
+		status := service.portMapper.Status()
+		reply.Router = status.Router
+		for _, mapping := range status.Mappings {
+			reply.Mappings = append(reply.Mappings, PortMapping{
+				Protocol:     mapping.Protocol,
+				InternalPort: json.Uint16(mapping.InternalPort),
+				ExternalPort: json.Uint16(mapping.ExternalPort),
+				Description:  mapping.Description,
+				Mapped:       mapping.Mapped,
+				Verified:     mapping.Verified,
+				LastRenewed:  timePtr(mapping.LastRenewed),
+				Expiry:       timePtr(mapping.Expiry),
+				Error:        mapping.Error,
+			})
+		}
+	}
+
+	lastInbound := service.networking.LastInboundConnection()
+	reply.Reachable = !lastInbound.IsZero()
+	reply.LastInboundConnection = timePtr(lastInbound)
+	return nil
+}
+
+// timePtr returns a pointer to [t], or nil if [t] is the zero time
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
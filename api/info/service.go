@@ -4,57 +4,98 @@
 package info
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
-	"github.com/gorilla/rpc/v2"
-
+	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+)
+
+const (
+	// unknownVersion is reported for VMs that don't report their version
+	unknownVersion = "unknown"
+)
+
+var (
+	errUnknownSubnet = errors.New("unknown subnet")
+	errNoValidators  = errors.New("the Primary Network has no validators")
 )
 
 // Info is the API service for unprivileged info on a node
 type Info struct {
 	version       version.Version
+	gitCommit     string
+	dbVersion     string
 	nodeID        ids.ShortID
 	networkID     uint32
 	log           logging.Logger
 	networking    network.Network
+	validators    validators.Manager
+	benchlist     benchlist.Manager
 	chainManager  chains.Manager
+	vmManager     vms.Manager
+	httpServer    *api.Server
 	creationTxFee uint64
 	txFee         uint64
+	// uptime, in [0, 1], a validator must have to be rewarded
+	minUptime float64
+	// maps ports for NAT traversal, or nil if ports aren't mapped
+	portMapper *nat.Mapper
 }
 
 // NewService returns a new admin API service
 func NewService(
 	log logging.Logger,
 	version version.Version,
+	gitCommit string,
+	dbVersion string,
 	nodeID ids.ShortID,
 	networkID uint32,
 	chainManager chains.Manager,
+	vmManager vms.Manager,
 	peers network.Network,
+	validators validators.Manager,
+	benchlist benchlist.Manager,
+	httpServer *api.Server,
 	creationTxFee uint64,
 	txFee uint64,
+	uptimeRequirement float64,
+	portMapper *nat.Mapper,
 ) (*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer := json.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	if err := newServer.RegisterService(&Info{
 		version:       version,
+		gitCommit:     gitCommit,
+		dbVersion:     dbVersion,
 		nodeID:        nodeID,
 		networkID:     networkID,
 		log:           log,
 		chainManager:  chainManager,
+		vmManager:     vmManager,
 		networking:    peers,
+		validators:    validators,
+		benchlist:     benchlist,
+		httpServer:    httpServer,
 		creationTxFee: creationTxFee,
 		txFee:         txFee,
+		minUptime:     uptimeRequirement,
+		portMapper:    portMapper,
 	}, "info"); err != nil {
 		return nil, err
 	}
@@ -64,6 +105,16 @@ func NewService(
 // GetNodeVersionReply are the results from calling GetNodeVersion
 type GetNodeVersionReply struct {
 	Version string `json:"version"`
+	// GitCommit this node was built from, if it was set at build time
+	GitCommit string `json:"gitCommit"`
+	// DatabaseVersion is the version of the database format
+	DatabaseVersion string `json:"databaseVersion"`
+	// RPCProtocolVersion is the version of the protocol used to communicate
+	// with VM plugins
+	RPCProtocolVersion json.Uint32 `json:"rpcProtocolVersion"`
+	// VMVersions maps the name of each VM to its version. VMs built into the
+	// node have the node's version.
+	VMVersions map[string]string `json:"vmVersions"`
 }
 
 // GetNodeVersion returns the version this node is running
@@ -71,9 +122,58 @@ func (service *Info) GetNodeVersion(_ *http.Request, _ *struct{}, reply *GetNode
 	service.log.Info("Info: GetNodeVersion called")
 
 	reply.Version = service.version.String()
+	reply.GitCommit = service.gitCommit
+	reply.DatabaseVersion = service.dbVersion
+	reply.RPCProtocolVersion = json.Uint32(rpcchainvm.Handshake.ProtocolVersion)
+	reply.VMVersions = make(map[string]string)
+	for _, vmID := range service.vmManager.ListVMs() {
+		name := vmID.String()
+		// Prefer a human readable alias, such as avm, to the VM's ID
+		if aliases := service.vmAliases(vmID); len(aliases) > 0 {
+			name = aliases[0]
+		}
+
+		vmVersion, err := service.vmVersion(vmID)
+		if err != nil {
+			return err
+		}
+		reply.VMVersions[name] = vmVersion
+	}
 	return nil
 }
 
+// vmAliases returns the aliases of the VM with ID [vmID], other than the
+// string representation of its ID
+func (service *Info) vmAliases(vmID ids.ID) []string {
+	idStr := vmID.String()
+	aliases := []string(nil)
+	for _, alias := range service.vmManager.Aliases(vmID) {
+		if alias != idStr {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+// vmVersion returns the version of the VM with ID [vmID]. VMs built into the
+// node have the node's version.
+func (service *Info) vmVersion(vmID ids.ID) (string, error) {
+	factory, err := service.vmManager.GetVMFactory(vmID)
+	if err != nil {
+		return "", err
+	}
+	versioner, ok := factory.(vms.Versioner)
+	if !ok {
+		return service.version.String(), nil
+	}
+	vmVersion, err := versioner.Version()
+	if err != nil {
+		service.log.Debug("couldn't get version of VM %s: %s", vmID, err)
+		return unknownVersion, nil
+	}
+	return vmVersion, nil
+}
+
 // GetNodeIDReply are the results from calling GetNodeID
 type GetNodeIDReply struct {
 	NodeID string `json:"nodeID"`
@@ -132,19 +232,83 @@ func (service *Info) GetBlockchainID(_ *http.Request, args *GetBlockchainIDArgs,
 	return err
 }
 
+// PeersArgs are the arguments for calling Peers
+type PeersArgs struct {
+	// If non-empty, only peers with these node IDs are returned
+	NodeIDs []string `json:"nodeIDs"`
+	// If set, only peers that validate this subnet are returned
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// Peer is a peer this node is connected to
+type Peer struct {
+	network.PeerID
+
+	// IDs of the chains on which the peer is benched
+	Benched []ids.ID `json:"benched"`
+
+	// Score of the peer on each chain, by chain ID, in [0, 1]. Peers whose
+	// score falls below the benchlist score threshold are benched.
+	Scores map[string]float64 `json:"scores"`
+}
+
 // PeersReply are the results from calling Peers
 type PeersReply struct {
 	// Number of elements in [Peers]
 	NumPeers json.Uint64 `json:"numPeers"`
 	// Each element is a peer
-	Peers []network.PeerID `json:"peers"`
+	Peers []Peer `json:"peers"`
 }
 
-// Peers returns the list of current validators
-func (service *Info) Peers(_ *http.Request, _ *struct{}, reply *PeersReply) error {
+// Peers returns the list of peers this node is connected to, filtered by
+// [args.NodeIDs] and [args.SubnetID]
+func (service *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error {
 	service.log.Info("Info: Peers called")
 
-	reply.Peers = service.networking.Peers()
+	nodeIDs := ids.ShortSet{}
+	for _, nodeIDStr := range args.NodeIDs {
+		nodeID, err := ids.ShortFromPrefixedString(nodeIDStr, constants.NodeIDPrefix)
+		if err != nil {
+			return fmt.Errorf("couldn't parse nodeID %q: %w", nodeIDStr, err)
+		}
+		nodeIDs.Add(nodeID)
+	}
+
+	var subnetValidators validators.Set
+	if !args.SubnetID.IsZero() {
+		vdrs, ok := service.validators.GetValidators(args.SubnetID)
+		if !ok {
+			return fmt.Errorf("%w: %s", errUnknownSubnet, args.SubnetID)
+		}
+		subnetValidators = vdrs
+	}
+
+	reply.Peers = []Peer{}
+	for _, peer := range service.networking.Peers() {
+		nodeID, err := ids.ShortFromPrefixedString(peer.ID, constants.NodeIDPrefix)
+		if err != nil {
+			return err
+		}
+		if nodeIDs.Len() != 0 && !nodeIDs.Contains(nodeID) {
+			continue
+		}
+		if subnetValidators != nil && !subnetValidators.Contains(nodeID) {
+			continue
+		}
+		benched := service.benchlist.GetBenched(nodeID)
+		if benched == nil {
+			benched = []ids.ID{}
+		}
+		scores := make(map[string]float64)
+		for chainID, score := range service.benchlist.GetScores(nodeID) {
+			scores[ids.NewID(chainID).String()] = score
+		}
+		reply.Peers = append(reply.Peers, Peer{
+			PeerID:  peer,
+			Benched: benched,
+			Scores:  scores,
+		})
+	}
 	reply.NumPeers = json.Uint64(len(reply.Peers))
 	return nil
 }
@@ -177,15 +341,262 @@ func (service *Info) IsBootstrapped(_ *http.Request, args *IsBootstrappedArgs, r
 	return nil
 }
 
-// GetTxFeeResponse ...
+// GetBootstrapProgressResponse are the results from calling
+// GetBootstrapProgress
+type GetBootstrapProgressResponse struct {
+	IsBootstrapped bool `json:"isBootstrapped"`
+	api.BootstrapProgress
+}
+
+// GetBootstrapProgress returns how far along bootstrapping [args.Chain] is, so
+// that a chain that's bootstrapping slowly can be told apart from one that's
+// stuck. Returns an error if the chain doesn't exist.
+func (service *Info) GetBootstrapProgress(_ *http.Request, args *IsBootstrappedArgs, reply *GetBootstrapProgressResponse) error {
+	service.log.Info("Info: GetBootstrapProgress called with chain: %s", args.Chain)
+	if args.Chain == "" {
+		return fmt.Errorf("argument 'chain' not given")
+	}
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return fmt.Errorf("there is no chain with alias/ID '%s'", args.Chain)
+	}
+	progress, err := service.chainManager.BootstrapProgress(chainID)
+	if err != nil {
+		return err
+	}
+	reply.IsBootstrapped = service.chainManager.IsBootstrapped(chainID)
+	reply.BootstrapProgress = api.NewBootstrapProgress(progress)
+	if reply.IsBootstrapped {
+		reply.Phase = api.DonePhase
+	}
+	return nil
+}
+
+// ChainBootstrapStatus is whether a chain is done bootstrapping
+type ChainBootstrapStatus struct {
+	ChainID ids.ID `json:"chainID"`
+	// Primary alias of the chain, if it has one
+	Alias          string `json:"alias,omitempty"`
+	IsBootstrapped bool   `json:"isBootstrapped"`
+	// How far along bootstrapping the chain is. Omitted once it's done.
+	Progress *api.BootstrapProgress `json:"progress,omitempty"`
+}
+
+// AreBootstrappedResponse are the results from calling AreBootstrapped
+type AreBootstrappedResponse struct {
+	// True iff every chain is done bootstrapping
+	AreBootstrapped bool                   `json:"areBootstrapped"`
+	Chains          []ChainBootstrapStatus `json:"chains"`
+}
+
+// AreBootstrapped returns whether each chain this node runs is done
+// bootstrapping
+func (service *Info) AreBootstrapped(_ *http.Request, _ *struct{}, reply *AreBootstrappedResponse) error {
+	service.log.Info("Info: AreBootstrapped called")
+
+	chainIDs := service.chainManager.Chains()
+	reply.AreBootstrapped = true
+	reply.Chains = make([]ChainBootstrapStatus, len(chainIDs))
+	for i, chainID := range chainIDs {
+		status := ChainBootstrapStatus{
+			ChainID:        chainID,
+			IsBootstrapped: service.chainManager.IsBootstrapped(chainID),
+		}
+		if aliases := service.chainManager.Aliases(chainID); len(aliases) > 0 {
+			status.Alias = aliases[0]
+		}
+		if !status.IsBootstrapped {
+			if progress, err := service.chainManager.BootstrapProgress(chainID); err == nil {
+				apiProgress := api.NewBootstrapProgress(progress)
+				status.Progress = &apiProgress
+			}
+		}
+		reply.AreBootstrapped = reply.AreBootstrapped && status.IsBootstrapped
+		reply.Chains[i] = status
+	}
+	return nil
+}
+
+// GetTxFeeResponse are the results from calling GetTxFee. All fees are in
+// nAVAX.
 type GetTxFeeResponse struct {
+	// CreationTxFee is the fee of transactions that create an asset, a subnet
+	// or a blockchain
 	CreationTxFee json.Uint64 `json:"creationTxFee"`
-	TxFee         json.Uint64 `json:"txFee"`
+	// TxFee is the fee of all other transactions
+	TxFee json.Uint64 `json:"txFee"`
+	// CreateAssetTxFee is the fee of an X-Chain transaction that creates an
+	// asset
+	CreateAssetTxFee json.Uint64 `json:"createAssetTxFee"`
+	// CreateSubnetTxFee is the fee of a P-Chain transaction that creates a
+	// subnet
+	CreateSubnetTxFee json.Uint64 `json:"createSubnetTxFee"`
+	// CreateBlockchainTxFee is the fee of a P-Chain transaction that creates a
+	// blockchain
+	CreateBlockchainTxFee json.Uint64 `json:"createBlockchainTxFee"`
 }
 
-// GetTxFee returns the transaction fee in nAVAX.
+// GetTxFee returns the transaction fees of the network this node is running on
 func (service *Info) GetTxFee(_ *http.Request, args *struct{}, reply *GetTxFeeResponse) error {
+	service.log.Info("Info: GetTxFee called")
+
 	reply.CreationTxFee = json.Uint64(service.creationTxFee)
 	reply.TxFee = json.Uint64(service.txFee)
+	reply.CreateAssetTxFee = json.Uint64(service.creationTxFee)
+	reply.CreateSubnetTxFee = json.Uint64(service.creationTxFee)
+	reply.CreateBlockchainTxFee = json.Uint64(service.creationTxFee)
+	return nil
+}
+
+// UptimeResponse are the results from calling Uptime
+type UptimeResponse struct {
+	// RewardingStakePercentage is the percentage of the Primary Network's
+	// stake, in [0, 100], held by validators that have been connected to this
+	// node for at least the required uptime
+	RewardingStakePercentage json.Float32 `json:"rewardingStakePercentage"`
+	// WeightedAveragePercentage is the average, weighted by stake, of the
+	// percentage of time, in [0, 100], each validator has been connected to
+	// this node
+	WeightedAveragePercentage json.Float32 `json:"weightedAveragePercentage"`
+}
+
+// Uptime returns the uptime of this node as observed by the Primary Network's
+// validators. As peers don't report the uptime they observe, it is estimated
+// by how long this node has been connected to each validator since it started.
+func (service *Info) Uptime(_ *http.Request, _ *struct{}, reply *UptimeResponse) error {
+	service.log.Info("Info: Uptime called")
+
+	vdrs, ok := service.validators.GetValidators(constants.PrimaryNetworkID)
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownSubnet, constants.PrimaryNetworkID)
+	}
+
+	// Node ID --> portion of time, in [0, 1], the peer has been connected
+	observedUptimes := make(map[[20]byte]float64)
+	for _, peer := range service.networking.Peers() {
+		nodeID, err := ids.ShortFromPrefixedString(peer.ID, constants.NodeIDPrefix)
+		if err != nil {
+			return err
+		}
+		observedUptimes[nodeID.Key()] = float64(peer.ObservedUptime)
+	}
+
+	totalWeight := float64(0)
+	rewardingWeight := float64(0)
+	weightedUptime := float64(0)
+	for _, vdr := range vdrs.List() {
+		weight := float64(vdr.Weight())
+		uptime := observedUptimes[vdr.ID().Key()]
+		if vdr.ID().Equals(service.nodeID) {
+			uptime = 1
+		}
+
+		totalWeight += weight
+		weightedUptime += weight * uptime
+		if uptime >= service.minUptime {
+			rewardingWeight += weight
+		}
+	}
+	if totalWeight == 0 {
+		return errNoValidators
+	}
+
+	reply.RewardingStakePercentage = json.Float32(100 * rewardingWeight / totalWeight)
+	reply.WeightedAveragePercentage = json.Float32(100 * weightedUptime / totalWeight)
+	return nil
+}
+
+// APIRoute describes an API endpoint served by this node
+type APIRoute struct {
+	// URL of the endpoint, relative to the node's HTTP address
+	URL string `json:"url"`
+	// Aliases of the URL
+	Aliases []string `json:"aliases"`
+	// BlockchainID of the chain serving the endpoint, if any
+	BlockchainID string `json:"blockchainID,omitempty"`
+	// VMID of the VM the chain serving the endpoint is running, if any
+	VMID string `json:"vmID,omitempty"`
+	// VMAliases are the aliases of [VMID], e.g. avm
+	VMAliases []string `json:"vmAliases,omitempty"`
+	// Version of the API the endpoint serves
+	Version json.Uint32 `json:"version"`
+	// AuthRequired is true if requests to the endpoint must include an auth
+	// token
+	AuthRequired bool `json:"authRequired"`
+}
+
+// GetAPIRoutesReply are the results from calling GetAPIRoutes
+type GetAPIRoutesReply struct {
+	Routes []APIRoute `json:"routes"`
+}
+
+// GetAPIRoutes returns the API endpoints served by this node
+func (service *Info) GetAPIRoutes(_ *http.Request, _ *struct{}, reply *GetAPIRoutesReply) error {
+	service.log.Info("Info: GetAPIRoutes called")
+
+	routes := service.httpServer.Routes()
+	reply.Routes = make([]APIRoute, len(routes))
+	for i, route := range routes {
+		apiRoute := APIRoute{
+			URL:          route.URL,
+			Aliases:      route.Aliases,
+			Version:      json.Uint32(route.Version),
+			AuthRequired: route.AuthRequired,
+		}
+		if !route.ChainID.IsZero() {
+			apiRoute.BlockchainID = route.ChainID.String()
+			if vmID, err := service.chainManager.VMID(route.ChainID); err == nil {
+				apiRoute.VMID = vmID.String()
+				apiRoute.VMAliases = service.vmAliases(vmID)
+			}
+		}
+		reply.Routes[i] = apiRoute
+	}
+	return nil
+}
+
+// VM describes a VM registered with this node
+type VM struct {
+	ID string `json:"id"`
+	// Aliases of the VM, e.g. avm
+	Aliases []string `json:"aliases"`
+	// Path of the VM's plugin binary. Empty for VMs built into the node.
+	Path    string `json:"path,omitempty"`
+	Version string `json:"version"`
+}
+
+// GetVMsReply are the results from calling GetVMs
+type GetVMsReply struct {
+	VMs []VM `json:"vms"`
+}
+
+// GetVMs returns the VMs registered with this node
+func (service *Info) GetVMs(_ *http.Request, _ *struct{}, reply *GetVMsReply) error {
+	service.log.Info("Info: GetVMs called")
+
+	vmIDs := service.vmManager.ListVMs()
+	reply.VMs = make([]VM, len(vmIDs))
+	for i, vmID := range vmIDs {
+		vmVersion, err := service.vmVersion(vmID)
+		if err != nil {
+			return err
+		}
+		vm := VM{
+			ID:      vmID.String(),
+			Aliases: service.vmAliases(vmID),
+			Version: vmVersion,
+		}
+		if vm.Aliases == nil {
+			vm.Aliases = []string{}
+		}
+		factory, err := service.vmManager.GetVMFactory(vmID)
+		if err != nil {
+			return err
+		}
+		if plugin, ok := factory.(*rpcchainvm.Factory); ok {
+			vm.Path = plugin.Path
+		}
+		reply.VMs[i] = vm
+	}
 	return nil
 }
@@ -0,0 +1,153 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// testNetwork is a network connected to [peers]
+type testNetwork struct {
+	network.Network
+	peers []network.PeerID
+}
+
+func (n *testNetwork) Peers() []network.PeerID { return n.peers }
+
+// testBenchlist benches each peer in [benched] on the chains it maps to, and
+// reports the scores in [scores]
+type testBenchlist struct {
+	benchlist.Manager
+	benched map[[20]byte][]ids.ID
+	scores  map[[20]byte]map[[32]byte]float64
+}
+
+func (b *testBenchlist) GetBenched(validatorID ids.ShortID) []ids.ID {
+	return b.benched[validatorID.Key()]
+}
+
+func (b *testBenchlist) GetScores(validatorID ids.ShortID) map[[32]byte]float64 {
+	return b.scores[validatorID.Key()]
+}
+
+func TestPeers(t *testing.T) {
+	nodeID0 := ids.NewShortID([20]byte{1})
+	nodeID1 := ids.NewShortID([20]byte{2})
+	nodeID2 := ids.NewShortID([20]byte{3})
+	subnetID := ids.Empty.Prefix(1)
+	chainID := ids.Empty.Prefix(2)
+
+	peer0 := network.PeerID{IP: "127.0.0.1:9651", ID: nodeID0.PrefixedString(constants.NodeIDPrefix), Version: "avalanche/1.0.4"}
+	peer1 := network.PeerID{IP: "127.0.0.1:9652", ID: nodeID1.PrefixedString(constants.NodeIDPrefix), Version: "avalanche/1.0.3"}
+	peer2 := network.PeerID{IP: "127.0.0.1:9653", ID: nodeID2.PrefixedString(constants.NodeIDPrefix), Features: []string{"compression"}}
+
+	vdrs := validators.NewManager()
+	subnetVdrs := validators.NewSet()
+	if err := subnetVdrs.AddWeight(nodeID1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := subnetVdrs.AddWeight(nodeID2, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := vdrs.Set(subnetID, subnetVdrs); err != nil {
+		t.Fatal(err)
+	}
+
+	service := &Info{
+		log:        logging.NoLog{},
+		networking: &testNetwork{peers: []network.PeerID{peer0, peer1, peer2}},
+		validators: vdrs,
+		benchlist: &testBenchlist{
+			benched: map[[20]byte][]ids.ID{nodeID1.Key(): {chainID}},
+			scores: map[[20]byte]map[[32]byte]float64{
+				nodeID1.Key(): {chainID.Key(): 0.25},
+				nodeID2.Key(): {chainID.Key(): 1},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		args      PeersArgs
+		expected  []Peer
+		shouldErr bool
+	}{
+		{
+			name: "all peers",
+			expected: []Peer{
+				{PeerID: peer0, Benched: []ids.ID{}, Scores: map[string]float64{}},
+				{PeerID: peer1, Benched: []ids.ID{chainID}, Scores: map[string]float64{chainID.String(): 0.25}},
+				{PeerID: peer2, Benched: []ids.ID{}, Scores: map[string]float64{chainID.String(): 1}},
+			},
+		},
+		{
+			name: "by node ID",
+			args: PeersArgs{NodeIDs: []string{peer0.ID, peer2.ID}},
+			expected: []Peer{
+				{PeerID: peer0, Benched: []ids.ID{}, Scores: map[string]float64{}},
+				{PeerID: peer2, Benched: []ids.ID{}, Scores: map[string]float64{chainID.String(): 1}},
+			},
+		},
+		{
+			name:     "by unknown node ID",
+			args:     PeersArgs{NodeIDs: []string{ids.NewShortID([20]byte{4}).PrefixedString(constants.NodeIDPrefix)}},
+			expected: []Peer{},
+		},
+		{
+			name: "by subnet",
+			args: PeersArgs{SubnetID: subnetID},
+			expected: []Peer{
+				{PeerID: peer1, Benched: []ids.ID{chainID}, Scores: map[string]float64{chainID.String(): 0.25}},
+				{PeerID: peer2, Benched: []ids.ID{}, Scores: map[string]float64{chainID.String(): 1}},
+			},
+		},
+		{
+			name: "by node ID and subnet",
+			args: PeersArgs{NodeIDs: []string{peer0.ID, peer1.ID}, SubnetID: subnetID},
+			expected: []Peer{
+				{PeerID: peer1, Benched: []ids.ID{chainID}, Scores: map[string]float64{chainID.String(): 0.25}},
+			},
+		},
+		{
+			name:      "by unknown subnet",
+			args:      PeersArgs{SubnetID: ids.Empty.Prefix(3)},
+			shouldErr: true,
+		},
+		{
+			name:      "by malformed node ID",
+			args:      PeersArgs{NodeIDs: []string{"not a node ID"}},
+			shouldErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reply := PeersReply{}
+			err := service.Peers(nil, &test.args, &reply)
+			if test.shouldErr {
+				if err == nil {
+					t.Fatalf("should have errored")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if int(reply.NumPeers) != len(test.expected) {
+				t.Fatalf("expected %d peers, got %d", len(test.expected), reply.NumPeers)
+			}
+			if !reflect.DeepEqual(test.expected, reply.Peers) {
+				t.Fatalf("expected peers %v, got %v", test.expected, reply.Peers)
+			}
+		})
+	}
+}
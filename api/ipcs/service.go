@@ -7,8 +7,6 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ipcs"
@@ -35,7 +33,7 @@ func NewService(log logging.Logger, chainManager chains.Manager, httpServer *api
 		ipcs: ipcs,
 	}
 
-	newServer := rpc.NewServer()
+	newServer := json.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -6,6 +6,7 @@ package keystore
 import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
 )
 
 // BlockchainKeystore ...
@@ -18,3 +19,8 @@ type BlockchainKeystore struct {
 func (bks *BlockchainKeystore) GetDatabase(username, password string) (database.Database, error) {
 	return bks.ks.GetDatabase(bks.blockchainID, username, password)
 }
+
+// NextKey implements the HDKeystore interface
+func (bks *BlockchainKeystore) NextKey(username, password string) (*crypto.PrivateKeySECP256K1R, error) {
+	return bks.ks.nextKey(bks.blockchainID, username, password)
+}
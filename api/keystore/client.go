@@ -0,0 +1,85 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+// Client for the Avalanche Keystore API Endpoint
+type Client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a Client for interacting with the Keystore API Endpoint
+func NewClient(uri string, requestTimeout time.Duration, options ...rpc.Option) *Client {
+	return &Client{
+		requester: rpc.NewEndpointRequester(uri, "/ext/keystore", "keystore", requestTimeout, options...),
+	}
+}
+
+// CreateUser creates the user [user]
+func (c *Client) CreateUser(user api.UserPass) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("createUser", &user, res)
+	return res.Success, err
+}
+
+// CreateUserFromMnemonic creates the user [user], whose keys are derived from
+// the 24 word mnemonic [mnemonic]
+func (c *Client) CreateUserFromMnemonic(user api.UserPass, mnemonic string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("createUserFromMnemonic", &CreateUserFromMnemonicArgs{
+		UserPass: user,
+		Mnemonic: mnemonic,
+	}, res)
+	return res.Success, err
+}
+
+// ListUsers returns the names of the users in the keystore
+func (c *Client) ListUsers() ([]string, error) {
+	res := &ListUsersReply{}
+	err := c.requester.SendRequest("listUsers", struct{}{}, res)
+	return res.Users, err
+}
+
+// ExportUser returns all of [user]'s information, encrypted with their
+// password
+func (c *Client) ExportUser(user api.UserPass) ([]byte, error) {
+	res := &ExportUserReply{}
+	err := c.requester.SendRequest("exportUser", &user, res)
+	return res.User.Bytes, err
+}
+
+// ImportUser imports [account], which was returned by ExportUser, as [user]
+func (c *Client) ImportUser(user api.UserPass, account []byte) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("importUser", &ImportUserArgs{
+		UserPass: user,
+		User:     formatting.CB58{Bytes: account},
+	}, res)
+	return res.Success, err
+}
+
+// DeleteUser deletes [user] and all of their information
+func (c *Client) DeleteUser(user api.UserPass) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("deleteUser", &user, res)
+	return res.Success, err
+}
+
+// ChangePassword changes the password of [user] to [newPassword] and
+// re-encrypts all of their information under it
+func (c *Client) ChangePassword(user api.UserPass, newPassword string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("changePassword", &ChangePasswordArgs{
+		UserPass:    user,
+		NewPassword: newPassword,
+	}, res)
+	return res.Success, err
+}
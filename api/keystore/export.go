@@ -0,0 +1,72 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"crypto/rand"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const saltLen = 16
+
+// encryptedUser is the format of the users returned by ExportUser. It's a
+// serialized UserDB, encrypted with a key derived from the user's password so
+// that an exported user reveals nothing without the password.
+type encryptedUser struct {
+	Salt       [saltLen]byte `serialize:"true"`
+	Nonce      []byte        `serialize:"true"`
+	Ciphertext []byte        `serialize:"true"`
+}
+
+// exportKey returns the key that exported users are encrypted with. It uses
+// the same key derivation as password.Hash.
+func exportKey(pword string, salt [saltLen]byte) []byte {
+	return argon2.IDKey([]byte(pword), salt[:], 1, 64*1024, 4, chacha20poly1305.KeySize)
+}
+
+// encryptUser returns [userData], encrypted with [pword]
+func (ks *Keystore) encryptUser(pword string, userData *UserDB) ([]byte, error) {
+	plaintext, err := ks.codec.Marshal(userData)
+	if err != nil {
+		return nil, err
+	}
+
+	user := encryptedUser{
+		Nonce: make([]byte, chacha20poly1305.NonceSizeX),
+	}
+	if _, err := rand.Read(user.Salt[:]); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(user.Nonce); err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(exportKey(pword, user.Salt))
+	if err != nil {
+		return nil, err
+	}
+	user.Ciphertext = aead.Seal(nil, user.Nonce, plaintext, nil)
+	return ks.codec.Marshal(&user)
+}
+
+// decryptUser parses [userBytes] as a user returned by ExportUser and
+// decrypts it with [pword]
+func (ks *Keystore) decryptUser(pword string, userBytes []byte) (*UserDB, error) {
+	user := encryptedUser{}
+	if err := ks.codec.Unmarshal(userBytes, &user); err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(exportKey(pword, user.Salt))
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, user.Nonce, user.Ciphertext, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	userData := &UserDB{}
+	return userData, ks.codec.Unmarshal(plaintext, userData)
+}
@@ -0,0 +1,166 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/crypto/bip32"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+const (
+	// mnemonicLen is the number of words in the mnemonics users are created
+	// from
+	mnemonicLen = 24
+
+	// AccountPath is the derivation path of the account that the keys of
+	// users created from a mnemonic are derived from. It matches the path
+	// used by the Avalanche wallet. The key with index i is derived at
+	// [AccountPath]/0/i.
+	AccountPath = "m/44'/9000'/0'"
+)
+
+var (
+	// ErrNoMnemonic is returned when deriving a key for a user that wasn't
+	// created from a mnemonic
+	ErrNoMnemonic = errors.New("user wasn't created from a mnemonic")
+
+	errInvalidMnemonic = fmt.Errorf("mnemonic must be a valid BIP 39 mnemonic of %d words", mnemonicLen)
+
+	// hdWalletID is used in place of a blockchain ID for the database that
+	// holds the seed of a user created from a mnemonic. That database also
+	// maps each blockchain's ID to the index of the next key derived for it.
+	hdWalletID = ids.NewID(hashing.ComputeHash256Array([]byte("hd wallet")))
+	seedKey    = []byte("seed")
+)
+
+// HDKeystore is a snow.Keystore that can derive keys for users created from a
+// mnemonic
+type HDKeystore interface {
+	snow.Keystore
+
+	// NextKey returns the next key derived for the user on this blockchain,
+	// or ErrNoMnemonic if the user wasn't created from a mnemonic
+	NextKey(username, password string) (*crypto.PrivateKeySECP256K1R, error)
+}
+
+// NewKey returns a new key for [username]. If [ks] is an HDKeystore and the
+// user was created from a mnemonic, it's the user's next derived key.
+// Otherwise, it's generated randomly.
+func NewKey(ks snow.Keystore, username, password string) (*crypto.PrivateKeySECP256K1R, error) {
+	if hdks, ok := ks.(HDKeystore); ok {
+		sk, err := hdks.NextKey(username, password)
+		if err != ErrNoMnemonic {
+			return sk, err
+		}
+	}
+
+	factory := crypto.FactorySECP256K1R{}
+	sk, err := factory.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return sk.(*crypto.PrivateKeySECP256K1R), nil
+}
+
+// CreateUserFromMnemonicArgs are the arguments for CreateUserFromMnemonic
+type CreateUserFromMnemonicArgs struct {
+	api.UserPass
+	Mnemonic string `json:"mnemonic"`
+}
+
+// CreateUserFromMnemonic creates a user whose keys are derived from a 24 word
+// BIP 39 mnemonic along [AccountPath], as they are by the Avalanche wallet.
+// Each blockchain's createAddress call reveals the user's next key.
+func (ks *Keystore) CreateUserFromMnemonic(_ *http.Request, args *CreateUserFromMnemonicArgs, reply *api.SuccessResponse) error {
+	ks.log.Info("Keystore: CreateUserFromMnemonic called with %.*s", maxUserLen, args.Username)
+
+	mnemonic := strings.Join(strings.Fields(args.Mnemonic), " ")
+	if len(strings.Fields(mnemonic)) != mnemonicLen || !bip39.IsMnemonicValid(mnemonic) {
+		return errInvalidMnemonic
+	}
+	seed := bip39.NewSeed(mnemonic, "")
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	if err := ks.AddUser(args.Username, args.Password); err != nil {
+		return err
+	}
+
+	db, err := ks.getDatabase(hdWalletID, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	if err := db.Put(seedKey, seed); err != nil {
+		return fmt.Errorf("couldn't save seed: %w", err)
+	}
+
+	reply.Success = true
+	return nil
+}
+
+// nextKey returns the next key derived for [username] on the blockchain
+// [bID]
+func (ks *Keystore) nextKey(bID ids.ID, username, password string) (*crypto.PrivateKeySECP256K1R, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	db, err := ks.getDatabase(hdWalletID, username, password)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := db.Get(seedKey)
+	if err == database.ErrNotFound {
+		return nil, ErrNoMnemonic
+	} else if err != nil {
+		return nil, err
+	}
+
+	index := uint32(0)
+	indexBytes, err := db.Get(bID.Bytes())
+	switch {
+	case err == nil && len(indexBytes) == 4:
+		index = binary.BigEndian.Uint32(indexBytes)
+	case err == nil:
+		return nil, fmt.Errorf("invalid key index for blockchain %s", bID)
+	case err != database.ErrNotFound:
+		return nil, err
+	}
+	if index >= bip32.HardenedOffset {
+		return nil, fmt.Errorf("no more keys can be derived for blockchain %s", bID)
+	}
+
+	master, err := bip32.NewMaster(seed)
+	if err != nil {
+		return nil, err
+	}
+	path, err := bip32.ParsePath(fmt.Sprintf("%s/0/%d", AccountPath, index))
+	if err != nil {
+		return nil, err
+	}
+	key, err := master.Derive(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't derive key %d: %w", index, err)
+	}
+
+	indexBytes = make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBytes, index+1)
+	if err := db.Put(bID.Bytes(), indexBytes); err != nil {
+		return nil, fmt.Errorf("couldn't save key index: %w", err)
+	}
+	return key.PrivateKey(), nil
+}
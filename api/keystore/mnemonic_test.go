@@ -0,0 +1,102 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tyler-smith/go-bip39"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bip32"
+)
+
+var testMnemonic = strings.Repeat("abandon ", mnemonicLen-1) + "art"
+
+func TestCreateUserFromMnemonic(t *testing.T) {
+	ks := CreateTestKeystore()
+
+	reply := api.SuccessResponse{}
+	if err := ks.CreateUserFromMnemonic(nil, &CreateUserFromMnemonicArgs{
+		UserPass: api.UserPass{Username: "bob", Password: strongPassword},
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}, &reply); err == nil {
+		t.Fatal("should have errored due to a short mnemonic")
+	}
+
+	if err := ks.CreateUserFromMnemonic(nil, &CreateUserFromMnemonicArgs{
+		UserPass: api.UserPass{Username: "bob", Password: strongPassword},
+		Mnemonic: testMnemonic,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply.Success {
+		t.Fatal("user should have been created successfully")
+	}
+
+	master, err := bip32.NewMaster(bip39.NewSeed(testMnemonic, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bks := ks.NewBlockchainKeyStore(ids.Empty)
+	for i := 0; i < 2; i++ {
+		sk, err := bks.NextKey("bob", strongPassword)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path, err := bip32.ParsePath(fmt.Sprintf("%s/0/%d", AccountPath, i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := master.Derive(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(sk.Bytes(), expected.PrivateKey().Bytes()) {
+			t.Fatalf("key %d wasn't derived along the account path", i)
+		}
+	}
+
+	// Each blockchain derives keys starting from the first
+	otherKey, err := ks.NewBlockchainKeyStore(ids.NewID([32]byte{1})).NextKey("bob", strongPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstKey, err := master.Derive([]uint32{
+		44 + bip32.HardenedOffset,
+		9000 + bip32.HardenedOffset,
+		bip32.HardenedOffset,
+		0,
+		0,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(otherKey.Bytes(), firstKey.PrivateKey().Bytes()) {
+		t.Fatal("first key of another blockchain should be the first derived key")
+	}
+
+	if _, err := bks.NextKey("bob", "wrong password"); err == nil {
+		t.Fatal("should have errored due to an incorrect password")
+	}
+}
+
+func TestNewKeyWithoutMnemonic(t *testing.T) {
+	ks := CreateTestKeystore()
+	if err := ks.AddUser("bob", strongPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	bks := ks.NewBlockchainKeyStore(ids.Empty)
+	if _, err := bks.NextKey("bob", strongPassword); err != ErrNoMnemonic {
+		t.Fatalf("expected %s but got %v", ErrNoMnemonic, err)
+	}
+	if _, err := NewKey(bks, "bob", strongPassword); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -9,8 +9,6 @@ import (
 	"net/http"
 	"sync"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
@@ -86,7 +84,7 @@ func (ks *Keystore) Initialize(log logging.Logger, db database.Database) {
 
 // CreateHandler returns a new service object that can send requests to thisAPI.
 func (ks *Keystore) CreateHandler() (*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer := jsoncodec.NewServer()
 	codec := jsoncodec.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -155,7 +153,8 @@ type ExportUserReply struct {
 	User formatting.CB58 `json:"user"`
 }
 
-// ExportUser exports a serialized encoding of a user's information complete with encrypted database values
+// ExportUser exports all of a user's information, including the data of each
+// blockchain, as a single blob encrypted with the user's password
 func (ks *Keystore) ExportUser(_ *http.Request, args *api.UserPass, reply *ExportUserReply) error {
 	ks.log.Info("Keystore: ExportUser called for %s", args.Username)
 
@@ -186,7 +185,7 @@ func (ks *Keystore) ExportUser(_ *http.Request, args *api.UserPass, reply *Expor
 		return err
 	}
 
-	b, err := ks.codec.Marshal(&userData)
+	b, err := ks.encryptUser(args.Password, &userData)
 	if err != nil {
 		return err
 	}
@@ -200,8 +199,9 @@ type ImportUserArgs struct {
 	User formatting.CB58 `json:"user"`
 }
 
-// ImportUser imports a serialized encoding of a user's information complete with encrypted database values,
-// integrity checks the password, and adds it to the database
+// ImportUser decrypts a user exported by ExportUser, integrity checks the
+// password, and adds it to the database. Users exported, without encryption,
+// by previous versions are also accepted.
 func (ks *Keystore) ImportUser(r *http.Request, args *ImportUserArgs, reply *api.SuccessResponse) error {
 	ks.log.Info("Keystore: ImportUser called for %s", args.Username)
 
@@ -216,9 +216,12 @@ func (ks *Keystore) ImportUser(r *http.Request, args *ImportUserArgs, reply *api
 		return fmt.Errorf("user already exists: %s", args.Username)
 	}
 
-	userData := UserDB{}
-	if err := ks.codec.Unmarshal(args.User.Bytes, &userData); err != nil {
-		return err
+	userData, err := ks.decryptUser(args.Password, args.User.Bytes)
+	if err != nil {
+		userData = &UserDB{}
+		if legacyErr := ks.codec.Unmarshal(args.User.Bytes, userData); legacyErr != nil {
+			return fmt.Errorf("couldn't decrypt user %q: %w", args.Username, err)
+		}
 	}
 	if !userData.Hash.Check(args.Password) {
 		return fmt.Errorf("incorrect password for user %q", args.Username)
@@ -305,6 +308,83 @@ func (ks *Keystore) DeleteUser(_ *http.Request, args *api.UserPass, reply *api.S
 	return nil
 }
 
+// ChangePasswordArgs are the arguments for ChangePassword
+type ChangePasswordArgs struct {
+	api.UserPass
+	NewPassword string `json:"newPassword"`
+}
+
+// ChangePassword changes the password of a user and re-encrypts all of the
+// user's data under the new password. Either all of the user's data is
+// re-encrypted or none of it is.
+func (ks *Keystore) ChangePassword(_ *http.Request, args *ChangePasswordArgs, reply *api.SuccessResponse) error {
+	ks.log.Info("Keystore: ChangePassword called with %s", args.Username)
+
+	if args.Username == "" {
+		return errEmptyUsername
+	}
+	if err := password.IsValid(args.NewPassword, password.OK); err != nil {
+		return err
+	}
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	usr, err := ks.getUser(args.Username)
+	switch {
+	case err != nil || usr == nil:
+		return fmt.Errorf("user doesn't exist: %s", args.Username)
+	case !usr.Check(args.Password):
+		return fmt.Errorf("incorrect password for user %q", args.Username)
+	}
+
+	newUser := &password.Hash{}
+	if err := newUser.Set(args.NewPassword); err != nil {
+		return err
+	}
+	userBytes, err := ks.codec.Marshal(newUser)
+	if err != nil {
+		return err
+	}
+	userBatch := ks.userDB.NewBatch()
+	if err := userBatch.Put([]byte(args.Username), userBytes); err != nil {
+		return err
+	}
+
+	// All of the user's values are encrypted under the user's password, so
+	// each of them is decrypted and then encrypted under the new password.
+	userDataDB := prefixdb.New([]byte(args.Username), ks.bcDB)
+	oldDB, err := encdb.New([]byte(args.Password), userDataDB)
+	if err != nil {
+		return err
+	}
+	newDB, err := encdb.New([]byte(args.NewPassword), userDataDB)
+	if err != nil {
+		return err
+	}
+	dataBatch := newDB.NewBatch()
+
+	it := oldDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		if err := dataBatch.Put(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return fmt.Errorf("couldn't decrypt data of user %q: %w", args.Username, err)
+	}
+
+	if err := atomic.WriteAll(dataBatch, userBatch); err != nil {
+		return err
+	}
+	ks.users[args.Username] = newUser
+
+	reply.Success = true
+	return nil
+}
+
 // NewBlockchainKeyStore ...
 func (ks *Keystore) NewBlockchainKeyStore(blockchainID ids.ID) *BlockchainKeystore {
 	return &BlockchainKeystore{
@@ -320,6 +400,27 @@ func (ks *Keystore) GetDatabase(bID ids.ID, username, password string) (database
 	ks.lock.Lock()
 	defer ks.lock.Unlock()
 
+	return ks.getDatabase(bID, username, password)
+}
+
+// CheckUser returns nil if [password] is the password of the user [username]
+func (ks *Keystore) CheckUser(username, password string) error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	usr, err := ks.getUser(username)
+	switch {
+	case err != nil || usr == nil:
+		return fmt.Errorf("user doesn't exist: %s", username)
+	case !usr.Check(password):
+		return fmt.Errorf("incorrect password for user %q", username)
+	}
+	return nil
+}
+
+// getDatabase returns the database of [username] for the blockchain [bID].
+// Assumes [ks.lock] is held.
+func (ks *Keystore) getDatabase(bID ids.ID, username, password string) (database.Database, error) {
 	usr, err := ks.getUser(username)
 	if err != nil {
 		return nil, err
@@ -12,6 +12,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
 )
 
 var (
@@ -300,6 +301,75 @@ func TestServiceExportImport(t *testing.T) {
 	}
 }
 
+func TestServiceExportEncrypted(t *testing.T) {
+	ks := CreateTestKeystore()
+	if err := ks.AddUser("bob", strongPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	exportReply := ExportUserReply{}
+	if err := ks.ExportUser(nil, &api.UserPass{
+		Username: "bob",
+		Password: strongPassword,
+	}, &exportReply); err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := ks.getUser("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(exportReply.User.Bytes, user.Password[:]) {
+		t.Fatal("exported user shouldn't contain the password hash in plaintext")
+	}
+
+	if _, err := ks.decryptUser("wrong password", exportReply.User.Bytes); err == nil {
+		t.Fatal("should have errored due to an incorrect password")
+	}
+	userData, err := ks.decryptUser(strongPassword, exportReply.User.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if userData.Hash != *user {
+		t.Fatal("decrypted the wrong user")
+	}
+}
+
+func TestServiceImportUnencrypted(t *testing.T) {
+	ks := CreateTestKeystore()
+	if err := ks.AddUser("bob", strongPassword); err != nil {
+		t.Fatal(err)
+	}
+	user, err := ks.getUser("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Users were exported without encryption by previous versions
+	userBytes, err := ks.codec.Marshal(&UserDB{
+		Hash: *user,
+		Data: []KeyValuePair{{Key: []byte("hello"), Value: []byte("world")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newKS := CreateTestKeystore()
+	reply := api.SuccessResponse{}
+	if err := newKS.ImportUser(nil, &ImportUserArgs{
+		UserPass: api.UserPass{
+			Username: "bob",
+			Password: strongPassword,
+		},
+		User: formatting.CB58{Bytes: userBytes},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply.Success {
+		t.Fatalf("User should have been imported successfully")
+	}
+}
+
 func TestServiceDeleteUser(t *testing.T) {
 	testUser := "testUser"
 	password := "passwTest@fake01ord"
@@ -384,3 +454,74 @@ func TestServiceDeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceChangePassword(t *testing.T) {
+	ks := CreateTestKeystore()
+	username := "bob"
+	newPassword := strongPassword + "new"
+
+	if err := ks.CreateUser(nil, &api.UserPass{Username: username, Password: strongPassword}, &api.SuccessResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	bID := ids.NewID([32]byte{1})
+	db, err := ks.GetDatabase(bID, username, strongPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put([]byte("hello"), []byte("world")); err != nil {
+		t.Fatal(err)
+	}
+
+	reply := api.SuccessResponse{}
+	if err := ks.ChangePassword(nil, &ChangePasswordArgs{
+		UserPass:    api.UserPass{Username: username, Password: "wrong password"},
+		NewPassword: newPassword,
+	}, &reply); err == nil {
+		t.Fatal("should have failed due to an incorrect password")
+	}
+	if err := ks.ChangePassword(nil, &ChangePasswordArgs{
+		UserPass:    api.UserPass{Username: username, Password: strongPassword},
+		NewPassword: "weak",
+	}, &reply); err == nil {
+		t.Fatal("should have failed due to a weak password")
+	}
+	if err := ks.ChangePassword(nil, &ChangePasswordArgs{
+		UserPass:    api.UserPass{Username: username, Password: strongPassword},
+		NewPassword: newPassword,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply.Success {
+		t.Fatal("should have changed the password")
+	}
+
+	if _, err := ks.GetDatabase(bID, username, strongPassword); err == nil {
+		t.Fatal("old password shouldn't be accepted")
+	}
+	db, err = ks.GetDatabase(bID, username, newPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := db.Get([]byte("hello")); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(value, []byte("world")) {
+		t.Fatalf("expected %q but got %q", "world", value)
+	}
+}
+
+func TestServiceCheckUser(t *testing.T) {
+	ks := CreateTestKeystore()
+
+	if err := ks.CheckUser("bob", strongPassword); err == nil {
+		t.Fatalf("should have failed because the user doesn't exist")
+	}
+	if err := ks.AddUser("bob", strongPassword); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.CheckUser("bob", strongPassword); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.CheckUser("bob", "wrong"); err == nil {
+		t.Fatalf("should have failed because the password is incorrect")
+	}
+}
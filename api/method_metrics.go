@@ -0,0 +1,221 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+const (
+	// maxMethodLabels is the maximum number of distinct chain and method pairs
+	// that metrics are reported for. This bounds the number of time series
+	// clients can create by calling methods that don't exist. Calls to methods
+	// beyond the limit are reported as calls to [otherMethod].
+	maxMethodLabels = 1024
+	otherMethod     = "other"
+
+	// JSON-RPC responses that report an error start with [errorResponsePrefix]
+	errorResponsePrefix = `{"jsonrpc":"2.0","error":`
+)
+
+var errHijackUnsupported = errors.New("response writer doesn't support hijacking")
+
+type methodLabels struct{ chain, method string }
+
+// methodMetrics reports the latency and failures of JSON-RPC calls, by chain
+// and method
+type methodMetrics struct {
+	lock sync.Mutex
+	// The chain and method pairs that metrics have been reported for
+	labels map[methodLabels]struct{}
+
+	latency *prometheus.HistogramVec
+	errors  *prometheus.CounterVec
+	// Requests rejected because they exceeded a rate limit, by endpoint class
+	rateLimited *prometheus.CounterVec
+}
+
+func newMethodMetrics(namespace string, registerer prometheus.Registerer) (*methodMetrics, error) {
+	m := &methodMetrics{
+		labels: make(map[methodLabels]struct{}),
+		latency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "request_duration",
+				Help:      "Latency of a JSON-RPC call in nanoseconds",
+				Buckets:   timer.NanosecondsBuckets,
+			},
+			[]string{"chain", "method"},
+		),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "request_errors",
+				Help:      "Number of JSON-RPC calls that returned an error",
+			},
+			[]string{"chain", "method"},
+		),
+		rateLimited: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "rate_limited_requests",
+				Help:      "Number of requests rejected because their IP exceeded a rate limit",
+			},
+			[]string{"class"},
+		),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.latency),
+		registerer.Register(m.errors),
+		registerer.Register(m.rateLimited),
+	)
+	if errs.Errored() {
+		return nil, fmt.Errorf("failed to register API metrics due to %w", errs.Err)
+	}
+	return m, nil
+}
+
+// observe that a call to [method] of [chain] took [duration] and failed if
+// [failed] is true
+func (m *methodMetrics) observe(chain, method string, duration time.Duration, failed bool) {
+	labels := methodLabels{chain: chain, method: method}
+
+	m.lock.Lock()
+	if _, ok := m.labels[labels]; !ok {
+		if len(m.labels) < maxMethodLabels {
+			m.labels[labels] = struct{}{}
+		} else {
+			labels.method = otherMethod
+		}
+	}
+	m.lock.Unlock()
+
+	m.latency.WithLabelValues(labels.chain, labels.method).Observe(float64(duration))
+	if failed {
+		m.errors.WithLabelValues(labels.chain, labels.method).Inc()
+	}
+}
+
+// RegisterMetrics starts reporting the latency and failures of the JSON-RPC
+// calls served by this server, and the requests it rejects due to rate limits,
+// with [registerer]
+func (s *Server) RegisterMetrics(namespace string, registerer prometheus.Registerer) error {
+	metrics, err := newMethodMetrics(namespace, registerer)
+	if err != nil {
+		return err
+	}
+
+	s.metricsLock.Lock()
+	defer s.metricsLock.Unlock()
+
+	s.metrics = metrics
+	return nil
+}
+
+func (s *Server) getMetrics() *methodMetrics {
+	s.metricsLock.RLock()
+	defer s.metricsLock.RUnlock()
+
+	return s.metrics
+}
+
+// metricsMiddleware wraps a handler. If the server reports metrics, it
+// reports the latency of each JSON-RPC call to the handler, and whether it
+// failed, labelled by [chain] and the called method. Requests that aren't
+// JSON-RPC calls aren't reported.
+func (s *Server) metricsMiddleware(handler http.Handler, chain string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		metrics := s.getMetrics()
+		if metrics == nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		methods, err := cjson.GetMethods(r)
+		if err != nil || len(methods) == 0 {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		recorder := &errorRecorder{ResponseWriter: w}
+		start := time.Now()
+		handler.ServeHTTP(recorder, r)
+		duration := time.Since(start)
+
+		failed := recorder.failed()
+		for _, method := range methods {
+			metrics.observe(chain, method, duration, failed)
+		}
+	})
+}
+
+// errorRecorder records whether a response reports an error
+type errorRecorder struct {
+	http.ResponseWriter
+	status int
+	// The first bytes of the response body
+	prefix []byte
+}
+
+func (r *errorRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *errorRecorder) Write(b []byte) (int, error) {
+	if remaining := len(errorResponsePrefix) - len(r.prefix); remaining > 0 {
+		if remaining > len(b) {
+			remaining = len(b)
+		}
+		r.prefix = append(r.prefix, b[:remaining]...)
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// statusCode returns the status of the response
+func (r *errorRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+// Hijack implements the http.Hijacker interface, so connections can be
+// upgraded, e.g. to WebSockets
+func (r *errorRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errHijackUnsupported
+	}
+	return hijacker.Hijack()
+}
+
+// Flush implements the http.Flusher interface
+func (r *errorRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// failed returns true if the response has an error status or is a JSON-RPC
+// error response. Errors of calls other than the first in a batch aren't
+// detected.
+func (r *errorRecorder) failed() bool {
+	return r.status >= http.StatusBadRequest || bytes.Equal(r.prefix, []byte(errorResponsePrefix))
+}
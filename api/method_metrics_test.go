@@ -0,0 +1,70 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	s := Server{}
+	registry := prometheus.NewRegistry()
+	if err := s.RegisterMetrics("", registry); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := s.metricsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "fail") {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32000,"message":"failed"},"id":1}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":{},"id":1}`))
+	}), "X")
+
+	calls := []struct {
+		path string
+		body string
+	}{
+		{"/ok", `{"jsonrpc":"2.0","id":1,"method":"avm.getBalance"}`},
+		{"/ok", `{"jsonrpc":"2.0","id":1,"method":"avm.getBalance"}`},
+		{"/fail", `{"jsonrpc":"2.0","id":1,"method":"avm.issueTx"}`},
+		{"/ok", ``},
+	}
+	for _, call := range calls {
+		req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650"+call.path, strings.NewReader(call.body))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if count := testutil.CollectAndCount(s.metrics.latency); count != 2 {
+		t.Fatalf("expected latency of 2 methods but got %d", count)
+	}
+	if errors := testutil.ToFloat64(s.metrics.errors.WithLabelValues("X", "avm.issueTx")); errors != 1 {
+		t.Fatalf("expected 1 error but got %f", errors)
+	}
+	if errors := testutil.ToFloat64(s.metrics.errors.WithLabelValues("X", "avm.getBalance")); errors != 0 {
+		t.Fatalf("expected no errors but got %f", errors)
+	}
+}
+
+func TestMethodMetricsLabelLimit(t *testing.T) {
+	m, err := newMethodMetrics("", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < maxMethodLabels+10; i++ {
+		m.observe("", strings.Repeat("a", i+1), 0, true)
+	}
+	if len(m.labels) != maxMethodLabels {
+		t.Fatalf("expected %d labels but got %d", maxMethodLabels, len(m.labels))
+	}
+	if errors := testutil.ToFloat64(m.errors.WithLabelValues("", otherMethod)); errors != 10 {
+		t.Fatalf("expected 10 errors of other methods but got %f", errors)
+	}
+}
@@ -0,0 +1,99 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+// Modes in which metrics can be pushed
+const (
+	// PushGatewayMode pushes metrics to a Prometheus Pushgateway
+	PushGatewayMode = "pushgateway"
+	// RemoteWriteMode pushes metrics to a Prometheus remote-write endpoint
+	RemoteWriteMode = "remote-write"
+)
+
+// PushConfig describes where and how often metrics are pushed
+type PushConfig struct {
+	// URL metrics are pushed to. If empty, metrics aren't pushed.
+	URL string
+	// Mode is either [PushGatewayMode] or [RemoteWriteMode]
+	Mode string
+	// Interval between pushes
+	Interval time.Duration
+	// Job label of the pushed metrics
+	Job string
+	// Instance label of the pushed metrics
+	Instance string
+	// Timeout of each push
+	Timeout time.Duration
+}
+
+// Pusher periodically pushes the metrics of a gatherer
+type Pusher struct {
+	log      logging.Logger
+	config   PushConfig
+	gatherer prometheus.Gatherer
+	client   *http.Client
+	repeater *timer.Repeater
+
+	// Set in PushGatewayMode
+	pushGateway *push.Pusher
+}
+
+// NewPusher returns a Pusher that pushes the metrics of [gatherer] as
+// described by [config] once dispatched
+func NewPusher(log logging.Logger, config PushConfig, gatherer prometheus.Gatherer) (*Pusher, error) {
+	if config.Interval <= 0 {
+		return nil, fmt.Errorf("metrics push interval must be positive but is %s", config.Interval)
+	}
+
+	p := &Pusher{
+		log:      log,
+		config:   config,
+		gatherer: gatherer,
+		client:   &http.Client{Timeout: config.Timeout},
+	}
+	switch config.Mode {
+	case PushGatewayMode:
+		p.pushGateway = push.New(config.URL, config.Job).
+			Gatherer(gatherer).
+			Grouping("instance", config.Instance).
+			Client(p.client)
+	case RemoteWriteMode:
+	default:
+		return nil, fmt.Errorf("unknown metrics push mode %q", config.Mode)
+	}
+	p.repeater = timer.NewRepeater(p.pushAndLog, config.Interval)
+	return p, nil
+}
+
+// Dispatch pushes metrics every interval until Stop is called
+func (p *Pusher) Dispatch() { p.repeater.Dispatch() }
+
+// Stop pushing metrics
+func (p *Pusher) Stop() { p.repeater.Stop() }
+
+// Push the current metrics
+func (p *Pusher) Push() error {
+	if p.pushGateway != nil {
+		return p.pushGateway.Push()
+	}
+	return p.remoteWrite()
+}
+
+func (p *Pusher) pushAndLog() {
+	if err := p.Push(); err != nil {
+		p.log.Warn("failed to push metrics to %s: %s", p.config.URL, err)
+	}
+}
@@ -0,0 +1,129 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestEncodeWriteRequest(t *testing.T) {
+	series := []timeSeries{{
+		labels: []label{{name: "a", value: "b"}},
+		value:  1,
+	}}
+	expected := []byte{
+		0x0a, 0x15, // TimeSeries
+		0x0a, 0x06, // Label
+		0x0a, 0x01, 'a',
+		0x12, 0x01, 'b',
+		0x12, 0x0b, // Sample
+		0x09, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0x3f, // 1.0
+		0x10, 0x01, // timestamp
+	}
+	if result := encodeWriteRequest(series, 1); !bytes.Equal(result, expected) {
+		t.Fatalf("expected %x but got %x", expected, result)
+	}
+}
+
+func TestRemoteWrite(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "requests"})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "latency", Buckets: []float64{1}})
+	registry.MustRegister(counter, histogram)
+	counter.Inc()
+	histogram.Observe(2)
+
+	received := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if encoding := r.Header.Get("Content-Encoding"); encoding != "snappy" {
+			t.Errorf("unexpected content encoding %q", encoding)
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		received <- body
+	}))
+	defer server.Close()
+
+	pusher, err := NewPusher(logging.NoLog{}, PushConfig{
+		URL:      server.URL,
+		Mode:     RemoteWriteMode,
+		Interval: time.Minute,
+		Job:      "job",
+		Instance: "instance",
+	}, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pusher.Push(); err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := snappy.Decode(nil, <-received)
+	if err != nil {
+		t.Fatal(err)
+	}
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	series := toTimeSeries(families, []label{
+		{name: "instance", value: "instance"},
+		{name: "job", value: "job"},
+	})
+	// requests, and latency's 2 buckets, sum and count
+	if len(series) != 5 {
+		t.Fatalf("expected 5 time series but got %d", len(series))
+	}
+	for _, s := range series {
+		labels := []byte(nil)
+		for _, l := range s.labels {
+			labelBytes := appendBytes(nil, 1, []byte(l.name))
+			labelBytes = appendBytes(labelBytes, 2, []byte(l.value))
+			labels = appendBytes(labels, 1, labelBytes)
+		}
+		if !bytes.Contains(body, labels) {
+			t.Fatalf("time series %v wasn't pushed", s.labels)
+		}
+	}
+}
+
+func TestRemoteWriteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	pusher, err := NewPusher(logging.NoLog{}, PushConfig{
+		URL:      server.URL,
+		Mode:     RemoteWriteMode,
+		Interval: time.Minute,
+	}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pusher.Push(); err == nil {
+		t.Fatal("should have failed to push metrics")
+	}
+}
+
+func TestNewPusherUnknownMode(t *testing.T) {
+	if _, err := NewPusher(logging.NoLog{}, PushConfig{
+		Mode:     "scrape",
+		Interval: time.Minute,
+	}, prometheus.NewRegistry()); err == nil {
+		t.Fatal("should have failed due to an unknown mode")
+	}
+}
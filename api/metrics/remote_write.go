@@ -0,0 +1,166 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang/snappy"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Protobuf wire types used by the remote-write protocol
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+type label struct{ name, value string }
+
+// timeSeries is a single sample of a metric, as sent by remote-write
+type timeSeries struct {
+	labels []label
+	value  float64
+}
+
+// remoteWrite sends the current metrics to the remote-write endpoint
+func (p *Pusher) remoteWrite() error {
+	families, err := p.gatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("couldn't gather metrics: %w", err)
+	}
+	timestamp := time.Now().UnixNano() / int64(time.Millisecond)
+	series := toTimeSeries(families, []label{
+		{name: "instance", value: p.config.Instance},
+		{name: "job", value: p.config.Job},
+	})
+	body := snappy.Encode(nil, encodeWriteRequest(series, timestamp))
+
+	req, err := http.NewRequest(http.MethodPost, p.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("remote-write endpoint responded with status %d: %s", resp.StatusCode, msg)
+	}
+	return nil
+}
+
+// toTimeSeries flattens [families] into time series, each of which has
+// [extraLabels] as well as its own labels
+func toTimeSeries(families []*dto.MetricFamily, extraLabels []label) []timeSeries {
+	series := []timeSeries(nil)
+	for _, family := range families {
+		name := family.GetName()
+		for _, metric := range family.GetMetric() {
+			labels := make([]label, 0, len(metric.GetLabel())+len(extraLabels))
+			labels = append(labels, extraLabels...)
+			for _, pair := range metric.GetLabel() {
+				labels = append(labels, label{name: pair.GetName(), value: pair.GetValue()})
+			}
+			add := func(name string, value float64, extra ...label) {
+				seriesLabels := make([]label, 0, len(labels)+len(extra)+1)
+				seriesLabels = append(seriesLabels, label{name: "__name__", value: name})
+				seriesLabels = append(seriesLabels, labels...)
+				seriesLabels = append(seriesLabels, extra...)
+				sort.Slice(seriesLabels, func(i, j int) bool { return seriesLabels[i].name < seriesLabels[j].name })
+				series = append(series, timeSeries{labels: seriesLabels, value: value})
+			}
+
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, metric.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, metric.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				add(name, metric.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				summary := metric.GetSummary()
+				for _, quantile := range summary.GetQuantile() {
+					add(name, quantile.GetValue(), label{
+						name:  "quantile",
+						value: strconv.FormatFloat(quantile.GetQuantile(), 'g', -1, 64),
+					})
+				}
+				add(name+"_sum", summary.GetSampleSum())
+				add(name+"_count", float64(summary.GetSampleCount()))
+			case dto.MetricType_HISTOGRAM:
+				histogram := metric.GetHistogram()
+				for _, bucket := range histogram.GetBucket() {
+					add(name+"_bucket", float64(bucket.GetCumulativeCount()), label{
+						name:  "le",
+						value: strconv.FormatFloat(bucket.GetUpperBound(), 'g', -1, 64),
+					})
+				}
+				add(name+"_bucket", float64(histogram.GetSampleCount()), label{name: "le", value: "+Inf"})
+				add(name+"_sum", histogram.GetSampleSum())
+				add(name+"_count", float64(histogram.GetSampleCount()))
+			}
+		}
+	}
+	return series
+}
+
+// encodeWriteRequest returns the protobuf encoding of a remote-write
+// WriteRequest holding [series], each sampled at [timestamp], in milliseconds
+func encodeWriteRequest(series []timeSeries, timestamp int64) []byte {
+	request := []byte(nil)
+	for _, s := range series {
+		ts := []byte(nil)
+		for _, l := range s.labels {
+			labelBytes := appendBytes(nil, 1, []byte(l.name))
+			labelBytes = appendBytes(labelBytes, 2, []byte(l.value))
+			ts = appendBytes(ts, 1, labelBytes)
+		}
+
+		sample := appendTag(nil, 1, wireFixed64)
+		var value [8]byte
+		binary.LittleEndian.PutUint64(value[:], math.Float64bits(s.value))
+		sample = append(sample, value[:]...)
+		sample = appendTag(sample, 2, wireVarint)
+		sample = appendVarint(sample, uint64(timestamp))
+		ts = appendBytes(ts, 2, sample)
+
+		request = appendBytes(request, 1, ts)
+	}
+	return request
+}
+
+func appendTag(b []byte, field, wireType uint64) []byte {
+	return appendVarint(b, field<<3|wireType)
+}
+
+func appendBytes(b []byte, field uint64, value []byte) []byte {
+	b = appendTag(b, field, wireBytes)
+	b = appendVarint(b, uint64(len(value)))
+	return append(b, value...)
+}
+
+func appendVarint(b []byte, value uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], value)
+	return append(b, buf[:n]...)
+}
@@ -19,5 +19,9 @@ func (mh middlewareHandler) ServeHTTP(writer http.ResponseWriter, request *http.
 	if mh.after != nil {
 		defer mh.after()
 	}
+	// The client may have given up while this request waited for the lock
+	if writeContextErr(writer, request) {
+		return
+	}
 	mh.handler.ServeHTTP(writer, request)
 }
@@ -0,0 +1,228 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+const (
+	// PublicClass is the class of endpoints that aren't in another class
+	PublicClass = "public"
+	// KeystoreClass is the class of the keystore's endpoints. Calls to the
+	// keystore are expensive, as passwords are hashed, so they can be limited
+	// separately.
+	KeystoreClass = "keystore"
+
+	keystoreURL = baseURL + "/keystore"
+
+	// rateLimitCleanupInterval is how often buckets that have refilled are
+	// dropped, so the memory used for IPs that stopped sending requests is
+	// freed
+	rateLimitCleanupInterval = time.Minute
+)
+
+var (
+	errNegativeRate  = errors.New("rate limit must not be negative")
+	errNegativeBurst = errors.New("rate limit burst must not be negative")
+)
+
+// RateLimit limits the requests each IP may send to a class of endpoints
+type RateLimit struct {
+	// Rate is the sustained number of requests per second an IP may send. If
+	// 0, requests aren't limited.
+	Rate float64
+	// Burst is the number of requests an IP may send at once. If 0, it
+	// defaults to Rate, rounded up.
+	Burst int
+}
+
+// burst returns the number of requests an IP may send at once
+func (l RateLimit) burst() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return math.Max(1, math.Ceil(l.Rate))
+}
+
+// Verify returns an error if the limit is invalid
+func (l RateLimit) Verify() error {
+	switch {
+	case l.Rate < 0:
+		return errNegativeRate
+	case l.Burst < 0:
+		return errNegativeBurst
+	default:
+		return nil
+	}
+}
+
+// RateLimitConfig limits the requests each IP may send to each class of
+// endpoints
+type RateLimitConfig struct {
+	Public   RateLimit
+	Keystore RateLimit
+}
+
+// Verify returns an error if a limit is invalid
+func (c RateLimitConfig) Verify() error {
+	if err := c.Public.Verify(); err != nil {
+		return fmt.Errorf("invalid public rate limit: %w", err)
+	}
+	if err := c.Keystore.Verify(); err != nil {
+		return fmt.Errorf("invalid keystore rate limit: %w", err)
+	}
+	return nil
+}
+
+// enabled returns true if any class of endpoints is limited
+func (c RateLimitConfig) enabled() bool {
+	return c.Public.Rate > 0 || c.Keystore.Rate > 0
+}
+
+type bucketKey struct{ class, ip string }
+
+// tokenBucket holds the requests an IP may still send to a class of endpoints
+type tokenBucket struct {
+	tokens float64
+	// Time [tokens] was last updated
+	updated time.Time
+}
+
+// rateLimiter limits requests with a token bucket per IP and endpoint class
+type rateLimiter struct {
+	lock        sync.Mutex
+	clock       timer.Clock
+	config      RateLimitConfig
+	buckets     map[bucketKey]*tokenBucket
+	lastCleanup time.Time
+}
+
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	return &rateLimiter{
+		config:  config,
+		buckets: make(map[bucketKey]*tokenBucket),
+	}
+}
+
+// limit returns the limit of [class]
+func (l *rateLimiter) limit(class string) RateLimit {
+	if class == KeystoreClass {
+		return l.config.Keystore
+	}
+	return l.config.Public
+}
+
+// allow returns true if [ip] may send a request to [class]. Otherwise, it
+// returns how long until [ip] may send one.
+func (l *rateLimiter) allow(class, ip string) (bool, time.Duration) {
+	limit := l.limit(class)
+	if limit.Rate <= 0 {
+		return true, 0
+	}
+	burst := limit.burst()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock.Time()
+	l.cleanup(now)
+
+	key := bucketKey{class: class, ip: ip}
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: burst, updated: now}
+		l.buckets[key] = bucket
+	}
+	if elapsed := now.Sub(bucket.updated); elapsed > 0 {
+		bucket.tokens = math.Min(burst, bucket.tokens+elapsed.Seconds()*limit.Rate)
+		bucket.updated = now
+	}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - bucket.tokens) / limit.Rate * float64(time.Second))
+	return false, wait
+}
+
+// cleanup drops the buckets that have refilled, as they're equivalent to new
+// buckets. Assumes [l.lock] is held.
+func (l *rateLimiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < rateLimitCleanupInterval {
+		return
+	}
+	l.lastCleanup = now
+	for key, bucket := range l.buckets {
+		limit := l.limit(key.class)
+		if bucket.tokens+now.Sub(bucket.updated).Seconds()*limit.Rate >= limit.burst() {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// SetRateLimits limits the requests each IP may send to the server according
+// to [config]. Must be called before the server is dispatched.
+func (s *Server) SetRateLimits(config RateLimitConfig) error {
+	if err := config.Verify(); err != nil {
+		return err
+	}
+	if config.enabled() {
+		s.rateLimiter = newRateLimiter(config)
+	} else {
+		s.rateLimiter = nil
+	}
+	return nil
+}
+
+// rateLimitMiddleware wraps a handler. If the server limits requests, requests
+// that exceed the limit of their IP are rejected with status 429.
+func (s *Server) rateLimitMiddleware(handler http.Handler) http.Handler {
+	limiter := s.rateLimiter
+	if limiter == nil {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		class := endpointClass(r)
+		allowed, wait := limiter.allow(class, requestIP(r))
+		if allowed {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		if metrics := s.getMetrics(); metrics != nil {
+			metrics.rateLimited.WithLabelValues(class).Inc()
+		}
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		w.WriteHeader(http.StatusTooManyRequests)
+		// Doesn't matter if there's an error while writing. They'll get the StatusTooManyRequests code.
+		_, _ = w.Write([]byte("API call rejected because the rate limit was exceeded"))
+	})
+}
+
+// endpointClass returns the class of the endpoint [r] is sent to
+func endpointClass(r *http.Request) string {
+	if r.URL.Path == keystoreURL || strings.HasPrefix(r.URL.Path, keystoreURL+"/") {
+		return KeystoreClass
+	}
+	return PublicClass
+}
+
+// requestIP returns the IP [r] was sent from
+func requestIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
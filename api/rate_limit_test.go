@@ -0,0 +1,109 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(RateLimitConfig{
+		Public:   RateLimit{Rate: 1, Burst: 2},
+		Keystore: RateLimit{Rate: 0.5},
+	})
+	now := time.Unix(1000, 0)
+	limiter.clock.Set(now)
+
+	for i := 0; i < 2; i++ {
+		if allowed, _ := limiter.allow(PublicClass, "1.2.3.4"); !allowed {
+			t.Fatalf("request %d should have been allowed", i)
+		}
+	}
+	allowed, wait := limiter.allow(PublicClass, "1.2.3.4")
+	if allowed {
+		t.Fatal("request exceeding the burst should have been rejected")
+	}
+	if wait != time.Second {
+		t.Fatalf("expected to wait %s but got %s", time.Second, wait)
+	}
+
+	// Other IPs and classes have their own buckets
+	if allowed, _ := limiter.allow(PublicClass, "5.6.7.8"); !allowed {
+		t.Fatal("request from another IP should have been allowed")
+	}
+	if allowed, _ := limiter.allow(KeystoreClass, "1.2.3.4"); !allowed {
+		t.Fatal("request to another class should have been allowed")
+	}
+	if allowed, wait := limiter.allow(KeystoreClass, "1.2.3.4"); allowed || wait != 2*time.Second {
+		t.Fatalf("expected keystore request to be rejected for %s but got %t, %s", 2*time.Second, allowed, wait)
+	}
+
+	limiter.clock.Set(now.Add(time.Second))
+	if allowed, _ := limiter.allow(PublicClass, "1.2.3.4"); !allowed {
+		t.Fatal("request should have been allowed after the bucket refilled")
+	}
+
+	// Buckets that have refilled are dropped
+	limiter.clock.Set(now.Add(rateLimitCleanupInterval + time.Second))
+	if allowed, _ := limiter.allow(PublicClass, "1.2.3.4"); !allowed {
+		t.Fatal("request should have been allowed")
+	}
+	if len(limiter.buckets) != 1 {
+		t.Fatalf("expected 1 bucket but got %d", len(limiter.buckets))
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	s := Server{}
+	if err := s.SetRateLimits(RateLimitConfig{Keystore: RateLimit{Rate: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	registry := prometheus.NewRegistry()
+	if err := s.RegisterMetrics("api", registry); err != nil {
+		t.Fatal(err)
+	}
+	handler := s.rateLimitMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	send := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		return w
+	}
+
+	if w := send("/ext/keystore"); w.Code != http.StatusOK {
+		t.Fatalf("expected status %d but got %d", http.StatusOK, w.Code)
+	}
+	w := send("/ext/keystore")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d but got %d", http.StatusTooManyRequests, w.Code)
+	}
+	if retryAfter := w.Header().Get("Retry-After"); retryAfter != "1" {
+		t.Fatalf("expected Retry-After 1 but got %q", retryAfter)
+	}
+	if count := testutil.ToFloat64(s.getMetrics().rateLimited.WithLabelValues(KeystoreClass)); count != 1 {
+		t.Fatalf("expected 1 rate limited request but got %f", count)
+	}
+
+	// Public endpoints aren't limited
+	for i := 0; i < 3; i++ {
+		if w := send("/ext/info"); w.Code != http.StatusOK {
+			t.Fatalf("expected status %d but got %d", http.StatusOK, w.Code)
+		}
+	}
+}
+
+func TestRateLimitConfigVerify(t *testing.T) {
+	if err := (RateLimitConfig{Public: RateLimit{Rate: -1}}).Verify(); err == nil {
+		t.Fatal("should have failed due to a negative rate")
+	}
+	if err := (RateLimitConfig{Keystore: RateLimit{Rate: 1, Burst: -1}}).Verify(); err == nil {
+		t.Fatal("should have failed due to a negative burst")
+	}
+}
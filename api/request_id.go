@@ -0,0 +1,81 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+const (
+	// RequestIDHeader is the header that carries the ID of an API request. If a
+	// client doesn't set it, the server generates an ID. The ID is returned in
+	// the header of the response.
+	RequestIDHeader = "X-Request-ID"
+
+	// maxRequestIDLen is the maximum length of a request ID set by a client
+	maxRequestIDLen = 128
+)
+
+type requestIDKey struct{}
+
+// RequestID returns the ID of [r]. If [r] is nil, as it is when an API method
+// is called directly, or [r] wasn't served by the API server, the empty
+// string is returned.
+func RequestID(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	id, _ := r.Context().Value(requestIDKey{}).(string)
+	return id
+}
+
+// validRequestID returns true if [id] may be used as a request ID. IDs are
+// written to logs and headers, so only short, printable IDs are accepted.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a random request ID
+func newRequestID() string {
+	var id [16]byte
+	// Read only fails if the system's source of randomness is unavailable, in
+	// which case the ID is still unique enough to be useful.
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// requestIDMiddleware wraps a handler. Each request is given the ID the client
+// set, or a new ID if it didn't set a valid one. The ID is set in the header
+// and context of the request passed to the handler, so it's passed along to
+// VMs, and in the header of the response. Failed requests are logged with
+// their ID.
+func (s *Server) requestIDMiddleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		r = r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id))
+		r.Header.Set(RequestIDHeader, id)
+		w.Header().Set(RequestIDHeader, id)
+
+		s.log.Verbo("serving API request %s to %s", id, r.URL.Path)
+		recorder := &errorRecorder{ResponseWriter: w}
+		handler.ServeHTTP(recorder, r)
+		if recorder.failed() {
+			s.log.Debug("API request %s to %s failed with status %d", id, r.URL.Path, recorder.statusCode())
+		}
+	})
+}
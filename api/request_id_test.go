@@ -0,0 +1,60 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	s := Server{log: logging.NoLog{}}
+	seenIDs := []string(nil)
+	handler := s.requestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if headerID := r.Header.Get(RequestIDHeader); headerID != RequestID(r) {
+			t.Errorf("expected header to hold ID %q but got %q", RequestID(r), headerID)
+		}
+		seenIDs = append(seenIDs, RequestID(r))
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	send := func(id string) string {
+		r := httptest.NewRequest(http.MethodPost, "/ext/info", nil)
+		if id != "" {
+			r.Header.Set(RequestIDHeader, id)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected status %d but got %d", http.StatusServiceUnavailable, w.Code)
+		}
+		return w.Header().Get(RequestIDHeader)
+	}
+
+	// The client's ID is used
+	if id := send("client-id"); id != "client-id" || seenIDs[0] != id {
+		t.Fatalf("expected ID client-id but got %q", id)
+	}
+	// IDs are generated for requests without a valid ID
+	generated := send("")
+	if len(generated) != 32 || seenIDs[1] != generated {
+		t.Fatalf("unexpected generated ID %q", generated)
+	}
+	if id := send("has spaces"); id == "has spaces" || id == generated {
+		t.Fatalf("expected a new ID but got %q", id)
+	}
+	if id := send(strings.Repeat("a", maxRequestIDLen+1)); len(id) != 32 {
+		t.Fatalf("expected a new ID but got %q", id)
+	}
+}
+
+func TestRequestIDDirectCall(t *testing.T) {
+	if id := RequestID(nil); id != "" {
+		t.Fatalf("expected no ID but got %q", id)
+	}
+}
@@ -58,6 +58,29 @@ func (r *router) GetHandler(base, endpoint string) (http.Handler, error) {
 	return handler, nil
 }
 
+// Routes returns the URL of every route that isn't an alias, mapped to the
+// aliases of that route
+func (r *router) Routes() map[string][]string {
+	r.routeLock.Lock()
+	defer r.routeLock.Unlock()
+
+	routes := make(map[string][]string)
+	for base, endpoints := range r.routes {
+		if r.reservedRoutes[base] {
+			continue
+		}
+		aliases := r.aliases[base]
+		for endpoint := range endpoints {
+			endpointAliases := make([]string, len(aliases))
+			for i, alias := range aliases {
+				endpointAliases[i] = alias + endpoint
+			}
+			routes[base+endpoint] = endpointAliases
+		}
+	}
+	return routes
+}
+
 func (r *router) AddRouter(base, endpoint string, handler http.Handler) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
@@ -0,0 +1,406 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/codec"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+const (
+	// maxNameLen is the maximum allowed length of a task's name
+	maxNameLen = 1024
+
+	// checkFrequency is how often the scheduler checks for due tasks
+	checkFrequency = time.Second
+
+	maxPackerSize  = 1 << 20 // max size, in bytes, of a marshalled task
+	maxSliceLength = 1 << 18
+
+	// keys of a keystore user's credentials in the params of a call
+	usernameKey = "username"
+	passwordKey = "password"
+)
+
+var (
+	errEmptyName       = errors.New("empty task name")
+	errNameMaxLength   = fmt.Errorf("task name exceeds maximum length of %d chars", maxNameLen)
+	errTaskExists      = errors.New("a task with that name already exists")
+	errUnknownTask     = errors.New("unknown task")
+	errInvalidEndpoint = errors.New("endpoint must be a path beginning with /ext/")
+	errEmptyMethod     = errors.New("empty method")
+	errInvalidParams   = errors.New("params must be valid JSON")
+	errClosed          = errors.New("scheduler is closed")
+	errLocked          = errors.New("the password of the task's keystore user hasn't been provided since the node started")
+)
+
+// Task is a JSON-RPC call that is issued to one of this node's APIs at a
+// scheduled time.
+type Task struct {
+	// Name uniquely identifies the task
+	Name string `serialize:"true"`
+	// Endpoint the call is made to, e.g. /ext/bc/X
+	Endpoint string `serialize:"true"`
+	// Method called, e.g. avm.send
+	Method string `serialize:"true"`
+	// Params of the call, as JSON. Never contains a keystore user's password.
+	Params []byte `serialize:"true"`
+	// Username of the keystore user whose credentials are added to the params
+	// when the task is executed. If empty, the params are used as is.
+	Username string `serialize:"true"`
+	// NextExecution is the unix time of the next execution of the task
+	NextExecution uint64 `serialize:"true"`
+	// Interval, in seconds, between executions. If 0, the task is only
+	// executed once.
+	Interval uint64 `serialize:"true"`
+	// Executions is the number of times the task has been executed
+	Executions uint64 `serialize:"true"`
+	// LastExecution is the unix time of the last execution of the task
+	LastExecution uint64 `serialize:"true"`
+	// LastResult is the JSON result of the last successful execution
+	LastResult string `serialize:"true"`
+	// LastError is the error of the last execution, if it failed
+	LastError string `serialize:"true"`
+}
+
+// Scheduler persists tasks and executes them once they are due.
+//
+// Tasks are executed against [handler] without going through token
+// authorization, so whoever schedules a task must be authorized to make the
+// call it describes.
+type Scheduler struct {
+	lock sync.Mutex
+	log  logging.Logger
+
+	codec codec.Codec
+	clock timer.Clock
+
+	// Handler the scheduled calls are dispatched to
+	handler http.Handler
+
+	// Key: task name
+	// Value: The task with that name
+	tasks map[string]*Task
+
+	// Key: keystore username
+	// Value: The user's password. Passwords are only kept in memory.
+	passwords map[string]string
+
+	// Used to persist tasks
+	db database.Database
+
+	closed   bool
+	closer   chan struct{}
+	stopped  chan struct{}
+	dispatch sync.Once
+}
+
+// New returns a new scheduler that persists tasks in [db] and issues them to
+// [handler]
+func New(log logging.Logger, db database.Database, handler http.Handler) (*Scheduler, error) {
+	s := &Scheduler{
+		log:       log,
+		codec:     codec.New(maxPackerSize, maxSliceLength),
+		handler:   handler,
+		tasks:     make(map[string]*Task),
+		passwords: make(map[string]string),
+		db:        db,
+		closer:    make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		task := &Task{}
+		if err := s.codec.Unmarshal(it.Value(), task); err != nil {
+			return nil, fmt.Errorf("couldn't parse scheduled task %q: %w", it.Key(), err)
+		}
+		s.tasks[task.Name] = task
+	}
+	return s, it.Error()
+}
+
+// Dispatch starts executing due tasks. Blocks until the scheduler is closed.
+func (s *Scheduler) Dispatch() {
+	s.dispatch.Do(func() {
+		defer close(s.stopped)
+
+		ticker := time.NewTicker(checkFrequency)
+		defer ticker.Stop()
+		for {
+			s.executeDue()
+
+			select {
+			case <-s.closer:
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// Close stops the execution of tasks. Tasks that are being executed will be
+// completed.
+func (s *Scheduler) Close() {
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return
+	}
+	s.closed = true
+	close(s.closer)
+	s.lock.Unlock()
+
+	// If Dispatch was never called, make sure Close doesn't block
+	s.dispatch.Do(func() { close(s.stopped) })
+	<-s.stopped
+}
+
+// Schedule persists [task] and executes it once it is due
+func (s *Scheduler) Schedule(task *Task) error {
+	switch {
+	case task.Name == "":
+		return errEmptyName
+	case len(task.Name) > maxNameLen:
+		return errNameMaxLength
+	case !strings.HasPrefix(task.Endpoint, "/ext/"):
+		return errInvalidEndpoint
+	case task.Method == "":
+		return errEmptyMethod
+	case !json.Valid(task.Params):
+		return errInvalidParams
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return errClosed
+	}
+	if _, exists := s.tasks[task.Name]; exists {
+		return errTaskExists
+	}
+	if err := s.put(task); err != nil {
+		return err
+	}
+	s.tasks[task.Name] = task
+	s.log.Info("Scheduler: scheduled task %q calling %s on %s at %s", task.Name, task.Method, task.Endpoint, time.Unix(int64(task.NextExecution), 0))
+	return nil
+}
+
+// Cancel removes the task named [name]
+func (s *Scheduler) Cancel(name string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, exists := s.tasks[name]; !exists {
+		return errUnknownTask
+	}
+	if err := s.db.Delete([]byte(name)); err != nil {
+		return err
+	}
+	delete(s.tasks, name)
+	s.log.Info("Scheduler: cancelled task %q", name)
+	return nil
+}
+
+// UnlockUser provides the password of the keystore user [username], which is
+// added to the params of the user's tasks when they are executed. Passwords
+// aren't persisted, so they must be provided again after the node restarts.
+func (s *Scheduler) UnlockUser(username, password string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.passwords[username] = password
+}
+
+// Tasks returns a copy of all of the scheduled tasks
+func (s *Scheduler) Tasks() []Task {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	tasks := make([]Task, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		tasks = append(tasks, *task)
+	}
+	return tasks
+}
+
+// HealthCheck fails if the most recent execution of any task failed
+func (s *Scheduler) HealthCheck() (interface{}, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	failed := map[string]string{}
+	for name, task := range s.tasks {
+		if task.LastError != "" {
+			failed[name] = task.LastError
+		}
+	}
+	if len(failed) > 0 {
+		return failed, fmt.Errorf("%d scheduled task(s) failed", len(failed))
+	}
+	return nil, nil
+}
+
+// dueTask is a task whose execution time has passed, along with the password
+// of its keystore user
+type dueTask struct {
+	task     *Task
+	password string
+}
+
+// executeDue executes all of the tasks whose execution time has passed. The
+// calls may take a while, so they're made without holding the lock.
+func (s *Scheduler) executeDue() {
+	s.lock.Lock()
+	now := s.clock.Unix()
+	due := []dueTask(nil)
+	for name, task := range s.tasks {
+		if task.NextExecution == 0 || task.NextExecution > now {
+			continue
+		}
+		password, unlocked := s.passwords[task.Username]
+		if task.Username != "" && !unlocked {
+			// The task is executed once the password is provided
+			if task.LastError != errLocked.Error() {
+				task.LastError = errLocked.Error()
+				s.log.Error("Scheduler: task %q can't be executed: %s", name, errLocked)
+			}
+			continue
+		}
+		due = append(due, dueTask{
+			task:     task,
+			password: password,
+		})
+	}
+	s.lock.Unlock()
+
+	for _, due := range due {
+		result, err := s.execute(due.task, due.password)
+		s.executed(due.task, now, result, err)
+	}
+}
+
+// executed records the outcome of the execution of [task] at [now]
+func (s *Scheduler) executed(task *Task, now uint64, result string, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	name := task.Name
+	if s.tasks[name] != task {
+		// The task was cancelled while it was being executed
+		return
+	}
+
+	task.Executions++
+	task.LastExecution = now
+	if err != nil {
+		task.LastError = err.Error()
+		s.log.Error("Scheduler: task %q failed: %s", name, err)
+	} else {
+		task.LastResult = result
+		task.LastError = ""
+		s.log.Info("Scheduler: task %q executed with result %s", name, result)
+	}
+
+	switch {
+	case task.Interval > 0:
+		// Skip any executions that were missed, e.g. while the node was
+		// offline, rather than executing them all at once
+		missed := (now - task.NextExecution) / task.Interval
+		task.NextExecution += (missed + 1) * task.Interval
+	case err == nil:
+		// A one-off task that succeeded is done
+		if err := s.db.Delete([]byte(name)); err != nil {
+			s.log.Error("Scheduler: couldn't delete task %q: %s", name, err)
+		}
+		delete(s.tasks, name)
+		return
+	default:
+		// A one-off task that failed is kept, without being executed
+		// again, so that the failure can be inspected
+		task.NextExecution = 0
+	}
+	if err := s.put(task); err != nil {
+		s.log.Error("Scheduler: couldn't persist task %q: %s", name, err)
+	}
+}
+
+// execute issues the JSON-RPC call described by [task], signed by its keystore
+// user with [password] if it has one, and returns the JSON result
+func (s *Scheduler) execute(task *Task, password string) (string, error) {
+	params := json.RawMessage(task.Params)
+	if task.Username != "" {
+		var err error
+		params, err = withCredentials(task.Params, task.Username, password)
+		if err != nil {
+			return "", fmt.Errorf("couldn't add credentials to params: %w", err)
+		}
+	}
+	body, err := json2.EncodeClientRequest(task.Method, params)
+	if err != nil {
+		return "", fmt.Errorf("couldn't encode request: %w", err)
+	}
+	request, err := http.NewRequest(http.MethodPost, task.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("couldn't create request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+	s.handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		return "", fmt.Errorf("received status code %d: %s", recorder.Code, strings.TrimSpace(recorder.Body.String()))
+	}
+
+	result := json.RawMessage{}
+	if err := json2.DecodeClientResponse(recorder.Body, &result); err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
+// withCredentials returns [params], which must be a JSON object, with the
+// credentials of a keystore user added
+func withCredentials(params []byte, username, password string) (json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(params, &fields); err != nil {
+		return nil, err
+	}
+	usernameJSON, err := json.Marshal(username)
+	if err != nil {
+		return nil, err
+	}
+	passwordJSON, err := json.Marshal(password)
+	if err != nil {
+		return nil, err
+	}
+	fields[usernameKey] = usernameJSON
+	fields[passwordKey] = passwordJSON
+	return json.Marshal(fields)
+}
+
+func (s *Scheduler) put(task *Task) error {
+	taskBytes, err := s.codec.Marshal(task)
+	if err != nil {
+		return err
+	}
+	return s.db.Put([]byte(task.Name), taskBytes)
+}
@@ -0,0 +1,356 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/rpc/v2"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+var errTestFailure = errors.New("test failure")
+
+type EchoArgs struct {
+	Message string `json:"message"`
+	Fail    bool   `json:"fail"`
+}
+
+type EchoReply struct {
+	Message string `json:"message"`
+}
+
+type UserArgs struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Message  string `json:"message"`
+}
+
+type testService struct{ calls int }
+
+func (s *testService) Echo(_ *http.Request, args *EchoArgs, reply *EchoReply) error {
+	s.calls++
+	if args.Fail {
+		return errTestFailure
+	}
+	reply.Message = args.Message
+	return nil
+}
+
+func (s *testService) User(_ *http.Request, args *UserArgs, reply *EchoReply) error {
+	s.calls++
+	if args.Password != "secret" {
+		return errTestFailure
+	}
+	reply.Message = args.Username + ":" + args.Message
+	return nil
+}
+
+func newTestHandler(t *testing.T) (http.Handler, *testService) {
+	service := &testService{}
+	server := rpc.NewServer()
+	server.RegisterCodec(cjson.NewCodec(), "application/json")
+	if err := server.RegisterService(service, "test"); err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/ext/test", server)
+	return mux, service
+}
+
+func TestSchedulerExecutesOnce(t *testing.T) {
+	handler, service := newTestHandler(t)
+	s, err := New(logging.NoLog{}, memdb.New(), handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1000, 0)
+	s.clock.Set(now)
+
+	if err := s.Schedule(&Task{
+		Name:          "once",
+		Endpoint:      "/ext/test",
+		Method:        "test.echo",
+		Params:        []byte(`{"message":"hello"}`),
+		NextExecution: 1010,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.executeDue()
+	if service.calls != 0 {
+		t.Fatalf("task shouldn't have been executed before its execution time")
+	}
+
+	s.clock.Set(now.Add(10 * time.Second))
+	s.executeDue()
+	if service.calls != 1 {
+		t.Fatalf("task should have been executed once but was executed %d times", service.calls)
+	}
+	if tasks := s.Tasks(); len(tasks) != 0 {
+		t.Fatalf("successful one-off task should have been removed")
+	}
+	if _, err := s.HealthCheck(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSchedulerRecurring(t *testing.T) {
+	handler, service := newTestHandler(t)
+	db := memdb.New()
+	s, err := New(logging.NoLog{}, db, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1000, 0)
+	s.clock.Set(now)
+
+	if err := s.Schedule(&Task{
+		Name:          "recurring",
+		Endpoint:      "/ext/test",
+		Method:        "test.echo",
+		Params:        []byte(`{"message":"hello"}`),
+		NextExecution: 1000,
+		Interval:      60,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.executeDue()
+	// Skipping several intervals only results in a single execution
+	s.clock.Set(now.Add(200 * time.Second))
+	s.executeDue()
+	if service.calls != 2 {
+		t.Fatalf("task should have been executed twice but was executed %d times", service.calls)
+	}
+
+	// Tasks should be restored from the database
+	s, err = New(logging.NoLog{}, db, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks := s.Tasks()
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task but got %d", len(tasks))
+	}
+	task := tasks[0]
+	switch {
+	case task.NextExecution != 1240:
+		t.Fatalf("expected next execution at 1240 but got %d", task.NextExecution)
+	case task.Executions != 2:
+		t.Fatalf("expected 2 executions but got %d", task.Executions)
+	case task.LastResult != `{"message":"hello"}`:
+		t.Fatalf("unexpected result %s", task.LastResult)
+	}
+}
+
+func TestSchedulerFailure(t *testing.T) {
+	handler, service := newTestHandler(t)
+	s, err := New(logging.NoLog{}, memdb.New(), handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.clock.Set(time.Unix(1000, 0))
+
+	if err := s.Schedule(&Task{
+		Name:          "failing",
+		Endpoint:      "/ext/test",
+		Method:        "test.echo",
+		Params:        []byte(`{"fail":true}`),
+		NextExecution: 1000,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.executeDue()
+	s.executeDue()
+	if service.calls != 1 {
+		t.Fatalf("failed one-off task shouldn't be retried")
+	}
+	if _, err := s.HealthCheck(); err == nil {
+		t.Fatalf("health check should fail after a task failed")
+	}
+	if err := s.Cancel("failing"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.HealthCheck(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSchedulerInvalidTasks(t *testing.T) {
+	handler, _ := newTestHandler(t)
+	s, err := New(logging.NoLog{}, memdb.New(), handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := Task{
+		Name:     "task",
+		Endpoint: "/ext/test",
+		Method:   "test.echo",
+		Params:   []byte(`{}`),
+	}
+	if err := s.Schedule(&valid); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Schedule(&valid); err != errTaskExists {
+		t.Fatalf("expected %s but got %v", errTaskExists, err)
+	}
+
+	invalidEndpoint := valid
+	invalidEndpoint.Name = "invalid endpoint"
+	invalidEndpoint.Endpoint = "http://example.com/ext/test"
+	if err := s.Schedule(&invalidEndpoint); err != errInvalidEndpoint {
+		t.Fatalf("expected %s but got %v", errInvalidEndpoint, err)
+	}
+
+	invalidParams := valid
+	invalidParams.Name = "invalid params"
+	invalidParams.Params = []byte("{")
+	if err := s.Schedule(&invalidParams); err != errInvalidParams {
+		t.Fatalf("expected %s but got %v", errInvalidParams, err)
+	}
+
+	if err := s.Cancel("unknown"); err != errUnknownTask {
+		t.Fatalf("expected %s but got %v", errUnknownTask, err)
+	}
+}
+
+func TestSchedulerClose(t *testing.T) {
+	handler, _ := newTestHandler(t)
+	s, err := New(logging.NoLog{}, memdb.New(), handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.Dispatch()
+		close(done)
+	}()
+	s.Close()
+	<-done
+
+	if err := s.Schedule(&Task{
+		Name:     "task",
+		Endpoint: "/ext/test",
+		Method:   "test.echo",
+		Params:   []byte(`{}`),
+	}); err != errClosed {
+		t.Fatalf("expected %s but got %v", errClosed, err)
+	}
+}
+
+func TestSchedulerCredentials(t *testing.T) {
+	handler, service := newTestHandler(t)
+	db := memdb.New()
+	s, err := New(logging.NoLog{}, db, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1000, 0)
+	s.clock.Set(now)
+
+	if err := s.Schedule(&Task{
+		Name:          "user",
+		Endpoint:      "/ext/test",
+		Method:        "test.user",
+		Params:        []byte(`{"message":"hello"}`),
+		Username:      "alice",
+		NextExecution: 1000,
+		Interval:      60,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The task isn't executed until the user's password is provided
+	s.executeDue()
+	if service.calls != 0 {
+		t.Fatalf("task shouldn't have been executed without a password")
+	}
+	if _, err := s.HealthCheck(); err == nil {
+		t.Fatalf("health check should fail while a task is waiting for a password")
+	}
+
+	s.UnlockUser("alice", "secret")
+	s.executeDue()
+	if service.calls != 1 {
+		t.Fatalf("task should have been executed once but was executed %d times", service.calls)
+	}
+	if tasks := s.Tasks(); len(tasks) != 1 || tasks[0].LastResult != `{"message":"alice:hello"}` {
+		t.Fatalf("unexpected tasks %v", tasks)
+	}
+
+	// The password should never be persisted
+	it := db.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		if bytes.Contains(it.Value(), []byte("secret")) {
+			t.Fatalf("password was persisted")
+		}
+	}
+	if err := it.Error(); err != nil {
+		t.Fatal(err)
+	}
+
+	// After a restart, the password must be provided again
+	s, err = New(logging.NoLog{}, db, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.clock.Set(now.Add(time.Minute))
+	s.executeDue()
+	if service.calls != 1 {
+		t.Fatalf("task shouldn't have been executed without a password")
+	}
+	if tasks := s.Tasks(); len(tasks) != 1 || tasks[0].LastError != errLocked.Error() {
+		t.Fatalf("unexpected tasks %v", tasks)
+	}
+}
+
+func TestSchedulerUnlockedWhileExecuting(t *testing.T) {
+	handler, service := newTestHandler(t)
+
+	var s *Scheduler
+	lockingHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Would deadlock if the task were executed while holding the lock
+		if err := s.Cancel("task"); err != nil {
+			t.Fatal(err)
+		}
+		handler.ServeHTTP(w, r)
+	})
+	s, err := New(logging.NoLog{}, memdb.New(), lockingHandler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.clock.Set(time.Unix(1000, 0))
+
+	if err := s.Schedule(&Task{
+		Name:          "task",
+		Endpoint:      "/ext/test",
+		Method:        "test.echo",
+		Params:        []byte(`{"message":"hello"}`),
+		NextExecution: 1000,
+		Interval:      60,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.executeDue()
+	if service.calls != 1 {
+		t.Fatalf("task should have been executed once but was executed %d times", service.calls)
+	}
+	if tasks := s.Tasks(); len(tasks) != 0 {
+		t.Fatalf("task cancelled while being executed shouldn't be restored")
+	}
+}
@@ -0,0 +1,242 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+const (
+	// Endpoint is the base path of the scheduler API
+	Endpoint = "scheduler"
+)
+
+var (
+	errSelfCall   = errors.New("tasks can't call the scheduler API")
+	errNoUsername = errors.New("params with a password must have a username")
+)
+
+// Authorizer checks the authorization of API calls made on behalf of the
+// caller of a request
+type Authorizer interface {
+	// Returns nil if the caller of [r] may call [method] on the API at [path]
+	Authorize(r *http.Request, path, method string) error
+}
+
+// Users checks the credentials of keystore users
+type Users interface {
+	// Returns nil if [password] is the password of the user [username]
+	CheckUser(username, password string) error
+}
+
+// Service is the API service for scheduling tasks
+type Service struct {
+	scheduler  *Scheduler
+	authorizer Authorizer
+	users      Users
+}
+
+// NewService returns a new scheduler API service. Tasks can only be scheduled
+// by callers that [authorizer] allows to make the scheduled calls. The
+// credentials of keystore users are checked with [users] before they're used.
+func NewService(scheduler *Scheduler, authorizer Authorizer, users Users) (*common.HTTPHandler, error) {
+	newServer := cjson.NewServer()
+	codec := cjson.NewCodec()
+	newServer.RegisterCodec(codec, "application/json")
+	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	service := &Service{
+		scheduler:  scheduler,
+		authorizer: authorizer,
+		users:      users,
+	}
+	if err := newServer.RegisterService(service, "scheduler"); err != nil {
+		return nil, err
+	}
+	return &common.HTTPHandler{LockOptions: common.NoLock, Handler: newServer}, nil
+}
+
+// ScheduleTaskArgs are the arguments for calling ScheduleTask
+type ScheduleTaskArgs struct {
+	// Name uniquely identifying the task
+	Name string `json:"name"`
+	// Endpoint the call is made to, e.g. /ext/bc/X
+	Endpoint string `json:"endpoint"`
+	// Method called, e.g. avm.send
+	Method string `json:"method"`
+	// Params of the call. May contain a keystore user's credentials, in which
+	// case the transaction is built and signed by the node at execution time,
+	// or a signed transaction. Only the username is persisted, so the password
+	// must be provided again with UnlockUser after the node restarts.
+	Params json.RawMessage `json:"params"`
+	// StartTime is the unix time of the first execution. If 0, the task is
+	// executed as soon as possible. Tasks are only scheduled by time, so a
+	// send after a locktime is scheduled with the locktime as the start time.
+	StartTime cjson.Uint64 `json:"startTime"`
+	// Interval, in seconds, between executions. If 0, the task is only
+	// executed once.
+	Interval cjson.Uint64 `json:"interval"`
+}
+
+// ScheduleTask schedules a call to one of this node's APIs. The caller must be
+// authorized to make the call.
+func (service *Service) ScheduleTask(r *http.Request, args *ScheduleTaskArgs, reply *api.SuccessResponse) error {
+	service.scheduler.log.Info("Scheduler: ScheduleTask called with %.*s", maxNameLen, args.Name)
+
+	if strings.HasPrefix(args.Endpoint, "/ext/"+Endpoint) {
+		return errSelfCall
+	}
+	if err := service.authorizer.Authorize(r, args.Endpoint, args.Method); err != nil {
+		return fmt.Errorf("not authorized to schedule the task: %w", err)
+	}
+
+	startTime := uint64(args.StartTime)
+	if now := service.scheduler.clock.Unix(); startTime < now {
+		startTime = now
+	}
+	params := []byte(args.Params)
+	if len(params) == 0 {
+		params = []byte("{}")
+	}
+	params, user, err := withoutCredentials(params)
+	if err != nil {
+		return err
+	}
+	if user.Username != "" {
+		if err := service.users.CheckUser(user.Username, user.Password); err != nil {
+			return err
+		}
+	}
+	if err := service.scheduler.Schedule(&Task{
+		Name:          args.Name,
+		Endpoint:      args.Endpoint,
+		Method:        args.Method,
+		Params:        params,
+		Username:      user.Username,
+		NextExecution: startTime,
+		Interval:      uint64(args.Interval),
+	}); err != nil {
+		return err
+	}
+	if user.Username != "" {
+		service.scheduler.UnlockUser(user.Username, user.Password)
+	}
+
+	reply.Success = true
+	return nil
+}
+
+// withoutCredentials returns [params] without the credentials of a keystore
+// user, and the credentials. If [params] isn't a JSON object with a password,
+// it's returned as is.
+func withoutCredentials(params []byte) ([]byte, api.UserPass, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(params, &fields); err != nil {
+		return params, api.UserPass{}, nil
+	}
+	passwordJSON, ok := fields[passwordKey]
+	if !ok {
+		return params, api.UserPass{}, nil
+	}
+
+	user := api.UserPass{}
+	if err := json.Unmarshal(passwordJSON, &user.Password); err != nil {
+		return nil, api.UserPass{}, fmt.Errorf("%w: %s", errInvalidParams, err)
+	}
+	if usernameJSON, ok := fields[usernameKey]; ok {
+		if err := json.Unmarshal(usernameJSON, &user.Username); err != nil {
+			return nil, api.UserPass{}, fmt.Errorf("%w: %s", errInvalidParams, err)
+		}
+	}
+	if user.Username == "" {
+		return nil, api.UserPass{}, errNoUsername
+	}
+
+	delete(fields, usernameKey)
+	delete(fields, passwordKey)
+	params, err := json.Marshal(fields)
+	return params, user, err
+}
+
+// UnlockUser provides the password of a keystore user whose credentials were
+// given to scheduled tasks. Passwords are only kept in memory, so this must be
+// called after the node restarts for the user's tasks to be executed. The
+// password must be the user's password in the keystore.
+func (service *Service) UnlockUser(_ *http.Request, args *api.UserPass, reply *api.SuccessResponse) error {
+	service.scheduler.log.Info("Scheduler: UnlockUser called with %.*s", maxNameLen, args.Username)
+
+	if err := service.users.CheckUser(args.Username, args.Password); err != nil {
+		return err
+	}
+	service.scheduler.UnlockUser(args.Username, args.Password)
+	reply.Success = true
+	return nil
+}
+
+// APITask is the representation of a task sent over APIs. The params of the
+// task are never returned, as they may contain a signed transaction.
+type APITask struct {
+	Name          string       `json:"name"`
+	Endpoint      string       `json:"endpoint"`
+	Method        string       `json:"method"`
+	Username      string       `json:"username,omitempty"`
+	NextExecution cjson.Uint64 `json:"nextExecution"`
+	Interval      cjson.Uint64 `json:"interval"`
+	Executions    cjson.Uint64 `json:"executions"`
+	LastExecution cjson.Uint64 `json:"lastExecution"`
+	LastResult    string       `json:"lastResult,omitempty"`
+	LastError     string       `json:"lastError,omitempty"`
+}
+
+// ListTasksReply is the response from calling ListTasks
+type ListTasksReply struct {
+	Tasks []APITask `json:"tasks"`
+}
+
+// ListTasks returns all of the scheduled tasks
+func (service *Service) ListTasks(_ *http.Request, _ *struct{}, reply *ListTasksReply) error {
+	service.scheduler.log.Info("Scheduler: ListTasks called")
+
+	tasks := service.scheduler.Tasks()
+	reply.Tasks = make([]APITask, len(tasks))
+	for i, task := range tasks {
+		reply.Tasks[i] = APITask{
+			Name:          task.Name,
+			Endpoint:      task.Endpoint,
+			Method:        task.Method,
+			Username:      task.Username,
+			NextExecution: cjson.Uint64(task.NextExecution),
+			Interval:      cjson.Uint64(task.Interval),
+			Executions:    cjson.Uint64(task.Executions),
+			LastExecution: cjson.Uint64(task.LastExecution),
+			LastResult:    task.LastResult,
+			LastError:     task.LastError,
+		}
+	}
+	return nil
+}
+
+// CancelTaskArgs are the arguments for calling CancelTask
+type CancelTaskArgs struct {
+	Name string `json:"name"`
+}
+
+// CancelTask removes a scheduled task
+func (service *Service) CancelTask(_ *http.Request, args *CancelTaskArgs, reply *api.SuccessResponse) error {
+	service.scheduler.log.Info("Scheduler: CancelTask called with %.*s", maxNameLen, args.Name)
+
+	if err := service.scheduler.Cancel(args.Name); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
@@ -0,0 +1,191 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scheduler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var (
+	errTestUnauthorized      = errors.New("unauthorized")
+	errTestIncorrectPassword = errors.New("incorrect password")
+)
+
+// testAuthorizer only authorizes calls to [path] and [method]
+type testAuthorizer struct {
+	path, method string
+}
+
+func (a *testAuthorizer) Authorize(_ *http.Request, path, method string) error {
+	if path != a.path || method != a.method {
+		return errTestUnauthorized
+	}
+	return nil
+}
+
+// testUsers is a keystore with the users in [passwords]
+type testUsers struct {
+	passwords map[string]string
+}
+
+func (u *testUsers) CheckUser(username, password string) error {
+	if pw, ok := u.passwords[username]; !ok || pw != password {
+		return errTestIncorrectPassword
+	}
+	return nil
+}
+
+func newTestService(t *testing.T) *Service {
+	handler, _ := newTestHandler(t)
+	s, err := New(logging.NoLog{}, memdb.New(), handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.clock.Set(time.Unix(1000, 0))
+	return &Service{
+		scheduler: s,
+		authorizer: &testAuthorizer{
+			path:   "/ext/test",
+			method: "test.user",
+		},
+		users: &testUsers{passwords: map[string]string{
+			"alice": "secret",
+		}},
+	}
+}
+
+func TestServiceScheduleTaskUnauthorized(t *testing.T) {
+	service := newTestService(t)
+	req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650/ext/scheduler", nil)
+
+	reply := api.SuccessResponse{}
+	if err := service.ScheduleTask(req, &ScheduleTaskArgs{
+		Name:     "task",
+		Endpoint: "/ext/test",
+		Method:   "test.echo",
+	}, &reply); !errors.Is(err, errTestUnauthorized) {
+		t.Fatalf("expected %s but got %v", errTestUnauthorized, err)
+	}
+	if tasks := service.scheduler.Tasks(); len(tasks) != 0 {
+		t.Fatalf("unauthorized task shouldn't have been scheduled")
+	}
+}
+
+func TestServiceScheduleTaskCredentials(t *testing.T) {
+	service := newTestService(t)
+	req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650/ext/scheduler", nil)
+
+	reply := api.SuccessResponse{}
+	if err := service.ScheduleTask(req, &ScheduleTaskArgs{
+		Name:     "task",
+		Endpoint: "/ext/test",
+		Method:   "test.user",
+		Params:   []byte(`{"username":"alice","password":"secret","message":"hello"}`),
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks := service.scheduler.Tasks()
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task but got %d", len(tasks))
+	}
+	switch task := tasks[0]; {
+	case task.Username != "alice":
+		t.Fatalf("expected the task to reference alice but references %q", task.Username)
+	case string(task.Params) != `{"message":"hello"}`:
+		t.Fatalf("credentials should have been removed from the params, but params are %s", task.Params)
+	}
+
+	service.scheduler.executeDue()
+	if tasks := service.scheduler.Tasks(); len(tasks) != 0 {
+		t.Fatalf("task should have been executed with the user's credentials")
+	}
+}
+
+func TestWithoutCredentials(t *testing.T) {
+	tests := []struct {
+		params   string
+		expected string
+		user     api.UserPass
+		err      error
+	}{
+		{`{"message":"hello"}`, `{"message":"hello"}`, api.UserPass{}, nil},
+		{`{"username":"alice"}`, `{"username":"alice"}`, api.UserPass{}, nil},
+		{`["password"]`, `["password"]`, api.UserPass{}, nil},
+		{`{"username":"alice","password":"secret"}`, `{}`, api.UserPass{Username: "alice", Password: "secret"}, nil},
+		{`{"password":"secret"}`, ``, api.UserPass{}, errNoUsername},
+		{`{"username":"alice","password":1}`, ``, api.UserPass{}, errInvalidParams},
+	}
+	for _, test := range tests {
+		params, user, err := withoutCredentials([]byte(test.params))
+		switch {
+		case !errors.Is(err, test.err):
+			t.Fatalf("expected %v for %s but got %v", test.err, test.params, err)
+		case err != nil:
+		case string(params) != test.expected:
+			t.Fatalf("expected params %s for %s but got %s", test.expected, test.params, params)
+		case user != test.user:
+			t.Fatalf("expected user %v for %s but got %v", test.user, test.params, user)
+		}
+	}
+}
+
+func TestServiceScheduleTaskIncorrectPassword(t *testing.T) {
+	service := newTestService(t)
+	req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650/ext/scheduler", nil)
+
+	reply := api.SuccessResponse{}
+	if err := service.ScheduleTask(req, &ScheduleTaskArgs{
+		Name:     "task",
+		Endpoint: "/ext/test",
+		Method:   "test.user",
+		Params:   []byte(`{"username":"alice","password":"wrong","message":"hello"}`),
+	}, &reply); !errors.Is(err, errTestIncorrectPassword) {
+		t.Fatalf("expected %s but got %v", errTestIncorrectPassword, err)
+	}
+	if tasks := service.scheduler.Tasks(); len(tasks) != 0 {
+		t.Fatalf("task with an incorrect password shouldn't have been scheduled")
+	}
+}
+
+func TestServiceUnlockUser(t *testing.T) {
+	service := newTestService(t)
+	if err := service.scheduler.Schedule(&Task{
+		Name:          "task",
+		Endpoint:      "/ext/test",
+		Method:        "test.user",
+		Params:        []byte(`{"message":"hello"}`),
+		Username:      "alice",
+		NextExecution: 1000,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// An incorrect password isn't stored
+	reply := api.SuccessResponse{}
+	err := service.UnlockUser(nil, &api.UserPass{Username: "alice", Password: "wrong"}, &reply)
+	if !errors.Is(err, errTestIncorrectPassword) {
+		t.Fatalf("expected %s but got %v", errTestIncorrectPassword, err)
+	}
+	service.scheduler.executeDue()
+	if tasks := service.scheduler.Tasks(); len(tasks) != 1 || tasks[0].LastError != errLocked.Error() {
+		t.Fatalf("task shouldn't have been executed with an incorrect password")
+	}
+
+	if err := service.UnlockUser(nil, &api.UserPass{Username: "alice", Password: "secret"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	service.scheduler.executeDue()
+	if tasks := service.scheduler.Tasks(); len(tasks) != 0 {
+		t.Fatalf("task should have been executed once the user was unlocked")
+	}
+}
@@ -10,16 +10,23 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/gorilla/handlers"
 
-	"github.com/rs/cors"
-
 	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/logging"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
 )
 
 const (
@@ -28,6 +35,7 @@ const (
 
 var (
 	errUnknownLockOption = errors.New("invalid lock options")
+	errNotSocket         = errors.New("file exists and isn't a socket")
 )
 
 // Server maintains the HTTP router
@@ -40,9 +48,72 @@ type Server struct {
 	router *router
 	// Listens for HTTP traffic on this address
 	listenAddress string
+	// Max amount of time a request may be processed for. If 0, requests don't
+	// time out.
+	requestTimeout time.Duration
+	// Max amount of time reading a request or writing its response may take.
+	// If 0, they don't time out.
+	readTimeout, writeTimeout time.Duration
+	// Max amount of time calls to each method or URL may be processed for.
+	// Override [requestTimeout].
+	methodTimeouts, urlTimeouts map[string]time.Duration
 	// Handles authorization. Must be non-nil after initialization, even if
 	// token authorization is off.
 	auth *auth.Auth
+
+	routeHandlersLock sync.Mutex
+	// Key: URL of a route, excluding aliases
+	// Value: Description of the handler serving the route
+	routeHandlers map[string]routeHandler
+
+	// Determines which cross-origin requests browsers may send
+	corsConfig CORSConfig
+	// Level responses are compressed at. If 0, responses aren't compressed.
+	compressionLevel int
+	// Limits the requests each IP may send. Nil if requests aren't limited.
+	rateLimiter *rateLimiter
+
+	metricsLock sync.RWMutex
+	// Reports the latency and failures of JSON-RPC calls. Nil until
+	// RegisterMetrics is called.
+	metrics *methodMetrics
+}
+
+// routeHandler describes the handler serving a route
+type routeHandler struct {
+	// ID of the chain serving the route, or the empty ID if the route isn't
+	// served by a chain
+	chainID ids.ID
+	// Version of the API the route serves
+	version uint32
+	// Go type of the handler, before middleware is applied
+	handlerType string
+	lockOption  common.LockOption
+	// Describes the handler's JSON-RPC services, if it can
+	describer cjson.Describer
+}
+
+// Route describes a URL served by the API server
+type Route struct {
+	// URL of the route, e.g. /ext/bc/2oYMBNV4eNHyqk2fjjV5nVQLDbtmNJzq5s3qs3Lo6ftnC6FByM
+	URL string
+	// Aliases of the URL, e.g. /ext/bc/X
+	Aliases []string
+	// ChainID of the chain serving the route, or the empty ID if the route
+	// isn't served by a chain
+	ChainID ids.ID
+	// Version of the API the route serves, e.g. 2 for /ext/bc/X/v2
+	Version uint32
+	// AuthRequired is true if requests to the route must include an auth token
+	AuthRequired bool
+	// HandlerType is the Go type of the handler serving the route, e.g.
+	// *json.Server
+	HandlerType string
+	// LockOption is the lock the handler grabs while serving a request
+	LockOption common.LockOption
+	// Describer describes the JSON-RPC services served at the route, or is
+	// nil if the handler can't describe them
+	Describer cjson.Describer
 }
 
 // Initialize creates the API server at the provided host and port
@@ -53,18 +124,27 @@ func (s *Server) Initialize(
 	port uint16,
 	authEnabled bool,
 	authPassword string,
+	authDB database.Database,
+	requestTimeout time.Duration,
 ) error {
 	s.log = log
 	s.factory = factory
-	s.listenAddress = fmt.Sprintf("%s:%d", host, port)
+	s.listenAddress = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	s.requestTimeout = requestTimeout
 	s.router = newRouter()
-	s.auth = &auth.Auth{Enabled: authEnabled}
+	s.corsConfig = DefaultCORSConfig()
+	s.compressionLevel = DefaultCompressionLevel
+	s.routeHandlers = make(map[string]routeHandler)
+	s.auth = &auth.Auth{Enabled: authEnabled, DB: authDB}
 	if err := s.auth.Password.Set(authPassword); err != nil {
 		return err
 	}
 	if !authEnabled {
 		return nil
 	}
+	if err := s.auth.RestoreRevokedTokens(); err != nil {
+		return fmt.Errorf("couldn't restore revoked auth tokens: %w", err)
+	}
 
 	// only create auth service if token authorization is required
 	s.log.Info("API authorization is enabled. Auth tokens must be passed in the header of API requests, except requests to the auth service.")
@@ -80,9 +160,7 @@ func (s *Server) Dispatch() error {
 		return err
 	}
 	s.log.Info("HTTP API server listening on %q", s.listenAddress)
-	handler := cors.Default().Handler(s.router)
-	handler = s.auth.WrapHandler(handler)
-	return http.Serve(listener, handler)
+	return s.httpServer().Serve(listener)
 }
 
 // DispatchTLS starts the API server with the provided TLS certificate
@@ -92,9 +170,79 @@ func (s *Server) DispatchTLS(certFile, keyFile string) error {
 		return err
 	}
 	s.log.Info("HTTPS API server listening on %q", s.listenAddress)
-	handler := cors.Default().Handler(s.router)
+	return s.httpServer().ServeTLS(listener, certFile, keyFile)
+}
+
+// DispatchUnix starts serving the API on the unix domain socket at [path],
+// which only users that [mode] grants write permission can connect to. If a
+// socket already exists at [path], such as one left behind by a node that
+// didn't shut down cleanly, it's replaced. Requests sent over the socket are
+// handled like those sent over TCP, including token authorization.
+func (s *Server) DispatchUnix(path string, mode os.FileMode) error {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return fmt.Errorf("couldn't listen on %q: %w", path, errNotSocket)
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("couldn't remove stale socket %q: %w", path, err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		_ = listener.Close()
+		return fmt.Errorf("couldn't set the permissions of %q: %w", path, err)
+	}
+	s.log.Info("HTTP API server listening on unix socket %q", path)
+	return s.httpServer().Serve(listener)
+}
+
+// httpServer returns the HTTP server that serves clients
+func (s *Server) httpServer() *http.Server {
+	return &http.Server{
+		Handler:      s.Handler(),
+		ReadTimeout:  s.readTimeout,
+		WriteTimeout: s.writeTimeout,
+	}
+}
+
+// Handler returns the handler of requests from clients, which applies the
+// middleware shared by every route, such as token authorization
+func (s *Server) Handler() http.Handler {
+	handler := s.corsMiddleware(s.router)
 	handler = s.auth.WrapHandler(handler)
-	return http.ServeTLS(listener, handler, certFile, keyFile)
+	handler = s.endpointTimeoutMiddleware(handler)
+	handler = s.rateLimitMiddleware(handler)
+	handler = requestSizeMiddleware(handler)
+	handler = s.requestIDMiddleware(handler)
+	return s.compressMiddleware(handler)
+}
+
+// requestSizeMiddleware wraps a handler so that reading more than
+// cjson.MaxRequestSize bytes of a request's body fails
+func requestSizeMiddleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body != nil {
+			r.Body = http.MaxBytesReader(w, r.Body, cjson.MaxRequestSize)
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// ServeInternal dispatches [r] to the registered handlers, without requiring
+// token authorization. It is intended for API calls made by this node itself.
+func (s *Server) ServeInternal(w http.ResponseWriter, r *http.Request) {
+	s.router.ServeHTTP(w, r)
+}
+
+// Authorize returns nil if the auth token of [r] allows calling [method] on
+// the API at [path]. It is intended for API calls that this node makes on
+// behalf of the caller of [r].
+func (s *Server) Authorize(r *http.Request, path, method string) error {
+	return s.auth.Authorize(r, path, method)
 }
 
 // RegisterChain registers the API endpoints associated with this chain That is,
@@ -116,7 +264,28 @@ func (s *Server) RegisterChain(ctx *snow.Context, vmIntf interface{}) {
 	s.log.Verbo("About to add API endpoints for chain with ID %s", ctx.ChainID)
 
 	// Register each endpoint
-	for extension, service := range vm.CreateHandlers() {
+	s.registerChainHandlers(ctx, defaultEndpoint, FirstVersion, vm.CreateHandlers(), httpLogger)
+	if versionedVM, ok := vm.(common.VersionedVM); ok {
+		for version, handlers := range versionedVM.CreateVersionedHandlers() {
+			if version <= FirstVersion {
+				s.log.Error("could not add version %d of chain's API because versions after the first must be greater than %d", version, FirstVersion)
+				continue
+			}
+			s.registerChainHandlers(ctx, defaultEndpoint, version, handlers, httpLogger)
+		}
+	}
+}
+
+// registerChainHandlers registers [handlers], of version [version] of the API
+// of the chain of [ctx], by extension
+func (s *Server) registerChainHandlers(
+	ctx *snow.Context,
+	base string,
+	version uint32,
+	handlers map[string]*common.HTTPHandler,
+	loggingWriter io.Writer,
+) {
+	for extension, service := range handlers {
 		// Validate that the route being added is valid
 		// e.g. "/foo" and "" are ok but "\n" is not
 		_, err := url.ParseRequestURI(extension)
@@ -124,7 +293,7 @@ func (s *Server) RegisterChain(ctx *snow.Context, vmIntf interface{}) {
 			s.log.Error("could not add route to chain's API handler because route is malformed: %s", err)
 			continue
 		}
-		if err := s.AddChainRoute(service, ctx, defaultEndpoint, extension, httpLogger); err != nil {
+		if err := s.addChainRoute(service, ctx, base, VersionPath(version)+extension, version, loggingWriter); err != nil {
 			s.log.Error("error adding route: %s", err)
 		}
 	}
@@ -132,6 +301,12 @@ func (s *Server) RegisterChain(ctx *snow.Context, vmIntf interface{}) {
 
 // AddChainRoute registers a route to a chain's handler
 func (s *Server) AddChainRoute(handler *common.HTTPHandler, ctx *snow.Context, base, endpoint string, loggingWriter io.Writer) error {
+	return s.addChainRoute(handler, ctx, base, endpoint, FirstVersion, loggingWriter)
+}
+
+// addChainRoute registers a route to a chain's handler, which serves version
+// [version] of the chain's API
+func (s *Server) addChainRoute(handler *common.HTTPHandler, ctx *snow.Context, base, endpoint string, version uint32, loggingWriter io.Writer) error {
 	url := fmt.Sprintf("%s/%s", baseURL, base)
 	s.log.Info("adding route %s%s", url, endpoint)
 	// Apply logging middleware
@@ -143,7 +318,15 @@ func (s *Server) AddChainRoute(handler *common.HTTPHandler, ctx *snow.Context, b
 	}
 	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
 	h = rejectMiddleware(h, ctx)
-	return s.router.AddRouter(url, endpoint, h)
+	// Apply middleware to report the latency and failures of calls
+	h = s.metricsMiddleware(h, ctx.ChainID.String())
+	// Apply middleware to serve each call of a batch separately
+	h = batchMiddleware(h)
+	if err := s.router.AddRouter(url, endpoint, h); err != nil {
+		return err
+	}
+	s.addRouteHandler(url+endpoint, ctx.ChainID, version, handler)
+	return nil
 }
 
 // AddRoute registers a route to a handler.
@@ -157,7 +340,57 @@ func (s *Server) AddRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base,
 	if err != nil {
 		return err
 	}
-	return s.router.AddRouter(url, endpoint, h)
+	// Apply middleware to report the latency and failures of calls
+	h = s.metricsMiddleware(h, "")
+	// Apply middleware to serve each call of a batch separately
+	h = batchMiddleware(h)
+	if err := s.router.AddRouter(url, endpoint, h); err != nil {
+		return err
+	}
+	s.addRouteHandler(url+endpoint, ids.ID{}, FirstVersion, handler)
+	return nil
+}
+
+// addRouteHandler records that [handler], of the chain [chainID], serves
+// version [version] of the API at [url]
+func (s *Server) addRouteHandler(url string, chainID ids.ID, version uint32, handler *common.HTTPHandler) {
+	s.routeHandlersLock.Lock()
+	defer s.routeHandlersLock.Unlock()
+
+	describer, _ := handler.Handler.(cjson.Describer)
+	s.routeHandlers[url] = routeHandler{
+		chainID:     chainID,
+		version:     version,
+		handlerType: fmt.Sprintf("%T", handler.Handler),
+		lockOption:  handler.LockOptions,
+		describer:   describer,
+	}
+}
+
+// Routes returns every route served by the server, sorted by URL
+func (s *Server) Routes() []Route {
+	routes := s.router.Routes()
+
+	s.routeHandlersLock.Lock()
+	defer s.routeHandlersLock.Unlock()
+
+	result := make([]Route, 0, len(routes))
+	for url, aliases := range routes {
+		sort.Strings(aliases)
+		handler := s.routeHandlers[url]
+		result = append(result, Route{
+			URL:          url,
+			Aliases:      aliases,
+			ChainID:      handler.chainID,
+			Version:      handler.version,
+			AuthRequired: s.auth.Enabled && path.Base(url) != auth.Endpoint,
+			HandlerType:  handler.handlerType,
+			LockOption:   handler.lockOption,
+			Describer:    handler.describer,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].URL < result[j].URL })
+	return result
 }
 
 // Wraps a handler by grabbing and releasing a lock before calling the handler.
@@ -187,9 +420,7 @@ func lockMiddleware(handler http.Handler, lockOption common.LockOption, lock *sy
 func rejectMiddleware(handler http.Handler, ctx *snow.Context) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { // If chain isn't done bootstrapping, ignore API calls
 		if !ctx.IsBootstrapped() {
-			w.WriteHeader(http.StatusServiceUnavailable)
-			// Doesn't matter if there's an error while writing. They'll get the StatusServiceUnavailable code.
-			_, _ = w.Write([]byte("API call rejected because chain is not done bootstrapping"))
+			writeNotBootstrapped(w, ctx)
 		} else {
 			handler.ServeHTTP(w, r)
 		}
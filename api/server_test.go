@@ -5,6 +5,7 @@ package api
 
 import (
 	"bytes"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"sync"
@@ -13,8 +14,13 @@ import (
 	"github.com/gorilla/rpc/v2"
 	"github.com/gorilla/rpc/v2/json2"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/logging"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
 )
 
 type Service struct{ called bool }
@@ -37,6 +43,8 @@ func TestCall(t *testing.T) {
 		8080,
 		false,
 		"",
+		memdb.New(),
+		0,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -80,3 +88,123 @@ func TestCall(t *testing.T) {
 		t.Fatalf("Should have been called")
 	}
 }
+
+func TestRoutes(t *testing.T) {
+	s := Server{}
+	err := s.Initialize(
+		logging.NoLog{},
+		logging.NoFactory{},
+		"localhost",
+		8080,
+		true,
+		"password",
+		memdb.New(),
+		0,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := &common.HTTPHandler{Handler: &testHandler{}}
+	if err := s.AddRoute(handler, new(sync.RWMutex), "info", "", logging.NoLog{}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := snow.DefaultContextTest()
+	ctx.ChainID = ids.Empty.Prefix(1)
+	base := "bc/" + ctx.ChainID.String()
+	if err := s.AddChainRoute(handler, ctx, base, "/wallet", logging.NoLog{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddAliases(base, "bc/X"); err != nil {
+		t.Fatal(err)
+	}
+
+	routes := s.Routes()
+	if len(routes) != 3 {
+		t.Fatalf("expected 3 routes but got %d", len(routes))
+	}
+
+	auth, chain, info := routes[0], routes[1], routes[2]
+	switch {
+	case auth.URL != "/ext/auth":
+		t.Fatalf("unexpected route %s", auth.URL)
+	case auth.AuthRequired:
+		t.Fatalf("auth route shouldn't require an auth token")
+	case chain.URL != "/ext/"+base+"/wallet":
+		t.Fatalf("unexpected route %s", chain.URL)
+	case !chain.ChainID.Equals(ctx.ChainID):
+		t.Fatalf("expected route to be served by chain %s but was %s", ctx.ChainID, chain.ChainID)
+	case len(chain.Aliases) != 1 || chain.Aliases[0] != "/ext/bc/X/wallet":
+		t.Fatalf("unexpected aliases %v", chain.Aliases)
+	case !chain.AuthRequired:
+		t.Fatalf("chain route should require an auth token")
+	case chain.HandlerType != "*api.testHandler":
+		t.Fatalf("unexpected handler type %s", chain.HandlerType)
+	case chain.LockOption != common.WriteLock:
+		t.Fatalf("unexpected lock option %s", chain.LockOption)
+	case info.URL != "/ext/info":
+		t.Fatalf("unexpected route %s", info.URL)
+	case !info.ChainID.IsZero():
+		t.Fatalf("info route shouldn't be served by a chain")
+	}
+}
+
+func TestVersionedRoutes(t *testing.T) {
+	s := Server{}
+	err := s.Initialize(
+		logging.NoLog{},
+		logging.NoFactory{},
+		"localhost",
+		8080,
+		false,
+		"",
+		memdb.New(),
+		0,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := snow.DefaultContextTest()
+	ctx.ChainID = ids.Empty.Prefix(1)
+	base := "bc/" + ctx.ChainID.String()
+	handlers := map[string]*common.HTTPHandler{"": {Handler: &testHandler{}}}
+	s.registerChainHandlers(ctx, base, FirstVersion, handlers, logging.NoLog{})
+	s.registerChainHandlers(ctx, base, 2, handlers, logging.NoLog{})
+
+	routes := s.Routes()
+	if len(routes) != 2 {
+		t.Fatalf("expected 2 routes but got %d", len(routes))
+	}
+	v1, v2 := routes[0], routes[1]
+	switch {
+	case v1.URL != "/ext/"+base:
+		t.Fatalf("unexpected route %s", v1.URL)
+	case v1.Version != FirstVersion:
+		t.Fatalf("expected route to serve version %d but served %d", FirstVersion, v1.Version)
+	case v2.URL != "/ext/"+base+"/v2":
+		t.Fatalf("unexpected route %s", v2.URL)
+	case v2.Version != 2:
+		t.Fatalf("expected route to serve version 2 but served %d", v2.Version)
+	}
+}
+
+func TestRequestSizeMiddleware(t *testing.T) {
+	var readErr error
+	handler := requestSizeMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = ioutil.ReadAll(r.Body)
+	}))
+
+	body := bytes.Repeat([]byte{' '}, cjson.MaxRequestSize)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+	if readErr != nil {
+		t.Fatalf("request of the maximum size should have been read but failed with %s", readErr)
+	}
+
+	body = append(body, ' ')
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+	if readErr == nil {
+		t.Fatalf("reading a request larger than the maximum size should have failed")
+	}
+}
@@ -0,0 +1,74 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// +build !windows
+
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestDispatchUnix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := Server{}
+	if err := s.Initialize(logging.NoLog{}, logging.NoFactory{}, "localhost", 0, false, "", memdb.New(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// A regular file isn't replaced
+	path := filepath.Join(dir, "node.sock")
+	if err := ioutil.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DispatchUnix(path, 0600); err == nil {
+		t.Fatal("should have refused to replace a regular file")
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+
+	go func() { _ = s.DispatchUnix(path, 0600) }()
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		if resp, err = client.Get("http://unix/ext/unknown"); err == nil {
+			break
+		}
+		if attempt == 100 {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected status %d but got %d", http.StatusNotFound, resp.StatusCode)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Fatalf("expected permissions 0600 but got %o", perm)
+	}
+}
@@ -0,0 +1,113 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+var errNegativeTimeout = errors.New("timeout must not be negative")
+
+// TimeoutConfig determines how long the server waits for clients and how long
+// API calls may run for
+type TimeoutConfig struct {
+	// ReadTimeout is the max amount of time reading a request, including its
+	// body, may take. If 0, reads don't time out.
+	ReadTimeout time.Duration
+	// WriteTimeout is the max amount of time from when a request's headers
+	// are read until its response is written. It applies to every endpoint, so
+	// it must allow for the slowest calls. If 0, writes don't time out.
+	WriteTimeout time.Duration
+	// HandlerTimeouts are the max amounts of time calls may run for, which
+	// override the server's request timeout. Keys are either JSON-RPC
+	// methods, e.g. avm.getUTXOs, or URLs, as requested, e.g. /ext/health. A
+	// method's timeout takes precedence over its URL's. If a timeout is 0,
+	// calls don't time out.
+	HandlerTimeouts map[string]time.Duration
+}
+
+// Verify returns an error if a timeout is invalid
+func (c TimeoutConfig) Verify() error {
+	if c.ReadTimeout < 0 {
+		return fmt.Errorf("invalid read timeout: %w", errNegativeTimeout)
+	}
+	if c.WriteTimeout < 0 {
+		return fmt.Errorf("invalid write timeout: %w", errNegativeTimeout)
+	}
+	for key, timeout := range c.HandlerTimeouts {
+		if timeout < 0 {
+			return fmt.Errorf("invalid timeout of %s: %w", key, errNegativeTimeout)
+		}
+	}
+	return nil
+}
+
+// SetTimeouts sets the timeouts of the server. Must be called before the
+// server is dispatched.
+func (s *Server) SetTimeouts(config TimeoutConfig) error {
+	if err := config.Verify(); err != nil {
+		return err
+	}
+	s.readTimeout = config.ReadTimeout
+	s.writeTimeout = config.WriteTimeout
+	s.methodTimeouts = make(map[string]time.Duration)
+	s.urlTimeouts = make(map[string]time.Duration)
+	for key, timeout := range config.HandlerTimeouts {
+		if strings.HasPrefix(key, "/") {
+			s.urlTimeouts[strings.TrimSuffix(key, "/")] = timeout
+		} else {
+			s.methodTimeouts[key] = timeout
+		}
+	}
+	return nil
+}
+
+// handlerTimeout returns how long the call [r] may run for. If [r] is a batch,
+// it may run for as long as its slowest method may.
+func (s *Server) handlerTimeout(r *http.Request) time.Duration {
+	fallback, ok := s.urlTimeouts[strings.TrimSuffix(r.URL.Path, "/")]
+	if !ok {
+		fallback = s.requestTimeout
+	}
+	if len(s.methodTimeouts) == 0 {
+		return fallback
+	}
+
+	// If the methods can't be parsed, the call will fail anyway
+	methods, _ := cjson.GetMethods(r)
+	if len(methods) == 0 {
+		return fallback
+	}
+	timeout := time.Duration(0)
+	for _, method := range methods {
+		methodTimeout, ok := s.methodTimeouts[method]
+		if !ok {
+			methodTimeout = fallback
+		}
+		if methodTimeout == 0 {
+			return 0
+		}
+		if methodTimeout > timeout {
+			timeout = methodTimeout
+		}
+	}
+	return timeout
+}
+
+// endpointTimeoutMiddleware wraps a handler. The context of each request
+// passed to the handler is done after the request's handler timeout.
+func (s *Server) endpointTimeoutMiddleware(handler http.Handler) http.Handler {
+	if len(s.methodTimeouts) == 0 && len(s.urlTimeouts) == 0 {
+		return timeoutMiddleware(handler, s.requestTimeout)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timeoutMiddleware(handler, s.handlerTimeout(r)).ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,55 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandlerTimeout(t *testing.T) {
+	s := Server{requestTimeout: 10 * time.Second}
+	if err := s.SetTimeouts(TimeoutConfig{
+		HandlerTimeouts: map[string]time.Duration{
+			"avm.getUTXOs":                 2 * time.Minute,
+			"platform.getBlockchainStatus": 0,
+			"/ext/health/":                 5 * time.Second,
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		url, body string
+		expected  time.Duration
+	}{
+		{"/ext/bc/X", `{"method":"avm.getBalance"}`, 10 * time.Second},
+		{"/ext/bc/X", `{"method":"avm.getUTXOs"}`, 2 * time.Minute},
+		{"/ext/bc/X", `[{"method":"avm.getBalance"},{"method":"avm.getUTXOs"}]`, 2 * time.Minute},
+		{"/ext/P", `[{"method":"platform.getBlockchainStatus"},{"method":"platform.getHeight"}]`, 0},
+		{"/ext/health", `{"method":"health.getLiveness"}`, 5 * time.Second},
+		{"/ext/health", ``, 5 * time.Second},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodPost, test.url, strings.NewReader(test.body))
+		if timeout := s.handlerTimeout(r); timeout != test.expected {
+			t.Fatalf("expected %s %s to time out after %s but got %s", test.url, test.body, test.expected, timeout)
+		}
+	}
+}
+
+func TestTimeoutConfigVerify(t *testing.T) {
+	if err := (TimeoutConfig{ReadTimeout: -time.Second}).Verify(); err == nil {
+		t.Fatal("should have failed due to a negative read timeout")
+	}
+	if err := (TimeoutConfig{HandlerTimeouts: map[string]time.Duration{"avm.getUTXOs": -time.Second}}).Verify(); err == nil {
+		t.Fatal("should have failed due to a negative handler timeout")
+	}
+	if err := (TimeoutConfig{WriteTimeout: time.Minute}).Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
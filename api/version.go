@@ -0,0 +1,20 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import "fmt"
+
+// FirstVersion is the version of the API served without a version in its path,
+// e.g. at /ext/bc/X
+const FirstVersion uint32 = 1
+
+// VersionPath returns the path version [version] of a chain's API is served at,
+// relative to the chain's base URL. For example, version 2 of the X-Chain's API
+// is served at /ext/bc/X/v2. The first version is served at the base URL.
+func VersionPath(version uint32) string {
+	if version <= FirstVersion {
+		return ""
+	}
+	return fmt.Sprintf("/v%d", version)
+}
@@ -24,6 +24,19 @@ type Cacher interface {
 	Flush()
 }
 
+// Observer is notified of the lookups and evictions of a cache
+type Observer interface {
+	// Hit is called when a lookup finds its element in the cache
+	Hit()
+
+	// Miss is called when a lookup doesn't find its element in the cache
+	Miss()
+
+	// Evicted is called when an element is removed from the cache to make room
+	// for another
+	Evicted()
+}
+
 // Evictable allows the object to be notified when it is evicted
 type Evictable interface {
 	ID() ids.ID
@@ -27,6 +27,9 @@ type LRU struct {
 	entryMap  map[[32]byte]*list.Element
 	entryList *list.List
 	Size      int
+
+	// If non-nil, notified of the cache's lookups and evictions
+	Observer Observer
 }
 
 // Put implements the cache interface
@@ -80,6 +83,7 @@ func (c *LRU) resize() {
 
 		val := e.Value.(*entry)
 		delete(c.entryMap, val.Key.Key())
+		c.evicted()
 	}
 }
 
@@ -94,6 +98,7 @@ func (c *LRU) put(key ids.ID, value interface{}) {
 
 			val := e.Value.(*entry)
 			delete(c.entryMap, val.Key.Key())
+			c.evicted()
 			val.Key = key
 			val.Value = value
 		} else {
@@ -119,11 +124,23 @@ func (c *LRU) get(key ids.ID) (interface{}, bool) {
 		c.entryList.MoveToBack(e)
 
 		val := e.Value.(*entry)
+		if c.Observer != nil {
+			c.Observer.Hit()
+		}
 		return val.Value, true
 	}
+	if c.Observer != nil {
+		c.Observer.Miss()
+	}
 	return struct{}{}, false
 }
 
+func (c *LRU) evicted() {
+	if c.Observer != nil {
+		c.Observer.Evicted()
+	}
+}
+
 func (c *LRU) evict(key ids.ID) {
 	c.init()
 	c.resize()
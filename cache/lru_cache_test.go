@@ -169,3 +169,32 @@ func TestLRUResize(t *testing.T) {
 		t.Fatalf("Retrieved wrong value")
 	}
 }
+
+type countingObserver struct{ hits, misses, evictions int }
+
+func (o *countingObserver) Hit()     { o.hits++ }
+func (o *countingObserver) Miss()    { o.misses++ }
+func (o *countingObserver) Evicted() { o.evictions++ }
+
+func TestLRUObserver(t *testing.T) {
+	observer := &countingObserver{}
+	cache := LRU{Size: 1, Observer: observer}
+
+	id1 := ids.NewID([32]byte{1})
+	id2 := ids.NewID([32]byte{2})
+	cache.Put(id1, 1)
+	cache.Get(id1)
+	cache.Get(id2)
+	cache.Put(id2, 2)
+	cache.Evict(id2)
+	cache.Flush()
+
+	switch {
+	case observer.hits != 1:
+		t.Fatalf("Expected 1 hit, got %d", observer.hits)
+	case observer.misses != 1:
+		t.Fatalf("Expected 1 miss, got %d", observer.misses)
+	case observer.evictions != 1:
+		t.Fatalf("Only evictions that make room should be observed, got %d", observer.evictions)
+	}
+}
@@ -0,0 +1,55 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package metercacher reports the lookups and evictions of caches as metrics
+package metercacher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+type metrics struct {
+	hits, misses, evictions prometheus.Counter
+}
+
+// New returns an observer of a cache that reports its lookups and evictions
+// to [registerer] under [namespace]
+func New(namespace string, registerer prometheus.Registerer) (cache.Observer, error) {
+	m := &metrics{
+		hits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "hits",
+			Help:      "Number of lookups that found their element in the cache",
+		}),
+		misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "misses",
+			Help:      "Number of lookups that didn't find their element in the cache",
+		}),
+		evictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "evictions",
+			Help:      "Number of elements evicted from the cache to make room for others",
+		}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.hits),
+		registerer.Register(m.misses),
+		registerer.Register(m.evictions),
+	)
+	return m, errs.Err
+}
+
+// Hit implements the cache.Observer interface
+func (m *metrics) Hit() { m.hits.Inc() }
+
+// Miss implements the cache.Observer interface
+func (m *metrics) Miss() { m.misses.Inc() }
+
+// Evicted implements the cache.Observer interface
+func (m *metrics) Evicted() { m.evictions.Inc() }
@@ -14,6 +14,9 @@ type EvictableLRU struct {
 	entryMap  map[[32]byte]*list.Element
 	entryList *list.List
 	Size      int
+
+	// If non-nil, notified of the cache's lookups and evictions
+	Observer Observer
 }
 
 // Deduplicate implements the Deduplicator interface
@@ -52,6 +55,7 @@ func (c *EvictableLRU) resize() {
 		val := e.Value.(Evictable)
 		delete(c.entryMap, val.ID().Key())
 		val.Evict()
+		c.evicted()
 	}
 }
 
@@ -68,26 +72,42 @@ func (c *EvictableLRU) deduplicate(value Evictable) Evictable {
 			val := e.Value.(Evictable)
 			delete(c.entryMap, val.ID().Key())
 			val.Evict()
+			c.evicted()
 
 			e.Value = value
 		} else {
 			e = c.entryList.PushBack(value)
 		}
 		c.entryMap[key] = e
+		if c.Observer != nil {
+			c.Observer.Miss()
+		}
 	} else {
 		c.entryList.MoveToBack(e)
 
 		val := e.Value.(Evictable)
 		value = val
+		if c.Observer != nil {
+			c.Observer.Hit()
+		}
 	}
 	return value
 }
 
+func (c *EvictableLRU) evicted() {
+	if c.Observer != nil {
+		c.Observer.Evicted()
+	}
+}
+
 func (c *EvictableLRU) flush() {
 	c.init()
 
-	size := c.Size
-	c.Size = 0
-	c.resize()
-	c.Size = size
+	// Flushed elements aren't evicted to make room for others, so the
+	// observer isn't notified of them
+	for e := c.entryList.Front(); e != nil; e = e.Next() {
+		e.Value.(Evictable).Evict()
+	}
+	c.entryMap = make(map[[32]byte]*list.Element)
+	c.entryList = list.New()
 }
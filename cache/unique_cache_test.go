@@ -65,3 +65,26 @@ func TestEvictableLRU(t *testing.T) {
 		t.Fatalf("Value was evicted unexpectedly")
 	}
 }
+
+func TestEvictableLRUObserver(t *testing.T) {
+	observer := &countingObserver{}
+	cache := EvictableLRU{Size: 1, Observer: observer}
+
+	value1 := &evictable{id: ids.NewID([32]byte{1})}
+	value2 := &evictable{id: ids.NewID([32]byte{2})}
+	cache.Deduplicate(value1)
+	cache.Deduplicate(value1)
+	cache.Deduplicate(value2)
+	cache.Flush()
+
+	switch {
+	case observer.hits != 1:
+		t.Fatalf("Expected 1 hit, got %d", observer.hits)
+	case observer.misses != 2:
+		t.Fatalf("Expected 2 misses, got %d", observer.misses)
+	case observer.evictions != 1:
+		t.Fatalf("Only evictions that make room should be observed, got %d", observer.evictions)
+	case value2.evicted != 1:
+		t.Fatalf("Flushed value should have been evicted")
+	}
+}
@@ -0,0 +1,63 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/bootstrap"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// ancestorsClient fetches the ancestors of a block from a bootstrap archive
+type ancestorsClient interface {
+	GetAncestors(blkID ids.ID) ([][]byte, error)
+}
+
+// archiveResponder is passed the responses of a bootstrap archive. It's
+// implemented by *router.Handler.
+type archiveResponder interface {
+	LocalMultiPut(validatorID ids.ShortID, requestID uint32, containers [][]byte)
+	GetAncestorsFailed(validatorID ids.ShortID, requestID uint32)
+}
+
+// archiveFetcher fetches blocks from a bootstrap archive without blocking the
+// engine. Responses are passed to the chain's handler, which delivers them to
+// the engine like responses from the beacons.
+type archiveFetcher struct {
+	log    logging.Logger
+	client ancestorsClient
+
+	// closed once [responder] is set, since the engine may request blocks
+	// before the chain's handler is initialized
+	ready     chan struct{}
+	responder archiveResponder
+}
+
+func newArchiveFetcher(log logging.Logger, client ancestorsClient) *archiveFetcher {
+	return &archiveFetcher{
+		log:    log,
+		client: client,
+		ready:  make(chan struct{}),
+	}
+}
+
+// setResponder sets where responses are passed. Must be called exactly once.
+func (a *archiveFetcher) setResponder(responder archiveResponder) {
+	a.responder = responder
+	close(a.ready)
+}
+
+// GetAncestors implements the bootstrap.Archive interface
+func (a *archiveFetcher) GetAncestors(requestID uint32, blkID ids.ID) {
+	go func() {
+		blks, err := a.client.GetAncestors(blkID)
+		<-a.ready
+		if err != nil {
+			a.log.Debug("archive request for %s failed: %s", blkID, err)
+			a.responder.GetAncestorsFailed(bootstrap.ArchiveID, requestID)
+			return
+		}
+		a.responder.LocalMultiPut(bootstrap.ArchiveID, requestID, blks)
+	}()
+}
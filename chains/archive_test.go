@@ -0,0 +1,78 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/bootstrap"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+type testAncestorsClient struct {
+	blks [][]byte
+	err  error
+}
+
+func (c *testAncestorsClient) GetAncestors(ids.ID) ([][]byte, error) { return c.blks, c.err }
+
+type archiveResponse struct {
+	validatorID ids.ShortID
+	requestID   uint32
+	containers  [][]byte
+	failed      bool
+}
+
+type testArchiveResponder struct {
+	responses chan archiveResponse
+}
+
+func (r *testArchiveResponder) LocalMultiPut(validatorID ids.ShortID, requestID uint32, containers [][]byte) {
+	r.responses <- archiveResponse{
+		validatorID: validatorID,
+		requestID:   requestID,
+		containers:  containers,
+	}
+}
+
+func (r *testArchiveResponder) GetAncestorsFailed(validatorID ids.ShortID, requestID uint32) {
+	r.responses <- archiveResponse{
+		validatorID: validatorID,
+		requestID:   requestID,
+		failed:      true,
+	}
+}
+
+func TestArchiveFetcherMultiPut(t *testing.T) {
+	blks := [][]byte{{1}, {0}}
+	fetcher := newArchiveFetcher(logging.NoLog{}, &testAncestorsClient{blks: blks})
+	responder := &testArchiveResponder{responses: make(chan archiveResponse, 1)}
+
+	// The request may be made before the responder is set
+	fetcher.GetAncestors(5, ids.GenerateTestID())
+	fetcher.setResponder(responder)
+
+	response := <-responder.responses
+	assert.Equal(t, bootstrap.ArchiveID, response.validatorID)
+	assert.Equal(t, uint32(5), response.requestID)
+	assert.Equal(t, blks, response.containers)
+	assert.False(t, response.failed)
+}
+
+func TestArchiveFetcherFailed(t *testing.T) {
+	fetcher := newArchiveFetcher(logging.NoLog{}, &testAncestorsClient{err: errors.New("unavailable")})
+	responder := &testArchiveResponder{responses: make(chan archiveResponse, 1)}
+	fetcher.setResponder(responder)
+
+	fetcher.GetAncestors(7, ids.GenerateTestID())
+
+	response := <-responder.responses
+	assert.Equal(t, bootstrap.ArchiveID, response.validatorID)
+	assert.Equal(t, uint32(7), response.requestID)
+	assert.True(t, response.failed)
+}
@@ -61,6 +61,28 @@ func (m *Memory) ReleaseDatabase(sharedID ids.ID) {
 	lock.Unlock()
 }
 
+// GetDatabases returns and locks the provided DBs, keyed by their shared ID.
+// The DBs are written to the returned versiondb, so that they can be committed
+// atomically. [sharedIDs] must be sorted and unique.
+func (m *Memory) GetDatabases(sharedIDs []ids.ID) (*versiondb.Database, map[[32]byte]database.Database) {
+	vdb := versiondb.New(m.db)
+	dbs := make(map[[32]byte]database.Database, len(sharedIDs))
+	for _, sharedID := range sharedIDs {
+		lock := m.makeLock(sharedID)
+		lock.Lock()
+
+		dbs[sharedID.Key()] = prefixdb.New(sharedID.Bytes(), vdb)
+	}
+	return vdb, dbs
+}
+
+// ReleaseDatabases unlocks the provided DBs
+func (m *Memory) ReleaseDatabases(sharedIDs []ids.ID) {
+	for i := len(sharedIDs) - 1; i >= 0; i-- {
+		m.ReleaseDatabase(sharedIDs[i])
+	}
+}
+
 func (m *Memory) makeLock(sharedID ids.ID) *sync.Mutex {
 	m.lock.Lock()
 	defer m.lock.Unlock()
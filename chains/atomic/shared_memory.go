@@ -47,6 +47,15 @@ type Element struct {
 	Traits [][]byte
 }
 
+// Requests are the operations a chain performs on its shared memory with a
+// peer chain
+type Requests struct {
+	// Keys removed from this chain's side
+	RemoveRequests [][]byte
+	// Elements added to the peer chain's side
+	PutRequests []*Element
+}
+
 // SharedMemory ...
 type SharedMemory interface {
 	// Adds to the peer chain's side
@@ -67,6 +76,10 @@ type SharedMemory interface {
 		err error,
 	)
 	Remove(peerChainID ids.ID, keys [][]byte, batches ...database.Batch) error
+
+	// Applies the requests on the shared memory with each peer chain, keyed by
+	// the peer chain's ID, along with [batches], in one atomic commit
+	Apply(requests map[[32]byte]*Requests, batches ...database.Batch) error
 }
 
 // sharedMemory provides the API for a blockchain to interact with shared memory
@@ -77,32 +90,9 @@ type sharedMemory struct {
 }
 
 func (sm *sharedMemory) Put(peerChainID ids.ID, elems []*Element, batches ...database.Batch) error {
-	sharedID := sm.m.sharedID(peerChainID, sm.thisChainID)
-	vdb, db := sm.m.GetDatabase(sharedID)
-	defer sm.m.ReleaseDatabase(sharedID)
-
-	s := state{
-		c: sm.m.codec,
-	}
-	if bytes.Compare(sm.thisChainID.Bytes(), peerChainID.Bytes()) == -1 {
-		s.valueDB = prefixdb.New(largerValuePrefix, db)
-		s.indexDB = prefixdb.New(largerIndexPrefix, db)
-	} else {
-		s.valueDB = prefixdb.New(smallerValuePrefix, db)
-		s.indexDB = prefixdb.New(smallerIndexPrefix, db)
-	}
-
-	for _, elem := range elems {
-		if err := s.SetValue(elem); err != nil {
-			return err
-		}
-	}
-
-	myBatch, err := vdb.CommitBatch()
-	if err != nil {
-		return err
-	}
-	return WriteAll(myBatch, batches...)
+	return sm.Apply(map[[32]byte]*Requests{
+		peerChainID.Key(): {PutRequests: elems},
+	}, batches...)
 }
 
 func (sm *sharedMemory) Get(peerChainID ids.ID, keys [][]byte) ([][]byte, error) {
@@ -169,24 +159,66 @@ func (sm *sharedMemory) Indexed(
 }
 
 func (sm *sharedMemory) Remove(peerChainID ids.ID, keys [][]byte, batches ...database.Batch) error {
-	sharedID := sm.m.sharedID(peerChainID, sm.thisChainID)
-	vdb, db := sm.m.GetDatabase(sharedID)
-	defer sm.m.ReleaseDatabase(sharedID)
+	return sm.Apply(map[[32]byte]*Requests{
+		peerChainID.Key(): {RemoveRequests: keys},
+	}, batches...)
+}
 
-	s := state{
-		c: sm.m.codec,
-	}
-	if bytes.Compare(sm.thisChainID.Bytes(), peerChainID.Bytes()) == -1 {
-		s.valueDB = prefixdb.New(smallerValuePrefix, db)
-		s.indexDB = prefixdb.New(smallerIndexPrefix, db)
-	} else {
-		s.valueDB = prefixdb.New(largerValuePrefix, db)
-		s.indexDB = prefixdb.New(largerIndexPrefix, db)
+func (sm *sharedMemory) Apply(requests map[[32]byte]*Requests, batches ...database.Batch) error {
+	// The shared databases are locked in a consistent order so that chains
+	// applying requests concurrently can't deadlock. Nil requests are skipped.
+	peerChainIDs := make([]ids.ID, 0, len(requests))
+	sharedIDs := make([]ids.ID, 0, len(requests))
+	for peerChainIDKey, req := range requests {
+		if req == nil {
+			continue
+		}
+		peerChainID := ids.NewID(peerChainIDKey)
+		peerChainIDs = append(peerChainIDs, peerChainID)
+		sharedIDs = append(sharedIDs, sm.m.sharedID(peerChainID, sm.thisChainID))
 	}
+	ids.SortIDs(sharedIDs)
 
-	for _, key := range keys {
-		if err := s.RemoveValue(key); err != nil {
-			return err
+	vdb, dbs := sm.m.GetDatabases(sharedIDs)
+	defer sm.m.ReleaseDatabases(sharedIDs)
+
+	for _, peerChainID := range peerChainIDs {
+		sharedID := sm.m.sharedID(peerChainID, sm.thisChainID)
+		db := dbs[sharedID.Key()]
+		req := requests[peerChainID.Key()]
+
+		// Keys are removed from this chain's side
+		s := state{
+			c: sm.m.codec,
+		}
+		if bytes.Compare(sm.thisChainID.Bytes(), peerChainID.Bytes()) == -1 {
+			s.valueDB = prefixdb.New(smallerValuePrefix, db)
+			s.indexDB = prefixdb.New(smallerIndexPrefix, db)
+		} else {
+			s.valueDB = prefixdb.New(largerValuePrefix, db)
+			s.indexDB = prefixdb.New(largerIndexPrefix, db)
+		}
+		for _, key := range req.RemoveRequests {
+			if err := s.RemoveValue(key); err != nil {
+				return err
+			}
+		}
+
+		// Elements are added to the peer chain's side
+		s = state{
+			c: sm.m.codec,
+		}
+		if bytes.Compare(sm.thisChainID.Bytes(), peerChainID.Bytes()) == -1 {
+			s.valueDB = prefixdb.New(largerValuePrefix, db)
+			s.indexDB = prefixdb.New(largerIndexPrefix, db)
+		} else {
+			s.valueDB = prefixdb.New(smallerValuePrefix, db)
+			s.indexDB = prefixdb.New(smallerIndexPrefix, db)
+		}
+		for _, elem := range req.PutRequests {
+			if err := s.SetValue(elem); err != nil {
+				return err
+			}
 		}
 	}
 
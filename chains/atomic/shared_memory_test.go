@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -71,3 +72,117 @@ func TestSharedMemory(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, [][]byte{{1}, {5}}, values, "wrong indexed values returned")
 }
+
+func TestSharedMemoryApply(t *testing.T) {
+	m := Memory{}
+	m.Initialize(logging.NoLog{}, memdb.New())
+
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+	chainID2 := ids.GenerateTestID()
+
+	sm0 := m.NewSharedMemory(chainID0)
+	sm1 := m.NewSharedMemory(chainID1)
+	sm2 := m.NewSharedMemory(chainID2)
+
+	err := sm1.Put(chainID0, []*Element{{
+		Key:   []byte{0},
+		Value: []byte{1},
+	}})
+	assert.NoError(t, err)
+
+	// Consume the element chain 1 sent, and send elements to chains 1 and 2
+	err = sm0.Apply(map[[32]byte]*Requests{
+		chainID1.Key(): {
+			RemoveRequests: [][]byte{{0}},
+			PutRequests: []*Element{{
+				Key:   []byte{2},
+				Value: []byte{3},
+			}},
+		},
+		chainID2.Key(): {
+			PutRequests: []*Element{{
+				Key:   []byte{4},
+				Value: []byte{5},
+			}},
+		},
+	})
+	assert.NoError(t, err)
+
+	_, err = sm0.Get(chainID1, [][]byte{{0}})
+	assert.Equal(t, database.ErrNotFound, err, "element should have been removed")
+
+	values, err := sm1.Get(chainID0, [][]byte{{2}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{3}}, values, "wrong values returned")
+
+	values, err = sm2.Get(chainID0, [][]byte{{4}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{5}}, values, "wrong values returned")
+}
+
+func TestSharedMemoryApplySkipsNilRequests(t *testing.T) {
+	m := Memory{}
+	m.Initialize(logging.NoLog{}, memdb.New())
+
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+	chainID2 := ids.GenerateTestID()
+
+	sm0 := m.NewSharedMemory(chainID0)
+	sm1 := m.NewSharedMemory(chainID1)
+
+	err := sm0.Apply(map[[32]byte]*Requests{
+		chainID1.Key(): {
+			PutRequests: []*Element{{
+				Key:   []byte{0},
+				Value: []byte{1},
+			}},
+		},
+		chainID2.Key(): nil,
+	})
+	assert.NoError(t, err)
+
+	values, err := sm1.Get(chainID0, [][]byte{{0}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{1}}, values, "wrong values returned")
+}
+
+func TestSharedMemoryApplyIsAtomic(t *testing.T) {
+	m := Memory{}
+	m.Initialize(logging.NoLog{}, memdb.New())
+
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+	chainID2 := ids.GenerateTestID()
+
+	sm0 := m.NewSharedMemory(chainID0)
+	sm1 := m.NewSharedMemory(chainID1)
+
+	err := sm0.Put(chainID2, []*Element{{
+		Key:   []byte{0},
+		Value: []byte{1},
+	}})
+	assert.NoError(t, err)
+
+	// Putting the same key to chain 2 again fails, so the put to chain 1
+	// mustn't be applied either
+	err = sm0.Apply(map[[32]byte]*Requests{
+		chainID1.Key(): {
+			PutRequests: []*Element{{
+				Key:   []byte{2},
+				Value: []byte{3},
+			}},
+		},
+		chainID2.Key(): {
+			PutRequests: []*Element{{
+				Key:   []byte{0},
+				Value: []byte{1},
+			}},
+		},
+	})
+	assert.Error(t, err, "should have failed due to the duplicated put")
+
+	_, err = sm1.Get(chainID0, [][]byte{{2}})
+	assert.Equal(t, database.ErrNotFound, err, "element shouldn't have been added")
+}
@@ -0,0 +1,54 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// CacheSizes are the sizes of the caches a chain uses to deduplicate the
+// containers it's gossiped and queried about. A size of 0 means the default
+// size is used.
+type CacheSizes struct {
+	// Number of parsed vertices cached
+	Vertices int `json:"vertices"`
+	// Number of vertex IDs cached, and of unique vertices deduplicated
+	VertexIDs int `json:"vertexIDs"`
+	// Number of vertices remembered as processed during bootstrapping
+	ProcessedVertices int `json:"processedVertices"`
+}
+
+// ParseCacheSizes parses the cache sizes of chains from [str], a JSON object
+// from chain IDs or aliases to their cache sizes, such as
+// {"X":{"vertices":50000}}. If [str] is empty, no chain has its cache sizes
+// set.
+func ParseCacheSizes(str string) (map[string]CacheSizes, error) {
+	sizes := map[string]CacheSizes{}
+	if str == "" {
+		return sizes, nil
+	}
+	if err := json.Unmarshal([]byte(str), &sizes); err != nil {
+		return nil, fmt.Errorf("couldn't parse chain cache sizes: %w", err)
+	}
+	for chain, chainSizes := range sizes {
+		if chainSizes.Vertices < 0 || chainSizes.VertexIDs < 0 || chainSizes.ProcessedVertices < 0 {
+			return nil, fmt.Errorf("cache sizes of chain %q can't be negative", chain)
+		}
+	}
+	return sizes, nil
+}
+
+// cacheSizes returns the cache sizes of the chain [chainID], which are looked
+// up by its ID and then by each of its aliases
+func (m *manager) cacheSizes(chainID ids.ID) CacheSizes {
+	for _, key := range m.chainKeys(chainID) {
+		if sizes, ok := m.CacheSizes[key]; ok {
+			return sizes
+		}
+	}
+	return CacheSizes{}
+}
@@ -0,0 +1,43 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	avcon "github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+)
+
+var errNotDAG = errors.New("chain doesn't run Avalanche consensus")
+
+// runningGraph dumps the vertices a running chain's Avalanche consensus is
+// processing
+type runningGraph struct {
+	// the chain's context lock, which must be held to access its engine
+	lock sync.Locker
+	// Assumes [lock] is held.
+	graph func() (avcon.Graph, error)
+}
+
+// ConsensusGraph implements the Manager interface
+func (m *manager) ConsensusGraph(chainID ids.ID) (avcon.Graph, error) {
+	m.chainsLock.Lock()
+	running, exists := m.chainGraphs[chainID.Key()]
+	_, isChain := m.chains[chainID.Key()]
+	m.chainsLock.Unlock()
+	if !exists {
+		if isChain {
+			return avcon.Graph{}, errNotDAG
+		}
+		return avcon.Graph{}, errUnknownChain
+	}
+
+	running.lock.Lock()
+	defer running.lock.Unlock()
+
+	return running.graph()
+}
@@ -0,0 +1,178 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	avcon "github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+)
+
+// chainConfigExtension is the extension of the files in the chain config
+// directory. Each is named after the ID or alias of the chain it configures.
+const chainConfigExtension = ".json"
+
+var (
+	errUnknownChain    = errors.New("unknown chain ID")
+	errFixedThresholds = errors.New("alpha, betaVirtuous and betaRogue can't be changed while the chain is running")
+)
+
+// ConsensusConfig overrides the consensus parameters of a chain. Parameters
+// that are 0 keep their value.
+type ConsensusConfig struct {
+	K                 int `json:"k"`
+	Alpha             int `json:"alpha"`
+	BetaVirtuous      int `json:"betaVirtuous"`
+	BetaRogue         int `json:"betaRogue"`
+	ConcurrentRepolls int `json:"concurrentRepolls"`
+	MaxK              int `json:"maxK"`
+	// Parents, BatchSize and MaxBatchSize only apply to chains that run
+	// Avalanche
+	Parents      int `json:"parents"`
+	BatchSize    int `json:"batchSize"`
+	MaxBatchSize int `json:"maxBatchSize"`
+}
+
+// chainConfig is the content of a file in the chain config directory
+type chainConfig struct {
+	Consensus ConsensusConfig `json:"consensus"`
+}
+
+// apply returns [params] with the parameters overridden by [c]
+func (c ConsensusConfig) apply(params avcon.Parameters) avcon.Parameters {
+	override := func(param *int, value int) {
+		if value != 0 {
+			*param = value
+		}
+	}
+	override(&params.K, c.K)
+	override(&params.Alpha, c.Alpha)
+	override(&params.BetaVirtuous, c.BetaVirtuous)
+	override(&params.BetaRogue, c.BetaRogue)
+	override(&params.ConcurrentRepolls, c.ConcurrentRepolls)
+	override(&params.MaxK, c.MaxK)
+	override(&params.Parents, c.Parents)
+	override(&params.BatchSize, c.BatchSize)
+	override(&params.MaxBatchSize, c.MaxBatchSize)
+	return params
+}
+
+// ReadConsensusConfigs reads the consensus parameters of chains from the chain
+// config directory [dir]. The file <chain ID or alias>.json configures the
+// chain with that ID or alias, such as {"consensus":{"k":20,"alpha":15}}.
+// If [dir] doesn't exist, no chain is configured.
+func ReadConsensusConfigs(dir string) (map[string]ConsensusConfig, error) {
+	configs := map[string]ConsensusConfig{}
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return configs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read chain config directory: %w", err)
+	}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || filepath.Ext(name) != chainConfigExtension {
+			continue
+		}
+		configBytes, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read chain config %s: %w", name, err)
+		}
+		config := chainConfig{}
+		if err := json.Unmarshal(configBytes, &config); err != nil {
+			return nil, fmt.Errorf("couldn't parse chain config %s: %w", name, err)
+		}
+		configs[strings.TrimSuffix(name, chainConfigExtension)] = config.Consensus
+	}
+	return configs, nil
+}
+
+// consensusParams returns the consensus parameters the chain [chainID] is
+// created with: the node's parameters, overridden by the chain's config
+func (m *manager) consensusParams(chainID ids.ID) (avcon.Parameters, error) {
+	params := m.ConsensusParams
+	for _, key := range m.chainKeys(chainID) {
+		if config, ok := m.ConsensusConfigs[key]; ok {
+			params = config.apply(params)
+			break
+		}
+	}
+	if err := params.Valid(); err != nil {
+		return params, fmt.Errorf("invalid consensus parameters: %w", err)
+	}
+	return params, nil
+}
+
+// chainKeys returns the keys the configs of the chain [chainID] are looked up
+// by, in order: its ID, then each of its aliases
+func (m *manager) chainKeys(chainID ids.ID) []string {
+	return append([]string{chainID.String()}, m.Aliases(chainID)...)
+}
+
+// runningParams are the consensus parameters a running chain uses
+type runningParams struct {
+	// the chain's context lock, which must be held to access its engine
+	lock   sync.Locker
+	params avcon.Parameters
+	// sets the parameters that can change while the chain runs on its engine.
+	// Assumes [lock] is held.
+	set func(avcon.Parameters)
+}
+
+// ConsensusParameters implements the Manager interface
+func (m *manager) ConsensusParameters(chainID ids.ID) (avcon.Parameters, error) {
+	m.chainsLock.Lock()
+	running, exists := m.chainParams[chainID.Key()]
+	m.chainsLock.Unlock()
+	if !exists {
+		return avcon.Parameters{}, errUnknownChain
+	}
+
+	running.lock.Lock()
+	defer running.lock.Unlock()
+
+	return running.params, nil
+}
+
+// SetConsensusParameters implements the Manager interface
+func (m *manager) SetConsensusParameters(chainID ids.ID, config ConsensusConfig) (avcon.Parameters, error) {
+	m.chainsLock.Lock()
+	running, exists := m.chainParams[chainID.Key()]
+	m.chainsLock.Unlock()
+	if !exists {
+		return avcon.Parameters{}, errUnknownChain
+	}
+
+	running.lock.Lock()
+	defer running.lock.Unlock()
+
+	// Changing the thresholds would change the outcome of the polls and
+	// decisions already in progress, so they can only be set when the chain
+	// is created
+	params := config.apply(running.params)
+	if params.Alpha != running.params.Alpha ||
+		params.BetaVirtuous != running.params.BetaVirtuous ||
+		params.BetaRogue != running.params.BetaRogue {
+		return running.params, errFixedThresholds
+	}
+	if err := params.Valid(); err != nil {
+		return running.params, fmt.Errorf("invalid consensus parameters: %w", err)
+	}
+
+	running.set(params)
+	running.params = params
+	m.Log.Info("set the consensus parameters of chain %s to K = %d, MaxK = %d, ConcurrentRepolls = %d, Parents = %d, BatchSize = %d, MaxBatchSize = %d",
+		chainID, params.K, params.MaxK, params.ConcurrentRepolls, params.Parents, params.BatchSize, params.MaxBatchSize)
+	return params, nil
+}
@@ -0,0 +1,189 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	avcon "github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+)
+
+func testConsensusParams() avcon.Parameters {
+	return avcon.Parameters{
+		Parameters: snowball.Parameters{
+			K:                 20,
+			Alpha:             15,
+			BetaVirtuous:      15,
+			BetaRogue:         20,
+			ConcurrentRepolls: 4,
+		},
+		Parents:   5,
+		BatchSize: 30,
+	}
+}
+
+func TestReadConsensusConfigs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chain-configs")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "X.json"), []byte(`{"consensus":{"k":10,"alpha":8}}`), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a config"), 0600))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "C.json"), 0700))
+
+	configs, err := ReadConsensusConfigs(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]ConsensusConfig{
+		"X": {K: 10, Alpha: 8},
+	}, configs)
+}
+
+func TestReadConsensusConfigsMissingDir(t *testing.T) {
+	configs, err := ReadConsensusConfigs(filepath.Join(os.TempDir(), ids.GenerateTestID().String()))
+	assert.NoError(t, err)
+	assert.Empty(t, configs)
+}
+
+func TestReadConsensusConfigsInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chain-configs")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "X.json"), []byte(`{"consensus":`), 0600))
+
+	_, err = ReadConsensusConfigs(dir)
+	assert.Error(t, err)
+}
+
+func TestConsensusConfigApply(t *testing.T) {
+	params := ConsensusConfig{
+		K:            30,
+		MaxK:         40,
+		MaxBatchSize: 60,
+	}.apply(testConsensusParams())
+
+	expected := testConsensusParams()
+	expected.K = 30
+	expected.MaxK = 40
+	expected.MaxBatchSize = 60
+	assert.Equal(t, expected, params)
+}
+
+func TestConsensusParamsByAlias(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	m := New(&ManagerConfig{
+		ConsensusParams: testConsensusParams(),
+		ConsensusConfigs: map[string]ConsensusConfig{
+			"X": {K: 25, Alpha: 20},
+		},
+	}).(*manager)
+	assert.NoError(t, m.Alias(chainID, "X"))
+
+	params, err := m.consensusParams(chainID)
+	assert.NoError(t, err)
+	assert.Equal(t, 25, params.K)
+	assert.Equal(t, 20, params.Alpha)
+
+	params, err = m.consensusParams(ids.GenerateTestID())
+	assert.NoError(t, err)
+	assert.Equal(t, testConsensusParams(), params)
+}
+
+func TestConsensusParamsInvalid(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	m := New(&ManagerConfig{
+		ConsensusParams: testConsensusParams(),
+		ConsensusConfigs: map[string]ConsensusConfig{
+			chainID.String(): {K: 10}, // Alpha > K
+		},
+	}).(*manager)
+
+	_, err := m.consensusParams(chainID)
+	assert.Error(t, err)
+}
+
+// newTestRunningParams returns a manager running the chain [chainID] with
+// [params], and the parameters set on the chain's engine
+func newTestRunningParams(chainID ids.ID, params avcon.Parameters) (*manager, *avcon.Parameters) {
+	m := New(&ManagerConfig{Log: logging.NoLog{}}).(*manager)
+	engineParams := params
+	m.chainParams[chainID.Key()] = &runningParams{
+		lock:   &sync.Mutex{},
+		params: params,
+		set:    func(params avcon.Parameters) { engineParams = params },
+	}
+	return m, &engineParams
+}
+
+func TestSetConsensusParameters(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	m, engineParams := newTestRunningParams(chainID, testConsensusParams())
+
+	params, err := m.SetConsensusParameters(chainID, ConsensusConfig{
+		K:                 25,
+		MaxK:              28,
+		ConcurrentRepolls: 2,
+	})
+	assert.NoError(t, err)
+
+	expected := testConsensusParams()
+	expected.K = 25
+	expected.MaxK = 28
+	expected.ConcurrentRepolls = 2
+	assert.Equal(t, expected, params)
+	assert.Equal(t, expected, *engineParams)
+
+	params, err = m.ConsensusParameters(chainID)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, params)
+}
+
+func TestSetConsensusParametersFixedThresholds(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	m, engineParams := newTestRunningParams(chainID, testConsensusParams())
+
+	for _, config := range []ConsensusConfig{
+		{Alpha: 16},
+		{BetaVirtuous: 10},
+		{K: 25, BetaRogue: 25},
+	} {
+		params, err := m.SetConsensusParameters(chainID, config)
+		assert.Equal(t, errFixedThresholds, err)
+		assert.Equal(t, testConsensusParams(), params)
+	}
+	assert.Equal(t, testConsensusParams(), *engineParams)
+}
+
+func TestSetConsensusParametersInvalid(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	m, engineParams := newTestRunningParams(chainID, testConsensusParams())
+
+	// Alpha must be a majority of K
+	params, err := m.SetConsensusParameters(chainID, ConsensusConfig{K: 40})
+	assert.Error(t, err)
+	assert.Equal(t, testConsensusParams(), params)
+	assert.Equal(t, testConsensusParams(), *engineParams)
+
+	// MaxK can't be less than K
+	_, err = m.SetConsensusParameters(chainID, ConsensusConfig{MaxK: 10})
+	assert.Error(t, err)
+	assert.Equal(t, testConsensusParams(), *engineParams)
+}
+
+func TestSetConsensusParametersUnknownChain(t *testing.T) {
+	m, _ := newTestRunningParams(ids.GenerateTestID(), testConsensusParams())
+
+	_, err := m.SetConsensusParameters(ids.GenerateTestID(), ConsensusConfig{K: 25})
+	assert.Equal(t, errUnknownChain, err)
+}
@@ -0,0 +1,203 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+// lastAcceptedHookName is the name of the decision hook that tracks the last
+// accepted container of every chain
+const lastAcceptedHookName = "lastAccepted"
+
+var (
+	errNoneAccepted = errors.New("chain hasn't accepted a container")
+	errNoHeight     = errors.New("chain's blocks don't report their height")
+)
+
+// LastAccepted describes the vertex or block a chain accepted most recently
+type LastAccepted struct {
+	// ID of the container
+	ID ids.ID
+	// Height of the container. Only set if HasHeight is true, since the
+	// blocks of some VMs don't report their height.
+	Height    uint64
+	HasHeight bool
+	// Epoch the container was issued in. Blocks are always in epoch 0.
+	Epoch uint32
+	// Time the container was accepted by this node. If it was accepted before
+	// the chain started, the time the chain started.
+	Timestamp time.Time
+}
+
+// heightBlock is a block that reports its height
+type heightBlock interface {
+	Height() uint64
+}
+
+// acceptedContainers reads the vertices or blocks a chain accepted
+type acceptedContainers interface {
+	// Returns the last container the chain accepted, or false if the chain
+	// hasn't accepted a container
+	lastAccepted() (ids.ID, bool, error)
+
+	// Returns the height of an accepted container, or errNoHeight if the
+	// chain's containers don't report their height
+	height(containerID ids.ID) (uint64, error)
+
+	// Returns the epoch of an accepted container
+	epoch(containerID ids.ID) (uint32, error)
+}
+
+// acceptedBlocks reads the blocks accepted by a linear chain
+type acceptedBlocks struct {
+	vm block.ChainVM
+}
+
+func (b acceptedBlocks) lastAccepted() (ids.ID, bool, error) {
+	return b.vm.LastAccepted(), true, nil
+}
+
+func (b acceptedBlocks) height(blkID ids.ID) (uint64, error) {
+	blk, err := b.vm.GetBlock(blkID)
+	if err != nil {
+		return 0, err
+	}
+	hBlk, ok := blk.(heightBlock)
+	if !ok {
+		return 0, errNoHeight
+	}
+	return hBlk.Height(), nil
+}
+
+func (acceptedBlocks) epoch(ids.ID) (uint32, error) { return 0, nil }
+
+// acceptedVertices reads the vertices accepted by a DAG-based chain
+type acceptedVertices struct {
+	manager vertex.Manager
+}
+
+// lastAccepted returns the highest vertex of the accepted frontier
+func (v acceptedVertices) lastAccepted() (ids.ID, bool, error) {
+	var (
+		lastAccepted ids.ID
+		maxHeight    uint64
+		found        bool
+	)
+	for _, vtxID := range v.manager.Edge() {
+		height, err := v.height(vtxID)
+		if err != nil {
+			return ids.ID{}, false, err
+		}
+		if !found || height > maxHeight {
+			lastAccepted = vtxID
+			maxHeight = height
+			found = true
+		}
+	}
+	return lastAccepted, found, nil
+}
+
+func (v acceptedVertices) height(vtxID ids.ID) (uint64, error) {
+	vtx, err := v.manager.GetVertex(vtxID)
+	if err != nil {
+		return 0, err
+	}
+	return vtx.Height()
+}
+
+func (v acceptedVertices) epoch(vtxID ids.ID) (uint32, error) {
+	vtx, err := v.manager.GetVertex(vtxID)
+	if err != nil {
+		return 0, err
+	}
+	return vtx.Epoch()
+}
+
+// lastAcceptedTracker is notified of the containers a chain decides, and
+// remembers the last vertex or block it accepted. Transactions are ignored.
+type lastAcceptedTracker struct {
+	// the chain's context lock, which must be held to access the tracker
+	lock sync.Locker
+	// the containers the chain accepted. Assumes [lock] is held.
+	containers acceptedContainers
+
+	accepted    bool
+	containerID ids.ID
+	timestamp   time.Time
+}
+
+// newLastAcceptedTracker returns a tracker of the containers accepted by a
+// chain, which starts from the last container the chain accepted before it
+// started, since no decision is made about that container again
+func newLastAcceptedTracker(lock sync.Locker, containers acceptedContainers) (*lastAcceptedTracker, error) {
+	containerID, accepted, err := containers.lastAccepted()
+	if err != nil {
+		return nil, err
+	}
+	return &lastAcceptedTracker{
+		lock:        lock,
+		containers:  containers,
+		accepted:    accepted,
+		containerID: containerID,
+		timestamp:   time.Now(),
+	}, nil
+}
+
+func (t *lastAcceptedTracker) Accepted(_ *snow.Context, decision snow.Decision) {
+	if decision.Type == snow.TxContainer {
+		return
+	}
+	t.accepted = true
+	t.containerID = decision.ID
+	t.timestamp = time.Now()
+}
+
+func (t *lastAcceptedTracker) Rejected(*snow.Context, snow.Decision) {}
+
+// lastAccepted returns the container the chain accepted most recently
+func (t *lastAcceptedTracker) lastAccepted() (LastAccepted, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if !t.accepted {
+		return LastAccepted{}, errNoneAccepted
+	}
+	lastAccepted := LastAccepted{
+		ID:        t.containerID,
+		Timestamp: t.timestamp,
+	}
+	height, err := t.containers.height(t.containerID)
+	switch err {
+	case nil:
+		lastAccepted.Height = height
+		lastAccepted.HasHeight = true
+	case errNoHeight:
+	default:
+		return LastAccepted{}, err
+	}
+	lastAccepted.Epoch, err = t.containers.epoch(t.containerID)
+	if err != nil {
+		return LastAccepted{}, err
+	}
+	return lastAccepted, nil
+}
+
+// LastAccepted implements the Manager interface
+func (m *manager) LastAccepted(chainID ids.ID) (LastAccepted, error) {
+	m.chainsLock.Lock()
+	tracker, exists := m.chainLastAccepted[chainID.Key()]
+	m.chainsLock.Unlock()
+	if !exists {
+		return LastAccepted{}, errUnknownChain
+	}
+	return tracker.lastAccepted()
+}
@@ -0,0 +1,200 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+// noHeightBlock is a block that doesn't report its height, such as a block of
+// a VM that runs as a plugin
+type noHeightBlock struct {
+	snowman.Block
+}
+
+func newTestBlockVM(t *testing.T, lastAcceptedID ids.ID, blks ...snowman.Block) *block.TestVM {
+	vm := &block.TestVM{}
+	vm.T = t
+	vm.LastAcceptedF = func() ids.ID { return lastAcceptedID }
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID().Equals(blkID) {
+				return blk, nil
+			}
+		}
+		return nil, errors.New("unknown block")
+	}
+	return vm
+}
+
+func TestLastAcceptedSeededFromVM(t *testing.T) {
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV: 5,
+	}
+	vm := newTestBlockVM(t, blk.ID(), blk)
+
+	tracker, err := newLastAcceptedTracker(&sync.Mutex{}, acceptedBlocks{vm: vm})
+	assert.NoError(t, err)
+
+	lastAccepted, err := tracker.lastAccepted()
+	assert.NoError(t, err)
+	assert.Equal(t, blk.ID(), lastAccepted.ID)
+	assert.True(t, lastAccepted.HasHeight)
+	assert.Equal(t, uint64(5), lastAccepted.Height)
+	assert.Equal(t, uint32(0), lastAccepted.Epoch)
+	assert.False(t, lastAccepted.Timestamp.IsZero())
+}
+
+func TestLastAcceptedTracksDecisions(t *testing.T) {
+	genesis := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+	}
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		ParentV: genesis,
+		HeightV: 1,
+	}
+	vm := newTestBlockVM(t, genesis.ID(), genesis, blk)
+
+	tracker, err := newLastAcceptedTracker(&sync.Mutex{}, acceptedBlocks{vm: vm})
+	assert.NoError(t, err)
+	seeded, err := tracker.lastAccepted()
+	assert.NoError(t, err)
+
+	tracker.Accepted(nil, snow.Decision{Type: snow.BlockContainer, ID: blk.ID()})
+	tracker.Accepted(nil, snow.Decision{Type: snow.TxContainer, ID: ids.GenerateTestID()})
+	tracker.Rejected(nil, snow.Decision{Type: snow.BlockContainer, ID: ids.GenerateTestID()})
+
+	lastAccepted, err := tracker.lastAccepted()
+	assert.NoError(t, err)
+	assert.Equal(t, blk.ID(), lastAccepted.ID)
+	assert.Equal(t, uint64(1), lastAccepted.Height)
+	assert.False(t, lastAccepted.Timestamp.Before(seeded.Timestamp))
+}
+
+func TestLastAcceptedWithoutHeight(t *testing.T) {
+	blk := noHeightBlock{&snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV: 5,
+	}}
+	vm := newTestBlockVM(t, blk.ID(), blk)
+
+	tracker, err := newLastAcceptedTracker(&sync.Mutex{}, acceptedBlocks{vm: vm})
+	assert.NoError(t, err)
+
+	lastAccepted, err := tracker.lastAccepted()
+	assert.NoError(t, err)
+	assert.Equal(t, blk.ID(), lastAccepted.ID)
+	assert.False(t, lastAccepted.HasHeight)
+	assert.False(t, lastAccepted.Timestamp.IsZero())
+}
+
+func TestLastAcceptedUnknownBlock(t *testing.T) {
+	vm := newTestBlockVM(t, ids.GenerateTestID())
+
+	tracker, err := newLastAcceptedTracker(&sync.Mutex{}, acceptedBlocks{vm: vm})
+	assert.NoError(t, err)
+
+	_, err = tracker.lastAccepted()
+	assert.Error(t, err)
+}
+
+func newTestVertexManager(t *testing.T, edge []ids.ID, vtxs ...avalanche.Vertex) *vertex.TestManager {
+	manager := &vertex.TestManager{T: t}
+	manager.EdgeF = func() []ids.ID { return edge }
+	manager.GetVertexF = func(vtxID ids.ID) (avalanche.Vertex, error) {
+		for _, vtx := range vtxs {
+			if vtx.ID().Equals(vtxID) {
+				return vtx, nil
+			}
+		}
+		return nil, errors.New("unknown vertex")
+	}
+	return manager
+}
+
+func TestLastAcceptedSeededFromEdge(t *testing.T) {
+	vtx0 := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV: 3,
+	}
+	vtx1 := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV: 4,
+		EpochV:  2,
+	}
+	manager := newTestVertexManager(t, []ids.ID{vtx0.ID(), vtx1.ID()}, vtx0, vtx1)
+
+	tracker, err := newLastAcceptedTracker(&sync.Mutex{}, acceptedVertices{manager: manager})
+	assert.NoError(t, err)
+
+	lastAccepted, err := tracker.lastAccepted()
+	assert.NoError(t, err)
+	assert.Equal(t, vtx1.ID(), lastAccepted.ID)
+	assert.True(t, lastAccepted.HasHeight)
+	assert.Equal(t, uint64(4), lastAccepted.Height)
+	assert.Equal(t, uint32(2), lastAccepted.Epoch)
+}
+
+func TestLastAcceptedNoneAccepted(t *testing.T) {
+	vtx := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV: 1,
+	}
+	manager := newTestVertexManager(t, nil, vtx)
+
+	tracker, err := newLastAcceptedTracker(&sync.Mutex{}, acceptedVertices{manager: manager})
+	assert.NoError(t, err)
+
+	_, err = tracker.lastAccepted()
+	assert.Equal(t, errNoneAccepted, err)
+
+	tracker.Accepted(nil, snow.Decision{Type: snow.VertexContainer, ID: vtx.ID()})
+
+	lastAccepted, err := tracker.lastAccepted()
+	assert.NoError(t, err)
+	assert.Equal(t, vtx.ID(), lastAccepted.ID)
+	assert.Equal(t, uint64(1), lastAccepted.Height)
+}
+
+func TestLastAcceptedUnknownChain(t *testing.T) {
+	m := New(&ManagerConfig{}).(*manager)
+
+	_, err := m.LastAccepted(ids.GenerateTestID())
+	assert.Equal(t, errUnknownChain, err)
+}
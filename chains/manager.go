@@ -6,13 +6,19 @@ package chains
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/cache/metercacher"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/meterdb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network"
@@ -22,6 +28,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/common/queue"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/archive"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
@@ -43,6 +50,10 @@ import (
 
 const (
 	defaultChannelSize = 1024
+
+	// Maximum amount of time to wait for a single response from a bootstrap
+	// archive
+	archiveRequestTimeout = time.Minute
 )
 
 // Manager manages the chains running on this node.
@@ -84,6 +95,34 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Returns how far along bootstrapping the provided chain is
+	BootstrapProgress(chainID ids.ID) (snow.BootstrapProgress, error)
+
+	// Returns the IDs of the chains that have been created, sorted
+	Chains() []ids.ID
+
+	// Returns the ID of the VM the provided chain is running
+	VMID(chainID ids.ID) (ids.ID, error)
+
+	// Returns the databases the provided chain's VM and consensus engine were
+	// given
+	Databases(chainID ids.ID) ([]database.Database, error)
+
+	// Returns the consensus parameters the provided chain is running with
+	ConsensusParameters(chainID ids.ID) (avcon.Parameters, error)
+
+	// Changes the consensus parameters of the provided chain that can change
+	// while it's running, and returns the parameters it's running with
+	SetConsensusParameters(chainID ids.ID, config ConsensusConfig) (avcon.Parameters, error)
+
+	// Returns the vertices the provided chain's Avalanche consensus is
+	// processing. Returns an error if the chain doesn't run Avalanche.
+	ConsensusGraph(chainID ids.ID) (avcon.Graph, error)
+
+	// Returns the vertex or block the provided chain accepted most recently,
+	// along with its height, its epoch and when it was accepted
+	LastAccepted(chainID ids.ID) (LastAccepted, error)
+
 	Shutdown()
 }
 
@@ -103,7 +142,23 @@ type chain struct {
 	Handler *router.Handler
 	Ctx     *snow.Context
 	VM      interface{}
+	VMID    ids.ID
 	Beacons validators.Set
+	DBs     []database.Database
+
+	// The consensus parameters the chain was created with, and a function
+	// that sets those that can change while it runs on its engine
+	Params    avcon.Parameters
+	SetParams func(avcon.Parameters)
+
+	// Returns the vertices being processed by the chain's engine. Nil if the
+	// chain doesn't run Avalanche.
+	Graph func() (avcon.Graph, error)
+
+	// Reads the vertices or blocks the chain accepted
+	Accepted acceptedContainers
+	// Tracks the last vertex or block the chain accepted
+	LastAccepted *lastAcceptedTracker
 }
 
 // ManagerConfig ...
@@ -129,9 +184,45 @@ type ManagerConfig struct {
 	AtomicMemory            *atomic.Memory
 	AVAXAssetID             ids.ID
 	XChainID                ids.ID
-	CriticalChains          ids.Set          // Chains that can't exit gracefully
-	TimeoutManager          *timeout.Manager // Manages request timeouts when sending messages to other validators
+	CriticalChains          ids.Set           // Chains that can't exit gracefully
+	TimeoutManager          *timeout.Manager  // Manages request timeouts when sending messages to other validators
+	PeerReporter            snow.PeerReporter // Scores peers by whether the containers they send are valid
 	HealthService           *health.Health
+
+	// If non-empty, snowman chains first attempt to fetch blocks during
+	// bootstrapping from the archive of the node at this URI
+	BootstrapArchiveURI       string
+	BootstrapArchiveAuthToken string
+
+	// Maximum number of GetAncestors requests a bootstrapping chain sends to
+	// each beacon at once. If 0, the default window is used.
+	BootstrapFetchWindow int
+
+	// Minimum time between checkpoints of a chain's consensus state
+	CheckpointFrequency time.Duration
+	// Maximum age of a checkpoint a chain resumes consensus from on startup,
+	// instead of bootstrapping from the network. If 0, chains always
+	// bootstrap.
+	MaxCheckpointAge time.Duration
+
+	// Sizes of the deduplication caches of chains, by chain ID or alias.
+	// Chains that aren't in the map use the default sizes.
+	CacheSizes map[string]CacheSizes
+
+	// Overrides of the consensus parameters of chains, by chain ID or alias
+	ConsensusConfigs map[string]ConsensusConfig
+
+	// If true, avalanche chains delete rejected vertices from their databases
+	PruneRejected bool
+
+	// Chains, by chain ID or alias, whose consensus messages are recorded to
+	// files in RecordDir so that they can be replayed
+	RecordChains []string
+	RecordDir    string
+
+	// Chains, by chain ID or alias, that aren't run even though this node
+	// validates their subnet. Chains of the primary network are always run.
+	SkippedChains []string
 }
 
 type manager struct {
@@ -149,6 +240,21 @@ type manager struct {
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[[32]byte]*router.Handler
+	// Key: Chain's ID
+	// Value: ID of the VM the chain is running
+	chainVMs map[[32]byte]ids.ID
+	// Key: Chain's ID
+	// Value: The databases the chain's VM and consensus engine were given
+	chainDBs map[[32]byte][]database.Database
+	// Key: Chain's ID
+	// Value: The consensus parameters the chain is running with
+	chainParams map[[32]byte]*runningParams
+	// Key: ID of a chain running Avalanche
+	// Value: Dumps the vertices the chain is processing
+	chainGraphs map[[32]byte]*runningGraph
+	// Key: Chain's ID
+	// Value: Tracks the last vertex or block the chain accepted
+	chainLastAccepted map[[32]byte]*lastAcceptedTracker
 }
 
 // New returns a new Manager where:
@@ -158,8 +264,13 @@ type manager struct {
 // TODO: Make this function take less arguments
 func New(config *ManagerConfig) Manager {
 	m := &manager{
-		ManagerConfig: *config,
-		chains:        make(map[[32]byte]*router.Handler),
+		ManagerConfig:     *config,
+		chains:            make(map[[32]byte]*router.Handler),
+		chainVMs:          make(map[[32]byte]ids.ID),
+		chainDBs:          make(map[[32]byte][]database.Database),
+		chainParams:       make(map[[32]byte]*runningParams),
+		chainGraphs:       make(map[[32]byte]*runningGraph),
+		chainLastAccepted: make(map[[32]byte]*lastAcceptedTracker),
 	}
 	m.Initialize()
 	return m
@@ -177,6 +288,22 @@ func (m *manager) CreateChain(chain ChainParameters) {
 	}
 }
 
+// skipped returns true if the chain described by [chainParams] is configured
+// not to be run. Chains of the primary network are never skipped.
+func (m *manager) skipped(chainParams ChainParameters) bool {
+	if chainParams.SubnetID.Equals(constants.PrimaryNetworkID) {
+		return false
+	}
+	for _, key := range m.chainKeys(chainParams.ID) {
+		for _, chain := range m.SkippedChains {
+			if chain == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Create a chain
 func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 	// Assert that there isn't already a chain with an alias in [chain].Aliases
@@ -187,6 +314,13 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 		return
 	}
 
+	if m.skipped(chainParams) {
+		m.Log.Info("not creating chain %s of subnet %s as it's configured to be skipped",
+			chainParams.ID,
+			chainParams.SubnetID)
+		return
+	}
+
 	m.Log.Info("creating chain:\n"+
 		"    ID: %s\n"+
 		"    VMID:%s",
@@ -203,6 +337,20 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 
 	m.chainsLock.Lock()
 	m.chains[chainID] = chain.Handler
+	m.chainVMs[chainID] = chain.VMID
+	m.chainDBs[chainID] = chain.DBs
+	m.chainParams[chainID] = &runningParams{
+		lock:   &chain.Ctx.Lock,
+		params: chain.Params,
+		set:    chain.SetParams,
+	}
+	if chain.Graph != nil {
+		m.chainGraphs[chainID] = &runningGraph{
+			lock:  &chain.Ctx.Lock,
+			graph: chain.Graph,
+		}
+	}
+	m.chainLastAccepted[chainID] = chain.LastAccepted
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
@@ -230,6 +378,12 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 		return nil, fmt.Errorf("error while creating chain's log %w", err)
 	}
 
+	// Each of the chain's metrics is registered under the chain's namespace
+	// and labelled with its primary alias, so that the metrics of chains that
+	// have no human readable alias can still be told apart
+	namespace := fmt.Sprintf("%s_%s", constants.PlatformName, metricNamespace(primaryAlias))
+	chainMetrics := prometheus.WrapRegistererWith(prometheus.Labels{"chain": primaryAlias}, m.ConsensusParams.Metrics)
+
 	ctx := &snow.Context{
 		NetworkID:           m.NetworkID,
 		SubnetID:            chainParams.SubnetID,
@@ -244,8 +398,14 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 		SharedMemory:        m.AtomicMemory.NewSharedMemory(chainParams.ID),
 		BCLookup:            m,
 		SNLookup:            m,
-		Namespace:           fmt.Sprintf("%s_%s_vm", constants.PlatformName, primaryAlias),
-		Metrics:             m.ConsensusParams.Metrics,
+		PeerReporter:        m.PeerReporter,
+		Namespace:           fmt.Sprintf("%s_vm", namespace),
+		Metrics:             chainMetrics,
+	}
+	ctx.Health = &chainHealthRegisterer{
+		health: m.HealthService,
+		lock:   &ctx.Lock,
+		chain:  primaryAlias,
 	}
 
 	// Get a factory for the vm we want to use on our chain
@@ -286,8 +446,12 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 		}
 	}
 
-	consensusParams := m.ConsensusParams
-	consensusParams.Namespace = fmt.Sprintf("%s_%s", constants.PlatformName, primaryAlias)
+	consensusParams, err := m.consensusParams(chainParams.ID)
+	if err != nil {
+		return nil, err
+	}
+	consensusParams.Namespace = namespace
+	consensusParams.Metrics = chainMetrics
 
 	// The validators of this blockchain
 	var vdrs validators.Set // Validators validating this blockchain
@@ -342,6 +506,18 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 		return nil, fmt.Errorf("the vm should have type avalanche.DAGVM or snowman.ChainVM. Chain not created")
 	}
 
+	chain.VMID = vmID
+	chain.Params = consensusParams
+
+	// Track the last vertex or block the chain accepts
+	chain.LastAccepted, err = newLastAcceptedTracker(&ctx.Lock, chain.Accepted)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the last accepted container: %w", err)
+	}
+	if err := ctx.RegisterDecisionHook(lastAcceptedHookName, chain.LastAccepted); err != nil {
+		return nil, err
+	}
+
 	// Register the chain with the timeout manager
 	if err := m.TimeoutManager.RegisterChain(ctx, consensusParams.Namespace); err != nil {
 		return nil, err
@@ -373,6 +549,31 @@ func (m *manager) unblockChains() {
 	}
 }
 
+// chainDB returns the database of the chain described by [ctx], whose
+// operations are measured under [namespace]
+func (m *manager) chainDB(ctx *snow.Context, namespace string) (database.Database, error) {
+	meterDB, err := meterdb.New(fmt.Sprintf("%s_db", namespace), ctx.Metrics, m.DB)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't register database metrics: %w", err)
+	}
+	// Neither [m.DB] nor [meterDB] is a prefixdb.Database, so the chain's keys
+	// are the same as if [m.DB] were prefixed directly
+	return prefixdb.New(ctx.ChainID.Bytes(), meterDB), nil
+}
+
+// metricNamespace returns [alias] with each character that isn't allowed in
+// a metric name replaced by an underscore
+func metricNamespace(alias string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, alias)
+}
+
 // Create a DAG-based blockchain that uses Avalanche
 func (m *manager) createAvalancheChain(
 	ctx *snow.Context,
@@ -387,11 +588,15 @@ func (m *manager) createAvalancheChain(
 	ctx.Lock.Lock()
 	defer ctx.Lock.Unlock()
 
-	db := prefixdb.New(ctx.ChainID.Bytes(), m.DB)
+	db, err := m.chainDB(ctx, consensusParams.Namespace)
+	if err != nil {
+		return nil, err
+	}
 	vmDB := prefixdb.New([]byte("vm"), db)
 	vertexDB := prefixdb.New([]byte("vertex"), db)
 	vertexBootstrappingDB := prefixdb.New([]byte("vertex_bs"), db)
 	txBootstrappingDB := prefixdb.New([]byte("tx_bs"), db)
+	checkpointDB := prefixdb.New([]byte("checkpoint"), db)
 
 	vtxBlocker, err := queue.New(vertexBootstrappingDB)
 	if err != nil {
@@ -410,9 +615,25 @@ func (m *manager) createAvalancheChain(
 		return nil, fmt.Errorf("error during vm's Initialize: %w", err)
 	}
 
+	cacheSizes := m.cacheSizes(ctx.ChainID)
+	vtxCacheObserver, err := metercacher.New(fmt.Sprintf("%s_vtx_cache", consensusParams.Namespace), consensusParams.Metrics)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't register vertex cache metrics: %w", err)
+	}
+	uniqueVtxObserver, err := metercacher.New(fmt.Sprintf("%s_unique_vtx_cache", consensusParams.Namespace), consensusParams.Metrics)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't register unique vertex cache metrics: %w", err)
+	}
+
 	// Handles serialization/deserialization of vertices and also the
 	// persistence of vertices
-	vtxManager := &state.Serializer{}
+	vtxManager := &state.Serializer{
+		VertexCacheSize:      cacheSizes.Vertices,
+		IDCacheSize:          cacheSizes.VertexIDs,
+		VertexCacheObserver:  vtxCacheObserver,
+		UniqueVertexObserver: uniqueVtxObserver,
+		PruneRejected:        m.PruneRejected,
+	}
 	vtxManager.Initialize(ctx, vm, vertexDB)
 
 	// Passes messages from the consensus engine to the network
@@ -424,6 +645,19 @@ func (m *manager) createAvalancheChain(
 		sampleK = int(bootstrapWeight)
 	}
 
+	// Records the messages the engine handles, if the chain is recorded
+	recorder, err := m.recorder(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if recorder != nil {
+		validators = recorder.Validators(validators)
+		beacons = recorder.Validators(beacons)
+	}
+
+	// The VM is state synced before it's bootstrapped, if it supports it
+	stateSyncVM, _ := vm.(common.StateSyncableVM)
+
 	// The engine handles consensus
 	engine := &aveng.Transitive{}
 	if err := engine.Initialize(aveng.Config{
@@ -436,11 +670,19 @@ func (m *manager) createAvalancheChain(
 				StartupAlpha: (3*bootstrapWeight + 3) / 4,
 				Alpha:        bootstrapWeight/2 + 1, // must be > 50%
 				Sender:       &sender,
+				FetchWindow:  m.BootstrapFetchWindow,
+				StateSyncVM:  stateSyncVM,
+
+				CheckpointDB:        checkpointDB,
+				CheckpointFrequency: m.CheckpointFrequency,
+				MaxCheckpointAge:    m.MaxCheckpointAge,
 			},
 			VtxBlocked: vtxBlocker,
 			TxBlocked:  txBlocker,
 			Manager:    vtxManager,
 			VM:         vm,
+
+			ProcessedCacheSize: cacheSizes.ProcessedVertices,
 		},
 		Params:    consensusParams,
 		Consensus: &avcon.Topological{},
@@ -454,7 +696,7 @@ func (m *manager) createAvalancheChain(
 		chainAlias = ctx.ChainID.String()
 	}
 	wrapperHc := &healthCheckWrapper{
-		chain: chainAlias,
+		name:  chainAlias,
 		lock:  &ctx.Lock,
 		check: engine.Health,
 	}
@@ -462,10 +704,16 @@ func (m *manager) createAvalancheChain(
 		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
 	}
 
+	var handlerEngine common.Engine = engine
+	if recorder != nil {
+		recorder.Engine = engine
+		handlerEngine = recorder
+	}
+
 	// Asynchronously passes messages from the network to the consensus engine
 	handler := &router.Handler{}
 	handler.Initialize(
-		engine,
+		handlerEngine,
 		validators,
 		msgChan,
 		defaultChannelSize,
@@ -481,6 +729,17 @@ func (m *manager) createAvalancheChain(
 		Handler: handler,
 		VM:      vm,
 		Ctx:     ctx,
+		DBs:     []database.Database{vmDB, vertexDB, vertexBootstrappingDB, txBootstrappingDB, checkpointDB},
+		SetParams: func(params avcon.Parameters) {
+			engine.Params.K = params.K
+			engine.Params.MaxK = params.MaxK
+			engine.Params.ConcurrentRepolls = params.ConcurrentRepolls
+			engine.Params.Parents = params.Parents
+			engine.Params.BatchSize = params.BatchSize
+			engine.Params.MaxBatchSize = params.MaxBatchSize
+		},
+		Graph:    engine.Graph,
+		Accepted: acceptedVertices{manager: vtxManager},
 	}, nil
 }
 
@@ -498,9 +757,13 @@ func (m *manager) createSnowmanChain(
 	ctx.Lock.Lock()
 	defer ctx.Lock.Unlock()
 
-	db := prefixdb.New(ctx.ChainID.Bytes(), m.DB)
+	db, err := m.chainDB(ctx, consensusParams.Namespace)
+	if err != nil {
+		return nil, err
+	}
 	vmDB := prefixdb.New([]byte("vm"), db)
 	bootstrappingDB := prefixdb.New([]byte("bs"), db)
+	checkpointDB := prefixdb.New([]byte("checkpoint"), db)
 
 	blocked, err := queue.New(bootstrappingDB)
 	if err != nil {
@@ -525,6 +788,33 @@ func (m *manager) createSnowmanChain(
 		sampleK = int(bootstrapWeight)
 	}
 
+	var (
+		fetcher       *archiveFetcher
+		archiveClient smbootstrap.Archive
+	)
+	if m.BootstrapArchiveURI != "" {
+		fetcher = newArchiveFetcher(ctx.Log, archive.NewClient(
+			m.BootstrapArchiveURI,
+			ctx.ChainID,
+			m.BootstrapArchiveAuthToken,
+			archiveRequestTimeout,
+		))
+		archiveClient = fetcher
+	}
+
+	// Records the messages the engine handles, if the chain is recorded
+	recorder, err := m.recorder(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if recorder != nil {
+		validators = recorder.Validators(validators)
+		beacons = recorder.Validators(beacons)
+	}
+
+	// The VM is state synced before it's bootstrapped, if it supports it
+	stateSyncVM, _ := vm.(common.StateSyncableVM)
+
 	// The engine handles consensus
 	engine := &smeng.Transitive{}
 	if err := engine.Initialize(smeng.Config{
@@ -537,9 +827,16 @@ func (m *manager) createSnowmanChain(
 				StartupAlpha: (3*bootstrapWeight + 3) / 4,
 				Alpha:        bootstrapWeight/2 + 1, // must be > 50%
 				Sender:       &sender,
+				FetchWindow:  m.BootstrapFetchWindow,
+				StateSyncVM:  stateSyncVM,
+
+				CheckpointDB:        checkpointDB,
+				CheckpointFrequency: m.CheckpointFrequency,
+				MaxCheckpointAge:    m.MaxCheckpointAge,
 			},
 			Blocked:      blocked,
 			VM:           vm,
+			Archive:      archiveClient,
 			Bootstrapped: m.unblockChains,
 		},
 		Params:    consensusParams,
@@ -548,10 +845,16 @@ func (m *manager) createSnowmanChain(
 		return nil, fmt.Errorf("error initializing snowman engine: %w", err)
 	}
 
+	var handlerEngine common.Engine = engine
+	if recorder != nil {
+		recorder.Engine = engine
+		handlerEngine = recorder
+	}
+
 	// Asynchronously passes messages from the network to the consensus engine
 	handler := &router.Handler{}
 	handler.Initialize(
-		engine,
+		handlerEngine,
 		validators,
 		msgChan,
 		defaultChannelSize,
@@ -561,6 +864,9 @@ func (m *manager) createSnowmanChain(
 		fmt.Sprintf("%s_handler", consensusParams.Namespace),
 		consensusParams.Metrics,
 	)
+	if fetcher != nil {
+		fetcher.setResponder(handler)
+	}
 
 	// Register health checks
 	chainAlias, err := m.PrimaryAlias(ctx.ChainID)
@@ -569,7 +875,7 @@ func (m *manager) createSnowmanChain(
 	}
 
 	wrapperHc := &healthCheckWrapper{
-		chain: chainAlias,
+		name:  chainAlias,
 		lock:  &ctx.Lock,
 		check: engine.Health,
 	}
@@ -582,6 +888,13 @@ func (m *manager) createSnowmanChain(
 		Handler: handler,
 		VM:      vm,
 		Ctx:     ctx,
+		DBs:     []database.Database{vmDB, bootstrappingDB, checkpointDB},
+		SetParams: func(params avcon.Parameters) {
+			engine.Params.K = params.K
+			engine.Params.MaxK = params.MaxK
+			engine.Params.ConcurrentRepolls = params.ConcurrentRepolls
+		},
+		Accepted: acceptedBlocks{vm: vm},
 	}, nil
 }
 
@@ -596,6 +909,28 @@ func (m *manager) SubnetID(chainID ids.ID) (ids.ID, error) {
 	return chain.Context().SubnetID, nil
 }
 
+func (m *manager) VMID(chainID ids.ID) (ids.ID, error) {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	vmID, exists := m.chainVMs[chainID.Key()]
+	if !exists {
+		return ids.ID{}, errors.New("unknown chain ID")
+	}
+	return vmID, nil
+}
+
+func (m *manager) Databases(chainID ids.ID) ([]database.Database, error) {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	dbs, exists := m.chainDBs[chainID.Key()]
+	if !exists {
+		return nil, errors.New("unknown chain ID")
+	}
+	return dbs, nil
+}
+
 func (m *manager) IsBootstrapped(id ids.ID) bool {
 	m.chainsLock.Lock()
 	chain, exists := m.chains[id.Key()]
@@ -607,6 +942,29 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Engine().IsBootstrapped()
 }
 
+func (m *manager) BootstrapProgress(chainID ids.ID) (snow.BootstrapProgress, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID.Key()]
+	m.chainsLock.Unlock()
+	if !exists {
+		return snow.BootstrapProgress{}, errors.New("unknown chain ID")
+	}
+
+	return chain.Context().BootstrapProgress(), nil
+}
+
+func (m *manager) Chains() []ids.ID {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	chainIDs := make([]ids.ID, 0, len(m.chains))
+	for key := range m.chains {
+		chainIDs = append(chainIDs, ids.NewID(key))
+	}
+	ids.SortIDs(chainIDs)
+	return chainIDs
+}
+
 // Shutdown stops all the chains
 func (m *manager) Shutdown() {
 	m.ManagerConfig.Router.Shutdown()
@@ -643,13 +1001,45 @@ type healthCheckWrapper struct {
 	// Grabs/releases this before/after health check func
 	lock *sync.RWMutex
 
-	// Alias/ID of chain this health check is for
-	chain string
+	// Alias/ID of the chain this health check is for, followed by the name of
+	// the check if it was registered by the chain's VM
+	name string
 }
 
 // Name is this health check's formatted name
 func (hc *healthCheckWrapper) Name() string {
-	return hc.chain
+	return hc.name
+}
+
+// chainHealthRegisterer lets a chain's VM add its own health checks. Each
+// check is named [chain].[name] and run while holding the chain's lock.
+type chainHealthRegisterer struct {
+	health *health.Health
+	lock   *sync.RWMutex
+	chain  string
+
+	// names of the checks the chain registered
+	namesLock sync.Mutex
+	names     map[string]struct{}
+}
+
+// RegisterHealthCheck implements the snow.HealthRegisterer interface
+func (r *chainHealthRegisterer) RegisterHealthCheck(name string, checkFn func() (interface{}, error)) error {
+	r.namesLock.Lock()
+	defer r.namesLock.Unlock()
+
+	if _, exists := r.names[name]; exists {
+		return fmt.Errorf("health check %q is already registered", name)
+	}
+	if r.names == nil {
+		r.names = make(map[string]struct{})
+	}
+	r.names[name] = struct{}{}
+	return r.health.RegisterCheck(&healthCheckWrapper{
+		check: checkFn,
+		lock:  r.lock,
+		name:  fmt.Sprintf("%s.%s", r.chain, name),
+	})
 }
 
 // Execute executes the health check function with the lock
@@ -0,0 +1,73 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestSkippedByID(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	chainID := ids.GenerateTestID()
+	otherChainID := ids.GenerateTestID()
+
+	m := New(&ManagerConfig{
+		SkippedChains: []string{chainID.String()},
+	}).(*manager)
+
+	assert.True(t, m.skipped(ChainParameters{ID: chainID, SubnetID: subnetID}))
+	assert.False(t, m.skipped(ChainParameters{ID: otherChainID, SubnetID: subnetID}))
+}
+
+func TestSkippedByAlias(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	chainID := ids.GenerateTestID()
+	otherChainID := ids.GenerateTestID()
+
+	m := New(&ManagerConfig{
+		SkippedChains: []string{"skipme"},
+	}).(*manager)
+	assert.NoError(t, m.Alias(chainID, "skipme"))
+	assert.NoError(t, m.Alias(otherChainID, "runme"))
+
+	assert.True(t, m.skipped(ChainParameters{ID: chainID, SubnetID: subnetID}))
+	assert.False(t, m.skipped(ChainParameters{ID: otherChainID, SubnetID: subnetID}))
+}
+
+func TestSkippedNeverSkipsPrimaryNetwork(t *testing.T) {
+	chainID := ids.GenerateTestID()
+
+	m := New(&ManagerConfig{
+		SkippedChains: []string{chainID.String(), "X"},
+	}).(*manager)
+	assert.NoError(t, m.Alias(chainID, "X"))
+
+	assert.False(t, m.skipped(ChainParameters{ID: chainID, SubnetID: constants.PrimaryNetworkID}))
+}
+
+func TestChainHealthRegisterer(t *testing.T) {
+	h := health.NewService(logging.NoLog{})
+	r := &chainHealthRegisterer{
+		health: h,
+		lock:   &sync.RWMutex{},
+		chain:  "X",
+	}
+
+	checkFn := func() (interface{}, error) { return nil, nil }
+	assert.NoError(t, r.RegisterHealthCheck("custom", checkFn))
+	assert.Error(t, r.RegisterHealthCheck("custom", checkFn), "the name of each check must be unique")
+
+	reply := health.GetLivenessReply{}
+	assert.NoError(t, h.GetLiveness(nil, nil, &reply))
+	_, ok := reply.Checks["X.custom"]
+	assert.True(t, ok, "the check should be reported under the chain's alias")
+}
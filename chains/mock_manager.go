@@ -1,8 +1,12 @@
 package chains
 
 import (
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
+
+	avcon "github.com/ava-labs/avalanchego/snow/consensus/avalanche"
 )
 
 // MockManager implements Manager but does nothing. Always returns nil error.
@@ -53,3 +57,37 @@ func (mm MockManager) SubnetID(ids.ID) (ids.ID, error) { return ids.ID{}, nil }
 
 // IsBootstrapped ...
 func (mm MockManager) IsBootstrapped(ids.ID) bool { return false }
+
+// BootstrapProgress ...
+func (mm MockManager) BootstrapProgress(ids.ID) (snow.BootstrapProgress, error) {
+	return snow.BootstrapProgress{}, nil
+}
+
+// Chains ...
+func (mm MockManager) Chains() []ids.ID { return nil }
+
+// VMID ...
+func (mm MockManager) VMID(ids.ID) (ids.ID, error) { return ids.ID{}, nil }
+
+// Databases ...
+func (mm MockManager) Databases(ids.ID) ([]database.Database, error) { return nil, nil }
+
+// ConsensusParameters ...
+func (mm MockManager) ConsensusParameters(ids.ID) (avcon.Parameters, error) {
+	return avcon.Parameters{}, nil
+}
+
+// SetConsensusParameters ...
+func (mm MockManager) SetConsensusParameters(ids.ID, ConsensusConfig) (avcon.Parameters, error) {
+	return avcon.Parameters{}, nil
+}
+
+// ConsensusGraph ...
+func (mm MockManager) ConsensusGraph(ids.ID) (avcon.Graph, error) {
+	return avcon.Graph{}, nil
+}
+
+// LastAccepted ...
+func (mm MockManager) LastAccepted(ids.ID) (LastAccepted, error) {
+	return LastAccepted{}, nil
+}
@@ -0,0 +1,45 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/replay"
+)
+
+// recorder returns a recorder of the consensus messages of the chain described
+// by [ctx], or nil if the chain isn't recorded. The chain is recorded if its ID
+// or one of its aliases is in RecordChains.
+func (m *manager) recorder(ctx *snow.Context) (*replay.Recorder, error) {
+	recorded := false
+	for _, key := range m.chainKeys(ctx.ChainID) {
+		for _, chain := range m.RecordChains {
+			recorded = recorded || chain == key
+		}
+	}
+	if !recorded {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(m.RecordDir, 0700); err != nil {
+		return nil, fmt.Errorf("couldn't create recording directory: %w", err)
+	}
+	path := filepath.Join(m.RecordDir, fmt.Sprintf("%s-%d.rec", ctx.ChainID, time.Now().Unix()))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create recording: %w", err)
+	}
+	recorder, err := replay.NewRecorder(file, ctx.Log)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("couldn't start recording: %w", err)
+	}
+	ctx.Log.Info("recording consensus messages to %s", path)
+	return recorder, nil
+}
@@ -0,0 +1,247 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// ManifestFile is the name of the file, in the backup directory, that
+	// describes the backup
+	ManifestFile = "manifest.json"
+
+	// DBDir is the name of the directory, in the backup directory, that holds
+	// the copy of the database
+	DBDir = "db"
+
+	// batchSize is the number of bytes written to the target database at once
+	batchSize = 4 * 1024 * 1024
+
+	manifestPerms = 0600
+	dirPerms      = 0700
+)
+
+var (
+	errDirNotEmpty      = errors.New("backup directory isn't empty")
+	errTargetNotEmpty   = errors.New("database being restored to isn't empty")
+	errChecksumMismatch = errors.New("backup checksum doesn't match its manifest")
+	errNumKeysMismatch  = errors.New("number of keys in the backup doesn't match its manifest")
+)
+
+// ChainState describes the state of a chain at the time of a backup
+type ChainState struct {
+	ChainID string `json:"chainID"`
+	// LastAccepted is the ID of the chain's last accepted block, if the chain
+	// is linear
+	LastAccepted string `json:"lastAccepted,omitempty"`
+	// Height of the chain's last accepted block, if the chain is linear and
+	// its blocks report their height
+	Height uint64 `json:"height,omitempty"`
+}
+
+// Manifest describes the content of a backup
+type Manifest struct {
+	NodeVersion     string `json:"nodeVersion"`
+	DatabaseVersion string `json:"databaseVersion"`
+	NetworkID       uint32 `json:"networkID"`
+	// Timestamp is the unix time the backup was taken at
+	Timestamp int64 `json:"timestamp"`
+	// Chains whose state is in the backup. The state of each chain is recorded
+	// immediately before the snapshot is taken, so the backup contains at
+	// least the recorded blocks.
+	Chains []ChainState `json:"chains"`
+	// NumKeys is the number of key/value pairs in the backup
+	NumKeys uint64 `json:"numKeys"`
+	// Size is the number of bytes of keys and values in the backup
+	Size uint64 `json:"size"`
+	// Checksum is the hex encoded SHA-256 hash of the key/value pairs in the
+	// backup, in iteration order
+	Checksum string `json:"checksum"`
+}
+
+// Create writes a consistent snapshot of [db] to the directory [dir], along
+// with a manifest describing it. [manifest] should be populated with
+// everything other than the number of keys, the size and the checksum, which
+// are set by Create.
+//
+// [db] must return iterators that are consistent snapshots of the database, as
+// is the case for leveldb and memdb, so that the backup can be taken while the
+// database is in use.
+func Create(db database.Database, dir string, manifest *Manifest) error {
+	if err := ensureEmptyDir(dir); err != nil {
+		return err
+	}
+
+	target, err := leveldb.New(filepath.Join(dir, DBDir), 0, 0, 0)
+	if err != nil {
+		return fmt.Errorf("couldn't create backup database: %w", err)
+	}
+
+	numKeys, size, checksum, err := copyDB(db, target)
+	if closeErr := target.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't copy database: %w", err)
+	}
+
+	manifest.NumKeys = numKeys
+	manifest.Size = size
+	manifest.Checksum = checksum
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, ManifestFile), manifestBytes, manifestPerms)
+}
+
+// Verify checks that the backup in [dir] matches its manifest, and returns the
+// manifest
+func Verify(dir string) (*Manifest, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	backupDB, err := leveldb.New(filepath.Join(dir, DBDir), 0, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open backup database: %w", err)
+	}
+	defer backupDB.Close()
+
+	numKeys, _, checksum, err := copyDB(backupDB, nil)
+	switch {
+	case err != nil:
+		return nil, err
+	case numKeys != manifest.NumKeys:
+		return nil, fmt.Errorf("%w: expected %d but found %d", errNumKeysMismatch, manifest.NumKeys, numKeys)
+	case checksum != manifest.Checksum:
+		return nil, fmt.Errorf("%w: expected %s but found %s", errChecksumMismatch, manifest.Checksum, checksum)
+	}
+	return manifest, nil
+}
+
+// Restore verifies the backup in [dir] and copies it into [db], which must be
+// empty. Returns the manifest of the backup.
+func Restore(dir string, db database.Database) (*Manifest, error) {
+	manifest, err := Verify(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	it := db.NewIterator()
+	empty := !it.Next()
+	it.Release()
+	if !empty {
+		return nil, errTargetNotEmpty
+	}
+
+	backupDB, err := leveldb.New(filepath.Join(dir, DBDir), 0, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open backup database: %w", err)
+	}
+	defer backupDB.Close()
+
+	if _, _, _, err := copyDB(backupDB, db); err != nil {
+		return nil, fmt.Errorf("couldn't copy backup: %w", err)
+	}
+	return manifest, nil
+}
+
+// ReadManifest returns the manifest of the backup in [dir], without verifying
+// the backup
+func ReadManifest(dir string) (*Manifest, error) {
+	manifestBytes, err := ioutil.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read manifest: %w", err)
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(manifestBytes, manifest); err != nil {
+		return nil, fmt.Errorf("couldn't parse manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// copyDB iterates over [source], writing every key/value pair to [target] if
+// it is non-nil. Returns the number of keys, their size and the checksum of
+// the iterated pairs.
+func copyDB(source, target database.Database) (uint64, uint64, string, error) {
+	var (
+		numKeys uint64
+		size    uint64
+		h       = sha256.New()
+		batch   database.Batch
+	)
+	if target != nil {
+		batch = target.NewBatch()
+	}
+
+	it := source.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		key := it.Key()
+		value := it.Value()
+		hashPair(h, key, value)
+		numKeys++
+		size += uint64(len(key) + len(value))
+
+		if batch == nil {
+			continue
+		}
+		if err := batch.Put(key, value); err != nil {
+			return 0, 0, "", err
+		}
+		if batch.ValueSize() >= batchSize {
+			if err := batch.Write(); err != nil {
+				return 0, 0, "", err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return 0, 0, "", err
+	}
+	if batch != nil {
+		if err := batch.Write(); err != nil {
+			return 0, 0, "", err
+		}
+	}
+	return numKeys, size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashPair adds a length prefixed [key] and [value] to [h], so that the
+// boundaries between keys and values are unambiguous
+func hashPair(h hash.Hash, key, value []byte) {
+	p := wrappers.Packer{MaxSize: 2*wrappers.IntLen + len(key) + len(value)}
+	p.PackBytes(key)
+	p.PackBytes(value)
+	_, _ = h.Write(p.Bytes)
+}
+
+func ensureEmptyDir(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	switch {
+	case os.IsNotExist(err):
+		return os.MkdirAll(dir, dirPerms)
+	case err != nil:
+		return err
+	case len(files) != 0:
+		return errDirNotEmpty
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/memdb"
+)
+
+func newTestDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func newTestDB(t *testing.T, numKeys int) *memdb.Database {
+	db := memdb.New()
+	for i := 0; i < numKeys; i++ {
+		if err := db.Put([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+func TestCreateAndRestore(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	db := newTestDB(t, 100)
+	if err := Create(db, filepath.Join(dir, "backup"), &Manifest{
+		NetworkID: 12345,
+		Chains:    []ChainState{{ChainID: "chain", Height: 5}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := memdb.New()
+	manifest, err := Restore(filepath.Join(dir, "backup"), restored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case manifest.NetworkID != 12345:
+		t.Fatalf("wrong network ID %d", manifest.NetworkID)
+	case manifest.NumKeys != 100:
+		t.Fatalf("expected 100 keys but got %d", manifest.NumKeys)
+	case len(manifest.Chains) != 1 || manifest.Chains[0].Height != 5:
+		t.Fatalf("wrong chain state %v", manifest.Chains)
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		value, err := restored.Get(it.Key())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(value, it.Value()) {
+			t.Fatalf("wrong value restored for key %s", it.Key())
+		}
+	}
+
+	// Restoring into a non-empty database should fail
+	if _, err := Restore(filepath.Join(dir, "backup"), restored); err != errTargetNotEmpty {
+		t.Fatalf("expected %s but got %v", errTargetNotEmpty, err)
+	}
+}
+
+func TestCreateNonEmptyDir(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "file"), nil, manifestPerms); err != nil {
+		t.Fatal(err)
+	}
+	if err := Create(newTestDB(t, 1), dir, &Manifest{}); err != errDirNotEmpty {
+		t.Fatalf("expected %s but got %v", errDirNotEmpty, err)
+	}
+}
+
+func TestVerifyDetectsModification(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	if err := Create(newTestDB(t, 10), dir, &Manifest{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Verify(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	backupDB, err := leveldb.New(filepath.Join(dir, DBDir), 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backupDB.Put([]byte("key0"), []byte("modified")); err != nil {
+		t.Fatal(err)
+	}
+	if err := backupDB.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Verify(dir); !errors.Is(err, errChecksumMismatch) {
+		t.Fatalf("expected %s but got %v", errChecksumMismatch, err)
+	}
+}
@@ -49,6 +49,14 @@ type Compacter interface {
 	Compact(start []byte, limit []byte) error
 }
 
+// Sizer wraps the ApproximateSize method of a backing data store.
+type Sizer interface {
+	// ApproximateSize returns the approximate number of bytes the DB uses to
+	// store the given key range. Nil start and limit are treated as they are
+	// by Compact.
+	ApproximateSize(start []byte, limit []byte) (uint64, error)
+}
+
 // Database contains all the methods required to allow handling different
 // key-value data stores backing the database.
 type Database interface {
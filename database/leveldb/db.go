@@ -161,6 +161,36 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.handleError(db.DB.CompactRange(util.Range{Start: start, Limit: limit}))
 }
 
+// ApproximateSize returns the approximate number of bytes the files of the
+// underlying DB use to store the given key range.
+//
+// A nil start is treated as a key before all keys in the DB.
+// And a nil limit is treated as a key after all keys in the DB.
+func (db *Database) ApproximateSize(start []byte, limit []byte) (uint64, error) {
+	if limit == nil {
+		// The underlying DB treats a nil limit as the smallest key, so use the
+		// key right after the last key in the range instead
+		iter := db.DB.NewIterator(&util.Range{Start: start}, nil)
+		if iter.Last() {
+			limit = make([]byte, len(iter.Key())+1)
+			copy(limit, iter.Key())
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return 0, db.handleError(err)
+		}
+		if limit == nil {
+			// There are no keys in the range
+			return 0, nil
+		}
+	}
+	sizes, err := db.DB.SizeOf([]util.Range{{Start: start, Limit: limit}})
+	if err != nil {
+		return 0, db.handleError(err)
+	}
+	return uint64(sizes.Sum()), nil
+}
+
 // Close implements the Database interface
 func (db *Database) Close() error { return db.handleError(db.DB.Close()) }
 
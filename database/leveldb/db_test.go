@@ -25,3 +25,42 @@ func TestInterface(t *testing.T) {
 		test(t, db)
 	}
 }
+
+func TestApproximateSize(t *testing.T) {
+	folder := "db_size"
+	db, err := New(folder, 0, 0, 0)
+	if err != nil {
+		t.Fatalf("leveldb.New(%s, 0, 0) errored with %s", folder, err)
+	}
+	defer os.RemoveAll(folder)
+	defer db.Close()
+
+	if size, err := db.ApproximateSize(nil, nil); err != nil {
+		t.Fatal(err)
+	} else if size != 0 {
+		t.Fatalf("expected an empty db to have size 0 but got %d", size)
+	}
+
+	value := make([]byte, 1024)
+	for i := 0; i < 1024; i++ {
+		if err := db.Put([]byte(fmt.Sprintf("key%04d", i)), value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Compact(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	size, err := db.ApproximateSize(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size == 0 {
+		t.Fatal("expected a non-empty db to have a non-zero size")
+	}
+	if partial, err := db.ApproximateSize([]byte("key0512"), nil); err != nil {
+		t.Fatal(err)
+	} else if partial >= size {
+		t.Fatalf("expected the size of part of the db (%d) to be less than the size of the db (%d)", partial, size)
+	}
+}
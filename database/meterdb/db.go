@@ -123,6 +123,15 @@ func (db *Database) Compact(start, limit []byte) error {
 	return err
 }
 
+// ApproximateSize implements the database.Sizer interface
+func (db *Database) ApproximateSize(start, limit []byte) (uint64, error) {
+	sizer, ok := db.db.(database.Sizer)
+	if !ok {
+		return 0, database.ErrNotSupported
+	}
+	return sizer.ApproximateSize(start, limit)
+}
+
 // Close implements the Database interface
 func (db *Database) Close() error {
 	start := db.clock.Time()
@@ -141,7 +141,22 @@ func (db *Database) Compact(start, limit []byte) error {
 	if db.db == nil {
 		return database.ErrClosed
 	}
-	return db.db.Compact(db.prefix(start), db.prefix(limit))
+	return db.db.Compact(db.keyRange(start, limit))
+}
+
+// ApproximateSize implements the database.Sizer interface
+func (db *Database) ApproximateSize(start, limit []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return 0, database.ErrClosed
+	}
+	sizer, ok := db.db.(database.Sizer)
+	if !ok {
+		return 0, database.ErrNotSupported
+	}
+	return sizer.ApproximateSize(db.keyRange(start, limit))
 }
 
 // Close implements the Database interface
@@ -156,6 +171,28 @@ func (db *Database) Close() error {
 	return nil
 }
 
+// keyRange returns the range of keys in the underlying database that
+// corresponds to the range [start, limit) of this database. A nil limit is
+// treated as a key after all keys with this database's prefix.
+func (db *Database) keyRange(start, limit []byte) ([]byte, []byte) {
+	if limit != nil {
+		return db.prefix(start), db.prefix(limit)
+	}
+
+	// The smallest key greater than all keys with [db.dbPrefix] as a prefix.
+	// If the prefix is all 0xff bytes, there is no such key, so nil is
+	// returned to include the rest of the underlying database.
+	for i := len(db.dbPrefix) - 1; i >= 0; i-- {
+		if db.dbPrefix[i] != 0xff {
+			prefixLimit := make([]byte, i+1)
+			copy(prefixLimit, db.dbPrefix)
+			prefixLimit[i]++
+			return db.prefix(start), prefixLimit
+		}
+	}
+	return db.prefix(start), nil
+}
+
 func (db *Database) prefix(key []byte) []byte {
 	prefixedKey := make([]byte, len(db.dbPrefix)+len(key))
 	copy(prefixedKey, db.dbPrefix)
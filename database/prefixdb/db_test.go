@@ -4,6 +4,7 @@
 package prefixdb
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -21,3 +22,24 @@ func TestInterface(t *testing.T) {
 		test(t, NewNested([]byte("ld"), New([]byte("wor"), db)))
 	}
 }
+
+func TestKeyRange(t *testing.T) {
+	db := &Database{dbPrefix: []byte{0x01, 0xff}}
+
+	start, limit := db.keyRange([]byte{0x02}, nil)
+	if !bytes.Equal(start, []byte{0x01, 0xff, 0x02}) {
+		t.Fatalf("wrong start %x", start)
+	}
+	if !bytes.Equal(limit, []byte{0x02}) {
+		t.Fatalf("wrong limit %x", limit)
+	}
+
+	if _, limit := db.keyRange(nil, []byte{0x03}); !bytes.Equal(limit, []byte{0x01, 0xff, 0x03}) {
+		t.Fatalf("wrong limit %x", limit)
+	}
+
+	db.dbPrefix = []byte{0xff, 0xff}
+	if _, limit := db.keyRange(nil, nil); limit != nil {
+		t.Fatalf("expected no limit but got %x", limit)
+	}
+}
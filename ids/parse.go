@@ -0,0 +1,100 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/mr-tron/base58/base58"
+
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+// ParseID parses an ID from [idStr], which may be:
+// * cb58, as returned by ID.String()
+// * base58 without a checksum
+// * hex, with or without a 0x prefix, as returned by ID.Hex()
+func ParseID(idStr string) (ID, error) {
+	idBytes, err := parseBytes(idStr, 32)
+	if err != nil {
+		return ID{}, err
+	}
+	return ToID(idBytes)
+}
+
+// ParseShortID parses a ShortID from [idStr], which may be in any of the
+// formats accepted by ParseID
+func ParseShortID(idStr string) (ShortID, error) {
+	idBytes, err := parseBytes(idStr, 20)
+	if err != nil {
+		return ShortID{}, err
+	}
+	return ToShortID(idBytes)
+}
+
+// parseBytes returns the [size] bytes encoded by [str]
+func parseBytes(str string, size int) ([]byte, error) {
+	if hexStr := strings.TrimPrefix(str, "0x"); len(hexStr) == 2*size {
+		if b, err := hex.DecodeString(hexStr); err == nil {
+			return b, nil
+		}
+	}
+	if b, err := (formatting.CB58{}).ConvertString(str); err == nil && len(b) == size {
+		return b, nil
+	}
+	if b, err := base58.Decode(str); err == nil && len(b) == size {
+		return b, nil
+	}
+	return nil, fmt.Errorf("couldn't parse %q as a %d byte cb58, base58 or hex ID", str, size)
+}
+
+// MarshalText returns the cb58 representation of this id. The zero value is
+// marshalled to an empty string.
+func (id ID) MarshalText() ([]byte, error) {
+	if id.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText parses an id in any format accepted by ParseID. An empty string
+// is unmarshalled to the zero value.
+func (id *ID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*id = ID{}
+		return nil
+	}
+	newID, err := ParseID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = newID
+	return nil
+}
+
+// MarshalText returns the cb58 representation of this id. The zero value is
+// marshalled to an empty string.
+func (id ShortID) MarshalText() ([]byte, error) {
+	if id.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText parses an id in any format accepted by ParseShortID. An empty
+// string is unmarshalled to the zero value.
+func (id *ShortID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*id = ShortID{}
+		return nil
+	}
+	newID, err := ParseShortID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = newID
+	return nil
+}
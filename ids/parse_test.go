@@ -0,0 +1,103 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mr-tron/base58/base58"
+)
+
+func TestParseID(t *testing.T) {
+	id := NewID([32]byte{'a', 'v', 'a', ' ', 'l', 'a', 'b', 's'})
+
+	inputs := []string{
+		id.String(),
+		base58.Encode(id.Bytes()),
+		id.Hex(),
+		"0x" + id.Hex(),
+	}
+	for _, input := range inputs {
+		parsed, err := ParseID(input)
+		if err != nil {
+			t.Fatalf("couldn't parse %s: %s", input, err)
+		}
+		if !parsed.Equals(id) {
+			t.Fatalf("parsed %s to %s but expected %s", input, parsed, id)
+		}
+	}
+
+	invalid := []string{"", "foo", "0x1234", NewShortID([20]byte{1}).String()}
+	for _, input := range invalid {
+		if _, err := ParseID(input); err == nil {
+			t.Fatalf("should have failed to parse %q", input)
+		}
+	}
+}
+
+func TestParseShortID(t *testing.T) {
+	id := NewShortID([20]byte{'a', 'v', 'a', ' ', 'l', 'a', 'b', 's'})
+
+	inputs := []string{
+		id.String(),
+		base58.Encode(id.Bytes()),
+		id.Hex(),
+		"0x" + id.Hex(),
+	}
+	for _, input := range inputs {
+		parsed, err := ParseShortID(input)
+		if err != nil {
+			t.Fatalf("couldn't parse %s: %s", input, err)
+		}
+		if !parsed.Equals(id) {
+			t.Fatalf("parsed %s to %s but expected %s", input, parsed, id)
+		}
+	}
+
+	if _, err := ParseShortID(NewID([32]byte{1}).String()); err == nil {
+		t.Fatal("should have failed to parse an ID as a ShortID")
+	}
+}
+
+func TestIDTextMarshalling(t *testing.T) {
+	id := NewID([32]byte{1})
+	shortID := NewShortID([20]byte{2})
+
+	// Map keys are marshalled using MarshalText
+	idBytes, err := json.Marshal(map[ID]ShortID{id: shortID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"` + id.String() + `":"` + shortID.String() + `"}`
+	if string(idBytes) != expected {
+		t.Fatalf("expected %s but got %s", expected, idBytes)
+	}
+
+	parsedID := ID{}
+	if err := parsedID.UnmarshalText([]byte(id.Hex())); err != nil {
+		t.Fatal(err)
+	}
+	if !parsedID.Equals(id) {
+		t.Fatalf("expected %s but got %s", id, parsedID)
+	}
+
+	parsedShortID := ShortID{}
+	if err := parsedShortID.UnmarshalText([]byte(shortID.String())); err != nil {
+		t.Fatal(err)
+	}
+	if !parsedShortID.Equals(shortID) {
+		t.Fatalf("expected %s but got %s", shortID, parsedShortID)
+	}
+
+	if err := parsedShortID.UnmarshalText(nil); err != nil {
+		t.Fatal(err)
+	}
+	if !parsedShortID.IsZero() {
+		t.Fatal("empty text should unmarshal to the zero value")
+	}
+	if text, err := parsedShortID.MarshalText(); err != nil || len(text) != 0 {
+		t.Fatalf("zero value should marshal to empty text but got %q, %v", text, err)
+	}
+}
@@ -31,3 +31,77 @@ func UnsortedEquals(a, b []ID) bool {
 
 	return aBag.Equals(bBag)
 }
+
+// SortedIDs returns a sorted copy of [ids]
+func SortedIDs(ids []ID) []ID {
+	sorted := make([]ID, len(ids))
+	copy(sorted, ids)
+	SortIDs(sorted)
+	return sorted
+}
+
+// DedupeIDs returns [ids] without duplicates, preserving the order of the
+// first occurrence of each ID
+func DedupeIDs(ids []ID) []ID {
+	set := Set{}
+	deduped := make([]ID, 0, len(ids))
+	for _, id := range ids {
+		if set.Contains(id) {
+			continue
+		}
+		set.Add(id)
+		deduped = append(deduped, id)
+	}
+	return deduped
+}
+
+// DifferenceIDs returns the elements of [a] that aren't in [b], preserving
+// their order in [a]
+func DifferenceIDs(a, b []ID) []ID {
+	set := Set{}
+	set.Add(b...)
+	diff := []ID(nil)
+	for _, id := range a {
+		if !set.Contains(id) {
+			diff = append(diff, id)
+		}
+	}
+	return diff
+}
+
+// SortedShortIDs returns a sorted copy of [ids]
+func SortedShortIDs(ids []ShortID) []ShortID {
+	sorted := make([]ShortID, len(ids))
+	copy(sorted, ids)
+	SortShortIDs(sorted)
+	return sorted
+}
+
+// DedupeShortIDs returns [ids] without duplicates, preserving the order of the
+// first occurrence of each ID
+func DedupeShortIDs(ids []ShortID) []ShortID {
+	set := ShortSet{}
+	deduped := make([]ShortID, 0, len(ids))
+	for _, id := range ids {
+		if set.Contains(id) {
+			continue
+		}
+		set.Add(id)
+		deduped = append(deduped, id)
+	}
+	return deduped
+}
+
+// DifferenceShortIDs returns the elements of [a] that aren't in [b], preserving
+// their order in [a]
+func DifferenceShortIDs(a, b []ShortID) []ShortID {
+	set := ShortSet{}
+	set.Add(b...)
+	diff := []ShortID(nil)
+	for _, id := range a {
+		if !set.Contains(id) {
+			diff = append(diff, id)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,62 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"testing"
+)
+
+func TestDedupeIDs(t *testing.T) {
+	id0 := NewID([32]byte{0})
+	id1 := NewID([32]byte{1})
+	id2 := NewID([32]byte{2})
+
+	deduped := DedupeIDs([]ID{id2, id0, id2, id1, id0})
+	if !Equals(deduped, []ID{id2, id0, id1}) {
+		t.Fatalf("unexpected deduped IDs %v", deduped)
+	}
+
+	sorted := SortedIDs(deduped)
+	if !Equals(sorted, []ID{id0, id1, id2}) {
+		t.Fatalf("unexpected sorted IDs %v", sorted)
+	}
+	if !Equals(deduped, []ID{id2, id0, id1}) {
+		t.Fatal("SortedIDs shouldn't modify its argument")
+	}
+}
+
+func TestDifferenceIDs(t *testing.T) {
+	id0 := NewID([32]byte{0})
+	id1 := NewID([32]byte{1})
+	id2 := NewID([32]byte{2})
+
+	diff := DifferenceIDs([]ID{id0, id1, id2}, []ID{id1})
+	if !Equals(diff, []ID{id0, id2}) {
+		t.Fatalf("unexpected difference %v", diff)
+	}
+	if diff := DifferenceIDs([]ID{id0}, []ID{id0, id1}); len(diff) != 0 {
+		t.Fatalf("unexpected difference %v", diff)
+	}
+}
+
+func TestShortIDSliceHelpers(t *testing.T) {
+	id0 := NewShortID([20]byte{0})
+	id1 := NewShortID([20]byte{1})
+	id2 := NewShortID([20]byte{2})
+
+	deduped := DedupeShortIDs([]ShortID{id1, id1, id0, id2, id0})
+	if len(deduped) != 3 || !deduped[0].Equals(id1) || !deduped[1].Equals(id0) || !deduped[2].Equals(id2) {
+		t.Fatalf("unexpected deduped IDs %v", deduped)
+	}
+
+	sorted := SortedShortIDs(deduped)
+	if !IsSortedAndUniqueShortIDs(sorted) {
+		t.Fatalf("unexpected sorted IDs %v", sorted)
+	}
+
+	diff := DifferenceShortIDs(deduped, []ShortID{id0})
+	if len(diff) != 2 || !diff[0].Equals(id1) || !diff[1].Equals(id2) {
+		t.Fatalf("unexpected difference %v", diff)
+	}
+}
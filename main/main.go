@@ -5,6 +5,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/node"
@@ -37,6 +38,19 @@ func main() {
 		return
 	}
 
+	// RestoreDir is set if the restore command was given
+	if RestoreDir != "" {
+		err := restore()
+		if closeErr := Config.DB.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			fmt.Printf("restoring backup failed with: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	factory := logging.NewFactory(Config.LoggingConfig)
 	defer factory.Close()
 
@@ -94,6 +108,7 @@ func main() {
 
 	mapper := nat.NewPortMapper(log, Config.Nat)
 	defer mapper.UnmapAllPorts()
+	Config.PortMapper = mapper
 
 	// Open staking port we want for NAT Traversal to have the external port
 	// (Config.StakingIP.Port) to connect to our internal listening port
@@ -108,7 +123,7 @@ func main() {
 	)
 
 	// Open the HTTP port iff the HTTP server is not listening on localhost
-	if Config.HTTPHost != "127.0.0.1" && Config.HTTPHost != "localhost" {
+	if Config.HTTPHost != "127.0.0.1" && Config.HTTPHost != "::1" && Config.HTTPHost != "localhost" {
 		// For NAT Traversal we want to route from the external port
 		// (Config.ExternalHTTPPort) to our internal port (Config.HTTPPort)
 		mapper.Map(
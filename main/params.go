@@ -7,20 +7,28 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/ipcs"
 	"github.com/ava-labs/avalanchego/nat"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils"
@@ -35,7 +43,11 @@ import (
 )
 
 const (
-	dbVersion = "v1.0.0"
+	dbVersion = node.DatabaseVersion
+
+	// restoreCommand is the first argument given to restore a database backup
+	// rather than run the node
+	restoreCommand = "restore"
 )
 
 // Results of parsing the CLI
@@ -49,6 +61,10 @@ var (
 	defaultDbDir           = filepath.Join(homeDir, dataDirName, "db")
 	defaultStakingKeyPath  = filepath.Join(homeDir, dataDirName, "staking", "staker.key")
 	defaultStakingCertPath = filepath.Join(homeDir, dataDirName, "staking", "staker.crt")
+	defaultProfileDir      = filepath.Join(homeDir, dataDirName, "profiles")
+	defaultCaptureDir      = filepath.Join(homeDir, dataDirName, "captures")
+	defaultChainConfigDir  = filepath.Join(homeDir, dataDirName, "configs", "chains")
+	defaultRecordDir       = filepath.Join(homeDir, dataDirName, "recordings")
 	defaultPluginDirs      = []string{
 		filepath.Join(".", "build", "plugins"),
 		filepath.Join(".", "plugins"),
@@ -58,12 +74,20 @@ var (
 
 	// GitCommit should be optionally set at compile time.
 	GitCommit string
+
+	// RestoreDir is the directory of the backup to restore, if the node was
+	// started with the restore command
+	RestoreDir string
 )
 
 var (
 	errBootstrapMismatch    = errors.New("more bootstrap IDs provided than bootstrap IPs")
 	errStakingRequiresTLS   = errors.New("if staking is enabled, network TLS must also be enabled")
 	errInvalidStakerWeights = errors.New("staking weights must be positive")
+	errRestoreNoDB          = errors.New("restoring a backup requires the database to be enabled")
+	errNoDNSSeedSigners     = errors.New("DNS seeds require at least one trusted seed record signer")
+	errNoBackupDir          = errors.New("backup-dir must be provided to restore a backup")
+	errInvalidFetchWindow   = errors.New("bootstrap-fetch-window must be positive")
 )
 
 // Parse the CLI arguments
@@ -110,6 +134,12 @@ func init() {
 	// Stake minting period
 	fs.DurationVar(&Config.StakeMintingPeriod, "stake-minting-period", 365*24*time.Hour, "Consumption period of the staking function")
 
+	// P-Chain address transaction index
+	fs.BoolVar(&Config.PlatformAddressTxIndexEnabled, "platform-address-tx-index-enabled", false, "If true, the P-Chain indexes the transactions that reference each address. Only transactions accepted while the index is enabled are indexed.")
+
+	// Pruning:
+	fs.BoolVar(&Config.PruneRejected, "prune-rejected", false, "If true, rejected vertices and P-Chain blocks are deleted from the database. Vertices rejected before pruning was enabled are deleted on startup.")
+
 	// Assertions:
 	fs.BoolVar(&loggingConfig.Assertions, "assertions-enabled", true, "Turn on assertion execution")
 
@@ -121,12 +151,12 @@ func init() {
 	dbDir := fs.String("db-dir", defaultDbDir, "Database directory for Avalanche state")
 
 	// IP:
-	consensusIP := fs.String("public-ip", "", "Public IP of this node for P2P communication. If empty, try to discover with NAT. Ignored if dynamic-public-ip is non-empty.")
+	consensusIP := fs.String("public-ip", "", "Public IPv4 or IPv6 address of this node for P2P communication. If empty, try to discover with NAT. Ignored if dynamic-public-ip is non-empty.")
 
 	// how often to update the dynamic IP and PnP/NAT-PMP IP and routing.
 	fs.DurationVar(&Config.DynamicUpdateDuration, "dynamic-update-duration", 5*time.Minute, "Dynamic IP and NAT Traversal update duration")
 
-	dynamicPublicIPResolver := fs.String("dynamic-public-ip", "", "'ifconfig' or 'opendns'. By default does not do dynamic public IP updates. If non-empty, ignores public-ip argument.")
+	dynamicPublicIPResolver := fs.String("dynamic-public-ip", "", "'ifconfig' or 'opendns' to resolve an IPv4 address, or 'ifconfig6' or 'opendns6' to resolve an IPv6 address. By default does not do dynamic public IP updates. If non-empty, ignores public-ip argument.")
 
 	// Incoming connection throttling
 	// After we receive [conn-meter-max-attempts] incoming connections from a given IP
@@ -140,25 +170,115 @@ func init() {
 		"Upgrade at most [conn-meter-max-attempts] connections from a given IP per [conn-meter-reset-duration]. "+
 			"If [conn-meter-reset-duration] is 0, incoming connections are not rate-limited.")
 
+	fs.IntVar(&Config.ConnMeterMaxSubnetConns, "conn-meter-max-subnet-conns", 25,
+		"Upgrade at most [conn-meter-max-subnet-conns] connections from a given /24 IPv4 or /64 IPv6 subnet per [conn-meter-reset-duration]. "+
+			"If [conn-meter-reset-duration] or [conn-meter-max-subnet-conns] is 0, incoming connections are not rate-limited by subnet.")
+
+	// Peer gating:
+	allowedNodeIDs := fs.String("network-allowed-node-ids", "", "Comma separated list of the node IDs of the only peers to connect to. If empty, peers aren't limited by node ID. Beacons must be included.")
+	deniedNodeIDs := fs.String("network-denied-node-ids", "", "Comma separated list of the node IDs of peers to never connect to")
+	allowedIPs := fs.String("network-allowed-ips", "", "Comma separated list of the IPs and CIDR networks of the only peers to connect to. Example: 10.0.0.0/8,2001:db8::1. If empty, peers aren't limited by IP. Beacons must be included.")
+	deniedIPs := fs.String("network-denied-ips", "", "Comma separated list of the IPs and CIDR networks of peers to never connect to")
+
+	// Outbound connection proxy:
+	fs.StringVar(&Config.NetworkProxy.Address, "network-socks5-proxy", "", "Address of a SOCKS5 proxy to connect to peers through. Example: 127.0.0.1:1080. If empty, peers are connected to directly.")
+	fs.StringVar(&Config.NetworkProxy.Username, "network-socks5-proxy-username", "", "Username to authenticate with the SOCKS5 proxy with, if it requires one")
+	fs.StringVar(&Config.NetworkProxy.Password, "network-socks5-proxy-password", "", "Password to authenticate with the SOCKS5 proxy with, if it requires one")
+	torEnabled := fs.Bool("network-tor-enabled", false,
+		fmt.Sprintf("If true, peers are connected to through Tor, at [network-socks5-proxy] or, if it's empty, %s, over a separate circuit for each peer", network.DefaultTorProxyAddress))
+
+	// Peer store:
+	fs.BoolVar(&Config.PeerStoreEnabled, "network-peer-store-enabled", true,
+		"If true, the peers this node connects to are stored in its database, and reconnected to when it restarts, without waiting on the beacons")
+
+	// Inbound handshakes:
+	fs.IntVar(&Config.MaxPendingHandshakes, "network-max-pending-handshakes", 1024,
+		"Maximum number of incoming connections being upgraded at once. Connections beyond it are dropped. If 0, the number isn't limited.")
+	fs.IntVar(&Config.MaxPendingHandshakesPerIP, "network-max-pending-handshakes-per-ip", 8,
+		"Maximum number of incoming connections from a given IP being upgraded at once. Connections beyond it are dropped. If 0, the number isn't limited.")
+
+	// Message compression:
+	fs.BoolVar(&Config.NetworkCompressionEnabled, "network-compression-enabled", false,
+		"If true, large messages are compressed when sent to peers that accept compressed messages. "+
+			"Only peers running v1.0.4 or later are asked whether they accept them.")
+
+	// Signed IPs:
+	fs.BoolVar(&Config.NetworkSignedIPsEnabled, "network-signed-ips-enabled", false,
+		"If true, this node signs the IP it claims with its staking key, and gossips peers' signatures of their IPs to peers that accept them. "+
+			"Only peers running v1.0.4 or later are sent signatures. Requires p2p TLS.")
+	fs.BoolVar(&Config.NetworkRequireSignedIPs, "network-require-signed-ips", false,
+		"If true, gossiped IPs that aren't signed by a validator are ignored rather than connected to.")
+
+	// State sync:
+	fs.BoolVar(&Config.NetworkStateSyncEnabled, "network-state-sync-enabled", false,
+		"If true, this node advertises that it can parse and serve state sync messages, so peers can state sync from it. "+
+			"It's only advertised to peers running v1.0.4 or later.")
+
+	// Peer limit:
+	fs.IntVar(&Config.MaxPeers, "network-max-peers", 0,
+		"Maximum number of peers to connect to. Once it's reached, peers with the least stake on the primary network and the subnets this node validates are disconnected to make room for peers with more. "+
+			"If 0, the number of peers isn't limited.")
+	fs.IntVar(&Config.MinSubnetPeers, "network-min-subnet-peers", 5,
+		"Minimum number of validators of each subnet this node validates, other than the primary network, to stay connected to. "+
+			"While a subnet has fewer, its validators are dialed at the IPs they were last connected at, and are connected to even if peers with more stake must be evicted. "+
+			"If 0, subnet validators are connected to like other peers.")
+
+	// Outbound bandwidth:
+	fs.Uint64Var(&Config.PeerSendBandwidth, "network-peer-send-bandwidth", 0,
+		"Maximum number of bytes per second sent to each peer. If 0, the bandwidth to each peer isn't limited.")
+	fs.Uint64Var(&Config.SendBandwidth, "network-send-bandwidth", 0,
+		"Maximum number of bytes per second sent to every peer combined. Peers take turns sending, so a peer that's sent large messages doesn't delay messages to the others. "+
+			"If 0, the combined bandwidth isn't limited.")
+
 	// HTTP Server:
 	httpHost := fs.String("http-host", "127.0.0.1", "Address of the HTTP server")
 	httpPort := fs.Uint("http-port", 9650, "Port of the HTTP server")
+	fs.StringVar(&Config.HTTPUnixSocket, "http-unix-socket", "", "Path of a unix domain socket the HTTP API is also served on. If empty, the API is only served over TCP.")
+	httpUnixSocketMode := fs.String("http-unix-socket-mode", "0660", "Permissions, in octal, of the unix domain socket the HTTP API is served on")
 	fs.BoolVar(&Config.HTTPSEnabled, "http-tls-enabled", false, "Upgrade the HTTP server to HTTPs")
 	fs.StringVar(&Config.HTTPSKeyFile, "http-tls-key-file", "", "TLS private key file for the HTTPs server")
 	fs.StringVar(&Config.HTTPSCertFile, "http-tls-cert-file", "", "TLS certificate file for the HTTPs server")
 	fs.BoolVar(&Config.APIRequireAuthToken, "api-auth-required", false, "Require authorization token to call HTTP APIs")
 	fs.StringVar(&Config.APIAuthPassword, "api-auth-password", "", "Password used to create/validate API authorization tokens. Can be changed via API call.")
+	fs.DurationVar(&Config.APIRequestTimeout, "api-request-timeout", 0, "Max amount of time an API call may run for. Calls are abandoned when they time out or the client disconnects. If 0, calls don't time out.")
+	corsAllowedOrigins := fs.String("api-cors-allowed-origins", "*", "Comma separated list of origins browsers may call the HTTP APIs from. '*' allows every origin. Example: https://wallet.example.com,https://*.example.org")
+	corsAllowedMethods := fs.String("api-cors-allowed-methods", "GET,POST,HEAD", "Comma separated list of HTTP methods browsers may call the HTTP APIs with from other origins")
+	corsAllowedHeaders := fs.String("api-cors-allowed-headers", "Origin,Accept,Content-Type,X-Requested-With", "Comma separated list of headers browsers may send to the HTTP APIs from other origins. '*' allows every header.")
+	fs.DurationVar(&Config.APITimeouts.ReadTimeout, "api-read-timeout", 0, "Max amount of time reading an API request, including its body, may take. If 0, reads don't time out.")
+	fs.DurationVar(&Config.APITimeouts.WriteTimeout, "api-write-timeout", 0, "Max amount of time from when an API request's headers are read until its response is written. Applies to every endpoint. If 0, writes don't time out.")
+	apiHandlerTimeouts := fs.String("api-handler-timeouts", "", "Comma separated list of max amounts of time calls to JSON-RPC methods or URLs may run for, overriding api-request-timeout. A method's timeout takes precedence over its URL's. Example: avm.getUTXOs=2m,platform.getCurrentValidators=1m,/ext/health=5s")
+	fs.IntVar(&Config.APICompressionLevel, "api-compression-level", api.DefaultCompressionLevel, "Level, in [0, 9], API responses are compressed at for clients that accept gzip or deflate encoded responses. If 0, responses aren't compressed.")
+	fs.Float64Var(&Config.APIRateLimits.Public.Rate, "api-rate-limit", 0, "Max number of requests per second each IP may send to the HTTP APIs, other than the keystore. If 0, requests aren't limited.")
+	fs.IntVar(&Config.APIRateLimits.Public.Burst, "api-rate-limit-burst", 0, "Max number of requests each IP may send at once to the HTTP APIs, other than the keystore. If 0, defaults to the rate limit.")
+	fs.Float64Var(&Config.APIRateLimits.Keystore.Rate, "api-keystore-rate-limit", 0, "Max number of requests per second each IP may send to the keystore API. If 0, requests aren't limited.")
+	fs.IntVar(&Config.APIRateLimits.Keystore.Burst, "api-keystore-rate-limit-burst", 0, "Max number of requests each IP may send at once to the keystore API. If 0, defaults to the keystore rate limit.")
 
 	// Bootstrapping:
 	bootstrapIPs := fs.String("bootstrap-ips", "default", "Comma separated list of bootstrap peer ips to connect to. Example: 127.0.0.1:9630,127.0.0.1:9631")
 	bootstrapIDs := fs.String("bootstrap-ids", "default", "Comma separated list of bootstrap peer ids to connect to. Example: NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET,NodeID-8CrVPQZ4VSqgL8zTdvL14G8HqAfrBr4z")
+	bootstrapDNSSeeds := fs.String("bootstrap-dns-seeds", "", "Comma separated list of domains whose signed seed records list more bootstrap peers. Example: seeds.example.com")
+	bootstrapDNSSeedSigners := fs.String("bootstrap-dns-seed-signers", "", "Comma separated list of the addresses of the keys trusted to sign seed records. Records signed by other keys are ignored")
+	fs.BoolVar(&Config.BootstrapArchiveEnabled, "bootstrap-archive-enabled", false, "If true, this node serves the accepted blocks of its linear chains to be used as a bootstrap archive by other nodes")
+	fs.StringVar(&Config.BootstrapArchiveURI, "bootstrap-archive-uri", "", "URI of a node serving a bootstrap archive. If non-empty, blocks are fetched from this node before falling back to the beacons. Example: http://127.0.0.1:9650")
+	fs.StringVar(&Config.BootstrapArchiveAuthToken, "bootstrap-archive-auth-token", "", "Authorization token passed to the bootstrap archive, if it requires one")
+	fs.IntVar(&Config.BootstrapFetchWindow, "bootstrap-fetch-window", common.DefaultFetchWindow, "Maximum number of requests for ancestors sent to each bootstrap peer at once. Requests are spread across the bootstrap peers")
+	fs.DurationVar(&Config.CheckpointFrequency, "checkpoint-frequency", 30*time.Second, "Minimum time between checkpoints of each chain's accepted frontier and processing containers. A checkpoint is also taken on shutdown")
+	fs.DurationVar(&Config.MaxCheckpointAge, "checkpoint-max-age", time.Minute, "Maximum age of a checkpoint a chain resumes consensus from on startup, instead of bootstrapping from the network. If 0, chains always bootstrap")
 
 	// Staking:
 	stakingPort := fs.Uint("staking-port", 9651, "Port of the consensus server")
+	fs.StringVar(&Config.StakingHost, "staking-host", "", "Address of the consensus server, either IPv4 or IPv6. If empty, the server listens on every interface, over both IPv4 and IPv6")
+	stakingListenAddresses := fs.String("staking-listen-addresses", "",
+		"Comma separated list of other addresses the consensus server listens on, each optionally followed by '=' and the public IP and port claimed to the peers that connect through it. "+
+			"Example: 10.0.0.2:9651=203.0.113.7:9651,[fd00::2]:9651. Peers that connect through an address without a public IP are told public-ip")
 	fs.BoolVar(&Config.EnableStaking, "staking-enabled", true, "Enable staking. If enabled, Network TLS is required.")
 	fs.BoolVar(&Config.EnableP2PTLS, "p2p-tls-enabled", true, "Require TLS to authenticate network communication")
 	fs.StringVar(&Config.StakingKeyFile, "staking-tls-key-file", defaultStakingKeyPath, "TLS private key for staking")
 	fs.StringVar(&Config.StakingCertFile, "staking-tls-cert-file", defaultStakingCertPath, "TLS certificate for staking")
+	stakingTLSMinVersion := fs.String("staking-tls-min-version", "1.2", "Minimum TLS version of staking connections, 1.2 or 1.3. Connections that negotiate an earlier version are rejected")
+	stakingTLSCipherSuites := fs.String("staking-tls-cipher-suites", "",
+		"Comma separated list of the names of the only cipher suites staking connections may use, including TLS 1.3 cipher suites. Example: TLS_AES_128_GCM_SHA256,TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256. "+
+			"Connections that negotiate another cipher suite are rejected. If empty, the default secure cipher suites are used")
 	fs.Uint64Var(&Config.DisabledStakingWeight, "staking-disabled-weight", 1, "Weight to provide to each peer when staking is disabled")
 
 	// Throttling:
@@ -178,10 +298,19 @@ func init() {
 	fs.BoolVar(&Config.BenchlistConfig.PeerSummaryEnabled, "benchlist-peer-summary-enabled", false, "Enables peer specific query latency metrics.")
 	fs.DurationVar(&Config.BenchlistConfig.Duration, "benchlist-duration", time.Hour, "Amount of time a peer is benchlisted after surpassing the threshold.")
 	fs.DurationVar(&Config.BenchlistConfig.MinimumFailingDuration, "benchlist-min-failing-duration", 5*time.Minute, "Minimum amount of time messages to a peer must be failing before the peer is benched.")
+	fs.Float64Var(&Config.BenchlistConfig.ScoreThreshold, "benchlist-score-threshold", 0.2,
+		"Peers whose score, a moving average of whether they respond to queries that's lowered by invalid containers, falls below this are benched. If 0, peers aren't benched because of their score.")
 
 	// Plugins:
 	fs.StringVar(&Config.PluginDir, "plugin-dir", defaultPluginDirs[0], "Plugin directory for Avalanche VMs")
 
+	// Profiling:
+	profileDir := fs.String("profile-dir", defaultProfileDir, "Directory that profiles captured through the Admin API are written to")
+	captureDir := fs.String("network-capture-dir", defaultCaptureDir, "Directory that network messages captured through the Admin API are written to")
+	recordChains := fs.String("consensus-record-chains", "", "Comma separated list of chain IDs or aliases whose consensus messages are recorded, so that they can be replayed. Example: X,P")
+	recordDir := fs.String("consensus-record-dir", defaultRecordDir, "Directory that recordings of consensus messages are written to")
+	skippedChains := fs.String("skip-chains", "", "Comma separated list of chain IDs or aliases that aren't run, even though this node validates their subnet. Chains of the primary network are always run")
+
 	// Logging:
 	logsDir := fs.String("log-dir", "", "Logging directory for Avalanche")
 	logLevel := fs.String("log-level", "info", "The log level. Should be one of {verbo, debug, info, warn, error, fatal, off}")
@@ -194,7 +323,10 @@ func init() {
 	fs.IntVar(&Config.ConsensusParams.BetaRogue, "snow-rogue-commit-threshold", 30, "Beta value to use for rogue transactions")
 	fs.IntVar(&Config.ConsensusParams.Parents, "snow-avalanche-num-parents", 5, "Number of vertexes for reference from each new vertex")
 	fs.IntVar(&Config.ConsensusParams.BatchSize, "snow-avalanche-batch-size", 30, "Number of operations to batch in each new vertex")
+	fs.IntVar(&Config.ConsensusParams.MaxBatchSize, "snow-avalanche-max-batch-size", 0, "Largest number of operations to batch in each new vertex when more operations are pending than fit in a batch. If 0, vertices never contain more than snow-avalanche-batch-size operations")
+	fs.DurationVar(&Config.AVMBatchDelay, "avm-batch-delay", time.Second, "Longest time the AVM waits for more transactions before issuing them to consensus")
 	fs.IntVar(&Config.ConsensusParams.ConcurrentRepolls, "snow-concurrent-repolls", 4, "Minimum number of concurrent polls for finalizing consensus")
+	fs.IntVar(&Config.ConsensusParams.MaxK, "snow-max-sample-size", 0, "Largest number of nodes to query for each network poll when many queries fail. Must be less than twice the quorum size. If 0, the sample size doesn't change")
 
 	// Enable/Disable APIs:
 	fs.BoolVar(&Config.AdminAPIEnabled, "api-admin-enabled", false, "If true, this node exposes the Admin API")
@@ -203,6 +335,20 @@ func init() {
 	fs.BoolVar(&Config.MetricsAPIEnabled, "api-metrics-enabled", true, "If true, this node exposes the Metrics API")
 	fs.BoolVar(&Config.HealthAPIEnabled, "api-health-enabled", true, "If true, this node exposes the Health API")
 	fs.BoolVar(&Config.IPCAPIEnabled, "api-ipcs-enabled", false, "If true, IPCs can be opened")
+	fs.BoolVar(&Config.SchedulerAPIEnabled, "api-scheduler-enabled", false, "If true, this node exposes the Scheduler API and executes scheduled API calls")
+	fs.BoolVar(&Config.GRPCAPIEnabled, "api-grpc-enabled", false, "If true, this node serves the Info, Health, AVM and Platform APIs over gRPC and gRPC-web")
+	grpcPort := fs.Uint("api-grpc-port", 9652, "Port of the gRPC and gRPC-web server")
+	fs.BoolVar(&Config.EventsAPIEnabled, "api-events-enabled", false, "If true, this node exposes the Events API, which streams the containers each chain accepts and rejects over a WebSocket")
+
+	// Metrics push:
+	fs.StringVar(&Config.MetricsPushConfig.URL, "metrics-push-url", "", "URL of a Prometheus Pushgateway or remote-write endpoint that metrics are pushed to. If empty, metrics aren't pushed.")
+	fs.StringVar(&Config.MetricsPushConfig.Mode, "metrics-push-mode", metrics.PushGatewayMode, fmt.Sprintf("How metrics are pushed. Either %q or %q", metrics.PushGatewayMode, metrics.RemoteWriteMode))
+	fs.DurationVar(&Config.MetricsPushConfig.Interval, "metrics-push-interval", 15*time.Second, "Time between metrics pushes")
+	fs.DurationVar(&Config.MetricsPushConfig.Timeout, "metrics-push-timeout", 10*time.Second, "Max amount of time a metrics push may take")
+	fs.StringVar(&Config.MetricsPushConfig.Job, "metrics-push-job", constants.AppName, "Job label of pushed metrics")
+
+	// Health:
+	healthChecksFile := fs.String("health-checks-file", "", "Path to a JSON file listing health checks that execute a command or probe a URL, and are reported by the Health API")
 
 	// Throughput Server
 	throughputPort := fs.Uint("xput-server-port", 9652, "Port of the deprecated throughput test server")
@@ -215,10 +361,30 @@ func init() {
 	// Router Configuration:
 	fs.DurationVar(&Config.ConsensusGossipFrequency, "consensus-gossip-frequency", 10*time.Second, "Frequency of gossiping accepted frontiers.")
 	fs.DurationVar(&Config.ConsensusShutdownTimeout, "consensus-shutdown-timeout", 5*time.Second, "Timeout before killing an unresponsive chain.")
+	fs.DurationVar(&Config.ConsensusMinGossipFrequency, "consensus-gossip-min-frequency", 2*time.Second,
+		"Minimum time between gossiping accepted frontiers. The time starts at [consensus-gossip-frequency], shrinks while peers connect and disconnect or chains are queried, and grows while the network is idle. "+
+			"If 0, accepted frontiers are gossiped every [consensus-gossip-frequency].")
+	fs.DurationVar(&Config.ConsensusMaxGossipFrequency, "consensus-gossip-max-frequency", time.Minute, "Maximum time between gossiping accepted frontiers.")
+	fs.IntVar(&Config.ConsensusIdleGossipSize, "consensus-gossip-idle-size", 10,
+		"Number of peers an accepted frontier is gossiped to if it hasn't changed, and no peers connected, since it was last gossiped. "+
+			"If 0, accepted frontiers are always gossiped to as many peers as new ones are.")
+	chainConfigDir := fs.String("chain-config-dir", defaultChainConfigDir,
+		"Directory of chain config files. The file <chain ID or alias>.json overrides the consensus parameters of that chain. Example: {\"consensus\":{\"k\":20,\"alpha\":15}}")
+	chainCacheSizes := fs.String("chain-cache-sizes", "",
+		"JSON object from chain IDs or aliases to the sizes of the caches they use to deduplicate the containers they're gossiped and queried about. "+
+			"Sizes that are 0 or omitted use the defaults. Example: {\"X\":{\"vertices\":50000,\"vertexIDs\":5000,\"processedVertices\":200000}}")
 
 	fdLimit := fs.Uint64("fd-limit", ulimit.DefaultFDLimit, "Attempts to raise the process file descriptor limit to at least this value.")
 
-	ferr := fs.Parse(os.Args[1:])
+	backupDir := fs.String("backup-dir", "", "Directory of the backup to restore. Only used by the restore command.")
+
+	args := os.Args[1:]
+	restore := len(args) > 0 && args[0] == restoreCommand
+	if restore {
+		args = args[1:]
+	}
+
+	ferr := fs.Parse(args)
 
 	if *version { // If --version used, print version and exit
 		format := "%s ["
@@ -278,6 +444,16 @@ func init() {
 	}
 
 	Config.NetworkID = networkID
+	Config.GitCommit = GitCommit
+	Config.ProfileDir = os.ExpandEnv(*profileDir) // parse any env variables
+	Config.NetworkCaptureDir = os.ExpandEnv(*captureDir)
+	Config.ConsensusRecordDir = os.ExpandEnv(*recordDir)
+	if *recordChains != "" {
+		Config.ConsensusRecordChains = strings.Split(*recordChains, ",")
+	}
+	if *skippedChains != "" {
+		Config.SkippedChains = strings.Split(*skippedChains, ",")
+	}
 
 	// DB:
 	if *db {
@@ -293,6 +469,19 @@ func init() {
 		Config.DB = memdb.New()
 	}
 
+	if restore {
+		switch {
+		case !*db:
+			errs.Add(errRestoreNoDB)
+		case *backupDir == "":
+			errs.Add(errNoBackupDir)
+		default:
+			RestoreDir = *backupDir
+		}
+		// The rest of the config isn't needed to restore a backup
+		return
+	}
+
 	// Resolves our public IP, or does nothing
 	Config.DynamicPublicIPResolver = dynamicip.NewResolver(*dynamicPublicIPResolver)
 
@@ -350,6 +539,27 @@ func init() {
 		}
 	}
 
+	for _, domain := range strings.Split(*bootstrapDNSSeeds, ",") {
+		if domain != "" {
+			Config.BootstrapDNSSeeds = append(Config.BootstrapDNSSeeds, domain)
+		}
+	}
+	Config.BootstrapDNSSeedSigners = ids.ShortSet{}
+	for _, signer := range strings.Split(*bootstrapDNSSeedSigners, ",") {
+		if signer != "" {
+			signerID, err := ids.ShortFromString(signer)
+			if err != nil {
+				errs.Add(fmt.Errorf("couldn't parse DNS seed signer: %w", err))
+				return
+			}
+			Config.BootstrapDNSSeedSigners.Add(signerID)
+		}
+	}
+	if len(Config.BootstrapDNSSeeds) > 0 && Config.BootstrapDNSSeedSigners.Len() == 0 {
+		errs.Add(errNoDNSSeedSigners)
+		return
+	}
+
 	if *bootstrapIDs == "default" {
 		if *bootstrapIPs == "" {
 			*bootstrapIDs = ""
@@ -358,6 +568,45 @@ func init() {
 		}
 	}
 
+	// Staking TLS:
+	if Config.StakingTLSMinVersion, err = network.ParseTLSVersion(*stakingTLSMinVersion); err != nil {
+		errs.Add(err)
+		return
+	}
+	if Config.StakingTLSCipherSuites, err = network.ParseCipherSuites(*stakingTLSCipherSuites); err != nil {
+		errs.Add(fmt.Errorf("couldn't parse staking TLS cipher suites: %w", err))
+		return
+	}
+	if Config.StakingListenAddresses, err = network.ParseListenAddresses(*stakingListenAddresses); err != nil {
+		errs.Add(fmt.Errorf("couldn't parse staking listen addresses: %w", err))
+		return
+	}
+
+	// Peer gating:
+	if Config.PeerGate.AllowedNodeIDs, err = network.ParseNodeIDs(*allowedNodeIDs); err != nil {
+		errs.Add(fmt.Errorf("couldn't parse allowed node IDs: %w", err))
+		return
+	}
+	if Config.PeerGate.DeniedNodeIDs, err = network.ParseNodeIDs(*deniedNodeIDs); err != nil {
+		errs.Add(fmt.Errorf("couldn't parse denied node IDs: %w", err))
+		return
+	}
+	if Config.PeerGate.AllowedIPs, err = network.ParseIPNets(*allowedIPs); err != nil {
+		errs.Add(fmt.Errorf("couldn't parse allowed IPs: %w", err))
+		return
+	}
+	if Config.PeerGate.DeniedIPs, err = network.ParseIPNets(*deniedIPs); err != nil {
+		errs.Add(fmt.Errorf("couldn't parse denied IPs: %w", err))
+		return
+	}
+
+	if *torEnabled {
+		if Config.NetworkProxy.Address == "" {
+			Config.NetworkProxy.Address = network.DefaultTorProxyAddress
+		}
+		Config.NetworkProxy.IsolateStreams = true
+	}
+
 	if Config.EnableStaking && !Config.EnableP2PTLS {
 		errs.Add(errStakingRequiresTLS)
 		return
@@ -393,6 +642,10 @@ func init() {
 			peer.ID = ids.NewShortID(hashing.ComputeHash160Array([]byte(peer.IP.String())))
 		}
 	}
+	if Config.BootstrapFetchWindow <= 0 {
+		errs.Add(errInvalidFetchWindow)
+		return
+	}
 
 	// Plugins
 	if _, err := os.Stat(Config.PluginDir); os.IsNotExist(err) {
@@ -427,7 +680,33 @@ func init() {
 
 	// HTTP:
 	Config.HTTPHost = *httpHost
+	Config.GRPCPort = uint16(*grpcPort)
+	Config.APITimeouts.HandlerTimeouts = make(map[string]time.Duration)
+	for _, entry := range splitList(*apiHandlerTimeouts) {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			errs.Add(fmt.Errorf("couldn't parse API handler timeout %q", entry))
+			return
+		}
+		timeout, err := time.ParseDuration(parts[1])
+		if err != nil {
+			errs.Add(fmt.Errorf("couldn't parse API handler timeout %q: %w", entry, err))
+			return
+		}
+		Config.APITimeouts.HandlerTimeouts[parts[0]] = timeout
+	}
+	Config.APICORSConfig = api.CORSConfig{
+		AllowedOrigins: splitList(*corsAllowedOrigins),
+		AllowedMethods: splitList(*corsAllowedMethods),
+		AllowedHeaders: splitList(*corsAllowedHeaders),
+	}
 	Config.HTTPPort = uint16(*httpPort)
+	unixSocketMode, err := strconv.ParseUint(*httpUnixSocketMode, 8, 32)
+	if err != nil || os.FileMode(unixSocketMode)&^os.ModePerm != 0 {
+		errs.Add(fmt.Errorf("invalid http-unix-socket-mode %q", *httpUnixSocketMode))
+		return
+	}
+	Config.HTTPUnixSocketMode = os.FileMode(unixSocketMode)
 	if Config.APIRequireAuthToken {
 		if Config.APIAuthPassword == "" {
 			errs.Add(errors.New("api-auth-password must be provided if api-auth-required is true"))
@@ -439,6 +718,20 @@ func init() {
 		}
 	}
 
+	// Health:
+	if *healthChecksFile != "" {
+		checksBytes, err := ioutil.ReadFile(*healthChecksFile)
+		if err != nil {
+			errs.Add(fmt.Errorf("couldn't read health checks file: %w", err))
+			return
+		}
+		Config.ExternalHealthChecks, err = health.ParseExternalCheckConfigs(checksBytes)
+		if err != nil {
+			errs.Add(fmt.Errorf("couldn't parse health checks file: %w", err))
+			return
+		}
+	}
+
 	// Logging:
 	if *logsDir != "" {
 		loggingConfig.Directory = *logsDir
@@ -499,9 +792,24 @@ func init() {
 	if Config.ConsensusGossipFrequency < 0 {
 		errs.Add(errors.New("gossip frequency can't be negative"))
 	}
+	if Config.ConsensusMinGossipFrequency < 0 {
+		errs.Add(errors.New("minimum gossip frequency can't be negative"))
+	}
+	if Config.ConsensusMinGossipFrequency > 0 && Config.ConsensusMaxGossipFrequency < Config.ConsensusMinGossipFrequency {
+		errs.Add(errors.New("maximum gossip frequency can't be less than the minimum gossip frequency"))
+	}
+	if Config.ConsensusIdleGossipSize < 0 {
+		errs.Add(errors.New("idle gossip size can't be negative"))
+	}
 	if Config.ConsensusShutdownTimeout < 0 {
 		errs.Add(errors.New("gossip frequency can't be negative"))
 	}
+	if Config.ChainCacheSizes, err = chains.ParseCacheSizes(*chainCacheSizes); err != nil {
+		errs.Add(err)
+	}
+	if Config.ChainConsensusConfigs, err = chains.ReadConsensusConfigs(os.ExpandEnv(*chainConfigDir)); err != nil {
+		errs.Add(err)
+	}
 
 	if err := ulimit.Set(*fdLimit); err != nil {
 		errs.Add(fmt.Errorf("failed to set fd limit correctly due to: %w", err))
@@ -539,3 +847,16 @@ func init() {
 		Config.Params = *genesis.GetParams(networkID)
 	}
 }
+
+// splitList returns the elements of the comma separated list [list], which are
+// trimmed of spaces. Returns nil if [list] is empty.
+func splitList(list string) []string {
+	if strings.TrimSpace(list) == "" {
+		return nil
+	}
+	elements := strings.Split(list, ",")
+	for i, element := range elements {
+		elements[i] = strings.TrimSpace(element)
+	}
+	return elements
+}
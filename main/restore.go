@@ -0,0 +1,49 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database/backup"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// restore copies the backup in [RestoreDir] into the node's database. The
+// backup must have been taken on the same network with the same database
+// version, and the node's database must be empty.
+func restore() error {
+	manifest, err := backup.ReadManifest(RestoreDir)
+	if err != nil {
+		return err
+	}
+	if manifest.NetworkID != Config.NetworkID {
+		return fmt.Errorf("backup was taken on network %s but the node is configured for network %s",
+			constants.NetworkName(manifest.NetworkID),
+			constants.NetworkName(Config.NetworkID),
+		)
+	}
+	if manifest.DatabaseVersion != dbVersion {
+		return fmt.Errorf("backup has database version %s but the node uses database version %s",
+			manifest.DatabaseVersion,
+			dbVersion,
+		)
+	}
+
+	if _, err := backup.Restore(RestoreDir, Config.DB); err != nil {
+		return err
+	}
+	fmt.Printf("restored %d keys, taken by node version %s, from %s\n",
+		manifest.NumKeys,
+		manifest.NodeVersion,
+		RestoreDir,
+	)
+	for _, chain := range manifest.Chains {
+		if chain.LastAccepted == "" {
+			continue
+		}
+		fmt.Printf("chain %s last accepted %s at height %d\n", chain.ChainID, chain.LastAccepted, chain.Height)
+	}
+	return nil
+}
@@ -15,15 +15,27 @@ import (
 const (
 	mapTimeout        = 30 * time.Minute
 	maxRefreshRetries = 3
+	// failedRenewDelay is the longest a failed renewal waits to be retried
+	failedRenewDelay = time.Minute
 )
 
+// mapRetryDelay is how long a failed attempt to map a port waits before it's
+// retried
+var mapRetryDelay = time.Second
+
 // Router describes the functionality that a network device must support to be
 // able to open ports to an external IP.
 type Router interface {
 	// True iff this router supports NAT
 	SupportsNAT() bool
-	// Map external port [extPort] to internal port [intPort] for [duration]
-	MapPort(protocol string, intPort, extPort uint16, desc string, duration time.Duration) error
+	// Map external port [extPort] to internal port [intPort] for [duration].
+	// Returns the external port that was mapped, which may differ from
+	// [extPort], and how long the mapping lasts, which may be shorter than
+	// [duration]. A lifetime of 0 means the mapping doesn't expire.
+	MapPort(protocol string, intPort, extPort uint16, desc string, duration time.Duration) (uint16, time.Duration, error)
+	// Verify that external port [extPort] is mapped to internal port [intPort]
+	// of this host
+	VerifyPortMapping(protocol string, intPort, extPort uint16) error
 	// Undo a port mapping
 	UnmapPort(protocol string, intPort, extPort uint16) error
 	// Return our external IP
@@ -42,17 +54,50 @@ func GetRouter() Router {
 	return NewNoRouter()
 }
 
+// MappingStatus describes a port mapping a Mapper keeps open
+type MappingStatus struct {
+	Protocol     string
+	InternalPort uint16
+	// ExternalPort is the port the router mapped, which may differ from the
+	// requested port
+	ExternalPort uint16
+	Description  string
+	// Mapped is true if the router mapped the port the last time it was asked
+	Mapped bool
+	// Verified is true if the router reported the mapping after mapping it
+	Verified bool
+	// LastRenewed is when the port was last mapped, or the zero time if it
+	// never was
+	LastRenewed time.Time
+	// Expiry is when the mapping expires unless it's renewed, or the zero time
+	// if it doesn't expire
+	Expiry time.Time
+	// Error is the error the last attempt to map or verify the port failed
+	// with, if it failed
+	Error string
+}
+
+// Status describes the router a Mapper maps ports on and its mappings
+type Status struct {
+	// Router is the protocol of the router: upnp, nat-pmp or none
+	Router   string
+	Mappings []MappingStatus
+}
+
 // Mapper attempts to open a set of ports on a router
 type Mapper struct {
 	log    logging.Logger
 	r      Router
 	closer chan struct{}
 	wg     sync.WaitGroup
+
+	lock     sync.RWMutex
+	mappings []*MappingStatus
 }
 
 // NewPortMapper returns an initialized mapper
-func NewPortMapper(log logging.Logger, r Router) Mapper {
-	return Mapper{
+func NewPortMapper(log logging.Logger, r Router) *Mapper {
+	return &Mapper{
 		log:    log,
 		r:      r,
 		closer: make(chan struct{}),
@@ -60,74 +105,179 @@ func NewPortMapper(log logging.Logger, r Router) Mapper {
 }
 
 // Map external port [extPort] (exposed to the internet) to internal port [intPort] (where our process is listening)
-// and set [ip]. Does this every [updateTime]. [ip] may be nil.
+// and set [ip]. Renews the mapping every [updateTime], or before it expires if
+// that's sooner, and updates [ip] when it does. [ip] may be nil.
 func (m *Mapper) Map(protocol string, intPort, extPort uint16, desc string, ip *utils.DynamicIPDesc, updateTime time.Duration) {
 	if !m.r.SupportsNAT() {
 		return
 	}
 
+	status := &MappingStatus{
+		Protocol:     protocol,
+		InternalPort: intPort,
+		ExternalPort: extPort,
+		Description:  desc,
+	}
+	m.lock.Lock()
+	m.mappings = append(m.mappings, status)
+	m.lock.Unlock()
+
 	// we attempt a port map, and log an Error if it fails.
-	err := m.retryMapPort(protocol, intPort, extPort, desc, mapTimeout)
+	lifetime, err := m.mapPort(status, ip)
 	if err != nil {
 		m.log.Error("NAT Traversal failed from external port %d to internal port %d with %s", extPort, intPort, err)
 	} else {
-		m.log.Info("NAT Traversal successful from external port %d to internal port %d", extPort, intPort)
+		m.log.Info("NAT Traversal successful from external port %d to internal port %d", m.externalPort(status), intPort)
 	}
 
-	go m.keepPortMapping(protocol, intPort, extPort, desc, ip, updateTime)
+	m.wg.Add(1)
+	go m.keepPortMapping(status, ip, updateTime, renewDelay(updateTime, lifetime, err))
 }
 
-// Retry port map up to maxRefreshRetries with a 1 second delay
-func (m *Mapper) retryMapPort(protocol string, intPort, extPort uint16, desc string, timeout time.Duration) error {
+// Status returns the router ports are mapped on and the mappings
+func (m *Mapper) Status() Status {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	status := Status{
+		Router:   routerName(m.r),
+		Mappings: make([]MappingStatus, len(m.mappings)),
+	}
+	for i, mapping := range m.mappings {
+		status.Mappings[i] = *mapping
+	}
+	return status
+}
+
+// mapPort maps the port [status] describes, verifies the mapping and records
+// the outcome in [status]. If the router mapped a different external port, it
+// will be requested from now on, and [ip], if non-nil, is updated to use it.
+// Returns how long the mapping lasts.
+func (m *Mapper) mapPort(status *MappingStatus, ip *utils.DynamicIPDesc) (time.Duration, error) {
+	m.lock.RLock()
+	protocol, intPort, extPort, desc := status.Protocol, status.InternalPort, status.ExternalPort, status.Description
+	m.lock.RUnlock()
+
+	mappedPort, lifetime, err := m.retryMapPort(protocol, intPort, extPort, desc, mapTimeout)
+	if err != nil {
+		m.lock.Lock()
+		status.Mapped = false
+		status.Verified = false
+		status.Error = err.Error()
+		m.lock.Unlock()
+		return 0, err
+	}
+	verifyErr := m.r.VerifyPortMapping(protocol, intPort, mappedPort)
+	if verifyErr != nil {
+		m.log.Warn("router didn't report the mapping from external port %d to internal port %d: %s",
+			mappedPort, intPort, verifyErr)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now()
+	status.Mapped = true
+	status.Verified = verifyErr == nil
+	status.Error = ""
+	if verifyErr != nil {
+		status.Error = verifyErr.Error()
+	}
+	status.LastRenewed = now
+	status.Expiry = time.Time{}
+	if lifetime > 0 {
+		status.Expiry = now.Add(lifetime)
+	}
+	if mappedPort != extPort {
+		m.log.Warn("router mapped external port %d rather than %d to internal port %d",
+			mappedPort, extPort, intPort)
+		status.ExternalPort = mappedPort
+		if ip != nil && ip.IP().Port == extPort {
+			ip.UpdatePort(mappedPort)
+		}
+	}
+	return lifetime, nil
+}
+
+// Retry port map up to maxRefreshRetries with a mapRetryDelay delay
+func (m *Mapper) retryMapPort(protocol string, intPort, extPort uint16, desc string, timeout time.Duration) (uint16, time.Duration, error) {
 	var err error
 	for retryCnt := 0; retryCnt < maxRefreshRetries; retryCnt++ {
-		err = m.r.MapPort(protocol, intPort, extPort, desc, timeout)
-		if err == nil {
-			return nil
+		mappedPort, lifetime, mapErr := m.r.MapPort(protocol, intPort, extPort, desc, timeout)
+		if mapErr == nil {
+			return mappedPort, lifetime, nil
 		}
+		err = mapErr
 
 		// log a message, sleep a second and retry.
 		m.log.Error("Renewing port mapping try #%d from external port %d to internal port %d failed with %s",
 			retryCnt+1, extPort, intPort, err)
-		time.Sleep(1 * time.Second)
+		time.Sleep(mapRetryDelay)
 	}
-	return err
+	return 0, 0, err
 }
 
-// keepPortMapping runs in the background to keep a port mapped. It renews the mapping from [extPort]
-// to [intPort]] every [updateTime]. Updates [ip] every [updateTime].
-func (m *Mapper) keepPortMapping(protocol string, intPort, extPort uint16, desc string, ip *utils.DynamicIPDesc, updateTime time.Duration) {
-	updateTimer := time.NewTimer(updateTime)
+// renewDelay returns how long to wait to renew a mapping that lasts
+// [lifetime], which is renewed every [updateTime] unless it would expire
+// first. If the mapping failed with [err], it's renewed sooner.
+func renewDelay(updateTime, lifetime time.Duration, err error) time.Duration {
+	delay := updateTime
+	// renew at the halfway point of the mapping, so that a failed renewal
+	// can be retried before it expires
+	if lifetime > 0 && lifetime/2 < delay {
+		delay = lifetime / 2
+	}
+	if err != nil && failedRenewDelay < delay {
+		delay = failedRenewDelay
+	}
+	return delay
+}
 
-	m.wg.Add(1)
+// keepPortMapping runs in the background to keep a port mapped. It renews the
+// mapping [status] describes after [delay], and then as renewDelay describes.
+// Updates [ip] whenever it renews the mapping.
+func (m *Mapper) keepPortMapping(status *MappingStatus, ip *utils.DynamicIPDesc, updateTime, delay time.Duration) {
+	updateTimer := time.NewTimer(delay)
 
-	defer func(extPort uint16) {
+	defer func() {
 		updateTimer.Stop()
 
+		m.lock.RLock()
+		protocol, intPort, extPort := status.Protocol, status.InternalPort, status.ExternalPort
+		m.lock.RUnlock()
+
 		m.log.Debug("Unmap protocol %s external port %d", protocol, extPort)
 		if err := m.r.UnmapPort(protocol, intPort, extPort); err != nil {
 			m.log.Debug("Error unmapping port %d to %d: %s", intPort, extPort, err)
 		}
 
 		m.wg.Done()
-	}(extPort)
+	}()
 
 	for {
 		select {
 		case <-updateTimer.C:
-			err := m.retryMapPort(protocol, intPort, extPort, desc, mapTimeout)
+			lifetime, err := m.mapPort(status, ip)
 			if err != nil {
 				m.log.Warn("Renew NAT Traversal failed from external port %d to internal port %d with %s",
-					extPort, intPort, err)
+					m.externalPort(status), status.InternalPort, err)
 			}
 			m.updateIP(ip)
-			updateTimer.Reset(updateTime)
+			updateTimer.Reset(renewDelay(updateTime, lifetime, err))
 		case <-m.closer:
 			return
 		}
 	}
 }
 
+// externalPort returns the external port of [status]
+func (m *Mapper) externalPort(status *MappingStatus) uint16 {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return status.ExternalPort
+}
+
 func (m *Mapper) updateIP(ip *utils.DynamicIPDesc) {
 	if ip == nil {
 		return
@@ -151,3 +301,15 @@ func (m *Mapper) UnmapAllPorts() {
 	m.wg.Wait()
 	m.log.Info("Unmapped all ports")
 }
+
+// routerName returns the protocol [r] maps ports with
+func routerName(r Router) string {
+	switch r.(type) {
+	case *upnpRouter:
+		return "upnp"
+	case *pmpRouter:
+		return "nat-pmp"
+	default:
+		return "none"
+	}
+}
@@ -0,0 +1,273 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var (
+	errTestMapFailed  = errors.New("map failed")
+	errTestNotMapped  = errors.New("not mapped")
+	errTestIPUnknown  = errors.New("external IP unknown")
+	testExternalIP    = net.IPv4(1, 2, 3, 4)
+	testNewExternalIP = net.IPv4(1, 2, 3, 5)
+)
+
+func init() { mapRetryDelay = time.Millisecond }
+
+// testRouter is a router that keeps its mappings in memory
+type testRouter struct {
+	lock sync.Mutex
+	// number of calls to MapPort that fail before one succeeds, or -1 if every
+	// call fails
+	mapFailures int
+	// port MapPort maps rather than the requested one, if non-zero
+	assignedPort uint16
+	// if true, MapPort reports success without mapping the port
+	forget bool
+	// how long the mappings last
+	lifetime time.Duration
+	ip       net.IP
+
+	mapCalls int
+	// internal port of each mapped external port
+	mappings map[uint16]uint16
+}
+
+func newTestRouter() *testRouter {
+	return &testRouter{
+		ip:       testExternalIP,
+		mappings: make(map[uint16]uint16),
+	}
+}
+
+func (*testRouter) SupportsNAT() bool { return true }
+
+func (r *testRouter) MapPort(_ string, intPort, extPort uint16, _ string, _ time.Duration) (uint16, time.Duration, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.mapCalls++
+	if r.mapFailures != 0 {
+		if r.mapFailures > 0 {
+			r.mapFailures--
+		}
+		return 0, 0, errTestMapFailed
+	}
+	if r.assignedPort != 0 {
+		extPort = r.assignedPort
+	}
+	if !r.forget {
+		r.mappings[extPort] = intPort
+	}
+	return extPort, r.lifetime, nil
+}
+
+func (r *testRouter) VerifyPortMapping(_ string, intPort, extPort uint16) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if mappedPort, ok := r.mappings[extPort]; !ok || mappedPort != intPort {
+		return errTestNotMapped
+	}
+	return nil
+}
+
+func (r *testRouter) UnmapPort(_ string, _, extPort uint16) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.mappings, extPort)
+	return nil
+}
+
+func (r *testRouter) ExternalIP() (net.IP, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.ip == nil {
+		return nil, errTestIPUnknown
+	}
+	return r.ip, nil
+}
+
+// lose drops the mapping of [extPort] without the mapper knowing
+func (r *testRouter) lose(extPort uint16) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.mappings, extPort)
+}
+
+func (r *testRouter) mapped(extPort uint16) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	_, ok := r.mappings[extPort]
+	return ok
+}
+
+func (r *testRouter) setIP(ip net.IP) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.ip = ip
+}
+
+// eventually returns true once [condition] does, or false if it doesn't within
+// a second
+func eventually(condition func() bool) bool {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if condition() {
+			return true
+		}
+	}
+	return condition()
+}
+
+func TestRenewDelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		updateTime time.Duration
+		lifetime   time.Duration
+		err        error
+		expected   time.Duration
+	}{
+		{"permanent mapping", time.Hour, 0, nil, time.Hour},
+		{"long mapping", time.Hour, 4 * time.Hour, nil, time.Hour},
+		{"short mapping", time.Hour, 20 * time.Minute, nil, 10 * time.Minute},
+		{"failed mapping", time.Hour, 0, errTestMapFailed, failedRenewDelay},
+		{"failed mapping with a short update time", time.Second, 0, errTestMapFailed, time.Second},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, renewDelay(test.updateTime, test.lifetime, test.err))
+		})
+	}
+}
+
+func TestMapPortRetries(t *testing.T) {
+	r := newTestRouter()
+	r.mapFailures = maxRefreshRetries - 1
+	r.lifetime = time.Hour
+	m := NewPortMapper(logging.NoLog{}, r)
+	status := &MappingStatus{Protocol: "TCP", InternalPort: 9651, ExternalPort: 9651}
+
+	lifetime, err := m.mapPort(status, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, lifetime)
+	assert.Equal(t, maxRefreshRetries, r.mapCalls)
+	assert.True(t, status.Mapped)
+	assert.True(t, status.Verified)
+	assert.Empty(t, status.Error)
+	assert.False(t, status.LastRenewed.IsZero())
+	assert.Equal(t, status.LastRenewed.Add(time.Hour), status.Expiry)
+}
+
+func TestMapPortFails(t *testing.T) {
+	r := newTestRouter()
+	r.mapFailures = -1
+	m := NewPortMapper(logging.NoLog{}, r)
+	status := &MappingStatus{Protocol: "TCP", InternalPort: 9651, ExternalPort: 9651}
+
+	_, err := m.mapPort(status, nil)
+	assert.Equal(t, errTestMapFailed, err)
+	assert.Equal(t, maxRefreshRetries, r.mapCalls)
+	assert.False(t, status.Mapped)
+	assert.False(t, status.Verified)
+	assert.Equal(t, errTestMapFailed.Error(), status.Error)
+
+	// the mapping is renewed once the router maps it
+	r.lock.Lock()
+	r.mapFailures = 0
+	r.lock.Unlock()
+	_, err = m.mapPort(status, nil)
+	assert.NoError(t, err)
+	assert.True(t, status.Mapped)
+	assert.Empty(t, status.Error)
+}
+
+func TestMapPortAssignsPort(t *testing.T) {
+	r := newTestRouter()
+	r.assignedPort = 9652
+	m := NewPortMapper(logging.NoLog{}, r)
+	status := &MappingStatus{Protocol: "TCP", InternalPort: 9651, ExternalPort: 9651}
+	ip := utils.NewDynamicIPDesc(testExternalIP, 9651)
+
+	_, err := m.mapPort(status, &ip)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(9652), status.ExternalPort)
+	assert.Equal(t, uint16(9652), ip.IP().Port)
+	assert.True(t, status.Verified)
+}
+
+func TestMapRenewsLostMapping(t *testing.T) {
+	r := newTestRouter()
+	m := NewPortMapper(logging.NoLog{}, r)
+
+	m.Map("TCP", 9651, 9651, "test", nil, 10*time.Millisecond)
+	assert.True(t, r.mapped(9651))
+
+	r.lose(9651)
+	assert.True(t, eventually(func() bool { return r.mapped(9651) }), "the lost mapping should be renewed")
+
+	status := m.Status()
+	if assert.Len(t, status.Mappings, 1) {
+		assert.True(t, status.Mappings[0].Mapped)
+	}
+
+	m.UnmapAllPorts()
+	assert.False(t, r.mapped(9651), "the mapping should be undone")
+}
+
+func TestMapPortUnverified(t *testing.T) {
+	r := newTestRouter()
+	r.forget = true
+	m := NewPortMapper(logging.NoLog{}, r)
+	status := &MappingStatus{Protocol: "TCP", InternalPort: 9651, ExternalPort: 9651}
+
+	// the router reports mapping the port, but doesn't
+	_, err := m.mapPort(status, nil)
+	assert.NoError(t, err)
+	assert.True(t, status.Mapped)
+	assert.False(t, status.Verified)
+	assert.Equal(t, errTestNotMapped.Error(), status.Error)
+
+	r.lock.Lock()
+	r.forget = false
+	r.lock.Unlock()
+	_, err = m.mapPort(status, nil)
+	assert.NoError(t, err)
+	assert.True(t, status.Verified)
+	assert.Empty(t, status.Error)
+}
+
+func TestMapUpdatesExternalIP(t *testing.T) {
+	r := newTestRouter()
+	m := NewPortMapper(logging.NoLog{}, r)
+	ip := utils.NewDynamicIPDesc(testExternalIP, 9651)
+
+	m.Map("TCP", 9651, 9651, "test", &ip, 10*time.Millisecond)
+
+	r.setIP(testNewExternalIP)
+	assert.True(t, eventually(func() bool { return ip.IP().IP.Equal(testNewExternalIP) }), "the new external IP should be used")
+
+	// an unknown external IP doesn't replace the known one
+	r.setIP(nil)
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, ip.IP().IP.Equal(testNewExternalIP))
+	assert.Equal(t, uint16(9651), ip.IP().Port)
+
+	m.UnmapAllPorts()
+}
@@ -25,7 +25,11 @@ func (noRouter) SupportsNAT() bool {
 	return false
 }
 
-func (noRouter) MapPort(_ string, intPort, extPort uint16, _ string, _ time.Duration) error {
+func (noRouter) MapPort(_ string, intPort, extPort uint16, _ string, _ time.Duration) (uint16, time.Duration, error) {
+	return 0, 0, errNoRouterCantMapPorts
+}
+
+func (noRouter) VerifyPortMapping(string, uint16, uint16) error {
 	return errNoRouterCantMapPorts
 }
 
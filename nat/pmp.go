@@ -34,7 +34,7 @@ func (r *pmpRouter) MapPort(
 	newExternalPort uint16,
 	mappingName string,
 	mappingDuration time.Duration,
-) error {
+) (uint16, time.Duration, error) {
 	protocol := networkProtocol
 	internalPort := int(newInternalPort)
 	externalPort := int(newExternalPort)
@@ -43,11 +43,22 @@ func (r *pmpRouter) MapPort(
 	lifetime := mappingDuration.Seconds()
 	// Assumes the architecture is at least 32-bit
 	if lifetime < 0 || lifetime > math.MaxInt32 {
-		return fmt.Errorf("invalid mapping duration range")
+		return 0, 0, fmt.Errorf("invalid mapping duration range")
 	}
 
-	_, err := r.client.AddPortMapping(protocol, internalPort, externalPort, int(lifetime))
-	return err
+	// the router may map a different external port, or for less time, than
+	// was requested
+	result, err := r.client.AddPortMapping(protocol, internalPort, externalPort, int(lifetime))
+	if err != nil {
+		return 0, 0, err
+	}
+	return result.MappedExternalPort, time.Duration(result.PortMappingLifetimeInSeconds) * time.Second, nil
+}
+
+// VerifyPortMapping is a no-op. NAT-PMP routers respond to mapping requests
+// with the mapping they made, and can't be asked about mappings otherwise.
+func (r *pmpRouter) VerifyPortMapping(string, uint16, uint16) error {
+	return nil
 }
 
 func (r *pmpRouter) UnmapPort(
@@ -4,18 +4,25 @@
 package nat
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/huin/goupnp"
 	"github.com/huin/goupnp/dcps/internetgateway1"
 	"github.com/huin/goupnp/dcps/internetgateway2"
+	"github.com/huin/goupnp/soap"
 )
 
 const (
 	soapRequestTimeout = 10 * time.Second
+
+	// errorCode the router responds with if it only supports mappings that
+	// don't expire
+	onlyPermanentLeasesSupported = "725"
 )
 
 // upnpClient is the interface used by goupnp for their client implementations
@@ -116,18 +123,48 @@ func (r *upnpRouter) ExternalIP() (net.IP, error) {
 }
 
 func (r *upnpRouter) MapPort(protocol string, intPort, extPort uint16,
-	desc string, duration time.Duration) error {
+	desc string, duration time.Duration) (uint16, time.Duration, error) {
 	ip, err := r.localIP()
 	if err != nil {
-		return nil
+		return 0, 0, err
 	}
 	lifetime := duration.Seconds()
 	if lifetime < 0 || lifetime > math.MaxUint32 {
-		return fmt.Errorf("invalid lifetime duration range")
+		return 0, 0, fmt.Errorf("invalid lifetime duration range")
 	}
 
-	return r.client.AddPortMapping("", extPort, protocol, intPort,
+	err = r.client.AddPortMapping("", extPort, protocol, intPort,
 		ip.String(), true, desc, uint32(lifetime))
+	var fault *soap.SOAPFaultError
+	if errors.As(err, &fault) && strings.Contains(fault.Detail, onlyPermanentLeasesSupported) {
+		// the router only supports mappings that don't expire, which are
+		// removed when the node shuts down
+		return extPort, 0, r.client.AddPortMapping("", extPort, protocol, intPort,
+			ip.String(), true, desc, 0)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return extPort, duration, nil
+}
+
+func (r *upnpRouter) VerifyPortMapping(protocol string, intPort, extPort uint16) error {
+	ip, err := r.localIP()
+	if err != nil {
+		return err
+	}
+	mappedPort, mappedClient, enabled, _, _, err := r.client.GetSpecificPortMappingEntry("", extPort, protocol)
+	switch {
+	case err != nil:
+		return err
+	case !enabled:
+		return fmt.Errorf("external port %d is mapped but disabled", extPort)
+	case mappedPort != intPort || !ip.Equal(net.ParseIP(mappedClient)):
+		return fmt.Errorf("external port %d is mapped to %s:%d rather than %s:%d",
+			extPort, mappedClient, mappedPort, ip, intPort)
+	default:
+		return nil
+	}
 }
 
 func (r *upnpRouter) UnmapPort(protocol string, _, extPort uint16) error {
@@ -0,0 +1,166 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+// Ban prevents a peer from connecting to this node
+type Ban struct {
+	// NodeID of the banned peer, if it's banned by node ID
+	NodeID string `json:"nodeID,omitempty"`
+	// IP of the banned peer, if it's banned by IP
+	IP string `json:"ip,omitempty"`
+	// Expiry of the ban, or nil if the ban doesn't expire
+	Expiry *time.Time `json:"expiry,omitempty"`
+}
+
+// banList tracks the node IDs and IPs that are banned. The zero time means a
+// ban doesn't expire. Expired bans are removed lazily.
+type banList struct {
+	lock    sync.Mutex
+	clock   *timer.Clock
+	nodeIDs map[[20]byte]time.Time
+	ips     map[string]time.Time
+}
+
+func newBanList(clock *timer.Clock) *banList {
+	return &banList{
+		clock:   clock,
+		nodeIDs: make(map[[20]byte]time.Time),
+		ips:     make(map[string]time.Time),
+	}
+}
+
+// expiry returns when a ban of [duration] made now expires
+func (b *banList) expiry(duration time.Duration) time.Time {
+	if duration <= 0 {
+		return time.Time{}
+	}
+	return b.clock.Time().Add(duration)
+}
+
+// expired returns true if a ban that expires at [expiry] has expired
+func (b *banList) expired(expiry time.Time) bool {
+	return !expiry.IsZero() && !b.clock.Time().Before(expiry)
+}
+
+func (b *banList) banNodeID(nodeID ids.ShortID, duration time.Duration) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.nodeIDs[nodeID.Key()] = b.expiry(duration)
+}
+
+func (b *banList) banIP(ip net.IP, duration time.Duration) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.ips[ip.String()] = b.expiry(duration)
+}
+
+// unbanNodeID returns true if [nodeID] was banned
+func (b *banList) unbanNodeID(nodeID ids.ShortID) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := nodeID.Key()
+	expiry, ok := b.nodeIDs[key]
+	delete(b.nodeIDs, key)
+	return ok && !b.expired(expiry)
+}
+
+// unbanIP returns true if [ip] was banned
+func (b *banList) unbanIP(ip net.IP) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := ip.String()
+	expiry, ok := b.ips[key]
+	delete(b.ips, key)
+	return ok && !b.expired(expiry)
+}
+
+func (b *banList) nodeIDBanned(nodeID ids.ShortID) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := nodeID.Key()
+	expiry, ok := b.nodeIDs[key]
+	if ok && b.expired(expiry) {
+		delete(b.nodeIDs, key)
+		return false
+	}
+	return ok
+}
+
+func (b *banList) ipBanned(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := ip.String()
+	expiry, ok := b.ips[key]
+	if ok && b.expired(expiry) {
+		delete(b.ips, key)
+		return false
+	}
+	return ok
+}
+
+// list returns the bans that haven't expired
+func (b *banList) list() []Ban {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	bans := make([]Ban, 0, len(b.nodeIDs)+len(b.ips))
+	for key, expiry := range b.nodeIDs {
+		if b.expired(expiry) {
+			delete(b.nodeIDs, key)
+			continue
+		}
+		bans = append(bans, Ban{
+			NodeID: ids.NewShortID(key).PrefixedString(constants.NodeIDPrefix),
+			Expiry: expiryPtr(expiry),
+		})
+	}
+	for ip, expiry := range b.ips {
+		if b.expired(expiry) {
+			delete(b.ips, ip)
+			continue
+		}
+		bans = append(bans, Ban{
+			IP:     ip,
+			Expiry: expiryPtr(expiry),
+		})
+	}
+	return bans
+}
+
+func expiryPtr(expiry time.Time) *time.Time {
+	if expiry.IsZero() {
+		return nil
+	}
+	return &expiry
+}
+
+// remoteIP returns the IP [conn] is connected to, or nil if it can't be
+// determined
+func remoteIP(conn net.Conn) net.IP {
+	host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(host)
+}
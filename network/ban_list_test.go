@@ -0,0 +1,81 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+func TestBanListNodeID(t *testing.T) {
+	clock := timer.Clock{}
+	clock.Set(time.Unix(1000, 0))
+	bans := newBanList(&clock)
+
+	nodeID := ids.NewShortID([20]byte{1})
+	if bans.nodeIDBanned(nodeID) {
+		t.Fatal("node ID shouldn't be banned")
+	}
+
+	bans.banNodeID(nodeID, time.Minute)
+	if !bans.nodeIDBanned(nodeID) {
+		t.Fatal("node ID should be banned")
+	}
+	if list := bans.list(); len(list) != 1 || list[0].Expiry == nil || !list[0].Expiry.Equal(time.Unix(1060, 0)) {
+		t.Fatalf("unexpected bans %v", list)
+	}
+
+	clock.Set(time.Unix(1060, 0))
+	if bans.nodeIDBanned(nodeID) {
+		t.Fatal("ban should have expired")
+	}
+	if list := bans.list(); len(list) != 0 {
+		t.Fatalf("expected no bans but got %v", list)
+	}
+
+	bans.banNodeID(nodeID, 0)
+	clock.Set(time.Unix(1000000, 0))
+	if !bans.nodeIDBanned(nodeID) {
+		t.Fatal("ban shouldn't expire")
+	}
+	if !bans.unbanNodeID(nodeID) {
+		t.Fatal("node ID should have been banned")
+	}
+	if bans.nodeIDBanned(nodeID) {
+		t.Fatal("node ID should have been unbanned")
+	}
+	if bans.unbanNodeID(nodeID) {
+		t.Fatal("node ID shouldn't be banned")
+	}
+}
+
+func TestBanListIP(t *testing.T) {
+	clock := timer.Clock{}
+	clock.Set(time.Unix(1000, 0))
+	bans := newBanList(&clock)
+
+	bans.banIP(net.IPv4(1, 2, 3, 4), 0)
+	if !bans.ipBanned(net.ParseIP("1.2.3.4")) {
+		t.Fatal("IP should be banned")
+	}
+	if bans.ipBanned(net.ParseIP("1.2.3.5")) {
+		t.Fatal("IP shouldn't be banned")
+	}
+	if bans.ipBanned(nil) {
+		t.Fatal("unknown IP shouldn't be banned")
+	}
+	if list := bans.list(); len(list) != 1 || list[0].IP != "1.2.3.4" || list[0].Expiry != nil {
+		t.Fatalf("unexpected bans %v", list)
+	}
+	if !bans.unbanIP(net.ParseIP("1.2.3.4")) {
+		t.Fatal("IP should have been banned")
+	}
+	if bans.ipBanned(net.ParseIP("1.2.3.4")) {
+		t.Fatal("IP should have been unbanned")
+	}
+}
@@ -0,0 +1,73 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sync"
+	"time"
+)
+
+// bandwidthQuantum is the size, in bytes, of the largest chunk of a message
+// that's written at once when sending is throttled. Writers wait their turn
+// between chunks, so a large message to one peer is interleaved with messages
+// to the others rather than holding up the bandwidth until it's sent.
+const bandwidthQuantum = 16 * 1024
+
+// bandwidthLimiter limits the rate bytes are sent at. It's a token bucket that
+// holds at most a second of bandwidth. Bytes are reserved before they're sent,
+// and each reservation is of the bandwidth after that of the reservations
+// before it, so writers waiting on the limiter are served in the order they
+// reserved bytes.
+type bandwidthLimiter struct {
+	lock sync.Mutex
+	// bytes per second. if 0, sending isn't limited.
+	rate float64
+	// the number of bytes that may be sent at once
+	burst float64
+	// the number of bytes that could be sent at [last]. negative if bytes that
+	// can't be sent yet are reserved.
+	tokens float64
+	last   time.Time
+}
+
+// newBandwidthLimiter returns a limiter that allows [rate] bytes to be sent
+// per second. If [rate] is 0, sending isn't limited.
+func newBandwidthLimiter(rate uint64) *bandwidthLimiter {
+	burst := float64(rate)
+	if burst < bandwidthQuantum {
+		burst = bandwidthQuantum
+	}
+	return &bandwidthLimiter{
+		rate:   float64(rate),
+		burst:  burst,
+		tokens: burst,
+	}
+}
+
+// reserve [n] bytes at [now] and return how long to wait before sending them
+func (b *bandwidthLimiter) reserve(now time.Time, n int) time.Duration {
+	if b.rate == 0 {
+		return 0
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.last.IsZero() {
+		b.last = now
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
@@ -0,0 +1,47 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBandwidthLimiterUnlimited(t *testing.T) {
+	b := newBandwidthLimiter(0)
+	now := time.Unix(0, 0)
+
+	assert.Zero(t, b.reserve(now, 1<<30))
+	assert.Zero(t, b.reserve(now, 1<<30))
+}
+
+func TestBandwidthLimiterReserve(t *testing.T) {
+	b := newBandwidthLimiter(1 << 20)
+	now := time.Unix(0, 0)
+
+	// The burst can be sent at once
+	assert.Zero(t, b.reserve(now, 1<<20))
+
+	// Bytes reserved after it wait until the limiter refills
+	assert.Equal(t, 500*time.Millisecond, b.reserve(now, 1<<19))
+
+	// Reservations are served in order, so a later reservation waits for the
+	// earlier ones
+	assert.Equal(t, time.Second, b.reserve(now, 1<<19))
+
+	// Once the reserved bytes are sent, the limiter refills
+	now = now.Add(2 * time.Second)
+	assert.Zero(t, b.reserve(now, 1<<20))
+}
+
+func TestBandwidthLimiterMinBurst(t *testing.T) {
+	b := newBandwidthLimiter(1)
+	now := time.Unix(0, 0)
+
+	// A chunk can always be sent at once, even if the rate is lower than it
+	assert.Zero(t, b.reserve(now, bandwidthQuantum))
+	assert.Equal(t, time.Second, b.reserve(now, 1))
+}
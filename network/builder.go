@@ -28,9 +28,17 @@ func (m Builder) Version(networkID, nodeID uint32, myTime uint64, ip utils.IPDes
 // GetPeerList message
 func (m Builder) GetPeerList() (Msg, error) { return m.Pack(GetPeerList, nil) }
 
-// PeerList message
-func (m Builder) PeerList(ipDescs []utils.IPDesc) (Msg, error) {
-	return m.Pack(PeerList, map[Field]interface{}{Peers: ipDescs})
+// PeerList message. [sigs], the signatures of [ipDescs], are only sent if
+// they're non-nil, so that nodes that don't accept them can parse the message.
+func (m Builder) PeerList(ipDescs []utils.IPDesc, sigs []ipSignature) (Msg, error) {
+	fields := map[Field]interface{}{Peers: ipDescs}
+	if sigs != nil {
+		if len(sigs) != len(ipDescs) {
+			return nil, errBadIPSignatures
+		}
+		fields[PeerSignatures] = sigs
+	}
+	return m.Pack(PeerList, fields)
 }
 
 // Ping message
@@ -39,6 +47,16 @@ func (m Builder) Ping() (Msg, error) { return m.Pack(Ping, nil) }
 // Pong message
 func (m Builder) Pong() (Msg, error) { return m.Pack(Pong, nil) }
 
+// Capabilities message. [sig] is the signature of the IP in the sender's
+// version message made at [sigTime], or empty if the sender doesn't sign it.
+func (m Builder) Capabilities(features uint64, sigTime uint64, sig []byte) (Msg, error) {
+	return m.Pack(Capabilities, map[Field]interface{}{
+		Features:  features,
+		SigTime:   sigTime,
+		Signature: sig,
+	})
+}
+
 // GetAcceptedFrontier message
 func (m Builder) GetAcceptedFrontier(chainID ids.ID, requestID uint32, deadline uint64) (Msg, error) {
 	return m.Pack(GetAcceptedFrontier, map[Field]interface{}{
@@ -160,3 +178,40 @@ func (m Builder) Chits(chainID ids.ID, requestID uint32, containerIDs ids.Set) (
 		ContainerIDs: containerIDBytes,
 	})
 }
+
+// GetStateSummaryFrontier message
+func (m Builder) GetStateSummaryFrontier(chainID ids.ID, requestID uint32, deadline uint64) (Msg, error) {
+	return m.Pack(GetStateSummaryFrontier, map[Field]interface{}{
+		ChainID:   chainID.Bytes(),
+		RequestID: requestID,
+		Deadline:  deadline,
+	})
+}
+
+// StateSummaryFrontier message
+func (m Builder) StateSummaryFrontier(chainID ids.ID, requestID uint32, summary []byte) (Msg, error) {
+	return m.Pack(StateSummaryFrontier, map[Field]interface{}{
+		ChainID:        chainID.Bytes(),
+		RequestID:      requestID,
+		ContainerBytes: summary,
+	})
+}
+
+// GetStateChunk message
+func (m Builder) GetStateChunk(chainID ids.ID, requestID uint32, deadline uint64, chunkID ids.ID) (Msg, error) {
+	return m.Pack(GetStateChunk, map[Field]interface{}{
+		ChainID:     chainID.Bytes(),
+		RequestID:   requestID,
+		Deadline:    deadline,
+		ContainerID: chunkID.Bytes(),
+	})
+}
+
+// StateChunk message
+func (m Builder) StateChunk(chainID ids.ID, requestID uint32, chunk []byte) (Msg, error) {
+	return m.Pack(StateChunk, map[Field]interface{}{
+		ChainID:        chainID.Bytes(),
+		RequestID:      requestID,
+		ContainerBytes: chunk,
+	})
+}
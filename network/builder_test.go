@@ -66,6 +66,19 @@ func TestBuildVersion(t *testing.T) {
 	assert.Equal(t, myVersion, parsedMsg.Get(VersionStr))
 }
 
+func TestBuildCapabilities(t *testing.T) {
+	msg, err := TestBuilder.Capabilities(CompressionFeature, 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Capabilities, msg.Op())
+	assert.Equal(t, CompressionFeature, msg.Get(Features))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, Capabilities, parsedMsg.Op())
+	assert.Equal(t, CompressionFeature, parsedMsg.Get(Features))
+	assert.Empty(t, parsedMsg.Get(Signature))
+}
+
 func TestBuildGetPeerList(t *testing.T) {
 	msg, err := TestBuilder.GetPeerList()
 	assert.NoError(t, err)
@@ -84,7 +97,7 @@ func TestBuildPeerList(t *testing.T) {
 		{IP: net.IPv6loopback, Port: 54321},
 	}
 
-	msg, err := TestBuilder.PeerList(ips)
+	msg, err := TestBuilder.PeerList(ips, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, msg)
 	assert.Equal(t, PeerList, msg.Op())
@@ -324,3 +337,94 @@ func TestBuildChits(t *testing.T) {
 	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
 	assert.Equal(t, containerIDs, parsedMsg.Get(ContainerIDs))
 }
+
+func TestBuildGetStateSummaryFrontier(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	deadline := uint64(15)
+
+	msg, err := TestBuilder.GetStateSummaryFrontier(chainID, requestID, deadline)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, GetStateSummaryFrontier, msg.Op())
+	assert.Equal(t, chainID.Bytes(), msg.Get(ChainID))
+	assert.Equal(t, requestID, msg.Get(RequestID))
+	assert.Equal(t, deadline, msg.Get(Deadline))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, GetStateSummaryFrontier, parsedMsg.Op())
+	assert.Equal(t, chainID.Bytes(), parsedMsg.Get(ChainID))
+	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
+	assert.Equal(t, deadline, parsedMsg.Get(Deadline))
+}
+
+func TestBuildStateSummaryFrontier(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	summary := []byte{1}
+
+	msg, err := TestBuilder.StateSummaryFrontier(chainID, requestID, summary)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, StateSummaryFrontier, msg.Op())
+	assert.Equal(t, chainID.Bytes(), msg.Get(ChainID))
+	assert.Equal(t, requestID, msg.Get(RequestID))
+	assert.Equal(t, summary, msg.Get(ContainerBytes))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, StateSummaryFrontier, parsedMsg.Op())
+	assert.Equal(t, chainID.Bytes(), parsedMsg.Get(ChainID))
+	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
+	assert.Equal(t, summary, parsedMsg.Get(ContainerBytes))
+}
+
+func TestBuildGetStateChunk(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	deadline := uint64(15)
+	chunkID := ids.Empty.Prefix(1)
+
+	msg, err := TestBuilder.GetStateChunk(chainID, requestID, deadline, chunkID)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, GetStateChunk, msg.Op())
+	assert.Equal(t, chainID.Bytes(), msg.Get(ChainID))
+	assert.Equal(t, requestID, msg.Get(RequestID))
+	assert.Equal(t, deadline, msg.Get(Deadline))
+	assert.Equal(t, chunkID.Bytes(), msg.Get(ContainerID))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, GetStateChunk, parsedMsg.Op())
+	assert.Equal(t, chainID.Bytes(), parsedMsg.Get(ChainID))
+	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
+	assert.Equal(t, deadline, parsedMsg.Get(Deadline))
+	assert.Equal(t, chunkID.Bytes(), parsedMsg.Get(ContainerID))
+}
+
+func TestBuildStateChunk(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	chunk := []byte{1}
+
+	msg, err := TestBuilder.StateChunk(chainID, requestID, chunk)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, StateChunk, msg.Op())
+	assert.Equal(t, chainID.Bytes(), msg.Get(ChainID))
+	assert.Equal(t, requestID, msg.Get(RequestID))
+	assert.Equal(t, chunk, msg.Get(ContainerBytes))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, StateChunk, parsedMsg.Op())
+	assert.Equal(t, chainID.Bytes(), parsedMsg.Get(ChainID))
+	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
+	assert.Equal(t, chunk, parsedMsg.Get(ContainerBytes))
+}
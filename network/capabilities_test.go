@@ -0,0 +1,337 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"fmt"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var (
+	baselineVersion = version.NewDefaultVersion("app", 0, 1, 0)
+	upgradedVersion = version.NewDefaultVersion("app", 0, 1, 1)
+)
+
+// parseBaseline parses [b] the way nodes that predate capabilities messages
+// do, which don't know of any ops after Chits or of any optional fields
+func parseBaseline(b []byte) (Op, error) {
+	p := wrappers.Packer{Bytes: b}
+	op := Op(p.UnpackByte())
+	if p.Errored() || op > Chits {
+		return op, errBadOp
+	}
+	for _, field := range Messages[op] {
+		field.Unpacker()(&p)
+	}
+	if p.Offset != len(b) {
+		p.Add(fmt.Errorf("expected length %d got %d", len(b), p.Offset))
+	}
+	return op, p.Err
+}
+
+// runBaselineNode completes the handshake over [conn] as a node that predates
+// capabilities messages. Returns an error if it was sent a message it couldn't
+// parse.
+func runBaselineNode(conn net.Conn, ip utils.IPDesc) error {
+	send := func(msg Msg, err error) error {
+		if err != nil {
+			return err
+		}
+		p := wrappers.Packer{MaxSize: math.MaxInt32}
+		p.PackBytes(msg.Bytes())
+		_, err = conn.Write(p.Bytes)
+		return err
+	}
+	sendVersion := func() error {
+		return send(TestBuilder.Version(0, 0, uint64(time.Now().Unix()), ip, baselineVersion.String()))
+	}
+	if err := sendVersion(); err != nil {
+		return err
+	}
+
+	var (
+		pending                 wrappers.Packer
+		gotVersion, gotPeerList bool
+		readBuffer              = make([]byte, 1024)
+	)
+	for !gotVersion || !gotPeerList {
+		read, err := conn.Read(readBuffer)
+		if err != nil {
+			return err
+		}
+		pending.Bytes = append(pending.Bytes, readBuffer[:read]...)
+		for {
+			msgBytes := pending.UnpackBytes()
+			if pending.Errored() {
+				pending.Offset = 0
+				pending.Err = nil
+				break
+			}
+			pending.Bytes = pending.Bytes[pending.Offset:]
+			pending.Offset = 0
+
+			op, err := parseBaseline(msgBytes)
+			if err != nil {
+				return fmt.Errorf("couldn't parse message with op %s: %w", op, err)
+			}
+			switch op {
+			case GetVersion:
+				err = sendVersion()
+			case Version:
+				gotVersion = true
+				err = send(TestBuilder.PeerList(nil, nil))
+			case GetPeerList:
+				err = send(TestBuilder.PeerList(nil, nil))
+			case PeerList:
+				gotPeerList = true
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func newCapabilitiesTestNetwork(
+	ip utils.DynamicIPDesc,
+	appVersion version.Version,
+	featuresVersion version.Version,
+	listener *testListener,
+	dialer *testDialer,
+	handler *testHandler,
+	compressionEnabled bool,
+	stakingCert *tls.Certificate,
+	stateSyncEnabled bool,
+) Network {
+	vdrs := validators.NewSet()
+	return NewDefaultNetwork(
+		prometheus.NewRegistry(),
+		logging.NoLog{},
+		ids.NewShortID(hashing.ComputeHash160Array([]byte(ip.IP().String()))),
+		ip,
+		0,
+		appVersion,
+		version.NewDefaultParser(),
+		featuresVersion,
+		listener,
+		dialer,
+		NewIPUpgrader(),
+		NewIPUpgrader(),
+		vdrs,
+		vdrs,
+		handler,
+		nil,
+		time.Duration(0),
+		0,
+		compressionEnabled,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		0,
+		0,
+		nil,
+		nil,
+		0,
+		0,
+		stakingCert,
+		false,
+		stateSyncEnabled,
+		nil,
+	)
+}
+
+// connectedHandler returns a handler that reports the peers it's told
+// connected on [connected], without blocking
+func connectedHandler(connected chan<- ids.ShortID) *testHandler {
+	return &testHandler{
+		connected: func(id ids.ShortID) {
+			select {
+			case connected <- id:
+			default:
+			}
+		},
+	}
+}
+
+func newTestListener(port int) *testListener {
+	return &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: port,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+}
+
+func TestBaselinePeerHandshake(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	stakingCert := newTestStakingCert(t, key)
+
+	tests := []struct {
+		name               string
+		compressionEnabled bool
+		stakingCert        *tls.Certificate
+		stateSyncEnabled   bool
+	}{
+		{name: "compression", compressionEnabled: true},
+		{name: "signed IPs", stakingCert: stakingCert},
+		{name: "state sync", stateSyncEnabled: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ip := utils.NewDynamicIPDesc(net.IPv6loopback, 0)
+			baselineIP := utils.IPDesc{IP: net.IPv6loopback, Port: 1}
+			listener := newTestListener(0)
+			dialer := &testDialer{
+				addr:      listener.addr,
+				outbounds: make(map[string]*testListener),
+			}
+			connected := make(chan ids.ShortID, 1)
+			handler := connectedHandler(connected)
+
+			n := newCapabilitiesTestNetwork(
+				ip,
+				upgradedVersion,
+				upgradedVersion,
+				listener,
+				dialer,
+				handler,
+				test.compressionEnabled,
+				test.stakingCert,
+				test.stateSyncEnabled,
+			)
+			go func() {
+				assert.Error(t, n.Dispatch())
+			}()
+
+			// connect to the upgraded node as the baseline node
+			server := &testConn{
+				pendingReads:  make(chan []byte, 1<<10),
+				pendingWrites: make(chan []byte, 1<<10),
+				closed:        make(chan struct{}),
+				local:         listener.addr,
+				remote:        &net.TCPAddr{IP: baselineIP.IP, Port: int(baselineIP.Port)},
+			}
+			client := &testConn{
+				pendingReads:  server.pendingWrites,
+				pendingWrites: server.pendingReads,
+				closed:        make(chan struct{}),
+				local:         server.remote,
+				remote:        server.local,
+			}
+			listener.inbound <- server
+
+			baselineErr := make(chan error, 1)
+			go func() { baselineErr <- runBaselineNode(client, baselineIP) }()
+
+			select {
+			case err := <-baselineErr:
+				assert.NoError(t, err, "the baseline node should finish the handshake")
+			case <-time.After(10 * time.Second):
+				t.Fatal("the baseline node didn't finish the handshake")
+			}
+			select {
+			case <-connected:
+			case <-time.After(10 * time.Second):
+				t.Fatal("the upgraded node didn't finish the handshake")
+			}
+
+			assert.NoError(t, client.Close())
+			assert.NoError(t, n.Close())
+		})
+	}
+}
+
+func TestUpgradedPeerHandshake(t *testing.T) {
+	ip0 := utils.NewDynamicIPDesc(net.IPv6loopback, 0)
+	ip1 := utils.NewDynamicIPDesc(net.IPv6loopback, 1)
+	listener0 := newTestListener(0)
+	listener1 := newTestListener(1)
+	dialer0 := &testDialer{
+		addr:      listener0.addr,
+		outbounds: map[string]*testListener{ip1.IP().String(): listener1},
+	}
+	dialer1 := &testDialer{
+		addr:      listener1.addr,
+		outbounds: map[string]*testListener{ip0.IP().String(): listener0},
+	}
+
+	connected0 := make(chan ids.ShortID, 1)
+	connected1 := make(chan ids.ShortID, 1)
+	net0 := newCapabilitiesTestNetwork(
+		ip0,
+		upgradedVersion,
+		upgradedVersion,
+		listener0,
+		dialer0,
+		connectedHandler(connected0),
+		true,
+		nil,
+		true,
+	)
+	net1 := newCapabilitiesTestNetwork(
+		ip1,
+		upgradedVersion,
+		upgradedVersion,
+		listener1,
+		dialer1,
+		connectedHandler(connected1),
+		true,
+		nil,
+		false,
+	)
+	go func() {
+		assert.Error(t, net0.Dispatch())
+	}()
+	go func() {
+		assert.Error(t, net1.Dispatch())
+	}()
+
+	net0.Track(ip1.IP())
+
+	for _, connected := range []chan ids.ShortID{connected0, connected1} {
+		select {
+		case <-connected:
+		case <-time.After(10 * time.Second):
+			t.Fatal("the nodes didn't finish the handshake")
+		}
+	}
+
+	// only the features both nodes report are used
+	for _, n := range []Network{net0, net1} {
+		n := n.(*network)
+		n.stateLock.RLock()
+		for _, p := range n.peers {
+			assert.True(t, p.supports(CompressionFeature))
+			assert.False(t, p.supports(StateSyncFeature))
+		}
+		n.stateLock.RUnlock()
+	}
+
+	assert.NoError(t, net0.Close())
+	assert.NoError(t, net1.Close())
+}
@@ -0,0 +1,359 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// Defaults for the size and number of capture files
+const (
+	DefaultMaxCaptureFileSize = 64 << 20
+	DefaultMaxCaptureFiles    = 8
+)
+
+const (
+	// captureVersion is the version of the capture file format
+	captureVersion byte = 0
+
+	// size of each record's header: the time it was recorded, whether it was
+	// received, the peer's ID and the size of the message
+	captureRecordHeaderSize = wrappers.LongLen + wrappers.BoolLen + 20 + wrappers.IntLen
+)
+
+var (
+	// captureMagic starts every capture file
+	captureMagic = []byte("avacap")
+
+	errNotCaptureFile = errors.New("not a capture file")
+	errCaptureRecord  = errors.New("capture record is too large")
+	errNoCaptureDir   = errors.New("capture directory isn't set")
+)
+
+// CaptureConfig describes the messages recorded by a capture, and the files
+// they're recorded to
+type CaptureConfig struct {
+	// Dir is the directory capture files are written to
+	Dir string
+	// NodeIDs of the peers whose messages are recorded. If empty, the messages
+	// of every peer are.
+	NodeIDs ids.ShortSet
+	// Ops of the messages recorded. If empty, messages with every op are.
+	Ops []Op
+	// MaxFileSize is the size, in bytes, a capture file grows to before the
+	// next one is started
+	MaxFileSize int64
+	// MaxFiles is the number of capture files kept. Once there are more, the
+	// oldest is deleted.
+	MaxFiles int
+}
+
+// CapturedMessage is a message recorded in a capture file
+type CapturedMessage struct {
+	// Time the message was sent or received at
+	Time time.Time
+	// Inbound is true if the message was received from the peer, rather than
+	// sent to it
+	Inbound bool
+	// NodeID of the peer
+	NodeID ids.ShortID
+	Msg    Msg
+}
+
+// capture records messages to a rotating set of capture files.
+//
+// A capture file starts with captureMagic and captureVersion, followed by a
+// record of each message: the unix time in nanoseconds it was recorded at,
+// whether it was received, the peer's ID, and the uncompressed message
+// prefixed by its size.
+type capture struct {
+	config CaptureConfig
+	ops    map[Op]bool
+	// unique to this capture, so that its files don't overwrite another's
+	name string
+
+	lock      sync.Mutex
+	file      *os.File
+	writer    *bufio.Writer
+	fileSize  int64
+	fileIndex int
+	// capture files written, oldest first
+	files []string
+	// error that stopped recording, if any
+	err error
+}
+
+// newCapture returns a capture that records the messages described by
+// [config], and starts its first file
+func newCapture(config CaptureConfig, now time.Time) (*capture, error) {
+	if config.Dir == "" {
+		return nil, errNoCaptureDir
+	}
+	if config.MaxFileSize <= 0 {
+		config.MaxFileSize = DefaultMaxCaptureFileSize
+	}
+	if config.MaxFiles <= 0 {
+		config.MaxFiles = DefaultMaxCaptureFiles
+	}
+	if err := os.MkdirAll(config.Dir, 0700); err != nil {
+		return nil, fmt.Errorf("couldn't create capture directory: %w", err)
+	}
+
+	c := &capture{
+		config: config,
+		name:   fmt.Sprintf("capture-%d", now.Unix()),
+	}
+	if len(config.Ops) > 0 {
+		c.ops = make(map[Op]bool, len(config.Ops))
+		for _, op := range config.Ops {
+			c.ops[op] = true
+		}
+	}
+	return c, c.rotate()
+}
+
+// captures returns true if messages with [op] exchanged with [nodeID] are
+// recorded
+func (c *capture) captures(nodeID ids.ShortID, op Op) bool {
+	if c.config.NodeIDs.Len() > 0 && !c.config.NodeIDs.Contains(nodeID) {
+		return false
+	}
+	return c.ops == nil || c.ops[op]
+}
+
+// record [msgBytes], sent to or received from [nodeID] at [now]. Once a
+// record fails to be written, recording stops.
+func (c *capture) record(now time.Time, inbound bool, nodeID ids.ShortID, msgBytes []byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.err != nil || c.file == nil {
+		return
+	}
+
+	p := wrappers.Packer{MaxSize: captureRecordHeaderSize + len(msgBytes)}
+	p.PackLong(uint64(now.UnixNano()))
+	p.PackBool(inbound)
+	p.PackFixedBytes(nodeID.Bytes())
+	p.PackBytes(msgBytes)
+	if p.Errored() {
+		c.err = p.Err
+		return
+	}
+
+	if c.fileSize > 0 && c.fileSize+int64(len(p.Bytes)) > c.config.MaxFileSize {
+		if c.err = c.rotate(); c.err != nil {
+			return
+		}
+	}
+	if _, c.err = c.writer.Write(p.Bytes); c.err == nil {
+		c.fileSize += int64(len(p.Bytes))
+	}
+}
+
+// rotate closes the current capture file, if any, starts the next one, and
+// deletes the oldest if there are more than MaxFiles
+// assumes the lock is held.
+func (c *capture) rotate() error {
+	if err := c.closeFile(); err != nil {
+		return err
+	}
+
+	path := filepath.Join(c.config.Dir, fmt.Sprintf("%s-%d.bin", c.name, c.fileIndex))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("couldn't create capture file: %w", err)
+	}
+	c.file = file
+	c.writer = bufio.NewWriter(file)
+	c.fileIndex++
+	c.files = append(c.files, path)
+
+	header := append(append([]byte{}, captureMagic...), captureVersion)
+	if _, err := c.writer.Write(header); err != nil {
+		return err
+	}
+	c.fileSize = int64(len(header))
+
+	for len(c.files) > c.config.MaxFiles {
+		if err := os.Remove(c.files[0]); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("couldn't delete capture file: %w", err)
+		}
+		c.files = c.files[1:]
+	}
+	return nil
+}
+
+// closeFile flushes and closes the current capture file, if any
+// assumes the lock is held.
+func (c *capture) closeFile() error {
+	if c.file == nil {
+		return nil
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.writer.Flush(),
+		c.file.Close(),
+	)
+	c.file = nil
+	c.writer = nil
+	return errs.Err
+}
+
+// close stops recording. Returns the capture files that were kept, and the
+// error that stopped recording early, if any.
+func (c *capture) close() ([]string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.err,
+		c.closeFile(),
+	)
+	return c.files, errs.Err
+}
+
+// StartCapture implements the Network interface
+func (n *network) StartCapture(config CaptureConfig) error {
+	c, err := newCapture(config, n.clock.Time())
+	if err != nil {
+		return err
+	}
+
+	n.captureLock.Lock()
+	old := n.capture
+	n.capture = c
+	n.captureLock.Unlock()
+
+	if old != nil {
+		if _, err := old.close(); err != nil {
+			n.log.Warn("capture stopped early due to: %s", err)
+		}
+	}
+	n.log.Info("capturing network messages to %s", config.Dir)
+	return nil
+}
+
+// StopCapture implements the Network interface
+func (n *network) StopCapture() ([]string, error) {
+	n.captureLock.Lock()
+	c := n.capture
+	n.capture = nil
+	n.captureLock.Unlock()
+
+	if c == nil {
+		return nil, nil
+	}
+	n.log.Info("stopped capturing network messages")
+	return c.close()
+}
+
+// recordCapture records [msg], sent to or received from [nodeID], if it's
+// being captured
+func (n *network) recordCapture(inbound bool, nodeID ids.ShortID, msg Msg) {
+	n.captureLock.RLock()
+	c := n.capture
+	n.captureLock.RUnlock()
+
+	if c != nil && c.captures(nodeID, msg.Op()) {
+		c.record(n.clock.Time(), inbound, nodeID, msg.Bytes())
+	}
+}
+
+// CaptureReader reads the messages recorded in a capture file, in the order
+// they were recorded
+type CaptureReader struct {
+	r *bufio.Reader
+	b Builder
+}
+
+// NewCaptureReader returns a reader of the capture file read from [r]
+func NewCaptureReader(r io.Reader) (*CaptureReader, error) {
+	reader := bufio.NewReader(r)
+	header := make([]byte, len(captureMagic)+1)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, errNotCaptureFile
+	}
+	if !bytes.Equal(header[:len(captureMagic)], captureMagic) {
+		return nil, errNotCaptureFile
+	}
+	if version := header[len(captureMagic)]; version != captureVersion {
+		return nil, fmt.Errorf("capture file has unknown version %d", version)
+	}
+	return &CaptureReader{r: reader}, nil
+}
+
+// Next returns the next message in the capture file, or io.EOF if there are
+// none left
+func (r *CaptureReader) Next() (CapturedMessage, error) {
+	header := make([]byte, captureRecordHeaderSize)
+	if _, err := io.ReadFull(r.r, header); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return CapturedMessage{}, fmt.Errorf("truncated capture record: %w", err)
+		}
+		return CapturedMessage{}, err
+	}
+
+	p := wrappers.Packer{Bytes: header}
+	nanos := p.UnpackLong()
+	inbound := p.UnpackBool()
+	nodeIDBytes := p.UnpackFixedBytes(20)
+	msgLen := p.UnpackInt()
+	if p.Errored() {
+		return CapturedMessage{}, p.Err
+	}
+	if msgLen > DefaultMaxMessageSize {
+		return CapturedMessage{}, errCaptureRecord
+	}
+
+	msgBytes := make([]byte, msgLen)
+	if _, err := io.ReadFull(r.r, msgBytes); err != nil {
+		return CapturedMessage{}, fmt.Errorf("truncated capture record: %w", err)
+	}
+	msg, err := r.b.Parse(msgBytes)
+	if err != nil {
+		return CapturedMessage{}, fmt.Errorf("couldn't parse captured message: %w", err)
+	}
+	nodeID, err := ids.ToShortID(nodeIDBytes)
+	if err != nil {
+		return CapturedMessage{}, err
+	}
+	return CapturedMessage{
+		Time:    time.Unix(0, int64(nanos)),
+		Inbound: inbound,
+		NodeID:  nodeID,
+		Msg:     msg,
+	}, nil
+}
+
+// ParseOps returns the ops with the names [names], as returned by Op.String
+func ParseOps(names []string) ([]Op, error) {
+	ops := make([]Op, 0, len(names))
+	for _, name := range names {
+		found := false
+		for op := range Messages {
+			if op.String() == name {
+				ops = append(ops, op)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown op %q", name)
+		}
+	}
+	return ops, nil
+}
@@ -0,0 +1,144 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// readCapture returns the messages recorded in the capture file at [path]
+func readCapture(t *testing.T, path string) []CapturedMessage {
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	r, err := NewCaptureReader(file)
+	assert.NoError(t, err)
+	msgs := []CapturedMessage(nil)
+	for {
+		msg, err := r.Next()
+		if err == io.EOF {
+			return msgs
+		}
+		if !assert.NoError(t, err) {
+			return msgs
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+func TestCaptureRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c, err := newCapture(CaptureConfig{Dir: dir}, time.Unix(1, 0))
+	assert.NoError(t, err)
+
+	nodeID := ids.NewShortID([20]byte{1})
+	get, err := TestBuilder.Get(ids.Empty, 5, 10, ids.Empty.Prefix(1))
+	assert.NoError(t, err)
+	ping, err := TestBuilder.Ping()
+	assert.NoError(t, err)
+	c.record(time.Unix(2, 0), false, nodeID, get.Bytes())
+	c.record(time.Unix(3, 0), true, nodeID, ping.Bytes())
+
+	files, err := c.close()
+	assert.NoError(t, err)
+	if !assert.Len(t, files, 1) {
+		return
+	}
+	msgs := readCapture(t, files[0])
+	if !assert.Len(t, msgs, 2) {
+		return
+	}
+	assert.Equal(t, time.Unix(2, 0), msgs[0].Time)
+	assert.False(t, msgs[0].Inbound)
+	assert.Equal(t, nodeID, msgs[0].NodeID)
+	assert.Equal(t, Get, msgs[0].Msg.Op())
+	assert.Equal(t, uint32(5), msgs[0].Msg.Get(RequestID))
+	assert.True(t, msgs[1].Inbound)
+	assert.Equal(t, Ping, msgs[1].Msg.Op())
+
+	// Recording after the capture is closed does nothing
+	c.record(time.Unix(4, 0), true, nodeID, ping.Bytes())
+	assert.Len(t, readCapture(t, files[0]), 2)
+}
+
+func TestCaptureRotates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ping, err := TestBuilder.Ping()
+	assert.NoError(t, err)
+	recordSize := int64(captureRecordHeaderSize + len(ping.Bytes()))
+	headerSize := int64(len(captureMagic) + 1)
+
+	// Each file holds two records, and only two files are kept
+	c, err := newCapture(CaptureConfig{
+		Dir:         dir,
+		MaxFileSize: headerSize + 2*recordSize,
+		MaxFiles:    2,
+	}, time.Unix(1, 0))
+	assert.NoError(t, err)
+	for i := 0; i < 7; i++ {
+		c.record(time.Unix(int64(i), 0), false, ids.ShortEmpty, ping.Bytes())
+	}
+	files, err := c.close()
+	assert.NoError(t, err)
+	if !assert.Len(t, files, 2) {
+		return
+	}
+	assert.Len(t, readCapture(t, files[0]), 2)
+	last := readCapture(t, files[1])
+	if assert.Len(t, last, 1) {
+		assert.Equal(t, time.Unix(6, 0), last[0].Time)
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2, "the oldest files should be deleted")
+}
+
+func TestCaptureFilters(t *testing.T) {
+	nodeID0, nodeID1 := ids.NewShortID([20]byte{1}), ids.NewShortID([20]byte{2})
+
+	c := &capture{}
+	assert.True(t, c.captures(nodeID0, Get))
+
+	c.config.NodeIDs.Add(nodeID0)
+	assert.True(t, c.captures(nodeID0, Get))
+	assert.False(t, c.captures(nodeID1, Get))
+
+	c.ops = map[Op]bool{Chits: true}
+	assert.False(t, c.captures(nodeID0, Get))
+	assert.True(t, c.captures(nodeID0, Chits))
+}
+
+func TestCaptureReaderRejectsOtherFiles(t *testing.T) {
+	_, err := NewCaptureReader(bytes.NewReader([]byte("not a capture")))
+	assert.Error(t, err)
+
+	_, err = newCapture(CaptureConfig{}, time.Unix(0, 0))
+	assert.Equal(t, errNoCaptureDir, err)
+}
+
+func TestParseOps(t *testing.T) {
+	ops, err := ParseOps([]string{Get.String(), Chits.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, []Op{Get, Chits}, ops)
+
+	_, err = ParseOps([]string{"not an op"})
+	assert.Error(t, err)
+}
@@ -36,6 +36,13 @@ func (Codec) Pack(op Op, fields map[Field]interface{}) (Msg, error) {
 		}
 		field.Packer()(&p, data)
 	}
+	for _, field := range OptionalFields[op] {
+		data, ok := fields[field]
+		if !ok {
+			break
+		}
+		field.Packer()(&p, data)
+	}
 
 	return &msg{
 		op:     op,
@@ -45,8 +52,17 @@ func (Codec) Pack(op Op, fields map[Field]interface{}) (Msg, error) {
 }
 
 // Parse attempts to convert bytes into a message.
-// The first byte of the message is the opcode of the message.
+// The first byte of the message is the opcode of the message. If the message
+// is compressed, it's decompressed first.
 func (Codec) Parse(b []byte) (Msg, error) {
+	if isCompressed(b) {
+		decompressed, err := decompress(b)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decompress message: %w", err)
+		}
+		b = decompressed
+	}
+
 	p := wrappers.Packer{Bytes: b}
 	op := Op(p.UnpackByte())
 	message, ok := Messages[op]
@@ -58,6 +74,12 @@ func (Codec) Parse(b []byte) (Msg, error) {
 	for _, field := range message {
 		fields[field] = field.Unpacker()(&p)
 	}
+	for _, field := range OptionalFields[op] {
+		if p.Offset == len(b) {
+			break
+		}
+		fields[field] = field.Unpacker()(&p)
+	}
 
 	if p.Offset != len(b) {
 		p.Add(fmt.Errorf("expected length %d got %d", len(b), p.Offset))
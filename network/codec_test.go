@@ -4,10 +4,13 @@
 package network
 
 import (
+	"bytes"
 	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
 )
 
 var (
@@ -33,3 +36,29 @@ func TestCodecParseExtraSpace(t *testing.T) {
 	_, err := TestCodec.Parse([]byte{byte(GetVersion), 0x00})
 	assert.Error(t, err)
 }
+
+func TestCodecCompression(t *testing.T) {
+	container := bytes.Repeat([]byte{1, 2, 3, 4}, 1024)
+	msg, err := TestBuilder.Put(ids.Empty, 1, ids.Empty, container)
+	assert.NoError(t, err)
+
+	compressed := msg.CompressedBytes()
+	assert.NotNil(t, compressed)
+	assert.Less(t, len(compressed), len(msg.Bytes()))
+
+	parsedMsg, err := TestCodec.Parse(compressed)
+	assert.NoError(t, err)
+	assert.Equal(t, Put, parsedMsg.Op())
+	assert.Equal(t, container, parsedMsg.Get(ContainerBytes))
+	assert.Equal(t, msg.Bytes(), parsedMsg.Bytes())
+}
+
+func TestCodecSmallMessagesNotCompressed(t *testing.T) {
+	msg, err := TestBuilder.Put(ids.Empty, 1, ids.Empty, []byte{1})
+	assert.NoError(t, err)
+	assert.Nil(t, msg.CompressedBytes())
+
+	msg, err = TestBuilder.Ping()
+	assert.NoError(t, err)
+	assert.Nil(t, msg.CompressedBytes())
+}
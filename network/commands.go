@@ -25,6 +25,10 @@ const (
 	ContainerBytes                   // Used for gossiping
 	ContainerIDs                     // Used for querying
 	MultiContainerBytes              // Used in MultiPut
+	Features                         // Used in handshake
+	SigTime                          // Used in handshake
+	Signature                        // Used in handshake
+	PeerSignatures                   // Used in handshake
 )
 
 // Packer returns the packer function that can be used to pack this field.
@@ -56,6 +60,14 @@ func (f Field) Packer() func(*wrappers.Packer, interface{}) {
 		return wrappers.TryPackHashes
 	case MultiContainerBytes:
 		return wrappers.TryPack2DBytes
+	case Features:
+		return wrappers.TryPackLong
+	case SigTime:
+		return wrappers.TryPackLong
+	case Signature:
+		return wrappers.TryPackBytes
+	case PeerSignatures:
+		return tryPackIPSignatures
 	default:
 		return nil
 	}
@@ -90,6 +102,14 @@ func (f Field) Unpacker() func(*wrappers.Packer) interface{} {
 		return wrappers.TryUnpackHashes
 	case MultiContainerBytes:
 		return wrappers.TryUnpack2DBytes
+	case Features:
+		return wrappers.TryUnpackLong
+	case SigTime:
+		return wrappers.TryUnpackLong
+	case Signature:
+		return wrappers.TryUnpackBytes
+	case PeerSignatures:
+		return tryUnpackIPSignatures
 	default:
 		return nil
 	}
@@ -123,6 +143,14 @@ func (f Field) String() string {
 		return "Container IDs"
 	case MultiContainerBytes:
 		return "MultiContainerBytes"
+	case Features:
+		return "Features"
+	case SigTime:
+		return "SigTime"
+	case Signature:
+		return "Signature"
+	case PeerSignatures:
+		return "PeerSignatures"
 	default:
 		return "Unknown Field"
 	}
@@ -167,6 +195,16 @@ func (op Op) String() string {
 		return "pull_query"
 	case Chits:
 		return "chits"
+	case GetStateSummaryFrontier:
+		return "get_state_summary_frontier"
+	case StateSummaryFrontier:
+		return "state_summary_frontier"
+	case GetStateChunk:
+		return "get_state_chunk"
+	case StateChunk:
+		return "state_chunk"
+	case Capabilities:
+		return "capabilities"
 	default:
 		return "Unknown Op"
 	}
@@ -194,6 +232,13 @@ const (
 	PushQuery
 	PullQuery
 	Chits
+	// State sync:
+	GetStateSummaryFrontier
+	StateSummaryFrontier
+	GetStateChunk
+	StateChunk
+	// Handshake, only with peers whose version supports it:
+	Capabilities
 )
 
 // Defines the messages that can be sent/received with this network
@@ -219,5 +264,19 @@ var (
 		PushQuery: {ChainID, RequestID, Deadline, ContainerID, ContainerBytes},
 		PullQuery: {ChainID, RequestID, Deadline, ContainerID},
 		Chits:     {ChainID, RequestID, ContainerIDs},
+		// State sync:
+		GetStateSummaryFrontier: {ChainID, RequestID, Deadline},
+		StateSummaryFrontier:    {ChainID, RequestID, ContainerBytes},
+		GetStateChunk:           {ChainID, RequestID, Deadline, ContainerID},
+		StateChunk:              {ChainID, RequestID, ContainerBytes},
+		// Handshake, only with peers whose version supports it:
+		Capabilities: {Features, SigTime, Signature},
+	}
+
+	// OptionalFields are the fields that may follow the fields of a message.
+	// Nodes that don't know of them don't send them, so each is packed only if
+	// the fields before it are.
+	OptionalFields = map[Op][]Field{
+		PeerList: {PeerSignatures},
 	}
 )
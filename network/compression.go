@@ -0,0 +1,77 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+
+	"github.com/golang/snappy"
+)
+
+const (
+	// compressedFlag is set in the op byte of a compressed message. The bytes
+	// after the op byte of a compressed message are snappy encoded.
+	compressedFlag byte = 0x80
+
+	// minCompressSize is the size, in bytes, of the smallest message that's
+	// compressed. Smaller messages don't shrink enough to be worth it.
+	minCompressSize = 512
+)
+
+var errNotCompressed = errors.New("message isn't compressed")
+
+// compressible returns true if messages with [op] carry containers, and so may
+// be large enough to be worth compressing
+func compressible(op Op) bool {
+	switch op {
+	case Put, PushQuery, MultiPut, StateSummaryFrontier, StateChunk:
+		return true
+	default:
+		return false
+	}
+}
+
+// compress returns [msgBytes], a message with an op that's compressible,
+// compressed, or nil if compressing it doesn't shrink it
+func compress(msgBytes []byte) []byte {
+	if len(msgBytes) < minCompressSize {
+		return nil
+	}
+	compressed := make([]byte, 1+snappy.MaxEncodedLen(len(msgBytes)-1))
+	compressed[0] = msgBytes[0] | compressedFlag
+	compressed = compressed[:1+len(snappy.Encode(compressed[1:], msgBytes[1:]))]
+	if len(compressed) >= len(msgBytes) {
+		return nil
+	}
+	return compressed
+}
+
+// isCompressed returns true if [msgBytes] is a compressed message
+func isCompressed(msgBytes []byte) bool {
+	return len(msgBytes) > 0 && msgBytes[0]&compressedFlag != 0
+}
+
+// decompressedLen returns the length of the compressed message [msgBytes] once
+// it's decompressed, without decompressing it
+func decompressedLen(msgBytes []byte) (int, error) {
+	if !isCompressed(msgBytes) {
+		return 0, errNotCompressed
+	}
+	n, err := snappy.DecodedLen(msgBytes[1:])
+	return 1 + n, err
+}
+
+// decompress returns the compressed message [msgBytes] decompressed
+func decompress(msgBytes []byte) ([]byte, error) {
+	n, err := decompressedLen(msgBytes)
+	if err != nil {
+		return nil, err
+	}
+	decompressed := make([]byte, n)
+	decompressed[0] = msgBytes[0] &^ compressedFlag
+	if _, err := snappy.Decode(decompressed[1:], msgBytes[1:]); err != nil {
+		return nil, err
+	}
+	return decompressed, nil
+}
@@ -1,6 +1,7 @@
 package network
 
 import (
+	"net"
 	"sync"
 	"time"
 
@@ -11,6 +12,12 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
 
+// The subnets connection attempts are counted by
+var (
+	ipv4SubnetMask = net.CIDRMask(24, 8*net.IPv4len)
+	ipv6SubnetMask = net.CIDRMask(64, 8*net.IPv6len)
+)
+
 // ConnMeter keeps track of how many times a peer from a given address
 // have attempted to connect to us in a given time period.
 type ConnMeter interface {
@@ -25,7 +32,35 @@ func NewConnMeter(resetDuration time.Duration, size int) ConnMeter {
 	if resetDuration == 0 {
 		return &noConnMeter{}
 	}
-	return &connMeter{cache: &cache.LRU{Size: size}}
+	return &connMeter{
+		cache:         &cache.LRU{Size: size},
+		resetDuration: resetDuration,
+		key:           net.IP.String,
+	}
+}
+
+// NewSubnetConnMeter returns a ConnMeter that counts the connection attempts
+// from each subnet, rather than each address. Subnets are /24 for IPv4
+// addresses and /64 for IPv6 addresses, the blocks a single host is typically
+// assigned addresses from.
+// If [resetDuration] is zero, returns a ConnMeter that always returns 0
+func NewSubnetConnMeter(resetDuration time.Duration, size int) ConnMeter {
+	if resetDuration == 0 {
+		return &noConnMeter{}
+	}
+	return &connMeter{
+		cache:         &cache.LRU{Size: size},
+		resetDuration: resetDuration,
+		key:           subnet,
+	}
+}
+
+// subnet returns the subnet [ip] is in
+func subnet(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return (&net.IPNet{IP: ip4.Mask(ipv4SubnetMask), Mask: ipv4SubnetMask}).String()
+	}
+	return (&net.IPNet{IP: ip.Mask(ipv6SubnetMask), Mask: ipv6SubnetMask}).String()
 }
 
 type noConnMeter struct{}
@@ -39,6 +74,8 @@ type connMeter struct {
 	cache         *cache.LRU
 	resetDuration time.Duration
 	lock          sync.RWMutex
+	// returns the key the connection attempts from an IP are counted under
+	key func(net.IP) string
 }
 
 func (n *connMeter) Register(addr string) (int, error) {
@@ -47,8 +84,8 @@ func (n *connMeter) Register(addr string) (int, error) {
 		return 0, err
 	}
 
-	// normalize to just the incoming IP
-	addr = ip.IP.String()
+	// normalize to just the incoming IP, or its subnet
+	addr = n.key(ip.IP)
 	meter, err := n.registerAddress(addr)
 	if err != nil {
 		return 0, err
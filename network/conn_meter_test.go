@@ -0,0 +1,37 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubnetConnMeter(t *testing.T) {
+	meter := NewSubnetConnMeter(time.Hour, 16)
+
+	ticks, err := meter.Register("1.2.3.4:9651")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, ticks)
+
+	// Addresses in the same /24 are counted together
+	ticks, err = meter.Register("1.2.3.5:9651")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ticks)
+
+	ticks, err = meter.Register("1.2.4.4:9651")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, ticks)
+
+	// Addresses in the same /64 are counted together
+	ticks, err = meter.Register("[2001:db8::1]:9651")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, ticks)
+
+	ticks, err = meter.Register("[2001:db8::2]:9651")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ticks)
+}
@@ -4,11 +4,20 @@
 package network
 
 import (
+	"errors"
 	"net"
 
+	"golang.org/x/net/proxy"
+
 	"github.com/ava-labs/avalanchego/utils"
 )
 
+// DefaultTorProxyAddress is the address of the SOCKS5 proxy a local Tor
+// daemon listens on by default
+const DefaultTorProxyAddress = "127.0.0.1:9050"
+
+var errNoProxyAddress = errors.New("no proxy address given")
+
 // Dialer attempts to create a connection with the provided IP/port pair
 type Dialer interface {
 	Dial(utils.IPDesc) (net.Conn, error)
@@ -23,3 +32,54 @@ type dialer struct {
 func NewDialer(network string) Dialer { return &dialer{network: network} }
 
 func (d *dialer) Dial(ip utils.IPDesc) (net.Conn, error) { return net.Dial(d.network, ip.String()) }
+
+// ProxyConfig describes the SOCKS5 proxy outbound connections are made through
+type ProxyConfig struct {
+	// Address of the proxy, e.g. 127.0.0.1:1080
+	Address string
+	// Username and Password to authenticate with, if the proxy requires it
+	Username, Password string
+	// IsolateStreams makes each connection authenticate with credentials
+	// unique to the IP it's to, so that a Tor proxy sends connections to
+	// different peers over different circuits. Username and Password are
+	// ignored.
+	IsolateStreams bool
+}
+
+type proxyDialer struct {
+	network string
+	config  ProxyConfig
+}
+
+// NewProxyDialer returns a new Dialer that connects over the provided network
+// through the SOCKS5 proxy described by [config]
+func NewProxyDialer(network string, config ProxyConfig) (Dialer, error) {
+	if config.Address == "" {
+		return nil, errNoProxyAddress
+	}
+	return &proxyDialer{
+		network: network,
+		config:  config,
+	}, nil
+}
+
+func (d *proxyDialer) Dial(ip utils.IPDesc) (net.Conn, error) {
+	var auth *proxy.Auth
+	switch {
+	case d.config.IsolateStreams:
+		auth = &proxy.Auth{
+			User:     ip.String(),
+			Password: ip.String(),
+		}
+	case d.config.Username != "" || d.config.Password != "":
+		auth = &proxy.Auth{
+			User:     d.config.Username,
+			Password: d.config.Password,
+		}
+	}
+	socks5, err := proxy.SOCKS5(d.network, d.config.Address, auth, proxy.Direct)
+	if err != nil {
+		return nil, err
+	}
+	return socks5.Dial(d.network, ip.String())
+}
@@ -0,0 +1,153 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+// socks5Request is a CONNECT request a testSOCKS5Server received
+type socks5Request struct {
+	username, password string
+	addr               net.IP
+	port               uint16
+}
+
+// testSOCKS5Server accepts a single connection, authenticating it by username
+// and password if the client offers to, and records its CONNECT request
+func testSOCKS5Server(t *testing.T, listener net.Listener, requests chan<- socks5Request) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	request := socks5Request{}
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		t.Error(err)
+		return
+	}
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		t.Error(err)
+		return
+	}
+	method := byte(0x00)
+	for _, m := range methods {
+		if m == 0x02 {
+			method = m
+		}
+	}
+	if _, err := conn.Write([]byte{0x05, method}); err != nil {
+		t.Error(err)
+		return
+	}
+	if method == 0x02 {
+		readString := func() string {
+			length := make([]byte, 1)
+			if _, err := io.ReadFull(conn, length); err != nil {
+				t.Error(err)
+			}
+			str := make([]byte, length[0])
+			if _, err := io.ReadFull(conn, str); err != nil {
+				t.Error(err)
+			}
+			return string(str)
+		}
+		version := make([]byte, 1)
+		if _, err := io.ReadFull(conn, version); err != nil {
+			t.Error(err)
+			return
+		}
+		request.username = readString()
+		request.password = readString()
+		if _, err := conn.Write([]byte{0x01, 0x00}); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	connect := make([]byte, 4)
+	if _, err := io.ReadFull(conn, connect); err != nil {
+		t.Error(err)
+		return
+	}
+	addrLen := net.IPv4len
+	if connect[3] == 0x04 {
+		addrLen = net.IPv6len
+	}
+	addr := make([]byte, addrLen+2)
+	if _, err := io.ReadFull(conn, addr); err != nil {
+		t.Error(err)
+		return
+	}
+	request.addr = net.IP(addr[:addrLen])
+	request.port = uint16(addr[addrLen])<<8 | uint16(addr[addrLen+1])
+	if _, err := conn.Write([]byte{0x05, 0x00, 0x00, 0x01, 127, 0, 0, 1, 0, 0}); err != nil {
+		t.Error(err)
+		return
+	}
+	requests <- request
+}
+
+func TestProxyDialer(t *testing.T) {
+	_, err := NewProxyDialer("tcp", ProxyConfig{})
+	assert.Error(t, err)
+
+	ip := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	tests := []struct {
+		name   string
+		config ProxyConfig
+		user   string
+		pass   string
+	}{
+		{
+			name: "no auth",
+		},
+		{
+			name:   "auth",
+			config: ProxyConfig{Username: "user", Password: "pass"},
+			user:   "user",
+			pass:   "pass",
+		},
+		{
+			name:   "isolated",
+			config: ProxyConfig{IsolateStreams: true},
+			user:   ip.String(),
+			pass:   ip.String(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			assert.NoError(t, err)
+			defer listener.Close()
+
+			requests := make(chan socks5Request, 1)
+			go testSOCKS5Server(t, listener, requests)
+
+			test.config.Address = listener.Addr().String()
+			dialer, err := NewProxyDialer("tcp", test.config)
+			assert.NoError(t, err)
+			conn, err := dialer.Dial(ip)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer conn.Close()
+
+			request := <-requests
+			assert.Equal(t, test.user, request.username)
+			assert.Equal(t, test.pass, request.password)
+			assert.True(t, ip.IP.Equal(request.addr))
+			assert.Equal(t, ip.Port, request.port)
+		})
+	}
+}
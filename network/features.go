@@ -0,0 +1,51 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+// Features a node reports in its capabilities message. A feature is only used
+// with a peer if both nodes report it, so that new wire capabilities can be
+// rolled out without bumping the protocol version.
+const (
+	// CompressionFeature is set if the node accepts compressed messages
+	CompressionFeature uint64 = 1 << iota
+	// SignedIPsFeature is set if the node accepts the signatures of the IPs
+	// in PeerList messages
+	SignedIPsFeature
+	// StateSyncFeature is set if the node can parse and serve state sync
+	// messages
+	StateSyncFeature
+)
+
+// featureNames are the names of the known features, in the order of their bits
+var featureNames = []string{
+	"compression",
+	"signedIPs",
+	"stateSync",
+}
+
+// opFeatures maps each op added after the initial protocol to the feature a
+// node reports if it can parse messages with the op. Messages with these ops
+// are only sent to, and accepted from, peers with which the feature was
+// negotiated.
+var opFeatures = map[Op]uint64{
+	GetStateSummaryFrontier: StateSyncFeature,
+	StateSummaryFrontier:    StateSyncFeature,
+	GetStateChunk:           StateSyncFeature,
+	StateChunk:              StateSyncFeature,
+}
+
+// requiredFeature returns the feature that must be negotiated with a peer to
+// exchange messages with [op], or 0 if any peer can parse them
+func requiredFeature(op Op) uint64 { return opFeatures[op] }
+
+// FeatureNames returns the names of the known features set in [features]
+func FeatureNames(features uint64) []string {
+	names := []string(nil)
+	for i, name := range featureNames {
+		if features&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
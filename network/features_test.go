@@ -0,0 +1,59 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestFeatureNames(t *testing.T) {
+	assert.Nil(t, FeatureNames(0))
+	assert.Equal(t, []string{"compression", "stateSync"}, FeatureNames(CompressionFeature|StateSyncFeature))
+	assert.Equal(t, []string{"signedIPs"}, FeatureNames(SignedIPsFeature|1<<63))
+}
+
+func TestPeerSupports(t *testing.T) {
+	p := &peer{features: CompressionFeature | SignedIPsFeature}
+	assert.True(t, p.supports(0))
+	assert.True(t, p.supports(CompressionFeature))
+	assert.True(t, p.supports(CompressionFeature|SignedIPsFeature))
+	assert.False(t, p.supports(StateSyncFeature))
+	assert.False(t, p.supports(CompressionFeature|StateSyncFeature))
+}
+
+func TestSendUnsupportedOp(t *testing.T) {
+	opFeatures[Ping] = StateSyncFeature
+	defer delete(opFeatures, Ping)
+
+	n := &network{
+		log:            logging.NoLog{},
+		maxMessageSize: int64(DefaultMaxMessageSize),
+	}
+	assert.NoError(t, n.metrics.initialize(prometheus.NewRegistry()))
+	p := &peer{net: n}
+	for i := range p.senders {
+		p.senders[i] = make(chan []byte, 1)
+	}
+
+	ping, err := n.b.Ping()
+	assert.NoError(t, err)
+	assert.False(t, p.Send(ping), "the peer didn't report supporting pings")
+
+	p.features = StateSyncFeature
+	assert.True(t, p.Send(ping))
+}
+
+func TestFeaturesOptIn(t *testing.T) {
+	n := &network{}
+	assert.Zero(t, n.features(), "features must be enabled explicitly")
+
+	n.compressionEnabled = true
+	n.stateSyncEnabled = true
+	assert.Equal(t, CompressionFeature|StateSyncFeature, n.features())
+}
@@ -0,0 +1,73 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+// frontierCache holds the accepted frontiers peers recently reported, so that
+// a GetAcceptedFrontier sent to a peer shortly after it reported its frontier
+// can be answered without asking it again. A nil cache caches nothing.
+type frontierCache struct {
+	clock *timer.Clock
+	// how long a reported frontier is used for. If 0, frontiers aren't cached.
+	ttl time.Duration
+	// hash of a peer's ID and a chain ID --> *cachedFrontier
+	frontiers cache.LRU
+}
+
+type cachedFrontier struct {
+	containerIDs ids.Set
+	reported     time.Time
+}
+
+func newFrontierCache(clock *timer.Clock, ttl time.Duration, size int) *frontierCache {
+	return &frontierCache{
+		clock:     clock,
+		ttl:       ttl,
+		frontiers: cache.LRU{Size: size},
+	}
+}
+
+func frontierKey(peerID ids.ShortID, chainID ids.ID) ids.ID {
+	return ids.NewID(hashing.ByteArraysToHash256Array(peerID.Bytes(), chainID.Bytes()))
+}
+
+// put records that [peerID] reported [containerIDs] as its accepted frontier
+// of [chainID]
+func (c *frontierCache) put(peerID ids.ShortID, chainID ids.ID, containerIDs ids.Set) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+	frontier := &cachedFrontier{reported: c.clock.Time()}
+	frontier.containerIDs.Union(containerIDs)
+	c.frontiers.Put(frontierKey(peerID, chainID), frontier)
+}
+
+// get returns the accepted frontier of [chainID] [peerID] reported, if it
+// reported it less than the TTL ago
+func (c *frontierCache) get(peerID ids.ShortID, chainID ids.ID) (ids.Set, bool) {
+	if c == nil || c.ttl <= 0 {
+		return nil, false
+	}
+	key := frontierKey(peerID, chainID)
+	value, ok := c.frontiers.Get(key)
+	if !ok {
+		return nil, false
+	}
+	frontier := value.(*cachedFrontier)
+	if c.clock.Time().Sub(frontier.reported) >= c.ttl {
+		c.frontiers.Evict(key)
+		return nil, false
+	}
+	containerIDs := ids.Set{}
+	containerIDs.Union(frontier.containerIDs)
+	return containerIDs, true
+}
@@ -0,0 +1,67 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+func TestFrontierCache(t *testing.T) {
+	clock := timer.Clock{}
+	clock.Set(time.Unix(100, 0))
+	c := newFrontierCache(&clock, 5*time.Second, 16)
+
+	peerID := ids.NewShortID([20]byte{1})
+	chainID := ids.NewID([32]byte{2})
+	frontier := ids.Set{}
+	frontier.Add(ids.NewID([32]byte{3}))
+
+	_, ok := c.get(peerID, chainID)
+	assert.False(t, ok, "nothing was reported")
+
+	c.put(peerID, chainID, frontier)
+	cached, ok := c.get(peerID, chainID)
+	assert.True(t, ok)
+	assert.True(t, cached.Equals(frontier))
+
+	// The cached frontier can't be modified through the reported or returned
+	// sets
+	frontier.Add(ids.NewID([32]byte{4}))
+	cached.Remove(ids.NewID([32]byte{3}))
+	cached, ok = c.get(peerID, chainID)
+	assert.True(t, ok)
+	assert.Equal(t, 1, cached.Len())
+
+	_, ok = c.get(peerID, ids.NewID([32]byte{5}))
+	assert.False(t, ok, "frontiers are cached per chain")
+	_, ok = c.get(ids.NewShortID([20]byte{6}), chainID)
+	assert.False(t, ok, "frontiers are cached per peer")
+
+	clock.Set(clock.Time().Add(5 * time.Second))
+	_, ok = c.get(peerID, chainID)
+	assert.False(t, ok, "the frontier expired")
+}
+
+func TestFrontierCacheDisabled(t *testing.T) {
+	clock := timer.Clock{}
+	c := newFrontierCache(&clock, 0, 16)
+
+	peerID := ids.NewShortID([20]byte{1})
+	chainID := ids.NewID([32]byte{2})
+	c.put(peerID, chainID, ids.Set{})
+	_, ok := c.get(peerID, chainID)
+	assert.False(t, ok)
+
+	// A nil cache caches nothing
+	c = nil
+	c.put(peerID, chainID, ids.Set{})
+	_, ok = c.get(peerID, chainID)
+	assert.False(t, ok)
+}
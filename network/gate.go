@@ -0,0 +1,111 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// Gate decides which peers this node may connect to, by node ID and IP. Unlike
+// bans, a gate is configured when the node starts and doesn't change. Peers
+// that match a deny rule are never connected to. If there are allow rules of a
+// kind, peers must also match one of them, so a private subnet can be limited
+// to its members. Beacons aren't exempt, so they must be allowed too.
+// A nil Gate allows every peer.
+type Gate struct {
+	// If non-empty, only peers with these node IDs are connected to
+	AllowedNodeIDs ids.ShortSet
+	// Peers with these node IDs are never connected to
+	DeniedNodeIDs ids.ShortSet
+	// If non-empty, only peers with an IP in one of these networks are
+	// connected to
+	AllowedIPs []*net.IPNet
+	// Peers with an IP in one of these networks are never connected to
+	DeniedIPs []*net.IPNet
+}
+
+// NodeIDAllowed returns true if the peer [nodeID] may be connected to
+func (g *Gate) NodeIDAllowed(nodeID ids.ShortID) bool {
+	if g == nil {
+		return true
+	}
+	if g.DeniedNodeIDs.Contains(nodeID) {
+		return false
+	}
+	return g.AllowedNodeIDs.Len() == 0 || g.AllowedNodeIDs.Contains(nodeID)
+}
+
+// IPAllowed returns true if a peer at [ip] may be connected to
+func (g *Gate) IPAllowed(ip net.IP) bool {
+	if g == nil || ip == nil {
+		return true
+	}
+	if containsIP(g.DeniedIPs, ip) {
+		return false
+	}
+	return len(g.AllowedIPs) == 0 || containsIP(g.AllowedIPs, ip)
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseNodeIDs parses a comma separated list of node IDs, such as
+// NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET,NodeID-8CrVPQZ4VSqgL8zTdvL14G8HqAfrBr4z
+func ParseNodeIDs(str string) (ids.ShortSet, error) {
+	nodeIDs := ids.ShortSet{}
+	for _, nodeIDStr := range strings.Split(str, ",") {
+		if nodeIDStr == "" {
+			continue
+		}
+		nodeID, err := ids.ShortFromPrefixedString(nodeIDStr, constants.NodeIDPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse node ID %q: %w", nodeIDStr, err)
+		}
+		nodeIDs.Add(nodeID)
+	}
+	return nodeIDs, nil
+}
+
+// ParseIPNets parses a comma separated list of IPs and networks in CIDR
+// notation, such as 1.2.3.4,10.0.0.0/8,2001:db8::/32
+func ParseIPNets(str string) ([]*net.IPNet, error) {
+	networks := []*net.IPNet(nil)
+	for _, networkStr := range strings.Split(str, ",") {
+		if networkStr == "" {
+			continue
+		}
+		if strings.Contains(networkStr, "/") {
+			_, network, err := net.ParseCIDR(networkStr)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't parse network %q: %w", networkStr, err)
+			}
+			networks = append(networks, network)
+			continue
+		}
+		ip := net.ParseIP(networkStr)
+		if ip == nil {
+			return nil, fmt.Errorf("couldn't parse IP %q", networkStr)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		networks = append(networks, &net.IPNet{
+			IP:   ip,
+			Mask: net.CIDRMask(bits, bits),
+		})
+	}
+	return networks, nil
+}
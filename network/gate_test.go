@@ -0,0 +1,73 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestGateNil(t *testing.T) {
+	var g *Gate
+	assert.True(t, g.NodeIDAllowed(ids.NewShortID([20]byte{1})))
+	assert.True(t, g.IPAllowed(net.IPv4(1, 2, 3, 4)))
+}
+
+func TestGateNodeIDs(t *testing.T) {
+	allowedID := ids.NewShortID([20]byte{1})
+	deniedID := ids.NewShortID([20]byte{2})
+	otherID := ids.NewShortID([20]byte{3})
+
+	g := &Gate{DeniedNodeIDs: ids.ShortSet{}}
+	g.DeniedNodeIDs.Add(deniedID)
+	assert.True(t, g.NodeIDAllowed(allowedID))
+	assert.False(t, g.NodeIDAllowed(deniedID))
+	assert.True(t, g.NodeIDAllowed(otherID))
+
+	g.AllowedNodeIDs = ids.ShortSet{}
+	g.AllowedNodeIDs.Add(allowedID, deniedID)
+	assert.True(t, g.NodeIDAllowed(allowedID))
+	assert.False(t, g.NodeIDAllowed(deniedID))
+	assert.False(t, g.NodeIDAllowed(otherID))
+}
+
+func TestGateIPs(t *testing.T) {
+	allowed, err := ParseIPNets("10.0.0.0/8,2001:db8::1")
+	assert.NoError(t, err)
+	denied, err := ParseIPNets("10.0.0.1")
+	assert.NoError(t, err)
+
+	g := &Gate{DeniedIPs: denied}
+	assert.False(t, g.IPAllowed(net.IPv4(10, 0, 0, 1)))
+	assert.True(t, g.IPAllowed(net.IPv4(10, 0, 0, 2)))
+	assert.True(t, g.IPAllowed(net.IPv4(1, 2, 3, 4)))
+
+	g.AllowedIPs = allowed
+	assert.False(t, g.IPAllowed(net.IPv4(10, 0, 0, 1)))
+	assert.True(t, g.IPAllowed(net.IPv4(10, 0, 0, 2)))
+	assert.False(t, g.IPAllowed(net.IPv4(1, 2, 3, 4)))
+	assert.True(t, g.IPAllowed(net.ParseIP("2001:db8::1")))
+	assert.False(t, g.IPAllowed(net.ParseIP("2001:db8::2")))
+}
+
+func TestParseGateLists(t *testing.T) {
+	nodeID := ids.NewShortID([20]byte{1})
+	nodeIDs, err := ParseNodeIDs(nodeID.PrefixedString(constants.NodeIDPrefix) + ",")
+	assert.NoError(t, err)
+	assert.True(t, nodeIDs.Contains(nodeID))
+	assert.Equal(t, 1, nodeIDs.Len())
+
+	_, err = ParseNodeIDs("not a node ID")
+	assert.Error(t, err)
+
+	_, err = ParseIPNets("1.2.3.4/33")
+	assert.Error(t, err)
+	_, err = ParseIPNets("not an IP")
+	assert.Error(t, err)
+}
@@ -0,0 +1,42 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sync/atomic"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// gossipedContainer is the accepted container last gossiped for a chain
+type gossipedContainer struct {
+	containerID ids.ID
+	// value of the network's peer changes when the container was gossiped
+	peerChanges uint64
+}
+
+// gossipFanout returns the number of peers to gossip the accepted container
+// [containerID] of [chainID] to. A container that was already gossiped, when
+// no peers have connected or disconnected since, is gossiped to
+// idleGossipSize peers, so that an idle network doesn't spend bandwidth
+// repeating what its peers already know. Otherwise, it's gossiped to
+// gossipSize peers.
+// assumes the stateLock is not held.
+func (n *network) gossipFanout(chainID, containerID ids.ID) int {
+	peerChanges := atomic.LoadUint64(&n.peerChanges)
+
+	n.gossipLock.Lock()
+	defer n.gossipLock.Unlock()
+
+	last, ok := n.gossiped[chainID.Key()]
+	n.gossiped[chainID.Key()] = gossipedContainer{
+		containerID: containerID,
+		peerChanges: peerChanges,
+	}
+	if n.idleGossipSize <= 0 || n.idleGossipSize >= n.gossipSize ||
+		!ok || !last.containerID.Equals(containerID) || last.peerChanges != peerChanges {
+		return n.gossipSize
+	}
+	return n.idleGossipSize
+}
@@ -0,0 +1,40 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestGossipFanout(t *testing.T) {
+	n := &network{
+		gossipSize:     50,
+		idleGossipSize: 10,
+		gossiped:       make(map[[32]byte]gossipedContainer),
+	}
+	chainID := ids.Empty.Prefix(0)
+	otherChainID := ids.Empty.Prefix(1)
+	containerID := ids.Empty.Prefix(2)
+	newContainerID := ids.Empty.Prefix(3)
+
+	// New containers are gossiped widely, and repeated ones narrowly
+	assert.Equal(t, 50, n.gossipFanout(chainID, containerID))
+	assert.Equal(t, 10, n.gossipFanout(chainID, containerID))
+	assert.Equal(t, 50, n.gossipFanout(otherChainID, containerID))
+	assert.Equal(t, 50, n.gossipFanout(chainID, newContainerID))
+	assert.Equal(t, 10, n.gossipFanout(chainID, newContainerID))
+
+	// Once peers change, the container is gossiped widely again
+	n.peerChanges++
+	assert.Equal(t, 50, n.gossipFanout(chainID, newContainerID))
+	assert.Equal(t, 10, n.gossipFanout(chainID, newContainerID))
+
+	// Without an idle gossip size, containers are always gossiped widely
+	n.idleGossipSize = 0
+	assert.Equal(t, 50, n.gossipFanout(chainID, newContainerID))
+}
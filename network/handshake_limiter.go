@@ -0,0 +1,68 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sync"
+)
+
+// handshakeLimiter limits the number of inbound connections that are being
+// upgraded at once, overall and from each IP. Each connection being upgraded
+// holds a file descriptor and a goroutine until its handshake finishes or
+// times out, so without a limit, a flood of connections that never finish
+// their handshakes exhausts the node's file descriptors.
+type handshakeLimiter struct {
+	lock sync.Mutex
+	// the maximum number of handshakes at once, overall and from each IP. 0
+	// means unlimited.
+	maxPending, maxPendingPerIP int
+	pending                     int
+	pendingPerIP                map[string]int
+}
+
+func newHandshakeLimiter(maxPending, maxPendingPerIP int) *handshakeLimiter {
+	return &handshakeLimiter{
+		maxPending:      maxPending,
+		maxPendingPerIP: maxPendingPerIP,
+		pendingPerIP:    make(map[string]int),
+	}
+}
+
+// acquire returns true if a handshake with a connection from [ip] may start.
+// If it returns true, release must be called once the handshake finishes.
+func (h *handshakeLimiter) acquire(ip string) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.maxPending > 0 && h.pending >= h.maxPending {
+		return false
+	}
+	if h.maxPendingPerIP > 0 && h.pendingPerIP[ip] >= h.maxPendingPerIP {
+		return false
+	}
+	h.pending++
+	h.pendingPerIP[ip]++
+	return true
+}
+
+// release marks that a handshake with a connection from [ip] finished
+func (h *handshakeLimiter) release(ip string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.pending--
+	if h.pendingPerIP[ip] <= 1 {
+		delete(h.pendingPerIP, ip)
+	} else {
+		h.pendingPerIP[ip]--
+	}
+}
+
+// numPending returns the number of handshakes in progress
+func (h *handshakeLimiter) numPending() int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return h.pending
+}
@@ -0,0 +1,35 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandshakeLimiterPerIP(t *testing.T) {
+	h := newHandshakeLimiter(0, 2)
+
+	assert.True(t, h.acquire("1.2.3.4"))
+	assert.True(t, h.acquire("1.2.3.4"))
+	assert.False(t, h.acquire("1.2.3.4"))
+	assert.True(t, h.acquire("5.6.7.8"))
+	assert.Equal(t, 3, h.numPending())
+
+	h.release("1.2.3.4")
+	assert.True(t, h.acquire("1.2.3.4"))
+}
+
+func TestHandshakeLimiterOverall(t *testing.T) {
+	h := newHandshakeLimiter(2, 0)
+
+	assert.True(t, h.acquire("1.2.3.4"))
+	assert.True(t, h.acquire("5.6.7.8"))
+	assert.False(t, h.acquire("9.10.11.12"))
+
+	h.release("1.2.3.4")
+	assert.True(t, h.acquire("9.10.11.12"))
+	assert.Empty(t, h.pendingPerIP["1.2.3.4"])
+}
@@ -0,0 +1,53 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+// AdvertisedListener accepts connections from peers on one of this node's
+// addresses. Peers that connect through it are told that this node is at
+// [IP], unless it's the zero IP, in which case they're told this node's IP.
+type AdvertisedListener struct {
+	net.Listener
+	IP utils.IPDesc
+}
+
+// ListenAddress is an address to accept connections from peers on, and the IP
+// to claim to the peers that connect through it, or the zero IP
+type ListenAddress struct {
+	Address string
+	IP      utils.IPDesc
+}
+
+// ParseListenAddresses parses a comma separated list of addresses, each
+// followed by "=" and the IP advertised to the peers that connect through it
+// if it isn't this node's IP. Example: 10.0.0.2:9651=203.0.113.7:9651,[::1]:9651
+func ParseListenAddresses(str string) ([]ListenAddress, error) {
+	addrs := []ListenAddress(nil)
+	for _, addrStr := range strings.Split(str, ",") {
+		if addrStr == "" {
+			continue
+		}
+		parts := strings.SplitN(addrStr, "=", 2)
+		if _, _, err := net.SplitHostPort(parts[0]); err != nil {
+			return nil, fmt.Errorf("couldn't parse listen address %q: %w", parts[0], err)
+		}
+		addr := ListenAddress{Address: parts[0]}
+		if len(parts) == 2 {
+			ip, err := utils.ToIPDesc(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("couldn't parse advertised IP %q: %w", parts[1], err)
+			}
+			addr.IP = ip
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
@@ -0,0 +1,43 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+func TestParseListenAddresses(t *testing.T) {
+	addrs, err := ParseListenAddresses("10.0.0.2:9651=203.0.113.7:9652,[::1]:9651,")
+	assert.NoError(t, err)
+	assert.Equal(t, []ListenAddress{
+		{
+			Address: "10.0.0.2:9651",
+			IP:      utils.IPDesc{IP: net.ParseIP("203.0.113.7"), Port: 9652},
+		},
+		{Address: "[::1]:9651"},
+	}, addrs)
+
+	addrs, err = ParseListenAddresses("")
+	assert.NoError(t, err)
+	assert.Empty(t, addrs)
+
+	_, err = ParseListenAddresses("10.0.0.2")
+	assert.Error(t, err, "the port is missing")
+	_, err = ParseListenAddresses("10.0.0.2:9651=not an IP")
+	assert.Error(t, err)
+}
+
+func TestAdvertisedIP(t *testing.T) {
+	myIP := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	listenerIP := utils.IPDesc{IP: net.IPv4(5, 6, 7, 8), Port: 9651}
+	n := &network{ip: utils.NewDynamicIPDesc(myIP.IP, myIP.Port)}
+
+	assert.Equal(t, myIP, (&peer{net: n}).advertisedIP())
+	assert.Equal(t, listenerIP, (&peer{net: n, myIP: listenerIP}).advertisedIP())
+}
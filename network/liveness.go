@@ -0,0 +1,85 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// livenessRecoveryPongs is the number of pings in a row a peer must answer
+// before reconnecting to its IP is no longer backed off
+const livenessRecoveryPongs = 3
+
+// probe records that a ping is about to be sent to the peer. Returns false if
+// the peer didn't answer maxMissedPongs pings in a row, meaning the connection
+// is silently dropping messages and should be closed. A ping that isn't
+// answered before the next one is sent counts as missed.
+func (p *peer) probe() bool {
+	now := p.net.clock.Time().UnixNano()
+	if sent := atomic.SwapInt64(&p.pingSent, now); sent == 0 {
+		return true
+	}
+	atomic.StoreUint32(&p.answeredPongs, 0)
+	missed := atomic.AddUint32(&p.missedPongs, 1)
+	return p.net.maxMissedPongs <= 0 || int(missed) < p.net.maxMissedPongs
+}
+
+// answered records that the peer answered the last ping it was sent
+func (p *peer) answered() {
+	sent := atomic.SwapInt64(&p.pingSent, 0)
+	if sent == 0 {
+		// the pong wasn't asked for
+		return
+	}
+	atomic.StoreUint32(&p.missedPongs, 0)
+
+	rtt := p.net.clock.Time().UnixNano() - sent
+	if rtt < 0 {
+		rtt = 0
+	}
+	p.net.pingRTT.Observe(time.Duration(rtt).Seconds())
+
+	// smooth the round trip time the way TCP does
+	if srtt := atomic.LoadInt64(&p.pingRTT); srtt != 0 {
+		rtt = (7*srtt + rtt) / 8
+	}
+	atomic.StoreInt64(&p.pingRTT, rtt)
+
+	if atomic.AddUint32(&p.answeredPongs, 1) == livenessRecoveryPongs {
+		p.net.livenessRecovered(p)
+	}
+}
+
+// nextLivenessBackoff returns how long to wait before reconnecting to [ip]
+// after its connection stopped answering pings. The delay doubles each time a
+// connection to [ip] stops answering pings, until it answers
+// livenessRecoveryPongs pings in a row.
+// assumes the stateLock is held.
+func (n *network) nextLivenessBackoff(ip string) time.Duration {
+	delay := 2 * n.livenessBackoff[ip]
+	if delay == 0 {
+		delay = n.initialReconnectDelay
+	}
+	if delay > n.maxReconnectDelay {
+		delay = n.maxReconnectDelay
+	}
+	n.livenessBackoff[ip] = delay
+	return delay
+}
+
+// livenessRecovered stops backing off reconnecting to the IP of [p], which is
+// answering pings again
+// assumes the stateLock is not held.
+func (n *network) livenessRecovered(p *peer) {
+	ip := p.getIP()
+	if ip.IsZero() {
+		return
+	}
+
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	delete(n.livenessBackoff, ip.String())
+}
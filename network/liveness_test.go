@@ -0,0 +1,67 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+func TestPeerProbe(t *testing.T) {
+	n := &network{
+		maxMissedPongs:  2,
+		livenessBackoff: make(map[string]time.Duration),
+	}
+	assert.NoError(t, n.metrics.initialize(prometheus.NewRegistry()))
+	n.clock.Set(time.Unix(100, 0))
+	p := &peer{net: n}
+
+	assert.True(t, p.probe())
+	n.clock.Set(n.clock.Time().Add(time.Second))
+	p.answered()
+	assert.Equal(t, int64(time.Second), p.pingRTT)
+
+	// An unasked for pong is ignored
+	p.answered()
+	assert.Equal(t, int64(time.Second), p.pingRTT)
+
+	assert.True(t, p.probe())
+	assert.True(t, p.probe(), "one missed pong is tolerated")
+	assert.False(t, p.probe(), "the second missed pong in a row closes the connection")
+
+	// Answering resets the missed pongs
+	n.clock.Set(n.clock.Time().Add(3 * time.Second))
+	p.answered()
+	assert.Equal(t, int64(time.Second+time.Second/4), p.pingRTT)
+	assert.True(t, p.probe())
+	assert.True(t, p.probe())
+}
+
+func TestLivenessBackoff(t *testing.T) {
+	n := &network{
+		initialReconnectDelay: time.Second,
+		maxReconnectDelay:     3 * time.Second,
+		livenessBackoff:       make(map[string]time.Duration),
+	}
+	assert.NoError(t, n.metrics.initialize(prometheus.NewRegistry()))
+	ip := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+
+	assert.Equal(t, time.Second, n.nextLivenessBackoff(ip.String()))
+	assert.Equal(t, 2*time.Second, n.nextLivenessBackoff(ip.String()))
+	assert.Equal(t, 3*time.Second, n.nextLivenessBackoff(ip.String()))
+
+	// The backoff is reset once the peer answers enough pings in a row
+	p := &peer{net: n, ip: ip}
+	for i := 0; i < livenessRecoveryPongs; i++ {
+		assert.True(t, p.probe())
+		p.answered()
+	}
+	assert.Equal(t, time.Second, n.nextLivenessBackoff(ip.String()))
+}
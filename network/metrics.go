@@ -12,8 +12,21 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
+// Values of the peer type label of message metrics
+const (
+	validatorPeer    = "validator"
+	nonValidatorPeer = "non_validator"
+)
+
 type messageMetrics struct {
 	numSent, numFailed, numReceived prometheus.Counter
+
+	// bytes of messages written to and read from peers, by peer type
+	bytesSent, bytesReceived *prometheus.CounterVec
+	// number of messages in peers' send queues
+	queued prometheus.Gauge
+	// seconds taken to handle received messages, by peer type
+	handleDuration *prometheus.HistogramVec
 }
 
 func (mm *messageMetrics) initialize(msgType Op, registerer prometheus.Registerer) error {
@@ -32,6 +45,27 @@ func (mm *messageMetrics) initialize(msgType Op, registerer prometheus.Registere
 		Name:      fmt.Sprintf("%s_received", msgType),
 		Help:      fmt.Sprintf("Number of %s messages received", msgType),
 	})
+	mm.bytesSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      fmt.Sprintf("%s_sent_bytes", msgType),
+		Help:      fmt.Sprintf("Number of bytes of %s messages written to peers", msgType),
+	}, []string{"peer_type"})
+	mm.bytesReceived = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      fmt.Sprintf("%s_received_bytes", msgType),
+		Help:      fmt.Sprintf("Number of bytes of %s messages read from peers", msgType),
+	}, []string{"peer_type"})
+	mm.queued = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: constants.PlatformName,
+		Name:      fmt.Sprintf("%s_queued", msgType),
+		Help:      fmt.Sprintf("Number of %s messages waiting in send queues", msgType),
+	})
+	mm.handleDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: constants.PlatformName,
+		Name:      fmt.Sprintf("%s_handle_duration_seconds", msgType),
+		Help:      fmt.Sprintf("Time, in seconds, taken to handle received %s messages", msgType),
+		Buckets:   prometheus.ExponentialBuckets(1e-6, 4, 10),
+	}, []string{"peer_type"})
 
 	if err := registerer.Register(mm.numSent); err != nil {
 		return fmt.Errorf("failed to register sent statistics of %s due to %s",
@@ -45,19 +79,64 @@ func (mm *messageMetrics) initialize(msgType Op, registerer prometheus.Registere
 		return fmt.Errorf("failed to register received statistics of %s due to %s",
 			msgType, err)
 	}
+	if err := registerer.Register(mm.bytesSent); err != nil {
+		return fmt.Errorf("failed to register sent bytes statistics of %s due to %s",
+			msgType, err)
+	}
+	if err := registerer.Register(mm.bytesReceived); err != nil {
+		return fmt.Errorf("failed to register received bytes statistics of %s due to %s",
+			msgType, err)
+	}
+	if err := registerer.Register(mm.queued); err != nil {
+		return fmt.Errorf("failed to register queued statistics of %s due to %s",
+			msgType, err)
+	}
+	if err := registerer.Register(mm.handleDuration); err != nil {
+		return fmt.Errorf("failed to register handle duration statistics of %s due to %s",
+			msgType, err)
+	}
 	return nil
 }
 
 type metrics struct {
 	numPeers prometheus.Gauge
 
-	getVersion, version,
+	// peers evicted to make room for peers with more stake, and peers whose
+	// connections were rejected because every peer had at least as much stake
+	peersEvicted, peersRejected prometheus.Counter
+
+	// ratio of the compressed size of messages sent compressed to their size,
+	// and the number of bytes compression saved sending
+	compressionRatio      prometheus.Histogram
+	compressionSavedBytes prometheus.Counter
+
+	// seconds messages waited to be sent because of bandwidth limits
+	sendThrottled prometheus.Counter
+
+	// inbound connections dropped because too many were attempted from their
+	// IP or subnet, or because too many handshakes were in progress, and the
+	// number of inbound handshakes in progress
+	inboundConnsThrottled, inboundHandshakesThrottled prometheus.Counter
+	pendingHandshakes                                 prometheus.Gauge
+
+	// seconds pings took to be answered, and connections closed because they
+	// stopped answering pings
+	pingRTT           prometheus.Histogram
+	unresponsivePeers prometheus.Counter
+
+	// GetAcceptedFrontier requests answered with a frontier the peer recently
+	// reported, rather than sent
+	frontierCacheHits prometheus.Counter
+
+	getVersion, version, capabilities,
 	getPeerlist, peerlist,
 	ping, pong,
 	getAcceptedFrontier, acceptedFrontier,
 	getAccepted, accepted,
 	get, getAncestors, put, multiPut,
-	pushQuery, pullQuery, chits messageMetrics
+	pushQuery, pullQuery, chits,
+	getStateSummaryFrontier, stateSummaryFrontier,
+	getStateChunk, stateChunk messageMetrics
 }
 
 func (m *metrics) initialize(registerer prometheus.Registerer) error {
@@ -67,14 +146,118 @@ func (m *metrics) initialize(registerer prometheus.Registerer) error {
 		Help:      "Number of network peers",
 	})
 
+	m.peersEvicted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "peers_evicted",
+		Help:      "Number of peers disconnected to make room for peers with more stake",
+	})
+	m.peersRejected = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "peers_rejected",
+		Help:      "Number of peer connections rejected because the maximum number of peers, all with at least as much stake, were connected",
+	})
+	m.compressionRatio = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: constants.PlatformName,
+		Name:      "compression_ratio",
+		Help:      "Ratio of the compressed size of messages sent compressed to their uncompressed size",
+		Buckets:   prometheus.LinearBuckets(0.1, 0.1, 10),
+	})
+	m.compressionSavedBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "compression_saved_bytes",
+		Help:      "Number of bytes not sent because messages were compressed",
+	})
+
+	m.sendThrottled = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "send_throttled_seconds",
+		Help:      "Total time, in seconds, messages waited to be sent because of bandwidth limits",
+	})
+	m.inboundConnsThrottled = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "inbound_conns_throttled",
+		Help:      "Number of inbound connections dropped because too many were attempted from their IP or subnet",
+	})
+	m.inboundHandshakesThrottled = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "inbound_handshakes_throttled",
+		Help:      "Number of inbound connections dropped because too many handshakes, overall or from their IP, were in progress",
+	})
+	m.pendingHandshakes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: constants.PlatformName,
+		Name:      "pending_inbound_handshakes",
+		Help:      "Number of inbound connections being upgraded",
+	})
+	m.pingRTT = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: constants.PlatformName,
+		Name:      "ping_rtt",
+		Help:      "Time, in seconds, pings took to be answered",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	})
+	m.unresponsivePeers = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "unresponsive_peers",
+		Help:      "Number of peer connections closed because they stopped answering pings",
+	})
+	m.frontierCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "accepted_frontier_cache_hits",
+		Help:      "Number of GetAcceptedFrontier requests answered with an accepted frontier the peer recently reported, rather than sent",
+	})
+
 	errs := wrappers.Errs{}
 	if err := registerer.Register(m.numPeers); err != nil {
 		errs.Add(fmt.Errorf("failed to register peers statistics due to %s",
 			err))
 	}
+	if err := registerer.Register(m.peersEvicted); err != nil {
+		errs.Add(fmt.Errorf("failed to register peers evicted statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.peersRejected); err != nil {
+		errs.Add(fmt.Errorf("failed to register peers rejected statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.compressionRatio); err != nil {
+		errs.Add(fmt.Errorf("failed to register compression ratio statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.compressionSavedBytes); err != nil {
+		errs.Add(fmt.Errorf("failed to register compression saved bytes statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.sendThrottled); err != nil {
+		errs.Add(fmt.Errorf("failed to register send throttled statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.inboundConnsThrottled); err != nil {
+		errs.Add(fmt.Errorf("failed to register inbound conns throttled statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.inboundHandshakesThrottled); err != nil {
+		errs.Add(fmt.Errorf("failed to register inbound handshakes throttled statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.pendingHandshakes); err != nil {
+		errs.Add(fmt.Errorf("failed to register pending inbound handshakes statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.pingRTT); err != nil {
+		errs.Add(fmt.Errorf("failed to register ping rtt statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.unresponsivePeers); err != nil {
+		errs.Add(fmt.Errorf("failed to register unresponsive peers statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.frontierCacheHits); err != nil {
+		errs.Add(fmt.Errorf("failed to register accepted frontier cache hits statistics due to %s",
+			err))
+	}
 	errs.Add(
 		m.getVersion.initialize(GetVersion, registerer),
 		m.version.initialize(Version, registerer),
+		m.capabilities.initialize(Capabilities, registerer),
 		m.getPeerlist.initialize(GetPeerList, registerer),
 		m.peerlist.initialize(PeerList, registerer),
 		m.ping.initialize(Ping, registerer),
@@ -90,6 +273,10 @@ func (m *metrics) initialize(registerer prometheus.Registerer) error {
 		m.pushQuery.initialize(PushQuery, registerer),
 		m.pullQuery.initialize(PullQuery, registerer),
 		m.chits.initialize(Chits, registerer),
+		m.getStateSummaryFrontier.initialize(GetStateSummaryFrontier, registerer),
+		m.stateSummaryFrontier.initialize(StateSummaryFrontier, registerer),
+		m.getStateChunk.initialize(GetStateChunk, registerer),
+		m.stateChunk.initialize(StateChunk, registerer),
 	)
 	return errs.Err
 }
@@ -100,6 +287,8 @@ func (m *metrics) message(msgType Op) *messageMetrics {
 		return &m.getVersion
 	case Version:
 		return &m.version
+	case Capabilities:
+		return &m.capabilities
 	case GetPeerList:
 		return &m.getPeerlist
 	case PeerList:
@@ -130,6 +319,14 @@ func (m *metrics) message(msgType Op) *messageMetrics {
 		return &m.pullQuery
 	case Chits:
 		return &m.chits
+	case GetStateSummaryFrontier:
+		return &m.getStateSummaryFrontier
+	case StateSummaryFrontier:
+		return &m.stateSummaryFrontier
+	case GetStateChunk:
+		return &m.getStateChunk
+	case StateChunk:
+		return &m.stateChunk
 	default:
 		return nil
 	}
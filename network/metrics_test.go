@@ -0,0 +1,54 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestMessageMetricsQueued(t *testing.T) {
+	n := &network{
+		log:            logging.NoLog{},
+		maxMessageSize: int64(DefaultMaxMessageSize),
+		vdrs:           validators.NewSet(),
+	}
+	assert.NoError(t, n.metrics.initialize(prometheus.NewRegistry()))
+
+	p := &peer{net: n}
+	for i := range p.senders {
+		p.senders[i] = make(chan []byte, 1)
+	}
+
+	multiPut, err := n.b.MultiPut(ids.Empty, 0, [][]byte{{1, 2, 3}})
+	assert.NoError(t, err)
+	ping, err := n.b.Ping()
+	assert.NoError(t, err)
+
+	assert.True(t, p.Send(multiPut))
+	assert.True(t, p.Send(ping))
+	assert.Equal(t, 1.0, testutil.ToFloat64(n.multiPut.queued))
+	assert.Equal(t, 1.0, testutil.ToFloat64(n.ping.queued))
+
+	msg, ok := p.nextMessage()
+	assert.True(t, ok)
+	assert.Equal(t, ping.Bytes(), msg)
+}
+
+func TestPeerType(t *testing.T) {
+	vdrs := validators.NewSet()
+	vdrID := ids.NewShortID([20]byte{1})
+	assert.NoError(t, vdrs.AddWeight(vdrID, 1))
+	n := &network{vdrs: vdrs}
+
+	assert.Equal(t, validatorPeer, (&peer{net: n, id: vdrID}).peerType())
+	assert.Equal(t, nonValidatorPeer, (&peer{net: n, id: ids.NewShortID([20]byte{2})}).peerType())
+}
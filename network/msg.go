@@ -3,17 +3,28 @@
 
 package network
 
+import (
+	"sync"
+)
+
 // Msg represents a set of fields that can be serialized into a byte stream
 type Msg interface {
 	Op() Op
 	Get(Field) interface{}
 	Bytes() []byte
+	// CompressedBytes returns this message compressed, or nil if it shouldn't
+	// be compressed
+	CompressedBytes() []byte
 }
 
 type msg struct {
 	op     Op
 	fields map[Field]interface{}
 	bytes  []byte
+
+	// the message is compressed at most once, however many peers it's sent to
+	compressOnce    sync.Once
+	compressedBytes []byte
 }
 
 // Field returns the value of the specified field in this message
@@ -24,3 +35,14 @@ func (msg *msg) Get(field Field) interface{} { return msg.fields[field] }
 
 // Bytes returns this message in bytes
 func (msg *msg) Bytes() []byte { return msg.bytes }
+
+// CompressedBytes returns this message compressed, or nil if it shouldn't be
+// compressed
+func (msg *msg) CompressedBytes() []byte {
+	msg.compressOnce.Do(func() {
+		if compressible(msg.op) {
+			msg.compressedBytes = compress(msg.bytes)
+		}
+	})
+	return msg.compressedBytes
+}
@@ -4,6 +4,8 @@
 package network
 
 import (
+	"crypto"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -15,8 +17,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/networking/drops"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/snow/triggers"
@@ -24,6 +28,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/sampler"
 	"github.com/ava-labs/avalanchego/utils/timer"
@@ -47,14 +52,19 @@ const (
 	defaultGossipSize                                = 50
 	defaultPingPongTimeout                           = time.Minute
 	defaultPingFrequency                             = 3 * defaultPingPongTimeout / 4
+	defaultMaxMissedPongs                            = 3
+	defaultFrontierCacheTTL                          = 5 * time.Second
+	defaultFrontierCacheSize                         = 4096
 	defaultReadBufferSize                            = 16 * 1024
 	defaultReadHandshakeTimeout                      = 15 * time.Second
 	defaultConnMeterCacheSize                        = 10000
 )
 
 var (
-	errNetworkClosed = errors.New("network closed")
-	errPeerIsMyself  = errors.New("peer is myself")
+	errNetworkClosed  = errors.New("network closed")
+	errPeerIsMyself   = errors.New("peer is myself")
+	errPeerBanned     = errors.New("peer is banned")
+	errPeerNotAllowed = errors.New("peer isn't allowed by the gate")
 )
 
 func init() { rand.Seed(time.Now().UnixNano()) }
@@ -86,6 +96,42 @@ type Network interface {
 	// to externally. Thread safety must be managed internally to the network.
 	Peers() []PeerID
 
+	// Disconnect the peer with [nodeID] and prevent it from connecting to
+	// this node for [duration], or until it's unbanned if [duration] is 0.
+	// Thread safety must be managed internally to the network.
+	BanNodeID(nodeID ids.ShortID, duration time.Duration)
+
+	// Disconnect peers at [ip] and prevent peers at [ip] from connecting to
+	// this node for [duration], or until it's unbanned if [duration] is 0.
+	// Thread safety must be managed internally to the network.
+	BanIP(ip net.IP, duration time.Duration)
+
+	// Lift the ban of [nodeID]. Returns true if it was banned. Thread safety
+	// must be managed internally to the network.
+	UnbanNodeID(nodeID ids.ShortID) bool
+
+	// Lift the ban of [ip]. Returns true if it was banned. Thread safety must
+	// be managed internally to the network.
+	UnbanIP(ip net.IP) bool
+
+	// Returns the bans that haven't expired. Thread safety must be managed
+	// internally to the network.
+	Bans() []Ban
+
+	// Start recording the messages described by [config] to capture files,
+	// replacing the capture in progress, if any. Thread safety must be managed
+	// internally to the network.
+	StartCapture(config CaptureConfig) error
+
+	// Stop recording messages, and return the capture files that were kept.
+	// Thread safety must be managed internally to the network.
+	StopCapture() ([]string, error)
+
+	// Returns when a peer at a public IP last connected to this node, which
+	// shows that this node is reachable, or the zero time if none has. Thread
+	// safety must be managed internally to the network.
+	LastInboundConnection() time.Time
+
 	// Close this network and all existing connections it has. Thread safety
 	// must be managed internally to the network. Calling close multiple times
 	// will return a nil error.
@@ -96,24 +142,32 @@ type network struct {
 	// The metrics that this network tracks
 	metrics
 
-	log            logging.Logger
-	id             ids.ShortID
-	ip             utils.DynamicIPDesc
-	networkID      uint32
-	version        version.Version
-	parser         version.Parser
-	listener       net.Listener
-	dialer         Dialer
-	serverUpgrader Upgrader
-	clientUpgrader Upgrader
-	vdrs           validators.Set // set of current validators in the Avalanche network
-	beacons        validators.Set // set of beacons in the Avalanche network
-	router         router.Router  // router must be thread safe
+	log             logging.Logger
+	id              ids.ShortID
+	ip              utils.DynamicIPDesc
+	networkID       uint32
+	version         version.Version
+	parser          version.Parser
+	featuresVersion version.Version // min version of peers that exchange capabilities, or nil
+	listener        net.Listener
+	extraListeners  []AdvertisedListener // listeners on this node's other addresses
+	dialer          Dialer
+	serverUpgrader  Upgrader
+	clientUpgrader  Upgrader
+	vdrs            validators.Set     // set of current validators in the Avalanche network
+	subnetVdrs      validators.Manager // validators of each subnet, used to prioritize peers
+	beacons         validators.Set     // set of beacons in the Avalanche network
+	router          router.Router      // router must be thread safe
+	drops           *drops.Log         // records messages dropped by this network
 
 	nodeID uint32
 
 	clock         timer.Clock
 	lastHeartbeat int64
+	// unix time a peer at a public IP last connected to this node, or 0
+	lastInbound int64
+	// number of times a peer connected or disconnected
+	peerChanges uint64
 
 	initialReconnectDelay              time.Duration
 	maxReconnectDelay                  time.Duration
@@ -130,15 +184,46 @@ type network struct {
 	gossipSize                         int
 	pingPongTimeout                    time.Duration
 	pingFrequency                      time.Duration
+	maxMissedPongs                     int
 	readBufferSize                     uint32
 	readHandshakeTimeout               time.Duration
 	connMeterMaxConns                  int
 	connMeter                          ConnMeter
+	connMeterMaxSubnetConns            int
+	subnetConnMeter                    ConnMeter
+	handshakes                         *handshakeLimiter
+	gate                               *Gate
+	peerStore                          *peerStore
+	bans                               *banList
+	compressionEnabled                 bool
+	// signs the IP this node claims, or nil if this node doesn't sign it
+	ipSigner *ipSigner
+	// if IPs gossiped without a signature are ignored
+	requireSignedIPs bool
+	// if this node advertises that it can parse and serve state sync messages
+	stateSyncEnabled  bool
+	maxPeers          int
+	minSubnetPeers    int
+	idleGossipSize    int
+	peerSendBandwidth uint64
+	// limits the rate bytes are sent to every peer at
+	sendBandwidth *bandwidthLimiter
 
 	executor timer.Executor
 
 	b Builder
 
+	// records messages to capture files while capture mode is on
+	captureLock sync.RWMutex
+	capture     *capture
+
+	// accepted frontiers peers recently reported
+	frontiers *frontierCache
+
+	// the accepted container last gossiped for each chain
+	gossipLock sync.Mutex
+	gossiped   map[[32]byte]gossipedContainer
+
 	// stateLock should never be held when grabbing a peer lock
 	stateLock sync.RWMutex
 
@@ -147,9 +232,30 @@ type network struct {
 	disconnectedIPs map[string]struct{}
 	connectedIPs    map[string]struct{}
 	retryDelay      map[string]time.Duration
+	// IP --> the delay before reconnecting to it, after its last connection
+	// stopped answering pings
+	livenessBackoff map[string]time.Duration
 	// TODO: bound the size of [myIPs] to avoid DoS. LRU caching would be ideal
 	myIPs map[string]struct{} // set of IPs that resulted in my ID.
 	peers map[[20]byte]*peer
+	// Node ID --> the IP a validator was last connected to at
+	vdrIPs map[[20]byte]utils.IPDesc
+
+	// startTime is when this network was created
+	startTime time.Time
+	// Node ID --> how long this node has observed the peer to be connected.
+	// Only validators are tracked after they disconnect.
+	uptimes map[[20]byte]*peerUptime
+}
+
+// peerUptime tracks how long a peer has been connected
+type peerUptime struct {
+	// connectedSince is when the peer connected, or the zero time if the peer
+	// isn't connected
+	connectedSince time.Time
+	// upDuration is the total time the peer was connected before
+	// [connectedSince]
+	upDuration time.Duration
 }
 
 // NewDefaultNetwork returns a new Network implementation with the provided
@@ -162,6 +268,7 @@ func NewDefaultNetwork(
 	networkID uint32,
 	version version.Version,
 	parser version.Parser,
+	featuresVersion version.Version,
 	listener net.Listener,
 	dialer Dialer,
 	serverUpgrader,
@@ -169,8 +276,25 @@ func NewDefaultNetwork(
 	vdrs validators.Set,
 	beacons validators.Set,
 	router router.Router,
+	dropLog *drops.Log,
 	connMeterResetDuration time.Duration,
 	connMeterMaxConns int,
+	compressionEnabled bool,
+	subnetVdrs validators.Manager,
+	maxPeers int,
+	peerSendBandwidth uint64,
+	sendBandwidth uint64,
+	connMeterMaxSubnetConns int,
+	maxPendingHandshakes int,
+	maxPendingHandshakesPerIP int,
+	gate *Gate,
+	peerDB database.Database,
+	minSubnetPeers int,
+	idleGossipSize int,
+	stakingCert *tls.Certificate,
+	requireSignedIPs bool,
+	stateSyncEnabled bool,
+	extraListeners []AdvertisedListener,
 ) Network {
 	return NewNetwork(
 		registerer,
@@ -180,6 +304,7 @@ func NewDefaultNetwork(
 		networkID,
 		version,
 		parser,
+		featuresVersion,
 		listener,
 		dialer,
 		serverUpgrader,
@@ -187,6 +312,7 @@ func NewDefaultNetwork(
 		vdrs,
 		beacons,
 		router,
+		dropLog,
 		defaultInitialReconnectDelay,
 		defaultMaxReconnectDelay,
 		DefaultMaxMessageSize,
@@ -207,6 +333,24 @@ func NewDefaultNetwork(
 		connMeterResetDuration,
 		defaultConnMeterCacheSize,
 		connMeterMaxConns,
+		compressionEnabled,
+		subnetVdrs,
+		maxPeers,
+		peerSendBandwidth,
+		sendBandwidth,
+		connMeterMaxSubnetConns,
+		maxPendingHandshakes,
+		maxPendingHandshakesPerIP,
+		gate,
+		peerDB,
+		minSubnetPeers,
+		idleGossipSize,
+		stakingCert,
+		requireSignedIPs,
+		stateSyncEnabled,
+		extraListeners,
+		defaultMaxMissedPongs,
+		defaultFrontierCacheTTL,
 	)
 }
 
@@ -219,6 +363,7 @@ func NewNetwork(
 	networkID uint32,
 	version version.Version,
 	parser version.Parser,
+	featuresVersion version.Version,
 	listener net.Listener,
 	dialer Dialer,
 	serverUpgrader,
@@ -226,6 +371,7 @@ func NewNetwork(
 	vdrs validators.Set,
 	beacons validators.Set,
 	router router.Router,
+	dropLog *drops.Log,
 	initialReconnectDelay,
 	maxReconnectDelay time.Duration,
 	maxMessageSize uint32,
@@ -246,22 +392,43 @@ func NewNetwork(
 	connMeterResetDuration time.Duration,
 	connMeterCacheSize int,
 	connMeterMaxConns int,
+	compressionEnabled bool,
+	subnetVdrs validators.Manager,
+	maxPeers int,
+	peerSendBandwidth uint64,
+	sendBandwidth uint64,
+	connMeterMaxSubnetConns int,
+	maxPendingHandshakes int,
+	maxPendingHandshakesPerIP int,
+	gate *Gate,
+	peerDB database.Database,
+	minSubnetPeers int,
+	idleGossipSize int,
+	stakingCert *tls.Certificate,
+	requireSignedIPs bool,
+	stateSyncEnabled bool,
+	extraListeners []AdvertisedListener,
+	maxMissedPongs int,
+	frontierCacheTTL time.Duration,
 ) Network {
 	// #nosec G404
 	netw := &network{
-		log:            log,
-		id:             id,
-		ip:             ip,
-		networkID:      networkID,
-		version:        version,
-		parser:         parser,
-		listener:       listener,
-		dialer:         dialer,
-		serverUpgrader: serverUpgrader,
-		clientUpgrader: clientUpgrader,
-		vdrs:           vdrs,
-		beacons:        beacons,
-		router:         router,
+		log:             log,
+		id:              id,
+		ip:              ip,
+		networkID:       networkID,
+		version:         version,
+		parser:          parser,
+		featuresVersion: featuresVersion,
+		listener:        listener,
+		extraListeners:  extraListeners,
+		dialer:          dialer,
+		serverUpgrader:  serverUpgrader,
+		clientUpgrader:  clientUpgrader,
+		vdrs:            vdrs,
+		beacons:         beacons,
+		router:          router,
+		drops:           dropLog,
 		// This field just makes sure we don't connect to ourselves when TLS is
 		// disabled. So, cryptographically secure random number generation isn't
 		// used here.
@@ -281,16 +448,44 @@ func NewNetwork(
 		gossipSize:                         gossipSize,
 		pingPongTimeout:                    pingPongTimeout,
 		pingFrequency:                      pingFrequency,
+		maxMissedPongs:                     maxMissedPongs,
 		disconnectedIPs:                    make(map[string]struct{}),
 		connectedIPs:                       make(map[string]struct{}),
 		retryDelay:                         make(map[string]time.Duration),
+		livenessBackoff:                    make(map[string]time.Duration),
 		myIPs:                              map[string]struct{}{ip.IP().String(): {}},
 		peers:                              make(map[[20]byte]*peer),
+		uptimes:                            make(map[[20]byte]*peerUptime),
 		readBufferSize:                     readBufferSize,
 		readHandshakeTimeout:               readHandshakeTimeout,
 		connMeter:                          NewConnMeter(connMeterResetDuration, connMeterCacheSize),
 		connMeterMaxConns:                  connMeterMaxConns,
+		subnetConnMeter:                    NewSubnetConnMeter(connMeterResetDuration, connMeterCacheSize),
+		connMeterMaxSubnetConns:            connMeterMaxSubnetConns,
+		handshakes:                         newHandshakeLimiter(maxPendingHandshakes, maxPendingHandshakesPerIP),
+		gate:                               gate,
+		peerStore:                          newPeerStore(peerDB),
+		compressionEnabled:                 compressionEnabled,
+		subnetVdrs:                         subnetVdrs,
+		maxPeers:                           maxPeers,
+		minSubnetPeers:                     minSubnetPeers,
+		idleGossipSize:                     idleGossipSize,
+		ipSigner:                           newStakingIPSigner(stakingCert),
+		requireSignedIPs:                   requireSignedIPs,
+		stateSyncEnabled:                   stateSyncEnabled,
+		gossiped:                           make(map[[32]byte]gossipedContainer),
+		vdrIPs:                             make(map[[20]byte]utils.IPDesc),
+		peerSendBandwidth:                  peerSendBandwidth,
+		sendBandwidth:                      newBandwidthLimiter(sendBandwidth),
 	}
+	for _, listener := range extraListeners {
+		if !listener.IP.IsZero() {
+			netw.myIPs[listener.IP.String()] = struct{}{}
+		}
+	}
+	netw.bans = newBanList(&netw.clock)
+	netw.frontiers = newFrontierCache(&netw.clock, frontierCacheTTL, defaultFrontierCacheSize)
+	netw.startTime = netw.clock.Time()
 	if err := netw.initialize(registerer); err != nil {
 		log.Warn("initializing network metrics failed with: %s", err)
 	}
@@ -300,7 +495,64 @@ func NewNetwork(
 	return netw
 }
 
-// GetAcceptedFrontier implements the Sender interface.
+// features returns the features this node reports in its capabilities message
+func (n *network) features() uint64 {
+	features := uint64(0)
+	if n.compressionEnabled {
+		features |= CompressionFeature
+	}
+	if n.ipSigner != nil {
+		features |= SignedIPsFeature
+	}
+	if n.stateSyncEnabled {
+		features |= StateSyncFeature
+	}
+	return features
+}
+
+// exchangesCapabilities returns true if this node and a peer running
+// [peerVersion] send each other capabilities messages during the handshake
+func (n *network) exchangesCapabilities(peerVersion version.Version) bool {
+	return n.featuresVersion != nil &&
+		peerVersion.App() == n.featuresVersion.App() &&
+		!peerVersion.Before(n.featuresVersion)
+}
+
+// newStakingIPSigner returns a signer that signs with the key of [cert], or nil
+// if this node doesn't sign the IP it claims
+func newStakingIPSigner(cert *tls.Certificate) *ipSigner {
+	if cert == nil || len(cert.Certificate) == 0 {
+		return nil
+	}
+	signer, ok := cert.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil
+	}
+	return newIPSigner(signer, cert.Certificate[0])
+}
+
+// verifyGossipedIP returns true if [ip], gossiped with the signature [sig],
+// should be tracked. A signed IP must be signed by a validator no later than
+// maxClockDifference from now. An unsigned IP is only tracked if signatures
+// aren't required.
+// assumes the stateLock is not held.
+func (n *network) verifyGossipedIP(ip utils.IPDesc, sig ipSignature) bool {
+	if !sig.signed() {
+		return !n.requireSignedIPs
+	}
+	if sig.Time > uint64(n.clock.Time().Add(n.maxClockDifference).Unix()) {
+		return false
+	}
+	nodeID, err := sig.verify(ip)
+	if err != nil {
+		return false
+	}
+	return n.vdrs.Contains(nodeID)
+}
+
+// GetAcceptedFrontier implements the Sender interface. Peers that recently
+// reported their accepted frontier aren't asked again; the frontier they
+// reported is routed as their response.
 // assumes the stateLock is not held.
 func (n *network) GetAcceptedFrontier(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time) {
 	msg, err := n.b.GetAcceptedFrontier(chainID, requestID, uint64(deadline.Sub(n.clock.Time())))
@@ -309,6 +561,11 @@ func (n *network) GetAcceptedFrontier(validatorIDs ids.ShortSet, chainID ids.ID,
 	for _, peerElement := range n.getPeers(validatorIDs) {
 		peer := peerElement.peer
 		vID := peerElement.id
+		if containerIDs, ok := n.frontiers.get(vID, chainID); ok {
+			n.executor.Add(func() { n.router.AcceptedFrontier(vID, chainID, requestID, containerIDs) })
+			n.frontierCacheHits.Inc()
+			continue
+		}
 		if peer == nil || !peer.connected.GetValue() || !peer.Send(msg) {
 			n.log.Debug("failed to send GetAcceptedFrontier(%s, %s, %d)",
 				vID,
@@ -588,6 +845,94 @@ func (n *network) Chits(validatorID ids.ShortID, chainID ids.ID, requestID uint3
 	}
 }
 
+// GetStateSummaryFrontier implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) GetStateSummaryFrontier(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time) {
+	msg, err := n.b.GetStateSummaryFrontier(chainID, requestID, uint64(deadline.Sub(n.clock.Time())))
+	n.log.AssertNoError(err)
+
+	for _, peerElement := range n.getPeers(validatorIDs) {
+		peer := peerElement.peer
+		vID := peerElement.id
+		if peer == nil || !peer.connected.GetValue() || !peer.Send(msg) {
+			n.log.Debug("failed to send GetStateSummaryFrontier(%s, %s, %d)",
+				vID,
+				chainID,
+				requestID)
+			n.executor.Add(func() { n.router.GetStateSummaryFrontierFailed(vID, chainID, requestID) })
+			n.getStateSummaryFrontier.numFailed.Inc()
+		} else {
+			n.getStateSummaryFrontier.numSent.Inc()
+		}
+	}
+}
+
+// StateSummaryFrontier implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) StateSummaryFrontier(validatorID ids.ShortID, chainID ids.ID, requestID uint32, summary []byte) {
+	msg, err := n.b.StateSummaryFrontier(chainID, requestID, summary)
+	if err != nil {
+		n.log.Error("failed to build StateSummaryFrontier message because of summary of size %d", len(summary))
+		return
+	}
+
+	peer := n.getPeer(validatorID)
+	if peer == nil || !peer.connected.GetValue() || !peer.Send(msg) {
+		n.log.Debug("failed to send StateSummaryFrontier(%s, %s, %d)",
+			validatorID,
+			chainID,
+			requestID)
+		n.stateSummaryFrontier.numFailed.Inc()
+	} else {
+		n.stateSummaryFrontier.numSent.Inc()
+	}
+}
+
+// GetStateChunk implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) GetStateChunk(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, chunkID ids.ID) {
+	msg, err := n.b.GetStateChunk(chainID, requestID, uint64(deadline.Sub(n.clock.Time())), chunkID)
+	if err != nil {
+		n.log.Error("failed to build GetStateChunk message: %s", err)
+		return
+	}
+
+	peer := n.getPeer(validatorID)
+	if peer == nil || !peer.connected.GetValue() || !peer.Send(msg) {
+		n.log.Debug("failed to send GetStateChunk(%s, %s, %d, %s)",
+			validatorID,
+			chainID,
+			requestID,
+			chunkID)
+		n.executor.Add(func() { n.router.GetStateChunkFailed(validatorID, chainID, requestID) })
+		n.getStateChunk.numFailed.Inc()
+	} else {
+		n.getStateChunk.numSent.Inc()
+	}
+}
+
+// StateChunk implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) StateChunk(validatorID ids.ShortID, chainID ids.ID, requestID uint32, chunk []byte) {
+	msg, err := n.b.StateChunk(chainID, requestID, chunk)
+	if err != nil {
+		n.log.Error("failed to build StateChunk message because of chunk of size %d", len(chunk))
+		return
+	}
+
+	peer := n.getPeer(validatorID)
+	if peer == nil || !peer.connected.GetValue() || !peer.Send(msg) {
+		n.log.Debug("failed to send StateChunk(%s, %s, %d, %d)",
+			validatorID,
+			chainID,
+			requestID,
+			len(chunk))
+		n.stateChunk.numFailed.Inc()
+	} else {
+		n.stateChunk.numSent.Inc()
+	}
+}
+
 // Gossip attempts to gossip the container to the network
 // assumes the stateLock is not held.
 func (n *network) Gossip(chainID, containerID ids.ID, container []byte) {
@@ -617,9 +962,27 @@ func (n *network) GetHeartbeat() int64 { return atomic.LoadInt64(&n.lastHeartbea
 // to this node.
 // assumes the stateLock is not held.
 func (n *network) Dispatch() error {
+	n.trackStoredPeers()
 	go n.gossip()
+	for _, listener := range n.extraListeners {
+		listener := listener
+		go func() {
+			err := n.serve(listener.Listener, listener.IP)
+			if !n.closed.GetValue() {
+				n.log.Error("stopped accepting connections on %s due to: %s", listener.Addr(), err)
+			}
+		}()
+	}
+	return n.serve(n.listener, utils.IPDesc{})
+}
+
+// serve accepts connections from other nodes on [listener], and tells them
+// that this node is at [myIP], unless it's the zero IP. Only returns once
+// [listener] fails.
+// assumes the stateLock is not held.
+func (n *network) serve(listener net.Listener, myIP utils.IPDesc) error {
 	for {
-		conn, err := n.listener.Accept()
+		conn, err := listener.Accept()
 		if err != nil {
 			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
 				// Sleep for a small amount of time to try to wait for the
@@ -640,20 +1003,57 @@ func (n *network) Dispatch() error {
 			}
 		}
 
+		if n.bans.ipBanned(remoteIP(conn)) {
+			n.log.Debug("connection from banned IP %s dropped", conn.RemoteAddr())
+			_ = conn.Close()
+			continue
+		}
+		if !n.gate.IPAllowed(remoteIP(conn)) {
+			n.log.Debug("connection from IP %s dropped because it isn't allowed", conn.RemoteAddr())
+			_ = conn.Close()
+			continue
+		}
+
 		addr := conn.RemoteAddr().String()
 		ticks, err := n.connMeter.Register(addr)
 		// looking for > n.connMeterMaxConns indicating the second tick
 		if err == nil && ticks > n.connMeterMaxConns {
 			n.log.Debug("connection from: %s temporarily dropped", addr)
 			_ = conn.Close()
+			n.inboundConnsThrottled.Inc()
 			continue
 		}
+		if n.connMeterMaxSubnetConns > 0 {
+			ticks, err := n.subnetConnMeter.Register(addr)
+			if err == nil && ticks > n.connMeterMaxSubnetConns {
+				n.log.Debug("connection from: %s temporarily dropped because of connections from its subnet", addr)
+				_ = conn.Close()
+				n.inboundConnsThrottled.Inc()
+				continue
+			}
+		}
+
+		ip := remoteIP(conn).String()
+		if !n.handshakes.acquire(ip) {
+			n.log.Debug("connection from: %s dropped due to too many pending handshakes", addr)
+			_ = conn.Close()
+			n.inboundHandshakesThrottled.Inc()
+			continue
+		}
+		n.pendingHandshakes.Inc()
 
 		go func() {
+			defer func() {
+				n.handshakes.release(ip)
+				n.pendingHandshakes.Dec()
+			}()
+
 			err := n.upgrade(
 				&peer{
 					net:          n,
 					conn:         conn,
+					inbound:      true,
+					myIP:         myIP,
 					tickerCloser: make(chan struct{}),
 				},
 				n.serverUpgrader,
@@ -675,12 +1075,15 @@ func (n *network) Peers() []PeerID {
 	for _, peer := range n.peers {
 		if peer.connected.GetValue() {
 			peers = append(peers, PeerID{
-				IP:           peer.conn.RemoteAddr().String(),
-				PublicIP:     peer.getIP().String(),
-				ID:           peer.id.PrefixedString(constants.NodeIDPrefix),
-				Version:      peer.versionStr.GetValue().(string),
-				LastSent:     time.Unix(atomic.LoadInt64(&peer.lastSent), 0),
-				LastReceived: time.Unix(atomic.LoadInt64(&peer.lastReceived), 0),
+				IP:             peer.conn.RemoteAddr().String(),
+				PublicIP:       peer.getIP().String(),
+				ID:             peer.id.PrefixedString(constants.NodeIDPrefix),
+				Version:        peer.versionStr.GetValue().(string),
+				LastSent:       time.Unix(atomic.LoadInt64(&peer.lastSent), 0),
+				LastReceived:   time.Unix(atomic.LoadInt64(&peer.lastReceived), 0),
+				ObservedUptime: json.Float32(n.observedUptime(peer.id)),
+				Features:       FeatureNames(atomic.LoadUint64(&peer.features)),
+				PingRTT:        json.Float32(float64(atomic.LoadInt64(&peer.pingRTT)) / float64(time.Millisecond)),
 			})
 		}
 	}
@@ -694,6 +1097,11 @@ func (n *network) Close() error {
 	if err != nil {
 		n.log.Debug("closing network listener failed with: %s", err)
 	}
+	for _, listener := range n.extraListeners {
+		if err := listener.Close(); err != nil {
+			n.log.Debug("closing network listener on %s failed with: %s", listener.Addr(), err)
+		}
+	}
 
 	if n.closed.GetValue() {
 		return nil
@@ -728,6 +1136,57 @@ func (n *network) Track(ip utils.IPDesc) {
 	n.track(ip)
 }
 
+// BanNodeID implements the Network interface
+// assumes the stateLock is not held.
+func (n *network) BanNodeID(nodeID ids.ShortID, duration time.Duration) {
+	n.bans.banNodeID(nodeID, duration)
+	n.log.Info("banned %s", nodeID.PrefixedString(constants.NodeIDPrefix))
+
+	if peer := n.getPeer(nodeID); peer != nil {
+		peer.Close() // Grabs the stateLock
+	}
+}
+
+// BanIP implements the Network interface
+// assumes the stateLock is not held.
+func (n *network) BanIP(ip net.IP, duration time.Duration) {
+	n.bans.banIP(ip, duration)
+	n.log.Info("banned %s", ip)
+
+	for _, peer := range n.getAllPeers() {
+		if ip.Equal(remoteIP(peer.conn)) || ip.Equal(peer.getIP().IP) {
+			peer.Close() // Grabs the stateLock
+		}
+	}
+}
+
+// UnbanNodeID implements the Network interface
+// assumes the stateLock is not held.
+func (n *network) UnbanNodeID(nodeID ids.ShortID) bool {
+	n.log.Info("unbanned %s", nodeID.PrefixedString(constants.NodeIDPrefix))
+	return n.bans.unbanNodeID(nodeID)
+}
+
+// UnbanIP implements the Network interface
+// assumes the stateLock is not held.
+func (n *network) UnbanIP(ip net.IP) bool {
+	n.log.Info("unbanned %s", ip)
+	return n.bans.unbanIP(ip)
+}
+
+// Bans implements the Network interface
+// assumes the stateLock is not held.
+func (n *network) Bans() []Ban { return n.bans.list() }
+
+// LastInboundConnection implements the Network interface
+func (n *network) LastInboundConnection() time.Time {
+	lastInbound := atomic.LoadInt64(&n.lastInbound)
+	if lastInbound == 0 {
+		return time.Time{}
+	}
+	return time.Unix(lastInbound, 0)
+}
+
 // assumes the stateLock is not held.
 func (n *network) gossipContainer(chainID, containerID ids.ID, container []byte) error {
 	msg, err := n.b.Put(chainID, constants.GossipMsgRequestID, containerID, container)
@@ -737,7 +1196,7 @@ func (n *network) gossipContainer(chainID, containerID ids.ID, container []byte)
 
 	allPeers := n.getAllPeers()
 
-	numToGossip := n.gossipSize
+	numToGossip := n.gossipFanout(chainID, containerID)
 	if numToGossip > len(allPeers) {
 		numToGossip = len(allPeers)
 	}
@@ -760,12 +1219,39 @@ func (n *network) gossipContainer(chainID, containerID ids.ID, container []byte)
 	return nil
 }
 
+// trackStoredPeers attempts to reconnect to the peers this node was connected
+// to before it restarted
+// assumes the stateLock is not held.
+func (n *network) trackStoredPeers() {
+	peers, err := n.peerStore.peers(n.clock.Time())
+	if err != nil {
+		n.log.Warn("failed to read stored peers due to: %s", err)
+		return
+	}
+
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	for _, peer := range peers {
+		if n.gate.NodeIDAllowed(peer.id) && !n.bans.nodeIDBanned(peer.id) {
+			n.track(peer.ip)
+		}
+	}
+	if len(peers) > 0 {
+		n.log.Info("reconnecting to %d stored peers", len(peers))
+	}
+}
+
 // assumes the stateLock is held.
 func (n *network) track(ip utils.IPDesc) {
 	if n.closed.GetValue() {
 		return
 	}
 
+	if n.bans.ipBanned(ip.IP) || !n.gate.IPAllowed(ip.IP) {
+		return
+	}
+
 	str := ip.String()
 	if _, ok := n.disconnectedIPs[str]; ok {
 		return
@@ -791,18 +1277,22 @@ func (n *network) gossip() {
 			return
 		}
 
+		n.connectSubnetPeers()
+
 		allPeers := n.getAllPeers()
 		if len(allPeers) == 0 {
 			continue
 		}
 
 		ips := make([]utils.IPDesc, 0, len(allPeers))
+		sigs := make([]ipSignature, 0, len(allPeers))
 		for _, peer := range allPeers {
-			ip := peer.getIP()
+			ip, sig := peer.getSignedIP()
 			if peer.connected.GetValue() &&
 				!ip.IsZero() &&
 				n.vdrs.Contains(peer.id) {
 				ips = append(ips, ip)
+				sigs = append(sigs, sig)
 			}
 		}
 
@@ -810,13 +1300,27 @@ func (n *network) gossip() {
 			n.log.Debug("skipping validator gossiping as no public validators are connected")
 			continue
 		}
-		msg, err := n.b.PeerList(ips)
+		unsignedMsg, err := n.b.PeerList(ips, nil)
 		if err != nil {
 			n.log.Error("failed to build peer list to gossip: %s. len(ips): %d",
 				err,
 				len(ips))
 			continue
 		}
+		signedMsg, err := n.b.PeerList(ips, sigs)
+		if err != nil {
+			n.log.Error("failed to build signed peer list to gossip: %s. len(ips): %d",
+				err,
+				len(ips))
+			continue
+		}
+		// peers that accept signatures are sent them with the IPs
+		msg := func(p *peer) Msg {
+			if p.supports(SignedIPsFeature) {
+				return signedMsg
+			}
+			return unsignedMsg
+		}
 
 		stakers := make([]*peer, 0, len(allPeers))
 		nonStakers := make([]*peer, 0, len(allPeers))
@@ -852,7 +1356,8 @@ func (n *network) gossip() {
 			continue
 		}
 		for _, index := range stakerIndices {
-			stakers[int(index)].Send(msg)
+			peer := stakers[int(index)]
+			peer.Send(msg(peer))
 		}
 
 		if err := s.Initialize(uint64(len(nonStakers))); err != nil {
@@ -869,7 +1374,8 @@ func (n *network) gossip() {
 			continue
 		}
 		for _, index := range nonStakerIndices {
-			nonStakers[int(index)].Send(msg)
+			peer := nonStakers[int(index)]
+			peer.Send(msg(peer))
 		}
 	}
 }
@@ -904,6 +1410,14 @@ func (n *network) connectTo(ip utils.IPDesc) {
 		_, isMyself := n.myIPs[str]
 		closed := n.closed
 
+		if isDisconnected && n.bans.ipBanned(ip.IP) {
+			// If the IP was banned, we should stop attempting to connect to
+			// it. It will be tracked again once it's unbanned and gossiped.
+			delete(n.disconnectedIPs, str)
+			delete(n.retryDelay, str)
+			isDisconnected = false
+		}
+
 		if !isDisconnected || isConnected || isMyself || closed.GetValue() {
 			// If the IP was discovered by the peer connecting to us, we don't
 			// need to attempt to connect anymore
@@ -976,20 +1490,36 @@ func (n *network) upgrade(p *peer, upgrader Upgrader) error {
 		return err
 	}
 
-	p.sender = make(chan []byte, n.sendQueueSize)
+	for i := range p.senders {
+		p.senders[i] = make(chan []byte, n.sendQueueSize)
+	}
+	p.bandwidth = newBandwidthLimiter(n.peerSendBandwidth)
 	p.id = id
 	p.conn = conn
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		if certs := tlsConn.ConnectionState().PeerCertificates; len(certs) > 0 {
+			p.cert = certs[0]
+		}
+	}
 
-	if err := n.tryAddPeer(p); err != nil {
+	evicted, err := n.tryAddPeer(p)
+	if err != nil {
 		_ = p.conn.Close()
 		n.log.Debug("dropping peer connection due to: %s", err)
 	}
+	if evicted != nil {
+		n.log.Debug("evicting peer %s to make room for %s, which has more stake",
+			evicted.id.PrefixedString(constants.NodeIDPrefix),
+			id.PrefixedString(constants.NodeIDPrefix))
+		evicted.Close()
+	}
 	return nil
 }
 
 // assumes the stateLock is not held. Returns an error if the peer couldn't be
-// added.
-func (n *network) tryAddPeer(p *peer) error {
+// added. If the peer was added in place of a peer with less stake, returns the
+// peer that should be evicted.
+func (n *network) tryAddPeer(p *peer) (*peer, error) {
 	n.stateLock.Lock()
 	defer n.stateLock.Unlock()
 
@@ -1000,7 +1530,7 @@ func (n *network) tryAddPeer(p *peer) error {
 	if n.closed.GetValue() {
 		// the network is closing, so make sure that no further reconnect
 		// attempts are made.
-		return errNetworkClosed
+		return nil, errNetworkClosed
 	}
 
 	// if this connection is myself, then I should delete the connection and
@@ -1018,7 +1548,29 @@ func (n *network) tryAddPeer(p *peer) error {
 			delete(n.retryDelay, str)
 			n.myIPs[str] = struct{}{}
 		}
-		return errPeerIsMyself
+		return nil, errPeerIsMyself
+	}
+
+	// If this peer is banned, then I should close this new connection and stop
+	// attempting to connect to it.
+	if n.bans.nodeIDBanned(p.id) || n.bans.ipBanned(remoteIP(p.conn)) {
+		if !ip.IsZero() {
+			str := ip.String()
+			delete(n.disconnectedIPs, str)
+			delete(n.retryDelay, str)
+		}
+		return nil, errPeerBanned
+	}
+
+	// If this peer isn't allowed, then I should close this new connection and
+	// stop attempting to connect to it.
+	if !n.gate.NodeIDAllowed(p.id) || !n.gate.IPAllowed(remoteIP(p.conn)) {
+		if !ip.IsZero() {
+			str := ip.String()
+			delete(n.disconnectedIPs, str)
+			delete(n.retryDelay, str)
+		}
+		return nil, errPeerNotAllowed
 	}
 
 	// If I am already connected to this peer, then I should close this new
@@ -1029,30 +1581,52 @@ func (n *network) tryAddPeer(p *peer) error {
 			delete(n.disconnectedIPs, str)
 			delete(n.retryDelay, str)
 		}
-		return fmt.Errorf("duplicated connection from %s at %s", p.id.PrefixedString(constants.NodeIDPrefix), ip)
+		return nil, fmt.Errorf("duplicated connection from %s at %s", p.id.PrefixedString(constants.NodeIDPrefix), ip)
+	}
+
+	// If I am connected to as many peers as I may be, then I should only keep
+	// this connection if the peer has more stake than a peer I'm connected to.
+	var evicted *peer
+	if n.maxPeers > 0 && len(n.peers) >= n.maxPeers {
+		evicted = n.evictionCandidate(p.id)
+		if evicted == nil {
+			n.peersRejected.Inc()
+			// stop attempting to connect to this peer. It will be tracked
+			// again once it's gossiped.
+			if !ip.IsZero() {
+				str := ip.String()
+				delete(n.disconnectedIPs, str)
+				delete(n.retryDelay, str)
+			}
+			return nil, errTooManyPeers
+		}
+		n.peersEvicted.Inc()
 	}
 
 	n.peers[key] = p
 	n.numPeers.Set(float64(len(n.peers)))
 	p.Start()
-	return nil
+	return evicted, nil
 }
 
 // assumes the stateLock is not held. Returns the ips of connections that have
-// valid IPs that are marked as validators.
-func (n *network) validatorIPs() []utils.IPDesc {
+// valid IPs that are marked as validators, along with the peers' signatures of
+// them.
+func (n *network) validatorIPs() ([]utils.IPDesc, []ipSignature) {
 	n.stateLock.RLock()
 	defer n.stateLock.RUnlock()
 	ips := make([]utils.IPDesc, 0, len(n.peers))
+	sigs := make([]ipSignature, 0, len(n.peers))
 	for _, peer := range n.peers {
-		ip := peer.getIP()
+		ip, sig := peer.getSignedIP()
 		if peer.connected.GetValue() &&
 			!ip.IsZero() &&
 			n.vdrs.Contains(peer.id) {
 			ips = append(ips, ip)
+			sigs = append(sigs, sig)
 		}
 	}
-	return ips
+	return ips, sigs
 }
 
 // should only be called after the peer is marked as connected. Should not be
@@ -1064,6 +1638,7 @@ func (n *network) connected(p *peer) {
 
 	ip := p.getIP()
 	n.log.Debug("connected to %s at %s", p.id, ip)
+	atomic.AddUint64(&n.peerChanges, 1)
 
 	if !ip.IsZero() {
 		str := ip.String()
@@ -1071,6 +1646,28 @@ func (n *network) connected(p *peer) {
 		delete(n.disconnectedIPs, str)
 		delete(n.retryDelay, str)
 		n.connectedIPs[str] = struct{}{}
+		if n.vdrs.Contains(p.id) {
+			n.vdrIPs[p.id.Key()] = ip
+		}
+
+		if err := n.peerStore.put(p.id, ip, n.clock.Time()); err != nil {
+			n.log.Warn("failed to store peer %s due to: %s", p.id, err)
+		}
+	}
+
+	key := p.id.Key()
+	uptime, ok := n.uptimes[key]
+	if !ok {
+		uptime = &peerUptime{}
+		n.uptimes[key] = uptime
+	}
+	uptime.connectedSince = n.clock.Time()
+
+	// a peer at a public IP connecting to this node shows that it's reachable
+	if p.inbound {
+		if ip := remoteIP(p.conn); ip != nil && !(utils.IPDesc{IP: ip}).IsPrivate() {
+			atomic.StoreInt64(&n.lastInbound, n.clock.Time().Unix())
+		}
 	}
 
 	n.router.Connected(p.id)
@@ -1096,14 +1693,54 @@ func (n *network) disconnected(p *peer) {
 		delete(n.disconnectedIPs, str)
 		delete(n.connectedIPs, str)
 
+		// a peer that stopped answering pings may be behind a path that
+		// silently drops messages, so reconnecting to it is backed off
+		if p.unresponsive.GetValue() {
+			n.retryDelay[str] = n.nextLivenessBackoff(str)
+		}
+
 		n.track(ip)
 	}
 
 	if p.connected.GetValue() {
+		atomic.AddUint64(&n.peerChanges, 1)
+		if !ip.IsZero() {
+			if err := n.peerStore.put(p.id, ip, n.clock.Time()); err != nil {
+				n.log.Warn("failed to store peer %s due to: %s", p.id, err)
+			}
+		}
+		if uptime, ok := n.uptimes[key]; ok {
+			if n.vdrs.Contains(p.id) {
+				uptime.upDuration += n.clock.Time().Sub(uptime.connectedSince)
+				uptime.connectedSince = time.Time{}
+			} else {
+				delete(n.uptimes, key)
+			}
+		}
 		n.router.Disconnected(p.id)
 	}
 }
 
+// observedUptime returns the portion, in [0, 1], of the time since this
+// network was created that the peer [peerID] has been connected.
+// assumes the stateLock is held.
+func (n *network) observedUptime(peerID ids.ShortID) float64 {
+	uptime, ok := n.uptimes[peerID.Key()]
+	if !ok {
+		return 0
+	}
+	now := n.clock.Time()
+	upDuration := uptime.upDuration
+	if !uptime.connectedSince.IsZero() {
+		upDuration += now.Sub(uptime.connectedSince)
+	}
+	totalDuration := now.Sub(n.startTime)
+	if totalDuration <= 0 {
+		return 1
+	}
+	return float64(upDuration) / float64(totalDuration)
+}
+
 // holds onto the peer object as a result of helper functions
 type PeerElement struct {
 	// the peer, if it wasn't a peer when we cloned the list this value will be
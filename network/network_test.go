@@ -197,6 +197,7 @@ func TestNewDefaultNetwork(t *testing.T) {
 		networkID,
 		appVersion,
 		versionParser,
+		appVersion,
 		listener,
 		caller,
 		serverUpgrader,
@@ -204,8 +205,25 @@ func TestNewDefaultNetwork(t *testing.T) {
 		vdrs,
 		vdrs,
 		handler,
+		nil,
 		time.Duration(0),
 		0,
+		false,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		0,
+		0,
+		nil,
+		nil,
+		0,
+		0,
+		nil,
+		false,
+		false,
+		nil,
 	)
 	assert.NotNil(t, net)
 
@@ -305,6 +323,7 @@ func TestEstablishConnection(t *testing.T) {
 		networkID,
 		appVersion,
 		versionParser,
+		appVersion,
 		listener0,
 		caller0,
 		serverUpgrader,
@@ -312,8 +331,25 @@ func TestEstablishConnection(t *testing.T) {
 		vdrs,
 		vdrs,
 		handler0,
+		nil,
 		time.Duration(0),
 		0,
+		false,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		0,
+		0,
+		nil,
+		nil,
+		0,
+		0,
+		nil,
+		false,
+		false,
+		nil,
 	)
 	assert.NotNil(t, net0)
 
@@ -325,6 +361,7 @@ func TestEstablishConnection(t *testing.T) {
 		networkID,
 		appVersion,
 		versionParser,
+		appVersion,
 		listener1,
 		caller1,
 		serverUpgrader,
@@ -332,8 +369,25 @@ func TestEstablishConnection(t *testing.T) {
 		vdrs,
 		vdrs,
 		handler1,
+		nil,
 		time.Duration(0),
 		0,
+		false,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		0,
+		0,
+		nil,
+		nil,
+		0,
+		0,
+		nil,
+		false,
+		false,
+		nil,
 	)
 	assert.NotNil(t, net1)
 
@@ -445,6 +499,7 @@ func TestDoubleTrack(t *testing.T) {
 		networkID,
 		appVersion,
 		versionParser,
+		appVersion,
 		listener0,
 		caller0,
 		serverUpgrader,
@@ -452,8 +507,25 @@ func TestDoubleTrack(t *testing.T) {
 		vdrs,
 		vdrs,
 		handler0,
+		nil,
 		time.Duration(0),
 		0,
+		false,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		0,
+		0,
+		nil,
+		nil,
+		0,
+		0,
+		nil,
+		false,
+		false,
+		nil,
 	)
 	assert.NotNil(t, net0)
 
@@ -465,6 +537,7 @@ func TestDoubleTrack(t *testing.T) {
 		networkID,
 		appVersion,
 		versionParser,
+		appVersion,
 		listener1,
 		caller1,
 		serverUpgrader,
@@ -472,8 +545,25 @@ func TestDoubleTrack(t *testing.T) {
 		vdrs,
 		vdrs,
 		handler1,
+		nil,
 		time.Duration(0),
 		0,
+		false,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		0,
+		0,
+		nil,
+		nil,
+		0,
+		0,
+		nil,
+		false,
+		false,
+		nil,
 	)
 	assert.NotNil(t, net1)
 
@@ -586,6 +676,7 @@ func TestDoubleClose(t *testing.T) {
 		networkID,
 		appVersion,
 		versionParser,
+		appVersion,
 		listener0,
 		caller0,
 		serverUpgrader,
@@ -593,8 +684,25 @@ func TestDoubleClose(t *testing.T) {
 		vdrs,
 		vdrs,
 		handler0,
+		nil,
 		time.Duration(0),
 		0,
+		false,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		0,
+		0,
+		nil,
+		nil,
+		0,
+		0,
+		nil,
+		false,
+		false,
+		nil,
 	)
 	assert.NotNil(t, net0)
 
@@ -606,6 +714,7 @@ func TestDoubleClose(t *testing.T) {
 		networkID,
 		appVersion,
 		versionParser,
+		appVersion,
 		listener1,
 		caller1,
 		serverUpgrader,
@@ -613,8 +722,25 @@ func TestDoubleClose(t *testing.T) {
 		vdrs,
 		vdrs,
 		handler1,
+		nil,
 		time.Duration(0),
 		0,
+		false,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		0,
+		0,
+		nil,
+		nil,
+		0,
+		0,
+		nil,
+		false,
+		false,
+		nil,
 	)
 	assert.NotNil(t, net1)
 
@@ -732,6 +858,7 @@ func TestTrackConnected(t *testing.T) {
 		networkID,
 		appVersion,
 		versionParser,
+		appVersion,
 		listener0,
 		caller0,
 		serverUpgrader,
@@ -739,8 +866,25 @@ func TestTrackConnected(t *testing.T) {
 		vdrs,
 		vdrs,
 		handler0,
+		nil,
 		time.Duration(0),
 		0,
+		false,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		0,
+		0,
+		nil,
+		nil,
+		0,
+		0,
+		nil,
+		false,
+		false,
+		nil,
 	)
 	assert.NotNil(t, net0)
 
@@ -752,6 +896,7 @@ func TestTrackConnected(t *testing.T) {
 		networkID,
 		appVersion,
 		versionParser,
+		appVersion,
 		listener1,
 		caller1,
 		serverUpgrader,
@@ -759,8 +904,25 @@ func TestTrackConnected(t *testing.T) {
 		vdrs,
 		vdrs,
 		handler1,
+		nil,
 		time.Duration(0),
 		0,
+		false,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		0,
+		0,
+		nil,
+		nil,
+		0,
+		0,
+		nil,
+		false,
+		false,
+		nil,
 	)
 	assert.NotNil(t, net1)
 
@@ -852,6 +1014,7 @@ func TestTrackConnectedRace(t *testing.T) {
 		networkID,
 		appVersion,
 		versionParser,
+		appVersion,
 		listener0,
 		caller0,
 		serverUpgrader,
@@ -859,8 +1022,25 @@ func TestTrackConnectedRace(t *testing.T) {
 		vdrs,
 		vdrs,
 		handler,
+		nil,
 		time.Duration(0),
 		0,
+		false,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		0,
+		0,
+		nil,
+		nil,
+		0,
+		0,
+		nil,
+		false,
+		false,
+		nil,
 	)
 	assert.NotNil(t, net0)
 
@@ -872,6 +1052,7 @@ func TestTrackConnectedRace(t *testing.T) {
 		networkID,
 		appVersion,
 		versionParser,
+		appVersion,
 		listener1,
 		caller1,
 		serverUpgrader,
@@ -879,8 +1060,25 @@ func TestTrackConnectedRace(t *testing.T) {
 		vdrs,
 		vdrs,
 		handler,
+		nil,
 		time.Duration(0),
 		0,
+		false,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		0,
+		0,
+		nil,
+		nil,
+		0,
+		0,
+		nil,
+		false,
+		false,
+		nil,
 	)
 	assert.NotNil(t, net1)
 
@@ -901,3 +1099,24 @@ func TestTrackConnectedRace(t *testing.T) {
 	err = net1.Close()
 	assert.NoError(t, err)
 }
+
+func TestObservedUptime(t *testing.T) {
+	startTime := time.Unix(1000, 0)
+	n := &network{
+		startTime: startTime,
+		uptimes:   make(map[[20]byte]*peerUptime),
+	}
+	id := ids.NewShortID([20]byte{1})
+	n.uptimes[id.Key()] = &peerUptime{
+		connectedSince: startTime.Add(50 * time.Second),
+		upDuration:     25 * time.Second,
+	}
+
+	n.clock.Set(startTime.Add(100 * time.Second))
+	assert.Equal(t, 0.75, n.observedUptime(id))
+
+	n.uptimes[id.Key()].connectedSince = time.Time{}
+	assert.Equal(t, 0.25, n.observedUptime(id))
+
+	assert.Equal(t, float64(0), n.observedUptime(ids.NewShortID([20]byte{2})))
+}
@@ -4,6 +4,7 @@
 package network
 
 import (
+	"crypto/x509"
 	"math"
 	"net"
 	"sync"
@@ -11,9 +12,11 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/drops"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/version"
 )
 
 type peer struct {
@@ -42,14 +45,19 @@ type peer struct {
 
 	// lock to ensure that closing of the sender queue is handled safely
 	senderLock sync.Mutex
-	// queue of messages this connection is attempting to send the peer. Is
-	// closed when the connection is closed.
-	sender chan []byte
+	// queues of messages this connection is attempting to send the peer, by
+	// priority. Are closed when the connection is closed.
+	senders [numPriorities]chan []byte
+
+	// limits the rate bytes are sent to the peer at
+	bandwidth *bandwidthLimiter
 
 	// ip may or may not be set when the peer is first started. is only modified
 	// on the connection's reader routine.
 	ip     utils.IPDesc
 	ipLock sync.RWMutex
+	// the peer's signature of [ip], if it signed it
+	ipSig ipSignature
 
 	// id should be set when the peer is first created.
 	id ids.ShortID
@@ -57,12 +65,46 @@ type peer struct {
 	// the connection object that is used to read/write messages from
 	conn net.Conn
 
+	// true if the peer connected to this node, rather than this node to it
+	inbound bool
+
+	// the IP this node claims to the peer, if the peer connected through a
+	// listener with its own advertised IP. Otherwise, the zero IP.
+	myIP utils.IPDesc
+
 	// version that the peer reported during the handshake
 	versionStr utils.AtomicInterface
 
+	// the version and IP the peer reported, once its version message is valid,
+	// while waiting for its capabilities message. is only modified on the
+	// connection's reader routine.
+	peerVersion version.Version
+	claimedIP   utils.IPDesc
+	// a capabilities message that arrived before the version message. is only
+	// modified on the connection's reader routine.
+	pendingCapabilities Msg
+
+	// features both this node and the peer reported during the handshake.
+	// is only modified on the connection's reader routine.
+	features uint64
+
+	// the peer's staking certificate, if the connection is over TLS
+	cert *x509.Certificate
+
 	// unix time of the last message sent and received respectively
 	lastSent, lastReceived int64
 
+	// unix nanoseconds the unanswered ping was sent at, or 0 if every ping was
+	// answered
+	pingSent int64
+	// number of pings in a row that were and weren't answered before the next
+	// ping was sent
+	answeredPongs, missedPongs uint32
+	// smoothed time, in nanoseconds, pings take to be answered
+	pingRTT int64
+	// if the connection was closed because it stopped answering pings
+	unresponsive utils.AtomicBool
+
 	tickerCloser chan struct{}
 
 	// ticker processes
@@ -93,6 +135,15 @@ func (p *peer) sendPings() {
 				return
 			}
 
+			if !p.probe() {
+				p.net.log.Debug("closing the connection to %s, which didn't answer %d pings in a row",
+					p.id,
+					p.net.maxMissedPongs)
+				p.net.unresponsivePeers.Inc()
+				p.unresponsive.SetValue(true)
+				p.Close()
+				return
+			}
 			p.Ping()
 		case <-p.tickerCloser:
 			return
@@ -183,6 +234,19 @@ func (p *peer) ReadMessages() {
 			return
 		}
 
+		if isCompressed(msgBytes) {
+			if !p.net.compressionEnabled {
+				p.net.log.Verbo("error reading compressed message from %s, which this node doesn't accept", p.id)
+				return
+			}
+			// check the size of the message before decompressing it, so that
+			// a small message can't decompress into a huge one
+			if size, err := decompressedLen(msgBytes); err != nil || int64(size) > p.net.maxMessageSize {
+				p.net.log.Verbo("error reading compressed message from %s that's too large or malformed", p.id)
+				return
+			}
+		}
+
 		p.net.log.Verbo("parsing new message from %s:\n%s",
 			p.id,
 			formatting.DumpBytes{Bytes: msgBytes})
@@ -196,6 +260,10 @@ func (p *peer) ReadMessages() {
 			return
 		}
 
+		if msgMetrics := p.net.message(msg.Op()); msgMetrics != nil {
+			msgMetrics.bytesReceived.WithLabelValues(p.peerType()).Add(float64(len(msgBytes) + wrappers.IntLen))
+		}
+
 		p.handle(msg)
 	}
 }
@@ -206,7 +274,16 @@ func (p *peer) WriteMessages() {
 
 	p.Version()
 
-	for msg := range p.sender {
+	for {
+		msg, ok := p.nextMessage()
+		if !ok {
+			return
+		}
+		msgMetrics := p.net.message(Op(msg[0] &^ compressedFlag))
+		if msgMetrics != nil {
+			msgMetrics.queued.Dec()
+		}
+
 		p.net.log.Verbo("sending new message to %s:\n%s",
 			p.id,
 			formatting.DumpBytes{Bytes: msg})
@@ -217,8 +294,18 @@ func (p *peer) WriteMessages() {
 		packer := wrappers.Packer{Bytes: make([]byte, len(msg)+wrappers.IntLen)}
 		packer.PackBytes(msg)
 		msg = packer.Bytes
+		msgLen := len(msg)
 		for len(msg) > 0 {
-			written, err := p.conn.Write(msg)
+			chunk := msg
+			if p.throttled() {
+				if len(chunk) > bandwidthQuantum {
+					chunk = chunk[:bandwidthQuantum]
+				}
+				if !p.throttle(len(chunk)) {
+					return
+				}
+			}
+			written, err := p.conn.Write(chunk)
 			if err != nil {
 				p.net.log.Verbo("error writing to %s at %s due to: %s", p.id, p.getIP(), err)
 				return
@@ -227,6 +314,57 @@ func (p *peer) WriteMessages() {
 			msg = msg[written:]
 		}
 		atomic.StoreInt64(&p.lastSent, p.net.clock.Time().Unix())
+		if msgMetrics != nil {
+			msgMetrics.bytesSent.WithLabelValues(p.peerType()).Add(float64(msgLen))
+		}
+	}
+}
+
+// nextMessage returns the next message to send the peer, from the queue with
+// the highest priority that isn't empty, waiting for one if every queue is
+// empty. Returns false once every queue is closed and drained.
+func (p *peer) nextMessage() ([]byte, bool) {
+	// A closed queue is replaced with nil, which is never ready, so that the
+	// messages still queued with a lower priority are sent
+	senders := p.senders
+	for {
+		closed := 0
+		for i, sender := range senders {
+			if sender == nil {
+				closed++
+				continue
+			}
+			select {
+			case msg, ok := <-sender:
+				if ok {
+					return msg, true
+				}
+				senders[i] = nil
+				closed++
+			default:
+			}
+		}
+		if closed == len(senders) {
+			return nil, false
+		}
+
+		var (
+			msg  []byte
+			ok   bool
+			from priority
+		)
+		select {
+		case msg, ok = <-senders[highPriority]:
+			from = highPriority
+		case msg, ok = <-senders[normalPriority]:
+			from = normalPriority
+		case msg, ok = <-senders[lowPriority]:
+			from = lowPriority
+		}
+		if ok {
+			return msg, true
+		}
+		senders[from] = nil
 	}
 }
 
@@ -239,16 +377,32 @@ func (p *peer) Send(msg Msg) bool {
 	// unable to send this message without panicking. So drop the message.
 	if p.closed.GetValue() {
 		p.net.log.Debug("dropping message to %s due to a closed connection", p.id)
+		p.dropped(msg.Op(), drops.Closed)
 		return false
 	}
 
 	// is it possible to send?
 	if dropMsg := p.dropMessagePeer(); dropMsg {
 		p.net.log.Debug("dropping message to %s due to a send queue with too many bytes", p.id)
+		p.dropped(msg.Op(), drops.Throttled)
+		return false
+	}
+
+	// the peer can't parse messages with ops it didn't report supporting
+	if !p.supports(requiredFeature(msg.Op())) {
+		p.net.log.Debug("dropping message to %s because it doesn't support %s", p.id, msg.Op())
+		p.dropped(msg.Op(), drops.Unsupported)
 		return false
 	}
 
 	msgBytes := msg.Bytes()
+	if p.supports(CompressionFeature) {
+		if compressed := msg.CompressedBytes(); compressed != nil {
+			p.net.compressionRatio.Observe(float64(len(compressed)) / float64(len(msgBytes)))
+			p.net.compressionSavedBytes.Add(float64(len(msgBytes) - len(compressed)))
+			msgBytes = compressed
+		}
+	}
 	msgBytesLen := int64(len(msgBytes))
 
 	// lets assume send will be successful, we add to the network pending bytes
@@ -260,17 +414,23 @@ func (p *peer) Send(msg Msg) bool {
 		// we never sent the message, remove from pending totals
 		atomic.AddInt64(&p.net.pendingBytes, -msgBytesLen)
 		p.net.log.Debug("dropping message to %s due to a send queue with too many bytes", p.id)
+		p.dropped(msg.Op(), drops.Throttled)
 		return false
 	}
 
 	select {
-	case p.sender <- msgBytes:
+	case p.senders[priorityOf(msg.Op())] <- msgBytes:
 		atomic.AddInt64(&p.pendingBytes, msgBytesLen)
+		if msgMetrics := p.net.message(msg.Op()); msgMetrics != nil {
+			msgMetrics.queued.Inc()
+		}
+		p.net.recordCapture(false, p.id, msg)
 		return true
 	default:
 		// we never sent the message, remove from pending totals
 		atomic.AddInt64(&p.net.pendingBytes, -msgBytesLen)
 		p.net.log.Debug("dropping message to %s due to a full send queue", p.id)
+		p.dropped(msg.Op(), drops.QueueFull)
 		return false
 	}
 }
@@ -292,14 +452,30 @@ func (p *peer) handle(msg Msg) {
 	msgMetrics := p.net.message(op)
 	if msgMetrics == nil {
 		p.net.log.Debug("dropping an unknown message from %s with op %s", p.id, op.String())
+		p.dropped(op, drops.UnknownOp)
 		return
 	}
 	msgMetrics.numReceived.Inc()
+	p.net.recordCapture(true, p.id, msg)
+
+	if !p.supports(requiredFeature(op)) {
+		p.net.log.Debug("dropping message from %s with op %s, which wasn't negotiated", p.id, op.String())
+		p.dropped(op, drops.Unsupported)
+		return
+	}
+
+	start := p.net.clock.Time()
+	defer func() {
+		msgMetrics.handleDuration.WithLabelValues(p.peerType()).Observe(p.net.clock.Time().Sub(start).Seconds())
+	}()
 
 	switch op {
 	case Version:
 		p.version(msg)
 		return
+	case Capabilities:
+		p.capabilities(msg)
+		return
 	case GetVersion:
 		p.getVersion(msg)
 		return
@@ -318,6 +494,7 @@ func (p *peer) handle(msg Msg) {
 	}
 	if !p.connected.GetValue() {
 		p.net.log.Debug("dropping message from %s because the connection hasn't been established yet", p.id)
+		p.dropped(op, drops.NotConnected)
 
 		// attempt to finish the handshake
 		if !p.gotVersion.GetValue() {
@@ -328,7 +505,13 @@ func (p *peer) handle(msg Msg) {
 		}
 		return
 	}
-	switch op {
+	p.route(msg)
+}
+
+// route passes the consensus message [msg] to the router
+// assumes the stateLock is not held
+func (p *peer) route(msg Msg) {
+	switch op := msg.Op(); op {
 	case GetAcceptedFrontier:
 		p.getAcceptedFrontier(msg)
 	case AcceptedFrontier:
@@ -351,8 +534,55 @@ func (p *peer) handle(msg Msg) {
 		p.pullQuery(msg)
 	case Chits:
 		p.chits(msg)
+	case GetStateSummaryFrontier:
+		p.getStateSummaryFrontier(msg)
+	case StateSummaryFrontier:
+		p.stateSummaryFrontier(msg)
+	case GetStateChunk:
+		p.getStateChunk(msg)
+	case StateChunk:
+		p.stateChunk(msg)
 	default:
 		p.net.log.Debug("dropping an unknown message from %s with op %s", p.id, op.String())
+		p.dropped(op, drops.UnknownOp)
+	}
+}
+
+// supports returns true if [features] were negotiated with the peer
+func (p *peer) supports(features uint64) bool {
+	return atomic.LoadUint64(&p.features)&features == features
+}
+
+// throttled returns true if the rate bytes are sent to the peer at is limited
+func (p *peer) throttled() bool {
+	return p.bandwidth.rate != 0 || p.net.sendBandwidth.rate != 0
+}
+
+// throttle waits until [n] bytes may be sent to the peer. The peer's own limit
+// is waited out before the bandwidth shared with the other peers is reserved,
+// so that shared bandwidth isn't reserved by a peer that can't use it yet.
+// Returns false if the peer was closed while waiting.
+func (p *peer) throttle(n int) bool {
+	return p.wait(p.bandwidth.reserve(p.net.clock.Time(), n)) &&
+		p.wait(p.net.sendBandwidth.reserve(p.net.clock.Time(), n))
+}
+
+// wait for [delay], unless the peer is closed first. Returns false if the peer
+// was closed.
+func (p *peer) wait(delay time.Duration) bool {
+	if delay <= 0 {
+		return true
+	}
+	p.net.sendThrottled.Add(delay.Seconds())
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return true
+	case <-p.tickerCloser:
+		return false
 	}
 }
 
@@ -367,6 +597,20 @@ func (p *peer) dropMessage(connPendingLen, networkPendingLen int64) bool {
 			connPendingLen > p.net.maxNetworkPendingSendBytes/20) // Check to see if this connection is using too much memory
 }
 
+// peerType returns the peer type label of the peer's message metrics
+func (p *peer) peerType() string {
+	if p.net.vdrs.Contains(p.id) {
+		return validatorPeer
+	}
+	return nonValidatorPeer
+}
+
+// dropped records that a message with [op] to or from this peer was dropped
+// because of [reason]
+func (p *peer) dropped(op Op, reason drops.Reason) {
+	p.net.drops.Record(p.id, op.String(), ids.ID{}, reason)
+}
+
 // assumes the stateLock is not held
 func (p *peer) Close() { p.once.Do(p.close) }
 
@@ -384,10 +628,24 @@ func (p *peer) close() {
 
 	p.senderLock.Lock()
 	// The locks guarantee here that the sender routine will read that the peer
-	// has been closed and will therefore not attempt to write on this channel.
-	close(p.sender)
+	// has been closed and will therefore not attempt to write on these
+	// channels.
+	for _, sender := range p.senders {
+		close(sender)
+	}
 	p.senderLock.Unlock()
 
+	// messages left in the queues are never sent
+	for _, sender := range p.senders {
+		for msg := range sender {
+			atomic.AddInt64(&p.pendingBytes, -int64(len(msg)))
+			atomic.AddInt64(&p.net.pendingBytes, -int64(len(msg)))
+			if msgMetrics := p.net.message(Op(msg[0] &^ compressedFlag)); msgMetrics != nil {
+				msgMetrics.queued.Dec()
+			}
+		}
+	}
+
 	p.net.disconnected(p)
 }
 
@@ -405,7 +663,7 @@ func (p *peer) Version() {
 		p.net.networkID,
 		p.net.nodeID,
 		p.net.clock.Unix(),
-		p.net.ip.IP(),
+		p.advertisedIP(),
 		p.net.version.String(),
 	)
 	p.net.stateLock.RUnlock()
@@ -413,6 +671,38 @@ func (p *peer) Version() {
 	p.Send(msg)
 }
 
+// Capabilities sends the features this node supports, and its signature of
+// the IP it claims if it signs it. Only peers whose version supports it may
+// be sent this message.
+// assumes the stateLock is not held
+func (p *peer) Capabilities() {
+	var (
+		sigTime uint64
+		sig     []byte
+	)
+	if p.net.ipSigner != nil {
+		ipSig, err := p.net.ipSigner.sign(p.advertisedIP(), p.net.clock.Unix())
+		if err != nil {
+			p.net.log.Warn("failed to sign IP due to %s", err)
+		} else {
+			sigTime = ipSig.Time
+			sig = ipSig.Sig
+		}
+	}
+
+	msg, err := p.net.b.Capabilities(p.net.features(), sigTime, sig)
+	p.net.log.AssertNoError(err)
+	p.Send(msg)
+}
+
+// advertisedIP returns the IP this node claims to the peer
+func (p *peer) advertisedIP() utils.IPDesc {
+	if !p.myIP.IsZero() {
+		return p.myIP
+	}
+	return p.net.ip.IP()
+}
+
 // assumes the stateLock is not held
 func (p *peer) GetPeerList() {
 	msg, err := p.net.b.GetPeerList()
@@ -422,13 +712,18 @@ func (p *peer) GetPeerList() {
 
 // assumes the stateLock is not held
 func (p *peer) SendPeerList() {
-	ips := p.net.validatorIPs()
-	p.PeerList(ips)
+	ips, sigs := p.net.validatorIPs()
+	p.PeerList(ips, sigs)
 }
 
+// PeerList sends [peers] to the peer, along with their signatures [sigs] if
+// the peer accepts them
 // assumes the stateLock is not held
-func (p *peer) PeerList(peers []utils.IPDesc) {
-	msg, err := p.net.b.PeerList(peers)
+func (p *peer) PeerList(peers []utils.IPDesc, sigs []ipSignature) {
+	if !p.supports(SignedIPsFeature) {
+		sigs = nil
+	}
+	msg, err := p.net.b.PeerList(peers, sigs)
 	if err != nil {
 		p.net.log.Warn("failed to send PeerList message due to %s", err)
 		return
@@ -459,11 +754,17 @@ func (p *peer) Pong() {
 }
 
 // assumes the stateLock is not held
-func (p *peer) getVersion(_ Msg) { p.Version() }
+func (p *peer) getVersion(_ Msg) {
+	p.Version()
+	if p.peerVersion != nil && p.net.exchangesCapabilities(p.peerVersion) {
+		// the peer may still be waiting for this node's capabilities
+		p.Capabilities()
+	}
+}
 
 // assumes the stateLock is not held
 func (p *peer) version(msg Msg) {
-	if p.gotVersion.GetValue() {
+	if p.gotVersion.GetValue() || p.peerVersion != nil {
 		p.net.log.Verbo("dropping duplicated version message from %s", p.id)
 		return
 	}
@@ -527,6 +828,7 @@ func (p *peer) version(msg Msg) {
 		p.net.log.Debug("peer version not compatible due to %s", err)
 
 		if !p.net.beacons.Contains(p.id) {
+			p.dropped(Version, drops.VersionTooOld)
 			p.discardIP()
 			return
 		}
@@ -552,6 +854,67 @@ func (p *peer) version(msg Msg) {
 		}
 	}
 
+	if !p.net.exchangesCapabilities(peerVersion) {
+		// older peers can't parse capabilities messages, so no features are
+		// used with them
+		p.finishVersion(peerVersion, 0)
+		return
+	}
+
+	// the handshake finishes once the peer's capabilities are known
+	p.peerVersion = peerVersion
+	p.claimedIP = msg.Get(IP).(utils.IPDesc)
+	p.Capabilities()
+
+	if msg := p.pendingCapabilities; msg != nil {
+		p.pendingCapabilities = nil
+		p.capabilities(msg)
+	}
+}
+
+// assumes the stateLock is not held
+func (p *peer) capabilities(msg Msg) {
+	if p.gotVersion.GetValue() {
+		p.net.log.Verbo("dropping duplicated capabilities message from %s", p.id)
+		return
+	}
+	if p.peerVersion == nil {
+		// handle it once the version message arrives
+		p.pendingCapabilities = msg
+		return
+	}
+
+	// remember the peer's signature of the IP it claims, so that it can be
+	// gossiped along with the IP
+	if sig := msg.Get(Signature).([]byte); len(sig) != 0 && p.cert != nil {
+		sigTime := msg.Get(SigTime).(uint64)
+		if _, err := verifyIPSignature(p.cert, p.claimedIP, sigTime, sig); err != nil {
+			p.net.log.Debug("peer %s sent an invalid signature of its IP due to %s", p.id, err)
+
+			p.discardIP()
+			return
+		}
+		if p.claimedIP.Equal(p.getIP()) {
+			p.setIPSignature(ipSignature{
+				Cert: p.cert.Raw,
+				Time: sigTime,
+				Sig:  sig,
+			})
+		}
+	}
+
+	// features are only used if both nodes report them
+	features := p.net.features() & msg.Get(Features).(uint64)
+	p.finishVersion(p.peerVersion, features)
+}
+
+// finishVersion completes the version handshake with the peer, running
+// [peerVersion], using [features] with it
+// assumes the stateLock is not held
+func (p *peer) finishVersion(peerVersion version.Version, features uint64) {
+	atomic.StoreUint64(&p.features, features)
+	p.net.log.Verbo("negotiated features %v with %s", FeatureNames(features), p.id)
+
 	p.SendPeerList()
 
 	p.versionStr.SetValue(peerVersion.String())
@@ -570,11 +933,26 @@ func (p *peer) getPeerList(_ Msg) {
 // assumes the stateLock is not held
 func (p *peer) peerList(msg Msg) {
 	ips := msg.Get(Peers).([]utils.IPDesc)
+	sigs, _ := msg.Get(PeerSignatures).([]ipSignature)
 
 	p.gotPeerList.SetValue(true)
 	p.tryMarkConnected()
 
-	for _, ip := range ips {
+	if sigs != nil && len(sigs) != len(ips) {
+		p.net.log.Debug("dropping peer list from %s due to %s", p.id, errBadIPSignatures)
+		return
+	}
+
+	for i, ip := range ips {
+		sig := ipSignature{}
+		if sigs != nil {
+			sig = sigs[i]
+		}
+		if !p.net.verifyGossipedIP(ip, sig) {
+			p.net.log.Verbo("not tracking %s, gossiped by %s, because its signature isn't valid", ip, p.id)
+			continue
+		}
+
 		p.net.stateLock.Lock()
 		if !ip.Equal(p.net.ip.IP()) &&
 			!ip.IsZero() &&
@@ -590,7 +968,7 @@ func (p *peer) peerList(msg Msg) {
 func (p *peer) ping(_ Msg) { p.Pong() }
 
 // assumes the stateLock is not held
-func (p *peer) pong(_ Msg) {}
+func (p *peer) pong(_ Msg) { p.answered() }
 
 // assumes the stateLock is not held
 func (p *peer) getAcceptedFrontier(msg Msg) {
@@ -618,6 +996,7 @@ func (p *peer) acceptedFrontier(msg Msg) {
 		containerIDs.Add(containerID)
 	}
 
+	p.net.frontiers.put(p.id, chainID, containerIDs)
 	p.net.router.AcceptedFrontier(p.id, chainID, requestID, containerIDs)
 }
 
@@ -749,6 +1128,48 @@ func (p *peer) chits(msg Msg) {
 	p.net.router.Chits(p.id, chainID, requestID, containerIDs)
 }
 
+// assumes the stateLock is not held
+func (p *peer) getStateSummaryFrontier(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	requestID := msg.Get(RequestID).(uint32)
+	deadline := p.net.clock.Time().Add(time.Duration(msg.Get(Deadline).(uint64)))
+
+	p.net.router.GetStateSummaryFrontier(p.id, chainID, requestID, deadline)
+}
+
+// assumes the stateLock is not held
+func (p *peer) stateSummaryFrontier(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	requestID := msg.Get(RequestID).(uint32)
+	summary := msg.Get(ContainerBytes).([]byte)
+
+	p.net.router.StateSummaryFrontier(p.id, chainID, requestID, summary)
+}
+
+// assumes the stateLock is not held
+func (p *peer) getStateChunk(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	requestID := msg.Get(RequestID).(uint32)
+	deadline := p.net.clock.Time().Add(time.Duration(msg.Get(Deadline).(uint64)))
+	chunkID, err := ids.ToID(msg.Get(ContainerID).([]byte))
+	p.net.log.AssertNoError(err)
+
+	p.net.router.GetStateChunk(p.id, chainID, requestID, deadline, chunkID)
+}
+
+// assumes the stateLock is not held
+func (p *peer) stateChunk(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	requestID := msg.Get(RequestID).(uint32)
+	chunk := msg.Get(ContainerBytes).([]byte)
+
+	p.net.router.StateChunk(p.id, chainID, requestID, chunk)
+}
+
 // assumes the stateLock is held
 func (p *peer) tryMarkConnected() {
 	if !p.connected.GetValue() && // not already connected
@@ -792,6 +1213,20 @@ func (p *peer) setIP(ip utils.IPDesc) {
 	p.ipLock.Lock()
 	defer p.ipLock.Unlock()
 	p.ip = ip
+	p.ipSig = ipSignature{}
+}
+
+func (p *peer) setIPSignature(sig ipSignature) {
+	p.ipLock.Lock()
+	defer p.ipLock.Unlock()
+	p.ipSig = sig
+}
+
+// getSignedIP returns the peer's IP and, if the peer signed it, its signature
+func (p *peer) getSignedIP() (utils.IPDesc, ipSignature) {
+	p.ipLock.RLock()
+	defer p.ipLock.RUnlock()
+	return p.ip, p.ipSig
 }
 
 func (p *peer) getIP() utils.IPDesc {
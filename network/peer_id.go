@@ -5,6 +5,8 @@ package network
 
 import (
 	"time"
+
+	"github.com/ava-labs/avalanchego/utils/json"
 )
 
 // PeerID ...
@@ -15,4 +17,12 @@ type PeerID struct {
 	Version      string    `json:"version"`
 	LastSent     time.Time `json:"lastSent"`
 	LastReceived time.Time `json:"lastReceived"`
+	// ObservedUptime is the portion, in [0, 1], of the time since this node
+	// started that it has been connected to the peer
+	ObservedUptime json.Float32 `json:"observedUptime"`
+	// Features are the features negotiated with the peer
+	Features []string `json:"features"`
+	// PingRTT is the smoothed time, in milliseconds, the peer takes to answer
+	// pings, or 0 if it hasn't answered any
+	PingRTT json.Float32 `json:"pingRTT"`
 }
@@ -0,0 +1,76 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"math"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var errTooManyPeers = errors.New("connected to the maximum number of peers, all of which have at least as much stake")
+
+// stakeWeight returns the stake [nodeID] has on the primary network and the
+// subnets this node validates. Beacons have the most stake possible, so that
+// they're never evicted.
+// assumes the stateLock is held.
+func (n *network) stakeWeight(nodeID ids.ShortID) uint64 {
+	if n.beacons.Contains(nodeID) {
+		return math.MaxUint64
+	}
+
+	weight, _ := n.vdrs.GetWeight(nodeID)
+	if n.subnetVdrs == nil {
+		return weight
+	}
+	for _, subnetID := range n.subnetVdrs.GetSubnets(n.id) {
+		if subnetID.Equals(constants.PrimaryNetworkID) {
+			continue
+		}
+		vdrs, ok := n.subnetVdrs.GetValidators(subnetID)
+		if !ok {
+			continue
+		}
+		subnetWeight, _ := vdrs.GetWeight(nodeID)
+		newWeight, err := safemath.Add64(weight, subnetWeight)
+		if err != nil {
+			return math.MaxUint64
+		}
+		weight = newWeight
+	}
+	return weight
+}
+
+// evictionCandidate returns the peer with the least stake, if it has less
+// stake than [nodeID], so that it can be evicted to make room for [nodeID].
+// If [nodeID] validates a subnet this node validates that has too few peers,
+// the peer with the least stake is returned regardless. Peers whose eviction
+// would leave a subnet with too few peers, and beacons, aren't evicted.
+// Returns nil if every other peer has at least as much stake as [nodeID].
+// assumes the stateLock is held.
+func (n *network) evictionCandidate(nodeID ids.ShortID) *peer {
+	weight := n.stakeWeight(nodeID)
+	numSubnetPeers := n.subnetPeers()
+	needed := n.subnetPeerNeeded(nodeID, numSubnetPeers)
+
+	var (
+		candidate       *peer
+		candidateWeight uint64
+	)
+	for _, p := range n.peers {
+		if p.closed.GetValue() || n.beacons.Contains(p.id) || n.subnetPeerProtected(p.id, numSubnetPeers) {
+			continue
+		}
+		if peerWeight := n.stakeWeight(p.id); (needed || peerWeight < weight) &&
+			(candidate == nil || peerWeight < candidateWeight) {
+			candidate = p
+			candidateWeight = peerWeight
+		}
+	}
+	return candidate
+}
@@ -0,0 +1,79 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestStakeWeight(t *testing.T) {
+	myID := ids.NewShortID([20]byte{1})
+	beaconID := ids.NewShortID([20]byte{2})
+	vdrID := ids.NewShortID([20]byte{3})
+	subnetID := ids.Empty.Prefix(1)
+	otherSubnetID := ids.Empty.Prefix(2)
+
+	primaryVdrs := validators.NewSet()
+	assert.NoError(t, primaryVdrs.AddWeight(myID, 1))
+	assert.NoError(t, primaryVdrs.AddWeight(vdrID, 10))
+	beacons := validators.NewSet()
+	assert.NoError(t, beacons.AddWeight(beaconID, 1))
+
+	subnetVdrs := validators.NewManager()
+	assert.NoError(t, subnetVdrs.Set(constants.PrimaryNetworkID, primaryVdrs))
+	assert.NoError(t, subnetVdrs.AddWeight(subnetID, myID, 1))
+	assert.NoError(t, subnetVdrs.AddWeight(subnetID, vdrID, 5))
+	// This node doesn't validate the other subnet, so stake on it is ignored
+	assert.NoError(t, subnetVdrs.AddWeight(otherSubnetID, vdrID, 100))
+
+	n := &network{
+		id:         myID,
+		vdrs:       primaryVdrs,
+		subnetVdrs: subnetVdrs,
+		beacons:    beacons,
+	}
+	assert.Equal(t, uint64(15), n.stakeWeight(vdrID))
+	assert.Equal(t, uint64(math.MaxUint64), n.stakeWeight(beaconID))
+	assert.Equal(t, uint64(0), n.stakeWeight(ids.NewShortID([20]byte{4})))
+}
+
+func TestEvictionCandidate(t *testing.T) {
+	lowID := ids.NewShortID([20]byte{1})
+	highID := ids.NewShortID([20]byte{2})
+	nonVdrID := ids.NewShortID([20]byte{3})
+
+	vdrs := validators.NewSet()
+	assert.NoError(t, vdrs.AddWeight(lowID, 1))
+	assert.NoError(t, vdrs.AddWeight(highID, 10))
+
+	n := &network{
+		vdrs:    vdrs,
+		beacons: validators.NewSet(),
+		peers: map[[20]byte]*peer{
+			lowID.Key():  {id: lowID},
+			highID.Key(): {id: highID},
+		},
+	}
+
+	// Peers without stake don't evict anyone
+	assert.Nil(t, n.evictionCandidate(nonVdrID))
+
+	// The peer with the least stake is evicted for a peer with more
+	assert.NoError(t, vdrs.AddWeight(nonVdrID, 5))
+	candidate := n.evictionCandidate(nonVdrID)
+	if assert.NotNil(t, candidate) {
+		assert.True(t, candidate.id.Equals(lowID))
+	}
+
+	// Peers that are closing aren't evicted again
+	n.peers[lowID.Key()].closed.SetValue(true)
+	assert.Nil(t, n.evictionCandidate(nonVdrID))
+}
@@ -0,0 +1,127 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// maxStoredPeers is the max number of peers kept in the peer store. The
+	// peers connected to least recently are removed first.
+	maxStoredPeers = 1024
+
+	// storedPeerMaxAge is how long after it was last connected to that a peer
+	// is removed from the peer store
+	storedPeerMaxAge = 7 * 24 * time.Hour
+
+	// storedPeerLen is the length of a stored peer's value: its IP and the
+	// unix time it was last connected to
+	storedPeerLen = net.IPv6len + wrappers.ShortLen + wrappers.LongLen
+)
+
+// storedPeer is a peer this node has connected to
+type storedPeer struct {
+	id            ids.ShortID
+	ip            utils.IPDesc
+	lastConnected time.Time
+}
+
+// peerStore persists the peers this node connects to, so that it can reconnect
+// to them after it restarts without waiting on the beacons and PeerList gossip.
+// Peers are keyed by their node ID. A nil peerStore stores nothing.
+type peerStore struct {
+	db database.Database
+}
+
+// newPeerStore returns a peer store backed by [db], or nil if [db] is nil
+func newPeerStore(db database.Database) *peerStore {
+	if db == nil {
+		return nil
+	}
+	return &peerStore{db: db}
+}
+
+// put records that the peer [id] at [ip] was connected to at [now]
+func (s *peerStore) put(id ids.ShortID, ip utils.IPDesc, now time.Time) error {
+	if s == nil {
+		return nil
+	}
+	p := wrappers.Packer{MaxSize: storedPeerLen}
+	p.PackIP(ip)
+	p.PackLong(uint64(now.Unix()))
+	if p.Errored() {
+		return p.Err
+	}
+	return s.db.Put(id.Bytes(), p.Bytes)
+}
+
+// peers returns the stored peers, connected to most recently first. Peers that
+// haven't been connected to within storedPeerMaxAge of [now], that can't be
+// parsed, or that are beyond the first maxStoredPeers, are removed.
+func (s *peerStore) peers(now time.Time) ([]storedPeer, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	iter := s.db.NewIterator()
+	defer iter.Release()
+
+	peers := []storedPeer(nil)
+	expired := [][]byte(nil)
+	for iter.Next() {
+		key := iter.Key()
+		id, err := ids.ToShortID(key)
+		if err != nil {
+			expired = append(expired, copyBytes(key))
+			continue
+		}
+		p := wrappers.Packer{Bytes: iter.Value()}
+		ip := p.UnpackIP()
+		lastConnected := time.Unix(int64(p.UnpackLong()), 0)
+		if p.Errored() || p.Offset != len(p.Bytes) || now.Sub(lastConnected) > storedPeerMaxAge {
+			expired = append(expired, copyBytes(key))
+			continue
+		}
+		peers = append(peers, storedPeer{
+			id:            id,
+			ip:            ip,
+			lastConnected: lastConnected,
+		})
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(peers, func(i, j int) bool {
+		return peers[i].lastConnected.After(peers[j].lastConnected)
+	})
+	if len(peers) > maxStoredPeers {
+		for _, peer := range peers[maxStoredPeers:] {
+			expired = append(expired, peer.id.Bytes())
+		}
+		peers = peers[:maxStoredPeers]
+	}
+
+	batch := s.db.NewBatch()
+	for _, key := range expired {
+		if err := batch.Delete(key); err != nil {
+			return nil, err
+		}
+	}
+	return peers, batch.Write()
+}
+
+func copyBytes(b []byte) []byte {
+	cp := make([]byte, len(b))
+	copy(cp, b)
+	return cp
+}
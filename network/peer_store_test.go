@@ -0,0 +1,82 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+func TestPeerStoreNil(t *testing.T) {
+	s := newPeerStore(nil)
+	assert.Nil(t, s)
+	assert.NoError(t, s.put(ids.NewShortID([20]byte{1}), utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}, time.Now()))
+	peers, err := s.peers(time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, peers)
+}
+
+func TestPeerStore(t *testing.T) {
+	db := memdb.New()
+	s := newPeerStore(db)
+	now := time.Unix(1600000000, 0)
+
+	id0 := ids.NewShortID([20]byte{1})
+	ip0 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	id1 := ids.NewShortID([20]byte{2})
+	ip1 := utils.IPDesc{IP: net.ParseIP("2001:db8::1"), Port: 9651}
+	expiredID := ids.NewShortID([20]byte{3})
+	expiredIP := utils.IPDesc{IP: net.IPv4(5, 6, 7, 8), Port: 9651}
+
+	assert.NoError(t, s.put(id0, ip0, now.Add(-time.Hour)))
+	assert.NoError(t, s.put(id1, ip1, now))
+	assert.NoError(t, s.put(expiredID, expiredIP, now.Add(-storedPeerMaxAge-time.Second)))
+	assert.NoError(t, db.Put([]byte{1, 2, 3}, []byte{4, 5, 6}))
+
+	peers, err := s.peers(now)
+	assert.NoError(t, err)
+	if assert.Len(t, peers, 2) {
+		assert.Equal(t, id1, peers[0].id)
+		assert.True(t, ip1.Equal(peers[0].ip))
+		assert.Equal(t, now, peers[0].lastConnected)
+		assert.Equal(t, id0, peers[1].id)
+		assert.True(t, ip0.Equal(peers[1].ip))
+	}
+
+	// The expired and malformed entries were removed
+	has, err := db.Has(expiredID.Bytes())
+	assert.NoError(t, err)
+	assert.False(t, has)
+	has, err = db.Has([]byte{1, 2, 3})
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestPeerStoreMaxPeers(t *testing.T) {
+	db := memdb.New()
+	s := newPeerStore(db)
+	now := time.Unix(1600000000, 0)
+
+	for i := 0; i < maxStoredPeers+1; i++ {
+		id := ids.NewShortID([20]byte{byte(i), byte(i >> 8)})
+		ip := utils.IPDesc{IP: net.IPv4(1, 2, byte(i>>8), byte(i)), Port: 9651}
+		assert.NoError(t, s.put(id, ip, now.Add(time.Duration(i)*time.Second)))
+	}
+
+	peers, err := s.peers(now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, peers, maxStoredPeers)
+
+	// The peer connected to least recently was removed
+	has, err := db.Has(ids.NewShortID([20]byte{}).Bytes())
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
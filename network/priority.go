@@ -0,0 +1,33 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+// priority is the class of a message in a peer's send queues. Queued messages
+// of a higher priority are always sent before those of a lower priority, so
+// that consensus messages aren't stuck behind large bootstrapping messages.
+type priority int
+
+const (
+	// highPriority messages keep the connection alive and consensus making
+	// progress
+	highPriority priority = iota
+	// normalPriority messages are small handshake and bootstrapping messages
+	normalPriority
+	// lowPriority messages carry many containers, and may be large
+	lowPriority
+
+	numPriorities
+)
+
+// priorityOf returns the priority class of messages with [op]
+func priorityOf(op Op) priority {
+	switch op {
+	case GetVersion, Version, Capabilities, Ping, Pong, Get, Put, PushQuery, PullQuery, Chits:
+		return highPriority
+	case MultiPut, StateChunk:
+		return lowPriority
+	default:
+		return normalPriority
+	}
+}
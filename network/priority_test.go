@@ -0,0 +1,74 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityOf(t *testing.T) {
+	assert.Equal(t, highPriority, priorityOf(Chits))
+	assert.Equal(t, highPriority, priorityOf(Put))
+	assert.Equal(t, highPriority, priorityOf(Pong))
+	assert.Equal(t, normalPriority, priorityOf(PeerList))
+	assert.Equal(t, normalPriority, priorityOf(AcceptedFrontier))
+	assert.Equal(t, lowPriority, priorityOf(MultiPut))
+}
+
+func TestPeerNextMessage(t *testing.T) {
+	p := &peer{}
+	for i := range p.senders {
+		p.senders[i] = make(chan []byte, 2)
+	}
+
+	p.senders[lowPriority] <- []byte{2}
+	p.senders[normalPriority] <- []byte{1}
+	p.senders[highPriority] <- []byte{0}
+	p.senders[highPriority] <- []byte{0}
+
+	for _, expected := range []byte{0, 0, 1, 2} {
+		msg, ok := p.nextMessage()
+		assert.True(t, ok)
+		assert.Equal(t, []byte{expected}, msg)
+	}
+
+	// An empty queue is waited on
+	go func() { p.senders[normalPriority] <- []byte{1} }()
+	msg, ok := p.nextMessage()
+	assert.True(t, ok)
+	assert.Equal(t, []byte{1}, msg)
+
+	for _, sender := range p.senders {
+		close(sender)
+	}
+	_, ok = p.nextMessage()
+	assert.False(t, ok)
+}
+
+func TestPeerNextMessageDrainsClosedQueues(t *testing.T) {
+	p := &peer{}
+	for i := range p.senders {
+		p.senders[i] = make(chan []byte, 2)
+	}
+
+	p.senders[normalPriority] <- []byte{1}
+	p.senders[lowPriority] <- []byte{2}
+	p.senders[lowPriority] <- []byte{2}
+	for _, sender := range p.senders {
+		close(sender)
+	}
+
+	// The closed high priority queue mustn't cause the queued messages with a
+	// lower priority to be dropped
+	for _, expected := range []byte{1, 2, 2} {
+		msg, ok := p.nextMessage()
+		assert.True(t, ok)
+		assert.Equal(t, []byte{expected}, msg)
+	}
+
+	_, ok := p.nextMessage()
+	assert.False(t, ok)
+}
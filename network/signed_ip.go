@@ -0,0 +1,162 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var (
+	errUnsupportedKey  = errors.New("staking key type isn't supported")
+	errBadIPSignatures = errors.New("IP signatures don't match the IPs they sign")
+	errBadType         = errors.New("wrong type passed")
+)
+
+// ipSignature is a signature a node made, with its staking key, over the IP it
+// claimed at [Time], along with the staking certificate that verifies it.
+// A signature without a certificate means the IP isn't signed.
+type ipSignature struct {
+	Cert []byte
+	Time uint64
+	Sig  []byte
+}
+
+// signed returns true if [s] holds a signature
+func (s ipSignature) signed() bool { return len(s.Cert) > 0 }
+
+// ipSigningBytes returns the bytes a node signs to claim [ip] at [time]
+func ipSigningBytes(ip utils.IPDesc, time uint64) []byte {
+	p := wrappers.Packer{MaxSize: 1 << 8}
+	p.PackIP(ip)
+	p.PackLong(time)
+	return p.Bytes
+}
+
+// signIP returns the signature of [signer] claiming [ip] at [time]
+func signIP(signer crypto.Signer, ip utils.IPDesc, time uint64) ([]byte, error) {
+	digest := sha256.Sum256(ipSigningBytes(ip, time))
+	return signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+// verifyIPSignature returns the ID of the node whose staking certificate
+// [cert] verifies [sig] claiming [ip] at [time]
+func verifyIPSignature(cert *x509.Certificate, ip utils.IPDesc, time uint64, sig []byte) (ids.ShortID, error) {
+	var algorithm x509.SignatureAlgorithm
+	switch cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		algorithm = x509.SHA256WithRSA
+	case *ecdsa.PublicKey:
+		algorithm = x509.ECDSAWithSHA256
+	default:
+		return ids.ShortID{}, errUnsupportedKey
+	}
+	if err := cert.CheckSignature(algorithm, ipSigningBytes(ip, time), sig); err != nil {
+		return ids.ShortID{}, err
+	}
+	return certNodeID(cert), nil
+}
+
+// verify returns the ID of the node that signed [ip] with [s]
+func (s ipSignature) verify(ip utils.IPDesc) (ids.ShortID, error) {
+	cert, err := x509.ParseCertificate(s.Cert)
+	if err != nil {
+		return ids.ShortID{}, err
+	}
+	return verifyIPSignature(cert, ip, s.Time, s.Sig)
+}
+
+// certNodeID returns the ID of the node with the staking certificate [cert]
+func certNodeID(cert *x509.Certificate) ids.ShortID {
+	return ids.NewShortID(
+		hashing.ComputeHash160Array(
+			hashing.ComputeHash256(cert.Raw)))
+}
+
+// ipSigner signs the IPs this node claims, reusing the signature of each IP
+type ipSigner struct {
+	signer crypto.Signer
+	cert   []byte
+
+	lock sync.Mutex
+	// IP --> its signature
+	sigs map[string]ipSignature
+}
+
+// newIPSigner returns a signer that signs with [signer], whose staking
+// certificate is [cert], or nil if [signer] is nil
+func newIPSigner(signer crypto.Signer, cert []byte) *ipSigner {
+	if signer == nil {
+		return nil
+	}
+	return &ipSigner{
+		signer: signer,
+		cert:   cert,
+		sigs:   make(map[string]ipSignature),
+	}
+}
+
+// sign returns a signature claiming [ip]. If [ip] was already signed, the
+// signature is reused, so that signing doesn't slow down handshakes.
+func (s *ipSigner) sign(ip utils.IPDesc, now uint64) (ipSignature, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := ip.String()
+	if sig, ok := s.sigs[key]; ok {
+		return sig, nil
+	}
+	sigBytes, err := signIP(s.signer, ip, now)
+	if err != nil {
+		return ipSignature{}, err
+	}
+	sig := ipSignature{
+		Cert: s.cert,
+		Time: now,
+		Sig:  sigBytes,
+	}
+	s.sigs[key] = sig
+	return sig, nil
+}
+
+// tryPackIPSignatures attempts to pack the value as a list of IP signatures
+func tryPackIPSignatures(packer *wrappers.Packer, valIntf interface{}) {
+	val, ok := valIntf.([]ipSignature)
+	if !ok {
+		packer.Add(errBadType)
+		return
+	}
+	packer.PackInt(uint32(len(val)))
+	for i := 0; i < len(val) && !packer.Errored(); i++ {
+		packer.PackBytes(val[i].Cert)
+		packer.PackLong(val[i].Time)
+		packer.PackBytes(val[i].Sig)
+	}
+}
+
+// tryUnpackIPSignatures attempts to unpack the value as a list of IP
+// signatures
+func tryUnpackIPSignatures(packer *wrappers.Packer) interface{} {
+	sliceSize := packer.UnpackInt()
+	sigs := []ipSignature(nil)
+	for i := uint32(0); i < sliceSize && !packer.Errored(); i++ {
+		sigs = append(sigs, ipSignature{
+			Cert: packer.UnpackBytes(),
+			Time: packer.UnpackLong(),
+			Sig:  packer.UnpackBytes(),
+		})
+	}
+	return sigs
+}
@@ -0,0 +1,152 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+func newTestStakingCert(t *testing.T, key crypto.Signer) *tls.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Unix(0, 0),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	assert.NoError(t, err)
+	return &tls.Certificate{
+		Certificate: [][]byte{certBytes},
+		PrivateKey:  key,
+	}
+}
+
+func TestSignIP(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	ip := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	otherIP := utils.IPDesc{IP: net.IPv4(1, 2, 3, 5), Port: 9651}
+	for _, key := range []crypto.Signer{rsaKey, ecdsaKey} {
+		cert := newTestStakingCert(t, key)
+		parsedCert, err := x509.ParseCertificate(cert.Certificate[0])
+		assert.NoError(t, err)
+
+		signer := newStakingIPSigner(cert)
+		if !assert.NotNil(t, signer) {
+			continue
+		}
+		sig, err := signer.sign(ip, 10)
+		assert.NoError(t, err)
+		assert.True(t, sig.signed())
+		assert.Equal(t, uint64(10), sig.Time)
+
+		nodeID, err := sig.verify(ip)
+		assert.NoError(t, err)
+		assert.True(t, nodeID.Equals(certNodeID(parsedCert)))
+
+		_, err = sig.verify(otherIP)
+		assert.Error(t, err, "the signature shouldn't verify another IP")
+
+		// The signature of each IP is reused
+		sameSig, err := signer.sign(ip, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, sig, sameSig)
+		newSig, err := signer.sign(otherIP, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(20), newSig.Time)
+	}
+
+	assert.Nil(t, newStakingIPSigner(nil))
+}
+
+func TestBuildSignedPeerList(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	signer := newStakingIPSigner(newTestStakingCert(t, key))
+
+	ips := []utils.IPDesc{
+		{IP: net.IPv4(1, 2, 3, 4), Port: 9651},
+		{IP: net.IPv4(1, 2, 3, 5), Port: 9651},
+	}
+	sig, err := signer.sign(ips[0], 10)
+	assert.NoError(t, err)
+	sigs := []ipSignature{sig, {}}
+
+	_, err = TestBuilder.PeerList(ips, sigs[:1])
+	assert.Equal(t, errBadIPSignatures, err)
+
+	msg, err := TestBuilder.PeerList(ips, sigs)
+	assert.NoError(t, err)
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, ips, parsedMsg.Get(Peers))
+
+	parsedSigs, ok := parsedMsg.Get(PeerSignatures).([]ipSignature)
+	if assert.True(t, ok) && assert.Len(t, parsedSigs, 2) {
+		assert.Equal(t, sig, parsedSigs[0])
+		assert.False(t, parsedSigs[1].signed())
+	}
+}
+
+func TestBuildSignedCapabilities(t *testing.T) {
+	msg, err := TestBuilder.Capabilities(0, 4, []byte{5})
+	assert.NoError(t, err)
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), parsedMsg.Get(Features))
+	assert.Equal(t, uint64(4), parsedMsg.Get(SigTime))
+	assert.Equal(t, []byte{5}, parsedMsg.Get(Signature))
+}
+
+func TestVerifyGossipedIP(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	cert := newTestStakingCert(t, key)
+	parsedCert, err := x509.ParseCertificate(cert.Certificate[0])
+	assert.NoError(t, err)
+	signer := newStakingIPSigner(cert)
+
+	n := &network{
+		vdrs:               validators.NewSet(),
+		maxClockDifference: time.Minute,
+	}
+	n.clock.Set(time.Unix(100, 0))
+
+	ip := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	sig, err := signer.sign(ip, 100)
+	assert.NoError(t, err)
+
+	assert.True(t, n.verifyGossipedIP(ip, ipSignature{}))
+	assert.False(t, n.verifyGossipedIP(ip, sig), "the signer isn't a validator")
+
+	assert.NoError(t, n.vdrs.AddWeight(certNodeID(parsedCert), 1))
+	assert.True(t, n.verifyGossipedIP(ip, sig))
+	assert.False(t, n.verifyGossipedIP(utils.IPDesc{IP: net.IPv4(1, 2, 3, 5), Port: 9651}, sig))
+
+	futureSig, err := newStakingIPSigner(cert).sign(ip, 1000)
+	assert.NoError(t, err)
+	assert.False(t, n.verifyGossipedIP(ip, futureSig), "the signature is too far in the future")
+
+	n.requireSignedIPs = true
+	assert.False(t, n.verifyGossipedIP(ip, ipSignature{}))
+	assert.True(t, n.verifyGossipedIP(ip, sig))
+}
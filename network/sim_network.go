@@ -0,0 +1,660 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"container/heap"
+	"errors"
+	"math/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var errCaptureNotSimulated = errors.New("capture mode isn't simulated")
+
+// Faults are the faults injected into the messages sent from one simulated
+// node to another
+type Faults struct {
+	// Latency is the minimum time a message takes to be delivered
+	Latency time.Duration
+	// Jitter is the maximum random delay added to Latency. Messages with
+	// jitter may be delivered out of order.
+	Jitter time.Duration
+	// DropRate is the probability, in [0, 1], that a message is lost
+	DropRate float64
+}
+
+// Tamper returns the messages a byzantine node sends to [to] in place of
+// [msg]. It may return nil to drop [msg], or build other messages with a
+// Builder. It's called with the SimNetwork's lock held, so it must not call
+// the SimNetwork.
+type Tamper func(to ids.ShortID, msg Msg) []Msg
+
+// SimNetwork is an in-process network between simulated nodes that injects
+// latency, message loss, partitions and byzantine behavior into the consensus
+// messages they send each other.
+//
+// Messages are only delivered when Step, Run or RunFor is called, in the
+// order of their simulated delivery times, and all randomness is drawn from a
+// seeded source. So a test that makes the same calls with the same seed sees
+// the same messages delivered in the same order, which makes liveness bugs
+// reproducible.
+type SimNetwork struct {
+	log logging.Logger
+	b   Builder
+
+	// serializes deliveries
+	stepLock sync.Mutex
+
+	lock   sync.Mutex
+	rng    *rand.Rand
+	now    time.Time
+	seq    uint64
+	events simQueue
+	nodes  map[[20]byte]*simNode
+	// nodes in the order they were added, so that iterating over them is
+	// deterministic
+	order     []*simNode
+	faults    Faults
+	links     map[[40]byte]Faults
+	groups    map[[20]byte]int
+	byzantine map[[20]byte]Tamper
+}
+
+// NewSimNetwork returns a simulated network whose randomness is drawn from
+// [seed], and that injects [faults] into every message unless SetFaults is
+// called for the message's link
+func NewSimNetwork(log logging.Logger, seed int64, faults Faults) *SimNetwork {
+	return &SimNetwork{
+		log: log,
+		// #nosec G404
+		rng:       rand.New(rand.NewSource(seed)),
+		now:       time.Now(),
+		nodes:     make(map[[20]byte]*simNode),
+		faults:    faults,
+		links:     make(map[[40]byte]Faults),
+		groups:    make(map[[20]byte]int),
+		byzantine: make(map[[20]byte]Tamper),
+	}
+}
+
+// Add a node with ID [nodeID] that passes the messages delivered to it to
+// [router], and return the Network the node sends messages through. The node
+// is connected to every node it isn't partitioned from.
+func (s *SimNetwork) Add(nodeID ids.ShortID, router router.Router) Network {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	n := &simNode{
+		sim:    s,
+		id:     nodeID,
+		router: router,
+		recv: &network{
+			log:    s.log,
+			router: router,
+		},
+		closed: make(chan struct{}),
+	}
+	s.relink(func() {
+		s.nodes[nodeID.Key()] = n
+		s.order = append(s.order, n)
+	})
+	return n
+}
+
+// SetFaults sets the faults injected into the messages [from] sends [to]
+func (s *SimNetwork) SetFaults(from, to ids.ShortID, faults Faults) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.links[linkKey(from, to)] = faults
+}
+
+// SetByzantine makes [nodeID] send the messages returned by [tamper] in place
+// of the ones it means to send. If [tamper] is nil, [nodeID] is honest again.
+func (s *SimNetwork) SetByzantine(nodeID ids.ShortID, tamper Tamper) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if tamper == nil {
+		delete(s.byzantine, nodeID.Key())
+	} else {
+		s.byzantine[nodeID.Key()] = tamper
+	}
+}
+
+// Partition the nodes into [groups]. Nodes can only exchange messages with
+// nodes in the same group. Nodes that aren't in any of [groups] form another
+// group. Messages in flight between nodes that are partitioned are lost.
+func (s *SimNetwork) Partition(groups ...[]ids.ShortID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.relink(func() {
+		s.groups = make(map[[20]byte]int)
+		for i, group := range groups {
+			for _, nodeID := range group {
+				s.groups[nodeID.Key()] = i + 1
+			}
+		}
+	})
+}
+
+// Heal the partition, so that all nodes can exchange messages again
+func (s *SimNetwork) Heal() { s.Partition() }
+
+// Now returns the simulated time
+func (s *SimNetwork) Now() time.Time {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.now
+}
+
+// Pending returns the number of messages and notifications that haven't been
+// delivered yet
+func (s *SimNetwork) Pending() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.events.Len()
+}
+
+// Step delivers the next message or notification, advancing the simulated
+// time to when it's delivered. Returns false if there was nothing to deliver.
+func (s *SimNetwork) Step() bool {
+	return s.step(time.Time{})
+}
+
+// Run delivers messages until there are none left to deliver, or until
+// [maxSteps] were delivered. Returns the number delivered.
+func (s *SimNetwork) Run(maxSteps int) int {
+	steps := 0
+	for steps < maxSteps && s.Step() {
+		steps++
+	}
+	return steps
+}
+
+// RunFor delivers the messages due in the next [duration] of simulated time,
+// including the ones sent in response, and then advances the simulated time
+// by [duration]. Returns the number delivered.
+func (s *SimNetwork) RunFor(duration time.Duration) int {
+	s.lock.Lock()
+	end := s.now.Add(duration)
+	s.lock.Unlock()
+
+	steps := 0
+	for s.step(end) {
+		steps++
+	}
+
+	s.lock.Lock()
+	if s.now.Before(end) {
+		s.now = end
+	}
+	s.lock.Unlock()
+	return steps
+}
+
+// step delivers the next event, if it's due no later than [end]. If [end] is
+// the zero time, the next event is delivered whenever it's due.
+func (s *SimNetwork) step(end time.Time) bool {
+	s.stepLock.Lock()
+	defer s.stepLock.Unlock()
+
+	s.lock.Lock()
+	if s.events.Len() == 0 || (!end.IsZero() && s.events[0].at.After(end)) {
+		s.lock.Unlock()
+		return false
+	}
+	event := heap.Pop(&s.events).(*simEvent)
+	s.now = event.at
+	s.lock.Unlock()
+
+	event.run()
+	return true
+}
+
+// schedule [run] to be called at [at]
+// assumes the lock is held.
+func (s *SimNetwork) schedule(at time.Time, run func()) {
+	s.seq++
+	heap.Push(&s.events, &simEvent{
+		at:  at,
+		seq: s.seq,
+		run: run,
+	})
+}
+
+// linked returns true if [from] can send messages to [to]
+// assumes the lock is held.
+func (s *SimNetwork) linked(from, to ids.ShortID) bool {
+	if from.Equals(to) {
+		return false
+	}
+	src, ok := s.nodes[from.Key()]
+	if !ok || src.isClosed() {
+		return false
+	}
+	dst, ok := s.nodes[to.Key()]
+	if !ok || dst.isClosed() {
+		return false
+	}
+	return s.groups[from.Key()] == s.groups[to.Key()]
+}
+
+// relink applies [change] to the links between nodes, and notifies the nodes
+// of the peers that it connected or disconnected them from
+// assumes the lock is held.
+func (s *SimNetwork) relink(change func()) {
+	before := make(map[[40]byte]bool)
+	for _, a := range s.order {
+		for _, b := range s.order {
+			before[linkKey(a.id, b.id)] = s.linked(a.id, b.id)
+		}
+	}
+
+	change()
+
+	for _, a := range s.order {
+		for _, b := range s.order {
+			wasLinked := before[linkKey(a.id, b.id)]
+			isLinked := s.linked(a.id, b.id)
+			router := a.router
+			peerID := b.id
+			switch {
+			case isLinked && !wasLinked:
+				s.schedule(s.now, func() { router.Connected(peerID) })
+			case wasLinked && !isLinked:
+				s.schedule(s.now, func() { router.Disconnected(peerID) })
+			}
+		}
+	}
+}
+
+// send [msg] from [from] to [to]. If [to] can't be reached, [onFailed], if
+// non-nil, is called instead.
+func (s *SimNetwork) send(from, to ids.ShortID, msg Msg, onFailed func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.linked(from, to) {
+		if onFailed != nil {
+			s.schedule(s.now, onFailed)
+		}
+		return
+	}
+
+	msgs := []Msg{msg}
+	if tamper, ok := s.byzantine[from.Key()]; ok {
+		msgs = tamper(to, msg)
+	}
+
+	faults, ok := s.links[linkKey(from, to)]
+	if !ok {
+		faults = s.faults
+	}
+	for _, msg := range msgs {
+		if s.rng.Float64() < faults.DropRate {
+			continue
+		}
+		delay := faults.Latency
+		if faults.Jitter > 0 {
+			delay += time.Duration(s.rng.Int63n(int64(faults.Jitter) + 1))
+		}
+		msgBytes := msg.Bytes()
+		s.schedule(s.now.Add(delay), func() { s.deliver(from, to, msgBytes) })
+	}
+}
+
+// deliver the message [msgBytes] that [from] sent to [to]
+func (s *SimNetwork) deliver(from, to ids.ShortID, msgBytes []byte) {
+	s.lock.Lock()
+	linked := s.linked(from, to)
+	dst := s.nodes[to.Key()]
+	now := s.now
+	s.lock.Unlock()
+
+	// the link was cut while the message was in flight
+	if !linked {
+		return
+	}
+
+	msg, err := s.b.Parse(msgBytes)
+	if err != nil {
+		s.log.Debug("dropping a message from %s to %s that failed to parse due to %s", from, to, err)
+		return
+	}
+
+	dst.recv.clock.Set(now)
+	atomic.StoreInt64(&dst.lastHeartbeat, now.Unix())
+	(&peer{net: dst.recv, id: from}).route(msg)
+}
+
+// linkKey returns the key of the link from [from] to [to]
+func linkKey(from, to ids.ShortID) [40]byte {
+	key := [40]byte{}
+	fromKey, toKey := from.Key(), to.Key()
+	copy(key[:20], fromKey[:])
+	copy(key[20:], toKey[:])
+	return key
+}
+
+// simNode is the Network of a node in a SimNetwork
+type simNode struct {
+	sim    *SimNetwork
+	id     ids.ShortID
+	router router.Router
+	// parses the messages delivered to this node and passes them to [router]
+	recv *network
+
+	lastHeartbeat int64
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func (n *simNode) isClosed() bool {
+	select {
+	case <-n.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// timeout returns how long before [deadline] a request must be answered
+func (n *simNode) timeout(deadline time.Time) uint64 {
+	return uint64(deadline.Sub(n.sim.Now()))
+}
+
+// sendAll sends [msg] to [nodeIDs], in a deterministic order. If a node can't
+// be reached, [onFailed] is called with its ID.
+func (n *simNode) sendAll(nodeIDs ids.ShortSet, msg Msg, onFailed func(ids.ShortID)) {
+	for _, nodeID := range ids.SortedShortIDs(nodeIDs.List()) {
+		nodeID := nodeID
+		n.sim.send(n.id, nodeID, msg, func() { onFailed(nodeID) })
+	}
+}
+
+// failAll calls [onFailed] with each of [nodeIDs], in a deterministic order, as
+// if they couldn't be reached
+func (n *simNode) failAll(nodeIDs ids.ShortSet, onFailed func(ids.ShortID)) {
+	n.sim.lock.Lock()
+	defer n.sim.lock.Unlock()
+
+	for _, nodeID := range ids.SortedShortIDs(nodeIDs.List()) {
+		nodeID := nodeID
+		n.sim.schedule(n.sim.now, func() { onFailed(nodeID) })
+	}
+}
+
+// GetAcceptedFrontier implements the Sender interface.
+func (n *simNode) GetAcceptedFrontier(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time) {
+	msg, err := n.sim.b.GetAcceptedFrontier(chainID, requestID, n.timeout(deadline))
+	n.sim.log.AssertNoError(err)
+	n.sendAll(validatorIDs, msg, func(vID ids.ShortID) { n.router.GetAcceptedFrontierFailed(vID, chainID, requestID) })
+}
+
+// AcceptedFrontier implements the Sender interface.
+func (n *simNode) AcceptedFrontier(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerIDs ids.Set) {
+	msg, err := n.sim.b.AcceptedFrontier(chainID, requestID, containerIDs)
+	if err != nil {
+		n.sim.log.Error("failed to build AcceptedFrontier(%s, %d, %s): %s", chainID, requestID, containerIDs, err)
+		return
+	}
+	n.sim.send(n.id, validatorID, msg, nil)
+}
+
+// GetAccepted implements the Sender interface.
+func (n *simNode) GetAccepted(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time, containerIDs ids.Set) {
+	msg, err := n.sim.b.GetAccepted(chainID, requestID, n.timeout(deadline), containerIDs)
+	onFailed := func(vID ids.ShortID) { n.router.GetAcceptedFailed(vID, chainID, requestID) }
+	if err != nil {
+		n.failAll(validatorIDs, onFailed)
+		return
+	}
+	n.sendAll(validatorIDs, msg, onFailed)
+}
+
+// Accepted implements the Sender interface.
+func (n *simNode) Accepted(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerIDs ids.Set) {
+	msg, err := n.sim.b.Accepted(chainID, requestID, containerIDs)
+	if err != nil {
+		n.sim.log.Error("failed to build Accepted(%s, %d, %s): %s", chainID, requestID, containerIDs, err)
+		return
+	}
+	n.sim.send(n.id, validatorID, msg, nil)
+}
+
+// GetAncestors implements the Sender interface.
+func (n *simNode) GetAncestors(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID) {
+	msg, err := n.sim.b.GetAncestors(chainID, requestID, n.timeout(deadline), containerID)
+	n.sim.log.AssertNoError(err)
+	n.sim.send(n.id, validatorID, msg, func() { n.router.GetAncestorsFailed(validatorID, chainID, requestID) })
+}
+
+// MultiPut implements the Sender interface.
+func (n *simNode) MultiPut(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containers [][]byte) {
+	msg, err := n.sim.b.MultiPut(chainID, requestID, containers)
+	if err != nil {
+		n.sim.log.Error("failed to build MultiPut message because of container of size %d", len(containers))
+		return
+	}
+	n.sim.send(n.id, validatorID, msg, nil)
+}
+
+// Get implements the Sender interface.
+func (n *simNode) Get(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID) {
+	msg, err := n.sim.b.Get(chainID, requestID, n.timeout(deadline), containerID)
+	n.sim.log.AssertNoError(err)
+	n.sim.send(n.id, validatorID, msg, func() { n.router.GetFailed(validatorID, chainID, requestID) })
+}
+
+// Put implements the Sender interface.
+func (n *simNode) Put(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerID ids.ID, container []byte) {
+	msg, err := n.sim.b.Put(chainID, requestID, containerID, container)
+	if err != nil {
+		n.sim.log.Error("failed to build Put(%s, %d, %s): %s. len(container) : %d", chainID, requestID, containerID, err, len(container))
+		return
+	}
+	n.sim.send(n.id, validatorID, msg, nil)
+}
+
+// PushQuery implements the Sender interface.
+func (n *simNode) PushQuery(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID, container []byte) {
+	onFailed := func(vID ids.ShortID) { n.router.QueryFailed(vID, chainID, requestID) }
+	msg, err := n.sim.b.PushQuery(chainID, requestID, n.timeout(deadline), containerID, container)
+	if err != nil {
+		n.sim.log.Error("failed to build PushQuery(%s, %d, %s): %s. len(container): %d", chainID, requestID, containerID, err, len(container))
+		n.failAll(validatorIDs, onFailed)
+		return
+	}
+	n.sendAll(validatorIDs, msg, onFailed)
+}
+
+// PullQuery implements the Sender interface.
+func (n *simNode) PullQuery(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID) {
+	msg, err := n.sim.b.PullQuery(chainID, requestID, n.timeout(deadline), containerID)
+	n.sim.log.AssertNoError(err)
+	n.sendAll(validatorIDs, msg, func(vID ids.ShortID) { n.router.QueryFailed(vID, chainID, requestID) })
+}
+
+// Chits implements the Sender interface.
+func (n *simNode) Chits(validatorID ids.ShortID, chainID ids.ID, requestID uint32, votes ids.Set) {
+	msg, err := n.sim.b.Chits(chainID, requestID, votes)
+	if err != nil {
+		n.sim.log.Error("failed to build Chits(%s, %d, %s): %s", chainID, requestID, votes, err)
+		return
+	}
+	n.sim.send(n.id, validatorID, msg, nil)
+}
+
+// GetStateSummaryFrontier implements the Sender interface.
+func (n *simNode) GetStateSummaryFrontier(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time) {
+	msg, err := n.sim.b.GetStateSummaryFrontier(chainID, requestID, n.timeout(deadline))
+	n.sim.log.AssertNoError(err)
+	n.sendAll(validatorIDs, msg, func(vID ids.ShortID) { n.router.GetStateSummaryFrontierFailed(vID, chainID, requestID) })
+}
+
+// StateSummaryFrontier implements the Sender interface.
+func (n *simNode) StateSummaryFrontier(validatorID ids.ShortID, chainID ids.ID, requestID uint32, summary []byte) {
+	msg, err := n.sim.b.StateSummaryFrontier(chainID, requestID, summary)
+	if err != nil {
+		n.sim.log.Error("failed to build StateSummaryFrontier(%s, %d): %s. len(summary): %d", chainID, requestID, err, len(summary))
+		return
+	}
+	n.sim.send(n.id, validatorID, msg, nil)
+}
+
+// GetStateChunk implements the Sender interface.
+func (n *simNode) GetStateChunk(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, chunkID ids.ID) {
+	msg, err := n.sim.b.GetStateChunk(chainID, requestID, n.timeout(deadline), chunkID)
+	n.sim.log.AssertNoError(err)
+	n.sim.send(n.id, validatorID, msg, func() { n.router.GetStateChunkFailed(validatorID, chainID, requestID) })
+}
+
+// StateChunk implements the Sender interface.
+func (n *simNode) StateChunk(validatorID ids.ShortID, chainID ids.ID, requestID uint32, chunk []byte) {
+	msg, err := n.sim.b.StateChunk(chainID, requestID, chunk)
+	if err != nil {
+		n.sim.log.Error("failed to build StateChunk(%s, %d): %s. len(chunk): %d", chainID, requestID, err, len(chunk))
+		return
+	}
+	n.sim.send(n.id, validatorID, msg, nil)
+}
+
+// Gossip attempts to gossip the container to every node this node is
+// connected to
+func (n *simNode) Gossip(chainID, containerID ids.ID, container []byte) {
+	msg, err := n.sim.b.Put(chainID, constants.GossipMsgRequestID, containerID, container)
+	if err != nil {
+		n.sim.log.Error("failed to build Put message for gossip (%s, %s): %s", chainID, containerID, err)
+		return
+	}
+
+	n.sim.lock.Lock()
+	peerIDs := make([]ids.ShortID, 0, len(n.sim.order))
+	for _, peer := range n.sim.order {
+		if n.sim.linked(n.id, peer.id) {
+			peerIDs = append(peerIDs, peer.id)
+		}
+	}
+	n.sim.lock.Unlock()
+
+	for _, peerID := range peerIDs {
+		n.sim.send(n.id, peerID, msg, nil)
+	}
+}
+
+// Accept is called after every consensus decision
+func (n *simNode) Accept(ctx *snow.Context, containerID ids.ID, container []byte) error {
+	if !ctx.IsBootstrapped() {
+		// don't gossip during bootstrapping
+		return nil
+	}
+	n.Gossip(ctx.ChainID, containerID, container)
+	return nil
+}
+
+// GetHeartbeat returns the simulated unix time a message was last delivered
+// to this node
+func (n *simNode) GetHeartbeat() int64 { return atomic.LoadInt64(&n.lastHeartbeat) }
+
+// Dispatch blocks until the node is closed
+func (n *simNode) Dispatch() error {
+	<-n.closed
+	return errNetworkClosed
+}
+
+// Track is a no-op, since a simulated node is connected to every node it isn't
+// partitioned from
+func (n *simNode) Track(utils.IPDesc) {}
+
+// Peers returns the nodes this node is connected to
+func (n *simNode) Peers() []PeerID {
+	n.sim.lock.Lock()
+	defer n.sim.lock.Unlock()
+
+	peers := []PeerID(nil)
+	for _, peer := range n.sim.order {
+		if n.sim.linked(n.id, peer.id) {
+			peers = append(peers, PeerID{ID: peer.id.PrefixedString(constants.NodeIDPrefix)})
+		}
+	}
+	return peers
+}
+
+// BanNodeID isn't simulated
+func (n *simNode) BanNodeID(ids.ShortID, time.Duration) {}
+
+// BanIP isn't simulated
+func (n *simNode) BanIP(net.IP, time.Duration) {}
+
+// UnbanNodeID isn't simulated
+func (n *simNode) UnbanNodeID(ids.ShortID) bool { return false }
+
+// UnbanIP isn't simulated
+func (n *simNode) UnbanIP(net.IP) bool { return false }
+
+// Bans isn't simulated
+func (n *simNode) Bans() []Ban { return nil }
+
+// StartCapture isn't simulated
+func (n *simNode) StartCapture(CaptureConfig) error { return errCaptureNotSimulated }
+
+// StopCapture isn't simulated
+func (n *simNode) StopCapture() ([]string, error) { return nil, nil }
+
+// LastInboundConnection isn't simulated
+func (n *simNode) LastInboundConnection() time.Time { return time.Time{} }
+
+// Close disconnects the node from every other node
+func (n *simNode) Close() error {
+	n.closeOnce.Do(func() {
+		n.sim.lock.Lock()
+		defer n.sim.lock.Unlock()
+
+		n.sim.relink(func() { close(n.closed) })
+	})
+	return nil
+}
+
+// simEvent is a message or notification delivered at a simulated time
+type simEvent struct {
+	at time.Time
+	// breaks ties between events delivered at the same time, so that they're
+	// delivered in the order they were scheduled
+	seq uint64
+	run func()
+}
+
+// simQueue is a heap of events ordered by when they're delivered
+type simQueue []*simEvent
+
+func (q simQueue) Len() int { return len(q) }
+func (q simQueue) Less(i, j int) bool {
+	if !q[i].at.Equal(q[j].at) {
+		return q[i].at.Before(q[j].at)
+	}
+	return q[i].seq < q[j].seq
+}
+func (q simQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *simQueue) Push(x interface{}) { *q = append(*q, x.(*simEvent)) }
+func (q *simQueue) Pop() interface{} {
+	old := *q
+	event := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return event
+}
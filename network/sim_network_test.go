@@ -0,0 +1,168 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// simRouter records the messages delivered to a simulated node
+type simRouter struct {
+	router.Router
+
+	gets         []ids.ShortID
+	getFailed    []ids.ShortID
+	chits        []ids.Set
+	connected    ids.ShortSet
+	disconnected int
+}
+
+func (r *simRouter) Get(validatorID ids.ShortID, _ ids.ID, _ uint32, _ time.Time, _ ids.ID) {
+	r.gets = append(r.gets, validatorID)
+}
+
+func (r *simRouter) GetFailed(validatorID ids.ShortID, _ ids.ID, _ uint32) {
+	r.getFailed = append(r.getFailed, validatorID)
+}
+
+func (r *simRouter) Chits(_ ids.ShortID, _ ids.ID, _ uint32, votes ids.Set) {
+	r.chits = append(r.chits, votes)
+}
+
+func (r *simRouter) Connected(validatorID ids.ShortID) { r.connected.Add(validatorID) }
+
+func (r *simRouter) Disconnected(validatorID ids.ShortID) {
+	r.connected.Remove(validatorID)
+	r.disconnected++
+}
+
+func TestSimNetworkLatency(t *testing.T) {
+	sim := NewSimNetwork(logging.NoLog{}, 0, Faults{Latency: time.Second})
+	id0, id1 := ids.NewShortID([20]byte{1}), ids.NewShortID([20]byte{2})
+	r0, r1 := &simRouter{}, &simRouter{}
+	net0 := sim.Add(id0, r0)
+	sim.Add(id1, r1)
+
+	// The nodes are told they're connected
+	sim.Run(10)
+	assert.True(t, r0.connected.Contains(id1))
+	assert.True(t, r1.connected.Contains(id0))
+
+	start := sim.Now()
+	net0.Get(id1, ids.Empty, 1, start.Add(time.Minute), ids.Empty)
+	assert.Equal(t, 0, sim.RunFor(time.Second/2))
+	assert.Empty(t, r1.gets)
+	assert.Equal(t, 1, sim.RunFor(time.Second/2))
+	assert.Equal(t, []ids.ShortID{id0}, r1.gets)
+	assert.Equal(t, start.Add(time.Second), sim.Now())
+}
+
+func TestSimNetworkDrops(t *testing.T) {
+	sim := NewSimNetwork(logging.NoLog{}, 0, Faults{})
+	id0, id1 := ids.NewShortID([20]byte{1}), ids.NewShortID([20]byte{2})
+	r1 := &simRouter{}
+	net0 := sim.Add(id0, &simRouter{})
+	sim.Add(id1, r1)
+	sim.Run(10)
+
+	sim.SetFaults(id0, id1, Faults{DropRate: 1})
+	net0.Get(id1, ids.Empty, 1, sim.Now().Add(time.Minute), ids.Empty)
+	assert.Equal(t, 0, sim.Run(10))
+	assert.Empty(t, r1.gets)
+
+	sim.SetFaults(id0, id1, Faults{})
+	net0.Get(id1, ids.Empty, 1, sim.Now().Add(time.Minute), ids.Empty)
+	assert.Equal(t, 1, sim.Run(10))
+	assert.Len(t, r1.gets, 1)
+}
+
+func TestSimNetworkPartition(t *testing.T) {
+	sim := NewSimNetwork(logging.NoLog{}, 0, Faults{Latency: time.Second})
+	id0, id1, id2 := ids.NewShortID([20]byte{1}), ids.NewShortID([20]byte{2}), ids.NewShortID([20]byte{3})
+	r0, r1, r2 := &simRouter{}, &simRouter{}, &simRouter{}
+	net0 := sim.Add(id0, r0)
+	sim.Add(id1, r1)
+	sim.Add(id2, r2)
+	sim.Run(10)
+	assert.Equal(t, 2, r0.connected.Len())
+
+	// A message in flight when the partition forms is lost
+	net0.Get(id2, ids.Empty, 1, sim.Now().Add(time.Minute), ids.Empty)
+	sim.Partition([]ids.ShortID{id0, id1})
+	sim.Run(10)
+	assert.Empty(t, r2.gets)
+	assert.Equal(t, 1, r0.connected.Len())
+	assert.True(t, r0.connected.Contains(id1))
+	assert.Equal(t, 0, r2.connected.Len())
+
+	// A request to a node that can't be reached fails
+	net0.Get(id2, ids.Empty, 2, sim.Now().Add(time.Minute), ids.Empty)
+	sim.Run(10)
+	assert.Equal(t, []ids.ShortID{id2}, r0.getFailed)
+
+	sim.Heal()
+	sim.Run(10)
+	assert.Equal(t, 2, r0.connected.Len())
+	net0.Get(id2, ids.Empty, 3, sim.Now().Add(time.Minute), ids.Empty)
+	sim.Run(10)
+	assert.Equal(t, []ids.ShortID{id0}, r2.gets)
+
+	// A closed node is disconnected from the others
+	assert.NoError(t, net0.Close())
+	sim.Run(10)
+	assert.False(t, r1.connected.Contains(id0))
+	assert.Error(t, net0.Dispatch())
+}
+
+func TestSimNetworkByzantine(t *testing.T) {
+	sim := NewSimNetwork(logging.NoLog{}, 0, Faults{})
+	id0, id1 := ids.NewShortID([20]byte{1}), ids.NewShortID([20]byte{2})
+	r1 := &simRouter{}
+	net0 := sim.Add(id0, &simRouter{})
+	sim.Add(id1, r1)
+	sim.Run(10)
+
+	// id0 votes for a different container than it means to, twice
+	badVote := ids.Empty.Prefix(1)
+	sim.SetByzantine(id0, func(_ ids.ShortID, msg Msg) []Msg {
+		chainID, _ := ids.ToID(msg.Get(ChainID).([]byte))
+		badMsg, err := sim.b.Chits(chainID, msg.Get(RequestID).(uint32), ids.Set{badVote.Key(): true})
+		assert.NoError(t, err)
+		return []Msg{badMsg, badMsg}
+	})
+	net0.Chits(id1, ids.Empty, 1, ids.Set{ids.Empty.Key(): true})
+	sim.Run(10)
+	if assert.Len(t, r1.chits, 2) {
+		assert.True(t, r1.chits[0].Contains(badVote))
+		assert.True(t, r1.chits[1].Contains(badVote))
+	}
+}
+
+func TestSimNetworkDeterministic(t *testing.T) {
+	deliveries := func(seed int64) []ids.ShortID {
+		sim := NewSimNetwork(logging.NoLog{}, seed, Faults{Latency: time.Millisecond, Jitter: time.Second})
+		r := &simRouter{}
+		sim.Add(ids.ShortEmpty, r)
+		senders := make([]Network, 10)
+		for i := range senders {
+			senders[i] = sim.Add(ids.NewShortID([20]byte{byte(i + 1)}), &simRouter{})
+		}
+		for i, sender := range senders {
+			sender.Get(ids.ShortEmpty, ids.Empty, uint32(i), sim.Now().Add(time.Minute), ids.Empty)
+		}
+		sim.Run(1000)
+		return r.gets
+	}
+
+	first := deliveries(1)
+	assert.Len(t, first, 10)
+	assert.Equal(t, first, deliveries(1))
+}
@@ -0,0 +1,105 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// mySubnets returns the IDs of the subnets, other than the primary network,
+// that this node validates
+// assumes the stateLock is held.
+func (n *network) mySubnets() []ids.ID {
+	if n.subnetVdrs == nil || n.minSubnetPeers <= 0 {
+		return nil
+	}
+	subnetIDs := []ids.ID(nil)
+	for _, subnetID := range n.subnetVdrs.GetSubnets(n.id) {
+		if !subnetID.Equals(constants.PrimaryNetworkID) {
+			subnetIDs = append(subnetIDs, subnetID)
+		}
+	}
+	return subnetIDs
+}
+
+// subnetPeers returns the number of peers that validate each subnet this node
+// validates, other than the primary network
+// assumes the stateLock is held.
+func (n *network) subnetPeers() map[[32]byte]int {
+	subnetIDs := n.mySubnets()
+	numPeers := make(map[[32]byte]int, len(subnetIDs))
+	for _, subnetID := range subnetIDs {
+		vdrs, ok := n.subnetVdrs.GetValidators(subnetID)
+		if !ok {
+			continue
+		}
+		count := 0
+		for _, p := range n.peers {
+			if !p.closed.GetValue() && vdrs.Contains(p.id) {
+				count++
+			}
+		}
+		numPeers[subnetID.Key()] = count
+	}
+	return numPeers
+}
+
+// belowSubnetPeers returns true if [nodeID] validates a subnet in [numPeers]
+// that has at most [slack] more than minSubnetPeers peers
+// assumes the stateLock is held.
+func (n *network) belowSubnetPeers(nodeID ids.ShortID, numPeers map[[32]byte]int, slack int) bool {
+	for subnetKey, count := range numPeers {
+		if count > n.minSubnetPeers+slack {
+			continue
+		}
+		if vdrs, ok := n.subnetVdrs.GetValidators(ids.NewID(subnetKey)); ok && vdrs.Contains(nodeID) {
+			return true
+		}
+	}
+	return false
+}
+
+// subnetPeerNeeded returns true if [nodeID] validates a subnet this node
+// validates that has fewer than minSubnetPeers peers, so that a connection to
+// it should be kept even if that means evicting a peer with more stake
+// assumes the stateLock is held.
+func (n *network) subnetPeerNeeded(nodeID ids.ShortID, numPeers map[[32]byte]int) bool {
+	return n.belowSubnetPeers(nodeID, numPeers, -1)
+}
+
+// subnetPeerProtected returns true if evicting the peer [nodeID] would leave a
+// subnet this node validates with fewer than minSubnetPeers peers
+// assumes the stateLock is held.
+func (n *network) subnetPeerProtected(nodeID ids.ShortID, numPeers map[[32]byte]int) bool {
+	return n.belowSubnetPeers(nodeID, numPeers, 0)
+}
+
+// connectSubnetPeers attempts to connect to the validators, at the IPs they
+// were last connected to at, of each subnet this node validates that has
+// fewer than minSubnetPeers peers
+// assumes the stateLock is not held.
+func (n *network) connectSubnetPeers() {
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	for subnetKey, count := range n.subnetPeers() {
+		if count >= n.minSubnetPeers {
+			continue
+		}
+		vdrs, ok := n.subnetVdrs.GetValidators(ids.NewID(subnetKey))
+		if !ok {
+			continue
+		}
+		for _, vdr := range vdrs.List() {
+			key := vdr.ID().Key()
+			if _, connected := n.peers[key]; connected {
+				continue
+			}
+			if ip, ok := n.vdrIPs[key]; ok {
+				n.track(ip)
+			}
+		}
+	}
+}
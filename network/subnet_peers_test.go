@@ -0,0 +1,73 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestSubnetPeers(t *testing.T) {
+	myID := ids.NewShortID([20]byte{1})
+	subnetVdrID := ids.NewShortID([20]byte{2})
+	otherVdrID := ids.NewShortID([20]byte{3})
+	newSubnetVdrID := ids.NewShortID([20]byte{4})
+	subnetID := ids.Empty.Prefix(1)
+
+	primaryVdrs := validators.NewSet()
+	assert.NoError(t, primaryVdrs.AddWeight(myID, 1))
+	assert.NoError(t, primaryVdrs.AddWeight(subnetVdrID, 1))
+	assert.NoError(t, primaryVdrs.AddWeight(otherVdrID, 10))
+	assert.NoError(t, primaryVdrs.AddWeight(newSubnetVdrID, 1))
+
+	subnetVdrs := validators.NewManager()
+	assert.NoError(t, subnetVdrs.Set(constants.PrimaryNetworkID, primaryVdrs))
+	assert.NoError(t, subnetVdrs.AddWeight(subnetID, myID, 1))
+	assert.NoError(t, subnetVdrs.AddWeight(subnetID, subnetVdrID, 1))
+	assert.NoError(t, subnetVdrs.AddWeight(subnetID, newSubnetVdrID, 1))
+
+	n := &network{
+		id:             myID,
+		vdrs:           primaryVdrs,
+		subnetVdrs:     subnetVdrs,
+		beacons:        validators.NewSet(),
+		minSubnetPeers: 2,
+		peers: map[[20]byte]*peer{
+			subnetVdrID.Key(): {id: subnetVdrID},
+			otherVdrID.Key():  {id: otherVdrID},
+		},
+	}
+
+	numPeers := n.subnetPeers()
+	assert.Equal(t, map[[32]byte]int{subnetID.Key(): 1}, numPeers)
+	assert.True(t, n.subnetPeerNeeded(newSubnetVdrID, numPeers))
+	assert.False(t, n.subnetPeerNeeded(otherVdrID, numPeers))
+	assert.True(t, n.subnetPeerProtected(subnetVdrID, numPeers))
+	assert.False(t, n.subnetPeerProtected(otherVdrID, numPeers))
+
+	// The subnet's validator is kept, and the peer with more stake is evicted
+	// for another of the subnet's validators
+	candidate := n.evictionCandidate(newSubnetVdrID)
+	if assert.NotNil(t, candidate) {
+		assert.True(t, candidate.id.Equals(otherVdrID))
+	}
+
+	// Once the subnet has enough peers, peers are evicted by stake
+	n.peers[newSubnetVdrID.Key()] = &peer{id: newSubnetVdrID}
+	delete(n.peers, otherVdrID.Key())
+	assert.Nil(t, n.evictionCandidate(ids.NewShortID([20]byte{5})))
+	candidate = n.evictionCandidate(otherVdrID)
+	assert.Nil(t, candidate, "evicting either subnet validator would leave the subnet with too few peers")
+
+	// Without a minimum, subnet validators aren't treated differently
+	n.minSubnetPeers = 0
+	assert.Empty(t, n.subnetPeers())
+	candidate = n.evictionCandidate(otherVdrID)
+	assert.NotNil(t, candidate)
+}
@@ -0,0 +1,89 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	errTLSVersionNotAllowed     = errors.New("negotiated TLS version isn't allowed")
+	errTLSCipherSuiteNotAllowed = errors.New("negotiated TLS cipher suite isn't allowed")
+)
+
+// ParseTLSVersion parses a TLS version, 1.2 or 1.3
+func ParseTLSVersion(str string) (uint16, error) {
+	switch str {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q. Must be 1.2 or 1.3", str)
+	}
+}
+
+// ParseCipherSuites parses a comma separated list of the names of secure
+// cipher suites, such as
+// TLS_AES_128_GCM_SHA256,TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256
+func ParseCipherSuites(str string) ([]uint16, error) {
+	suites := []uint16(nil)
+	for _, name := range strings.Split(str, ",") {
+		if name == "" {
+			continue
+		}
+		id, ok := secureCipherSuite(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown or insecure cipher suite %q", name)
+		}
+		suites = append(suites, id)
+	}
+	return suites, nil
+}
+
+func secureCipherSuite(name string) (uint16, bool) {
+	for _, suite := range tls.CipherSuites() {
+		if suite.Name == name {
+			return suite.ID, true
+		}
+	}
+	return 0, false
+}
+
+// verifyConnectionState returns an error if the TLS version or cipher suite
+// negotiated in [state] isn't allowed by [config]. The TLS library doesn't let
+// the TLS 1.3 cipher suites be configured, so the negotiated cipher suite is
+// checked after the handshake rather than only being offered.
+func verifyConnectionState(config *tls.Config, state tls.ConnectionState) error {
+	if state.Version < config.MinVersion {
+		return fmt.Errorf("%w: %s", errTLSVersionNotAllowed, tlsVersionName(state.Version))
+	}
+	if len(config.CipherSuites) == 0 {
+		return nil
+	}
+	for _, suite := range config.CipherSuites {
+		if suite == state.CipherSuite {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", errTLSCipherSuiteNotAllowed, tls.CipherSuiteName(state.CipherSuite))
+}
+
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "1.0"
+	case tls.VersionTLS11:
+		return "1.1"
+	case tls.VersionTLS12:
+		return "1.2"
+	case tls.VersionTLS13:
+		return "1.3"
+	default:
+		return fmt.Sprintf("0x%04x", version)
+	}
+}
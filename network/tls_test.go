@@ -0,0 +1,70 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"crypto/tls"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTLSVersion(t *testing.T) {
+	version, err := ParseTLSVersion("1.3")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), version)
+
+	_, err = ParseTLSVersion("1.1")
+	assert.Error(t, err)
+}
+
+func TestParseCipherSuites(t *testing.T) {
+	suites, err := ParseCipherSuites("TLS_AES_128_GCM_SHA256,TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint16{tls.TLS_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}, suites)
+
+	suites, err = ParseCipherSuites("")
+	assert.NoError(t, err)
+	assert.Empty(t, suites)
+
+	// Insecure cipher suites aren't allowed
+	_, err = ParseCipherSuites("TLS_RSA_WITH_RC4_128_SHA")
+	assert.Error(t, err)
+	_, err = ParseCipherSuites("TLS_NOT_A_CIPHER_SUITE")
+	assert.Error(t, err)
+}
+
+func TestVerifyConnectionState(t *testing.T) {
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		CipherSuites: []uint16{tls.TLS_AES_256_GCM_SHA384},
+	}
+
+	err := verifyConnectionState(config, tls.ConnectionState{
+		Version:     tls.VersionTLS13,
+		CipherSuite: tls.TLS_AES_256_GCM_SHA384,
+	})
+	assert.NoError(t, err)
+
+	err = verifyConnectionState(config, tls.ConnectionState{
+		Version:     tls.VersionTLS12,
+		CipherSuite: tls.TLS_AES_256_GCM_SHA384,
+	})
+	assert.True(t, errors.Is(err, errTLSVersionNotAllowed))
+
+	err = verifyConnectionState(config, tls.ConnectionState{
+		Version:     tls.VersionTLS13,
+		CipherSuite: tls.TLS_AES_128_GCM_SHA256,
+	})
+	assert.True(t, errors.Is(err, errTLSCipherSuiteNotAllowed))
+
+	// Without configured cipher suites, any negotiated suite is allowed
+	config.CipherSuites = nil
+	err = verifyConnectionState(config, tls.ConnectionState{
+		Version:     tls.VersionTLS13,
+		CipherSuite: tls.TLS_AES_128_GCM_SHA256,
+	})
+	assert.NoError(t, err)
+}
@@ -52,6 +52,10 @@ func (t tlsServerUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, error)
 	}
 
 	connState := encConn.ConnectionState()
+	if err := verifyConnectionState(t.config, connState); err != nil {
+		_ = encConn.Close()
+		return ids.ShortID{}, nil, err
+	}
 	if len(connState.PeerCertificates) == 0 {
 		return ids.ShortID{}, nil, errNoCert
 	}
@@ -80,6 +84,10 @@ func (t tlsClientUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, error)
 	}
 
 	connState := encConn.ConnectionState()
+	if err := verifyConnectionState(t.config, connState); err != nil {
+		_ = encConn.Close()
+		return ids.ShortID{}, nil, err
+	}
 	if len(connState.PeerCertificates) == 0 {
 		return ids.ShortID{}, nil, errNoCert
 	}
@@ -4,11 +4,18 @@
 package node
 
 import (
+	"os"
 	"time"
 
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/nat"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
@@ -25,12 +32,18 @@ type Config struct {
 	// protocol to use for opening the network interface
 	Nat nat.Router
 
+	// maps the node's ports on [Nat], or nil if they aren't mapped
+	PortMapper *nat.Mapper
+
 	// Attempted NAT Traversal did we attempt
 	AttemptedNATTraversal bool
 
 	// ID of the network this node should connect to
 	NetworkID uint32
 
+	// Git commit this node was built from, if set at compile time
+	GitCommit string
+
 	// Assertions configuration
 	EnableAssertions bool
 
@@ -51,6 +64,19 @@ type Config struct {
 	StakerMSGPortion        float64
 	StakerCPUPortion        float64
 
+	// Address the staking server listens on. If empty, it listens on every
+	// interface, over both IPv4 and IPv6.
+	StakingHost string
+
+	// Other addresses the staking server listens on, each with the IP claimed
+	// to the peers that connect through it, if it isn't StakingIP
+	StakingListenAddresses []network.ListenAddress
+
+	// Minimum TLS version of staking connections and, if non-empty, the only
+	// cipher suites they may use
+	StakingTLSMinVersion   uint16
+	StakingTLSCipherSuites []uint16
+
 	// Network configuration
 	NetworkConfig timer.AdaptiveTimeoutConfig
 
@@ -60,22 +86,64 @@ type Config struct {
 	// Bootstrapping configuration
 	BootstrapPeers []*Peer
 
+	// Domains whose seed records list more peers to first connect to, and the
+	// addresses of the keys trusted to sign seed records
+	BootstrapDNSSeeds       []string
+	BootstrapDNSSeedSigners ids.ShortSet
+
+	// Bootstrap archive configuration
+	BootstrapArchiveEnabled   bool
+	BootstrapArchiveURI       string
+	BootstrapArchiveAuthToken string
+
+	// Maximum number of GetAncestors requests sent to each beacon at once
+	BootstrapFetchWindow int
+
+	// Consensus checkpoint configuration
+	CheckpointFrequency time.Duration
+	MaxCheckpointAge    time.Duration
+
 	// HTTP configuration
 	HTTPHost string
 	HTTPPort uint16
+	// If non-empty, the API is also served on the unix socket at this path,
+	// with the permissions HTTPUnixSocketMode
+	HTTPUnixSocket     string
+	HTTPUnixSocketMode os.FileMode
 
 	HTTPSEnabled        bool
 	HTTPSKeyFile        string
 	HTTPSCertFile       string
 	APIRequireAuthToken bool
 	APIAuthPassword     string
+	APIRequestTimeout   time.Duration
+	APITimeouts         api.TimeoutConfig
+	APIRateLimits       api.RateLimitConfig
+	APICORSConfig       api.CORSConfig
+	APICompressionLevel int
 
 	// Enable/Disable APIs
-	AdminAPIEnabled    bool
-	InfoAPIEnabled     bool
-	KeystoreAPIEnabled bool
-	MetricsAPIEnabled  bool
-	HealthAPIEnabled   bool
+	AdminAPIEnabled     bool
+	InfoAPIEnabled      bool
+	KeystoreAPIEnabled  bool
+	MetricsAPIEnabled   bool
+	HealthAPIEnabled    bool
+	SchedulerAPIEnabled bool
+	EventsAPIEnabled    bool
+
+	// Serves the info, health, AVM and platform APIs over gRPC and gRPC-web
+	GRPCAPIEnabled bool
+	GRPCPort       uint16
+
+	// Pushes metrics to a Pushgateway or remote-write endpoint if its URL is
+	// set
+	MetricsPushConfig metrics.PushConfig
+
+	// Index the P-Chain transactions that reference each address
+	PlatformAddressTxIndexEnabled bool
+
+	// Health checks that execute external commands or probe URLs
+	ExternalHealthChecks []health.ExternalCheckConfig
 
 	// Logging configuration
 	LoggingConfig logging.Config
@@ -83,9 +151,20 @@ type Config struct {
 	// Plugin directory
 	PluginDir string
 
+	// Directory that profiles captured through the Admin API are written to
+	ProfileDir string
+
+	// Directory that network captures started through the Admin API are
+	// written to
+	NetworkCaptureDir string
+
 	// Consensus configuration
 	ConsensusParams avalanche.Parameters
 
+	// Longest time the AVM waits for more transactions before issuing those
+	// it has to consensus
+	AVMBatchDelay time.Duration
+
 	// Throughput configuration
 	ThroughputPort          uint16
 	ThroughputServerEnabled bool
@@ -100,12 +179,77 @@ type Config struct {
 	ConsensusGossipFrequency time.Duration
 	ConsensusShutdownTimeout time.Duration
 
+	// Bounds of the time between gossiping accepted frontiers, which adapts to
+	// peer churn and chain activity. If the minimum is 0, the time is fixed.
+	ConsensusMinGossipFrequency, ConsensusMaxGossipFrequency time.Duration
+
+	// Number of peers accepted frontiers are gossiped to while they're
+	// unchanged and no peers connected since they were last gossiped
+	ConsensusIdleGossipSize int
+
+	// Sizes of the deduplication caches of chains, by chain ID or alias
+	ChainCacheSizes map[string]chains.CacheSizes
+
+	// Overrides of the consensus parameters of chains, by chain ID or alias
+	ChainConsensusConfigs map[string]chains.ConsensusConfig
+
+	// Delete rejected vertices and P-Chain blocks from the database
+	PruneRejected bool
+
+	// Chains, by chain ID or alias, whose consensus messages are recorded to
+	// files in ConsensusRecordDir
+	ConsensusRecordChains []string
+	ConsensusRecordDir    string
+
+	// Chains, by chain ID or alias, that aren't run even though this node
+	// validates their subnet
+	SkippedChains []string
+
 	// Dynamic Update duration for IP or NAT traversal
 	DynamicUpdateDuration time.Duration
 
 	DynamicPublicIPResolver dynamicip.Resolver
 
 	// Throttling incoming connections
-	ConnMeterResetDuration time.Duration
-	ConnMeterMaxConns      int
+	ConnMeterResetDuration  time.Duration
+	ConnMeterMaxConns       int
+	ConnMeterMaxSubnetConns int
+
+	// Decides which peers may be connected to, by node ID and IP
+	PeerGate network.Gate
+
+	// Persist the peers connected to, and reconnect to them on startup
+	PeerStoreEnabled bool
+
+	// SOCKS5 proxy outbound peer connections are made through. If its address
+	// is empty, peers are connected to directly.
+	NetworkProxy network.ProxyConfig
+
+	// Maximum number of inbound connections being upgraded at once, overall
+	// and from each IP. 0 means unlimited.
+	MaxPendingHandshakes, MaxPendingHandshakesPerIP int
+
+	// Compress large messages to peers that accept compressed messages
+	NetworkCompressionEnabled bool
+
+	// Sign the IP this node claims with its staking key, and gossip the
+	// signatures of peers' IPs to peers that accept them
+	NetworkSignedIPsEnabled bool
+
+	// Ignore gossiped IPs that aren't signed by a validator
+	NetworkRequireSignedIPs bool
+
+	// Advertise that this node can parse and serve state sync messages
+	NetworkStateSyncEnabled bool
+
+	// Maximum number of peers, or 0 if the number of peers isn't limited
+	MaxPeers int
+
+	// Minimum number of peers to keep connected to from each subnet, other
+	// than the primary network, this node validates
+	MinSubnetPeers int
+
+	// Bandwidth, in bytes per second, messages are sent to each peer and to
+	// every peer at. 0 means unlimited.
+	PeerSendBandwidth, SendBandwidth uint64
 }
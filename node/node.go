@@ -4,25 +4,33 @@
 package node
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/admin"
+	"github.com/ava-labs/avalanchego/api/events"
+	"github.com/ava-labs/avalanchego/api/finality"
+	"github.com/ava-labs/avalanchego/api/gateway"
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/api/scheduler"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
@@ -32,13 +40,18 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/ipcs"
 	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/archive"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/drops"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/triggers"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/dnsseed"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math"
@@ -60,14 +73,28 @@ import (
 // Networking constants
 const (
 	TCP = "tcp"
+
+	// Number of recently dropped messages reported by the admin API
+	droppedMessagesLogSize = 256
+
+	// Time allowed to resolve each DNS seed
+	dnsSeedTimeout = 10 * time.Second
+)
+
+const (
+	// DatabaseVersion is the version of the database format used by this code
+	DatabaseVersion = "v1.0.0"
 )
 
 var (
 	genesisHashKey = []byte("genesisID")
 
 	// Version is the version of this code
-	Version       = version.NewDefaultVersion(constants.PlatformName, 1, 0, 3)
+	Version       = version.NewDefaultVersion(constants.PlatformName, 1, 0, 4)
 	versionParser = version.NewDefaultParser()
+	// peers running at least this version report their features and sign
+	// their IPs in a capabilities message, which older peers can't parse
+	featuresVersion = version.NewDefaultVersion(constants.PlatformName, 1, 0, 4)
 )
 
 // Node is an instance of an Avalanche node.
@@ -92,6 +119,15 @@ type Node struct {
 	// Monitors node health and runs health checks
 	healthService *health.Health
 
+	// Executes scheduled API calls. Nil if the scheduler API is disabled.
+	scheduler *scheduler.Scheduler
+
+	// Pushes metrics. Nil if metrics aren't pushed.
+	metricsPusher *metrics.Pusher
+
+	// Serves APIs over gRPC. Nil if the gRPC API is disabled.
+	grpcGateway *gateway.Gateway
+
 	// Manages creation of blockchains and routing messages to them
 	chainManager chains.Manager
 
@@ -113,6 +149,12 @@ type Node struct {
 	// current validators of the network
 	vdrs validators.Manager
 
+	// Benches validators that repeatedly fail to respond to queries
+	benchlistManager benchlist.Manager
+
+	// Records messages dropped by the network and the chain router
+	dropLog *drops.Log
+
 	// Handles HTTP API calls
 	APIServer api.Server
 
@@ -130,13 +172,43 @@ type Node struct {
  */
 
 func (n *Node) initNetworking() error {
-	listener, err := net.Listen(TCP, fmt.Sprintf(":%d", n.Config.StakingIP.Port))
+	listener, err := net.Listen(TCP, net.JoinHostPort(n.Config.StakingHost, strconv.Itoa(int(n.Config.StakingIP.Port))))
 	if err != nil {
 		return err
 	}
+	extraListeners := make([]network.AdvertisedListener, 0, len(n.Config.StakingListenAddresses))
+	for _, addr := range n.Config.StakingListenAddresses {
+		extraListener, err := net.Listen(TCP, addr.Address)
+		if err != nil {
+			_ = listener.Close()
+			for _, extraListener := range extraListeners {
+				_ = extraListener.Close()
+			}
+			return fmt.Errorf("couldn't listen on %s: %w", addr.Address, err)
+		}
+		extraListeners = append(extraListeners, network.AdvertisedListener{
+			Listener: extraListener,
+			IP:       addr.IP,
+		})
+	}
 	dialer := network.NewDialer(TCP)
+	if n.Config.NetworkProxy.Address != "" {
+		dialer, err = network.NewProxyDialer(TCP, n.Config.NetworkProxy)
+		if err != nil {
+			return err
+		}
+		n.Log.Info("connecting to peers through the SOCKS5 proxy at %s", n.Config.NetworkProxy.Address)
+	}
 
-	var serverUpgrader, clientUpgrader network.Upgrader
+	n.dropLog, err = drops.NewLog(droppedMessagesLogSize, constants.PlatformName, n.Config.ConsensusParams.Metrics)
+	if err != nil {
+		return err
+	}
+
+	var (
+		serverUpgrader, clientUpgrader network.Upgrader
+		stakingCert                    *tls.Certificate
+	)
 	if n.Config.EnableP2PTLS {
 		cert, err := tls.LoadX509KeyPair(n.Config.StakingCertFile, n.Config.StakingKeyFile)
 		if err != nil {
@@ -154,10 +226,15 @@ func (n *Node) initNetworking() error {
 			// During our security audit by Quantstamp, this was investigated
 			// and determinted to be safe and correct.
 			InsecureSkipVerify: true,
+			MinVersion:         n.Config.StakingTLSMinVersion,
+			CipherSuites:       n.Config.StakingTLSCipherSuites,
 		}
 
 		serverUpgrader = network.NewTLSServerUpgrader(tlsConfig)
 		clientUpgrader = network.NewTLSClientUpgrader(tlsConfig)
+		if n.Config.NetworkSignedIPsEnabled {
+			stakingCert = &cert
+		}
 	} else {
 		serverUpgrader = network.NewIPUpgrader()
 		clientUpgrader = network.NewIPUpgrader()
@@ -202,6 +279,11 @@ func (n *Node) initNetworking() error {
 		}
 	}
 
+	var peerDB database.Database
+	if n.Config.PeerStoreEnabled {
+		peerDB = prefixdb.New([]byte("peers"), n.DB)
+	}
+
 	n.Net = network.NewDefaultNetwork(
 		n.Config.ConsensusParams.Metrics,
 		n.Log,
@@ -210,6 +292,7 @@ func (n *Node) initNetworking() error {
 		n.Config.NetworkID,
 		Version,
 		versionParser,
+		featuresVersion,
 		listener,
 		dialer,
 		serverUpgrader,
@@ -217,8 +300,25 @@ func (n *Node) initNetworking() error {
 		primaryNetworkValidators,
 		n.beacons,
 		consensusRouter,
+		n.dropLog,
 		n.Config.ConnMeterResetDuration,
 		n.Config.ConnMeterMaxConns,
+		n.Config.NetworkCompressionEnabled,
+		n.vdrs,
+		n.Config.MaxPeers,
+		n.Config.PeerSendBandwidth,
+		n.Config.SendBandwidth,
+		n.Config.ConnMeterMaxSubnetConns,
+		n.Config.MaxPendingHandshakes,
+		n.Config.MaxPendingHandshakesPerIP,
+		&n.Config.PeerGate,
+		peerDB,
+		n.Config.MinSubnetPeers,
+		n.Config.ConsensusIdleGossipSize,
+		stakingCert,
+		n.Config.NetworkRequireSignedIPs,
+		n.Config.NetworkStateSyncEnabled,
+		extraListeners,
 	)
 
 	n.nodeCloser = utils.HandleSignals(func(os.Signal) {
@@ -307,6 +407,33 @@ func (n *Node) Dispatch() error {
 		_ = n.Net.Close() // If the server isn't up, shut down the node.
 	})
 
+	// Start the unix socket endpoint
+	if n.Config.HTTPUnixSocket != "" {
+		go n.Log.RecoverAndPanic(func() {
+			err := n.APIServer.DispatchUnix(n.Config.HTTPUnixSocket, n.Config.HTTPUnixSocketMode)
+			n.Log.Error("API server on unix socket %q failed with %s", n.Config.HTTPUnixSocket, err)
+		})
+	}
+
+	// Start the gRPC endpoint
+	if n.grpcGateway != nil {
+		go n.Log.RecoverAndPanic(func() {
+			listenAddress := net.JoinHostPort(n.Config.HTTPHost, strconv.Itoa(int(n.Config.GRPCPort)))
+			var err error
+			if n.Config.HTTPSEnabled {
+				err = n.grpcGateway.DispatchTLS(listenAddress, n.Config.HTTPSCertFile, n.Config.HTTPSKeyFile)
+			} else {
+				err = n.grpcGateway.Dispatch(listenAddress)
+			}
+			n.Log.Error("gRPC gateway failed with %s", err)
+		})
+	}
+
+	// Start executing scheduled API calls
+	if n.scheduler != nil {
+		go n.Log.RecoverAndPanic(n.scheduler.Dispatch)
+	}
+
 	// Add bootstrap nodes to the peer network
 	for _, peer := range n.Config.BootstrapPeers {
 		if !peer.IP.Equal(n.Config.StakingIP.IP()) {
@@ -389,6 +516,8 @@ func (n *Node) initNodeID() error {
 
 // Create the IDs of the peers this node should first connect to
 func (n *Node) initBeacons() error {
+	n.resolveDNSSeeds()
+
 	n.beacons = validators.NewSet()
 	for _, peer := range n.Config.BootstrapPeers {
 		if err := n.beacons.AddWeight(peer.ID, 1); err != nil {
@@ -398,6 +527,40 @@ func (n *Node) initBeacons() error {
 	return nil
 }
 
+// Add the peers listed in the seed records of the DNS seeds to the peers this
+// node should first connect to. Seeds that can't be resolved are skipped, as
+// the node may still be able to bootstrap from the other peers.
+func (n *Node) resolveDNSSeeds() {
+	known := ids.ShortSet{}
+	for _, peer := range n.Config.BootstrapPeers {
+		known.Add(peer.ID)
+	}
+	for _, domain := range n.Config.BootstrapDNSSeeds {
+		ctx, cancel := context.WithTimeout(context.Background(), dnsSeedTimeout)
+		peers, err := dnsseed.Resolve(ctx, net.DefaultResolver, domain, n.Config.BootstrapDNSSeedSigners)
+		cancel()
+		if err != nil {
+			n.Log.Warn("couldn't resolve DNS seed: %s", err)
+			continue
+		}
+		n.Log.Info("resolved %d bootstrap peers from DNS seed %s", len(peers), domain)
+		for _, seedPeer := range peers {
+			peer := &Peer{
+				IP: seedPeer.IP,
+				ID: seedPeer.ID,
+			}
+			if !n.Config.EnableP2PTLS {
+				peer.ID = ids.NewShortID(hashing.ComputeHash160Array([]byte(peer.IP.String())))
+			}
+			if known.Contains(peer.ID) {
+				continue
+			}
+			known.Add(peer.ID)
+			n.Config.BootstrapPeers = append(n.Config.BootstrapPeers, peer)
+		}
+	}
+}
+
 // Create the EventDispatcher used for hooking events
 // into the general process flow.
 func (n *Node) initEventDispatcher() error {
@@ -447,14 +610,27 @@ func (n *Node) initChains(genesisBytes []byte, avaxAssetID ids.ID) error {
 func (n *Node) initAPIServer() error {
 	n.Log.Info("Initializing API server")
 
-	return n.APIServer.Initialize(
+	err := n.APIServer.Initialize(
 		n.Log,
 		n.LogFactory,
 		n.Config.HTTPHost,
 		n.Config.HTTPPort,
 		n.Config.APIRequireAuthToken,
 		n.Config.APIAuthPassword,
+		prefixdb.New([]byte("auth"), n.DB),
+		n.Config.APIRequestTimeout,
 	)
+	if err != nil {
+		return err
+	}
+	n.APIServer.SetCORS(n.Config.APICORSConfig)
+	if err := n.APIServer.SetTimeouts(n.Config.APITimeouts); err != nil {
+		return err
+	}
+	if err := n.APIServer.SetCompressionLevel(n.Config.APICompressionLevel); err != nil {
+		return err
+	}
+	return n.APIServer.SetRateLimits(n.Config.APIRateLimits)
 }
 
 // Create the vmManager, chainManager and register the following vms:
@@ -476,10 +652,10 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 	n.Config.NetworkConfig.Namespace = constants.PlatformName
 	n.Config.NetworkConfig.Registerer = n.Config.ConsensusParams.Metrics
 	n.Config.BenchlistConfig.Validators = n.vdrs
-	benchlistManager := benchlist.NewManager(&n.Config.BenchlistConfig)
+	n.benchlistManager = benchlist.NewManager(&n.Config.BenchlistConfig)
 
 	timeoutManager := timeout.Manager{}
-	if err := timeoutManager.Initialize(&n.Config.NetworkConfig, benchlistManager); err != nil {
+	if err := timeoutManager.Initialize(&n.Config.NetworkConfig, n.benchlistManager); err != nil {
 		return err
 	}
 	go n.Log.RecoverAndPanic(timeoutManager.Dispatch)
@@ -487,8 +663,11 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 	n.Config.ConsensusRouter.Initialize(
 		n.ID,
 		n.Log,
+		n.dropLog,
 		&timeoutManager,
 		n.Config.ConsensusGossipFrequency,
+		n.Config.ConsensusMinGossipFrequency,
+		n.Config.ConsensusMaxGossipFrequency,
 		n.Config.ConsensusShutdownTimeout,
 		criticalChains,
 		func() {
@@ -522,7 +701,22 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		XChainID:                xChainID,
 		CriticalChains:          criticalChains,
 		TimeoutManager:          &timeoutManager,
+		PeerReporter:            n.benchlistManager,
 		HealthService:           n.healthService,
+
+		BootstrapArchiveURI:       n.Config.BootstrapArchiveURI,
+		BootstrapArchiveAuthToken: n.Config.BootstrapArchiveAuthToken,
+		BootstrapFetchWindow:      n.Config.BootstrapFetchWindow,
+
+		CheckpointFrequency: n.Config.CheckpointFrequency,
+		MaxCheckpointAge:    n.Config.MaxCheckpointAge,
+
+		CacheSizes:       n.Config.ChainCacheSizes,
+		ConsensusConfigs: n.Config.ChainConsensusConfigs,
+		PruneRejected:    n.Config.PruneRejected,
+		RecordChains:     n.Config.ConsensusRecordChains,
+		RecordDir:        n.Config.ConsensusRecordDir,
+		SkippedChains:    n.Config.SkippedChains,
 	})
 
 	vdrs := n.vdrs
@@ -550,10 +744,13 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			MinStakeDuration:   n.Config.MinStakeDuration,
 			MaxStakeDuration:   n.Config.MaxStakeDuration,
 			StakeMintingPeriod: n.Config.StakeMintingPeriod,
+			IndexAddressTxs:    n.Config.PlatformAddressTxIndexEnabled,
+			PruneRejected:      n.Config.PruneRejected,
 		}),
 		n.vmManager.RegisterVMFactory(avm.ID, &avm.Factory{
-			CreationFee: n.Config.CreationTxFee,
-			Fee:         n.Config.TxFee,
+			CreationFee:  n.Config.CreationTxFee,
+			Fee:          n.Config.TxFee,
+			BatchTimeout: n.Config.AVMBatchDelay,
 		}),
 		n.vmManager.RegisterVMFactory(genesis.EVMID, &rpcchainvm.Factory{
 			Path: filepath.Join(n.Config.PluginDir, "evm"),
@@ -567,10 +764,69 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		return errs.Err
 	}
 
+	// Register any other VM plugins that were installed
+	loaded, failed, err := rpcchainvm.LoadPlugins(n.vmManager, n.Config.PluginDir)
+	if err != nil {
+		n.Log.Warn("couldn't load VM plugins: %s", err)
+	}
+	for path, vmID := range loaded {
+		n.Log.Info("registered VM %s from plugin %s", vmID, path)
+	}
+	for path, err := range failed {
+		n.Log.Debug("couldn't register plugin %s: %s", path, err)
+	}
+
 	n.chainManager.AddRegistrant(&n.APIServer)
+	n.chainManager.AddRegistrant(&finalityRegistrant{
+		log:          n.Log,
+		server:       &n.APIServer,
+		chainManager: n.chainManager,
+	})
+	if n.Config.BootstrapArchiveEnabled {
+		n.chainManager.AddRegistrant(&archiveRegistrant{
+			log:    n.Log,
+			server: &n.APIServer,
+		})
+	}
 	return nil
 }
 
+// archiveRegistrant serves the accepted blocks of every snowman chain so that
+// other nodes can use this node as a bootstrap archive
+type archiveRegistrant struct {
+	log    logging.Logger
+	server *api.Server
+}
+
+func (a *archiveRegistrant) RegisterChain(ctx *snow.Context, vmIntf interface{}) {
+	vm, ok := vmIntf.(block.ChainVM)
+	if !ok {
+		return
+	}
+	handler := archive.NewHandler(ctx, vm)
+	if err := a.server.AddChainRoute(handler, ctx, "bc/"+ctx.ChainID.String(), archive.Endpoint, ctx.Log); err != nil {
+		a.log.Error("couldn't add bootstrap archive route for chain %s: %s", ctx.ChainID, err)
+	}
+}
+
+// finalityRegistrant serves the finality API on every chain
+type finalityRegistrant struct {
+	log          logging.Logger
+	server       *api.Server
+	chainManager chains.Manager
+}
+
+func (f *finalityRegistrant) RegisterChain(ctx *snow.Context, _ interface{}) {
+	handler, err := finality.NewService(ctx.Log, f.chainManager, ctx.ChainID)
+	if err != nil {
+		f.log.Error("couldn't create finality API for chain %s: %s", ctx.ChainID, err)
+		return
+	}
+	if err := f.server.AddChainRoute(handler, ctx, "bc/"+ctx.ChainID.String(), finality.Endpoint, ctx.Log); err != nil {
+		f.log.Error("couldn't add finality API route for chain %s: %s", ctx.ChainID, err)
+	}
+}
+
 // initSharedMemory initializes the shared memory for cross chain interation
 func (n *Node) initSharedMemory() {
 	n.Log.Info("initializing SharedMemory")
@@ -603,6 +859,14 @@ func (n *Node) initMetricsAPI() error {
 	// It is assumed by components of the system that the Metrics interface is
 	// non-nil. So, it is set regardless of if the metrics API is available or not.
 	n.Config.ConsensusParams.Metrics = registry
+
+	apiNamespace := fmt.Sprintf("%s_api", constants.PlatformName)
+	if err := n.APIServer.RegisterMetrics(apiNamespace, registry); err != nil {
+		return err
+	}
+	if err := n.initMetricsPusher(registry); err != nil {
+		return err
+	}
 	if !n.Config.MetricsAPIEnabled {
 		n.Log.Info("skipping metrics API initialization because it has been disabled")
 		return nil
@@ -620,6 +884,25 @@ func (n *Node) initMetricsAPI() error {
 	return n.APIServer.AddRoute(handler, &sync.RWMutex{}, "metrics", "", n.HTTPLog)
 }
 
+// initMetricsPusher starts pushing the metrics of [gatherer] if a push URL
+// is configured
+func (n *Node) initMetricsPusher(gatherer prometheus.Gatherer) error {
+	config := n.Config.MetricsPushConfig
+	if config.URL == "" {
+		return nil
+	}
+	config.Instance = n.ID.PrefixedString(constants.NodeIDPrefix)
+
+	n.Log.Info("pushing metrics to %s every %s", config.URL, config.Interval)
+	pusher, err := metrics.NewPusher(n.Log, config, gatherer)
+	if err != nil {
+		return err
+	}
+	n.metricsPusher = pusher
+	go n.Log.RecoverAndPanic(pusher.Dispatch)
+	return nil
+}
+
 // initAdminAPI initializes the Admin API service
 // Assumes n.log, n.chainManager, and n.ValidatorAPI already initialized
 func (n *Node) initAdminAPI() error {
@@ -628,7 +911,24 @@ func (n *Node) initAdminAPI() error {
 		return nil
 	}
 	n.Log.Info("initializing admin API")
-	service, err := admin.NewService(n.Log, n.chainManager, &n.APIServer)
+	service, err := admin.NewService(
+		n.Log,
+		n.LogFactory,
+		n.chainManager,
+		n.vmManager,
+		n.Net,
+		n.Config.PluginDir,
+		n.Config.ProfileDir,
+		n.Config.NetworkCaptureDir,
+		&n.APIServer,
+		n.DB,
+		n.dropLog,
+		admin.BackupConfig{
+			NodeVersion:     Version.String(),
+			DatabaseVersion: DatabaseVersion,
+			NetworkID:       n.Config.NetworkID,
+		},
+	)
 	if err != nil {
 		return err
 	}
@@ -644,12 +944,20 @@ func (n *Node) initInfoAPI() error {
 	service, err := info.NewService(
 		n.Log,
 		Version,
+		n.Config.GitCommit,
+		DatabaseVersion,
 		n.ID,
 		n.Config.NetworkID,
 		n.chainManager,
+		n.vmManager,
 		n.Net,
+		n.vdrs,
+		n.benchlistManager,
+		&n.APIServer,
 		n.Config.CreationTxFee,
 		n.Config.TxFee,
+		n.Config.UptimeRequirement,
+		n.Config.PortMapper,
 	)
 	if err != nil {
 		return err
@@ -670,20 +978,25 @@ func (n *Node) initHealthAPI() error {
 		return fmt.Errorf("couldn't register heartbeat health check: %w", err)
 	}
 	isBootstrappedFunc := func() (interface{}, error) {
-		if pChainID, err := n.chainManager.Lookup("P"); err != nil {
-			return nil, errors.New("P-Chain not created")
-		} else if !n.chainManager.IsBootstrapped(pChainID) {
-			return nil, errors.New("P-Chain not bootstrapped")
-		}
-		if xChainID, err := n.chainManager.Lookup("X"); err != nil {
-			return nil, errors.New("X-Chain not created")
-		} else if !n.chainManager.IsBootstrapped(xChainID) {
-			return nil, errors.New("X-Chain not bootstrapped")
-		}
-		if cChainID, err := n.chainManager.Lookup("C"); err != nil {
-			return nil, errors.New("C-Chain not created")
-		} else if !n.chainManager.IsBootstrapped(cChainID) {
-			return nil, errors.New("C-Chain not bootstrapped")
+		for _, alias := range []string{"P", "X", "C"} {
+			chainID, err := n.chainManager.Lookup(alias)
+			if err != nil {
+				return nil, fmt.Errorf("%s-Chain not created", alias)
+			}
+			if n.chainManager.IsBootstrapped(chainID) {
+				continue
+			}
+			// Report the chain's progress, so that a chain that's slowly
+			// bootstrapping can be told apart from one that's stuck
+			progress, err := n.chainManager.BootstrapProgress(chainID)
+			if err != nil {
+				return nil, fmt.Errorf("%s-Chain not bootstrapped", alias)
+			}
+			details := map[string]interface{}{
+				"chain":    alias,
+				"progress": api.NewBootstrapProgress(progress),
+			}
+			return details, fmt.Errorf("%s-Chain not bootstrapped", alias)
 		}
 		return nil, nil
 	}
@@ -691,6 +1004,23 @@ func (n *Node) initHealthAPI() error {
 	if err := service.RegisterMonotonicCheckFunc("chains.default.bootstrapped", isBootstrappedFunc); err != nil {
 		return err
 	}
+	// Passes if the database can be read from
+	isDatabaseReadableFunc := func() (interface{}, error) {
+		_, err := n.DB.Has(genesisHashKey)
+		return nil, err
+	}
+	if err := service.RegisterCheck(health.NewCheck("database", isDatabaseReadableFunc)); err != nil {
+		return fmt.Errorf("couldn't register database health check: %w", err)
+	}
+	// Passes if the P and X chains are running
+	if err := service.RegisterCheck(health.NewCheck("router", n.Config.ConsensusRouter.HealthCheck)); err != nil {
+		return fmt.Errorf("couldn't register router health check: %w", err)
+	}
+	for _, check := range n.Config.ExternalHealthChecks {
+		if err := service.RegisterExternalCheck(check); err != nil {
+			return fmt.Errorf("couldn't register health check %q: %w", check.Name, err)
+		}
+	}
 	handler, err := service.Handler()
 	if err != nil {
 		return err
@@ -714,6 +1044,60 @@ func (n *Node) initIPCAPI() error {
 	return n.APIServer.AddRoute(service, &sync.RWMutex{}, "ipcs", "", n.HTTPLog)
 }
 
+// initEventsAPI initializes the Events API service
+// Assumes n.Log, n.chainManager, n.DecisionDispatcher and n.APIServer already
+// initialized
+func (n *Node) initEventsAPI() error {
+	if !n.Config.EventsAPIEnabled {
+		n.Log.Info("skipping events API initialization because it has been disabled")
+		return nil
+	}
+	n.Log.Info("initializing events API")
+	service := events.NewService(n.Log, n.chainManager, n.Config.APICORSConfig)
+	if err := n.DecisionDispatcher.Register(events.Endpoint, service); err != nil {
+		return err
+	}
+	return n.APIServer.AddRoute(service.Handler(), &sync.RWMutex{}, events.Endpoint, "", n.HTTPLog)
+}
+
+// initGRPCGateway initializes the gateway serving APIs over gRPC
+// Assumes n.Log and n.APIServer already initialized
+func (n *Node) initGRPCGateway() {
+	if !n.Config.GRPCAPIEnabled {
+		n.Log.Info("skipping gRPC API initialization because it has been disabled")
+		return
+	}
+	n.Log.Info("initializing gRPC API")
+	n.grpcGateway = gateway.New(n.Log, n.APIServer.Handler(), n.Config.APICORSConfig)
+}
+
+// initSchedulerAPI initializes the Scheduler API service
+// Assumes n.Log, n.DB, n.APIServer, n.keystoreServer and n.healthService
+// already initialized
+func (n *Node) initSchedulerAPI() error {
+	if !n.Config.SchedulerAPIEnabled {
+		n.Log.Info("skipping scheduler API initialization because it has been disabled")
+		return nil
+	}
+	n.Log.Info("initializing scheduler API")
+	schedulerDB := prefixdb.New([]byte("scheduler"), n.DB)
+	s, err := scheduler.New(n.Log, schedulerDB, http.HandlerFunc(n.APIServer.ServeInternal))
+	if err != nil {
+		return err
+	}
+	if n.healthService != nil {
+		if err := n.healthService.RegisterCheck(health.NewCheck("scheduler.tasks", s.HealthCheck)); err != nil {
+			return fmt.Errorf("couldn't register scheduler health check: %w", err)
+		}
+	}
+	service, err := scheduler.NewService(s, &n.APIServer, &n.keystoreServer)
+	if err != nil {
+		return err
+	}
+	n.scheduler = s
+	return n.APIServer.AddRoute(service, &sync.RWMutex{}, scheduler.Endpoint, "", n.HTTPLog)
+}
+
 // Give chains and VMs aliases as specified by the genesis information
 func (n *Node) initAliases(genesisBytes []byte) error {
 	n.Log.Info("initializing aliases")
@@ -816,6 +1200,14 @@ func (n *Node) Initialize(config *Config, logger logging.Logger, logFactory logg
 	if err := n.initIPCAPI(); err != nil { // Start the IPC API
 		return fmt.Errorf("couldn't initialize the IPC API: %w", err)
 	}
+	if err := n.initEventsAPI(); err != nil { // Start the Events API
+		return fmt.Errorf("couldn't initialize the events API: %w", err)
+	}
+	// Start the gRPC API
+	n.initGRPCGateway()
+	if err := n.initSchedulerAPI(); err != nil { // Start the Scheduler API
+		return fmt.Errorf("couldn't initialize the scheduler API: %w", err)
+	}
 	if err := n.initAliases(genesisBytes); err != nil { // Set up aliases
 		return fmt.Errorf("couldn't initialize aliases: %w", err)
 	}
@@ -831,6 +1223,12 @@ func (n *Node) Shutdown() {
 	// Close already logs its own error if one occurs, so the error is ignored
 	// here
 	_ = n.Net.Close()
+	if n.scheduler != nil {
+		n.scheduler.Close()
+	}
+	if n.metricsPusher != nil {
+		n.metricsPusher.Stop()
+	}
 	n.chainManager.Shutdown()
 	utils.ClearSignals(n.nodeCloser)
 	n.Log.Info("node shut down successfully")
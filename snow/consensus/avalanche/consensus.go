@@ -53,6 +53,10 @@ type Consensus interface {
 	// Returns a set of vertex IDs that are preferred
 	Preferences() ids.Set
 
+	// Graph returns the vertices that have been added but not yet accepted or
+	// rejected, along with the consensus state of their transactions
+	Graph() (Graph, error)
+
 	// RecordPoll collects the results of a network poll. If a result has not
 	// been added, the result is dropped. Returns if a critical error has
 	// occurred.
@@ -68,3 +72,38 @@ type Consensus interface {
 	// decision may be added such that this instance is no longer finalized.
 	Finalized() bool
 }
+
+// Graph describes the vertices being processed by an Avalanche instance
+type Graph struct {
+	// Vertices are the processing vertices, sorted by ID
+	Vertices []VertexState `json:"vertices"`
+
+	// Txs are the processing transactions, sorted by ID
+	Txs []snowstorm.TxState `json:"txs"`
+
+	// Preferred is the frontier of strongly preferred vertices
+	Preferred []ids.ID `json:"preferred"`
+
+	// Virtuous is the frontier of strongly virtuous vertices
+	Virtuous []ids.ID `json:"virtuous"`
+
+	// Orphans are the transactions that are virtuous, but not in any
+	// preferred vertex
+	Orphans []ids.ID `json:"orphans"`
+}
+
+// VertexState is the consensus state of a processing vertex
+type VertexState struct {
+	ID      ids.ID   `json:"id"`
+	Height  uint64   `json:"height"`
+	Parents []ids.ID `json:"parents"`
+	Txs     []ids.ID `json:"txs"`
+
+	// StronglyPreferred is true if this vertex, and each of its processing
+	// ancestors, only contains preferred transactions
+	StronglyPreferred bool `json:"stronglyPreferred"`
+
+	// StronglyVirtuous is true if this vertex, and each of its processing
+	// ancestors, only contains virtuous transactions
+	StronglyVirtuous bool `json:"stronglyVirtuous"`
+}
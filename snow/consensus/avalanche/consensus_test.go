@@ -29,6 +29,7 @@ var (
 		VirtuousTest,
 		VirtuousSkippedUpdateTest,
 		VotingTest,
+		GraphTest,
 		IgnoreInvalidVotingTest,
 		TransitiveVotingTest,
 		SplitVotingTest,
@@ -748,6 +749,121 @@ func VotingTest(t *testing.T, factory Factory) {
 	}
 }
 
+func GraphTest(t *testing.T, factory Factory) {
+	avl := factory.New()
+
+	params := Parameters{
+		Parameters: snowball.Parameters{
+			Metrics:           prometheus.NewRegistry(),
+			K:                 2,
+			Alpha:             2,
+			BetaVirtuous:      1,
+			BetaRogue:         2,
+			ConcurrentRepolls: 1,
+		},
+		Parents:   2,
+		BatchSize: 1,
+	}
+	vts := []Vertex{
+		&TestVertex{TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		}},
+		&TestVertex{TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		}},
+	}
+	utxos := []ids.ID{ids.GenerateTestID()}
+
+	err := avl.Initialize(snow.DefaultContextTest(), params, vts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx0 := &snowstorm.TestTx{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Processing,
+	}}
+	tx0.InputIDsV.Add(utxos[0])
+
+	vtx0 := &TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: vts,
+		HeightV:  1,
+		TxsV:     []snowstorm.Tx{tx0},
+	}
+
+	tx1 := &snowstorm.TestTx{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Processing,
+	}}
+	tx1.InputIDsV.Add(utxos[0])
+
+	vtx1 := &TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: vts,
+		HeightV:  1,
+		TxsV:     []snowstorm.Tx{tx1},
+	}
+
+	if err := avl.Add(vtx0); err != nil {
+		t.Fatal(err)
+	} else if err := avl.Add(vtx1); err != nil {
+		t.Fatal(err)
+	}
+
+	sm := ids.UniqueBag{}
+	sm.Add(0, vtx1.IDV)
+	sm.Add(1, vtx1.IDV)
+	if err := avl.RecordPoll(sm); err != nil {
+		t.Fatal(err)
+	}
+
+	graph, err := avl.Graph()
+	switch {
+	case err != nil:
+		t.Fatal(err)
+	case len(graph.Vertices) != 2:
+		t.Fatalf("Wrong number of processing vertices")
+	case len(graph.Txs) != 2:
+		t.Fatalf("Wrong number of processing transactions")
+	case !ids.UnsortedEquals([]ids.ID{vtx1.IDV}, graph.Preferred):
+		t.Fatalf("Wrong preferred frontier")
+	case !ids.UnsortedEquals([]ids.ID{vts[0].ID(), vts[1].ID()}, graph.Virtuous):
+		t.Fatalf("Conflicting vertices shouldn't be in the virtuous frontier")
+	}
+
+	for _, vtx := range graph.Vertices {
+		switch {
+		case !ids.UnsortedEquals([]ids.ID{vts[0].ID(), vts[1].ID()}, vtx.Parents):
+			t.Fatalf("Wrong parents")
+		case vtx.Height != 1:
+			t.Fatalf("Wrong height")
+		case vtx.StronglyVirtuous:
+			t.Fatalf("Conflicting vertices shouldn't be strongly virtuous")
+		case vtx.ID.Equals(vtx0.IDV) && vtx.StronglyPreferred:
+			t.Fatalf("vtx0 shouldn't be strongly preferred")
+		case vtx.ID.Equals(vtx1.IDV) && !vtx.StronglyPreferred:
+			t.Fatalf("vtx1 should be strongly preferred")
+		case vtx.ID.Equals(vtx1.IDV) && !ids.UnsortedEquals([]ids.ID{tx1.IDV}, vtx.Txs):
+			t.Fatalf("Wrong transactions")
+		}
+	}
+
+	for _, tx := range graph.Txs {
+		if tx.ID.Equals(tx1.IDV) && (!tx.Preferred || tx.Confidence != 1) {
+			t.Fatalf("tx1 should be preferred with a confidence of 1")
+		}
+	}
+}
+
 func IgnoreInvalidVotingTest(t *testing.T, factory Factory) {
 	avl := factory.New()
 
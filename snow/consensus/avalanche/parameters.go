@@ -14,6 +14,11 @@ import (
 type Parameters struct {
 	snowball.Parameters
 	Parents, BatchSize int
+
+	// MaxBatchSize is the largest number of transactions batched into a
+	// vertex when more transactions are pending than fit in BatchSize. If 0,
+	// vertices never contain more than BatchSize transactions.
+	MaxBatchSize int
 }
 
 // Valid returns nil if the parameters describe a valid initialization.
@@ -23,6 +28,8 @@ func (p Parameters) Valid() error {
 		return fmt.Errorf("parents = %d: Fails the condition that: 1 < Parents", p.Parents)
 	case p.BatchSize <= 0:
 		return fmt.Errorf("batchSize = %d: Fails the condition that: 0 < BatchSize", p.BatchSize)
+	case p.MaxBatchSize != 0 && p.MaxBatchSize < p.BatchSize:
+		return fmt.Errorf("batchSize = %d, maxBatchSize = %d: Fails the condition that: BatchSize <= MaxBatchSize", p.BatchSize, p.MaxBatchSize)
 	default:
 		return p.Parameters.Valid()
 	}
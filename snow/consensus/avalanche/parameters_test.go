@@ -62,3 +62,22 @@ func TestParametersInvalidBatchSize(t *testing.T) {
 		t.Fatalf("Should have failed due to invalid batch size")
 	}
 }
+
+func TestParametersInvalidMaxBatchSize(t *testing.T) {
+	p := Parameters{
+		Parameters: snowball.Parameters{
+			K:                 1,
+			Alpha:             1,
+			BetaVirtuous:      1,
+			BetaRogue:         1,
+			ConcurrentRepolls: 1,
+		},
+		Parents:      2,
+		BatchSize:    2,
+		MaxBatchSize: 1,
+	}
+
+	if err := p.Valid(); err == nil {
+		t.Fatalf("Should have failed due to invalid max batch size")
+	}
+}
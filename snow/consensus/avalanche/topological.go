@@ -4,6 +4,9 @@
 package avalanche
 
 import (
+	"bytes"
+	"sort"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
@@ -149,6 +152,56 @@ func (ta *Topological) Virtuous() ids.Set { return ta.virtuous }
 // Preferences implements the Avalanche interface
 func (ta *Topological) Preferences() ids.Set { return ta.preferred }
 
+// Graph implements the Avalanche interface
+func (ta *Topological) Graph() (Graph, error) {
+	vertices := make([]VertexState, 0, len(ta.nodes))
+	for key, vtx := range ta.nodes {
+		height, err := vtx.Height()
+		if err != nil {
+			return Graph{}, err
+		}
+		parents, err := vtx.Parents()
+		if err != nil {
+			return Graph{}, err
+		}
+		txs, err := vtx.Txs()
+		if err != nil {
+			return Graph{}, err
+		}
+
+		parentIDs := make([]ids.ID, len(parents))
+		for i, parent := range parents {
+			parentIDs[i] = parent.ID()
+		}
+		txIDs := make([]ids.ID, len(txs))
+		for i, tx := range txs {
+			txIDs[i] = tx.ID()
+		}
+		ids.SortIDs(parentIDs)
+		ids.SortIDs(txIDs)
+
+		vertices = append(vertices, VertexState{
+			ID:                vtx.ID(),
+			Height:            height,
+			Parents:           parentIDs,
+			Txs:               txIDs,
+			StronglyPreferred: ta.preferenceCache[key],
+			StronglyVirtuous:  ta.virtuousCache[key],
+		})
+	}
+	sort.Slice(vertices, func(i, j int) bool {
+		return bytes.Compare(vertices[i].ID.Bytes(), vertices[j].ID.Bytes()) == -1
+	})
+
+	return Graph{
+		Vertices:  vertices,
+		Txs:       ta.cg.Processing(),
+		Preferred: ids.SortedIDs(ta.preferred.List()),
+		Virtuous:  ids.SortedIDs(ta.virtuous.List()),
+		Orphans:   ids.SortedIDs(ta.orphans.List()),
+	}, nil
+}
+
 // RecordPoll implements the Avalanche interface
 func (ta *Topological) RecordPoll(responses ids.UniqueBag) error {
 	// If it isn't possible to have alpha votes for any transaction, then we can
@@ -437,7 +490,7 @@ func (ta *Topological) update(vtx Vertex) error {
 			if err := vtx.Reject(); err != nil {
 				return err
 			}
-			ta.ctx.ConsensusDispatcher.Reject(ta.ctx, vtxID, vtx.Bytes())
+			ta.ctx.Rejected(snow.VertexContainer, vtxID, vtx.Bytes())
 			delete(ta.nodes, vtxKey)
 			ta.metrics.Rejected(vtxID)
 
@@ -491,7 +544,7 @@ func (ta *Topological) update(vtx Vertex) error {
 		if err := vtx.Accept(); err != nil {
 			return err
 		}
-		ta.ctx.ConsensusDispatcher.Accept(ta.ctx, vtxID, vtx.Bytes())
+		ta.ctx.Accepted(snow.VertexContainer, vtxID, vtx.Bytes())
 		delete(ta.nodes, vtxKey)
 		ta.metrics.Accepted(vtxID)
 	case rejectable:
@@ -499,7 +552,7 @@ func (ta *Topological) update(vtx Vertex) error {
 		if err := vtx.Reject(); err != nil {
 			return err
 		}
-		ta.ctx.ConsensusDispatcher.Reject(ta.ctx, vtxID, vtx.Bytes())
+		ta.ctx.Rejected(snow.VertexContainer, vtxID, vtx.Bytes())
 		delete(ta.nodes, vtxKey)
 		ta.metrics.Rejected(vtxID)
 	}
@@ -32,6 +32,10 @@ type Parameters struct {
 	Namespace                                            string
 	Metrics                                              prometheus.Registerer
 	K, Alpha, BetaVirtuous, BetaRogue, ConcurrentRepolls int
+
+	// MaxK is the largest number of nodes a poll may sample when many queries
+	// fail. If 0, polls always sample K nodes.
+	MaxK int
 }
 
 // Valid returns nil if the parameters describe a valid initialization.
@@ -51,6 +55,10 @@ func (p Parameters) Valid() error {
 		return fmt.Errorf("ConcurrentRepolls = %d: Fails the condition that: 0 < ConcurrentRepolls", p.ConcurrentRepolls)
 	case p.ConcurrentRepolls > p.BetaRogue:
 		return fmt.Errorf("ConcurrentRepolls = %d, BetaRogue = %d: Fails the condition that: ConcurrentRepolls <= BetaRogue", p.ConcurrentRepolls, p.BetaRogue)
+	case p.MaxK != 0 && p.MaxK < p.K:
+		return fmt.Errorf("K = %d, MaxK = %d: Fails the condition that: K <= MaxK", p.K, p.MaxK)
+	case p.Alpha <= p.MaxK/2:
+		return fmt.Errorf("MaxK = %d, Alpha = %d: Fails the condition that: MaxK/2 < Alpha", p.MaxK, p.Alpha)
 	default:
 		return nil
 	}
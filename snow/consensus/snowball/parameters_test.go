@@ -149,3 +149,48 @@ func TestParametersInvalidConcurrentRepolls(t *testing.T) {
 		})
 	}
 }
+
+func TestParametersValidMaxK(t *testing.T) {
+	p := Parameters{
+		K:                 20,
+		Alpha:             14,
+		BetaVirtuous:      1,
+		BetaRogue:         1,
+		ConcurrentRepolls: 1,
+		MaxK:              27,
+	}
+
+	if err := p.Valid(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParametersInvalidMaxKBelowK(t *testing.T) {
+	p := Parameters{
+		K:                 20,
+		Alpha:             14,
+		BetaVirtuous:      1,
+		BetaRogue:         1,
+		ConcurrentRepolls: 1,
+		MaxK:              19,
+	}
+
+	if err := p.Valid(); err == nil {
+		t.Fatalf("Should have failed due to MaxK being less than K")
+	}
+}
+
+func TestParametersInvalidMaxKAlpha(t *testing.T) {
+	p := Parameters{
+		K:                 20,
+		Alpha:             14,
+		BetaVirtuous:      1,
+		BetaRogue:         1,
+		ConcurrentRepolls: 1,
+		MaxK:              28,
+	}
+
+	if err := p.Valid(); err == nil {
+		t.Fatalf("Should have failed due to Alpha not being a majority of MaxK")
+	}
+}
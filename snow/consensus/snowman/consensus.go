@@ -29,6 +29,10 @@ type Consensus interface {
 	// decisions.
 	Preference() ids.ID
 
+	// Processing returns the IDs of the blocks that have been added but not
+	// yet accepted or rejected
+	Processing() ids.Set
+
 	// RecordPoll collects the results of a network poll. Assumes all decisions
 	// have been previously added. Returns if a critical error has occurred.
 	RecordPoll(ids.Bag) error
@@ -32,6 +32,7 @@ var (
 		IssuedPreviouslyRejectedTest,
 		IssuedUnissuedTest,
 		IssuedIssuedTest,
+		ProcessingTest,
 		RecordPollAcceptSingleBlockTest,
 		RecordPollAcceptAndRejectTest,
 		RecordPollWhenFinalizedTest,
@@ -318,6 +319,69 @@ func IssuedIssuedTest(t *testing.T, factory Factory) {
 	}
 }
 
+// Make sure that the processing blocks are reported until they're decided
+func ProcessingTest(t *testing.T, factory Factory) {
+	sm := factory.New()
+
+	ctx := snow.DefaultContextTest()
+	params := snowball.Parameters{
+		Metrics:           prometheus.NewRegistry(),
+		K:                 1,
+		Alpha:             1,
+		BetaVirtuous:      1,
+		BetaRogue:         2,
+		ConcurrentRepolls: 1,
+	}
+	if err := sm.Initialize(ctx, params, GenesisID); err != nil {
+		t.Fatal(err)
+	}
+
+	if processing := sm.Processing(); processing.Len() != 0 {
+		t.Fatalf("Shouldn't have reported the last accepted block as processing")
+	}
+
+	firstBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis,
+	}
+	secondBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: firstBlock,
+	}
+
+	if err := sm.Add(firstBlock); err != nil {
+		t.Fatal(err)
+	}
+	if err := sm.Add(secondBlock); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := ids.Set{}
+	expected.Add(firstBlock.ID(), secondBlock.ID())
+	if processing := sm.Processing(); !processing.Equals(expected) {
+		t.Fatalf("Expected %s to be processing, but %s are", expected, processing)
+	}
+
+	votes := ids.Bag{}
+	votes.Add(firstBlock.ID())
+	if err := sm.RecordPoll(votes); err != nil {
+		t.Fatal(err)
+	}
+
+	expected.Remove(firstBlock.ID())
+	if firstBlock.Status() != choices.Accepted {
+		t.Fatalf("Should have accepted the first block")
+	} else if processing := sm.Processing(); !processing.Equals(expected) {
+		t.Fatalf("Expected %s to be processing, but %s are", expected, processing)
+	}
+}
+
 func RecordPollAcceptSingleBlockTest(t *testing.T, factory Factory) {
 	sm := factory.New()
 
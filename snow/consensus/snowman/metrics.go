@@ -14,17 +14,33 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
 
-type metrics struct {
-	numProcessing            prometheus.Gauge
-	latAccepted, latRejected prometheus.Histogram
+// pollsBuckets are the buckets of the number of polls it takes to decide a
+// block
+var pollsBuckets = []float64{1, 2, 3, 5, 10, 15, 20, 30, 50, 100, 200}
+
+// processingBlock is when a processing block was issued, and how many polls
+// had been recorded by then
+type processingBlock struct {
+	issued time.Time
+	polls  uint64
+}
 
-	clock      timer.Clock
-	processing map[[32]byte]time.Time
+type metrics struct {
+	numProcessing                prometheus.Gauge
+	numAccepted, numRejected     prometheus.Counter
+	numPolls                     prometheus.Counter
+	latAccepted, latRejected     prometheus.Histogram
+	pollsAccepted, pollsRejected prometheus.Histogram
+
+	clock timer.Clock
+	// number of polls recorded so far
+	polls      uint64
+	processing map[[32]byte]processingBlock
 }
 
 // Initialize implements the Engine interface
 func (m *metrics) Initialize(log logging.Logger, namespace string, registerer prometheus.Registerer) error {
-	m.processing = make(map[[32]byte]time.Time)
+	m.processing = make(map[[32]byte]processingBlock)
 
 	m.numProcessing = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
@@ -43,6 +59,33 @@ func (m *metrics) Initialize(log logging.Logger, namespace string, registerer pr
 		Help:      "Latency of rejecting from the time the block was issued in milliseconds",
 		Buckets:   timer.MillisecondsBuckets,
 	})
+	m.numAccepted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "blks_accepted",
+		Help:      "Number of blocks accepted",
+	})
+	m.numRejected = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "blks_rejected",
+		Help:      "Number of blocks rejected",
+	})
+	m.numPolls = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "polls_recorded",
+		Help:      "Number of finished polls whose votes were applied",
+	})
+	m.pollsAccepted = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "polls_to_accept",
+		Help:      "Number of polls recorded from the time the block was issued until it was accepted",
+		Buckets:   pollsBuckets,
+	})
+	m.pollsRejected = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "polls_to_reject",
+		Help:      "Number of polls recorded from the time the block was issued until it was rejected",
+		Buckets:   pollsBuckets,
+	})
 
 	if err := registerer.Register(m.numProcessing); err != nil {
 		return fmt.Errorf("failed to register processing statistics due to %w", err)
@@ -53,14 +96,38 @@ func (m *metrics) Initialize(log logging.Logger, namespace string, registerer pr
 	if err := registerer.Register(m.latRejected); err != nil {
 		return fmt.Errorf("failed to register rejected statistics due to %w", err)
 	}
+	if err := registerer.Register(m.numAccepted); err != nil {
+		return fmt.Errorf("failed to register blks_accepted statistics due to %w", err)
+	}
+	if err := registerer.Register(m.numRejected); err != nil {
+		return fmt.Errorf("failed to register blks_rejected statistics due to %w", err)
+	}
+	if err := registerer.Register(m.numPolls); err != nil {
+		return fmt.Errorf("failed to register polls_recorded statistics due to %w", err)
+	}
+	if err := registerer.Register(m.pollsAccepted); err != nil {
+		return fmt.Errorf("failed to register polls_to_accept statistics due to %w", err)
+	}
+	if err := registerer.Register(m.pollsRejected); err != nil {
+		return fmt.Errorf("failed to register polls_to_reject statistics due to %w", err)
+	}
 	return nil
 }
 
 func (m *metrics) Issued(id ids.ID) {
-	m.processing[id.Key()] = m.clock.Time()
+	m.processing[id.Key()] = processingBlock{
+		issued: m.clock.Time(),
+		polls:  m.polls,
+	}
 	m.numProcessing.Inc()
 }
 
+// Polled is called each time the votes of a finished poll are applied
+func (m *metrics) Polled() {
+	m.polls++
+	m.numPolls.Inc()
+}
+
 func (m *metrics) Accepted(id ids.ID) {
 	key := id.Key()
 	start := m.processing[key]
@@ -68,7 +135,9 @@ func (m *metrics) Accepted(id ids.ID) {
 
 	delete(m.processing, key)
 
-	m.latAccepted.Observe(float64(end.Sub(start).Milliseconds()))
+	m.latAccepted.Observe(float64(end.Sub(start.issued).Milliseconds()))
+	m.pollsAccepted.Observe(float64(m.polls - start.polls))
+	m.numAccepted.Inc()
 	m.numProcessing.Dec()
 }
 
@@ -79,6 +148,8 @@ func (m *metrics) Rejected(id ids.ID) {
 
 	delete(m.processing, key)
 
-	m.latRejected.Observe(float64(end.Sub(start).Milliseconds()))
+	m.latRejected.Observe(float64(end.Sub(start.issued).Milliseconds()))
+	m.pollsRejected.Observe(float64(m.polls - start.polls))
+	m.numRejected.Inc()
 	m.numProcessing.Dec()
 }
@@ -0,0 +1,106 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// GetAncestry returns [blkID] followed by its processing ancestors, if [blkID]
+// is a processing block. Otherwise, nothing is returned.
+type GetAncestry func(blkID ids.ID) []ids.ID
+
+type earlyTermTraversalFactory struct {
+	alpha       int
+	getAncestry GetAncestry
+}
+
+// NewEarlyTermTraversalFactory returns a factory that returns polls with
+// early termination, which traverse the processing blocks to terminate as soon
+// as the result of the poll is determined
+func NewEarlyTermTraversalFactory(alpha int, getAncestry GetAncestry) Factory {
+	return &earlyTermTraversalFactory{
+		alpha:       alpha,
+		getAncestry: getAncestry,
+	}
+}
+
+func (f *earlyTermTraversalFactory) New(vdrs ids.ShortBag) Poll {
+	return &earlyTermTraversalPoll{
+		polled:      vdrs,
+		alpha:       f.alpha,
+		getAncestry: f.getAncestry,
+	}
+}
+
+// earlyTermTraversalPoll finishes as soon as the remaining validators can't
+// change the result of the poll. That is, once every processing block either
+// received an alpha majority or can no longer receive one. Because a vote for a
+// block is also a vote for its processing ancestors, this requires traversing
+// the processing blocks.
+type earlyTermTraversalPoll struct {
+	votes       ids.Bag
+	polled      ids.ShortBag
+	alpha       int
+	getAncestry GetAncestry
+}
+
+// Vote registers a response for this poll
+func (p *earlyTermTraversalPoll) Vote(vdr ids.ShortID, vote ids.ID) {
+	count := p.polled.Count(vdr)
+	// make sure that a validator can't respond multiple times
+	p.polled.Remove(vdr)
+
+	// track the votes the validator responded with
+	p.votes.AddCount(vote, count)
+}
+
+// Drop any future response for this poll
+func (p *earlyTermTraversalPoll) Drop(vdr ids.ShortID) {
+	p.polled.Remove(vdr)
+}
+
+// Finished returns true when the remaining validators can't change the result
+// of the poll
+func (p *earlyTermTraversalPoll) Finished() bool {
+	remaining := p.polled.Len()
+	if remaining == 0 {
+		// All k nodes responded
+		return true
+	}
+	if remaining >= p.alpha {
+		// The remaining validators could give an alpha majority to any block
+		return false
+	}
+
+	// Apply the votes to the voted blocks and their processing ancestors
+	transitiveVotes := ids.Bag{}
+	for _, vote := range p.votes.List() {
+		count := p.votes.Count(vote)
+		for _, blkID := range p.getAncestry(vote) {
+			transitiveVotes.AddCount(blkID, count)
+		}
+	}
+
+	// Ignore any block that has already received an alpha majority. The poll
+	// can terminate iff none of the remaining blocks can still receive one.
+	maxPartialVotes := 0
+	for _, blkID := range transitiveVotes.List() {
+		if votes := transitiveVotes.Count(blkID); votes < p.alpha && votes > maxPartialVotes {
+			maxPartialVotes = votes
+		}
+	}
+	return maxPartialVotes+remaining < p.alpha
+}
+
+// Result returns the result of this poll
+func (p *earlyTermTraversalPoll) Result() ids.Bag { return p.votes }
+
+func (p *earlyTermTraversalPoll) PrefixedString(prefix string) string {
+	return fmt.Sprintf("waiting on %s", p.polled.PrefixedString(prefix))
+}
+
+func (p *earlyTermTraversalPoll) String() string { return p.PrefixedString("") }
@@ -0,0 +1,290 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// newTestAncestry returns the ancestry of the processing blocks described by
+// [parents], which maps each processing block to its parent
+func newTestAncestry(parents map[[32]byte]ids.ID) GetAncestry {
+	return func(blkID ids.ID) []ids.ID {
+		ancestry := []ids.ID(nil)
+		for {
+			parentID, ok := parents[blkID.Key()]
+			if !ok {
+				return ancestry
+			}
+			ancestry = append(ancestry, blkID)
+			blkID = parentID
+		}
+	}
+}
+
+func TestEarlyTermTraversalResults(t *testing.T) {
+	alpha := 1
+
+	lastAcceptedID := ids.NewID([32]byte{0})
+	blkID := ids.NewID([32]byte{1})
+
+	vdr1 := ids.NewShortID([20]byte{1}) // k = 1
+
+	vdrs := ids.ShortBag{}
+	vdrs.Add(vdr1)
+
+	factory := NewEarlyTermTraversalFactory(alpha, newTestAncestry(map[[32]byte]ids.ID{
+		blkID.Key(): lastAcceptedID,
+	}))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate after receiving k votes")
+	}
+
+	result := poll.Result()
+	if list := result.List(); len(list) != 1 {
+		t.Fatalf("Wrong number of blocks returned")
+	} else if retBlkID := list[0]; !retBlkID.Equals(blkID) {
+		t.Fatalf("Wrong block returned")
+	} else if result.Count(blkID) != 1 {
+		t.Fatalf("Wrong number of votes returned")
+	}
+}
+
+func TestEarlyTermTraversalTerminatesWithAlphaVotes(t *testing.T) {
+	alpha := 3
+
+	lastAcceptedID := ids.NewID([32]byte{0})
+	blkID := ids.NewID([32]byte{1})
+
+	vdr1 := ids.NewShortID([20]byte{1})
+	vdr2 := ids.NewShortID([20]byte{2})
+	vdr3 := ids.NewShortID([20]byte{3})
+	vdr4 := ids.NewShortID([20]byte{4})
+	vdr5 := ids.NewShortID([20]byte{5}) // k = 5
+
+	vdrs := ids.ShortBag{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+		vdr3,
+		vdr4,
+		vdr5,
+	)
+
+	factory := NewEarlyTermTraversalFactory(alpha, newTestAncestry(map[[32]byte]ids.ID{
+		blkID.Key(): lastAcceptedID,
+	}))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID)
+	if poll.Finished() {
+		t.Fatalf("Poll finished after less than alpha votes")
+	}
+	poll.Vote(vdr2, blkID)
+	if poll.Finished() {
+		t.Fatalf("Poll finished after less than alpha votes")
+	}
+	poll.Vote(vdr3, blkID)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate early after receiving alpha votes for one block and none for other blocks")
+	}
+}
+
+func TestEarlyTermTraversalTerminatesWithSplitVotes(t *testing.T) {
+	alpha := 4
+
+	// Blocks A, B, and C conflict, so votes for one of them don't count
+	// towards the others
+	lastAcceptedID := ids.NewID([32]byte{0})
+	blkA := ids.NewID([32]byte{1})
+	blkB := ids.NewID([32]byte{2})
+	blkC := ids.NewID([32]byte{3})
+
+	vdr1 := ids.NewShortID([20]byte{1})
+	vdr2 := ids.NewShortID([20]byte{2})
+	vdr3 := ids.NewShortID([20]byte{3})
+	vdr4 := ids.NewShortID([20]byte{4})
+	vdr5 := ids.NewShortID([20]byte{5}) // k = 5
+
+	vdrs := ids.ShortBag{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+		vdr3,
+		vdr4,
+		vdr5,
+	)
+
+	factory := NewEarlyTermTraversalFactory(alpha, newTestAncestry(map[[32]byte]ids.ID{
+		blkA.Key(): lastAcceptedID,
+		blkB.Key(): lastAcceptedID,
+		blkC.Key(): lastAcceptedID,
+	}))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkA)
+	if poll.Finished() {
+		t.Fatalf("Poll finished after receiving one vote")
+	}
+	poll.Vote(vdr2, blkB)
+	if poll.Finished() {
+		t.Fatalf("Poll finished when a block could still receive alpha votes")
+	}
+	poll.Vote(vdr3, blkC)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate after no block could receive alpha votes")
+	}
+}
+
+func TestEarlyTermTraversalForSharedAncestor(t *testing.T) {
+	alpha := 4
+
+	// Blocks B and C share the processing parent A, so votes for either of
+	// them count towards A
+	lastAcceptedID := ids.NewID([32]byte{0})
+	blkA := ids.NewID([32]byte{1})
+	blkB := ids.NewID([32]byte{2})
+	blkC := ids.NewID([32]byte{3})
+
+	vdr1 := ids.NewShortID([20]byte{1})
+	vdr2 := ids.NewShortID([20]byte{2})
+	vdr3 := ids.NewShortID([20]byte{3})
+	vdr4 := ids.NewShortID([20]byte{4})
+	vdr5 := ids.NewShortID([20]byte{5}) // k = 5
+
+	vdrs := ids.ShortBag{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+		vdr3,
+		vdr4,
+		vdr5,
+	)
+
+	factory := NewEarlyTermTraversalFactory(alpha, newTestAncestry(map[[32]byte]ids.ID{
+		blkA.Key(): lastAcceptedID,
+		blkB.Key(): blkA,
+		blkC.Key(): blkA,
+	}))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkB)
+	poll.Vote(vdr2, blkC)
+	if poll.Finished() {
+		t.Fatalf("Poll terminated early, when a shared ancestor could have received alpha votes")
+	}
+	poll.Vote(vdr3, blkB)
+	if poll.Finished() {
+		t.Fatalf("Poll terminated early, when a shared ancestor could have received alpha votes")
+	}
+	poll.Vote(vdr4, blkC)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate after the shared ancestor received alpha votes")
+	}
+}
+
+func TestEarlyTermTraversalWaitsForDescendant(t *testing.T) {
+	alpha := 3
+
+	// Block B is a child of block A, so B can still receive alpha votes after
+	// A received them
+	lastAcceptedID := ids.NewID([32]byte{0})
+	blkA := ids.NewID([32]byte{1})
+	blkB := ids.NewID([32]byte{2})
+
+	vdr1 := ids.NewShortID([20]byte{1})
+	vdr2 := ids.NewShortID([20]byte{2})
+	vdr3 := ids.NewShortID([20]byte{3})
+	vdr4 := ids.NewShortID([20]byte{4})
+	vdr5 := ids.NewShortID([20]byte{5}) // k = 5
+
+	vdrs := ids.ShortBag{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+		vdr3,
+		vdr4,
+		vdr5,
+	)
+
+	factory := NewEarlyTermTraversalFactory(alpha, newTestAncestry(map[[32]byte]ids.ID{
+		blkA.Key(): lastAcceptedID,
+		blkB.Key(): blkA,
+	}))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkA)
+	poll.Vote(vdr2, blkB)
+	poll.Vote(vdr3, blkA)
+	if poll.Finished() {
+		t.Fatalf("Poll terminated early, when a descendant could have received alpha votes")
+	}
+	poll.Vote(vdr4, blkA)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate after the descendant couldn't receive alpha votes")
+	}
+}
+
+func TestEarlyTermTraversalIgnoresDecidedBlocks(t *testing.T) {
+	alpha := 3
+
+	// Votes for the last accepted block can't give any processing block an
+	// alpha majority
+	lastAcceptedID := ids.NewID([32]byte{0})
+	blkID := ids.NewID([32]byte{1})
+
+	vdr1 := ids.NewShortID([20]byte{1})
+	vdr2 := ids.NewShortID([20]byte{2})
+	vdr3 := ids.NewShortID([20]byte{3})
+	vdr4 := ids.NewShortID([20]byte{4}) // k = 4
+
+	vdrs := ids.ShortBag{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+		vdr3,
+		vdr4,
+	)
+
+	factory := NewEarlyTermTraversalFactory(alpha, newTestAncestry(map[[32]byte]ids.ID{
+		blkID.Key(): lastAcceptedID,
+	}))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, lastAcceptedID)
+	if poll.Finished() {
+		t.Fatalf("Poll finished after receiving one vote")
+	}
+	poll.Vote(vdr2, lastAcceptedID)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate after no processing block could receive alpha votes")
+	}
+}
+
+func TestEarlyTermTraversalDropWithWeightedResponses(t *testing.T) {
+	alpha := 2
+
+	vdr1 := ids.NewShortID([20]byte{1})
+	vdr2 := ids.NewShortID([20]byte{2})
+
+	vdrs := ids.ShortBag{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+		vdr2,
+	) // k = 3
+
+	factory := NewEarlyTermTraversalFactory(alpha, newTestAncestry(nil))
+	poll := factory.New(vdrs)
+
+	poll.Drop(vdr2)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate after dropping two votes")
+	}
+}
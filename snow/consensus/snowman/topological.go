@@ -103,8 +103,7 @@ func (ts *Topological) Add(blk Block) error {
 		}
 
 		// Notify anyone listening that this block was rejected.
-		ts.ctx.DecisionDispatcher.Reject(ts.ctx, blkID, blkBytes)
-		ts.ctx.ConsensusDispatcher.Reject(ts.ctx, blkID, blkBytes)
+		ts.ctx.Rejected(snow.BlockContainer, blkID, blkBytes)
 		ts.metrics.Rejected(blkID)
 		return nil
 	}
@@ -137,6 +136,17 @@ func (ts *Topological) Issued(blk Block) bool {
 // Preference implements the Snowman interface
 func (ts *Topological) Preference() ids.ID { return ts.tail }
 
+// Processing implements the Snowman interface
+func (ts *Topological) Processing() ids.Set {
+	processing := ids.Set{}
+	for key, n := range ts.blocks {
+		if !n.Accepted() {
+			processing.Add(ids.NewID(key))
+		}
+	}
+	return processing
+}
+
 // RecordPoll implements the Snowman interface
 //
 // The votes bag contains at most K votes for blocks in the tree. If there is a
@@ -161,6 +171,8 @@ func (ts *Topological) Preference() ids.ID { return ts.tail }
 // - Runtime = 3 * |live set| + |votes|
 // - Space = 2 * |live set| + |votes|
 func (ts *Topological) RecordPoll(voteBag ids.Bag) error {
+	ts.metrics.Polled()
+
 	var voteStack []votes
 	if voteBag.Len() >= ts.params.Alpha {
 		// If there is no way for an alpha majority to occur, there is no need
@@ -441,8 +453,7 @@ func (ts *Topological) accept(n *snowmanBlock) error {
 
 	// Notify anyone listening that this block was accepted.
 	bytes := child.Bytes()
-	ts.ctx.DecisionDispatcher.Accept(ts.ctx, pref, bytes)
-	ts.ctx.ConsensusDispatcher.Accept(ts.ctx, pref, bytes)
+	ts.ctx.Accepted(snow.BlockContainer, pref, bytes)
 	ts.metrics.Accepted(pref)
 
 	// Because this is the newest accepted block, this is the new head.
@@ -465,8 +476,7 @@ func (ts *Topological) accept(n *snowmanBlock) error {
 
 		// Notify anyone listening that this block was rejected.
 		bytes := child.Bytes()
-		ts.ctx.DecisionDispatcher.Reject(ts.ctx, childID, bytes)
-		ts.ctx.ConsensusDispatcher.Reject(ts.ctx, childID, bytes)
+		ts.ctx.Rejected(snow.BlockContainer, childID, bytes)
 		ts.metrics.Rejected(childID)
 
 		// Track which blocks have been directly rejected
@@ -500,8 +510,7 @@ func (ts *Topological) rejectTransitively(rejected []ids.ID) error {
 			// Notify anyone listening that this block was rejected.
 			childID := ids.NewID(childIDKey)
 			bytes := child.Bytes()
-			ts.ctx.DecisionDispatcher.Reject(ts.ctx, childID, bytes)
-			ts.ctx.ConsensusDispatcher.Reject(ts.ctx, childID, bytes)
+			ts.ctx.Rejected(snow.BlockContainer, childID, bytes)
 			ts.metrics.Rejected(childID)
 
 			// add the newly rejected block to the end of the queue
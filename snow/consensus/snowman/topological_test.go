@@ -5,6 +5,188 @@ package snowman
 
 import (
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+
+	dto "github.com/prometheus/client_model/go"
 )
 
 func TestTopological(t *testing.T) { ConsensusTest(t, TopologicalFactory{}) }
+
+func TestTopologicalPollMetrics(t *testing.T) {
+	sm := &Topological{}
+
+	ctx := snow.DefaultContextTest()
+	params := snowball.Parameters{
+		Metrics:           prometheus.NewRegistry(),
+		K:                 1,
+		Alpha:             1,
+		BetaVirtuous:      1,
+		BetaRogue:         2,
+		ConcurrentRepolls: 1,
+	}
+	if err := sm.Initialize(ctx, params, GenesisID); err != nil {
+		t.Fatal(err)
+	}
+
+	// A poll recorded before the blocks are issued doesn't count towards them
+	if err := sm.RecordPoll(ids.Bag{}); err != nil {
+		t.Fatal(err)
+	}
+
+	firstBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis,
+	}
+	secondBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis,
+	}
+	if err := sm.Add(firstBlock); err != nil {
+		t.Fatal(err)
+	} else if err := sm.Add(secondBlock); err != nil {
+		t.Fatal(err)
+	}
+	if processing := testutil.ToFloat64(sm.numProcessing); processing != 2 {
+		t.Fatalf("Expected 2 processing blocks, got %f", processing)
+	}
+
+	votes := ids.Bag{}
+	votes.Add(firstBlock.ID())
+	for i := 0; i < 2; i++ {
+		if err := sm.RecordPoll(votes); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !sm.Finalized() {
+		t.Fatalf("Snowman instance didn't finalize")
+	}
+
+	switch {
+	case testutil.ToFloat64(sm.numProcessing) != 0:
+		t.Fatalf("No blocks should be processing")
+	case testutil.ToFloat64(sm.numPolls) != 3:
+		t.Fatalf("Expected 3 polls to be recorded")
+	case testutil.ToFloat64(sm.numAccepted) != 1:
+		t.Fatalf("Expected 1 block to be accepted")
+	case testutil.ToFloat64(sm.numRejected) != 1:
+		t.Fatalf("Expected 1 block to be rejected")
+	}
+
+	for _, histogram := range []prometheus.Histogram{sm.pollsAccepted, sm.pollsRejected} {
+		metric := &dto.Metric{}
+		if err := histogram.Write(metric); err != nil {
+			t.Fatal(err)
+		}
+		if count := metric.GetHistogram().GetSampleCount(); count != 1 {
+			t.Fatalf("Expected 1 decided block, got %d", count)
+		}
+		if sum := metric.GetHistogram().GetSampleSum(); sum != 2 {
+			t.Fatalf("Expected the block to be decided after 2 polls, got %f", sum)
+		}
+	}
+}
+
+// decisionRecorder records the decisions it's notified of
+type decisionRecorder struct {
+	accepted, rejected []snow.Decision
+}
+
+func (r *decisionRecorder) Accepted(_ *snow.Context, d snow.Decision) {
+	r.accepted = append(r.accepted, d)
+}
+
+func (r *decisionRecorder) Rejected(_ *snow.Context, d snow.Decision) {
+	r.rejected = append(r.rejected, d)
+}
+
+func TestTopologicalDecisionHooks(t *testing.T) {
+	sm := &Topological{}
+
+	ctx := snow.DefaultContextTest()
+	hook := &decisionRecorder{}
+	if err := ctx.RegisterDecisionHook("test", hook); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.RegisterDecisionHook("test", hook); err == nil {
+		t.Fatalf("Registering a hook twice should have errored")
+	}
+	params := snowball.Parameters{
+		Metrics:           prometheus.NewRegistry(),
+		K:                 1,
+		Alpha:             1,
+		BetaVirtuous:      1,
+		BetaRogue:         1,
+		ConcurrentRepolls: 1,
+	}
+	if err := sm.Initialize(ctx, params, GenesisID); err != nil {
+		t.Fatal(err)
+	}
+
+	acceptedBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis,
+		BytesV:  []byte{1},
+	}
+	rejectedBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis,
+		BytesV:  []byte{2},
+	}
+	if err := sm.Add(acceptedBlock); err != nil {
+		t.Fatal(err)
+	} else if err := sm.Add(rejectedBlock); err != nil {
+		t.Fatal(err)
+	}
+
+	votes := ids.Bag{}
+	votes.Add(acceptedBlock.ID())
+	if err := sm.RecordPoll(votes); err != nil {
+		t.Fatal(err)
+	}
+
+	switch {
+	case len(hook.accepted) != 1:
+		t.Fatalf("Expected 1 accepted block, got %d", len(hook.accepted))
+	case len(hook.rejected) != 1:
+		t.Fatalf("Expected 1 rejected block, got %d", len(hook.rejected))
+	case hook.accepted[0].Type != snow.BlockContainer:
+		t.Fatalf("Expected a block to be accepted, got a %s", hook.accepted[0].Type)
+	case !hook.accepted[0].ID.Equals(acceptedBlock.ID()):
+		t.Fatalf("Wrong block accepted")
+	case string(hook.accepted[0].Bytes) != string(acceptedBlock.Bytes()):
+		t.Fatalf("Wrong bytes of the accepted block")
+	case !hook.rejected[0].ID.Equals(rejectedBlock.ID()):
+		t.Fatalf("Wrong block rejected")
+	}
+
+	// A deregistered hook isn't notified
+	if err := ctx.DeregisterDecisionHook("test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.DeregisterDecisionHook("test"); err == nil {
+		t.Fatalf("Deregistering an unknown hook should have errored")
+	}
+	ctx.Accepted(snow.BlockContainer, ids.Empty.Prefix(3), nil)
+	if len(hook.accepted) != 1 {
+		t.Fatalf("Deregistered hook was notified")
+	}
+}
@@ -121,7 +121,7 @@ func (c *common) shouldVote(con Consensus, tx Tx) (bool, error) {
 	}
 
 	// Notify the IPC socket that this tx has been accepted.
-	c.ctx.DecisionDispatcher.Accept(c.ctx, txID, bytes)
+	c.ctx.Accepted(snow.TxContainer, txID, bytes)
 
 	// Notify the metrics that this transaction was just accepted.
 	c.metrics.Accepted(txID)
@@ -139,7 +139,7 @@ func (c *common) acceptTx(tx Tx) error {
 	txID := tx.ID()
 
 	// Notify the IPC socket that this tx has been accepted.
-	c.ctx.DecisionDispatcher.Accept(c.ctx, txID, tx.Bytes())
+	c.ctx.Accepted(snow.TxContainer, txID, tx.Bytes())
 
 	// Update the metrics to account for this transaction's acceptance
 	c.metrics.Accepted(txID)
@@ -164,7 +164,7 @@ func (c *common) rejectTx(tx Tx) error {
 	txID := tx.ID()
 
 	// Notify the IPC that the tx was rejected
-	c.ctx.DecisionDispatcher.Reject(c.ctx, txID, tx.Bytes())
+	c.ctx.Rejected(snow.TxContainer, txID, tx.Bytes())
 
 	// Update the metrics to account for this transaction's rejection
 	c.metrics.Rejected(txID)
@@ -330,3 +330,13 @@ func ConsensusString(name string, nodes []*snowballNode) string {
 	sb.WriteString(")")
 	return sb.String()
 }
+
+type sortTxStateData []TxState
+
+func (s sortTxStateData) Less(i, j int) bool {
+	return bytes.Compare(s[i].ID.Bytes(), s[j].ID.Bytes()) == -1
+}
+func (s sortTxStateData) Len() int      { return len(s) }
+func (s sortTxStateData) Swap(i, j int) { s[j], s[i] = s[i], s[j] }
+
+func sortTxStates(states []TxState) { sort.Sort(sortTxStateData(states)) }
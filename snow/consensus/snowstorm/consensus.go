@@ -45,6 +45,10 @@ type Consensus interface {
 	// Returns the set of transactions conflicting with <Tx>
 	Conflicts(Tx) ids.Set
 
+	// Returns the consensus state of the transactions that have been added
+	// but not yet finalized, sorted by ID
+	Processing() []TxState
+
 	// Collects the results of a network poll. Assumes all transactions
 	// have been previously added. Returns true is any statuses or preferences
 	// changed. Returns if a critical error has occurred.
@@ -66,3 +70,25 @@ type Consensus interface {
 	// Reject all the provided txs and remove them from the graph
 	reject(txIDs ...ids.ID) error
 }
+
+// TxState is the consensus state of a processing transaction
+type TxState struct {
+	ID ids.ID `json:"id"`
+
+	// Conflicts are the processing transactions that conflict with this one
+	Conflicts []ids.ID `json:"conflicts"`
+
+	// Preferred is true if this transaction is preferred over its conflicts
+	Preferred bool `json:"preferred"`
+
+	// Virtuous is true if no conflicting transaction has been added
+	Virtuous bool `json:"virtuous"`
+
+	// NumSuccessfulPolls is the number of polls this transaction was the
+	// successful result of
+	NumSuccessfulPolls int `json:"numSuccessfulPolls"`
+
+	// Confidence is the number of consecutive polls this transaction was the
+	// successful result of
+	Confidence int `json:"confidence"`
+}
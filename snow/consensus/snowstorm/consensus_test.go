@@ -4,6 +4,7 @@
 package snowstorm
 
 import (
+	"bytes"
 	"errors"
 	"testing"
 
@@ -35,6 +36,7 @@ var (
 		RejectingDependencyTest,
 		VacuouslyAcceptedTest,
 		ConflictsTest,
+		ProcessingTest,
 		VirtuousDependsOnRogueTest,
 		ErrorOnVacuouslyAcceptedTest,
 		ErrorOnAcceptedTest,
@@ -1068,6 +1070,81 @@ func ConflictsTest(t *testing.T, factory Factory) {
 	}
 }
 
+func ProcessingTest(t *testing.T, factory Factory) {
+	Setup()
+
+	graph := factory.New()
+
+	params := sbcon.Parameters{
+		Metrics:           prometheus.NewRegistry(),
+		K:                 1,
+		Alpha:             1,
+		BetaVirtuous:      1,
+		BetaRogue:         2,
+		ConcurrentRepolls: 1,
+	}
+	err := graph.Initialize(snow.DefaultContextTest(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := graph.Add(Red); err != nil {
+		t.Fatal(err)
+	} else if err := graph.Add(Green); err != nil {
+		t.Fatal(err)
+	} else if err := graph.Add(Alpha); err != nil {
+		t.Fatal(err)
+	}
+
+	votes := ids.Bag{}
+	votes.Add(Green.ID())
+	if _, err := graph.RecordPoll(votes); err != nil {
+		t.Fatal(err)
+	}
+
+	states := graph.Processing()
+	if len(states) != 3 {
+		t.Fatalf("Wrong number of processing transactions")
+	}
+	byID := map[[32]byte]TxState{}
+	for i, state := range states {
+		if i > 0 && bytes.Compare(states[i-1].ID.Bytes(), state.ID.Bytes()) != -1 {
+			t.Fatalf("Processing transactions should be sorted by ID")
+		}
+		byID[state.ID.Key()] = state
+	}
+
+	green := byID[Green.ID().Key()]
+	switch {
+	case len(green.Conflicts) != 1 || !green.Conflicts[0].Equals(Red.ID()):
+		t.Fatalf("Green should conflict with Red")
+	case !green.Preferred:
+		t.Fatalf("Green should be preferred")
+	case green.Virtuous:
+		t.Fatalf("Green shouldn't be virtuous")
+	case green.NumSuccessfulPolls != 1:
+		t.Fatalf("Green should have had one successful poll")
+	case green.Confidence != 1:
+		t.Fatalf("Green should have a confidence of 1")
+	}
+
+	red := byID[Red.ID().Key()]
+	switch {
+	case red.Preferred:
+		t.Fatalf("Red shouldn't be preferred")
+	case red.NumSuccessfulPolls != 0 || red.Confidence != 0:
+		t.Fatalf("Red shouldn't have had a successful poll")
+	}
+
+	alpha := byID[Alpha.ID().Key()]
+	switch {
+	case len(alpha.Conflicts) != 0:
+		t.Fatalf("Alpha shouldn't have conflicts")
+	case !alpha.Preferred || !alpha.Virtuous:
+		t.Fatalf("Alpha should be preferred and virtuous")
+	}
+}
+
 func VirtuousDependsOnRogueTest(t *testing.T, factory Factory) {
 	Setup()
 
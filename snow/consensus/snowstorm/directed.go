@@ -246,6 +246,24 @@ func (dg *Directed) RecordPoll(votes ids.Bag) (bool, error) {
 	return changed, dg.errs.Err
 }
 
+// Processing implements the Consensus interface
+func (dg *Directed) Processing() []TxState {
+	states := make([]TxState, 0, len(dg.txs))
+	for _, txNode := range dg.txs {
+		txID := txNode.tx.ID()
+		states = append(states, TxState{
+			ID:                 txID,
+			Conflicts:          ids.SortedIDs(dg.Conflicts(txNode.tx).List()),
+			Preferred:          dg.preferences.Contains(txID),
+			Virtuous:           dg.virtuous.Contains(txID),
+			NumSuccessfulPolls: txNode.numSuccessfulPolls,
+			Confidence:         txNode.Confidence(dg.currentVote),
+		})
+	}
+	sortTxStates(states)
+	return states
+}
+
 func (dg *Directed) String() string {
 	nodes := make([]*snowballNode, 0, len(dg.txs))
 	for _, txNode := range dg.txs {
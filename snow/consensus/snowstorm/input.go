@@ -315,32 +315,53 @@ func (ig *Input) RecordPoll(votes ids.Bag) (bool, error) {
 	return changed, ig.errs.Err
 }
 
-func (ig *Input) String() string {
-	nodes := make([]*snowballNode, 0, len(ig.txs))
+// Processing implements the ConflictGraph interface
+func (ig *Input) Processing() []TxState {
+	states := make([]TxState, 0, len(ig.txs))
 	for _, tx := range ig.txs {
 		txID := tx.tx.ID()
+		states = append(states, TxState{
+			ID:                 txID,
+			Conflicts:          ids.SortedIDs(ig.Conflicts(tx.tx).List()),
+			Preferred:          ig.preferences.Contains(txID),
+			Virtuous:           ig.virtuous.Contains(txID),
+			NumSuccessfulPolls: tx.numSuccessfulPolls,
+			Confidence:         ig.confidence(tx),
+		})
+	}
+	sortTxStates(states)
+	return states
+}
 
-		confidence := ig.params.BetaRogue
-		for _, inputID := range tx.tx.InputIDs().List() {
-			input := ig.utxos[inputID.Key()]
-			if input.lastVote != ig.currentVote || !txID.Equals(input.color) {
-				confidence = 0
-				break
-			}
-			if input.confidence < confidence {
-				confidence = input.confidence
-			}
-		}
-
+func (ig *Input) String() string {
+	nodes := make([]*snowballNode, 0, len(ig.txs))
+	for _, tx := range ig.txs {
 		nodes = append(nodes, &snowballNode{
-			txID:               txID,
+			txID:               tx.tx.ID(),
 			numSuccessfulPolls: tx.numSuccessfulPolls,
-			confidence:         confidence,
+			confidence:         ig.confidence(tx),
 		})
 	}
 	return ConsensusString("IG", nodes)
 }
 
+// confidence returns the number of consecutive polls [tx] was preferred in
+// all of its inputs
+func (ig *Input) confidence(tx *inputTx) int {
+	txID := tx.tx.ID()
+	confidence := ig.params.BetaRogue
+	for _, inputID := range tx.tx.InputIDs().List() {
+		input := ig.utxos[inputID.Key()]
+		if input.lastVote != ig.currentVote || !txID.Equals(input.color) {
+			return 0
+		}
+		if input.confidence < confidence {
+			confidence = input.confidence
+		}
+	}
+	return confidence
+}
+
 // accept the named txID and remove it from the graph
 func (ig *Input) accept(txID ids.ID) error {
 	txKey := txID.Key()
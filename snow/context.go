@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"sync"
+	"time"
 
 	stdatomic "sync/atomic"
 
@@ -46,6 +47,21 @@ type SubnetLookup interface {
 	SubnetID(chainID ids.ID) (ids.ID, error)
 }
 
+// HealthRegisterer ...
+type HealthRegisterer interface {
+	// RegisterHealthCheck adds a health check named [name] to the node's
+	// health response. [checkFn] passes if it returns a nil error and may
+	// return details describing the check's status.
+	RegisterHealthCheck(name string, checkFn func() (interface{}, error)) error
+}
+
+// PeerReporter ...
+type PeerReporter interface {
+	// InvalidContainer reports that [nodeID] sent an invalid container on the
+	// chain [chainID]
+	InvalidContainer(chainID ids.ID, nodeID ids.ShortID)
+}
+
 // Context is information about the current execution.
 // [NetworkID] is the ID of the network this context exists within.
 // [ChainID] is the ID of the chain this context exists within.
@@ -67,11 +83,88 @@ type Context struct {
 	SharedMemory        atomic.SharedMemory
 	BCLookup            AliasLookup
 	SNLookup            SubnetLookup
+	Health              HealthRegisterer
+	PeerReporter        PeerReporter
 
 	// Non-zero iff this chain bootstrapped. Should only be accessed atomically.
 	bootstrapped uint32
 	Namespace    string
 	Metrics      prometheus.Registerer
+
+	progressLock sync.Mutex
+	progress     BootstrapProgress
+	// Time the fetched containers started being executed
+	executionStart time.Time
+	// Measures the rate containers are fetched at, and then executed at
+	progressRate rateMeter
+
+	hooksLock sync.RWMutex
+	// Name --> hook notified of the containers decided on this chain
+	hooks map[string]DecisionHook
+}
+
+// BootstrapProgress describes how far along bootstrapping a chain is
+type BootstrapProgress struct {
+	// Fetched is the number of containers fetched
+	Fetched uint64
+	// Executed is the number of fetched containers executed
+	Executed uint64
+	// Executing is true once every container has been fetched
+	Executing bool
+	// ETA is an estimate of how much longer executing the fetched containers
+	// will take. It's 0 if unknown, which it is until containers are executed.
+	ETA time.Duration
+	// Rate is the number of containers fetched per second recently, or the
+	// number executed per second once Executing
+	Rate float64
+	// LastProgress is when a container was last fetched or executed. It's the
+	// zero time if none have been.
+	LastProgress time.Time
+}
+
+// rateWindow is roughly how far back BootstrapProgress.Rate looks
+const rateWindow = 30 * time.Second
+
+// rateMeter measures the rate a count grows at over the last [rateWindow] to
+// [2*rateWindow]
+type rateMeter struct {
+	// count and time the window starts at
+	start      time.Time
+	startCount uint64
+	// count and time the next window will start at
+	next      time.Time
+	nextCount uint64
+}
+
+// reset starts measuring from [count] at [now]
+func (m *rateMeter) reset(count uint64, now time.Time) {
+	*m = rateMeter{
+		start:      now,
+		startCount: count,
+		next:       now,
+		nextCount:  count,
+	}
+}
+
+// update records that the count was [count] at [now]
+func (m *rateMeter) update(count uint64, now time.Time) {
+	if m.start.IsZero() {
+		m.reset(count, now)
+		return
+	}
+	if now.Sub(m.next) >= rateWindow {
+		m.start, m.startCount = m.next, m.nextCount
+		m.next, m.nextCount = now, count
+	}
+}
+
+// rate returns the rate the count grew at up to [count] at [now], per second
+func (m *rateMeter) rate(count uint64, now time.Time) float64 {
+	elapsed := now.Sub(m.start)
+	if m.start.IsZero() || elapsed <= 0 {
+		return 0
+	}
+	return float64(count-m.startCount) / elapsed.Seconds()
 }
 
 // IsBootstrapped returns true iff this chain is done bootstrapping
@@ -84,6 +177,71 @@ func (ctx *Context) Bootstrapped() {
 	stdatomic.StoreUint32(&ctx.bootstrapped, 1)
 }
 
+// ReportInvalidContainer reports that [nodeID] sent an invalid container on
+// this chain, if there's a PeerReporter
+func (ctx *Context) ReportInvalidContainer(nodeID ids.ShortID) {
+	if ctx.PeerReporter != nil {
+		ctx.PeerReporter.InvalidContainer(ctx.ChainID, nodeID)
+	}
+}
+
+// BootstrapFetched records that [numContainers] more containers were fetched
+// while bootstrapping
+func (ctx *Context) BootstrapFetched(numContainers int) {
+	ctx.progressLock.Lock()
+	defer ctx.progressLock.Unlock()
+
+	now := time.Now()
+	ctx.progress.Fetched += uint64(numContainers)
+	ctx.progress.LastProgress = now
+	ctx.progressRate.update(ctx.progress.Fetched, now)
+}
+
+// BootstrapExecuting records that every container was fetched and that the
+// fetched containers are being executed
+func (ctx *Context) BootstrapExecuting() {
+	ctx.progressLock.Lock()
+	defer ctx.progressLock.Unlock()
+
+	if !ctx.progress.Executing {
+		ctx.progress.Executing = true
+		ctx.executionStart = time.Now()
+		ctx.progressRate.reset(0, ctx.executionStart)
+	}
+}
+
+// BootstrapExecuted records that a fetched container was executed
+func (ctx *Context) BootstrapExecuted() {
+	ctx.progressLock.Lock()
+	defer ctx.progressLock.Unlock()
+
+	now := time.Now()
+	ctx.progress.Executed++
+	ctx.progress.LastProgress = now
+	ctx.progressRate.update(ctx.progress.Executed, now)
+}
+
+// BootstrapProgress returns how far along bootstrapping this chain is
+func (ctx *Context) BootstrapProgress() BootstrapProgress {
+	ctx.progressLock.Lock()
+	defer ctx.progressLock.Unlock()
+
+	progress := ctx.progress
+	if progress.Executing && progress.Executed > 0 && progress.Executed < progress.Fetched {
+		// Assume the remaining containers are executed as quickly as those
+		// already executed were
+		elapsed := time.Since(ctx.executionStart)
+		remaining := progress.Fetched - progress.Executed
+		progress.ETA = time.Duration(float64(elapsed) * float64(remaining) / float64(progress.Executed))
+	}
+	count := progress.Fetched
+	if progress.Executing {
+		count = progress.Executed
+	}
+	progress.Rate = ctx.progressRate.rate(count, time.Now())
+	return progress
+}
+
 // DefaultContextTest ...
 func DefaultContextTest() *Context {
 	aliaser := &ids.Aliaser{}
@@ -0,0 +1,137 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snow
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// ContainerType is the type of a container consensus decides
+type ContainerType byte
+
+// The types of containers consensus decides
+const (
+	// A transaction of a DAG-based chain
+	TxContainer ContainerType = iota
+	// A vertex of a DAG-based chain
+	VertexContainer
+	// A block of a linear chain
+	BlockContainer
+)
+
+func (t ContainerType) String() string {
+	switch t {
+	case TxContainer:
+		return "transaction"
+	case VertexContainer:
+		return "vertex"
+	case BlockContainer:
+		return "block"
+	default:
+		return fmt.Sprintf("Unknown Container Type: %d", t)
+	}
+}
+
+// Decision is a container that was accepted or rejected
+type Decision struct {
+	Type ContainerType
+	// ID of the container
+	ID ids.ID
+	// Bytes of the container
+	Bytes []byte
+}
+
+// DecisionHook is notified of the containers accepted and rejected on a chain.
+// It's called while the chain's lock is held, so it shouldn't block, and it
+// mustn't grab the lock.
+type DecisionHook interface {
+	Accepted(ctx *Context, decision Decision)
+	Rejected(ctx *Context, decision Decision)
+}
+
+// RegisterDecisionHook registers [hook], named [name], to be notified of the
+// containers decided on this chain
+func (ctx *Context) RegisterDecisionHook(name string, hook DecisionHook) error {
+	ctx.hooksLock.Lock()
+	defer ctx.hooksLock.Unlock()
+
+	if _, exists := ctx.hooks[name]; exists {
+		return fmt.Errorf("decision hook %s already exists on chain %s", name, ctx.ChainID)
+	}
+	if ctx.hooks == nil {
+		ctx.hooks = make(map[string]DecisionHook)
+	}
+	ctx.hooks[name] = hook
+	return nil
+}
+
+// DeregisterDecisionHook stops the hook named [name] from being notified of
+// the containers decided on this chain
+func (ctx *Context) DeregisterDecisionHook(name string) error {
+	ctx.hooksLock.Lock()
+	defer ctx.hooksLock.Unlock()
+
+	if _, exists := ctx.hooks[name]; !exists {
+		return fmt.Errorf("decision hook %s doesn't exist on chain %s", name, ctx.ChainID)
+	}
+	delete(ctx.hooks, name)
+	return nil
+}
+
+// Accepted notifies the registered hooks, and the event dispatchers, that the
+// container [containerID] with bytes [container] was accepted. Transactions
+// are dispatched to the DecisionDispatcher, vertices to the
+// ConsensusDispatcher, and blocks to both.
+func (ctx *Context) Accepted(containerType ContainerType, containerID ids.ID, container []byte) {
+	for _, dispatcher := range ctx.dispatchers(containerType) {
+		dispatcher.Accept(ctx, containerID, container)
+	}
+
+	ctx.hooksLock.RLock()
+	defer ctx.hooksLock.RUnlock()
+
+	decision := Decision{
+		Type:  containerType,
+		ID:    containerID,
+		Bytes: container,
+	}
+	for _, hook := range ctx.hooks {
+		hook.Accepted(ctx, decision)
+	}
+}
+
+// Rejected notifies the registered hooks, and the event dispatchers, that the
+// container [containerID] with bytes [container] was rejected
+func (ctx *Context) Rejected(containerType ContainerType, containerID ids.ID, container []byte) {
+	for _, dispatcher := range ctx.dispatchers(containerType) {
+		dispatcher.Reject(ctx, containerID, container)
+	}
+
+	ctx.hooksLock.RLock()
+	defer ctx.hooksLock.RUnlock()
+
+	decision := Decision{
+		Type:  containerType,
+		ID:    containerID,
+		Bytes: container,
+	}
+	for _, hook := range ctx.hooks {
+		hook.Rejected(ctx, decision)
+	}
+}
+
+// dispatchers returns the event dispatchers containers of type
+// [containerType] are dispatched to
+func (ctx *Context) dispatchers(containerType ContainerType) []EventDispatcher {
+	dispatchers := []EventDispatcher(nil)
+	if containerType != VertexContainer && ctx.DecisionDispatcher != nil {
+		dispatchers = append(dispatchers, ctx.DecisionDispatcher)
+	}
+	if containerType != TxContainer && ctx.ConsensusDispatcher != nil {
+		dispatchers = append(dispatchers, ctx.ConsensusDispatcher)
+	}
+	return dispatchers
+}
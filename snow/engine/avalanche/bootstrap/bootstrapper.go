@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/cache/metercacher"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
@@ -26,7 +27,10 @@ const (
 	// This helps to limit the number of repeated DAG traversals performed
 	stripeDistance = 2000
 	stripeWidth    = 5
-	cacheSize      = 100000
+
+	// DefaultProcessedCacheSize is the default number of processed vertices
+	// cached
+	DefaultProcessedCacheSize = 100000
 )
 
 // Config ...
@@ -39,6 +43,11 @@ type Config struct {
 
 	Manager vertex.Manager
 	VM      vertex.DAGVM
+
+	// Number of processed vertices cached, so that vertices that are fetched
+	// repeatedly aren't traversed again. If 0, DefaultProcessedCacheSize is
+	// used.
+	ProcessedCacheSize int
 }
 
 // Bootstrapper ...
@@ -73,13 +82,25 @@ func (b *Bootstrapper) Initialize(
 	b.TxBlocked = config.TxBlocked
 	b.Manager = config.Manager
 	b.VM = config.VM
-	b.processedCache = &cache.LRU{Size: cacheSize}
 	b.OnFinished = onFinished
 
 	if err := b.metrics.Initialize(namespace, registerer); err != nil {
 		return err
 	}
 
+	processedCacheSize := config.ProcessedCacheSize
+	if processedCacheSize <= 0 {
+		processedCacheSize = DefaultProcessedCacheSize
+	}
+	processedCacheObserver, err := metercacher.New(fmt.Sprintf("%s_processed_cache", namespace), registerer)
+	if err != nil {
+		return err
+	}
+	b.processedCache = &cache.LRU{
+		Size:     processedCacheSize,
+		Observer: processedCacheObserver,
+	}
+
 	b.VtxBlocked.SetParser(&vtxParser{
 		log:         config.Ctx.Log,
 		numAccepted: b.numAcceptedVts,
@@ -119,28 +140,31 @@ func (b *Bootstrapper) FilterAccepted(containerIDs ids.Set) ids.Set {
 
 // Add the vertices in [vtxIDs] to the set of vertices that we need to fetch,
 // and then fetch vertices (and their ancestors) until either there are no more
-// to fetch or we are at the maximum number of outstanding requests.
+// to fetch or the windows of outstanding requests to the beacons are full.
 func (b *Bootstrapper) fetch(vtxIDs ...ids.ID) error {
 	b.needToFetch.Add(vtxIDs...)
-	for b.needToFetch.Len() > 0 && b.OutstandingRequests.Len() < common.MaxOutstandingRequests {
+	for b.needToFetch.Len() > 0 {
 		vtxID := b.needToFetch.CappedList(1)[0]
-		b.needToFetch.Remove(vtxID)
 
-		// Make sure we haven't already requested this vertex
+		// Make sure we haven't already requested this vertex, and that we don't
+		// already have it
 		if b.OutstandingRequests.Contains(vtxID) {
+			b.needToFetch.Remove(vtxID)
 			continue
 		}
-
-		// Make sure we don't already have this vertex
 		if _, err := b.Manager.GetVertex(vtxID); err == nil {
+			b.needToFetch.Remove(vtxID)
 			continue
 		}
 
-		validators, err := b.Beacons.Sample(1) // validator to send request to
+		validatorID, ok, err := b.FetchTarget(&b.OutstandingRequests) // validator to send request to
 		if err != nil {
 			return fmt.Errorf("dropping request for %s as there are no validators", vtxID)
 		}
-		validatorID := validators[0].ID()
+		if !ok { // every beacon is busy, fetch the rest once they respond
+			break
+		}
+		b.needToFetch.Remove(vtxID)
 		b.RequestID++
 
 		b.OutstandingRequests.Add(validatorID, b.RequestID, vtxID)
@@ -181,6 +205,7 @@ func (b *Bootstrapper) process(vtxs ...avalanche.Vertex) error {
 				vtx:         vtx,
 			}); err == nil {
 				b.numFetchedVts.Inc()
+				b.Ctx.BootstrapFetched(1)
 				b.NumFetched++ // Progress tracker
 				if b.NumFetched%common.StatusUpdateFrequency == 0 {
 					b.Ctx.Log.Info("fetched %d vertices", b.NumFetched)
@@ -200,6 +225,7 @@ func (b *Bootstrapper) process(vtxs ...avalanche.Vertex) error {
 					tx:          tx,
 				}); err == nil {
 					b.numFetchedTxs.Inc()
+					b.Ctx.BootstrapFetched(1)
 				} else {
 					b.Ctx.Log.Verbo("couldn't push to txBlocked: %s", err)
 				}
@@ -254,6 +280,7 @@ func (b *Bootstrapper) MultiPut(vdr ids.ShortID, requestID uint32, vtxs [][]byte
 
 		b.Ctx.Log.Debug("failed to parse requested vertex %s: %s", requestedVtxID, err)
 		b.Ctx.Log.Verbo("vertex: %s", formatting.DumpBytes{Bytes: vtxs[0]})
+		b.Ctx.ReportInvalidContainer(vdr)
 		return b.fetch(requestedVtxID)
 	}
 
@@ -350,12 +377,13 @@ func (b *Bootstrapper) finish() error {
 
 	b.Ctx.Log.Info("bootstrapping fetched %d vertices. executing transaction state transitions...",
 		b.NumFetched)
-	if err := b.executeAll(b.TxBlocked, b.Ctx.DecisionDispatcher); err != nil {
+	b.Ctx.BootstrapExecuting()
+	if err := b.executeAll(b.TxBlocked, snow.TxContainer); err != nil {
 		return err
 	}
 
 	b.Ctx.Log.Info("executing vertex state transitions...")
-	if err := b.executeAll(b.VtxBlocked, b.Ctx.ConsensusDispatcher); err != nil {
+	if err := b.executeAll(b.VtxBlocked, snow.VertexContainer); err != nil {
 		return err
 	}
 
@@ -373,7 +401,7 @@ func (b *Bootstrapper) finish() error {
 	return nil
 }
 
-func (b *Bootstrapper) executeAll(jobs *queue.Jobs, events snow.EventDispatcher) error {
+func (b *Bootstrapper) executeAll(jobs *queue.Jobs, containerType snow.ContainerType) error {
 	numExecuted := 0
 
 	for job, err := jobs.Pop(); err == nil; job, err = jobs.Pop() {
@@ -386,11 +414,12 @@ func (b *Bootstrapper) executeAll(jobs *queue.Jobs, events snow.EventDispatcher)
 			return err
 		}
 		numExecuted++
+		b.Ctx.BootstrapExecuted()
 		if numExecuted%common.StatusUpdateFrequency == 0 { // Periodically print progress
 			b.Ctx.Log.Info("executed %d operations", numExecuted)
 		}
 
-		events.Accept(b.Ctx, job.ID(), job.Bytes())
+		b.Ctx.Accepted(containerType, job.ID(), job.Bytes())
 	}
 	b.Ctx.Log.Info("executed %d operations", numExecuted)
 	return nil
@@ -1105,3 +1105,130 @@ func TestBootstrapperAcceptsMultiPutParents(t *testing.T) {
 		t.Fatalf("Vertex should be accepted")
 	}
 }
+
+// Accepted frontier has three unknown vertices. With a window of one request
+// per beacon, one vertex is requested from each of the two beacons, and the
+// third is requested once a beacon responds.
+func TestBootstrapperFetchWindow(t *testing.T) {
+	config, peerID0, sender, manager, vm := newConfig(t)
+
+	peerID1 := ids.GenerateTestShortID()
+	if err := config.Beacons.AddWeight(peerID1, 1); err != nil {
+		t.Fatal(err)
+	}
+	config.FetchWindow = 1
+
+	vtxID0 := ids.Empty.Prefix(0)
+	vtxID1 := ids.Empty.Prefix(1)
+	vtxID2 := ids.Empty.Prefix(2)
+
+	vtxBytes0 := []byte{0}
+	vtxBytes1 := []byte{1}
+	vtxBytes2 := []byte{2}
+
+	vtxs := map[[32]byte]*avalanche.TestVertex{}
+	for i, vtxBytes := range [][]byte{vtxBytes0, vtxBytes1, vtxBytes2} {
+		vtx := &avalanche.TestVertex{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.Empty.Prefix(uint64(i)),
+				StatusV: choices.Unknown,
+			},
+			HeightV: 0,
+			BytesV:  vtxBytes,
+		}
+		vtxs[vtx.ID().Key()] = vtx
+	}
+
+	bs := Bootstrapper{}
+	finished := new(bool)
+	err := bs.Initialize(
+		config,
+		func() error { *finished = true; return nil },
+		fmt.Sprintf("%s_%s_bs", constants.PlatformName, config.Ctx.ChainID),
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acceptedIDs := ids.Set{}
+	acceptedIDs.Add(vtxID0, vtxID1, vtxID2)
+
+	manager.GetVertexF = func(vtxID ids.ID) (avalanche.Vertex, error) {
+		vtx, ok := vtxs[vtxID.Key()]
+		if !ok {
+			t.Fatal(errUnknownVertex)
+		}
+		if vtx.Status() == choices.Unknown {
+			return nil, errUnknownVertex
+		}
+		return vtx, nil
+	}
+	manager.ParseVertexF = func(vtxBytes []byte) (avalanche.Vertex, error) {
+		for _, vtx := range vtxs {
+			if bytes.Equal(vtxBytes, vtx.Bytes()) {
+				if vtx.Status() == choices.Unknown {
+					vtx.StatusV = choices.Processing
+				}
+				return vtx, nil
+			}
+		}
+		t.Fatal(errParsedUnknownVertex)
+		return nil, errParsedUnknownVertex
+	}
+
+	type request struct {
+		vdr   ids.ShortID
+		reqID uint32
+		vtxID ids.ID
+	}
+	requests := []request(nil)
+	sender.GetAncestorsF = func(vdr ids.ShortID, reqID uint32, vtxID ids.ID) {
+		requests = append(requests, request{vdr: vdr, reqID: reqID, vtxID: vtxID})
+	}
+
+	vm.CantBootstrapping = false
+
+	if err := bs.ForceAccepted(acceptedIDs); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case len(requests) != 2:
+		t.Fatalf("should have sent 2 requests, sent %d", len(requests))
+	case requests[0].vdr.Equals(requests[1].vdr):
+		t.Fatalf("should have sent the requests to different beacons")
+	case !requests[0].vdr.Equals(peerID0) && !requests[0].vdr.Equals(peerID1):
+		t.Fatalf("should have sent the request to a beacon")
+	case !requests[1].vdr.Equals(peerID0) && !requests[1].vdr.Equals(peerID1):
+		t.Fatalf("should have sent the request to a beacon")
+	}
+
+	req := requests[0]
+	if err := bs.MultiPut(req.vdr, req.reqID, [][]byte{vtxs[req.vtxID.Key()].Bytes()}); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case len(requests) != 3:
+		t.Fatalf("should have sent the last request, sent %d", len(requests))
+	case !requests[2].vdr.Equals(req.vdr):
+		t.Fatalf("should have sent the last request to the beacon that responded")
+	case *finished:
+		t.Fatalf("should not have finished")
+	}
+
+	vm.CantBootstrapped = false
+
+	for _, req := range requests[1:] {
+		if err := bs.MultiPut(req.vdr, req.reqID, [][]byte{vtxs[req.vtxID.Key()].Bytes()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !*finished {
+		t.Fatalf("should have finished")
+	}
+	for _, vtx := range vtxs {
+		if vtx.Status() != choices.Accepted {
+			t.Fatalf("vertex %s should be accepted", vtx.ID())
+		}
+	}
+}
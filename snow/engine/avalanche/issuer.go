@@ -85,8 +85,8 @@ func (i *issuer) Update() {
 	}
 
 	// Issue a poll for this vertex.
-	p := i.t.Consensus.Parameters()
-	vdrs, err := i.t.Validators.Sample(p.K) // Validators to sample
+	k := i.t.pollSizer.SampleSize(i.t.Params.Parameters)
+	vdrs, err := i.t.Validators.Sample(k) // Validators to sample
 
 	vdrBag := ids.ShortBag{} // Validators to sample repr. as a set
 	for _, vdr := range vdrs {
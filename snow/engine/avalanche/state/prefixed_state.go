@@ -13,10 +13,12 @@ const (
 	vtxID uint64 = iota
 	vtxStatusID
 	edgeID
+	prunedID
 )
 
 var (
 	uniqueEdgeID = ids.Empty.Prefix(edgeID)
+	prunedKey    = ids.Empty.Prefix(prunedID)
 )
 
 type prefixedState struct {
@@ -26,12 +28,15 @@ type prefixedState struct {
 	uniqueVtx   cache.Deduplicator
 }
 
-func newPrefixedState(state *state, idCacheSizes int) *prefixedState {
+func newPrefixedState(state *state, idCacheSizes int, uniqueVtxObserver cache.Observer) *prefixedState {
 	return &prefixedState{
-		state:     state,
-		vtx:       &cache.LRU{Size: idCacheSizes},
-		status:    &cache.LRU{Size: idCacheSizes},
-		uniqueVtx: &cache.EvictableLRU{Size: idCacheSizes},
+		state:  state,
+		vtx:    &cache.LRU{Size: idCacheSizes},
+		status: &cache.LRU{Size: idCacheSizes},
+		uniqueVtx: &cache.EvictableLRU{
+			Size:     idCacheSizes,
+			Observer: uniqueVtxObserver,
+		},
 	}
 }
 
@@ -63,6 +68,20 @@ func (s *prefixedState) SetVertex(vtx *innerVertex) error {
 	return s.state.SetVertex(vID, vtx)
 }
 
+// PruneVertex deletes the vertex [id] from the database. Its status is kept, so
+// that it isn't issued into consensus again.
+func (s *prefixedState) PruneVertex(id ids.ID) error {
+	var vID ids.ID
+	if cachedVtxIDIntf, found := s.vtx.Get(id); found {
+		vID = cachedVtxIDIntf.(ids.ID)
+	} else {
+		vID = id.Prefix(vtxID)
+		s.vtx.Put(id, vID)
+	}
+
+	return s.state.SetVertex(vID, nil)
+}
+
 func (s *prefixedState) Status(id ids.ID) choices.Status {
 	var sID ids.ID
 	if cachedStatusIDIntf, found := s.status.Get(id); found {
@@ -6,6 +6,7 @@
 package state
 
 import (
+	"bytes"
 	"errors"
 
 	"github.com/ava-labs/avalanchego/cache"
@@ -22,8 +23,11 @@ import (
 )
 
 const (
-	dbCacheSize = 10000
-	idCacheSize = 1000
+	// DefaultVertexCacheSize is the default number of parsed vertices cached
+	DefaultVertexCacheSize = 10000
+	// DefaultIDCacheSize is the default number of vertex IDs cached, and of
+	// unique vertices deduplicated
+	DefaultIDCacheSize = 1000
 )
 
 var (
@@ -33,6 +37,21 @@ var (
 
 // Serializer manages the state of multiple vertices
 type Serializer struct {
+	// Number of parsed vertices cached, so that vertices that are gossiped or
+	// queried repeatedly are only parsed once. If 0, DefaultVertexCacheSize is
+	// used.
+	VertexCacheSize int
+	// Number of vertex IDs cached, and of unique vertices deduplicated. If 0,
+	// DefaultIDCacheSize is used.
+	IDCacheSize int
+	// If non-nil, notified of the lookups and evictions of the vertex cache and
+	// of the unique vertex deduplicator
+	VertexCacheObserver, UniqueVertexObserver cache.Observer
+	// If true, rejected vertices are deleted from the database. Their statuses
+	// are kept, so that they aren't issued into consensus again. Vertices that
+	// were rejected before pruning was enabled are deleted on initialization.
+	PruneRejected bool
+
 	ctx   *snow.Context
 	vm    vertex.DAGVM
 	state *prefixedState
@@ -45,17 +64,85 @@ func (s *Serializer) Initialize(ctx *snow.Context, vm vertex.DAGVM, db database.
 	s.ctx = ctx
 	s.vm = vm
 
+	if s.VertexCacheSize <= 0 {
+		s.VertexCacheSize = DefaultVertexCacheSize
+	}
+	if s.IDCacheSize <= 0 {
+		s.IDCacheSize = DefaultIDCacheSize
+	}
+
 	vdb := versiondb.New(db)
-	dbCache := &cache.LRU{Size: dbCacheSize}
+	dbCache := &cache.LRU{
+		Size:     s.VertexCacheSize,
+		Observer: s.VertexCacheObserver,
+	}
 	rawState := &state{
 		serializer: s,
 		dbCache:    dbCache,
 		db:         vdb,
 	}
-	s.state = newPrefixedState(rawState, idCacheSize)
+	s.state = newPrefixedState(rawState, s.IDCacheSize, s.UniqueVertexObserver)
 	s.db = vdb
 
 	s.edge.Add(s.state.Edge()...)
+
+	if err := s.prune(); err != nil {
+		s.ctx.Log.Error("failed to prune rejected vertices due to %s", err)
+	}
+}
+
+// prune deletes the vertices that were rejected before pruning was enabled.
+// Once they're deleted, the database is marked as pruned so that they don't
+// need to be searched for again. If pruning is disabled, the mark is removed,
+// as vertices may be rejected without being deleted.
+func (s *Serializer) prune() error {
+	pruned, err := s.db.Has(prunedKey.Bytes())
+	if err != nil {
+		return err
+	}
+	if !s.PruneRejected {
+		if !pruned {
+			return nil
+		}
+		if err := s.db.Delete(prunedKey.Bytes()); err != nil {
+			return err
+		}
+		return s.db.Commit()
+	}
+	if pruned {
+		return nil
+	}
+
+	rejected := []ids.ID(nil)
+	it := s.db.NewIterator()
+	for it.Next() {
+		// Vertices are keyed by a prefix of the hash of their bytes, which
+		// distinguishes them from the other values in the database
+		value := it.Value()
+		id := ids.NewID(hashing.ComputeHash256Array(value))
+		if !bytes.Equal(it.Key(), id.Prefix(vtxID).Bytes()) {
+			continue
+		}
+		if s.state.Status(id) == choices.Rejected {
+			rejected = append(rejected, id)
+		}
+	}
+	err = it.Error()
+	it.Release()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range rejected {
+		if err := s.state.PruneVertex(id); err != nil {
+			return err
+		}
+	}
+	if err := s.db.Put(prunedKey.Bytes(), nil); err != nil {
+		return err
+	}
+	s.ctx.Log.Info("pruned %d rejected vertices", len(rejected))
+	return s.db.Commit()
 }
 
 // ParseVertex implements the avalanche.State interface
@@ -71,10 +158,14 @@ func (s *Serializer) ParseVertex(b []byte) (avalanche.Vertex, error) {
 		serializer: s,
 		vtxID:      vtx.ID(),
 	}
-	if uVtx.Status() == choices.Unknown {
+	switch {
+	case uVtx.Status() == choices.Unknown:
 		if err := uVtx.setVertex(vtx); err != nil {
 			return nil, err
 		}
+	case uVtx.v.vtx == nil:
+		// The vertex was rejected and pruned, so it's only kept in memory
+		uVtx.v.vtx = vtx
 	}
 
 	return uVtx, s.db.Commit()
@@ -149,7 +240,8 @@ func (s *Serializer) getVertex(vtxID ids.ID) (*uniqueVertex, error) {
 		serializer: s,
 		vtxID:      vtxID,
 	}
-	if vtx.Status() == choices.Unknown {
+	// A rejected vertex may have been pruned, in which case it's unknown
+	if vtx.Status() == choices.Unknown || vtx.v.vtx == nil {
 		return nil, errUnknownVertex
 	}
 	return vtx, nil
@@ -0,0 +1,114 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowstorm"
+	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
+)
+
+func TestPruneRejected(t *testing.T) {
+	txs := []snowstorm.Tx{
+		&snowstorm.TestTx{
+			TestDecidable: choices.TestDecidable{IDV: ids.NewID([32]byte{1})},
+			BytesV:        []byte{1},
+		},
+		&snowstorm.TestTx{
+			TestDecidable: choices.TestDecidable{IDV: ids.NewID([32]byte{2})},
+			BytesV:        []byte{2},
+		},
+	}
+
+	vm := vertex.TestVM{}
+	vm.T = t
+	vm.Default(true)
+	vm.ParseTxF = func(b []byte) (snowstorm.Tx, error) {
+		for _, tx := range txs {
+			if bytes.Equal(tx.Bytes(), b) {
+				return tx, nil
+			}
+		}
+		return nil, errors.New("unknown tx")
+	}
+
+	db := memdb.New()
+	newSerializer := func(prune bool) *Serializer {
+		s := &Serializer{PruneRejected: prune}
+		s.Initialize(snow.DefaultContextTest(), &vm, db)
+		return s
+	}
+	rejectVertex := func(s *Serializer, tx snowstorm.Tx) avalanche.Vertex {
+		vtx, err := s.BuildVertex(ids.Set{}, []snowstorm.Tx{tx})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := vtx.Reject(); err != nil {
+			t.Fatal(err)
+		}
+		return vtx
+	}
+
+	// A vertex rejected while pruning is disabled is kept
+	s := newSerializer(false)
+	keptVtx := rejectVertex(s, txs[0])
+	if _, err := s.GetVertex(keptVtx.ID()); err != nil {
+		t.Fatalf("rejected vertex should have been kept")
+	}
+
+	// A vertex rejected while pruning is enabled is deleted, but its status is
+	// kept
+	s = newSerializer(true)
+	prunedVtx := rejectVertex(s, txs[1])
+	vtxBytes := prunedVtx.Bytes()
+	s = newSerializer(true)
+	if _, err := s.GetVertex(prunedVtx.ID()); err != errUnknownVertex {
+		t.Fatalf("rejected vertex should have been pruned")
+	}
+	if _, err := db.Get(prunedVtx.ID().Prefix(vtxID).Bytes()); err != database.ErrNotFound {
+		t.Fatalf("rejected vertex should have been deleted from the database")
+	}
+
+	// A pruned vertex that's parsed again is only kept in memory
+	parsedVtx, err := s.ParseVertex(vtxBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := parsedVtx.Status(); status != choices.Rejected {
+		t.Fatalf("pruned vertex should have status %s, but has %s", choices.Rejected, status)
+	}
+	if _, err := parsedVtx.Txs(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Get(prunedVtx.ID().Prefix(vtxID).Bytes()); err != database.ErrNotFound {
+		t.Fatalf("parsing a pruned vertex shouldn't have stored it")
+	}
+
+	// The vertex rejected before pruning was enabled was deleted on
+	// initialization
+	if _, err := s.GetVertex(keptVtx.ID()); err != errUnknownVertex {
+		t.Fatalf("vertex rejected before pruning was enabled should have been pruned")
+	}
+	if status := s.state.Status(keptVtx.ID()); status != choices.Rejected {
+		t.Fatalf("pruned vertex should have status %s, but has %s", choices.Rejected, status)
+	}
+	if has, err := db.Has(prunedKey.Bytes()); err != nil || !has {
+		t.Fatalf("database should have been marked as pruned")
+	}
+
+	// Disabling pruning removes the mark
+	newSerializer(false)
+	if has, err := db.Has(prunedKey.Bytes()); err != nil || has {
+		t.Fatalf("database shouldn't be marked as pruned")
+	}
+}
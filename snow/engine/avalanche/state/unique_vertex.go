@@ -114,6 +114,11 @@ func (vtx *uniqueVertex) Reject() error {
 	if err := vtx.setStatus(choices.Rejected); err != nil {
 		return err
 	}
+	if vtx.serializer.PruneRejected {
+		if err := vtx.serializer.state.PruneVertex(vtx.vtxID); err != nil {
+			return fmt.Errorf("failed to prune vertex %s due to %w", vtx.vtxID, err)
+		}
+	}
 
 	// Should never traverse into parents of a decided vertex. Allows for the
 	// parents to be garbage collected
@@ -154,6 +159,16 @@ func (vtx *uniqueVertex) Height() (uint64, error) {
 	return vtx.v.vtx.height, nil
 }
 
+func (vtx *uniqueVertex) Epoch() (uint32, error) {
+	vtx.refresh()
+
+	if vtx.v.vtx == nil {
+		return 0, fmt.Errorf("failed to get epoch for vertex with status: %s", vtx.v.status)
+	}
+
+	return vtx.v.vtx.epoch, nil
+}
+
 func (vtx *uniqueVertex) Txs() ([]snowstorm.Tx, error) {
 	vtx.refresh()
 
@@ -49,6 +49,11 @@ func TestUnknownUniqueVertexErrors(t *testing.T) {
 		t.Fatalf("Height should have produced error for unknown vertex")
 	}
 
+	_, err = uVtx.Epoch()
+	if err == nil {
+		t.Fatalf("Epoch should have produced error for unknown vertex")
+	}
+
 	_, err = uVtx.Txs()
 	if err == nil {
 		t.Fatalf("Txs should have produced an error for unknown vertex")
@@ -107,6 +112,14 @@ func TestUniqueVertexCacheHit(t *testing.T) {
 		t.Fatalf("Vertex height should have been %d, but was: %d", height, newHeight)
 	}
 
+	epoch, err := newUVtx.Epoch()
+	if err != nil {
+		t.Fatalf("Error while retrieving epoch of known vertex")
+	}
+	if epoch != 0 {
+		t.Fatalf("Vertex epoch should have been 0, but was: %d", epoch)
+	}
+
 	txs, err := newUVtx.Txs()
 	if err != nil {
 		t.Fatalf("Error while retrieving txs of known vertex: %s", err)
@@ -16,9 +16,6 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
-// maxSize is the maximum allowed vertex size. It is necessary to deter DoS.
-const maxSize = 1 << 20
-
 const (
 	version uint16 = 0
 )
@@ -38,6 +35,7 @@ type innerVertex struct {
 
 	chainID ids.ID
 	height  uint64
+	epoch   uint32
 
 	parentIDs []ids.ID
 	txs       []snowstorm.Tx
@@ -87,12 +85,12 @@ func (vtx *innerVertex) Verify() error {
 
 // Marshal creates the byte representation of the vertex
 func (vtx *innerVertex) Marshal() ([]byte, error) {
-	p := wrappers.Packer{MaxSize: maxSize}
+	p := wrappers.Packer{MaxSize: vertex.MaxSize}
 
 	p.PackShort(version)
 	p.PackFixedBytes(vtx.chainID.Bytes())
 	p.PackLong(vtx.height)
-	p.PackInt(0)
+	p.PackInt(vtx.epoch)
 
 	p.PackInt(uint32(len(vtx.parentIDs)))
 	for _, parentID := range vtx.parentIDs {
@@ -117,7 +115,8 @@ func (vtx *innerVertex) Unmarshal(b []byte, vm vertex.DAGVM) error {
 
 	chainID, _ := ids.ToID(p.UnpackFixedBytes(hashing.HashLen))
 	height := p.UnpackLong()
-	if epoch := p.UnpackInt(); epoch != 0 {
+	epoch := p.UnpackInt()
+	if epoch != 0 {
 		p.Add(errBadEpoch)
 	}
 
@@ -147,6 +146,7 @@ func (vtx *innerVertex) Unmarshal(b []byte, vm vertex.DAGVM) error {
 		parentIDs: parentIDs,
 		chainID:   chainID,
 		height:    height,
+		epoch:     epoch,
 		txs:       txs,
 		bytes:     b,
 	}
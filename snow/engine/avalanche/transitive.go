@@ -4,6 +4,7 @@
 package avalanche
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/events"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/sampler"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
@@ -27,8 +29,15 @@ const (
 	// TODO define this constant in one place rather than here and in snowman
 	// Max containers size in a MultiPut message
 	maxContainersLen = int(4 * network.DefaultMaxMessageSize / 5)
+
+	// vertexHeaderLen is the size of the fields of a vertex other than its
+	// parents and transactions: the codec, chain ID, height, epoch and the
+	// number of parents and transactions
+	vertexHeaderLen = wrappers.ShortLen + hashing.HashLen + wrappers.LongLen + 3*wrappers.IntLen
 )
 
+var errNotBootstrapped = errors.New("consensus isn't running until the chain is bootstrapped")
+
 // Transitive implements the Engine interface by attempting to fetch all
 // transitive dependencies.
 type Transitive struct {
@@ -38,7 +47,8 @@ type Transitive struct {
 	Params    avalanche.Parameters
 	Consensus avalanche.Consensus
 
-	polls poll.Set // track people I have asked for their preference
+	polls     poll.Set         // track people I have asked for their preference
+	pollSizer common.PollSizer // sizes polls based on how many queries fail
 
 	// The set of vertices that have been requested in Get messages but not yet received
 	outstandingVtxReqs common.Requests
@@ -74,6 +84,9 @@ func (t *Transitive) Initialize(config Config) error {
 	if err := t.metrics.Initialize(config.Params.Namespace, config.Params.Metrics); err != nil {
 		return err
 	}
+	if err := t.pollSizer.Initialize(config.Params.Namespace, config.Params.Metrics); err != nil {
+		return err
+	}
 
 	return t.Bootstrapper.Initialize(
 		config.Config,
@@ -96,11 +109,87 @@ func (t *Transitive) finishBootstrapping() error {
 	}
 
 	t.Ctx.Log.Info("bootstrapping finished with %d vertices in the accepted frontier", len(frontier))
-	return t.Consensus.Initialize(t.Ctx, t.Params, frontier)
+	if err := t.Consensus.Initialize(t.Ctx, t.Params, frontier); err != nil {
+		return err
+	}
+
+	// Issue the vertices that were processing when the checkpoint this chain
+	// resumed from was taken, so that they don't need to be fetched again
+	if checkpoint, ok := t.ResumedCheckpoint(); ok {
+		return t.issueCheckpointed(checkpoint.Processing)
+	}
+	return nil
+}
+
+// issueCheckpointed issues the processing vertices in [vtxIDs] whose parents
+// are issued or are issued before them
+func (t *Transitive) issueCheckpointed(vtxIDs []ids.ID) error {
+	vtxHeap := vertex.NewHeap()
+	for _, vtxID := range vtxIDs {
+		if vtx, err := t.Manager.GetVertex(vtxID); err == nil && vtx.Status() == choices.Processing {
+			vtxHeap.Push(vtx)
+		}
+	}
+	// The heap pops the highest vertices first, so the vertices are issued in
+	// the reverse order
+	vts := make([]avalanche.Vertex, vtxHeap.Len())
+	for i := len(vts) - 1; i >= 0; i-- {
+		vts[i] = vtxHeap.Pop()
+	}
+
+	for _, vtx := range vts {
+		parents, err := vtx.Parents()
+		if err != nil {
+			return err
+		}
+		issuable := true
+		for _, parent := range parents {
+			if !t.Consensus.VertexIssued(parent) && !t.pending.Contains(parent.ID()) {
+				issuable = false
+				break
+			}
+		}
+		if !issuable {
+			t.Ctx.Log.Debug("not issuing checkpointed vertex %s due to a missing parent", vtx.ID())
+			continue
+		}
+		if err := t.issue(vtx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processing returns the IDs of the vertices being processed by consensus
+func (t *Transitive) processing() (ids.Set, error) {
+	graph, err := t.Consensus.Graph()
+	if err != nil {
+		return nil, err
+	}
+	processing := ids.Set{}
+	for _, vtx := range graph.Vertices {
+		processing.Add(vtx.ID)
+	}
+	return processing, nil
+}
+
+// checkpoint persists the accepted frontier and the processing vertices
+func (t *Transitive) checkpoint() error {
+	processing, err := t.processing()
+	if err != nil {
+		return err
+	}
+	return t.SaveCheckpoint(processing)
 }
 
 // Gossip implements the Engine interface
 func (t *Transitive) Gossip() error {
+	if t.CheckpointDue() {
+		if err := t.checkpoint(); err != nil {
+			return err
+		}
+	}
+
 	edge := t.Manager.Edge()
 	if len(edge) == 0 {
 		t.Ctx.Log.Verbo("dropping gossip request as no vertices have been accepted")
@@ -130,6 +219,11 @@ func (t *Transitive) Gossip() error {
 // Shutdown implements the Engine interface
 func (t *Transitive) Shutdown() error {
 	t.Ctx.Log.Info("shutting down consensus engine")
+	if t.IsBootstrapped() {
+		if err := t.checkpoint(); err != nil {
+			t.Ctx.Log.Error("failed to checkpoint consensus due to: %s", err)
+		}
+	}
 	return t.VM.Shutdown()
 }
 
@@ -208,6 +302,7 @@ func (t *Transitive) Put(vdr ids.ShortID, requestID uint32, vtxID ids.ID, vtxByt
 	if err != nil {
 		t.Ctx.Log.Debug("failed to parse vertex %s due to: %s", vtxID, err)
 		t.Ctx.Log.Verbo("vertex:\n%s", formatting.DumpBytes{Bytes: vtxBytes})
+		t.Ctx.ReportInvalidContainer(vdr)
 		return t.GetFailed(vdr, requestID)
 	}
 	_, err = t.issueFrom(vdr, vtx)
@@ -288,6 +383,7 @@ func (t *Transitive) PushQuery(vdr ids.ShortID, requestID uint32, vtxID ids.ID,
 	if err != nil {
 		t.Ctx.Log.Debug("failed to parse vertex %s due to: %s", vtxID, err)
 		t.Ctx.Log.Verbo("vertex:\n%s", formatting.DumpBytes{Bytes: vtxBytes})
+		t.Ctx.ReportInvalidContainer(vdr)
 		return nil
 	}
 
@@ -305,6 +401,14 @@ func (t *Transitive) Chits(vdr ids.ShortID, requestID uint32, votes ids.Set) err
 		return nil
 	}
 
+	// A response without votes can't count towards an alpha majority, so it's
+	// treated like a failed query
+	if votes.Len() == 0 {
+		t.pollSizer.Failed()
+	} else {
+		t.pollSizer.Responded()
+	}
+
 	v := &voter{
 		t:         t,
 		vdr:       vdr,
@@ -499,22 +603,36 @@ func (t *Transitive) issue(vtx avalanche.Vertex) error {
 // Otherwise, some txs may not be put into vertices that are issued.
 // If [empty], will always result in a new poll.
 func (t *Transitive) batch(txs []snowstorm.Tx, force, empty bool) error {
+	// Under load, more transactions are pending than fit in a batch, so they
+	// are issued in fewer, larger vertices
+	batchSize := t.Params.BatchSize
+	if len(txs) > batchSize && t.Params.MaxBatchSize > batchSize {
+		batchSize = t.Params.MaxBatchSize
+	}
+	// Each batch must fit in a vertex with the most parents a vertex can have
+	maxBatchBytes := vertex.MaxSize - vertexHeaderLen - t.Params.Parents*hashing.HashLen
+
 	issuedTxs := ids.Set{}
 	consumed := ids.Set{}
 	issued := false
 	orphans := t.Consensus.Orphans()
 	start := 0
 	end := 0
+	batchBytes := 0
 	for end < len(txs) {
 		tx := txs[end]
 		inputs := tx.InputIDs()
 		overlaps := consumed.Overlaps(inputs)
-		if end-start >= t.Params.BatchSize || (force && overlaps) {
+		txBytes := wrappers.IntLen + len(tx.Bytes())
+		if end-start >= batchSize ||
+			(end > start && batchBytes+txBytes > maxBatchBytes) ||
+			(force && overlaps) {
 			if err := t.issueBatch(txs[start:end]); err != nil {
 				return err
 			}
 			start = end
 			consumed.Clear()
+			batchBytes = 0
 			issued = true
 			overlaps = false
 		}
@@ -526,6 +644,7 @@ func (t *Transitive) batch(txs []snowstorm.Tx, force, empty bool) error {
 			end++
 			issuedTxs.Add(txID)
 			consumed.Union(inputs)
+			batchBytes += txBytes
 		} else {
 			newLen := len(txs) - 1
 			txs[end] = txs[newLen]
@@ -561,8 +680,9 @@ func (t *Transitive) issueRepoll() {
 	}
 	vtxID := preferredIDs[int(indices[0])] // ID of a preferred vertex
 
-	vdrs, err := t.Validators.Sample(t.Params.K) // Validators to sample
-	vdrBag := ids.ShortBag{}                     // IDs of validators to be sampled
+	k := t.pollSizer.SampleSize(t.Params.Parameters)
+	vdrs, err := t.Validators.Sample(k) // Validators to sample
+	vdrBag := ids.ShortBag{}            // IDs of validators to be sampled
 	for _, vdr := range vdrs {
 		vdrBag.Add(vdr.ID())
 	}
@@ -625,6 +745,15 @@ func (t *Transitive) sendRequest(vdr ids.ShortID, vtxID ids.ID) {
 	t.numVtxRequests.Set(float64(t.outstandingVtxReqs.Len())) // Tracks performance statistics
 }
 
+// Graph returns the vertices being processed by consensus. Returns an error if
+// the chain is still bootstrapping.
+func (t *Transitive) Graph() (avalanche.Graph, error) {
+	if !t.Ctx.IsBootstrapped() {
+		return avalanche.Graph{}, errNotBootstrapped
+	}
+	return t.Consensus.Graph()
+}
+
 // Health implements the common.Engine interface
 func (t *Transitive) Health() (interface{}, error) {
 	// TODO add more health checks
@@ -1693,6 +1693,93 @@ func TestEngineSingleQuery(t *testing.T) {
 	}
 }
 
+func TestEngineSampleSizeChanged(t *testing.T) {
+	config := DefaultConfig()
+
+	vals := validators.NewSet()
+	config.Validators = vals
+
+	for i := 0; i < 3; i++ {
+		if err := vals.AddWeight(ids.GenerateTestShortID(), 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sender := &common.SenderTest{}
+	sender.T = t
+	config.Sender = sender
+
+	sender.Default(true)
+	sender.CantGetAcceptedFrontier = false
+
+	manager := &vertex.TestManager{T: t}
+	config.Manager = manager
+
+	manager.Default(true)
+
+	gVtx := &avalanche.TestVertex{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Accepted,
+	}}
+	mVtx := &avalanche.TestVertex{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Accepted,
+	}}
+
+	vts := []avalanche.Vertex{gVtx, mVtx}
+
+	vtx := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: vts,
+		HeightV:  1,
+		BytesV:   []byte{0, 1, 2, 3},
+	}
+
+	manager.EdgeF = func() []ids.ID { return []ids.ID{vts[0].ID(), vts[1].ID()} }
+	manager.GetVertexF = func(id ids.ID) (avalanche.Vertex, error) {
+		switch {
+		case id.Equals(gVtx.ID()):
+			return gVtx, nil
+		case id.Equals(mVtx.ID()):
+			return mVtx, nil
+		case id.Equals(vtx.ID()):
+			return vtx, nil
+		}
+		t.Fatalf("Unknown vertex")
+		panic("Should have errored")
+	}
+
+	te := &Transitive{}
+	if err := te.Initialize(config); err != nil {
+		t.Fatal(err)
+	}
+
+	// The sample size is changed after the engine started, as the chain
+	// manager does when the consensus parameters of a running chain are set
+	te.Params.K = 3
+
+	queried := false
+	sender.PushQueryF = func(vdrs ids.ShortSet, _ uint32, vtxID ids.ID, _ []byte) {
+		queried = true
+		if vdrs.Len() != 3 {
+			t.Fatalf("Should have queried 3 validators but queried %d", vdrs.Len())
+		}
+		if !vtxID.Equals(vtx.ID()) {
+			t.Fatalf("Asking for wrong vertex")
+		}
+	}
+
+	if err := te.issue(vtx); err != nil {
+		t.Fatal(err)
+	}
+	if !queried {
+		t.Fatalf("Should have queried the validators")
+	}
+}
+
 func TestEngineParentBlockingInsert(t *testing.T) {
 	config := DefaultConfig()
 
@@ -3517,3 +3604,113 @@ func TestEngineDoubleChit(t *testing.T) {
 		t.Fatalf("Wrong tx status: %s ; expected: %s", status, choices.Accepted)
 	}
 }
+
+func TestEngineBatchUnderLoad(t *testing.T) {
+	config := DefaultConfig()
+
+	config.Params.BatchSize = 2
+	config.Params.MaxBatchSize = 4
+
+	sender := &common.SenderTest{}
+	sender.T = t
+	config.Sender = sender
+
+	sender.Default(true)
+	sender.CantGetAcceptedFrontier = false
+
+	vals := validators.NewSet()
+	config.Validators = vals
+
+	vdr := ids.GenerateTestShortID()
+	if err := vals.AddWeight(vdr, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := &vertex.TestManager{T: t}
+	config.Manager = manager
+
+	manager.Default(true)
+
+	vm := &vertex.TestVM{}
+	vm.T = t
+	config.VM = vm
+
+	vm.Default(true)
+
+	gVtx := &avalanche.TestVertex{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Accepted,
+	}}
+	mVtx := &avalanche.TestVertex{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Accepted,
+	}}
+
+	gTx := &snowstorm.TestTx{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Accepted,
+	}}
+
+	txs := []snowstorm.Tx{}
+	for i := 0; i < 5; i++ {
+		tx := &snowstorm.TestTx{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			DependenciesV: []snowstorm.Tx{gTx},
+		}
+		tx.InputIDsV.Add(ids.GenerateTestID())
+		txs = append(txs, tx)
+	}
+
+	manager.EdgeF = func() []ids.ID { return []ids.ID{gVtx.ID(), mVtx.ID()} }
+	manager.GetVertexF = func(id ids.ID) (avalanche.Vertex, error) {
+		switch {
+		case id.Equals(gVtx.ID()):
+			return gVtx, nil
+		case id.Equals(mVtx.ID()):
+			return mVtx, nil
+		}
+		t.Fatalf("Unknown vertex")
+		panic("Should have errored")
+	}
+	batchSizes := []int{}
+	manager.BuildVertexF = func(_ ids.Set, txs []snowstorm.Tx) (avalanche.Vertex, error) {
+		batchSizes = append(batchSizes, len(txs))
+		return &avalanche.TestVertex{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			ParentsV: []avalanche.Vertex{gVtx, mVtx},
+			HeightV:  1,
+			TxsV:     txs,
+			BytesV:   []byte{1},
+		}, nil
+	}
+
+	vm.CantBootstrapping = false
+	vm.CantBootstrapped = false
+
+	te := &Transitive{}
+	if err := te.Initialize(config); err != nil {
+		t.Fatal(err)
+	}
+
+	vm.CantBootstrapping = true
+	vm.CantBootstrapped = true
+
+	sender.CantPushQuery = false
+
+	// More transactions are pending than fit in BatchSize, so they're batched
+	// into vertices of up to MaxBatchSize transactions
+	vm.PendingTxsF = func() []snowstorm.Tx { return txs }
+	if err := te.Notify(common.PendingTxs); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(batchSizes) != 2 || batchSizes[0] != 4 || batchSizes[1] != 1 {
+		t.Fatalf("Should have issued vertices of 4 and 1 transactions, but issued %v", batchSizes)
+	}
+}
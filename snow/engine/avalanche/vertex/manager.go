@@ -9,6 +9,9 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowstorm"
 )
 
+// MaxSize is the maximum allowed vertex size. It is necessary to deter DoS.
+const MaxSize = 1 << 20
+
 // Manager defines the persistent storage that is required by the consensus
 // engine
 type Manager interface {
@@ -4,6 +4,7 @@
 package common
 
 import (
+	"errors"
 	"time"
 
 	stdmath "math"
@@ -12,6 +13,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/math"
 )
 
+var errNoBeacons = errors.New("no beacons to fetch from")
+
 const (
 	// MaxContainersPerMultiPut is the maximum number of containers that can be
 	// sent in a MultiPut message
@@ -22,8 +25,12 @@ const (
 	StatusUpdateFrequency = 2500
 
 	// MaxOutstandingRequests is the maximum number of GetAncestors sent but not
-	// responded to/failed
-	MaxOutstandingRequests = 8
+	// responded to/failed, across all beacons
+	MaxOutstandingRequests = 64
+
+	// DefaultFetchWindow is the default maximum number of GetAncestors sent to
+	// a single beacon but not responded to/failed
+	DefaultFetchWindow = 4
 
 	// MaxTimeFetchingAncestors is the maximum amount of time to spend fetching
 	// vertices during a call to GetAncestors
@@ -44,9 +51,27 @@ type Bootstrapper struct {
 	pendingAccepted ids.ShortSet
 	acceptedVotes   map[[32]byte]uint64
 
-	// current weight
-	started bool
-	weight  uint64
+	// IDs of validators we have requested a state summary from but haven't
+	// received a reply from
+	pendingSummaryFrontier ids.ShortSet
+	summaryVotes           map[[32]byte]uint64
+	summaries              map[[32]byte]StateSummary
+
+	// summary being synced from, if state sync is fetching chunks
+	syncSummary   StateSummary
+	chunksToFetch ids.Set
+	chunkRequests Requests
+
+	// peers that are currently connected. The weight they have is looked up
+	// when needed, so that changes to the beacons' weights are accounted for.
+	started   bool
+	connected ids.ShortSet
+
+	// time the last checkpoint was taken
+	lastCheckpoint time.Time
+	// checkpoint this chain resumed from, if it skipped bootstrapping
+	resumed               Checkpoint
+	resumedFromCheckpoint bool
 }
 
 // Initialize implements the Engine interface.
@@ -75,9 +100,48 @@ func (b *Bootstrapper) Initialize(config Config) error {
 	return b.Startup()
 }
 
-// Startup implements the Engine interface.
+// Startup implements the Engine interface. If the VM can be state synced, it's
+// synced before the containers after its state are bootstrapped.
 func (b *Bootstrapper) Startup() error {
 	b.started = true
+
+	// A recent checkpoint means this node was participating in consensus
+	// shortly before it restarted, so it can resume consensus from its
+	// accepted frontier instead of asking the beacons for theirs
+	checkpoint, ok, err := b.loadCheckpoint()
+	if err != nil {
+		return err
+	}
+	if ok {
+		b.Ctx.Log.Info("resuming from checkpoint taken at %s with %d containers in the accepted frontier",
+			checkpoint.Time, len(checkpoint.AcceptedFrontier))
+		b.resumed = checkpoint
+		b.resumedFromCheckpoint = true
+
+		accepted := ids.Set{}
+		accepted.Add(checkpoint.AcceptedFrontier...)
+		return b.Bootstrapable.ForceAccepted(accepted)
+	}
+
+	if b.StateSyncVM == nil || b.pendingAcceptedFrontier.Len() == 0 {
+		return b.startBootstrapping()
+	}
+	enabled, err := b.StateSyncVM.StateSyncEnabled()
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return b.startBootstrapping()
+	}
+
+	b.pendingSummaryFrontier.Union(b.pendingAcceptedFrontier)
+	b.summaryVotes = make(map[[32]byte]uint64)
+	b.summaries = make(map[[32]byte]StateSummary)
+	return b.startStateSync()
+}
+
+// startBootstrapping asks the sampled beacons for their accepted frontiers
+func (b *Bootstrapper) startBootstrapping() error {
 	if b.pendingAcceptedFrontier.Len() == 0 {
 		b.Ctx.Log.Info("Bootstrapping skipped due to no provided bootstraps")
 		return b.Bootstrapable.ForceAccepted(ids.Set{})
@@ -194,16 +258,12 @@ func (b *Bootstrapper) Connected(validatorID ids.ShortID) error {
 	if b.started {
 		return nil
 	}
-	weight, ok := b.Beacons.GetWeight(validatorID)
-	if !ok {
-		return nil
-	}
-	weight, err := math.Add64(weight, b.weight)
+	b.connected.Add(validatorID)
+	weight, err := b.Beacons.SubsetWeight(b.connected)
 	if err != nil {
 		return err
 	}
-	b.weight = weight
-	if b.weight < b.StartupAlpha {
+	if weight < b.StartupAlpha {
 		return nil
 	}
 	return b.Startup()
@@ -211,14 +271,42 @@ func (b *Bootstrapper) Connected(validatorID ids.ShortID) error {
 
 // Disconnected implements the Engine interface.
 func (b *Bootstrapper) Disconnected(validatorID ids.ShortID) error {
-	if weight, ok := b.Beacons.GetWeight(validatorID); ok {
-		// TODO: Account for weight changes in a more robust manner.
+	b.connected.Remove(validatorID)
+	return nil
+}
 
-		// Sub64 should rarely error since only validators that have added their
-		// weight can become disconnected. Because it is possible that there are
-		// changes to the validators set, we utilize that Sub64 returns 0 on
-		// error.
-		b.weight, _ = math.Sub64(b.weight, weight)
+// FetchTarget returns the beacon the next GetAncestors should be sent to, given
+// the requests already outstanding in [outstanding]. A beacon is sampled by
+// weight. If its window is full, the beacon with the fewest outstanding
+// requests is picked instead, so that requests are spread across the beacons.
+// Returns false if no more requests should be sent until an outstanding one is
+// answered.
+func (b *Bootstrapper) FetchTarget(outstanding *Requests) (ids.ShortID, bool, error) {
+	if outstanding.Len() >= MaxOutstandingRequests {
+		return ids.ShortID{}, false, nil
 	}
-	return nil
+	window := b.FetchWindow
+	if window <= 0 {
+		window = DefaultFetchWindow
+	}
+
+	sampled, err := b.Beacons.Sample(1)
+	if err != nil {
+		return ids.ShortID{}, false, errNoBeacons
+	}
+	vdrID := sampled[0].ID()
+	if outstanding.LenOf(vdrID) < window {
+		return vdrID, true, nil
+	}
+
+	found := false
+	fewest := window
+	for _, vdr := range b.Beacons.List() {
+		if n := outstanding.LenOf(vdr.ID()); n < fewest {
+			vdrID = vdr.ID()
+			fewest = n
+			found = true
+		}
+	}
+	return vdrID, found, nil
 }
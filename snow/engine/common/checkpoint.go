@@ -0,0 +1,153 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// maxCheckpointSize is the largest a serialized checkpoint can be
+	maxCheckpointSize = 1 << 24
+)
+
+var (
+	errInvalidCheckpoint = errors.New("invalid checkpoint")
+
+	checkpointKey = []byte("checkpoint")
+)
+
+// Checkpoint is a snapshot of a chain's consensus state. A restarted node
+// resumes consensus from a recent checkpoint instead of bootstrapping from the
+// network.
+type Checkpoint struct {
+	// Time the checkpoint was taken
+	Time time.Time
+
+	// AcceptedFrontier is the accepted frontier when the checkpoint was taken
+	AcceptedFrontier []ids.ID
+
+	// Processing are the containers that were being processed when the
+	// checkpoint was taken
+	Processing []ids.ID
+}
+
+// Bytes returns the binary representation of this checkpoint
+func (c *Checkpoint) Bytes() ([]byte, error) {
+	p := wrappers.Packer{MaxSize: maxCheckpointSize}
+	p.PackLong(uint64(c.Time.Unix()))
+	packIDs(&p, c.AcceptedFrontier)
+	packIDs(&p, c.Processing)
+	return p.Bytes, p.Err
+}
+
+func parseCheckpoint(b []byte) (Checkpoint, error) {
+	p := wrappers.Packer{Bytes: b}
+	c := Checkpoint{
+		Time:             time.Unix(int64(p.UnpackLong()), 0),
+		AcceptedFrontier: unpackIDs(&p),
+		Processing:       unpackIDs(&p),
+	}
+	return c, p.Err
+}
+
+func packIDs(p *wrappers.Packer, containerIDs []ids.ID) {
+	p.PackInt(uint32(len(containerIDs)))
+	for _, containerID := range containerIDs {
+		p.PackFixedBytes(containerID.Bytes())
+	}
+}
+
+func unpackIDs(p *wrappers.Packer) []ids.ID {
+	numIDs := p.UnpackInt()
+	if p.Errored() || numIDs > uint32(len(p.Bytes)-p.Offset)/hashing.HashLen {
+		p.Add(errInvalidCheckpoint)
+		return nil
+	}
+	containerIDs := make([]ids.ID, numIDs)
+	for i := range containerIDs {
+		containerID, err := ids.ToID(p.UnpackFixedBytes(hashing.HashLen))
+		p.Add(err)
+		containerIDs[i] = containerID
+	}
+	return containerIDs
+}
+
+// CheckpointDue returns true if checkpoints are enabled and the last one was
+// taken at least CheckpointFrequency ago
+func (b *Bootstrapper) CheckpointDue() bool {
+	return b.CheckpointDB != nil &&
+		b.IsBootstrapped() &&
+		time.Since(b.lastCheckpoint) >= b.CheckpointFrequency
+}
+
+// SaveCheckpoint persists the current accepted frontier along with the
+// [processing] containers
+func (b *Bootstrapper) SaveCheckpoint(processing ids.Set) error {
+	if b.CheckpointDB == nil {
+		return nil
+	}
+	now := time.Now()
+	checkpoint := Checkpoint{
+		Time:             now,
+		AcceptedFrontier: b.Bootstrapable.CurrentAcceptedFrontier().List(),
+		Processing:       processing.List(),
+	}
+	checkpointBytes, err := checkpoint.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := b.CheckpointDB.Put(checkpointKey, checkpointBytes); err != nil {
+		return err
+	}
+	b.lastCheckpoint = now
+	b.Ctx.Log.Verbo("checkpointed %d accepted and %d processing containers",
+		len(checkpoint.AcceptedFrontier),
+		len(checkpoint.Processing))
+	return nil
+}
+
+// ResumedCheckpoint returns the checkpoint this chain resumed from, if it
+// skipped bootstrapping from the network
+func (b *Bootstrapper) ResumedCheckpoint() (Checkpoint, bool) {
+	return b.resumed, b.resumedFromCheckpoint
+}
+
+// loadCheckpoint returns the persisted checkpoint, if it's recent enough to
+// resume from and its accepted frontier is accepted locally
+func (b *Bootstrapper) loadCheckpoint() (Checkpoint, bool, error) {
+	if b.CheckpointDB == nil || b.MaxCheckpointAge <= 0 {
+		return Checkpoint{}, false, nil
+	}
+	checkpointBytes, err := b.CheckpointDB.Get(checkpointKey)
+	if err == database.ErrNotFound {
+		return Checkpoint{}, false, nil
+	} else if err != nil {
+		return Checkpoint{}, false, err
+	}
+	checkpoint, err := parseCheckpoint(checkpointBytes)
+	if err != nil {
+		b.Ctx.Log.Warn("ignoring checkpoint that failed to parse due to: %s", err)
+		return Checkpoint{}, false, nil
+	}
+
+	if age := time.Since(checkpoint.Time); age > b.MaxCheckpointAge {
+		b.Ctx.Log.Info("ignoring checkpoint taken %s ago", age)
+		return Checkpoint{}, false, nil
+	}
+
+	frontier := ids.Set{}
+	frontier.Add(checkpoint.AcceptedFrontier...)
+	if accepted := b.Bootstrapable.FilterAccepted(frontier); accepted.Len() != frontier.Len() {
+		b.Ctx.Log.Warn("ignoring checkpoint whose accepted frontier isn't accepted locally")
+		return Checkpoint{}, false, nil
+	}
+	return checkpoint, true, nil
+}
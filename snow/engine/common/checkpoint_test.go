@@ -0,0 +1,170 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+// newCheckpointTest returns a bootstrapper config with 1 beacon, whose accepted
+// frontier is [frontier]
+func newCheckpointTest(t *testing.T, frontier ids.Set) (Config, *SenderTest, *BootstrapableTest) {
+	beacons := validators.NewSet()
+	if err := beacons.AddWeight(ids.NewShortID([20]byte{1}), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	sender := &SenderTest{T: t}
+	sender.Default(true)
+	bootstrapable := &BootstrapableTest{T: t}
+	bootstrapable.Default(true)
+	bootstrapable.CurrentAcceptedFrontierF = func() ids.Set { return frontier }
+	bootstrapable.FilterAcceptedF = func(containerIDs ids.Set) ids.Set {
+		accepted := ids.Set{}
+		for _, containerID := range containerIDs.List() {
+			if frontier.Contains(containerID) {
+				accepted.Add(containerID)
+			}
+		}
+		return accepted
+	}
+
+	return Config{
+		Ctx:                 snow.DefaultContextTest(),
+		Validators:          beacons,
+		Beacons:             beacons,
+		SampleK:             1,
+		Alpha:               1,
+		Sender:              sender,
+		Bootstrapable:       bootstrapable,
+		CheckpointDB:        memdb.New(),
+		CheckpointFrequency: time.Minute,
+		MaxCheckpointAge:    time.Minute,
+	}, sender, bootstrapable
+}
+
+func TestCheckpointBytes(t *testing.T) {
+	checkpoint := Checkpoint{
+		Time:             time.Unix(100, 0),
+		AcceptedFrontier: []ids.ID{ids.NewID([32]byte{1})},
+		Processing:       []ids.ID{ids.NewID([32]byte{2}), ids.NewID([32]byte{3})},
+	}
+	checkpointBytes, err := checkpoint.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := parseCheckpoint(checkpointBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Time.Equal(checkpoint.Time) {
+		t.Fatalf("Expected time %s, but parsed %s", checkpoint.Time, parsed.Time)
+	}
+	if len(parsed.AcceptedFrontier) != 1 || !parsed.AcceptedFrontier[0].Equals(checkpoint.AcceptedFrontier[0]) {
+		t.Fatalf("Parsed the wrong accepted frontier")
+	}
+	if len(parsed.Processing) != 2 ||
+		!parsed.Processing[0].Equals(checkpoint.Processing[0]) ||
+		!parsed.Processing[1].Equals(checkpoint.Processing[1]) {
+		t.Fatalf("Parsed the wrong processing containers")
+	}
+
+	if _, err := parseCheckpoint(checkpointBytes[:len(checkpointBytes)-1]); err == nil {
+		t.Fatalf("Should have failed to parse a truncated checkpoint")
+	}
+}
+
+func TestResumeFromCheckpoint(t *testing.T) {
+	frontier := ids.Set{}
+	frontier.Add(ids.NewID([32]byte{1}))
+	config, sender, bootstrapable := newCheckpointTest(t, frontier)
+
+	// A node that was bootstrapped takes a checkpoint
+	config.Ctx.Bootstrapped()
+	bs := Bootstrapper{Config: config}
+	if !bs.CheckpointDue() {
+		t.Fatalf("Should have been due to take the first checkpoint")
+	}
+	processing := ids.Set{}
+	processing.Add(ids.NewID([32]byte{2}))
+	if err := bs.SaveCheckpoint(processing); err != nil {
+		t.Fatal(err)
+	}
+	if bs.CheckpointDue() {
+		t.Fatalf("Shouldn't have been due to take another checkpoint")
+	}
+
+	// Once restarted, it resumes from the checkpoint without asking the
+	// beacons for their accepted frontiers
+	config.Ctx = snow.DefaultContextTest()
+	forceAccepted := ids.Set(nil)
+	bootstrapable.ForceAcceptedF = func(containerIDs ids.Set) error {
+		forceAccepted = containerIDs
+		return nil
+	}
+	bs = Bootstrapper{}
+	if err := bs.Initialize(config); err != nil {
+		t.Fatal(err)
+	}
+	if !forceAccepted.Equals(frontier) {
+		t.Fatalf("Should have accepted the checkpointed accepted frontier")
+	}
+	checkpoint, ok := bs.ResumedCheckpoint()
+	if !ok {
+		t.Fatalf("Should have resumed from the checkpoint")
+	}
+	if len(checkpoint.Processing) != 1 || !processing.Contains(checkpoint.Processing[0]) {
+		t.Fatalf("Should have resumed with the checkpointed processing containers")
+	}
+
+	// A checkpoint whose accepted frontier isn't accepted locally isn't
+	// resumed from
+	frontier.Remove(ids.NewID([32]byte{1}))
+	bootstrapping := false
+	sender.GetAcceptedFrontierF = func(ids.ShortSet, uint32) { bootstrapping = true }
+	bs = Bootstrapper{}
+	if err := bs.Initialize(config); err != nil {
+		t.Fatal(err)
+	}
+	if !bootstrapping {
+		t.Fatalf("Should have bootstrapped from the beacons")
+	}
+	if _, ok := bs.ResumedCheckpoint(); ok {
+		t.Fatalf("Shouldn't have resumed from the checkpoint")
+	}
+}
+
+func TestStaleCheckpointIgnored(t *testing.T) {
+	frontier := ids.Set{}
+	frontier.Add(ids.NewID([32]byte{1}))
+	config, sender, _ := newCheckpointTest(t, frontier)
+
+	checkpoint := Checkpoint{
+		Time:             time.Now().Add(-2 * config.MaxCheckpointAge),
+		AcceptedFrontier: frontier.List(),
+	}
+	checkpointBytes, err := checkpoint.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := config.CheckpointDB.Put(checkpointKey, checkpointBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	bootstrapping := false
+	sender.GetAcceptedFrontierF = func(ids.ShortSet, uint32) { bootstrapping = true }
+	bs := Bootstrapper{}
+	if err := bs.Initialize(config); err != nil {
+		t.Fatal(err)
+	}
+	if !bootstrapping {
+		t.Fatalf("Should have bootstrapped from the beacons")
+	}
+}
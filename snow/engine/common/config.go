@@ -4,6 +4,9 @@
 package common
 
 import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/validators"
 )
@@ -20,6 +23,24 @@ type Config struct {
 	Alpha         uint64
 	Sender        Sender
 	Bootstrapable Bootstrapable
+
+	// Maximum number of GetAncestors sent to a single beacon but not
+	// responded to/failed. If 0, DefaultFetchWindow is used.
+	FetchWindow int
+
+	// VM to state sync before bootstrapping, if the chain's VM supports it
+	StateSyncVM StateSyncableVM
+
+	// Database consensus checkpoints are persisted to. If nil, checkpoints
+	// aren't taken.
+	CheckpointDB database.Database
+
+	// Minimum time between checkpoints
+	CheckpointFrequency time.Duration
+
+	// Maximum age of a checkpoint the chain resumes from on startup instead of
+	// bootstrapping from the network. If 0, the chain always bootstraps.
+	MaxCheckpointAge time.Duration
 }
 
 // Context implements the Engine interface
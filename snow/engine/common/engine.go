@@ -36,6 +36,7 @@ type ExternalHandler interface {
 	AcceptedHandler
 	FetchHandler
 	QueryHandler
+	StateSyncHandler
 }
 
 // FrontierHandler defines how a consensus engine reacts to frontier messages
@@ -276,6 +277,64 @@ type QueryHandler interface {
 	QueryFailed(validatorID ids.ShortID, requestID uint32) error
 }
 
+// StateSyncHandler defines how a consensus engine reacts to state sync
+// messages from other validators. Functions only return fatal errors if they
+// occur.
+type StateSyncHandler interface {
+	// Notify this engine of a request for the state summary its VM would have
+	// new nodes sync from.
+	//
+	// This function can be called by any validator. It is not safe to assume
+	// this message is utilizing a unique requestID. However, the validatorID is
+	// assumed to be authenticated.
+	//
+	// This engine should respond with a StateSummaryFrontier message with the
+	// same requestID. The summary is empty if the VM can't be synced from.
+	GetStateSummaryFrontier(validatorID ids.ShortID, requestID uint32) error
+
+	// Notify this engine of the state summary a validator would have new nodes
+	// sync from.
+	//
+	// This function can be called by any validator. It is not safe to assume
+	// this message is in response to a GetStateSummaryFrontier message, is
+	// utilizing a unique requestID, or that the summary is valid. However, the
+	// validatorID is assumed to be authenticated.
+	StateSummaryFrontier(validatorID ids.ShortID, requestID uint32, summary []byte) error
+
+	// Notify this engine that a GetStateSummaryFrontier request it issued has
+	// failed.
+	//
+	// The validatorID and requestID are assumed to be the same as those sent
+	// in the GetStateSummaryFrontier message.
+	GetStateSummaryFrontierFailed(validatorID ids.ShortID, requestID uint32) error
+
+	// Notify this engine of a request for a chunk of state.
+	//
+	// This function can be called by any validator. It is not safe to assume
+	// this message is utilizing a unique requestID. It is also not safe to
+	// assume the requested chunk exists. However, the validatorID is assumed
+	// to be authenticated.
+	//
+	// This engine should respond with a StateChunk message with the same
+	// requestID if the chunk is available. Otherwise, the message can be
+	// safely dropped.
+	GetStateChunk(validatorID ids.ShortID, requestID uint32, chunkID ids.ID) error
+
+	// Notify this engine of a chunk of state.
+	//
+	// This function can be called by any validator. It is not safe to assume
+	// this message is in response to a GetStateChunk message, is utilizing a
+	// unique requestID, or that the chunk is valid. However, the validatorID
+	// is assumed to be authenticated.
+	StateChunk(validatorID ids.ShortID, requestID uint32, chunk []byte) error
+
+	// Notify this engine that a GetStateChunk request it issued has failed.
+	//
+	// The validatorID and requestID are assumed to be the same as those sent
+	// in the GetStateChunk message.
+	GetStateChunkFailed(validatorID ids.ShortID, requestID uint32) error
+}
+
 // InternalHandler defines how this consensus engine reacts to messages from
 // other components of this validator. Functions only return fatal errors if
 // they occur.
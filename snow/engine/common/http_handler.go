@@ -4,6 +4,7 @@
 package common
 
 import (
+	"fmt"
 	"net/http"
 )
 
@@ -17,6 +18,19 @@ const (
 	NoLock
 )
 
+func (o LockOption) String() string {
+	switch o {
+	case WriteLock:
+		return "writeLock"
+	case ReadLock:
+		return "readLock"
+	case NoLock:
+		return "noLock"
+	default:
+		return fmt.Sprintf("unknown lock option %d", uint32(o))
+	}
+}
+
 // HTTPHandler ...
 type HTTPHandler struct {
 	LockOptions LockOption
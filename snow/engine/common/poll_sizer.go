@@ -0,0 +1,86 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"math"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// failureWeight is the weight of the latest query outcome in the failure
+	// rate
+	failureWeight = 0.05
+)
+
+// PollSizer adapts the number of validators sampled by each poll to the
+// fraction of queries that fail, so that polls can still gather an alpha
+// majority when many validators time out. Polls sample at least K and at most
+// MaxK validators. Because MaxK/2 < Alpha, Alpha remains a majority of every
+// sample, so the adaptation doesn't weaken consensus.
+type PollSizer struct {
+	// exponentially weighted moving average of the fraction of queries that
+	// failed
+	failureRate float64
+
+	sampleSize, queryFailureRate prometheus.Gauge
+}
+
+// Initialize the metrics
+func (s *PollSizer) Initialize(namespace string, registerer prometheus.Registerer) error {
+	s.sampleSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "poll_sample_size",
+		Help:      "Number of validators sampled by the latest poll",
+	})
+	s.queryFailureRate = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "query_failure_rate",
+		Help:      "Moving average of the fraction of queries that failed",
+	})
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(s.sampleSize),
+		registerer.Register(s.queryFailureRate),
+	)
+	return errs.Err
+}
+
+// Responded records that a validator responded to a query
+func (s *PollSizer) Responded() { s.observe(0) }
+
+// Failed records that a query to a validator failed
+func (s *PollSizer) Failed() { s.observe(1) }
+
+func (s *PollSizer) observe(outcome float64) {
+	s.failureRate = (1-failureWeight)*s.failureRate + failureWeight*outcome
+	s.queryFailureRate.Set(s.failureRate)
+}
+
+// SampleSize returns the number of validators the next poll should sample.
+// That's K, unless so many queries fail that fewer than Alpha of K validators
+// are expected to respond, in which case enough validators are sampled for
+// Alpha of them to respond, up to MaxK.
+func (s *PollSizer) SampleSize(params snowball.Parameters) int {
+	k := params.K
+	if params.MaxK > params.K {
+		needed := params.MaxK
+		if s.failureRate < 1 {
+			needed = int(math.Ceil(float64(params.Alpha) / (1 - s.failureRate)))
+		}
+		if needed > k {
+			k = needed
+		}
+		if k > params.MaxK {
+			k = params.MaxK
+		}
+	}
+	s.sampleSize.Set(float64(k))
+	return k
+}
@@ -0,0 +1,76 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+)
+
+func TestPollSizer(t *testing.T) {
+	params := snowball.Parameters{
+		K:                 20,
+		Alpha:             14,
+		BetaVirtuous:      1,
+		BetaRogue:         1,
+		ConcurrentRepolls: 1,
+		MaxK:              27,
+	}
+
+	s := PollSizer{}
+	if err := s.Initialize("", prometheus.NewRegistry()); err != nil {
+		t.Fatal(err)
+	}
+
+	if k := s.SampleSize(params); k != params.K {
+		t.Fatalf("Should have sampled K validators without failures, but sampled %d", k)
+	}
+
+	// As long as Alpha of K validators are expected to respond, K validators
+	// are sampled
+	for s.failureRate < 0.25 {
+		s.Failed()
+		s.Responded()
+		s.Responded()
+	}
+	if k := s.SampleSize(params); k != params.K {
+		t.Fatalf("Should have sampled K validators with few failures, but sampled %d", k)
+	}
+
+	// Once too many queries fail, more validators are sampled
+	for s.failureRate < 0.4 {
+		s.Failed()
+	}
+	if k := s.SampleSize(params); k <= params.K || k > params.MaxK {
+		t.Fatalf("Should have sampled more than K validators, but sampled %d", k)
+	}
+
+	// The sample size is bounded by MaxK
+	for i := 0; i < 1000; i++ {
+		s.Failed()
+	}
+	if k := s.SampleSize(params); k != params.MaxK {
+		t.Fatalf("Should have sampled MaxK validators, but sampled %d", k)
+	}
+
+	// Once queries succeed again, K validators are sampled
+	for i := 0; i < 1000; i++ {
+		s.Responded()
+	}
+	if k := s.SampleSize(params); k != params.K {
+		t.Fatalf("Should have sampled K validators after recovering, but sampled %d", k)
+	}
+
+	// Without MaxK, the sample size never changes
+	params.MaxK = 0
+	for i := 0; i < 1000; i++ {
+		s.Failed()
+	}
+	if k := s.SampleSize(params); k != params.K {
+		t.Fatalf("Should have sampled K validators without MaxK, but sampled %d", k)
+	}
+}
@@ -84,6 +84,9 @@ func (r *Requests) RemoveAny(containerID ids.ID) bool {
 // Len returns the total number of outstanding requests.
 func (r *Requests) Len() int { return len(r.idToReq) }
 
+// LenOf returns the number of requests outstanding to [vdr].
+func (r *Requests) LenOf(vdr ids.ShortID) int { return len(r.reqsToID[vdr.Key()]) }
+
 // Contains returns true if there is an outstanding request for the container
 // ID.
 func (r *Requests) Contains(containerID ids.ID) bool {
@@ -88,3 +88,19 @@ func TestRequests(t *testing.T) {
 	length = req.Len()
 	assert.Equal(t, 0, length, "should have had no outstanding requests")
 }
+
+func TestRequestsLenOf(t *testing.T) {
+	req := Requests{}
+	vdr0 := ids.NewShortID([20]byte{1})
+	vdr1 := ids.NewShortID([20]byte{2})
+
+	req.Add(vdr0, 0, ids.Empty.Prefix(0))
+	req.Add(vdr0, 1, ids.Empty.Prefix(1))
+	req.Add(vdr1, 2, ids.Empty.Prefix(2))
+
+	assert.Equal(t, 2, req.LenOf(vdr0), "should have had two requests outstanding to the first validator")
+	assert.Equal(t, 1, req.LenOf(vdr1), "should have had one request outstanding to the second validator")
+
+	req.RemoveAny(ids.Empty.Prefix(2))
+	assert.Equal(t, 0, req.LenOf(vdr1), "should have had no requests outstanding to the second validator")
+}
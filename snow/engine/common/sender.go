@@ -14,6 +14,7 @@ type Sender interface {
 	AcceptedSender
 	FetchSender
 	QuerySender
+	StateSyncSender
 	Gossiper
 }
 
@@ -80,6 +81,25 @@ type QuerySender interface {
 	Chits(validatorID ids.ShortID, requestID uint32, votes ids.Set)
 }
 
+// StateSyncSender defines how a consensus engine sends state sync messages to
+// other validators
+type StateSyncSender interface {
+	// GetStateSummaryFrontier requests that every validator in [validatorIDs]
+	// sends a StateSummaryFrontier message.
+	GetStateSummaryFrontier(validatorIDs ids.ShortSet, requestID uint32)
+
+	// StateSummaryFrontier responds to a GetStateSummaryFrontier message with
+	// the state summary this engine's VM would have new nodes sync from.
+	StateSummaryFrontier(validatorID ids.ShortID, requestID uint32, summary []byte)
+
+	// GetStateChunk requests that the validator with ID [validatorID] send the
+	// chunk of state with ID [chunkID].
+	GetStateChunk(validatorID ids.ShortID, requestID uint32, chunkID ids.ID)
+
+	// StateChunk responds to a GetStateChunk message with a chunk of state.
+	StateChunk(validatorID ids.ShortID, requestID uint32, chunk []byte)
+}
+
 // Gossiper defines how a consensus engine gossips a container on the accepted
 // frontier to other validators
 type Gossiper interface {
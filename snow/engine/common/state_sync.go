@@ -0,0 +1,280 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"bytes"
+	stdmath "math"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+)
+
+// StateSyncableVM is a VM that can sync its state from a summary of another
+// node's state, rather than by executing the chain's history. A node
+// bootstrapping such a VM asks the beacons for the summary they'd have it sync
+// from, fetches the chunks of state the summary is made of, and then
+// bootstraps the containers accepted after the summary.
+type StateSyncableVM interface {
+	// StateSyncEnabled returns true if the VM should be synced from a state
+	// summary before it's bootstrapped. It should return false if the VM
+	// already has state it would rather bootstrap from.
+	StateSyncEnabled() (bool, error)
+
+	// GetStateSummary returns the summary of the VM's state that new nodes
+	// should sync from, or nil if it has none.
+	GetStateSummary() (StateSummary, error)
+
+	// ParseStateSummary parses the state summary with the binary
+	// representation [summary].
+	ParseStateSummary(summary []byte) (StateSummary, error)
+
+	// GetStateChunk returns the binary representation of the chunk of state
+	// with ID [chunkID]. Returns an error if the chunk isn't available.
+	GetStateChunk(chunkID ids.ID) ([]byte, error)
+
+	// ParseStateChunk parses the chunk of state with the binary representation
+	// [chunk].
+	ParseStateChunk(chunk []byte) (StateChunk, error)
+
+	// StateSynced is called once every chunk of [summary] was stored. The VM
+	// should then act as though the containers up to the summary were
+	// accepted.
+	StateSynced(summary StateSummary) error
+}
+
+// StateSummary describes a VM's state as of an accepted container
+type StateSummary interface {
+	// ID returns the ID of this summary
+	ID() ids.ID
+
+	// Height returns the height of the container this summary was taken at
+	Height() uint64
+
+	// Bytes returns the binary representation of this summary
+	Bytes() []byte
+
+	// Chunks returns the IDs of the root chunks of state this summary
+	// references that the VM doesn't have yet
+	Chunks() []ids.ID
+}
+
+// StateChunk is a piece of a VM's state
+type StateChunk interface {
+	// ID returns the ID of this chunk, which must be derived from its bytes
+	ID() ids.ID
+
+	// Store persists this chunk
+	Store() error
+
+	// Children returns the IDs of the chunks this chunk references that the VM
+	// doesn't have yet. Only called once this chunk was stored.
+	Children() []ids.ID
+}
+
+// startStateSync asks the sampled beacons for the state summaries they'd have
+// this node sync from
+func (b *Bootstrapper) startStateSync() error {
+	b.Ctx.Log.Info("State sync started")
+
+	vdrs := ids.ShortSet{}
+	vdrs.Union(b.pendingSummaryFrontier)
+
+	b.RequestID++
+	b.Sender.GetStateSummaryFrontier(vdrs, b.RequestID)
+	return nil
+}
+
+// GetStateSummaryFrontier implements the Engine interface.
+func (b *Bootstrapper) GetStateSummaryFrontier(validatorID ids.ShortID, requestID uint32) error {
+	if b.StateSyncVM == nil {
+		b.Sender.StateSummaryFrontier(validatorID, requestID, nil)
+		return nil
+	}
+	summary, err := b.StateSyncVM.GetStateSummary()
+	if err != nil {
+		b.Ctx.Log.Debug("couldn't get the state summary due to: %s", err)
+		summary = nil
+	}
+	summaryBytes := []byte(nil)
+	if summary != nil {
+		summaryBytes = summary.Bytes()
+	}
+	b.Sender.StateSummaryFrontier(validatorID, requestID, summaryBytes)
+	return nil
+}
+
+// GetStateSummaryFrontierFailed implements the Engine interface.
+func (b *Bootstrapper) GetStateSummaryFrontierFailed(validatorID ids.ShortID, requestID uint32) error {
+	// If we can't get a response from [validatorID], act as though they don't
+	// have a summary
+	return b.StateSummaryFrontier(validatorID, requestID, nil)
+}
+
+// StateSummaryFrontier implements the Engine interface.
+func (b *Bootstrapper) StateSummaryFrontier(validatorID ids.ShortID, requestID uint32, summaryBytes []byte) error {
+	if !b.pendingSummaryFrontier.Contains(validatorID) {
+		b.Ctx.Log.Debug("Received a StateSummaryFrontier message from %s unexpectedly", validatorID)
+		return nil
+	}
+	// Mark that we received a response from [validatorID]
+	b.pendingSummaryFrontier.Remove(validatorID)
+
+	if len(summaryBytes) > 0 {
+		summary, err := b.StateSyncVM.ParseStateSummary(summaryBytes)
+		if err != nil {
+			b.Ctx.Log.Debug("failed to parse the state summary from %s due to: %s", validatorID, err)
+		} else {
+			weight := uint64(0)
+			if w, ok := b.Beacons.GetWeight(validatorID); ok {
+				weight = w
+			}
+			key := summary.ID().Key()
+			newWeight, err := math.Add64(weight, b.summaryVotes[key])
+			if err != nil {
+				newWeight = stdmath.MaxUint64
+			}
+			b.summaryVotes[key] = newWeight
+			b.summaries[key] = summary
+		}
+	}
+
+	if b.pendingSummaryFrontier.Len() != 0 {
+		return nil
+	}
+
+	// We've received a summary from every sampled beacon. Sync from the
+	// summary with the most weight behind it, if it has enough.
+	var (
+		chosen       StateSummary
+		chosenWeight uint64
+	)
+	for key, weight := range b.summaryVotes {
+		summary := b.summaries[key]
+		if weight < b.Alpha || !betterSummary(summary, weight, chosen, chosenWeight) {
+			continue
+		}
+		chosen = summary
+		chosenWeight = weight
+	}
+	b.summaryVotes = nil
+	b.summaries = nil
+
+	if chosen == nil {
+		b.Ctx.Log.Info("State sync skipped as no state summary had enough weight behind it")
+		return b.startBootstrapping()
+	}
+
+	b.Ctx.Log.Info("State sync started fetching the state summary %s at height %d", chosen.ID(), chosen.Height())
+	b.syncSummary = chosen
+	b.chunksToFetch.Add(chosen.Chunks()...)
+	return b.fetchChunks()
+}
+
+// betterSummary returns true if [summary], with [weight] behind it, should be
+// synced from over [chosen], with [chosenWeight] behind it. Ties are broken by
+// height, and then by ID, so that the choice is deterministic.
+func betterSummary(summary StateSummary, weight uint64, chosen StateSummary, chosenWeight uint64) bool {
+	switch {
+	case chosen == nil:
+		return true
+	case weight != chosenWeight:
+		return weight > chosenWeight
+	case summary.Height() != chosen.Height():
+		return summary.Height() > chosen.Height()
+	default:
+		return bytes.Compare(summary.ID().Bytes(), chosen.ID().Bytes()) > 0
+	}
+}
+
+// fetchChunks requests the chunks of state that still need to be fetched, as
+// long as there are beacons with room in their fetch window. Once every chunk
+// was fetched, state sync finishes.
+func (b *Bootstrapper) fetchChunks() error {
+	for _, chunkID := range b.chunksToFetch.List() {
+		if b.chunkRequests.Contains(chunkID) {
+			b.chunksToFetch.Remove(chunkID)
+			continue
+		}
+
+		validatorID, ok, err := b.FetchTarget(&b.chunkRequests)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		b.chunksToFetch.Remove(chunkID)
+
+		b.RequestID++
+		b.chunkRequests.Add(validatorID, b.RequestID, chunkID)
+		b.Sender.GetStateChunk(validatorID, b.RequestID, chunkID)
+	}
+
+	if b.chunksToFetch.Len() != 0 || b.chunkRequests.Len() != 0 {
+		return nil
+	}
+
+	summary := b.syncSummary
+	b.syncSummary = nil
+	if err := b.StateSyncVM.StateSynced(summary); err != nil {
+		return err
+	}
+	b.Ctx.Log.Info("State sync finished at height %d", summary.Height())
+	return b.startBootstrapping()
+}
+
+// GetStateChunk implements the Engine interface.
+func (b *Bootstrapper) GetStateChunk(validatorID ids.ShortID, requestID uint32, chunkID ids.ID) error {
+	if b.StateSyncVM == nil {
+		b.Ctx.Log.Debug("dropping GetStateChunk(%s, %d, %s) as state sync isn't supported", validatorID, requestID, chunkID)
+		return nil
+	}
+	chunk, err := b.StateSyncVM.GetStateChunk(chunkID)
+	if err != nil {
+		b.Ctx.Log.Debug("dropping GetStateChunk(%s, %d, %s) due to: %s", validatorID, requestID, chunkID, err)
+		return nil
+	}
+	b.Sender.StateChunk(validatorID, requestID, chunk)
+	return nil
+}
+
+// StateChunk implements the Engine interface.
+func (b *Bootstrapper) StateChunk(validatorID ids.ShortID, requestID uint32, chunkBytes []byte) error {
+	chunkID, ok := b.chunkRequests.Remove(validatorID, requestID)
+	if !ok {
+		b.Ctx.Log.Debug("Received a StateChunk message from %s with request ID %d unexpectedly", validatorID, requestID)
+		return nil
+	}
+
+	chunk, err := b.StateSyncVM.ParseStateChunk(chunkBytes)
+	switch {
+	case err != nil:
+		b.Ctx.Log.Debug("failed to parse state chunk %s from %s due to: %s", chunkID, validatorID, err)
+	case !chunk.ID().Equals(chunkID):
+		b.Ctx.Log.Debug("expected state chunk %s from %s but got %s", chunkID, validatorID, chunk.ID())
+	default:
+		if err := chunk.Store(); err != nil {
+			return err
+		}
+		b.Ctx.BootstrapFetched(1)
+		b.chunksToFetch.Add(chunk.Children()...)
+		return b.fetchChunks()
+	}
+
+	b.Ctx.ReportInvalidContainer(validatorID)
+	b.chunksToFetch.Add(chunkID)
+	return b.fetchChunks()
+}
+
+// GetStateChunkFailed implements the Engine interface.
+func (b *Bootstrapper) GetStateChunkFailed(validatorID ids.ShortID, requestID uint32) error {
+	chunkID, ok := b.chunkRequests.Remove(validatorID, requestID)
+	if !ok {
+		b.Ctx.Log.Debug("GetStateChunkFailed(%s, %d) called without sending the corresponding GetStateChunk message", validatorID, requestID)
+		return nil
+	}
+	b.chunksToFetch.Add(chunkID)
+	return b.fetchChunks()
+}
@@ -0,0 +1,264 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+var errUnknownState = errors.New("unknown state")
+
+type testSummary struct {
+	id     ids.ID
+	height uint64
+	bytes  []byte
+	chunks []ids.ID
+}
+
+func (s *testSummary) ID() ids.ID       { return s.id }
+func (s *testSummary) Height() uint64   { return s.height }
+func (s *testSummary) Bytes() []byte    { return s.bytes }
+func (s *testSummary) Chunks() []ids.ID { return s.chunks }
+
+type testChunk struct {
+	id       ids.ID
+	bytes    []byte
+	children []ids.ID
+	stored   bool
+}
+
+func (c *testChunk) ID() ids.ID         { return c.id }
+func (c *testChunk) Store() error       { c.stored = true; return nil }
+func (c *testChunk) Children() []ids.ID { return c.children }
+
+// testStateSyncVM parses the summaries and chunks it knows of
+type testStateSyncVM struct {
+	summaries map[string]*testSummary
+	chunks    map[string]*testChunk
+	synced    StateSummary
+}
+
+func (vm *testStateSyncVM) StateSyncEnabled() (bool, error)        { return true, nil }
+func (vm *testStateSyncVM) GetStateSummary() (StateSummary, error) { return nil, nil }
+
+func (vm *testStateSyncVM) ParseStateSummary(summary []byte) (StateSummary, error) {
+	if s, ok := vm.summaries[string(summary)]; ok {
+		return s, nil
+	}
+	return nil, errUnknownState
+}
+
+func (vm *testStateSyncVM) GetStateChunk(chunkID ids.ID) ([]byte, error) {
+	for _, c := range vm.chunks {
+		if c.stored && c.id.Equals(chunkID) {
+			return c.bytes, nil
+		}
+	}
+	return nil, errUnknownState
+}
+
+func (vm *testStateSyncVM) ParseStateChunk(chunk []byte) (StateChunk, error) {
+	if c, ok := vm.chunks[string(chunk)]; ok {
+		return c, nil
+	}
+	return nil, errUnknownState
+}
+
+func (vm *testStateSyncVM) StateSynced(summary StateSummary) error {
+	vm.synced = summary
+	return nil
+}
+
+// newStateSyncTest returns a bootstrapper config with 2 beacons of weight 1,
+// which state syncs [vm]
+func newStateSyncTest(t *testing.T, vm StateSyncableVM, alpha uint64) (Config, *SenderTest, []ids.ShortID) {
+	beacons := validators.NewSet()
+	vdrIDs := []ids.ShortID{
+		ids.NewShortID([20]byte{1}),
+		ids.NewShortID([20]byte{2}),
+	}
+	for _, vdrID := range vdrIDs {
+		if err := beacons.AddWeight(vdrID, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sender := &SenderTest{T: t}
+	sender.Default(true)
+	bootstrapable := &BootstrapableTest{T: t}
+	bootstrapable.Default(true)
+
+	return Config{
+		Ctx:           snow.DefaultContextTest(),
+		Validators:    beacons,
+		Beacons:       beacons,
+		SampleK:       len(vdrIDs),
+		Alpha:         alpha,
+		Sender:        sender,
+		Bootstrapable: bootstrapable,
+		StateSyncVM:   vm,
+	}, sender, vdrIDs
+}
+
+func TestStateSync(t *testing.T) {
+	chunk0 := &testChunk{id: ids.NewID([32]byte{1}), bytes: []byte{1}}
+	chunk1 := &testChunk{id: ids.NewID([32]byte{2}), bytes: []byte{2}}
+	chunk0.children = []ids.ID{chunk1.id}
+	summary := &testSummary{
+		id:     ids.NewID([32]byte{3}),
+		height: 10,
+		bytes:  []byte{3},
+		chunks: []ids.ID{chunk0.id},
+	}
+	vm := &testStateSyncVM{
+		summaries: map[string]*testSummary{string(summary.bytes): summary},
+		chunks: map[string]*testChunk{
+			string(chunk0.bytes): chunk0,
+			string(chunk1.bytes): chunk1,
+		},
+	}
+	config, sender, vdrIDs := newStateSyncTest(t, vm, 2)
+
+	reqID := new(uint32)
+	sender.GetStateSummaryFrontierF = func(vdrs ids.ShortSet, requestID uint32) {
+		if vdrs.Len() != len(vdrIDs) {
+			t.Fatalf("should have asked every beacon for its summary")
+		}
+		*reqID = requestID
+	}
+	bs := Bootstrapper{}
+	if err := bs.Initialize(config); err != nil {
+		t.Fatal(err)
+	}
+	sender.GetStateSummaryFrontierF = nil
+
+	requested := ids.ShortID{}
+	requestedChunk := ids.ID{}
+	sender.GetStateChunkF = func(vdr ids.ShortID, requestID uint32, chunkID ids.ID) {
+		requested = vdr
+		*reqID = requestID
+		requestedChunk = chunkID
+	}
+	for _, vdrID := range vdrIDs {
+		if err := bs.StateSummaryFrontier(vdrID, *reqID, summary.bytes); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !requestedChunk.Equals(chunk0.id) {
+		t.Fatalf("should have requested the summary's chunk")
+	}
+
+	// A chunk other than the one requested is re-requested
+	requestedChunk = ids.ID{}
+	if err := bs.StateChunk(requested, *reqID, chunk1.bytes); err != nil {
+		t.Fatal(err)
+	}
+	if chunk1.stored {
+		t.Fatalf("shouldn't have stored a chunk that wasn't requested")
+	}
+	if !requestedChunk.Equals(chunk0.id) {
+		t.Fatalf("should have re-requested the chunk")
+	}
+
+	// The children of a stored chunk are fetched
+	if err := bs.StateChunk(requested, *reqID, chunk0.bytes); err != nil {
+		t.Fatal(err)
+	}
+	if !chunk0.stored {
+		t.Fatalf("should have stored the chunk")
+	}
+	if !requestedChunk.Equals(chunk1.id) {
+		t.Fatalf("should have requested the chunk's child")
+	}
+
+	// A failed request is retried
+	requestedChunk = ids.ID{}
+	if err := bs.GetStateChunkFailed(requested, *reqID); err != nil {
+		t.Fatal(err)
+	}
+	if !requestedChunk.Equals(chunk1.id) {
+		t.Fatalf("should have re-requested the chunk")
+	}
+
+	// Once every chunk is stored, the VM is synced and bootstrapping starts
+	bootstrapping := false
+	sender.GetAcceptedFrontierF = func(ids.ShortSet, uint32) { bootstrapping = true }
+	if err := bs.StateChunk(requested, *reqID, chunk1.bytes); err != nil {
+		t.Fatal(err)
+	}
+	if !chunk1.stored {
+		t.Fatalf("should have stored the chunk")
+	}
+	if vm.synced != summary {
+		t.Fatalf("should have synced the VM to the summary")
+	}
+	if !bootstrapping {
+		t.Fatalf("should have started bootstrapping")
+	}
+}
+
+func TestStateSyncSkippedWithoutEnoughWeight(t *testing.T) {
+	summary := &testSummary{
+		id:     ids.NewID([32]byte{1}),
+		height: 10,
+		bytes:  []byte{1},
+	}
+	vm := &testStateSyncVM{
+		summaries: map[string]*testSummary{string(summary.bytes): summary},
+	}
+	config, sender, vdrIDs := newStateSyncTest(t, vm, 2)
+
+	reqID := new(uint32)
+	sender.GetStateSummaryFrontierF = func(_ ids.ShortSet, requestID uint32) { *reqID = requestID }
+	bs := Bootstrapper{}
+	if err := bs.Initialize(config); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only one of the beacons has a summary, so it doesn't have enough weight
+	// behind it
+	bootstrapping := false
+	sender.GetAcceptedFrontierF = func(ids.ShortSet, uint32) { bootstrapping = true }
+	if err := bs.StateSummaryFrontier(vdrIDs[0], *reqID, summary.bytes); err != nil {
+		t.Fatal(err)
+	}
+	if err := bs.GetStateSummaryFrontierFailed(vdrIDs[1], *reqID); err != nil {
+		t.Fatal(err)
+	}
+	if vm.synced != nil {
+		t.Fatalf("shouldn't have synced the VM")
+	}
+	if !bootstrapping {
+		t.Fatalf("should have started bootstrapping")
+	}
+}
+
+func TestGetStateChunk(t *testing.T) {
+	chunk := &testChunk{id: ids.NewID([32]byte{1}), bytes: []byte{1}, stored: true}
+	vm := &testStateSyncVM{
+		chunks: map[string]*testChunk{string(chunk.bytes): chunk},
+	}
+	config, sender, vdrIDs := newStateSyncTest(t, vm, 2)
+	bs := Bootstrapper{Config: config}
+
+	sent := []byte(nil)
+	sender.StateChunkF = func(_ ids.ShortID, _ uint32, chunk []byte) { sent = chunk }
+	if err := bs.GetStateChunk(vdrIDs[0], 0, chunk.id); err != nil {
+		t.Fatal(err)
+	}
+	if string(sent) != string(chunk.bytes) {
+		t.Fatalf("should have sent the chunk")
+	}
+
+	// Requests for unknown chunks are dropped
+	sender.StateChunkF = nil
+	if err := bs.GetStateChunk(vdrIDs[0], 0, ids.NewID([32]byte{2})); err != nil {
+		t.Fatal(err)
+	}
+}
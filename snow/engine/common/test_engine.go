@@ -44,6 +44,14 @@ type EngineTest struct {
 	CantQueryFailed,
 	CantChits,
 
+	CantGetStateSummaryFrontier,
+	CantGetStateSummaryFrontierFailed,
+	CantStateSummaryFrontier,
+
+	CantGetStateChunk,
+	CantGetStateChunkFailed,
+	CantStateChunk,
+
 	CantConnected,
 	CantDisconnected,
 
@@ -59,8 +67,12 @@ type EngineTest struct {
 	AcceptedFrontierF, GetAcceptedF, AcceptedF, ChitsF func(validatorID ids.ShortID, requestID uint32, containerIDs ids.Set) error
 	GetAcceptedFrontierF, GetFailedF, GetAncestorsFailedF,
 	QueryFailedF, GetAcceptedFrontierFailedF, GetAcceptedFailedF func(validatorID ids.ShortID, requestID uint32) error
-	ConnectedF, DisconnectedF func(validatorID ids.ShortID) error
-	HealthF                   func() (interface{}, error)
+	ConnectedF, DisconnectedF          func(validatorID ids.ShortID) error
+	StateSummaryFrontierF, StateChunkF func(validatorID ids.ShortID, requestID uint32, bytes []byte) error
+	GetStateChunkF                     func(validatorID ids.ShortID, requestID uint32, chunkID ids.ID) error
+	GetStateSummaryFrontierF, GetStateSummaryFrontierFailedF,
+	GetStateChunkFailedF func(validatorID ids.ShortID, requestID uint32) error
+	HealthF func() (interface{}, error)
 }
 
 var _ Engine = &EngineTest{}
@@ -97,6 +109,14 @@ func (e *EngineTest) Default(cant bool) {
 	e.CantQueryFailed = cant
 	e.CantChits = cant
 
+	e.CantGetStateSummaryFrontier = cant
+	e.CantGetStateSummaryFrontierFailed = cant
+	e.CantStateSummaryFrontier = cant
+
+	e.CantGetStateChunk = cant
+	e.CantGetStateChunkFailed = cant
+	e.CantStateChunk = cant
+
 	e.CantConnected = cant
 	e.CantDisconnected = cant
 
@@ -395,6 +415,90 @@ func (e *EngineTest) Chits(validatorID ids.ShortID, requestID uint32, containerI
 	return errors.New("unexpectedly called Chits")
 }
 
+// GetStateSummaryFrontier ...
+func (e *EngineTest) GetStateSummaryFrontier(validatorID ids.ShortID, requestID uint32) error {
+	if e.GetStateSummaryFrontierF != nil {
+		return e.GetStateSummaryFrontierF(validatorID, requestID)
+	}
+	if !e.CantGetStateSummaryFrontier {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called GetStateSummaryFrontier")
+	}
+	return errors.New("unexpectedly called GetStateSummaryFrontier")
+}
+
+// StateSummaryFrontier ...
+func (e *EngineTest) StateSummaryFrontier(validatorID ids.ShortID, requestID uint32, summary []byte) error {
+	if e.StateSummaryFrontierF != nil {
+		return e.StateSummaryFrontierF(validatorID, requestID, summary)
+	}
+	if !e.CantStateSummaryFrontier {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called StateSummaryFrontier")
+	}
+	return errors.New("unexpectedly called StateSummaryFrontier")
+}
+
+// GetStateSummaryFrontierFailed ...
+func (e *EngineTest) GetStateSummaryFrontierFailed(validatorID ids.ShortID, requestID uint32) error {
+	if e.GetStateSummaryFrontierFailedF != nil {
+		return e.GetStateSummaryFrontierFailedF(validatorID, requestID)
+	}
+	if !e.CantGetStateSummaryFrontierFailed {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called GetStateSummaryFrontierFailed")
+	}
+	return errors.New("unexpectedly called GetStateSummaryFrontierFailed")
+}
+
+// GetStateChunk ...
+func (e *EngineTest) GetStateChunk(validatorID ids.ShortID, requestID uint32, chunkID ids.ID) error {
+	if e.GetStateChunkF != nil {
+		return e.GetStateChunkF(validatorID, requestID, chunkID)
+	}
+	if !e.CantGetStateChunk {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called GetStateChunk")
+	}
+	return errors.New("unexpectedly called GetStateChunk")
+}
+
+// StateChunk ...
+func (e *EngineTest) StateChunk(validatorID ids.ShortID, requestID uint32, chunk []byte) error {
+	if e.StateChunkF != nil {
+		return e.StateChunkF(validatorID, requestID, chunk)
+	}
+	if !e.CantStateChunk {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called StateChunk")
+	}
+	return errors.New("unexpectedly called StateChunk")
+}
+
+// GetStateChunkFailed ...
+func (e *EngineTest) GetStateChunkFailed(validatorID ids.ShortID, requestID uint32) error {
+	if e.GetStateChunkFailedF != nil {
+		return e.GetStateChunkFailedF(validatorID, requestID)
+	}
+	if !e.CantGetStateChunkFailed {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called GetStateChunkFailed")
+	}
+	return errors.New("unexpectedly called GetStateChunkFailed")
+}
+
 // Connected ...
 func (e *EngineTest) Connected(validatorID ids.ShortID) error {
 	if e.ConnectedF != nil {
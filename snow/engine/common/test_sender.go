@@ -17,6 +17,8 @@ type SenderTest struct {
 	CantGetAccepted, CantAccepted,
 	CantGet, CantGetAncestors, CantPut, CantMultiPut,
 	CantPullQuery, CantPushQuery, CantChits,
+	CantGetStateSummaryFrontier, CantStateSummaryFrontier,
+	CantGetStateChunk, CantStateChunk,
 	CantGossip bool
 
 	GetAcceptedFrontierF func(ids.ShortSet, uint32)
@@ -31,6 +33,11 @@ type SenderTest struct {
 	PullQueryF           func(ids.ShortSet, uint32, ids.ID)
 	ChitsF               func(ids.ShortID, uint32, ids.Set)
 	GossipF              func(ids.ID, []byte)
+
+	GetStateSummaryFrontierF func(ids.ShortSet, uint32)
+	StateSummaryFrontierF    func(ids.ShortID, uint32, []byte)
+	GetStateChunkF           func(ids.ShortID, uint32, ids.ID)
+	StateChunkF              func(ids.ShortID, uint32, []byte)
 }
 
 // Default set the default callable value to [cant]
@@ -46,6 +53,10 @@ func (s *SenderTest) Default(cant bool) {
 	s.CantPullQuery = cant
 	s.CantPushQuery = cant
 	s.CantChits = cant
+	s.CantGetStateSummaryFrontier = cant
+	s.CantStateSummaryFrontier = cant
+	s.CantGetStateChunk = cant
+	s.CantStateChunk = cant
 	s.CantGossip = cant
 }
 
@@ -170,6 +181,50 @@ func (s *SenderTest) Chits(vdr ids.ShortID, requestID uint32, votes ids.Set) {
 	}
 }
 
+// GetStateSummaryFrontier calls GetStateSummaryFrontierF if it was
+// initialized. If it wasn't initialized and this function shouldn't be called
+// and testing was initialized, then testing will fail.
+func (s *SenderTest) GetStateSummaryFrontier(validatorIDs ids.ShortSet, requestID uint32) {
+	if s.GetStateSummaryFrontierF != nil {
+		s.GetStateSummaryFrontierF(validatorIDs, requestID)
+	} else if s.CantGetStateSummaryFrontier && s.T != nil {
+		s.T.Fatalf("Unexpectedly called GetStateSummaryFrontier")
+	}
+}
+
+// StateSummaryFrontier calls StateSummaryFrontierF if it was initialized. If
+// it wasn't initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *SenderTest) StateSummaryFrontier(validatorID ids.ShortID, requestID uint32, summary []byte) {
+	if s.StateSummaryFrontierF != nil {
+		s.StateSummaryFrontierF(validatorID, requestID, summary)
+	} else if s.CantStateSummaryFrontier && s.T != nil {
+		s.T.Fatalf("Unexpectedly called StateSummaryFrontier")
+	}
+}
+
+// GetStateChunk calls GetStateChunkF if it was initialized. If it wasn't
+// initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *SenderTest) GetStateChunk(validatorID ids.ShortID, requestID uint32, chunkID ids.ID) {
+	if s.GetStateChunkF != nil {
+		s.GetStateChunkF(validatorID, requestID, chunkID)
+	} else if s.CantGetStateChunk && s.T != nil {
+		s.T.Fatalf("Unexpectedly called GetStateChunk")
+	}
+}
+
+// StateChunk calls StateChunkF if it was initialized. If it wasn't initialized
+// and this function shouldn't be called and testing was initialized, then
+// testing will fail.
+func (s *SenderTest) StateChunk(validatorID ids.ShortID, requestID uint32, chunk []byte) {
+	if s.StateChunkF != nil {
+		s.StateChunkF(validatorID, requestID, chunk)
+	} else if s.CantStateChunk && s.T != nil {
+		s.T.Fatalf("Unexpectedly called StateChunk")
+	}
+}
+
 // Gossip calls GossipF if it was initialized. If it wasn't initialized and this
 // function shouldn't be called and testing was initialized, then testing will
 // fail.
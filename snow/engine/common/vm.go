@@ -82,3 +82,19 @@ type StaticVM interface {
 	// genesis bytes this VM can interpret.
 	CreateStaticHandlers() map[string]*HTTPHandler
 }
+
+// VersionedVM describes a VM that serves more than one version of its API, so
+// that breaking changes can be made to the API without breaking clients of
+// earlier versions.
+type VersionedVM interface {
+	VM
+
+	// Creates the HTTP handlers of the versions of the chain's API after the
+	// first, which is served by the handlers CreateHandlers returns. Each
+	// handler has the path:
+	// [Address of node]/ext/bc/[chain ID]/v[version]/[extension]
+	//
+	// Returns a mapping from versions to mappings from [extension]s to HTTP
+	// handlers.
+	CreateVersionedHandlers() map[uint32]map[string]*HTTPHandler
+}
@@ -0,0 +1,250 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// the kinds of recorded events
+	messageEvent byte = iota
+	sampleEvent
+
+	// maxEventSize is the largest a recorded event can be
+	maxEventSize = 1 << 24
+)
+
+// event is a message an engine handled, or a sample of validators it took
+type event struct {
+	kind byte
+
+	// set if [kind] is messageEvent
+	msgType      constants.MsgType
+	validatorID  ids.ShortID
+	requestID    uint32
+	containerID  ids.ID
+	container    []byte
+	containers   [][]byte
+	containerIDs ids.Set
+	notification common.Message
+
+	// set if [kind] is sampleEvent
+	sampleSize   int
+	sampleFailed bool
+	sample       []validators.Validator
+}
+
+// hasContainerIDs returns true if messages of type [msgType] hold a set of
+// container IDs
+func hasContainerIDs(msgType constants.MsgType) bool {
+	switch msgType {
+	case constants.AcceptedFrontierMsg, constants.GetAcceptedMsg, constants.AcceptedMsg, constants.ChitsMsg:
+		return true
+	}
+	return false
+}
+
+// hasContainerID returns true if messages of type [msgType] hold a container
+// ID
+func hasContainerID(msgType constants.MsgType) bool {
+	switch msgType {
+	case constants.GetAncestorsMsg, constants.GetMsg, constants.PutMsg, constants.PushQueryMsg, constants.PullQueryMsg,
+		constants.GetStateChunkMsg:
+		return true
+	}
+	return false
+}
+
+// hasContainer returns true if messages of type [msgType] hold a container
+func hasContainer(msgType constants.MsgType) bool {
+	switch msgType {
+	case constants.PutMsg, constants.PushQueryMsg, constants.StateSummaryFrontierMsg, constants.StateChunkMsg:
+		return true
+	}
+	return false
+}
+
+// Bytes returns the binary representation of [e]
+func (e *event) Bytes() ([]byte, error) {
+	p := wrappers.Packer{MaxSize: maxEventSize}
+	p.PackByte(e.kind)
+	switch e.kind {
+	case messageEvent:
+		p.PackInt(uint32(e.msgType))
+		p.PackFixedBytes(e.validatorID.Bytes())
+		p.PackInt(e.requestID)
+		if hasContainerIDs(e.msgType) {
+			containerIDs := make([][]byte, 0, e.containerIDs.Len())
+			for _, containerID := range e.containerIDs.List() {
+				containerIDs = append(containerIDs, containerID.Bytes())
+			}
+			p.PackFixedByteSlices(containerIDs)
+		}
+		if hasContainerID(e.msgType) {
+			p.PackFixedBytes(e.containerID.Bytes())
+		}
+		if hasContainer(e.msgType) {
+			p.PackBytes(e.container)
+		}
+		switch e.msgType {
+		case constants.MultiPutMsg:
+			p.Pack2DByteSlice(e.containers)
+		case constants.NotifyMsg:
+			p.PackInt(uint32(e.notification))
+		}
+	case sampleEvent:
+		p.PackInt(uint32(e.sampleSize))
+		p.PackBool(e.sampleFailed)
+		p.PackInt(uint32(len(e.sample)))
+		for _, vdr := range e.sample {
+			p.PackFixedBytes(vdr.ID().Bytes())
+			p.PackLong(vdr.Weight())
+		}
+	default:
+		return nil, fmt.Errorf("unknown event kind %d", e.kind)
+	}
+	return p.Bytes, p.Err
+}
+
+// parseEvent parses the event with the binary representation [b]
+func parseEvent(b []byte) (*event, error) {
+	p := wrappers.Packer{Bytes: b}
+	e := &event{kind: p.UnpackByte()}
+	switch e.kind {
+	case messageEvent:
+		e.msgType = constants.MsgType(p.UnpackInt())
+		e.validatorID = unpackShortID(&p)
+		e.requestID = p.UnpackInt()
+		if hasContainerIDs(e.msgType) {
+			for _, containerID := range p.UnpackFixedByteSlices(hashing.HashLen) {
+				id, err := ids.ToID(containerID)
+				if err != nil {
+					return nil, err
+				}
+				e.containerIDs.Add(id)
+			}
+		}
+		if hasContainerID(e.msgType) {
+			e.containerID = unpackID(&p)
+		}
+		if hasContainer(e.msgType) {
+			e.container = p.UnpackBytes()
+		}
+		switch e.msgType {
+		case constants.MultiPutMsg:
+			e.containers = p.Unpack2DByteSlice()
+		case constants.NotifyMsg:
+			e.notification = common.Message(p.UnpackInt())
+		}
+	case sampleEvent:
+		e.sampleSize = int(p.UnpackInt())
+		e.sampleFailed = p.UnpackBool()
+		for i := p.UnpackInt(); i > 0 && !p.Errored(); i-- {
+			vdrID := unpackShortID(&p)
+			e.sample = append(e.sample, validators.NewValidator(vdrID, p.UnpackLong()))
+		}
+	default:
+		return nil, fmt.Errorf("unknown event kind %d", e.kind)
+	}
+	if p.Errored() {
+		return nil, p.Err
+	}
+	if p.Offset != len(b) {
+		return nil, fmt.Errorf("event has %d trailing bytes", len(b)-p.Offset)
+	}
+	return e, nil
+}
+
+func unpackShortID(p *wrappers.Packer) ids.ShortID {
+	id, _ := ids.ToShortID(p.UnpackFixedBytes(hashing.AddrLen))
+	return id
+}
+
+func unpackID(p *wrappers.Packer) ids.ID {
+	id, _ := ids.ToID(p.UnpackFixedBytes(hashing.HashLen))
+	return id
+}
+
+// handle passes the message [e] to [engine]
+func (e *event) handle(engine common.Engine) error {
+	switch e.msgType {
+	case constants.GetAcceptedFrontierMsg:
+		return engine.GetAcceptedFrontier(e.validatorID, e.requestID)
+	case constants.AcceptedFrontierMsg:
+		return engine.AcceptedFrontier(e.validatorID, e.requestID, e.containerIDs)
+	case constants.GetAcceptedFrontierFailedMsg:
+		return engine.GetAcceptedFrontierFailed(e.validatorID, e.requestID)
+	case constants.GetAcceptedMsg:
+		return engine.GetAccepted(e.validatorID, e.requestID, e.containerIDs)
+	case constants.AcceptedMsg:
+		return engine.Accepted(e.validatorID, e.requestID, e.containerIDs)
+	case constants.GetAcceptedFailedMsg:
+		return engine.GetAcceptedFailed(e.validatorID, e.requestID)
+	case constants.GetAncestorsMsg:
+		return engine.GetAncestors(e.validatorID, e.requestID, e.containerID)
+	case constants.GetAncestorsFailedMsg:
+		return engine.GetAncestorsFailed(e.validatorID, e.requestID)
+	case constants.MultiPutMsg:
+		return engine.MultiPut(e.validatorID, e.requestID, e.containers)
+	case constants.GetMsg:
+		return engine.Get(e.validatorID, e.requestID, e.containerID)
+	case constants.GetFailedMsg:
+		return engine.GetFailed(e.validatorID, e.requestID)
+	case constants.PutMsg:
+		return engine.Put(e.validatorID, e.requestID, e.containerID, e.container)
+	case constants.PushQueryMsg:
+		return engine.PushQuery(e.validatorID, e.requestID, e.containerID, e.container)
+	case constants.PullQueryMsg:
+		return engine.PullQuery(e.validatorID, e.requestID, e.containerID)
+	case constants.QueryFailedMsg:
+		return engine.QueryFailed(e.validatorID, e.requestID)
+	case constants.ChitsMsg:
+		return engine.Chits(e.validatorID, e.requestID, e.containerIDs)
+	case constants.GetStateSummaryFrontierMsg:
+		return engine.GetStateSummaryFrontier(e.validatorID, e.requestID)
+	case constants.StateSummaryFrontierMsg:
+		return engine.StateSummaryFrontier(e.validatorID, e.requestID, e.container)
+	case constants.GetStateSummaryFrontierFailedMsg:
+		return engine.GetStateSummaryFrontierFailed(e.validatorID, e.requestID)
+	case constants.GetStateChunkMsg:
+		return engine.GetStateChunk(e.validatorID, e.requestID, e.containerID)
+	case constants.StateChunkMsg:
+		return engine.StateChunk(e.validatorID, e.requestID, e.container)
+	case constants.GetStateChunkFailedMsg:
+		return engine.GetStateChunkFailed(e.validatorID, e.requestID)
+	case constants.ConnectedMsg:
+		return engine.Connected(e.validatorID)
+	case constants.DisconnectedMsg:
+		return engine.Disconnected(e.validatorID)
+	case constants.NotifyMsg:
+		return engine.Notify(e.notification)
+	case constants.GossipMsg:
+		return engine.Gossip()
+	default:
+		return fmt.Errorf("unknown message type %s", e.msgType)
+	}
+}
+
+func (e *event) String() string {
+	if e.kind == sampleEvent {
+		if e.sampleFailed {
+			return fmt.Sprintf("failed sample of %d validators", e.sampleSize)
+		}
+		vdrIDs := make([]string, len(e.sample))
+		for i, vdr := range e.sample {
+			vdrIDs[i] = vdr.ID().String()
+		}
+		return fmt.Sprintf("sample of %d validators [%s]", e.sampleSize, strings.Join(vdrIDs, ", "))
+	}
+	return fmt.Sprintf("%s from %s with request ID %d", e.msgType, e.validatorID, e.requestID)
+}
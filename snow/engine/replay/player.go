@@ -0,0 +1,165 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var (
+	// ErrDiverged is returned when the replayed engine stops behaving as the
+	// recorded engine did
+	ErrDiverged = errors.New("engine diverged from the recording")
+
+	errSampleFailed = errors.New("recorded sample failed")
+)
+
+// Player replays a recording against a fresh engine
+type Player struct {
+	r *bufio.Reader
+
+	// the next event of the recording, if it was read but not yet replayed
+	next *event
+	// number of events replayed
+	events int
+	// number of messages replayed
+	messages int
+	// divergence found while the engine sampled validators, if any
+	err error
+}
+
+// NewPlayer returns a player of the recording read from [r]
+func NewPlayer(r io.Reader) (*Player, error) {
+	reader := bufio.NewReader(r)
+	header := make([]byte, len(recordingMagic)+1)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, errNotRecording
+	}
+	if !bytes.Equal(header[:len(recordingMagic)], recordingMagic) {
+		return nil, errNotRecording
+	}
+	if version := header[len(recordingMagic)]; version != recordingVersion {
+		return nil, fmt.Errorf("recording has unknown version %d", version)
+	}
+	return &Player{r: reader}, nil
+}
+
+// Validators returns [vdrs], with each sample taken from it replaced by the
+// next sample of the recording. The engine the recording is played against
+// should sample validators from the returned set, including while it's
+// initialized.
+func (p *Player) Validators(vdrs validators.Set) validators.Set {
+	return &playedSet{validatorSet: vdrs, p: p}
+}
+
+// playedSet returns the recorded samples in place of samples of its validators
+type playedSet struct {
+	validatorSet
+	p *Player
+}
+
+// Sample implements the validators.Set interface
+func (s *playedSet) Sample(size int) ([]validators.Validator, error) {
+	return s.p.sample(size)
+}
+
+// sample returns the next event of the recording, which must be a sample of
+// [size] validators
+func (p *Player) sample(size int) ([]validators.Validator, error) {
+	e, err := p.peek()
+	switch {
+	case err == io.EOF:
+		return nil, p.diverged(fmt.Errorf("engine sampled %d validators after the recording ended", size))
+	case err != nil:
+		p.err = err
+		return nil, err
+	case e.kind != sampleEvent:
+		return nil, p.diverged(fmt.Errorf("engine sampled %d validators, but the recording has %s", size, e))
+	case e.sampleSize != size:
+		return nil, p.diverged(fmt.Errorf("engine sampled %d validators, but the recording has %s", size, e))
+	}
+
+	p.next = nil
+	p.events++
+	if e.sampleFailed {
+		return nil, errSampleFailed
+	}
+	return e.sample, nil
+}
+
+// diverged records that the engine diverged from the recording at the next
+// event due to [err]
+func (p *Player) diverged(err error) error {
+	if p.err == nil {
+		p.err = fmt.Errorf("%w at event %d: %s", ErrDiverged, p.events, err)
+	}
+	return p.err
+}
+
+// peek returns the next event of the recording without replaying it. Returns
+// io.EOF if the recording has ended.
+func (p *Player) peek() (*event, error) {
+	if p.next != nil {
+		return p.next, nil
+	}
+
+	sizeBytes := make([]byte, wrappers.IntLen)
+	if _, err := io.ReadFull(p.r, sizeBytes); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("truncated recording: %w", err)
+		}
+		return nil, err
+	}
+	sizePacker := wrappers.Packer{Bytes: sizeBytes}
+	size := sizePacker.UnpackInt()
+	if size > maxEventSize {
+		return nil, fmt.Errorf("recorded event is too large")
+	}
+
+	eventBytes := make([]byte, size)
+	if _, err := io.ReadFull(p.r, eventBytes); err != nil {
+		return nil, fmt.Errorf("truncated recording: %w", err)
+	}
+	e, err := parseEvent(eventBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse recorded event: %w", err)
+	}
+	p.next = e
+	return e, nil
+}
+
+// Play passes the recorded messages to [engine], in the order they were
+// recorded, until the recording ends. [engine] must have been initialized
+// with the validator sets returned by Validators. Returns the number of
+// messages replayed, and ErrDiverged if [engine] didn't sample the validators
+// the recorded engine did.
+func (p *Player) Play(engine common.Engine) (int, error) {
+	for p.err == nil {
+		e, err := p.peek()
+		switch {
+		case err == io.EOF:
+			return p.messages, nil
+		case err != nil:
+			return p.messages, err
+		case e.kind != messageEvent:
+			return p.messages, p.diverged(fmt.Errorf("engine didn't take the recorded %s", e))
+		}
+
+		p.next = nil
+		p.events++
+		p.messages++
+		if err := e.handle(engine); err != nil {
+			return p.messages, fmt.Errorf("engine failed to handle %s: %w", e, err)
+		}
+	}
+	return p.messages, p.err
+}
@@ -0,0 +1,407 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package replay records the messages a consensus engine handles, so that
+// they can be replayed against a fresh engine to reproduce its decisions.
+//
+// A recording holds every message the engine handled, in the order it handled
+// them, along with every sample of validators it took. When the recording is
+// played, the samples are returned to the fresh engine in place of new ones,
+// so that it polls the same validators and the recorded responses reach the
+// polls they were meant for. If the fresh engine handles a message without
+// taking the samples the recorded one did, or takes samples the recorded one
+// didn't, it has diverged from the recorded engine.
+//
+// The fresh engine's chain must start from the state the recorded chain was
+// in when the recording started, and its VM must ignore the transactions it's
+// sent other than through the engine, as the VM notifications the recorded
+// engine handled are replayed.
+package replay
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// version of the recording format
+const recordingVersion byte = 0
+
+var (
+	// recordingMagic starts every recording
+	recordingMagic = []byte("avarec")
+
+	errNotRecording    = errors.New("not a consensus recording")
+	errRecordingClosed = errors.New("recording is closed")
+)
+
+// Recorder is an engine that records the messages it handles before passing
+// them to the engine it wraps
+type Recorder struct {
+	// Engine the recorded messages are passed to. Must be set before the
+	// recorder handles any messages.
+	common.Engine
+
+	log logging.Logger
+
+	lock   sync.Mutex
+	w      *bufio.Writer
+	closer io.Closer
+	// error that stopped recording, if any
+	err error
+}
+
+// NewRecorder returns a recorder that writes its recording to [w], which is
+// closed when the engine shuts down
+func NewRecorder(w io.WriteCloser, log logging.Logger) (*Recorder, error) {
+	r := &Recorder{
+		log:    log,
+		w:      bufio.NewWriter(w),
+		closer: w,
+	}
+	header := append(append([]byte{}, recordingMagic...), recordingVersion)
+	if _, err := r.w.Write(header); err != nil {
+		return nil, err
+	}
+	return r, r.w.Flush()
+}
+
+// Validators returns [vdrs], with each sample taken from it recorded. The
+// engine should sample validators from the returned set.
+func (r *Recorder) Validators(vdrs validators.Set) validators.Set {
+	return &recordedSet{validatorSet: vdrs, r: r}
+}
+
+// validatorSet is embedded by the wrappers of validator sets. The alias names
+// the embedded field, which can't be named after the Set method.
+type validatorSet = validators.Set
+
+// recordedSet records the samples taken from a validator set
+type recordedSet struct {
+	validatorSet
+	r *Recorder
+}
+
+// Sample implements the validators.Set interface
+func (s *recordedSet) Sample(size int) ([]validators.Validator, error) {
+	sample, err := s.validatorSet.Sample(size)
+	s.r.record(&event{
+		kind:         sampleEvent,
+		sampleSize:   size,
+		sampleFailed: err != nil,
+		sample:       sample,
+	})
+	return sample, err
+}
+
+// record writes [e] to the recording. Each event is flushed once it's written,
+// so that the recording is complete if the node crashes. Once an event fails to
+// be written, recording stops.
+func (r *Recorder) record(e *event) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.err != nil {
+		return
+	}
+
+	eventBytes, err := e.Bytes()
+	if err == nil {
+		p := wrappers.Packer{MaxSize: wrappers.IntLen + len(eventBytes)}
+		p.PackBytes(eventBytes)
+		err = p.Err
+		if err == nil {
+			_, err = r.w.Write(p.Bytes)
+		}
+	}
+	if err == nil {
+		err = r.w.Flush()
+	}
+	if err != nil {
+		r.err = err
+		r.log.Warn("stopped recording consensus messages due to: %s", err)
+	}
+}
+
+// recordMsg records a message of type [msgType] from [validatorID]
+func (r *Recorder) recordMsg(msgType constants.MsgType, validatorID ids.ShortID, requestID uint32) {
+	r.record(&event{
+		kind:        messageEvent,
+		msgType:     msgType,
+		validatorID: validatorID,
+		requestID:   requestID,
+	})
+}
+
+// GetAcceptedFrontier implements the common.Engine interface
+func (r *Recorder) GetAcceptedFrontier(validatorID ids.ShortID, requestID uint32) error {
+	r.recordMsg(constants.GetAcceptedFrontierMsg, validatorID, requestID)
+	return r.Engine.GetAcceptedFrontier(validatorID, requestID)
+}
+
+// AcceptedFrontier implements the common.Engine interface
+func (r *Recorder) AcceptedFrontier(validatorID ids.ShortID, requestID uint32, containerIDs ids.Set) error {
+	r.record(&event{
+		kind:         messageEvent,
+		msgType:      constants.AcceptedFrontierMsg,
+		validatorID:  validatorID,
+		requestID:    requestID,
+		containerIDs: containerIDs,
+	})
+	return r.Engine.AcceptedFrontier(validatorID, requestID, containerIDs)
+}
+
+// GetAcceptedFrontierFailed implements the common.Engine interface
+func (r *Recorder) GetAcceptedFrontierFailed(validatorID ids.ShortID, requestID uint32) error {
+	r.recordMsg(constants.GetAcceptedFrontierFailedMsg, validatorID, requestID)
+	return r.Engine.GetAcceptedFrontierFailed(validatorID, requestID)
+}
+
+// GetAccepted implements the common.Engine interface
+func (r *Recorder) GetAccepted(validatorID ids.ShortID, requestID uint32, containerIDs ids.Set) error {
+	r.record(&event{
+		kind:         messageEvent,
+		msgType:      constants.GetAcceptedMsg,
+		validatorID:  validatorID,
+		requestID:    requestID,
+		containerIDs: containerIDs,
+	})
+	return r.Engine.GetAccepted(validatorID, requestID, containerIDs)
+}
+
+// Accepted implements the common.Engine interface
+func (r *Recorder) Accepted(validatorID ids.ShortID, requestID uint32, containerIDs ids.Set) error {
+	r.record(&event{
+		kind:         messageEvent,
+		msgType:      constants.AcceptedMsg,
+		validatorID:  validatorID,
+		requestID:    requestID,
+		containerIDs: containerIDs,
+	})
+	return r.Engine.Accepted(validatorID, requestID, containerIDs)
+}
+
+// GetAcceptedFailed implements the common.Engine interface
+func (r *Recorder) GetAcceptedFailed(validatorID ids.ShortID, requestID uint32) error {
+	r.recordMsg(constants.GetAcceptedFailedMsg, validatorID, requestID)
+	return r.Engine.GetAcceptedFailed(validatorID, requestID)
+}
+
+// GetAncestors implements the common.Engine interface
+func (r *Recorder) GetAncestors(validatorID ids.ShortID, requestID uint32, containerID ids.ID) error {
+	r.record(&event{
+		kind:        messageEvent,
+		msgType:     constants.GetAncestorsMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		containerID: containerID,
+	})
+	return r.Engine.GetAncestors(validatorID, requestID, containerID)
+}
+
+// MultiPut implements the common.Engine interface
+func (r *Recorder) MultiPut(validatorID ids.ShortID, requestID uint32, containers [][]byte) error {
+	r.record(&event{
+		kind:        messageEvent,
+		msgType:     constants.MultiPutMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		containers:  containers,
+	})
+	return r.Engine.MultiPut(validatorID, requestID, containers)
+}
+
+// GetAncestorsFailed implements the common.Engine interface
+func (r *Recorder) GetAncestorsFailed(validatorID ids.ShortID, requestID uint32) error {
+	r.recordMsg(constants.GetAncestorsFailedMsg, validatorID, requestID)
+	return r.Engine.GetAncestorsFailed(validatorID, requestID)
+}
+
+// Get implements the common.Engine interface
+func (r *Recorder) Get(validatorID ids.ShortID, requestID uint32, containerID ids.ID) error {
+	r.record(&event{
+		kind:        messageEvent,
+		msgType:     constants.GetMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		containerID: containerID,
+	})
+	return r.Engine.Get(validatorID, requestID, containerID)
+}
+
+// Put implements the common.Engine interface
+func (r *Recorder) Put(validatorID ids.ShortID, requestID uint32, containerID ids.ID, container []byte) error {
+	r.record(&event{
+		kind:        messageEvent,
+		msgType:     constants.PutMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		containerID: containerID,
+		container:   container,
+	})
+	return r.Engine.Put(validatorID, requestID, containerID, container)
+}
+
+// GetFailed implements the common.Engine interface
+func (r *Recorder) GetFailed(validatorID ids.ShortID, requestID uint32) error {
+	r.recordMsg(constants.GetFailedMsg, validatorID, requestID)
+	return r.Engine.GetFailed(validatorID, requestID)
+}
+
+// PushQuery implements the common.Engine interface
+func (r *Recorder) PushQuery(validatorID ids.ShortID, requestID uint32, containerID ids.ID, container []byte) error {
+	r.record(&event{
+		kind:        messageEvent,
+		msgType:     constants.PushQueryMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		containerID: containerID,
+		container:   container,
+	})
+	return r.Engine.PushQuery(validatorID, requestID, containerID, container)
+}
+
+// PullQuery implements the common.Engine interface
+func (r *Recorder) PullQuery(validatorID ids.ShortID, requestID uint32, containerID ids.ID) error {
+	r.record(&event{
+		kind:        messageEvent,
+		msgType:     constants.PullQueryMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		containerID: containerID,
+	})
+	return r.Engine.PullQuery(validatorID, requestID, containerID)
+}
+
+// QueryFailed implements the common.Engine interface
+func (r *Recorder) QueryFailed(validatorID ids.ShortID, requestID uint32) error {
+	r.recordMsg(constants.QueryFailedMsg, validatorID, requestID)
+	return r.Engine.QueryFailed(validatorID, requestID)
+}
+
+// Chits implements the common.Engine interface
+func (r *Recorder) Chits(validatorID ids.ShortID, requestID uint32, votes ids.Set) error {
+	r.record(&event{
+		kind:         messageEvent,
+		msgType:      constants.ChitsMsg,
+		validatorID:  validatorID,
+		requestID:    requestID,
+		containerIDs: votes,
+	})
+	return r.Engine.Chits(validatorID, requestID, votes)
+}
+
+// GetStateSummaryFrontier implements the common.Engine interface
+func (r *Recorder) GetStateSummaryFrontier(validatorID ids.ShortID, requestID uint32) error {
+	r.recordMsg(constants.GetStateSummaryFrontierMsg, validatorID, requestID)
+	return r.Engine.GetStateSummaryFrontier(validatorID, requestID)
+}
+
+// StateSummaryFrontier implements the common.Engine interface
+func (r *Recorder) StateSummaryFrontier(validatorID ids.ShortID, requestID uint32, summary []byte) error {
+	r.record(&event{
+		kind:        messageEvent,
+		msgType:     constants.StateSummaryFrontierMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		container:   summary,
+	})
+	return r.Engine.StateSummaryFrontier(validatorID, requestID, summary)
+}
+
+// GetStateSummaryFrontierFailed implements the common.Engine interface
+func (r *Recorder) GetStateSummaryFrontierFailed(validatorID ids.ShortID, requestID uint32) error {
+	r.recordMsg(constants.GetStateSummaryFrontierFailedMsg, validatorID, requestID)
+	return r.Engine.GetStateSummaryFrontierFailed(validatorID, requestID)
+}
+
+// GetStateChunk implements the common.Engine interface
+func (r *Recorder) GetStateChunk(validatorID ids.ShortID, requestID uint32, chunkID ids.ID) error {
+	r.record(&event{
+		kind:        messageEvent,
+		msgType:     constants.GetStateChunkMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		containerID: chunkID,
+	})
+	return r.Engine.GetStateChunk(validatorID, requestID, chunkID)
+}
+
+// StateChunk implements the common.Engine interface
+func (r *Recorder) StateChunk(validatorID ids.ShortID, requestID uint32, chunk []byte) error {
+	r.record(&event{
+		kind:        messageEvent,
+		msgType:     constants.StateChunkMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		container:   chunk,
+	})
+	return r.Engine.StateChunk(validatorID, requestID, chunk)
+}
+
+// GetStateChunkFailed implements the common.Engine interface
+func (r *Recorder) GetStateChunkFailed(validatorID ids.ShortID, requestID uint32) error {
+	r.recordMsg(constants.GetStateChunkFailedMsg, validatorID, requestID)
+	return r.Engine.GetStateChunkFailed(validatorID, requestID)
+}
+
+// Connected implements the common.Engine interface
+func (r *Recorder) Connected(validatorID ids.ShortID) error {
+	r.recordMsg(constants.ConnectedMsg, validatorID, 0)
+	return r.Engine.Connected(validatorID)
+}
+
+// Disconnected implements the common.Engine interface
+func (r *Recorder) Disconnected(validatorID ids.ShortID) error {
+	r.recordMsg(constants.DisconnectedMsg, validatorID, 0)
+	return r.Engine.Disconnected(validatorID)
+}
+
+// Gossip implements the common.Engine interface
+func (r *Recorder) Gossip() error {
+	r.recordMsg(constants.GossipMsg, ids.ShortEmpty, 0)
+	return r.Engine.Gossip()
+}
+
+// Notify implements the common.Engine interface
+func (r *Recorder) Notify(msg common.Message) error {
+	r.record(&event{
+		kind:         messageEvent,
+		msgType:      constants.NotifyMsg,
+		validatorID:  ids.ShortEmpty,
+		notification: msg,
+	})
+	return r.Engine.Notify(msg)
+}
+
+// Shutdown implements the common.Engine interface. The recording is closed
+// once the wrapped engine shuts down.
+func (r *Recorder) Shutdown() error {
+	err := r.Engine.Shutdown()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.err == nil {
+		r.err = errRecordingClosed
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		r.w.Flush(),
+		r.closer.Close(),
+	)
+	if errs.Errored() {
+		return fmt.Errorf("couldn't shut down recorded engine: %w", errs.Err)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+type closingBuffer struct{ bytes.Buffer }
+
+func (*closingBuffer) Close() error { return nil }
+
+// newTestEngine returns an engine that describes each message it handles in
+// [handled]. If [vdrs] is non-nil, the engine samples 2 validators from it for
+// each PushQuery it handles.
+func newTestEngine(t *testing.T, vdrs validators.Set, handled *[]string) *common.EngineTest {
+	engine := &common.EngineTest{T: t}
+	engine.Default(true)
+	engine.CantShutdown = false
+	engine.PushQueryF = func(validatorID ids.ShortID, requestID uint32, containerID ids.ID, container []byte) error {
+		msg := fmt.Sprintf("PushQuery(%s, %d, %s, %v)", validatorID, requestID, containerID, container)
+		if vdrs != nil {
+			sample, err := vdrs.Sample(2)
+			if err != nil {
+				return err
+			}
+			for _, vdr := range sample {
+				msg += " " + vdr.ID().String()
+			}
+		}
+		*handled = append(*handled, msg)
+		return nil
+	}
+	engine.ChitsF = func(validatorID ids.ShortID, requestID uint32, votes ids.Set) error {
+		*handled = append(*handled, fmt.Sprintf("Chits(%s, %d, %s)", validatorID, requestID, votes))
+		return nil
+	}
+	engine.MultiPutF = func(validatorID ids.ShortID, requestID uint32, containers [][]byte) error {
+		*handled = append(*handled, fmt.Sprintf("MultiPut(%s, %d, %v)", validatorID, requestID, containers))
+		return nil
+	}
+	engine.NotifyF = func(msg common.Message) error {
+		*handled = append(*handled, fmt.Sprintf("Notify(%s)", msg))
+		return nil
+	}
+	engine.GossipF = func() error {
+		*handled = append(*handled, "Gossip()")
+		return nil
+	}
+	engine.ConnectedF = func(validatorID ids.ShortID) error {
+		*handled = append(*handled, fmt.Sprintf("Connected(%s)", validatorID))
+		return nil
+	}
+	return engine
+}
+
+// record returns a recording of messages handled by an engine that samples
+// validators, and the descriptions of the messages it handled
+func record(t *testing.T) ([]byte, []string) {
+	vdrs := validators.NewSet()
+	for i := byte(1); i <= 5; i++ {
+		if err := vdrs.AddWeight(ids.NewShortID([20]byte{i}), 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := &closingBuffer{}
+	r, err := NewRecorder(w, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handled := []string(nil)
+	r.Engine = newTestEngine(t, r.Validators(vdrs), &handled)
+
+	vdrID := ids.NewShortID([20]byte{1})
+	containerID := ids.NewID([32]byte{2})
+	errs := []error{
+		r.Connected(vdrID),
+		r.PushQuery(vdrID, 1, containerID, []byte{3}),
+		r.Chits(vdrID, 2, ids.Set{containerID.Key(): true}),
+		r.MultiPut(vdrID, 3, [][]byte{{4}, {5, 6}}),
+		r.Notify(common.PendingTxs),
+		r.PushQuery(vdrID, 4, containerID, []byte{7}),
+		r.Gossip(),
+		r.Shutdown(),
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return w.Bytes(), handled
+}
+
+func TestReplay(t *testing.T) {
+	recording, recorded := record(t)
+
+	p, err := NewPlayer(bytes.NewReader(recording))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The replayed engine samples from an empty set, so it can only poll the
+	// validators the recorded engine did if the samples are replayed
+	replayed := []string(nil)
+	engine := newTestEngine(t, p.Validators(validators.NewSet()), &replayed)
+	n, err := p.Play(engine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(recorded) {
+		t.Fatalf("replayed %d messages, but %d were recorded", n, len(recorded))
+	}
+	for i := range recorded {
+		if replayed[i] != recorded[i] {
+			t.Fatalf("replayed %q, but recorded %q", replayed[i], recorded[i])
+		}
+	}
+}
+
+func TestReplayDiverged(t *testing.T) {
+	recording, _ := record(t)
+
+	// An engine that doesn't sample validators when the recorded one did has
+	// diverged
+	p, err := NewPlayer(bytes.NewReader(recording))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed := []string(nil)
+	if _, err := p.Play(newTestEngine(t, nil, &replayed)); !errors.Is(err, ErrDiverged) {
+		t.Fatalf("expected the engine to diverge, but got: %v", err)
+	}
+
+	// So is an engine that samples validators when the recorded one didn't
+	p, err = NewPlayer(bytes.NewReader(recording))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdrs := p.Validators(validators.NewSet())
+	engine := newTestEngine(t, vdrs, &replayed)
+	engine.ConnectedF = func(ids.ShortID) error {
+		_, err := vdrs.Sample(1)
+		return err
+	}
+	if _, err := p.Play(engine); !errors.Is(err, ErrDiverged) {
+		t.Fatalf("expected the engine to diverge, but got: %v", err)
+	}
+}
+
+func TestPlayerRejectsOtherFiles(t *testing.T) {
+	if _, err := NewPlayer(bytes.NewReader([]byte("not a recording"))); err != errNotRecording {
+		t.Fatalf("expected %s, but got: %v", errNotRecording, err)
+	}
+}
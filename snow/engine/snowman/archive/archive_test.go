@@ -0,0 +1,121 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archive
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+var errUnknownBlock = errors.New("unknown block")
+
+func newTestArchive(t *testing.T) (*httptest.Server, []*snowman.TestBlock) {
+	unknown := &snowman.TestBlock{TestDecidable: choices.TestDecidable{
+		IDV:     ids.Empty.Prefix(100),
+		StatusV: choices.Unknown,
+	}}
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(0),
+			StatusV: choices.Accepted,
+		},
+		ParentV: unknown,
+		BytesV:  []byte{0},
+	}
+	blk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Accepted,
+		},
+		ParentV: blk0,
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+	blk2 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: blk1,
+		HeightV: 2,
+		BytesV:  []byte{2},
+	}
+	blks := []*snowman.TestBlock{blk0, blk1, blk2}
+
+	vm := &block.TestVM{}
+	vm.T = t
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID().Equals(blkID) {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+
+	handler := NewHandler(snow.DefaultContextTest(), vm)
+	mux := http.NewServeMux()
+	mux.Handle("/ext/bc/"+ids.Empty.String()+Endpoint, handler.Handler)
+	return httptest.NewServer(mux), blks
+}
+
+func TestArchiveGetAncestors(t *testing.T) {
+	server, blks := newTestArchive(t)
+	defer server.Close()
+
+	client := NewClient(server.URL, ids.Empty, "", time.Second)
+	ancestors, err := client.GetAncestors(blks[1].ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ancestors) != 2 {
+		t.Fatalf("expected 2 blocks but got %d", len(ancestors))
+	}
+	if !bytes.Equal(ancestors[0], blks[1].Bytes()) {
+		t.Fatalf("expected the requested block first")
+	}
+	if !bytes.Equal(ancestors[1], blks[0].Bytes()) {
+		t.Fatalf("expected the parent of the requested block second")
+	}
+}
+
+func TestArchiveOnlyServesAccepted(t *testing.T) {
+	server, blks := newTestArchive(t)
+	defer server.Close()
+
+	client := NewClient(server.URL, ids.Empty, "", time.Second)
+	if _, err := client.GetAncestors(blks[2].ID()); err == nil {
+		t.Fatalf("shouldn't have served a processing block")
+	}
+	if _, err := client.GetAncestors(ids.Empty.Prefix(50)); err == nil {
+		t.Fatalf("shouldn't have served an unknown block")
+	}
+}
+
+func TestArchiveMax(t *testing.T) {
+	server, blks := newTestArchive(t)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/ext/bc/" + ids.Empty.String() + Endpoint + "?" + BlockIDParam + "=" + blks[1].ID().String() + "&" + MaxParam + "=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %s", resp.Status)
+	}
+	if resp.ContentLength != 4+4+1 {
+		t.Fatalf("expected a single block to be returned but response had length %d", resp.ContentLength)
+	}
+}
@@ -0,0 +1,84 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archive
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// Client fetches accepted blocks from the archive of a remote node.
+//
+// The returned blocks are not trusted. It is the responsibility of the caller
+// to verify that the blocks are ancestors of a block that was agreed upon by
+// consensus before accepting them.
+type Client struct {
+	// URL of the chain's archive, not including any query parameters
+	endpoint  string
+	authToken string
+	client    http.Client
+}
+
+// NewClient returns a client for the archive of chain [chainID] served by
+// the node at [uri] (e.g. http://127.0.0.1:9650). If [authToken] is non-empty,
+// it is passed as a bearer token on every request.
+func NewClient(uri string, chainID ids.ID, authToken string, requestTimeout time.Duration) *Client {
+	return &Client{
+		endpoint:  fmt.Sprintf("%s/ext/bc/%s%s", uri, chainID, Endpoint),
+		authToken: authToken,
+		client:    http.Client{Timeout: requestTimeout},
+	}
+}
+
+// GetAncestors returns the bytes of block [blkID] followed by as many of its
+// ancestors as the remote node is willing to return.
+func (c *Client) GetAncestors(blkID ids.ID) ([][]byte, error) {
+	query := url.Values{}
+	query.Set(BlockIDParam, blkID.String())
+
+	request, err := http.NewRequest(http.MethodGet, c.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.authToken != "" {
+		request.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	resp, err := c.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch %s from archive: %w", blkID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("archive responded to request for %s with status %s", blkID, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, MaxContainersLen+wrappers.IntLen*(common.MaxContainersPerMultiPut+1)))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read archive response: %w", err)
+	}
+
+	p := wrappers.Packer{Bytes: body}
+	blks := p.Unpack2DByteSlice()
+	switch {
+	case p.Errored():
+		return nil, fmt.Errorf("couldn't parse archive response: %w", p.Err)
+	case p.Offset != len(body):
+		return nil, fmt.Errorf("archive response has %d trailing bytes", len(body)-p.Offset)
+	case len(blks) == 0:
+		return nil, fmt.Errorf("archive returned no blocks for %s", blkID)
+	case len(blks) > common.MaxContainersPerMultiPut:
+		return nil, fmt.Errorf("archive returned %d blocks but at most %d are allowed", len(blks), common.MaxContainersPerMultiPut)
+	}
+	return blks, nil
+}
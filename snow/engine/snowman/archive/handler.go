@@ -0,0 +1,138 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archive
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// Endpoint is the extension, relative to a chain's base URL, that the
+	// archive is served on
+	Endpoint = "/archive"
+
+	// BlockIDParam is the query parameter specifying the first block to return
+	BlockIDParam = "blockID"
+
+	// MaxParam is the query parameter limiting the number of returned blocks
+	MaxParam = "max"
+
+	// ContentType of a successful archive response
+	ContentType = "application/octet-stream"
+
+	// MaxContainersLen is the maximum number of bytes of containers returned in
+	// a single response
+	MaxContainersLen = 64 * 1024 * 1024
+)
+
+var (
+	errNotAccepted = errors.New("block is not accepted")
+	errInvalidMax  = errors.New("invalid max")
+)
+
+// NewHandler returns an HTTP handler that serves accepted blocks of [vm].
+//
+// A request for block [blockID] is answered with that block followed by its
+// accepted ancestors, in order of decreasing height. This is the same
+// ordering as the containers of a MultiPut message, so a bootstrapping node
+// can process the response exactly as if it had been received from a peer.
+func NewHandler(ctx *snow.Context, vm block.ChainVM) *common.HTTPHandler {
+	return &common.HTTPHandler{
+		LockOptions: common.ReadLock,
+		Handler:     &handler{ctx: ctx, vm: vm},
+	}
+}
+
+type handler struct {
+	ctx *snow.Context
+	vm  block.ChainVM
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	blkID, err := ids.FromString(query.Get(BlockIDParam))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	max := common.MaxContainersPerMultiPut
+	if maxStr := query.Get(MaxParam); maxStr != "" {
+		requestedMax, err := strconv.Atoi(maxStr)
+		if err != nil || requestedMax <= 0 {
+			http.Error(w, errInvalidMax.Error(), http.StatusBadRequest)
+			return
+		}
+		if requestedMax < max {
+			max = requestedMax
+		}
+	}
+
+	blks, err := h.ancestors(blkID, max)
+	switch {
+	case err == errNotAccepted:
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case err != nil:
+		h.ctx.Log.Debug("couldn't serve archive request for %s: %s", blkID, err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	p := wrappers.Packer{MaxSize: MaxContainersLen + wrappers.IntLen*(len(blks)+1)}
+	p.Pack2DByteSlice(blks)
+	if p.Errored() {
+		http.Error(w, p.Err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(p.Bytes)))
+	w.WriteHeader(http.StatusOK)
+	// Doesn't matter if there's an error while writing. The client will fail
+	// to parse the response and fall back to another source.
+	_, _ = w.Write(p.Bytes)
+}
+
+// ancestors returns the bytes of [blkID] and up to [max]-1 of its accepted
+// ancestors. Only accepted blocks are ever returned.
+func (h *handler) ancestors(blkID ids.ID, max int) ([][]byte, error) {
+	blk, err := h.vm.GetBlock(blkID)
+	if err != nil {
+		return nil, err
+	}
+	if blk.Status() != choices.Accepted {
+		return nil, errNotAccepted
+	}
+
+	blkBytes := blk.Bytes()
+	blks := [][]byte{blkBytes}
+	size := len(blkBytes)
+	for len(blks) < max {
+		blk = blk.Parent()
+		if blk.Status() != choices.Accepted {
+			break
+		}
+		blkBytes = blk.Bytes()
+		if size += len(blkBytes); size > MaxContainersLen {
+			break
+		}
+		blks = append(blks, blkBytes)
+	}
+	return blks, nil
+}
@@ -4,11 +4,13 @@
 package bootstrap
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -18,6 +20,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/formatting"
 )
 
+var errArchiveFailed = errors.New("archive request failed")
+
 // Config ...
 type Config struct {
 	common.Config
@@ -27,9 +31,28 @@ type Config struct {
 
 	VM block.ChainVM
 
+	// Archive is an optional source of accepted blocks that is tried before
+	// sending GetAncestors messages to the beacons
+	Archive Archive
+
 	Bootstrapped func()
 }
 
+// ArchiveID is the ID the archive's responses are delivered from. It isn't the
+// ID of any node.
+var ArchiveID = ids.ShortEmpty
+
+// Archive provides the accepted ancestors of a block. Blocks returned by an
+// archive aren't trusted, they are only accepted if they are ancestors of the
+// accepted frontier agreed upon by the beacons.
+type Archive interface {
+	// GetAncestors requests the bytes of [blkID] followed by its ancestors, in
+	// order of decreasing height. The archive must not block. It responds
+	// asynchronously with a MultiPut from ArchiveID with ID [requestID], or a
+	// GetAncestorsFailed from ArchiveID with ID [requestID] if it fails.
+	GetAncestors(requestID uint32, blkID ids.ID)
+}
+
 // Bootstrapper ...
 type Bootstrapper struct {
 	common.Bootstrapper
@@ -41,8 +64,16 @@ type Bootstrapper struct {
 
 	VM block.ChainVM
 
+	// Optional source of blocks. Set to nil once the archive fails to provide
+	// a block so that the remaining blocks are fetched from the beacons.
+	Archive Archive
+
 	Bootstrapped func()
 
+	// IDs of blocks that we will send a GetAncestors request for once the
+	// windows of outstanding requests to the beacons aren't full
+	needToFetch ids.Set
+
 	// true if all of the vertices in the original accepted frontier have been processed
 	processedStartingAcceptedFrontier bool
 }
@@ -56,6 +87,7 @@ func (b *Bootstrapper) Initialize(
 ) error {
 	b.Blocked = config.Blocked
 	b.VM = config.VM
+	b.Archive = config.Archive
 	b.Bootstrapped = config.Bootstrapped
 	b.OnFinished = onFinished
 
@@ -110,7 +142,7 @@ func (b *Bootstrapper) ForceAccepted(acceptedContainerIDs ids.Set) error {
 	}
 
 	b.processedStartingAcceptedFrontier = true
-	if numPending := b.OutstandingRequests.Len(); numPending == 0 {
+	if numPending := b.OutstandingRequests.Len(); numPending == 0 && b.needToFetch.Len() == 0 {
 		return b.finish()
 	}
 	return nil
@@ -125,17 +157,28 @@ func (b *Bootstrapper) fetch(blkID ids.ID) error {
 
 	// Make sure we don't already have this block
 	if _, err := b.VM.GetBlock(blkID); err == nil {
-		if numPending := b.OutstandingRequests.Len(); numPending == 0 && b.processedStartingAcceptedFrontier {
+		if b.done() {
 			return b.finish()
 		}
 		return nil
 	}
 
-	validators, err := b.Beacons.Sample(1) // validator to send request to
+	if b.Archive != nil {
+		b.RequestID++
+
+		b.OutstandingRequests.Add(ArchiveID, b.RequestID, blkID)
+		b.Archive.GetAncestors(b.RequestID, blkID) // request block and ancestors
+		return nil
+	}
+
+	validatorID, ok, err := b.FetchTarget(&b.OutstandingRequests) // validator to send request to
 	if err != nil {
 		return fmt.Errorf("dropping request for %s as there are no validators", blkID)
 	}
-	validatorID := validators[0].ID()
+	if !ok { // every beacon is busy, fetch this block once one responds
+		b.needToFetch.Add(blkID)
+		return nil
+	}
 	b.RequestID++
 
 	b.OutstandingRequests.Add(validatorID, b.RequestID, blkID)
@@ -163,24 +206,76 @@ func (b *Bootstrapper) MultiPut(vdr ids.ShortID, requestID uint32, blks [][]byte
 		return nil
 	}
 
+	wantedBlk, err := b.parseAncestors(wantedBlkID, blks)
+	if err != nil && vdr.Equals(ArchiveID) {
+		b.abandonArchive(wantedBlkID, err)
+		if err := b.fetch(wantedBlkID); err != nil {
+			return err
+		}
+		return b.fetchPending()
+	}
+	if err != nil {
+		b.Ctx.Log.Debug("%s", err)
+		b.Ctx.ReportInvalidContainer(vdr)
+		if err := b.fetch(wantedBlkID); err != nil {
+			return err
+		}
+		return b.fetchPending()
+	}
+	if vdr.Equals(ArchiveID) {
+		b.numArchiveFetched.Add(float64(len(blks)))
+	}
+	if err := b.process(wantedBlk); err != nil {
+		return err
+	}
+	return b.fetchPending()
+}
+
+// fetchPending sends the GetAncestors requests that were held back while the
+// windows of outstanding requests to the beacons were full
+func (b *Bootstrapper) fetchPending() error {
+	for _, blkID := range b.needToFetch.List() {
+		b.needToFetch.Remove(blkID)
+		if err := b.fetch(blkID); err != nil {
+			return err
+		}
+		if b.needToFetch.Contains(blkID) { // the windows are full again
+			return nil
+		}
+	}
+	return nil
+}
+
+// abandonArchive stops fetching blocks from the archive after it failed to
+// provide [blkID], so that the remaining blocks are fetched from the beacons
+func (b *Bootstrapper) abandonArchive(blkID ids.ID, err error) {
+	if b.Archive == nil {
+		return
+	}
+	b.Ctx.Log.Warn("bootstrap archive failed to provide %s, falling back to beacons: %s",
+		blkID, err)
+	b.Archive = nil
+}
+
+// parseAncestors parses [blks], where the first block must be [wantedBlkID],
+// and returns the wanted block. Ancestors that fail to parse are dropped, as
+// they will be re-requested when processing reaches them.
+func (b *Bootstrapper) parseAncestors(wantedBlkID ids.ID, blks [][]byte) (snowman.Block, error) {
 	wantedBlk, err := b.VM.ParseBlock(blks[0]) // the block we requested
 	if err != nil {
-		b.Ctx.Log.Debug("Failed to parse requested block %s: %s", wantedBlkID, err)
-		return b.fetch(wantedBlkID)
+		return nil, fmt.Errorf("failed to parse requested block %s: %w", wantedBlkID, err)
 	} else if actualID := wantedBlk.ID(); !actualID.Equals(wantedBlkID) {
-		b.Ctx.Log.Debug("expected the first block to be the requested block, %s, but is %s",
-			wantedBlk, actualID)
-		return b.fetch(wantedBlkID)
+		return nil, fmt.Errorf("expected the first block to be the requested block, %s, but is %s",
+			wantedBlkID, actualID)
 	}
 
-	for _, blkBytes := range blks {
+	for _, blkBytes := range blks[1:] {
 		if _, err := b.VM.ParseBlock(blkBytes); err != nil { // persists the block
 			b.Ctx.Log.Debug("Failed to parse block: %s", err)
 			b.Ctx.Log.Verbo("block: %s", formatting.DumpBytes{Bytes: blkBytes})
 		}
 	}
-
-	return b.process(wantedBlk)
+	return wantedBlk, nil
 }
 
 // GetAncestorsFailed is called when a GetAncestors message we sent fails
@@ -191,8 +286,14 @@ func (b *Bootstrapper) GetAncestorsFailed(vdr ids.ShortID, requestID uint32) err
 			vdr, requestID)
 		return nil
 	}
+	if vdr.Equals(ArchiveID) {
+		b.abandonArchive(blkID, errArchiveFailed)
+	}
 	// Send another request for this
-	return b.fetch(blkID)
+	if err := b.fetch(blkID); err != nil {
+		return err
+	}
+	return b.fetchPending()
 }
 
 // process a block
@@ -206,6 +307,7 @@ func (b *Bootstrapper) process(blk snowman.Block) error {
 			blk:         blk,
 		}); err == nil {
 			b.numFetched.Inc()
+			b.Ctx.BootstrapFetched(1)
 			b.NumFetched++                                      // Progress tracker
 			if b.NumFetched%common.StatusUpdateFrequency == 0 { // Periodically print progress
 				b.Ctx.Log.Info("fetched %d blocks", b.NumFetched)
@@ -231,12 +333,17 @@ func (b *Bootstrapper) process(blk snowman.Block) error {
 		return fmt.Errorf("bootstrapping wants to accept %s, however it was previously rejected", blkID)
 	}
 
-	if numPending := b.OutstandingRequests.Len(); numPending == 0 && b.processedStartingAcceptedFrontier {
+	if b.done() {
 		return b.finish()
 	}
 	return nil
 }
 
+// done returns true if every block has been fetched
+func (b *Bootstrapper) done() bool {
+	return b.processedStartingAcceptedFrontier && b.OutstandingRequests.Len() == 0 && b.needToFetch.Len() == 0
+}
+
 func (b *Bootstrapper) finish() error {
 	if b.IsBootstrapped() {
 		return nil
@@ -244,6 +351,7 @@ func (b *Bootstrapper) finish() error {
 	b.Ctx.Log.Info("bootstrapping fetched %d blocks. executing state transitions...",
 		b.NumFetched)
 
+	b.Ctx.BootstrapExecuting()
 	if err := b.executeAll(b.Blocked); err != nil {
 		return err
 	}
@@ -275,12 +383,12 @@ func (b *Bootstrapper) executeAll(jobs *queue.Jobs) error {
 			return err
 		}
 		numExecuted++
+		b.Ctx.BootstrapExecuted()
 		if numExecuted%common.StatusUpdateFrequency == 0 { // Periodically print progress
 			b.Ctx.Log.Info("executed %d blocks", numExecuted)
 		}
 
-		b.Ctx.ConsensusDispatcher.Accept(b.Ctx, job.ID(), job.Bytes())
-		b.Ctx.DecisionDispatcher.Accept(b.Ctx, job.ID(), job.Bytes())
+		b.Ctx.Accepted(snow.BlockContainer, job.ID(), job.Bytes())
 	}
 	b.Ctx.Log.Info("executed %d blocks", numExecuted)
 	return nil
@@ -818,3 +818,197 @@ func TestBootstrapperFinalized(t *testing.T) {
 		t.Fatalf("Block should be accepted")
 	}
 }
+
+type testArchive struct {
+	getAncestorsF func(uint32, ids.ID)
+}
+
+func (a *testArchive) GetAncestors(requestID uint32, blkID ids.ID) {
+	a.getAncestorsF(requestID, blkID)
+}
+
+// The archive provides the missing blocks, so no GetAncestors message is sent.
+// Once the archive fails, blocks are requested from the beacons. The archive's
+// responses are delivered asynchronously, like responses from the beacons.
+func TestBootstrapperArchive(t *testing.T) {
+	config, peerID, sender, vm := newConfig(t)
+
+	blkID0 := ids.Empty.Prefix(0)
+	blkID1 := ids.Empty.Prefix(1)
+	blkID2 := ids.Empty.Prefix(2)
+	blkID3 := ids.Empty.Prefix(3)
+
+	blkBytes0 := []byte{0}
+	blkBytes1 := []byte{1}
+	blkBytes2 := []byte{2}
+	blkBytes3 := []byte{3}
+
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID0,
+			StatusV: choices.Accepted,
+		},
+		HeightV: 0,
+		BytesV:  blkBytes0,
+	}
+	blk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID1,
+			StatusV: choices.Unknown,
+		},
+		ParentV: blk0,
+		HeightV: 1,
+		BytesV:  blkBytes1,
+	}
+	blk2 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID2,
+			StatusV: choices.Unknown,
+		},
+		ParentV: blk1,
+		HeightV: 2,
+		BytesV:  blkBytes2,
+	}
+	blk3 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID3,
+			StatusV: choices.Processing,
+		},
+		ParentV: blk2,
+		HeightV: 3,
+		BytesV:  blkBytes3,
+	}
+
+	archiveCalls := 0
+	archiveRequestID := new(uint32)
+	archiveRequested := ids.Empty
+	config.Archive = &testArchive{getAncestorsF: func(reqID uint32, blkID ids.ID) {
+		archiveCalls++
+		*archiveRequestID = reqID
+		archiveRequested = blkID
+	}}
+
+	finished := new(bool)
+	bs := Bootstrapper{}
+	err := bs.Initialize(
+		config,
+		func() error { *finished = true; return nil },
+		fmt.Sprintf("%s_%s", constants.PlatformName, config.Ctx.ChainID),
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acceptedIDs := ids.Set{}
+	acceptedIDs.Add(blkID3)
+
+	parsedBlk1 := false
+	parsedBlk2 := false
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		switch {
+		case blkID.Equals(blkID0):
+			return blk0, nil
+		case blkID.Equals(blkID1):
+			if parsedBlk1 {
+				return blk1, nil
+			}
+			return nil, errUnknownBlock
+		case blkID.Equals(blkID2):
+			if parsedBlk2 {
+				return blk2, nil
+			}
+			return nil, errUnknownBlock
+		case blkID.Equals(blkID3):
+			return blk3, nil
+		default:
+			t.Fatal(errUnknownBlock)
+			panic(errUnknownBlock)
+		}
+	}
+	vm.ParseBlockF = func(blkBytes []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(blkBytes, blkBytes0):
+			return blk0, nil
+		case bytes.Equal(blkBytes, blkBytes1):
+			blk1.StatusV = choices.Processing
+			parsedBlk1 = true
+			return blk1, nil
+		case bytes.Equal(blkBytes, blkBytes2):
+			blk2.StatusV = choices.Processing
+			parsedBlk2 = true
+			return blk2, nil
+		case bytes.Equal(blkBytes, blkBytes3):
+			return blk3, nil
+		}
+		t.Fatal(errUnknownBlock)
+		return nil, errUnknownBlock
+	}
+
+	requestID := new(uint32)
+	requested := ids.Empty
+	sender.GetAncestorsF = func(vdr ids.ShortID, reqID uint32, blkID ids.ID) {
+		if !vdr.Equals(peerID) {
+			t.Fatalf("Should have requested block from %s, requested from %s", peerID, vdr)
+		}
+		*requestID = reqID
+		requested = blkID
+	}
+	vm.CantBootstrapping = false
+
+	if err := bs.ForceAccepted(acceptedIDs); err != nil { // should request blk2 from the archive
+		t.Fatal(err)
+	}
+	switch {
+	case archiveCalls != 1:
+		t.Fatalf("should have made 1 archive request but made %d", archiveCalls)
+	case !archiveRequested.Equals(blkID2):
+		t.Fatalf("should have requested blk2 from the archive")
+	case !requested.Equals(ids.Empty):
+		t.Fatalf("shouldn't have requested a block from the peer")
+	case *finished:
+		t.Fatalf("bootstrapping shouldn't finish while an archive request is outstanding")
+	}
+
+	// should request blk1 from the archive
+	if err := bs.MultiPut(ArchiveID, *archiveRequestID, [][]byte{blkBytes2}); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case archiveCalls != 2:
+		t.Fatalf("should have made 2 archive requests but made %d", archiveCalls)
+	case !archiveRequested.Equals(blkID1):
+		t.Fatalf("should have requested blk1 from the archive")
+	case blk2.Status() != choices.Processing:
+		t.Fatalf("blk2 should have been fetched from the archive")
+	}
+
+	// should request blk1 from the peer
+	if err := bs.GetAncestorsFailed(ArchiveID, *archiveRequestID); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case archiveCalls != 2:
+		t.Fatalf("shouldn't have used the archive after it failed")
+	case !requested.Equals(blkID1):
+		t.Fatalf("should have requested blk1 from the peer after the archive failed")
+	case bs.Archive != nil:
+		t.Fatalf("archive should have been abandoned")
+	}
+
+	vm.CantBootstrapped = false
+
+	err = bs.MultiPut(peerID, *requestID, [][]byte{blkBytes1})
+	switch {
+	case err != nil:
+		t.Fatal(err)
+	case !*finished:
+		t.Fatalf("Bootstrapping should have finished")
+	case blk1.Status() != choices.Accepted:
+		t.Fatalf("Block should be accepted")
+	case blk2.Status() != choices.Accepted:
+		t.Fatalf("Block should be accepted")
+	case blk3.Status() != choices.Accepted:
+		t.Fatalf("Block should be accepted")
+	}
+}
@@ -12,6 +12,7 @@ import (
 type metrics struct {
 	numRequests, numPendingRequests     prometheus.Gauge
 	numFetched, numDropped, numAccepted prometheus.Counter
+	numArchiveFetched                   prometheus.Counter
 }
 
 // Initialize implements the Engine interface
@@ -45,6 +46,11 @@ func (m *metrics) Initialize(
 		Name:      "accepted",
 		Help:      "Number of blocks accepted during bootstrapping",
 	})
+	m.numArchiveFetched = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "archive_fetched",
+		Help:      "Number of blocks fetched from the bootstrap archive",
+	})
 
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -53,6 +59,7 @@ func (m *metrics) Initialize(
 		registerer.Register(m.numFetched),
 		registerer.Register(m.numDropped),
 		registerer.Register(m.numAccepted),
+		registerer.Register(m.numArchiveFetched),
 	)
 	return errs.Err
 }
@@ -39,6 +39,9 @@ type Transitive struct {
 	// track outstanding preference requests
 	polls poll.Set
 
+	// sizes polls based on how many queries fail
+	pollSizer common.PollSizer
+
 	// blocks that have we have sent get requests for but haven't yet received
 	blkReqs common.Requests
 
@@ -60,7 +63,7 @@ func (t *Transitive) Initialize(config Config) error {
 	t.Params = config.Params
 	t.Consensus = config.Consensus
 
-	factory := poll.NewEarlyTermNoTraversalFactory(config.Params.Alpha)
+	factory := poll.NewEarlyTermTraversalFactory(config.Params.Alpha, t.getProcessingAncestry)
 	t.polls = poll.NewSet(factory,
 		config.Ctx.Log,
 		config.Params.Namespace,
@@ -70,6 +73,9 @@ func (t *Transitive) Initialize(config Config) error {
 	if err := t.metrics.Initialize(config.Params.Namespace, config.Params.Metrics); err != nil {
 		return err
 	}
+	if err := t.pollSizer.Initialize(config.Params.Namespace, config.Params.Metrics); err != nil {
+		return err
+	}
 
 	return t.Bootstrapper.Initialize(
 		config.Config,
@@ -79,6 +85,21 @@ func (t *Transitive) Initialize(config Config) error {
 	)
 }
 
+// getProcessingAncestry returns [blkID] and its ancestors, up to the first
+// block that isn't processing
+func (t *Transitive) getProcessingAncestry(blkID ids.ID) []ids.ID {
+	blk, err := t.VM.GetBlock(blkID)
+	if err != nil {
+		return nil
+	}
+	ancestry := []ids.ID(nil)
+	for blk.Status() == choices.Processing {
+		ancestry = append(ancestry, blk.ID())
+		blk = blk.Parent()
+	}
+	return ancestry
+}
+
 // When bootstrapping is finished, this will be called.
 // This initializes the consensus engine with the last accepted block.
 func (t *Transitive) finishBootstrapping() error {
@@ -113,12 +134,32 @@ func (t *Transitive) finishBootstrapping() error {
 		t.VM.SetPreference(lastAcceptedID)
 	}
 
+	// Issue the blocks that were processing when the checkpoint this chain
+	// resumed from was taken, so that they don't need to be fetched again
+	if checkpoint, ok := t.ResumedCheckpoint(); ok {
+		for _, blkID := range checkpoint.Processing {
+			blk, err := t.VM.GetBlock(blkID)
+			if err != nil || blk.Status() != choices.Processing {
+				continue
+			}
+			if _, err := t.issueWithAncestors(blk); err != nil {
+				return err
+			}
+		}
+	}
+
 	t.Ctx.Log.Info("bootstrapping finished with %s as the last accepted block", lastAcceptedID)
 	return nil
 }
 
 // Gossip implements the Engine interface
 func (t *Transitive) Gossip() error {
+	if t.CheckpointDue() {
+		if err := t.SaveCheckpoint(t.Consensus.Processing()); err != nil {
+			return err
+		}
+	}
+
 	blkID := t.VM.LastAccepted()
 	blk, err := t.VM.GetBlock(blkID)
 	if err != nil {
@@ -133,6 +174,11 @@ func (t *Transitive) Gossip() error {
 // Shutdown implements the Engine interface
 func (t *Transitive) Shutdown() error {
 	t.Ctx.Log.Info("shutting down consensus engine")
+	if t.IsBootstrapped() {
+		if err := t.SaveCheckpoint(t.Consensus.Processing()); err != nil {
+			t.Ctx.Log.Error("failed to checkpoint consensus due to: %s", err)
+		}
+	}
 	return t.VM.Shutdown()
 }
 
@@ -203,6 +249,7 @@ func (t *Transitive) Put(vdr ids.ShortID, requestID uint32, blkID ids.ID, blkByt
 	if err != nil {
 		t.Ctx.Log.Debug("failed to parse block %s: %s", blkID, err)
 		t.Ctx.Log.Verbo("block:\n%s", formatting.DumpBytes{Bytes: blkBytes})
+		t.Ctx.ReportInvalidContainer(vdr)
 		// because GetFailed doesn't utilize the assumption that we actually
 		// sent a Get message, we can safely call GetFailed here to potentially
 		// abandon the request.
@@ -285,6 +332,7 @@ func (t *Transitive) PushQuery(vdr ids.ShortID, requestID uint32, blkID ids.ID,
 	if err != nil {
 		t.Ctx.Log.Debug("failed to parse block %s: %s", blkID, err)
 		t.Ctx.Log.Verbo("block:\n%s", formatting.DumpBytes{Bytes: blkBytes})
+		t.Ctx.ReportInvalidContainer(vdr)
 		return nil
 	}
 
@@ -318,6 +366,7 @@ func (t *Transitive) Chits(vdr ids.ShortID, requestID uint32, votes ids.Set) err
 		return t.QueryFailed(vdr, requestID)
 	}
 	blkID := votes.List()[0]
+	t.pollSizer.Responded()
 
 	t.Ctx.Log.Verbo("Chits(%s, %d) contains vote for %s", vdr, requestID, blkID)
 
@@ -350,6 +399,7 @@ func (t *Transitive) QueryFailed(vdr ids.ShortID, requestID uint32) error {
 		t.Ctx.Log.Warn("dropping QueryFailed(%s, %d) due to bootstrapping", vdr, requestID)
 		return nil
 	}
+	t.pollSizer.Failed()
 
 	t.blocked.Register(&voter{
 		t:         t,
@@ -538,7 +588,7 @@ func (t *Transitive) sendRequest(vdr ids.ShortID, blkID ids.ID) {
 func (t *Transitive) pullSample(blkID ids.ID) {
 	t.Ctx.Log.Verbo("about to sample from: %s", t.Validators)
 	// The validators we will query
-	vdrs, err := t.Validators.Sample(t.Params.K)
+	vdrs, err := t.Validators.Sample(t.pollSizer.SampleSize(t.Params))
 	vdrBag := ids.ShortBag{}
 	for _, vdr := range vdrs {
 		vdrBag.Add(vdr.ID())
@@ -558,7 +608,7 @@ func (t *Transitive) pullSample(blkID ids.ID) {
 // send a push request for this block
 func (t *Transitive) pushSample(blk snowman.Block) {
 	t.Ctx.Log.Verbo("about to sample from: %s", t.Validators)
-	vdrs, err := t.Validators.Sample(t.Params.K)
+	vdrs, err := t.Validators.Sample(t.pollSizer.SampleSize(t.Params))
 	vdrBag := ids.ShortBag{}
 	for _, vdr := range vdrs {
 		vdrBag.Add(vdr.ID())
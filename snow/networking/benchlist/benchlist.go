@@ -25,6 +25,20 @@ import (
 // queries to that node fail immediately to avoid waiting up to
 // the full network timeout for a response.
 
+// Nodes are also scored by how they behave. A node's score is a moving average
+// of the outcomes of its queries, where a response counts as 1 and a failed
+// query as 0, starting at 1. An invalid container counts as several failed
+// queries. Nodes that fail often, though not consecutively, or that send
+// invalid containers are benched once their score falls below the threshold.
+
+const (
+	// scoreWeight is the weight of the latest outcome in a node's score
+	scoreWeight = 0.1
+	// invalidContainerPenalty is the number of failed queries an invalid
+	// container counts as
+	invalidContainerPenalty = 5
+)
+
 // QueryBenchlist ...
 type QueryBenchlist interface {
 	// RegisterQuery registers a sent query and returns whether the query is subject to benchlist
@@ -33,6 +47,13 @@ type QueryBenchlist interface {
 	RegisterResponse(validatorID ids.ShortID, requstID uint32)
 	// QueryFailed registers that a query did not receive a response within our synchrony bound
 	QueryFailed(validatorID ids.ShortID, requestID uint32)
+	// InvalidContainer registers that [validatorID] sent a container that was
+	// invalid
+	InvalidContainer(validatorID ids.ShortID)
+	// IsBenched returns true if [validatorID] is currently benched
+	IsBenched(validatorID ids.ShortID) bool
+	// Score returns the score of [validatorID], in [0, 1]
+	Score(validatorID ids.ShortID) float64
 }
 
 type queryBenchlist struct {
@@ -43,6 +64,8 @@ type queryBenchlist struct {
 	pendingQueries map[[20]byte]map[uint32]pendingQuery
 	// Map of consecutive query failures
 	consecutiveFailures map[[20]byte]failureStreak
+	// Validator ID --> score. Validators without a score have a score of 1.
+	scores map[[20]byte]float64
 
 	// Maintain benchlist
 	benchlistTimes map[[20]byte]time.Time
@@ -53,6 +76,9 @@ type queryBenchlist struct {
 	minimumFailingDuration time.Duration
 	duration               time.Duration
 	maxPortion             float64
+	// validators with a score below it are benched. if 0, validators aren't
+	// benched because of their score.
+	scoreThreshold float64
 
 	clock timer.Clock
 
@@ -80,6 +106,7 @@ func NewQueryBenchlist(
 	minimumFailingDuration,
 	duration time.Duration,
 	maxPortion float64,
+	scoreThreshold float64,
 	summaryEnabled bool,
 	namespace string,
 ) (QueryBenchlist, error) {
@@ -87,6 +114,7 @@ func NewQueryBenchlist(
 	return &queryBenchlist{
 		pendingQueries:         make(map[[20]byte]map[uint32]pendingQuery),
 		consecutiveFailures:    make(map[[20]byte]failureStreak),
+		scores:                 make(map[[20]byte]float64),
 		benchlistTimes:         make(map[[20]byte]time.Time),
 		benchlistOrder:         list.New(),
 		benchlistSet:           ids.ShortSet{},
@@ -95,6 +123,7 @@ func NewQueryBenchlist(
 		minimumFailingDuration: minimumFailingDuration,
 		duration:               duration,
 		maxPortion:             maxPortion,
+		scoreThreshold:         scoreThreshold,
 		ctx:                    ctx,
 		metrics:                metrics,
 	}, metrics.Initialize(ctx, namespace, summaryEnabled)
@@ -135,6 +164,7 @@ func (b *queryBenchlist) RegisterResponse(validatorID ids.ShortID, requestID uin
 
 	// Reset consecutive failures on success
 	delete(b.consecutiveFailures, validatorID.Key())
+	b.updateScore(validatorID, 1, 1)
 }
 
 // QueryFailed notes a failure and benchlists [validatorID] if necessary
@@ -159,6 +189,59 @@ func (b *queryBenchlist) QueryFailed(validatorID ids.ShortID, requestID uint32)
 
 	if failureStreak.consecutive >= b.threshold && !currentTime.Before(failureStreak.firstFailure.Add(b.minimumFailingDuration)) {
 		b.bench(validatorID)
+		return
+	}
+	b.updateScore(validatorID, 0, 1)
+}
+
+// InvalidContainer notes that [validatorID] sent an invalid container and
+// benchlists [validatorID] if necessary
+func (b *queryBenchlist) InvalidContainer(validatorID ids.ShortID) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.updateScore(validatorID, 0, invalidContainerPenalty)
+}
+
+// Score implements the QueryBenchlist interface
+func (b *queryBenchlist) Score(validatorID ids.ShortID) float64 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.score(validatorID)
+}
+
+func (b *queryBenchlist) score(validatorID ids.ShortID) float64 {
+	if score, ok := b.scores[validatorID.Key()]; ok {
+		return score
+	}
+	return 1
+}
+
+// updateScore adds [count] outcomes of [outcome] to the score of [validatorID]
+// and benchlists [validatorID] if its score falls below the threshold
+func (b *queryBenchlist) updateScore(validatorID ids.ShortID, outcome float64, count int) {
+	if b.benchlistSet.Contains(validatorID) {
+		return
+	}
+
+	score := b.score(validatorID)
+	for i := 0; i < count; i++ {
+		score = (1-scoreWeight)*score + scoreWeight*outcome
+	}
+	if score >= 1 {
+		delete(b.scores, validatorID.Key())
+	} else {
+		b.scores[validatorID.Key()] = score
+	}
+
+	if score < b.scoreThreshold {
+		b.ctx.Log.Debug("benching validator %s because its score, %f, is below %f",
+			validatorID,
+			score,
+			b.scoreThreshold,
+		)
+		b.bench(validatorID)
 	}
 }
 
@@ -195,6 +278,8 @@ func (b *queryBenchlist) bench(validatorID ids.ShortID) {
 	b.benchlistOrder.PushBack(validatorID)
 	b.benchlistSet.Add(validatorID)
 	delete(b.consecutiveFailures, key)
+	// The validator is judged anew once it's unbenched
+	delete(b.scores, key)
 	b.ctx.Log.Debug(
 		"benching validator %s after %d consecutive failed queries for %s",
 		validatorID,
@@ -209,6 +294,14 @@ func (b *queryBenchlist) bench(validatorID ids.ShortID) {
 	b.cleanup()
 }
 
+// IsBenched implements the QueryBenchlist interface
+func (b *queryBenchlist) IsBenched(validatorID ids.ShortID) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.benched(validatorID)
+}
+
 // benched checks if [validatorID] is currently benched
 // and calls cleanup if its benching period has elapsed
 func (b *queryBenchlist) benched(validatorID ids.ShortID) bool {
@@ -295,6 +388,7 @@ func (b *queryBenchlist) cleanup() {
 func (b *queryBenchlist) reset() {
 	b.pendingQueries = make(map[[20]byte]map[uint32]pendingQuery)
 	b.consecutiveFailures = make(map[[20]byte]failureStreak)
+	b.scores = make(map[[20]byte]float64)
 	b.benchlistTimes = make(map[[20]byte]time.Time)
 	b.benchlistOrder.Init()
 	b.benchlistSet.Clear()
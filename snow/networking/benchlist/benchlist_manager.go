@@ -28,6 +28,13 @@ type Manager interface {
 	QueryFailed(ids.ID, ids.ShortID, uint32)
 	// RegisterChain registers a new chain with metrics under [namespac]
 	RegisterChain(*snow.Context, string) error
+	// InvalidContainer registers that [validatorID] sent an invalid container
+	// on the chain [chainID]
+	InvalidContainer(chainID ids.ID, validatorID ids.ShortID)
+	// GetBenched returns the IDs of the chains [validatorID] is benched on
+	GetBenched(validatorID ids.ShortID) []ids.ID
+	// GetScores returns the score of [validatorID] on each chain, by chain ID
+	GetScores(validatorID ids.ShortID) map[[32]byte]float64
 }
 
 // Config defines the configuration for a benchlist
@@ -37,6 +44,7 @@ type Config struct {
 	MinimumFailingDuration time.Duration
 	Duration               time.Duration
 	MaxPortion             float64
+	ScoreThreshold         float64
 	PeerSummaryEnabled     bool
 }
 
@@ -77,6 +85,7 @@ func (bm *benchlistManager) RegisterChain(ctx *snow.Context, namespace string) e
 		bm.config.MinimumFailingDuration,
 		bm.config.Duration,
 		bm.config.MaxPortion,
+		bm.config.ScoreThreshold,
 		bm.config.PeerSummaryEnabled,
 		namespace,
 	)
@@ -133,6 +142,45 @@ func (bm *benchlistManager) QueryFailed(chainID ids.ID, validatorID ids.ShortID,
 	chain.QueryFailed(validatorID, requestID)
 }
 
+// InvalidContainer implements the Manager interface
+func (bm *benchlistManager) InvalidContainer(chainID ids.ID, validatorID ids.ShortID) {
+	bm.lock.RLock()
+	defer bm.lock.RUnlock()
+
+	chain, exists := bm.chainBenchlists[chainID.Key()]
+	if !exists {
+		return
+	}
+
+	chain.InvalidContainer(validatorID)
+}
+
+// GetBenched implements the Manager interface
+func (bm *benchlistManager) GetBenched(validatorID ids.ShortID) []ids.ID {
+	bm.lock.RLock()
+	defer bm.lock.RUnlock()
+
+	benched := []ids.ID(nil)
+	for key, chain := range bm.chainBenchlists {
+		if chain.IsBenched(validatorID) {
+			benched = append(benched, ids.NewID(key))
+		}
+	}
+	return benched
+}
+
+// GetScores implements the Manager interface
+func (bm *benchlistManager) GetScores(validatorID ids.ShortID) map[[32]byte]float64 {
+	bm.lock.RLock()
+	defer bm.lock.RUnlock()
+
+	scores := make(map[[32]byte]float64, len(bm.chainBenchlists))
+	for key, chain := range bm.chainBenchlists {
+		scores[key] = chain.Score(validatorID)
+	}
+	return scores
+}
+
 type noBenchlist struct{}
 
 // NewNoBenchlist returns an empty benchlist that will never stop any queries
@@ -142,3 +190,6 @@ func (noBenchlist) RegisterChain(*snow.Context, string) error
 func (noBenchlist) RegisterQuery(ids.ID, ids.ShortID, uint32, constants.MsgType) bool { return true }
 func (noBenchlist) RegisterResponse(ids.ID, ids.ShortID, uint32)                      {}
 func (noBenchlist) QueryFailed(ids.ID, ids.ShortID, uint32)                           {}
+func (noBenchlist) InvalidContainer(ids.ID, ids.ShortID)                              {}
+func (noBenchlist) GetBenched(ids.ShortID) []ids.ID                                   { return nil }
+func (noBenchlist) GetScores(ids.ShortID) map[[32]byte]float64                        { return nil }
@@ -46,6 +46,7 @@ func TestBenchlist(t *testing.T) {
 		minimumFailingDuration,
 		duration,
 		maxPortion,
+		0,
 		false,
 		"",
 	)
@@ -114,6 +115,7 @@ func TestBenchlistDoesNotGetStuck(t *testing.T) {
 		minimumFailingDuration,
 		duration,
 		maxPortion,
+		0,
 		false,
 		"",
 	)
@@ -190,6 +192,7 @@ func TestBenchlistOverUnbench(t *testing.T) {
 		minimumFailingDuration,
 		duration,
 		maxPortion,
+		0,
 		false,
 		"",
 	)
@@ -266,3 +269,83 @@ func bench(b *queryBenchlist, validatorIDs []ids.ShortID) bool {
 
 	return true
 }
+
+// Test that validators are benched once their score falls below the threshold
+func TestBenchlistScore(t *testing.T) {
+	vdrs := validators.NewSet()
+	vdr0 := validators.GenerateRandomValidator(50)
+	vdr1 := validators.GenerateRandomValidator(50)
+	vdr2 := validators.GenerateRandomValidator(50)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		vdrs.AddWeight(vdr0.ID(), vdr0.Weight()),
+		vdrs.AddWeight(vdr1.ID(), vdr1.Weight()),
+		vdrs.AddWeight(vdr2.ID(), vdr2.Weight()),
+	)
+	if errs.Errored() {
+		t.Fatal(errs.Err)
+	}
+
+	threshold := 100
+	duration := time.Minute
+	maxPortion := 0.5
+	scoreThreshold := 0.5
+	benchIntf, err := NewQueryBenchlist(
+		vdrs,
+		snow.DefaultContextTest(),
+		threshold,
+		minimumFailingDuration,
+		duration,
+		maxPortion,
+		scoreThreshold,
+		false,
+		"",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := benchIntf.(*queryBenchlist)
+	b.clock.Set(time.Now())
+
+	if score := b.Score(vdr0.ID()); score != 1 {
+		t.Fatalf("expected the initial score to be 1 but got %f", score)
+	}
+
+	// Failed queries lower the score, and responses raise it
+	requestID := uint32(0)
+	b.RegisterQuery(vdr0.ID(), requestID, constants.PullQueryMsg)
+	b.QueryFailed(vdr0.ID(), requestID)
+	failedScore := b.Score(vdr0.ID())
+	if failedScore >= 1 {
+		t.Fatalf("expected a failed query to lower the score but got %f", failedScore)
+	}
+	requestID++
+	b.RegisterQuery(vdr0.ID(), requestID, constants.PullQueryMsg)
+	b.RegisterResponse(vdr0.ID(), requestID)
+	if score := b.Score(vdr0.ID()); score <= failedScore {
+		t.Fatalf("expected a response to raise the score above %f but got %f", failedScore, score)
+	}
+
+	// Invalid containers lower the score until the validator is benched
+	b.InvalidContainer(vdr1.ID())
+	if b.IsBenched(vdr1.ID()) {
+		t.Fatal("vdr1 shouldn't be benched after one invalid container")
+	}
+	b.InvalidContainer(vdr1.ID())
+	if !b.IsBenched(vdr1.ID()) {
+		t.Fatal("vdr1 should be benched after its score fell below the threshold")
+	}
+	if ok := b.RegisterQuery(vdr1.ID(), requestID, constants.PullQueryMsg); ok {
+		t.Fatal("RegisterQuery should have benchlisted query to vdr1")
+	}
+
+	// Once unbenched, the validator is judged anew
+	b.clock.Set(b.clock.Time().Add(duration))
+	if b.IsBenched(vdr1.ID()) {
+		t.Fatal("vdr1 should have been unbenched after the benchlisting time elapsed")
+	}
+	if score := b.Score(vdr1.ID()); score != 1 {
+		t.Fatalf("expected the score to be reset to 1 but got %f", score)
+	}
+}
@@ -0,0 +1,139 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package drops
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+// Reason describes why a message was dropped
+type Reason string
+
+// Reasons a message may be dropped
+const (
+	Throttled     Reason = "throttled"
+	QueueFull     Reason = "queue full"
+	UnknownChain  Reason = "unknown chain"
+	VersionTooOld Reason = "version too old"
+	Expired       Reason = "expired"
+	Closed        Reason = "closed"
+	NotConnected  Reason = "not connected"
+	UnknownOp     Reason = "unknown op"
+	InvalidSender Reason = "invalid sender"
+	Unsupported   Reason = "unsupported"
+)
+
+// Drop is a record of a single dropped message
+type Drop struct {
+	Time    time.Time
+	NodeID  ids.ShortID
+	Op      string
+	ChainID ids.ID
+	Reason  Reason
+}
+
+// Log counts dropped messages by reason and remembers the most recent drops.
+// A nil *Log is valid and ignores all drops.
+type Log struct {
+	lock   sync.Mutex
+	clock  timer.Clock
+	counts map[Reason]uint64
+	recent []Drop
+	next   int
+	full   bool
+
+	dropped *prometheus.CounterVec
+}
+
+// NewLog returns a Log that remembers the [size] most recent drops and
+// registers a counter of drops by reason with [registerer]
+func NewLog(size int, namespace string, registerer prometheus.Registerer) (*Log, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("drop log size must be positive but is %d", size)
+	}
+	l := &Log{
+		counts: make(map[Reason]uint64),
+		recent: make([]Drop, size),
+		dropped: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "dropped_messages",
+				Help:      "Number of dropped messages by reason",
+			},
+			[]string{"reason"},
+		),
+	}
+	if err := registerer.Register(l.dropped); err != nil {
+		return nil, fmt.Errorf("failed to register dropped messages statistics due to %w", err)
+	}
+	return l, nil
+}
+
+// Record that the message [op] from or to [nodeID] on [chainID] was dropped
+// because of [reason]. [chainID] is the zero value for messages that aren't
+// associated with a chain.
+func (l *Log) Record(nodeID ids.ShortID, op string, chainID ids.ID, reason Reason) {
+	if l == nil {
+		return
+	}
+
+	l.dropped.WithLabelValues(string(reason)).Inc()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.counts[reason]++
+	l.recent[l.next] = Drop{
+		Time:    l.clock.Time(),
+		NodeID:  nodeID,
+		Op:      op,
+		ChainID: chainID,
+		Reason:  reason,
+	}
+	l.next++
+	if l.next == len(l.recent) {
+		l.next = 0
+		l.full = true
+	}
+}
+
+// Counts returns the number of messages dropped for each reason
+func (l *Log) Counts() map[Reason]uint64 {
+	counts := make(map[Reason]uint64)
+	if l == nil {
+		return counts
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for reason, count := range l.counts {
+		counts[reason] = count
+	}
+	return counts
+}
+
+// Recent returns the most recent drops, oldest first
+func (l *Log) Recent() []Drop {
+	if l == nil {
+		return nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if !l.full {
+		return append([]Drop(nil), l.recent[:l.next]...)
+	}
+	recent := make([]Drop, 0, len(l.recent))
+	recent = append(recent, l.recent[l.next:]...)
+	return append(recent, l.recent[:l.next]...)
+}
@@ -0,0 +1,61 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package drops
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestLogRecent(t *testing.T) {
+	l, err := NewLog(2, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if recent := l.Recent(); len(recent) != 0 {
+		t.Fatalf("expected no drops but got %d", len(recent))
+	}
+
+	chainID := ids.NewID([32]byte{1})
+	l.Record(ids.NewShortID([20]byte{1}), "put", chainID, QueueFull)
+	l.Record(ids.NewShortID([20]byte{2}), "get", chainID, Throttled)
+	l.Record(ids.NewShortID([20]byte{3}), "chits", ids.ID{}, UnknownChain)
+
+	recent := l.Recent()
+	if len(recent) != 2 {
+		t.Fatalf("expected 2 drops but got %d", len(recent))
+	}
+	if recent[0].Op != "get" || recent[0].Reason != Throttled {
+		t.Fatalf("wrong oldest drop %+v", recent[0])
+	}
+	if recent[1].Op != "chits" || recent[1].Reason != UnknownChain {
+		t.Fatalf("wrong newest drop %+v", recent[1])
+	}
+
+	counts := l.Counts()
+	if counts[QueueFull] != 1 || counts[Throttled] != 1 || counts[UnknownChain] != 1 {
+		t.Fatalf("wrong counts %v", counts)
+	}
+}
+
+func TestNilLog(t *testing.T) {
+	var l *Log
+	l.Record(ids.ShortEmpty, "put", ids.ID{}, QueueFull)
+	if recent := l.Recent(); len(recent) != 0 {
+		t.Fatalf("expected no drops but got %d", len(recent))
+	}
+	if counts := l.Counts(); len(counts) != 0 {
+		t.Fatalf("expected no counts but got %v", counts)
+	}
+}
+
+func TestNewLogInvalidSize(t *testing.T) {
+	if _, err := NewLog(0, "", prometheus.NewRegistry()); err == nil {
+		t.Fatal("should have errored due to a non-positive size")
+	}
+}
@@ -4,10 +4,12 @@
 package router
 
 import (
+	"errors"
 	"sync"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/drops"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -21,6 +23,8 @@ const (
 
 var (
 	_ Router = &ChainRouter{}
+
+	errCriticalChainNotRunning = errors.New("critical chain isn't running")
 )
 
 // ChainRouter routes incoming messages from the validator network
@@ -28,7 +32,12 @@ var (
 // Note that consensus engines are uniquely identified by the ID of the chain
 // that they are working on.
 type ChainRouter struct {
+	// number of peer connections, disconnections and queries since accepted
+	// frontiers were last gossiped. Accessed atomically.
+	activity int64
+
 	log              logging.Logger
+	drops            *drops.Log
 	lock             sync.RWMutex
 	chains           map[[32]byte]*Handler
 	timeouts         *timeout.Manager
@@ -38,6 +47,11 @@ type ChainRouter struct {
 	peers            ids.ShortSet
 	criticalChains   ids.Set
 	onFatal          func()
+
+	// bounds of the adaptive gossip frequency. If the minimum isn't positive,
+	// the gossip frequency is fixed.
+	minGossipFrequency time.Duration
+	maxGossipFrequency time.Duration
 }
 
 // Initialize the router.
@@ -47,20 +61,31 @@ type ChainRouter struct {
 // applicable.
 //
 // This router also fires a gossip event every [gossipFrequency] to the engine,
-// notifying the engine it should gossip it's accepted set.
+// notifying the engine it should gossip it's accepted set. If
+// [minGossipFrequency] is positive, the time between gossip events adapts to
+// peer churn and chain activity, within [minGossipFrequency,
+// maxGossipFrequency].
+//
+// Messages that can't be delivered to a chain are recorded in [dropLog].
 func (sr *ChainRouter) Initialize(
 	nodeID ids.ShortID,
 	log logging.Logger,
+	dropLog *drops.Log,
 	timeouts *timeout.Manager,
-	gossipFrequency time.Duration,
+	gossipFrequency,
+	minGossipFrequency,
+	maxGossipFrequency time.Duration,
 	closeTimeout time.Duration,
 	criticalChains ids.Set,
 	onFatal func(),
 ) {
 	sr.log = log
+	sr.drops = dropLog
 	sr.chains = make(map[[32]byte]*Handler)
 	sr.timeouts = timeouts
 	sr.gossiper = timer.NewRepeater(sr.Gossip, gossipFrequency)
+	sr.minGossipFrequency = minGossipFrequency
+	sr.maxGossipFrequency = maxGossipFrequency
 	sr.intervalNotifier = timer.NewRepeater(sr.EndInterval, defaultCPUInterval)
 	sr.closeTimeout = closeTimeout
 	sr.criticalChains = criticalChains
@@ -110,6 +135,7 @@ func (sr *ChainRouter) AddChain(chain *Handler) {
 	chainID := chain.Context().ChainID
 	sr.log.Debug("registering chain %s with chain router", chainID)
 	chain.toClose = func() { sr.RemoveChain(chainID) }
+	chain.drops = sr.drops
 	sr.chains[chainID.Key()] = chain
 
 	for _, validatorID := range sr.peers.List() {
@@ -145,6 +171,24 @@ func (sr *ChainRouter) RemoveChain(chainID ids.ID) {
 	}
 }
 
+// HealthCheck reports the number of chains messages are being routed to. It
+// fails if any critical chain isn't running.
+func (sr *ChainRouter) HealthCheck() (interface{}, error) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	details := map[string]interface{}{
+		"chains": len(sr.chains),
+	}
+	for _, chainID := range sr.criticalChains.List() {
+		if _, exists := sr.chains[chainID.Key()]; !exists {
+			details["missingChain"] = chainID.String()
+			return details, errCriticalChainNotRunning
+		}
+	}
+	return details, nil
+}
+
 // GetAcceptedFrontier routes an incoming GetAcceptedFrontier request from the
 // validator with ID [validatorID]  to the consensus engine working on the
 // chain with ID [chainID]
@@ -156,6 +200,7 @@ func (sr *ChainRouter) GetAcceptedFrontier(validatorID ids.ShortID, chainID ids.
 		chain.GetAcceptedFrontier(validatorID, requestID, deadline)
 	} else {
 		sr.log.Debug("GetAcceptedFrontier(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		sr.drops.Record(validatorID, constants.GetAcceptedFrontierMsg.String(), chainID, drops.UnknownChain)
 	}
 }
 
@@ -172,6 +217,7 @@ func (sr *ChainRouter) AcceptedFrontier(validatorID ids.ShortID, chainID ids.ID,
 		}
 	} else {
 		sr.log.Debug("AcceptedFrontier(%s, %s, %d, %s) dropped due to unknown chain", validatorID, chainID, requestID, containerIDs)
+		sr.drops.Record(validatorID, constants.AcceptedFrontierMsg.String(), chainID, drops.UnknownChain)
 	}
 }
 
@@ -187,6 +233,7 @@ func (sr *ChainRouter) GetAcceptedFrontierFailed(validatorID ids.ShortID, chainI
 		chain.GetAcceptedFrontierFailed(validatorID, requestID)
 	} else {
 		sr.log.Error("GetAcceptedFrontierFailed(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		sr.drops.Record(validatorID, constants.GetAcceptedFrontierFailedMsg.String(), chainID, drops.UnknownChain)
 	}
 }
 
@@ -201,6 +248,7 @@ func (sr *ChainRouter) GetAccepted(validatorID ids.ShortID, chainID ids.ID, requ
 		chain.GetAccepted(validatorID, requestID, deadline, containerIDs)
 	} else {
 		sr.log.Debug("GetAccepted(%s, %s, %d, %s) dropped due to unknown chain", validatorID, chainID, requestID, containerIDs)
+		sr.drops.Record(validatorID, constants.GetAcceptedMsg.String(), chainID, drops.UnknownChain)
 	}
 }
 
@@ -217,6 +265,7 @@ func (sr *ChainRouter) Accepted(validatorID ids.ShortID, chainID ids.ID, request
 		}
 	} else {
 		sr.log.Debug("Accepted(%s, %s, %d, %s) dropped due to unknown chain", validatorID, chainID, requestID, containerIDs)
+		sr.drops.Record(validatorID, constants.AcceptedMsg.String(), chainID, drops.UnknownChain)
 	}
 }
 
@@ -232,6 +281,7 @@ func (sr *ChainRouter) GetAcceptedFailed(validatorID ids.ShortID, chainID ids.ID
 		chain.GetAcceptedFailed(validatorID, requestID)
 	} else {
 		sr.log.Error("GetAcceptedFailed(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		sr.drops.Record(validatorID, constants.GetAcceptedFailedMsg.String(), chainID, drops.UnknownChain)
 	}
 }
 
@@ -246,6 +296,7 @@ func (sr *ChainRouter) GetAncestors(validatorID ids.ShortID, chainID ids.ID, req
 		chain.GetAncestors(validatorID, requestID, deadline, containerID)
 	} else {
 		sr.log.Debug("GetAncestors(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		sr.drops.Record(validatorID, constants.GetAncestorsMsg.String(), chainID, drops.UnknownChain)
 	}
 }
 
@@ -263,6 +314,7 @@ func (sr *ChainRouter) MultiPut(validatorID ids.ShortID, chainID ids.ID, request
 		}
 	} else {
 		sr.log.Debug("MultiPut(%s, %s, %d, %d) dropped due to unknown chain", validatorID, chainID, requestID, len(containers))
+		sr.drops.Record(validatorID, constants.MultiPutMsg.String(), chainID, drops.UnknownChain)
 	}
 }
 
@@ -277,6 +329,7 @@ func (sr *ChainRouter) GetAncestorsFailed(validatorID ids.ShortID, chainID ids.I
 		chain.GetAncestorsFailed(validatorID, requestID)
 	} else {
 		sr.log.Error("GetAncestorsFailed(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		sr.drops.Record(validatorID, constants.GetAncestorsFailedMsg.String(), chainID, drops.UnknownChain)
 	}
 }
 
@@ -290,6 +343,7 @@ func (sr *ChainRouter) Get(validatorID ids.ShortID, chainID ids.ID, requestID ui
 		chain.Get(validatorID, requestID, deadline, containerID)
 	} else {
 		sr.log.Debug("Get(%s, %s, %d, %s) dropped due to unknown chain", validatorID, chainID, requestID, containerID)
+		sr.drops.Record(validatorID, constants.GetMsg.String(), chainID, drops.UnknownChain)
 	}
 }
 
@@ -311,8 +365,10 @@ func (sr *ChainRouter) Put(validatorID ids.ShortID, chainID ids.ID, requestID ui
 		sr.log.Verbo("Gossiped Put(%s, %s, %d, %s) dropped due to unknown chain. Container:",
 			validatorID, chainID, requestID, containerID, formatting.DumpBytes{Bytes: container},
 		)
+		sr.drops.Record(validatorID, constants.PutMsg.String(), chainID, drops.UnknownChain)
 	default:
 		sr.log.Debug("Put(%s, %s, %d, %s) dropped due to unknown chain", validatorID, chainID, requestID, containerID)
+		sr.drops.Record(validatorID, constants.PutMsg.String(), chainID, drops.UnknownChain)
 		sr.log.Verbo("container:\n%s", formatting.DumpBytes{Bytes: container})
 	}
 }
@@ -328,6 +384,7 @@ func (sr *ChainRouter) GetFailed(validatorID ids.ShortID, chainID ids.ID, reques
 		chain.GetFailed(validatorID, requestID)
 	} else {
 		sr.log.Error("GetFailed(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		sr.drops.Record(validatorID, constants.GetFailedMsg.String(), chainID, drops.UnknownChain)
 	}
 }
 
@@ -338,9 +395,11 @@ func (sr *ChainRouter) PushQuery(validatorID ids.ShortID, chainID ids.ID, reques
 	defer sr.lock.RUnlock()
 
 	if chain, exists := sr.chains[chainID.Key()]; exists {
+		sr.gossipActivity()
 		chain.PushQuery(validatorID, requestID, deadline, containerID, container)
 	} else {
 		sr.log.Debug("PushQuery(%s, %s, %d, %s) dropped due to unknown chain", validatorID, chainID, requestID, containerID)
+		sr.drops.Record(validatorID, constants.PushQueryMsg.String(), chainID, drops.UnknownChain)
 		sr.log.Verbo("container:\n%s", formatting.DumpBytes{Bytes: container})
 	}
 }
@@ -352,9 +411,11 @@ func (sr *ChainRouter) PullQuery(validatorID ids.ShortID, chainID ids.ID, reques
 	defer sr.lock.RUnlock()
 
 	if chain, exists := sr.chains[chainID.Key()]; exists {
+		sr.gossipActivity()
 		chain.PullQuery(validatorID, requestID, deadline, containerID)
 	} else {
 		sr.log.Debug("PullQuery(%s, %s, %d, %s) dropped due to unknown chain", validatorID, chainID, requestID, containerID)
+		sr.drops.Record(validatorID, constants.PullQueryMsg.String(), chainID, drops.UnknownChain)
 	}
 }
 
@@ -371,6 +432,7 @@ func (sr *ChainRouter) Chits(validatorID ids.ShortID, chainID ids.ID, requestID
 		}
 	} else {
 		sr.log.Debug("Chits(%s, %s, %d, %s) dropped due to unknown chain", validatorID, chainID, requestID, votes)
+		sr.drops.Record(validatorID, constants.ChitsMsg.String(), chainID, drops.UnknownChain)
 	}
 }
 
@@ -385,6 +447,102 @@ func (sr *ChainRouter) QueryFailed(validatorID ids.ShortID, chainID ids.ID, requ
 		chain.QueryFailed(validatorID, requestID)
 	} else {
 		sr.log.Error("QueryFailed(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		sr.drops.Record(validatorID, constants.QueryFailedMsg.String(), chainID, drops.UnknownChain)
+	}
+}
+
+// GetStateSummaryFrontier routes an incoming GetStateSummaryFrontier request
+// from the validator with ID [validatorID] to the consensus engine working on
+// the chain with ID [chainID]
+func (sr *ChainRouter) GetStateSummaryFrontier(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	if chain, exists := sr.chains[chainID.Key()]; exists {
+		chain.GetStateSummaryFrontier(validatorID, requestID, deadline)
+	} else {
+		sr.log.Debug("GetStateSummaryFrontier(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		sr.drops.Record(validatorID, constants.GetStateSummaryFrontierMsg.String(), chainID, drops.UnknownChain)
+	}
+}
+
+// StateSummaryFrontier routes an incoming StateSummaryFrontier message from
+// the validator with ID [validatorID] to the consensus engine working on the
+// chain with ID [chainID]
+func (sr *ChainRouter) StateSummaryFrontier(validatorID ids.ShortID, chainID ids.ID, requestID uint32, summary []byte) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	if chain, exists := sr.chains[chainID.Key()]; exists {
+		if chain.StateSummaryFrontier(validatorID, requestID, summary) {
+			sr.timeouts.Cancel(validatorID, chainID, requestID)
+		}
+	} else {
+		sr.log.Debug("StateSummaryFrontier(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		sr.drops.Record(validatorID, constants.StateSummaryFrontierMsg.String(), chainID, drops.UnknownChain)
+	}
+}
+
+// GetStateSummaryFrontierFailed routes an incoming
+// GetStateSummaryFrontierFailed message from the validator with ID
+// [validatorID] to the consensus engine working on the chain with ID [chainID]
+func (sr *ChainRouter) GetStateSummaryFrontierFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	sr.timeouts.Cancel(validatorID, chainID, requestID)
+	if chain, exists := sr.chains[chainID.Key()]; exists {
+		chain.GetStateSummaryFrontierFailed(validatorID, requestID)
+	} else {
+		sr.log.Error("GetStateSummaryFrontierFailed(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		sr.drops.Record(validatorID, constants.GetStateSummaryFrontierFailedMsg.String(), chainID, drops.UnknownChain)
+	}
+}
+
+// GetStateChunk routes an incoming GetStateChunk request from the validator
+// with ID [validatorID] to the consensus engine working on the chain with ID
+// [chainID]
+func (sr *ChainRouter) GetStateChunk(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, chunkID ids.ID) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	if chain, exists := sr.chains[chainID.Key()]; exists {
+		chain.GetStateChunk(validatorID, requestID, deadline, chunkID)
+	} else {
+		sr.log.Debug("GetStateChunk(%s, %s, %d, %s) dropped due to unknown chain", validatorID, chainID, requestID, chunkID)
+		sr.drops.Record(validatorID, constants.GetStateChunkMsg.String(), chainID, drops.UnknownChain)
+	}
+}
+
+// StateChunk routes an incoming StateChunk message from the validator with ID
+// [validatorID] to the consensus engine working on the chain with ID [chainID]
+func (sr *ChainRouter) StateChunk(validatorID ids.ShortID, chainID ids.ID, requestID uint32, chunk []byte) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	if chain, exists := sr.chains[chainID.Key()]; exists {
+		if chain.StateChunk(validatorID, requestID, chunk) {
+			sr.timeouts.Cancel(validatorID, chainID, requestID)
+		}
+	} else {
+		sr.log.Debug("StateChunk(%s, %s, %d, %d) dropped due to unknown chain", validatorID, chainID, requestID, len(chunk))
+		sr.drops.Record(validatorID, constants.StateChunkMsg.String(), chainID, drops.UnknownChain)
+	}
+}
+
+// GetStateChunkFailed routes an incoming GetStateChunkFailed message from the
+// validator with ID [validatorID] to the consensus engine working on the chain
+// with ID [chainID]
+func (sr *ChainRouter) GetStateChunkFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	sr.timeouts.Cancel(validatorID, chainID, requestID)
+	if chain, exists := sr.chains[chainID.Key()]; exists {
+		chain.GetStateChunkFailed(validatorID, requestID)
+	} else {
+		sr.log.Error("GetStateChunkFailed(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		sr.drops.Record(validatorID, constants.GetStateChunkFailedMsg.String(), chainID, drops.UnknownChain)
 	}
 }
 
@@ -394,6 +552,7 @@ func (sr *ChainRouter) Connected(validatorID ids.ShortID) {
 	defer sr.lock.Unlock()
 
 	sr.peers.Add(validatorID)
+	sr.gossipActivity()
 	for _, chain := range sr.chains {
 		chain.Connected(validatorID)
 	}
@@ -405,6 +564,7 @@ func (sr *ChainRouter) Disconnected(validatorID ids.ShortID) {
 	defer sr.lock.Unlock()
 
 	sr.peers.Remove(validatorID)
+	sr.gossipActivity()
 	for _, chain := range sr.chains {
 		chain.Disconnected(validatorID)
 	}
@@ -418,6 +578,7 @@ func (sr *ChainRouter) Gossip() {
 	for _, chain := range sr.chains {
 		chain.Gossip()
 	}
+	sr.adaptGossipFrequency()
 }
 
 // EndInterval notifies the chains that the current CPU interval has ended
@@ -38,7 +38,7 @@ func TestShutdown(t *testing.T) {
 	go tm.Dispatch()
 
 	chainRouter := ChainRouter{}
-	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil)
+	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, nil, &tm, time.Hour, 0, 0, time.Second, ids.Set{}, nil)
 
 	engine := common.EngineTest{T: t}
 	engine.Default(false)
@@ -101,7 +101,7 @@ func TestShutdownTimesOut(t *testing.T) {
 	go tm.Dispatch()
 
 	chainRouter := ChainRouter{}
-	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Millisecond, ids.Set{}, nil)
+	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, nil, &tm, time.Hour, 0, 0, time.Millisecond, ids.Set{}, nil)
 
 	engine := common.EngineTest{T: t}
 	engine.Default(false)
@@ -0,0 +1,45 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// gossipActivity records that a peer connected or disconnected, or that a
+// query was received, so accepted frontiers should be gossiped more often
+func (sr *ChainRouter) gossipActivity() { atomic.AddInt64(&sr.activity, 1) }
+
+// adaptGossipFrequency sets the time until accepted frontiers are next
+// gossiped. While peers churn or chains are queried, the time is halved, down
+// to the minimum gossip frequency. While the network is idle, it's doubled, up
+// to the maximum.
+func (sr *ChainRouter) adaptGossipFrequency() {
+	if sr.minGossipFrequency <= 0 || sr.maxGossipFrequency < sr.minGossipFrequency {
+		return // The gossip frequency isn't adaptive
+	}
+
+	active := atomic.SwapInt64(&sr.activity, 0) > 0
+	frequency := nextGossipFrequency(sr.gossiper.Frequency(), sr.minGossipFrequency, sr.maxGossipFrequency, active)
+	sr.gossiper.SetFrequency(frequency)
+}
+
+// nextGossipFrequency returns the gossip frequency that follows [current],
+// within [min, max], given whether the network was [active]
+func nextGossipFrequency(current, min, max time.Duration, active bool) time.Duration {
+	if active {
+		current /= 2
+	} else {
+		current *= 2
+	}
+	switch {
+	case current < min:
+		return min
+	case current > max:
+		return max
+	default:
+		return current
+	}
+}
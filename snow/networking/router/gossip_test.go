@@ -0,0 +1,55 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+func TestNextGossipFrequency(t *testing.T) {
+	tests := []struct {
+		current  time.Duration
+		active   bool
+		expected time.Duration
+	}{
+		{10 * time.Second, true, 5 * time.Second},
+		{10 * time.Second, false, 20 * time.Second},
+		{3 * time.Second, true, 2 * time.Second},
+		{40 * time.Second, false, time.Minute},
+	}
+	for _, test := range tests {
+		if frequency := nextGossipFrequency(test.current, 2*time.Second, time.Minute, test.active); frequency != test.expected {
+			t.Fatalf("expected %s after %s, active: %v, but got %s", test.expected, test.current, test.active, frequency)
+		}
+	}
+}
+
+func TestAdaptGossipFrequency(t *testing.T) {
+	sr := &ChainRouter{
+		gossiper:           timer.NewRepeater(func() {}, 10*time.Second),
+		minGossipFrequency: time.Second,
+		maxGossipFrequency: time.Minute,
+	}
+
+	sr.adaptGossipFrequency()
+	if frequency := sr.gossiper.Frequency(); frequency != 20*time.Second {
+		t.Fatalf("idle network should have gossiped less often, but the frequency is %s", frequency)
+	}
+
+	sr.gossipActivity()
+	sr.adaptGossipFrequency()
+	if frequency := sr.gossiper.Frequency(); frequency != 10*time.Second {
+		t.Fatalf("active network should have gossiped more often, but the frequency is %s", frequency)
+	}
+
+	// Without a minimum, the frequency is fixed
+	sr.minGossipFrequency = 0
+	sr.adaptGossipFrequency()
+	if frequency := sr.gossiper.Frequency(); frequency != 10*time.Second {
+		t.Fatalf("fixed frequency shouldn't have changed, but is %s", frequency)
+	}
+}
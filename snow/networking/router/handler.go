@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/drops"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -109,6 +110,9 @@ type Handler struct {
 
 	toClose func()
 	closing bool
+
+	// Set by the router when this handler is registered
+	drops *drops.Log
 }
 
 // Initialize this consensus handler
@@ -171,6 +175,7 @@ func (h *Handler) Initialize(
 		bufferSize,
 		h.ctx.Log,
 		&h.metrics,
+		h.dropped,
 	)
 	h.engine = engine
 	h.validators = validators
@@ -207,6 +212,7 @@ func (h *Handler) Dispatch() {
 				h.ctx.Log.Verbo("Dropping message due to likely timeout: %s", msg)
 				h.metrics.dropped.Inc()
 				h.metrics.expired.Inc()
+				h.dropped(msg, drops.Expired)
 				continue
 			}
 
@@ -239,6 +245,7 @@ func (h *Handler) dispatchMsg(msg message) {
 	if h.closing {
 		h.ctx.Log.Debug("dropping message due to closing:\n%s", msg)
 		h.metrics.dropped.Inc()
+		h.dropped(msg, drops.Closed)
 		return
 	}
 
@@ -365,6 +372,19 @@ func (h *Handler) MultiPut(validatorID ids.ShortID, requestID uint32, containers
 	})
 }
 
+// LocalMultiPut passes a MultiPut message that was produced by this node,
+// rather than received from the network, to the consensus engine. Unlike
+// MultiPut, the message is never dropped.
+func (h *Handler) LocalMultiPut(validatorID ids.ShortID, requestID uint32, containers [][]byte) {
+	h.sendReliableMsg(message{
+		messageType: constants.MultiPutMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		containers:  containers,
+		received:    h.clock.Time(),
+	})
+}
+
 // GetAncestorsFailed passes a GetAncestorsFailed message to the consensus engine.
 func (h *Handler) GetAncestorsFailed(validatorID ids.ShortID, requestID uint32) {
 	h.sendReliableMsg(message{
@@ -452,6 +472,75 @@ func (h *Handler) QueryFailed(validatorID ids.ShortID, requestID uint32) {
 	})
 }
 
+// GetStateSummaryFrontier passes a GetStateSummaryFrontier message received
+// from the network to the consensus engine.
+func (h *Handler) GetStateSummaryFrontier(validatorID ids.ShortID, requestID uint32, deadline time.Time) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.GetStateSummaryFrontierMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		deadline:    deadline,
+		received:    h.clock.Time(),
+	})
+}
+
+// StateSummaryFrontier passes a StateSummaryFrontier message received from the
+// network to the consensus engine.
+func (h *Handler) StateSummaryFrontier(validatorID ids.ShortID, requestID uint32, summary []byte) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.StateSummaryFrontierMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		container:   summary,
+		received:    h.clock.Time(),
+	})
+}
+
+// GetStateSummaryFrontierFailed passes a GetStateSummaryFrontierFailed message
+// to the consensus engine.
+func (h *Handler) GetStateSummaryFrontierFailed(validatorID ids.ShortID, requestID uint32) {
+	h.sendReliableMsg(message{
+		messageType: constants.GetStateSummaryFrontierFailedMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+	})
+}
+
+// GetStateChunk passes a GetStateChunk message received from the network to
+// the consensus engine.
+func (h *Handler) GetStateChunk(validatorID ids.ShortID, requestID uint32, deadline time.Time, chunkID ids.ID) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.GetStateChunkMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		deadline:    deadline,
+		containerID: chunkID,
+		received:    h.clock.Time(),
+	})
+}
+
+// StateChunk passes a StateChunk message received from the network to the
+// consensus engine.
+func (h *Handler) StateChunk(validatorID ids.ShortID, requestID uint32, chunk []byte) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.StateChunkMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		container:   chunk,
+		received:    h.clock.Time(),
+	})
+}
+
+// GetStateChunkFailed passes a GetStateChunkFailed message to the consensus
+// engine.
+func (h *Handler) GetStateChunkFailed(validatorID ids.ShortID, requestID uint32) {
+	h.sendReliableMsg(message{
+		messageType: constants.GetStateChunkFailedMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+	})
+}
+
 // Connected passes a new connection notification to the consensus engine
 func (h *Handler) Connected(validatorID ids.ShortID) {
 	h.sendReliableMsg(message{
@@ -548,6 +637,18 @@ func (h *Handler) handleValidatorMsg(msg message, startTime time.Time) error {
 		err = h.engine.QueryFailed(msg.validatorID, msg.requestID)
 	case constants.ChitsMsg:
 		err = h.engine.Chits(msg.validatorID, msg.requestID, msg.containerIDs)
+	case constants.GetStateSummaryFrontierMsg:
+		err = h.engine.GetStateSummaryFrontier(msg.validatorID, msg.requestID)
+	case constants.StateSummaryFrontierMsg:
+		err = h.engine.StateSummaryFrontier(msg.validatorID, msg.requestID, msg.container)
+	case constants.GetStateSummaryFrontierFailedMsg:
+		err = h.engine.GetStateSummaryFrontierFailed(msg.validatorID, msg.requestID)
+	case constants.GetStateChunkMsg:
+		err = h.engine.GetStateChunk(msg.validatorID, msg.requestID, msg.containerID)
+	case constants.StateChunkMsg:
+		err = h.engine.StateChunk(msg.validatorID, msg.requestID, msg.container)
+	case constants.GetStateChunkFailedMsg:
+		err = h.engine.GetStateChunkFailed(msg.validatorID, msg.requestID)
 	case constants.ConnectedMsg:
 		err = h.engine.Connected(msg.validatorID)
 	case constants.DisconnectedMsg:
@@ -564,6 +665,11 @@ func (h *Handler) handleValidatorMsg(msg message, startTime time.Time) error {
 	return err
 }
 
+// dropped records that [msg] was dropped because of [reason]
+func (h *Handler) dropped(msg message, reason drops.Reason) {
+	h.drops.Record(msg.validatorID, msg.messageType.String(), h.ctx.ChainID, reason)
+}
+
 func (h *Handler) sendReliableMsg(msg message) {
 	h.reliableMsgsLock.Lock()
 	defer h.reliableMsgsLock.Unlock()
@@ -185,3 +185,49 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 	case <-closed:
 	}
 }
+
+func TestHandlerLocalMultiPut(t *testing.T) {
+	engine := common.EngineTest{T: t}
+	engine.Default(true)
+	engine.ContextF = snow.DefaultContextTest
+	called := make(chan struct{})
+
+	containers := [][]byte{{1}, {0}}
+	engine.MultiPutF = func(validatorID ids.ShortID, requestID uint32, blks [][]byte) error {
+		switch {
+		case !validatorID.Equals(ids.ShortEmpty):
+			t.Fatalf("unexpected validator %s", validatorID)
+		case requestID != 1:
+			t.Fatalf("unexpected request ID %d", requestID)
+		case len(blks) != len(containers):
+			t.Fatalf("expected %d containers but got %d", len(containers), len(blks))
+		}
+		called <- struct{}{}
+		return nil
+	}
+
+	handler := &Handler{}
+	handler.Initialize(
+		&engine,
+		validators.NewSet(),
+		nil,
+		16,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+
+	handler.LocalMultiPut(ids.ShortEmpty, 1, containers)
+
+	go handler.Dispatch()
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	select {
+	case <-ticker.C:
+		t.Fatalf("Calling engine function timed out")
+	case <-called:
+	}
+}
@@ -45,6 +45,10 @@ func (m message) String() string {
 		sb.WriteString(fmt.Sprintf("\n    containerID: %s", m.containerID))
 	case constants.MultiPutMsg:
 		sb.WriteString(fmt.Sprintf("\n    numContainers: %d", len(m.containers)))
+	case constants.GetStateChunkMsg:
+		sb.WriteString(fmt.Sprintf("\n    chunkID: %s", m.containerID))
+	case constants.StateSummaryFrontierMsg, constants.StateChunkMsg:
+		sb.WriteString(fmt.Sprintf("\n    size: %d", len(m.container)))
 	case constants.NotifyMsg:
 		sb.WriteString(fmt.Sprintf("\n    notification: %s", m.notification))
 	}
@@ -42,6 +42,8 @@ type metrics struct {
 	getAncestors, multiPut, getAncestorsFailed,
 	get, put, getFailed,
 	pushQuery, pullQuery, chits, queryFailed,
+	getStateSummaryFrontier, stateSummaryFrontier, getStateSummaryFrontierFailed,
+	getStateChunk, stateChunk, getStateChunkFailed,
 	connected, disconnected,
 	notify,
 	gossip,
@@ -108,6 +110,12 @@ func (m *metrics) Initialize(namespace string, registerer prometheus.Registerer)
 	m.pullQuery = initHistogram(namespace, "pull_query", registerer, &errs)
 	m.chits = initHistogram(namespace, "chits", registerer, &errs)
 	m.queryFailed = initHistogram(namespace, "query_failed", registerer, &errs)
+	m.getStateSummaryFrontier = initHistogram(namespace, "get_state_summary_frontier", registerer, &errs)
+	m.stateSummaryFrontier = initHistogram(namespace, "state_summary_frontier", registerer, &errs)
+	m.getStateSummaryFrontierFailed = initHistogram(namespace, "get_state_summary_frontier_failed", registerer, &errs)
+	m.getStateChunk = initHistogram(namespace, "get_state_chunk", registerer, &errs)
+	m.stateChunk = initHistogram(namespace, "state_chunk", registerer, &errs)
+	m.getStateChunkFailed = initHistogram(namespace, "get_state_chunk_failed", registerer, &errs)
 	m.connected = initHistogram(namespace, "connected", registerer, &errs)
 	m.disconnected = initHistogram(namespace, "disconnected", registerer, &errs)
 	m.notify = initHistogram(namespace, "notify", registerer, &errs)
@@ -193,6 +201,18 @@ func (m *metrics) getMSGHistogram(msg constants.MsgType) prometheus.Histogram {
 		return m.queryFailed
 	case constants.ChitsMsg:
 		return m.chits
+	case constants.GetStateSummaryFrontierMsg:
+		return m.getStateSummaryFrontier
+	case constants.StateSummaryFrontierMsg:
+		return m.stateSummaryFrontier
+	case constants.GetStateSummaryFrontierFailedMsg:
+		return m.getStateSummaryFrontierFailed
+	case constants.GetStateChunkMsg:
+		return m.getStateChunk
+	case constants.StateChunkMsg:
+		return m.stateChunk
+	case constants.GetStateChunkFailedMsg:
+		return m.getStateChunkFailed
 	case constants.ConnectedMsg:
 		return m.connected
 	case constants.DisconnectedMsg:
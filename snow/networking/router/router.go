@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/drops"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
@@ -20,8 +21,11 @@ type Router interface {
 	Initialize(
 		nodeID ids.ShortID,
 		log logging.Logger,
+		dropLog *drops.Log,
 		timeouts *timeout.Manager,
 		gossipFrequency,
+		minGossipFrequency,
+		maxGossipFrequency,
 		shutdownTimeout time.Duration,
 		criticalChains ids.Set,
 		onFatal func(),
@@ -29,6 +33,7 @@ type Router interface {
 	Shutdown()
 	AddChain(chain *Handler)
 	RemoveChain(chainID ids.ID)
+	HealthCheck() (interface{}, error)
 }
 
 // ExternalRouter routes messages from the network to the
@@ -45,6 +50,10 @@ type ExternalRouter interface {
 	PushQuery(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID, container []byte)
 	PullQuery(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID)
 	Chits(validatorID ids.ShortID, chainID ids.ID, requestID uint32, votes ids.Set)
+	GetStateSummaryFrontier(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time)
+	StateSummaryFrontier(validatorID ids.ShortID, chainID ids.ID, requestID uint32, summary []byte)
+	GetStateChunk(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, chunkID ids.ID)
+	StateChunk(validatorID ids.ShortID, chainID ids.ID, requestID uint32, chunk []byte)
 }
 
 // InternalRouter deals with messages internal to this node
@@ -54,6 +63,8 @@ type InternalRouter interface {
 	GetFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
 	GetAncestorsFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
 	QueryFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
+	GetStateSummaryFrontierFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
+	GetStateChunkFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
 
 	Connected(validatorID ids.ShortID)
 	Disconnected(validatorID ids.ShortID)
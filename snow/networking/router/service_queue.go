@@ -11,6 +11,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/drops"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
@@ -50,6 +51,7 @@ type multiLevelQueue struct {
 
 	log     logging.Logger
 	metrics *metrics
+	onDrop  func(message, drops.Reason)
 }
 
 // newMultiLevelQueue creates a new MultilevelQueue and counting semaphore for signaling when messages are available
@@ -63,6 +65,7 @@ func newMultiLevelQueue(
 	bufferSize int,
 	log logging.Logger,
 	metrics *metrics,
+	onDrop func(message, drops.Reason),
 ) (messageQueue, chan struct{}) {
 	semaChan := make(chan struct{}, bufferSize)
 	singleLevelSize := bufferSize / len(consumptionRanges)
@@ -88,6 +91,7 @@ func newMultiLevelQueue(
 		cpuAllotments: consumptionAllotments,
 		log:           log,
 		metrics:       metrics,
+		onDrop:        onDrop,
 		bufferSize:    bufferSize,
 		semaChan:      semaChan,
 	}, semaChan
@@ -202,12 +206,14 @@ func (ml *multiLevelQueue) pushMessage(msg message) bool {
 	if ml.pendingMessages >= ml.bufferSize {
 		ml.log.Debug("Dropped message due to a full message queue with %d messages", ml.pendingMessages)
 		ml.metrics.dropped.Inc()
+		ml.dropped(msg, drops.QueueFull)
 		return false
 	}
 
 	validatorID := msg.validatorID
 	if validatorID.IsZero() {
 		ml.metrics.dropped.Inc()
+		ml.dropped(msg, drops.InvalidSender)
 		ml.log.Warn("Dropping message due to invalid validatorID")
 		return false
 	}
@@ -216,6 +222,7 @@ func (ml *multiLevelQueue) pushMessage(msg message) bool {
 	if !processing {
 		ml.metrics.dropped.Inc()
 		ml.metrics.throttled.Inc()
+		ml.dropped(msg, drops.Throttled)
 		return false
 	}
 
@@ -223,6 +230,7 @@ func (ml *multiLevelQueue) pushMessage(msg message) bool {
 	if !ml.placeMessage(msg) {
 		ml.log.Verbo("Dropped message while attempting to place it in a queue: %s", msg)
 		ml.metrics.dropped.Inc()
+		ml.dropped(msg, drops.QueueFull)
 		ml.msgManager.RemovePending(validatorID)
 		return false
 	}
@@ -237,6 +245,13 @@ func (ml *multiLevelQueue) pushMessage(msg message) bool {
 	return true
 }
 
+// dropped reports that [msg] was dropped because of [reason]
+func (ml *multiLevelQueue) dropped(msg message, reason drops.Reason) {
+	if ml.onDrop != nil {
+		ml.onDrop(msg, reason)
+	}
+}
+
 // placeMessage finds the correct index of a message and attempts to place the
 // message at that queue or lower
 func (ml *multiLevelQueue) placeMessage(msg message) bool {
@@ -48,6 +48,7 @@ func setupMultiLevelQueue(t *testing.T, bufferSize int) (messageQueue, chan stru
 		bufferSize,
 		logging.NoLog{},
 		metrics,
+		nil,
 	)
 
 	return queue, semaChan
@@ -176,6 +177,7 @@ func TestMultiLevelQueuePrioritizes(t *testing.T) {
 		bufferSize,
 		logging.NoLog{},
 		metrics,
+		nil,
 	)
 
 	// Utilize CPU such that the next message from validator2 will be placed on a lower
@@ -289,6 +291,7 @@ func TestMultiLevelQueuePushesDownOldMessages(t *testing.T) {
 		bufferSize,
 		logging.NoLog{},
 		metrics,
+		nil,
 	)
 
 	queue.PushMessage(message{
@@ -392,6 +395,7 @@ func TestMultiLevelQueueFreesSpace(t *testing.T) {
 		bufferSize,
 		logging.NoLog{},
 		metrics,
+		nil,
 	)
 
 	for i := 0; i < 4; i++ {
@@ -479,6 +483,7 @@ func TestMultiLevelQueueThrottles(t *testing.T) {
 		bufferSize,
 		logging.NoLog{},
 		metrics,
+		nil,
 	)
 
 	success := queue.PushMessage(message{
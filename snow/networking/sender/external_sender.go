@@ -28,5 +28,11 @@ type ExternalSender interface {
 	PullQuery(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID)
 	Chits(validatorID ids.ShortID, chainID ids.ID, requestID uint32, votes ids.Set)
 
+	GetStateSummaryFrontier(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time)
+	StateSummaryFrontier(validatorID ids.ShortID, chainID ids.ID, requestID uint32, summary []byte)
+
+	GetStateChunk(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, chunkID ids.ID)
+	StateChunk(validatorID ids.ShortID, chainID ids.ID, requestID uint32, chunk []byte)
+
 	Gossip(chainID ids.ID, containerID ids.ID, container []byte)
 }
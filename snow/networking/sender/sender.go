@@ -238,6 +238,70 @@ func (s *Sender) Chits(validatorID ids.ShortID, requestID uint32, votes ids.Set)
 	}
 }
 
+// GetStateSummaryFrontier sends a GetStateSummaryFrontier message to the
+// specified validators, asking each for the state summary it would have new
+// nodes sync from
+func (s *Sender) GetStateSummaryFrontier(validatorIDs ids.ShortSet, requestID uint32) {
+	s.ctx.Log.Verbo("Sending GetStateSummaryFrontier to validators %v. RequestID: %d", validatorIDs, requestID)
+
+	currentDeadline := time.Time{}
+	for _, validatorID := range validatorIDs.List() {
+		vID := validatorID
+		deadline, ok := s.timeouts.Register(validatorID, s.ctx.ChainID, requestID, true, constants.GetStateSummaryFrontierMsg, func() {
+			s.router.GetStateSummaryFrontierFailed(vID, s.ctx.ChainID, requestID)
+		})
+		if deadline.After(currentDeadline) {
+			currentDeadline = deadline
+		}
+		if !ok {
+			validatorIDs.Remove(validatorID)
+		}
+	}
+
+	if validatorIDs.Contains(s.ctx.NodeID) {
+		validatorIDs.Remove(s.ctx.NodeID)
+		go s.router.GetStateSummaryFrontier(s.ctx.NodeID, s.ctx.ChainID, requestID, currentDeadline)
+	}
+
+	s.sender.GetStateSummaryFrontier(validatorIDs, s.ctx.ChainID, requestID, currentDeadline)
+}
+
+// StateSummaryFrontier sends a StateSummaryFrontier message, holding the
+// state summary this node would have new nodes sync from
+func (s *Sender) StateSummaryFrontier(validatorID ids.ShortID, requestID uint32, summary []byte) {
+	s.ctx.Log.Verbo("Sending StateSummaryFrontier to validator %s. RequestID: %d. Size: %d", validatorID, requestID, len(summary))
+	if validatorID.Equals(s.ctx.NodeID) {
+		go s.router.StateSummaryFrontier(validatorID, s.ctx.ChainID, requestID, summary)
+	} else {
+		s.sender.StateSummaryFrontier(validatorID, s.ctx.ChainID, requestID, summary)
+	}
+}
+
+// GetStateChunk sends a GetStateChunk message, asking the validator for the
+// chunk of state with ID [chunkID]
+func (s *Sender) GetStateChunk(validatorID ids.ShortID, requestID uint32, chunkID ids.ID) {
+	s.ctx.Log.Verbo("Sending GetStateChunk to validator %s. RequestID: %d. ChunkID: %s", validatorID, requestID, chunkID)
+	// Sending a GetStateChunk to myself will always fail
+	if validatorID.Equals(s.ctx.NodeID) {
+		go s.router.GetStateChunkFailed(validatorID, s.ctx.ChainID, requestID)
+		return
+	}
+
+	deadline, ok := s.timeouts.Register(validatorID, s.ctx.ChainID, requestID, false, constants.GetStateChunkMsg, func() {
+		s.router.GetStateChunkFailed(validatorID, s.ctx.ChainID, requestID)
+	})
+	if !ok {
+		return
+	}
+	s.sender.GetStateChunk(validatorID, s.ctx.ChainID, requestID, deadline, chunkID)
+}
+
+// StateChunk sends a StateChunk message, holding a chunk of this node's state
+func (s *Sender) StateChunk(validatorID ids.ShortID, requestID uint32, chunk []byte) {
+	s.ctx.Log.Verbo("Sending StateChunk to validator %s. RequestID: %d. Size: %d", validatorID, requestID, len(chunk))
+	s.sender.StateChunk(validatorID, s.ctx.ChainID, requestID, chunk)
+}
+
 // Gossip the provided container
 func (s *Sender) Gossip(containerID ids.ID, container []byte) {
 	s.ctx.Log.Verbo("Gossiping %s", containerID)
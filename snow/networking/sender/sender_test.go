@@ -56,7 +56,7 @@ func TestTimeout(t *testing.T) {
 	go tm.Dispatch()
 
 	chainRouter := router.ChainRouter{}
-	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil)
+	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, nil, &tm, time.Hour, 0, 0, time.Second, ids.Set{}, nil)
 
 	sender := Sender{}
 	sender.Initialize(snow.DefaultContextTest(), &ExternalSenderTest{}, &chainRouter, &tm)
@@ -125,7 +125,7 @@ func TestReliableMessages(t *testing.T) {
 	go tm.Dispatch()
 
 	chainRouter := router.ChainRouter{}
-	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil)
+	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, nil, &tm, time.Hour, 0, 0, time.Second, ids.Set{}, nil)
 
 	sender := Sender{}
 	sender.Initialize(snow.DefaultContextTest(), &ExternalSenderTest{}, &chainRouter, &tm)
@@ -205,7 +205,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 	go tm.Dispatch()
 
 	chainRouter := router.ChainRouter{}
-	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil)
+	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, nil, &tm, time.Hour, 0, 0, time.Second, ids.Set{}, nil)
 
 	sender := Sender{}
 	sender.Initialize(snow.DefaultContextTest(), &ExternalSenderTest{}, &chainRouter, &tm)
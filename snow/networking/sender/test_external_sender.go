@@ -20,6 +20,8 @@ type ExternalSenderTest struct {
 	CantGetAncestors, CantMultiPut,
 	CantGet, CantPut,
 	CantPullQuery, CantPushQuery, CantChits,
+	CantGetStateSummaryFrontier, CantStateSummaryFrontier,
+	CantGetStateChunk, CantStateChunk,
 	CantGossip bool
 
 	GetAcceptedFrontierF func(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time)
@@ -38,6 +40,12 @@ type ExternalSenderTest struct {
 	PullQueryF func(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID)
 	ChitsF     func(validatorID ids.ShortID, chainID ids.ID, requestID uint32, votes ids.Set)
 
+	GetStateSummaryFrontierF func(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time)
+	StateSummaryFrontierF    func(validatorID ids.ShortID, chainID ids.ID, requestID uint32, summary []byte)
+
+	GetStateChunkF func(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, chunkID ids.ID)
+	StateChunkF    func(validatorID ids.ShortID, chainID ids.ID, requestID uint32, chunk []byte)
+
 	GossipF func(chainID ids.ID, containerID ids.ID, container []byte)
 }
 
@@ -59,6 +67,12 @@ func (s *ExternalSenderTest) Default(cant bool) {
 	s.CantPushQuery = cant
 	s.CantChits = cant
 
+	s.CantGetStateSummaryFrontier = cant
+	s.CantStateSummaryFrontier = cant
+
+	s.CantGetStateChunk = cant
+	s.CantStateChunk = cant
+
 	s.CantGossip = cant
 }
 
@@ -216,6 +230,62 @@ func (s *ExternalSenderTest) Chits(vdr ids.ShortID, chainID ids.ID, requestID ui
 	}
 }
 
+// GetStateSummaryFrontier calls GetStateSummaryFrontierF if it was
+// initialized. If it wasn't initialized and this function shouldn't be called
+// and testing was initialized, then testing will fail.
+func (s *ExternalSenderTest) GetStateSummaryFrontier(vdrs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time) {
+	switch {
+	case s.GetStateSummaryFrontierF != nil:
+		s.GetStateSummaryFrontierF(vdrs, chainID, requestID, deadline)
+	case s.CantGetStateSummaryFrontier && s.T != nil:
+		s.T.Fatalf("Unexpectedly called GetStateSummaryFrontier")
+	case s.CantGetStateSummaryFrontier && s.B != nil:
+		s.B.Fatalf("Unexpectedly called GetStateSummaryFrontier")
+	}
+}
+
+// StateSummaryFrontier calls StateSummaryFrontierF if it was initialized. If
+// it wasn't initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *ExternalSenderTest) StateSummaryFrontier(vdr ids.ShortID, chainID ids.ID, requestID uint32, summary []byte) {
+	switch {
+	case s.StateSummaryFrontierF != nil:
+		s.StateSummaryFrontierF(vdr, chainID, requestID, summary)
+	case s.CantStateSummaryFrontier && s.T != nil:
+		s.T.Fatalf("Unexpectedly called StateSummaryFrontier")
+	case s.CantStateSummaryFrontier && s.B != nil:
+		s.B.Fatalf("Unexpectedly called StateSummaryFrontier")
+	}
+}
+
+// GetStateChunk calls GetStateChunkF if it was initialized. If it wasn't
+// initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *ExternalSenderTest) GetStateChunk(vdr ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, chunkID ids.ID) {
+	switch {
+	case s.GetStateChunkF != nil:
+		s.GetStateChunkF(vdr, chainID, requestID, deadline, chunkID)
+	case s.CantGetStateChunk && s.T != nil:
+		s.T.Fatalf("Unexpectedly called GetStateChunk")
+	case s.CantGetStateChunk && s.B != nil:
+		s.B.Fatalf("Unexpectedly called GetStateChunk")
+	}
+}
+
+// StateChunk calls StateChunkF if it was initialized. If it wasn't initialized
+// and this function shouldn't be called and testing was initialized, then
+// testing will fail.
+func (s *ExternalSenderTest) StateChunk(vdr ids.ShortID, chainID ids.ID, requestID uint32, chunk []byte) {
+	switch {
+	case s.StateChunkF != nil:
+		s.StateChunkF(vdr, chainID, requestID, chunk)
+	case s.CantStateChunk && s.T != nil:
+		s.T.Fatalf("Unexpectedly called StateChunk")
+	case s.CantStateChunk && s.B != nil:
+		s.B.Fatalf("Unexpectedly called StateChunk")
+	}
+}
+
 // Gossip calls GossipF if it was initialized. If it wasn't initialized and this
 // function shouldn't be called and testing was initialized, then testing will
 // fail.
@@ -11,7 +11,8 @@ import (
 
 // Manager holds the validator set of each subnet
 type Manager interface {
-	// Set a subnet's validator set
+	// Set a subnet's validator set. If the subnet already has a validator
+	// set, it's updated in place to hold the validators of the provided set.
 	Set(ids.ID, Set) error
 
 	// AddWeight adds weight to a given validator on the given subnet
@@ -23,6 +24,9 @@ type Manager interface {
 	// GetValidators returns the validator set for the given subnet
 	// Returns false if the subnet doesn't exist
 	GetValidators(ids.ID) (Set, bool)
+
+	// GetSubnets returns the IDs of the subnets the given validator validates
+	GetSubnets(ids.ShortID) []ids.ID
 }
 
 // NewManager returns a new, empty manager
@@ -87,3 +91,17 @@ func (m *manager) GetValidators(subnetID ids.ID) (Set, bool) {
 	vdrs, ok := m.subnetToVdrs[subnetID.Key()]
 	return vdrs, ok
 }
+
+// GetSubnets implements the Manager interface.
+func (m *manager) GetSubnets(vdrID ids.ShortID) []ids.ID {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	subnetIDs := []ids.ID(nil)
+	for subnetKey, vdrs := range m.subnetToVdrs {
+		if vdrs.Contains(vdrID) {
+			subnetIDs = append(subnetIDs, ids.NewID(subnetKey))
+		}
+	}
+	return subnetIDs
+}
@@ -32,8 +32,10 @@ const (
 type Set interface {
 	fmt.Stringer
 
-	// Set removes all the current validators and adds all the provided
-	// validators to the set.
+	// Set replaces the current validators with the provided validators. Only
+	// the validators that were added, removed, or whose weight changed are
+	// updated, so the set can be re-synced with the validator set it's
+	// tracking as often as that changes.
 	Set([]Validator) error
 
 	// AddWeight to a staker.
@@ -70,7 +72,7 @@ type Set interface {
 func NewSet() Set {
 	return &set{
 		vdrMap:  make(map[[20]byte]int),
-		sampler: sampler.NewWeightedWithoutReplacement(),
+		sampler: sampler.NewUpdatableWeightedWithoutReplacement(),
 	}
 }
 
@@ -78,7 +80,7 @@ func NewSet() Set {
 func NewBestSet(expectedSampleSize int) Set {
 	return &set{
 		vdrMap:  make(map[[20]byte]int),
-		sampler: sampler.NewBestWeightedWithoutReplacement(expectedSampleSize),
+		sampler: sampler.NewBestUpdatableWeightedWithoutReplacement(expectedSampleSize),
 	}
 }
 
@@ -90,7 +92,7 @@ type set struct {
 	vdrMap      map[[20]byte]int
 	vdrSlice    []*validator
 	vdrWeights  []uint64
-	sampler     sampler.WeightedWithoutReplacement
+	sampler     sampler.UpdatableWeightedWithoutReplacement
 	totalWeight uint64
 }
 
@@ -103,47 +105,88 @@ func (s *set) Set(vdrs []Validator) error {
 }
 
 func (s *set) set(vdrs []Validator) error {
-	lenVdrs := len(vdrs)
-	// If the underlying arrays are much larger than necessary, resize them to
-	// allow garbage collection of unused memory
-	if cap(s.vdrSlice) > len(s.vdrSlice)*maxExcessCapacityFactor {
-		newCap := cap(s.vdrSlice) / capacityReductionFactor
-		if newCap < lenVdrs {
-			newCap = lenVdrs
-		}
-		s.vdrSlice = make([]*validator, 0, newCap)
-		s.vdrWeights = make([]uint64, 0, newCap)
-	} else {
-		s.vdrSlice = s.vdrSlice[:0]
-		s.vdrWeights = s.vdrWeights[:0]
-	}
-	s.vdrMap = make(map[[20]byte]int, lenVdrs)
-	s.totalWeight = 0
-
+	// Index the new weights, ignoring duplicates and validators that would
+	// never be sampled
+	newWeights := make(map[[20]byte]uint64, len(vdrs))
+	newVdrIDs := make([]ids.ShortID, 0, len(vdrs))
+	newTotalWeight := uint64(0)
 	for _, vdr := range vdrs {
 		vdrID := vdr.ID()
-		if s.contains(vdrID) {
+		vdrIDKey := vdrID.Key()
+		if _, exists := newWeights[vdrIDKey]; exists {
 			continue
 		}
 		w := vdr.Weight()
 		if w == 0 {
 			continue // This validator would never be sampled anyway
 		}
+		totalWeight, err := safemath.Add64(newTotalWeight, w)
+		if err != nil {
+			return err
+		}
+		newTotalWeight = totalWeight
+		newWeights[vdrIDKey] = w
+		newVdrIDs = append(newVdrIDs, vdrID)
+	}
 
-		i := len(s.vdrSlice)
-		s.vdrMap[vdrID.Key()] = i
+	// Remove the validators that are no longer in the set, and update the
+	// weights of the ones that remain
+	for i := len(s.vdrSlice) - 1; i >= 0; i-- {
+		vdr := s.vdrSlice[i]
+		vdrIDKey := vdr.ID().Key()
+		w, stays := newWeights[vdrIDKey]
+		if !stays {
+			if err := s.remove(vdr.ID()); err != nil {
+				return err
+			}
+			continue
+		}
+		if vdr.weight == w {
+			continue
+		}
+		index := s.vdrMap[vdrIDKey]
+		vdr.weight = w
+		s.vdrWeights[index] = w
+		if err := s.sampler.Update(index, w); err != nil {
+			return err
+		}
+	}
+
+	// Add the validators that are new to the set
+	for _, vdrID := range newVdrIDs {
+		vdrIDKey := vdrID.Key()
+		if _, exists := s.vdrMap[vdrIDKey]; exists {
+			continue
+		}
+		w := newWeights[vdrIDKey]
+		index := len(s.vdrSlice)
+		s.vdrMap[vdrIDKey] = index
 		s.vdrSlice = append(s.vdrSlice, &validator{
-			nodeID: vdr.ID(),
-			weight: vdr.Weight(),
+			nodeID: vdrID,
+			weight: w,
 		})
 		s.vdrWeights = append(s.vdrWeights, w)
-		newTotalWeight, err := safemath.Add64(s.totalWeight, w)
-		if err != nil {
+		if err := s.sampler.Update(index, w); err != nil {
 			return err
 		}
-		s.totalWeight = newTotalWeight
 	}
-	return s.sampler.Initialize(s.vdrWeights)
+	s.totalWeight = newTotalWeight
+
+	// If the underlying arrays are much larger than necessary, resize them to
+	// allow garbage collection of unused memory
+	if cap(s.vdrSlice) > len(s.vdrSlice)*maxExcessCapacityFactor {
+		newCap := cap(s.vdrSlice) / capacityReductionFactor
+		if newCap < len(s.vdrSlice) {
+			newCap = len(s.vdrSlice)
+		}
+		vdrSlice := make([]*validator, len(s.vdrSlice), newCap)
+		copy(vdrSlice, s.vdrSlice)
+		vdrWeights := make([]uint64, len(s.vdrWeights), newCap)
+		copy(vdrWeights, s.vdrWeights)
+		s.vdrSlice = vdrSlice
+		s.vdrWeights = vdrWeights
+	}
+	return nil
 }
 
 // Add implements the Set interface.
@@ -183,7 +226,7 @@ func (s *set) addWeight(vdrID ids.ShortID, weight uint64) error {
 
 	s.vdrWeights[i] += weight
 	vdr.addWeight(weight)
-	return s.sampler.Initialize(s.vdrWeights)
+	return s.sampler.Update(i, s.vdrWeights[i])
 }
 
 // GetWeight implements the Set interface.
@@ -248,11 +291,9 @@ func (s *set) removeWeight(vdrID ids.ShortID, weight uint64) error {
 	vdr.removeWeight(weight)
 
 	if vdr.Weight() == 0 {
-		if err := s.remove(vdrID); err != nil {
-			return err
-		}
+		return s.remove(vdrID)
 	}
-	return s.sampler.Initialize(s.vdrWeights)
+	return s.sampler.Update(i, s.vdrWeights[i])
 }
 
 // Get implements the Set interface.
@@ -271,6 +312,7 @@ func (s *set) get(vdrID ids.ShortID) (Validator, bool) {
 	return s.vdrSlice[index], true
 }
 
+// remove [vdrID] from the set
 func (s *set) remove(vdrID ids.ShortID) error {
 	// Get the element to remove
 	iKey := vdrID.Key()
@@ -295,12 +337,13 @@ func (s *set) remove(vdrID ids.ShortID) error {
 	s.vdrSlice = s.vdrSlice[:e]
 	s.vdrWeights = s.vdrWeights[:e]
 
-	newTotalWeight, err := safemath.Sub64(s.totalWeight, iElem.Weight())
-	if err != nil {
+	// The weight of the removed element is always included in the total weight
+	s.totalWeight -= iElem.Weight()
+
+	if err := s.sampler.Update(i, eVdr.Weight()); err != nil {
 		return err
 	}
-	s.totalWeight = newTotalWeight
-	return s.sampler.Initialize(s.vdrWeights)
+	return s.sampler.Truncate(e)
 }
 
 // Contains implements the Set interface.
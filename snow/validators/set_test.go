@@ -178,3 +178,49 @@ func TestSetSubsetWeight(t *testing.T) {
 	expectedWeight := weight0 + weight1
 	assert.Equal(t, expectedWeight, subsetWeight, "wrong subset weight")
 }
+
+func TestSetSetUpdatesChanges(t *testing.T) {
+	vdr0 := NewValidator(ids.NewShortID([20]byte{1}), 1)
+	vdr1 := NewValidator(ids.NewShortID([20]byte{2}), 2)
+	vdr2 := NewValidator(ids.NewShortID([20]byte{3}), 3)
+
+	s := NewSet()
+	err := s.Set([]Validator{vdr0, vdr1})
+	assert.NoError(t, err)
+
+	// vdr0 leaves, vdr1's weight changes, and vdr2 joins
+	err = s.Set([]Validator{
+		NewValidator(vdr1.ID(), 5),
+		vdr2,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, s.Len(), "should have two validators")
+	assert.False(t, s.Contains(vdr0.ID()), "should have removed vdr0")
+
+	weight, ok := s.GetWeight(vdr1.ID())
+	assert.True(t, ok, "should have kept vdr1")
+	assert.Equal(t, uint64(5), weight, "should have updated vdr1's weight")
+
+	weight, ok = s.GetWeight(vdr2.ID())
+	assert.True(t, ok, "should have added vdr2")
+	assert.Equal(t, uint64(3), weight, "wrong weight for vdr2")
+
+	assert.Equal(t, uint64(8), s.Weight(), "wrong set weight")
+
+	// Sampling all of the weight samples each validator as often as its
+	// updated weight
+	sampled, err := s.Sample(8)
+	assert.NoError(t, err)
+	counts := map[[20]byte]int{}
+	for _, vdr := range sampled {
+		counts[vdr.ID().Key()]++
+	}
+	assert.Equal(t, 5, counts[vdr1.ID().Key()], "wrong number of samples of vdr1")
+	assert.Equal(t, 3, counts[vdr2.ID().Key()], "wrong number of samples of vdr2")
+
+	err = s.Set(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, s.Len(), "should have removed every validator")
+	assert.Equal(t, uint64(0), s.Weight(), "wrong set weight")
+}
@@ -30,6 +30,12 @@ const (
 	GetAncestorsMsg
 	MultiPutMsg
 	GetAncestorsFailedMsg
+	GetStateSummaryFrontierMsg
+	StateSummaryFrontierMsg
+	GetStateSummaryFrontierFailedMsg
+	GetStateChunkMsg
+	StateChunkMsg
+	GetStateChunkFailedMsg
 )
 
 func (t MsgType) String() string {
@@ -76,6 +82,18 @@ func (t MsgType) String() string {
 		return "Notify Message"
 	case GossipMsg:
 		return "Gossip Message"
+	case GetStateSummaryFrontierMsg:
+		return "Get State Summary Frontier Message"
+	case StateSummaryFrontierMsg:
+		return "State Summary Frontier Message"
+	case GetStateSummaryFrontierFailedMsg:
+		return "Get State Summary Frontier Failed Message"
+	case GetStateChunkMsg:
+		return "Get State Chunk Message"
+	case StateChunkMsg:
+		return "State Chunk Message"
+	case GetStateChunkFailedMsg:
+		return "Get State Chunk Failed Message"
 	default:
 		return fmt.Sprintf("Unknown Message Type: %d", t)
 	}
@@ -0,0 +1,137 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package bip32 derives secp256k1 keys from a seed as specified by BIP 32
+package bip32
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	secp256k1 "github.com/decred/dcrd/dcrec/secp256k1/v3"
+
+	"github.com/ava-labs/avalanchego/utils/crypto"
+)
+
+const (
+	// HardenedOffset is added to the index of a hardened child key
+	HardenedOffset uint32 = 1 << 31
+
+	// MinSeedLen is the minimum length, in bytes, of a seed
+	MinSeedLen = 16
+	// MaxSeedLen is the maximum length, in bytes, of a seed
+	MaxSeedLen = 64
+)
+
+var (
+	masterKeyHMACKey = []byte("Bitcoin seed")
+
+	errInvalidSeedLen = fmt.Errorf("seed must be between %d and %d bytes", MinSeedLen, MaxSeedLen)
+	errInvalidKey     = errors.New("derived key is invalid")
+	errInvalidPath    = errors.New("derivation path must start with \"m\"")
+)
+
+// Key is an extended private key
+type Key struct {
+	key       secp256k1.ModNScalar
+	chainCode [32]byte
+}
+
+// NewMaster returns the master key of [seed]
+func NewMaster(seed []byte) (*Key, error) {
+	if len(seed) < MinSeedLen || len(seed) > MaxSeedLen {
+		return nil, errInvalidSeedLen
+	}
+	mac := hmac.New(sha512.New, masterKeyHMACKey)
+	_, _ = mac.Write(seed)
+	return newKey(mac.Sum(nil), nil)
+}
+
+// newKey returns the key whose private key is the left half of [i], plus
+// [parent] if non-nil, and whose chain code is the right half of [i]
+func newKey(i []byte, parent *secp256k1.ModNScalar) (*Key, error) {
+	k := &Key{}
+	if overflow := k.key.SetByteSlice(i[:32]); overflow {
+		return nil, errInvalidKey
+	}
+	if parent != nil {
+		k.key.Add(parent)
+	}
+	if k.key.IsZero() {
+		return nil, errInvalidKey
+	}
+	copy(k.chainCode[:], i[32:])
+	return k, nil
+}
+
+// Child returns the child key of [k] with index [index]. Indices of at least
+// [HardenedOffset] derive hardened keys.
+func (k *Key) Child(index uint32) (*Key, error) {
+	data := make([]byte, 0, 37)
+	if index >= HardenedOffset {
+		keyBytes := k.key.Bytes()
+		data = append(data, 0)
+		data = append(data, keyBytes[:]...)
+	} else {
+		data = append(data, secp256k1.NewPrivateKey(&k.key).PubKey().SerializeCompressed()...)
+	}
+	var indexBytes [4]byte
+	binary.BigEndian.PutUint32(indexBytes[:], index)
+	data = append(data, indexBytes[:]...)
+
+	mac := hmac.New(sha512.New, k.chainCode[:])
+	_, _ = mac.Write(data)
+	return newKey(mac.Sum(nil), &k.key)
+}
+
+// Derive returns the descendant of [k] at [path]
+func (k *Key) Derive(path []uint32) (*Key, error) {
+	key := k
+	for _, index := range path {
+		child, err := key.Child(index)
+		if err != nil {
+			return nil, err
+		}
+		key = child
+	}
+	return key, nil
+}
+
+// PrivateKey returns the private key of [k]
+func (k *Key) PrivateKey() *crypto.PrivateKeySECP256K1R {
+	keyBytes := k.key.Bytes()
+	factory := crypto.FactorySECP256K1R{}
+	sk, _ := factory.ToPrivateKey(keyBytes[:])
+	return sk.(*crypto.PrivateKeySECP256K1R)
+}
+
+// ParsePath parses a derivation path such as "m/44'/9000'/0'/0/1" into child
+// key indices
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, errInvalidPath
+	}
+
+	indices := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+		index, err := strconv.ParseUint(part, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %q in derivation path: %w", part, err)
+		}
+		if hardened {
+			index += uint64(HardenedOffset)
+		}
+		indices = append(indices, uint32(index))
+	}
+	return indices, nil
+}
@@ -0,0 +1,80 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bip32
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// Test vector 1 of BIP 32
+func TestDerive(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMaster(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path      string
+		key       string
+		chainCode string
+	}{
+		{
+			path:      "m",
+			key:       "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+			chainCode: "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508",
+		},
+		{
+			path:      "m/0'",
+			key:       "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+			chainCode: "47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141",
+		},
+		{
+			path:      "m/0'/1",
+			key:       "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+			chainCode: "2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19",
+		},
+		{
+			path:      "m/0h/1/2h",
+			key:       "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca",
+			chainCode: "04466b9cc8e161e966409ca52986c584f07e9dc81f735db683c3ff6ec7b1503f",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			path, err := ParsePath(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, err := master.Derive(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if keyHex := hex.EncodeToString(key.PrivateKey().Bytes()); keyHex != test.key {
+				t.Fatalf("expected key %s but got %s", test.key, keyHex)
+			}
+			if chainCodeHex := hex.EncodeToString(key.chainCode[:]); chainCodeHex != test.chainCode {
+				t.Fatalf("expected chain code %s but got %s", test.chainCode, chainCodeHex)
+			}
+		})
+	}
+}
+
+func TestParsePathInvalid(t *testing.T) {
+	for _, path := range []string{"", "44'/0", "m/", "m/x", "m/2147483648"} {
+		if _, err := ParsePath(path); err == nil {
+			t.Fatalf("should have failed to parse %q", path)
+		}
+	}
+}
+
+func TestNewMasterInvalidSeed(t *testing.T) {
+	if _, err := NewMaster(make([]byte, MinSeedLen-1)); err == nil {
+		t.Fatal("should have errored due to a short seed")
+	}
+	if _, err := NewMaster(make([]byte, MaxSeedLen+1)); err == nil {
+		t.Fatal("should have errored due to a long seed")
+	}
+}
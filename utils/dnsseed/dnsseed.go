@@ -0,0 +1,167 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package dnsseed discovers the peers a node first connects to from signed
+// DNS seed records, so that the infrastructure a network bootstraps from can
+// change without a new release.
+//
+// A seed domain has a TXT record of the form
+//
+//	v=1;exp=<unix time>;peers=<node ID>@<ip:port>,...;sig=<signature>
+//
+// e.g.
+//
+//	v=1;exp=1609459200;peers=NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET@1.2.3.4:9651;sig=...
+//
+// The signature is a CB58 encoded, recoverable secp256k1 signature of the
+// record up to ";sig=". A record is only used if it was signed by one of the
+// signers the node trusts, identified by the address of their public key, and
+// hasn't expired, so a stale record can't be replayed indefinitely.
+package dnsseed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+const (
+	version      = "1"
+	sigSeparator = ";sig="
+)
+
+var (
+	errMalformedRecord = errors.New("malformed seed record")
+	errUnknownVersion  = errors.New("unknown seed record version")
+	errExpiredRecord   = errors.New("seed record expired")
+	errUnknownSigner   = errors.New("seed record wasn't signed by a trusted signer")
+	errNoValidRecord   = errors.New("no valid seed record")
+
+	factory = crypto.FactorySECP256K1R{}
+)
+
+// Peer is a peer listed in a seed record
+type Peer struct {
+	ID ids.ShortID
+	IP utils.IPDesc
+}
+
+// FormatRecord returns a seed record that lists [peers], expires at [expiry]
+// and is signed by [key]
+func FormatRecord(peers []Peer, expiry time.Time, key crypto.PrivateKey) (string, error) {
+	peerStrs := make([]string, len(peers))
+	for i, peer := range peers {
+		peerStrs[i] = peer.ID.PrefixedString(constants.NodeIDPrefix) + "@" + peer.IP.String()
+	}
+	unsigned := fmt.Sprintf("v=%s;exp=%d;peers=%s", version, expiry.Unix(), strings.Join(peerStrs, ","))
+	sig, err := key.Sign([]byte(unsigned))
+	if err != nil {
+		return "", err
+	}
+	return unsigned + sigSeparator + formatting.CB58{Bytes: sig}.String(), nil
+}
+
+// ParseRecord returns the peers listed in [record], if it's valid at [now] and
+// was signed by one of [signers]
+func ParseRecord(record string, signers ids.ShortSet, now time.Time) ([]Peer, error) {
+	sigIndex := strings.LastIndex(record, sigSeparator)
+	if sigIndex < 0 {
+		return nil, errMalformedRecord
+	}
+	unsigned, sigStr := record[:sigIndex], record[sigIndex+len(sigSeparator):]
+
+	sig := formatting.CB58{}
+	if err := sig.FromString(sigStr); err != nil {
+		return nil, fmt.Errorf("couldn't parse signature: %w", err)
+	}
+	publicKey, err := factory.RecoverPublicKey([]byte(unsigned), sig.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't recover signer: %w", err)
+	}
+	if !signers.Contains(publicKey.Address()) {
+		return nil, errUnknownSigner
+	}
+
+	fields := strings.Split(unsigned, ";")
+	if len(fields) != 3 ||
+		!strings.HasPrefix(fields[0], "v=") ||
+		!strings.HasPrefix(fields[1], "exp=") ||
+		!strings.HasPrefix(fields[2], "peers=") {
+		return nil, errMalformedRecord
+	}
+	if fields[0][len("v="):] != version {
+		return nil, errUnknownVersion
+	}
+	expiry, err := strconv.ParseInt(fields[1][len("exp="):], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse expiry: %w", err)
+	}
+	if now.Unix() >= expiry {
+		return nil, errExpiredRecord
+	}
+
+	peers := []Peer(nil)
+	for _, peerStr := range strings.Split(fields[2][len("peers="):], ",") {
+		if peerStr == "" {
+			continue
+		}
+		atIndex := strings.Index(peerStr, "@")
+		if atIndex < 0 {
+			return nil, fmt.Errorf("%w: peer %q has no IP", errMalformedRecord, peerStr)
+		}
+		id, err := ids.ShortFromPrefixedString(peerStr[:atIndex], constants.NodeIDPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse node ID of peer %q: %w", peerStr, err)
+		}
+		ip, err := utils.ToIPDesc(peerStr[atIndex+1:])
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse IP of peer %q: %w", peerStr, err)
+		}
+		peers = append(peers, Peer{
+			ID: id,
+			IP: ip,
+		})
+	}
+	return peers, nil
+}
+
+// Resolve returns the peers listed in the valid seed records of [domain].
+// Records that are invalid, such as those not signed by one of [signers], are
+// skipped.
+func Resolve(ctx context.Context, resolver *net.Resolver, domain string, signers ids.ShortSet) ([]Peer, error) {
+	records, err := resolver.LookupTXT(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't look up seed records of %s: %w", domain, err)
+	}
+
+	now := time.Now()
+	peers := []Peer(nil)
+	lastErr := errNoValidRecord
+	valid := false
+	for _, record := range records {
+		if !strings.HasPrefix(record, "v=") {
+			continue // Not a seed record
+		}
+		recordPeers, err := ParseRecord(record, signers, now)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		valid = true
+		peers = append(peers, recordPeers...)
+	}
+	if !valid {
+		return nil, fmt.Errorf("%s has no valid seed record: %w", domain, lastErr)
+	}
+	return peers, nil
+}
@@ -0,0 +1,67 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dnsseed
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+func TestRecord(t *testing.T) {
+	key, err := factory.NewPrivateKey()
+	assert.NoError(t, err)
+	otherKey, err := factory.NewPrivateKey()
+	assert.NoError(t, err)
+
+	peers := []Peer{
+		{
+			ID: ids.NewShortID([20]byte{1}),
+			IP: utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651},
+		},
+		{
+			ID: ids.NewShortID([20]byte{2}),
+			IP: utils.IPDesc{IP: net.ParseIP("2001:db8::1"), Port: 9651},
+		},
+	}
+	now := time.Unix(1000, 0)
+	record, err := FormatRecord(peers, now.Add(time.Hour), key)
+	assert.NoError(t, err)
+
+	signers := ids.ShortSet{}
+	signers.Add(key.PublicKey().Address())
+
+	parsedPeers, err := ParseRecord(record, signers, now)
+	assert.NoError(t, err)
+	assert.Len(t, parsedPeers, len(peers))
+	for i, peer := range peers {
+		assert.True(t, peer.ID.Equals(parsedPeers[i].ID))
+		assert.True(t, peer.IP.Equal(parsedPeers[i].IP))
+	}
+
+	// Records signed by keys that aren't trusted are rejected
+	otherSigners := ids.ShortSet{}
+	otherSigners.Add(otherKey.PublicKey().Address())
+	_, err = ParseRecord(record, otherSigners, now)
+	assert.True(t, errors.Is(err, errUnknownSigner))
+
+	// Records that were changed after they were signed are rejected
+	tampered := strings.Replace(record, "1.2.3.4", "5.6.7.8", 1)
+	_, err = ParseRecord(tampered, signers, now)
+	assert.Error(t, err)
+
+	// Expired records are rejected
+	_, err = ParseRecord(record, signers, now.Add(time.Hour))
+	assert.True(t, errors.Is(err, errExpiredRecord))
+
+	_, err = ParseRecord("v=1;exp=0;peers=", signers, now)
+	assert.True(t, errors.Is(err, errMalformedRecord))
+}
@@ -15,7 +15,8 @@ import (
 )
 
 var (
-	errOpenDNSNoIP = errors.New("opendns returned no ip")
+	errOpenDNSNoIP   = errors.New("opendns returned no ip")
+	errOpenDNSNoIPv6 = errors.New("opendns returned no ipv6 address")
 )
 
 // Resolver resolves our public IP
@@ -40,18 +41,36 @@ func (r *NoResolver) Resolve() (net.IP, error) {
 // IFConfigResolves resolves our public IP using openDNS
 type OpenDNSResolver struct {
 	*net.Resolver
+	// If true, resolves our public IPv6 address. Otherwise, resolves our
+	// public IPv4 address if we have one.
+	IPv6 bool
 }
 
 func NewOpenDNSResolver() *OpenDNSResolver {
-	return &OpenDNSResolver{&net.Resolver{
-		PreferGo: true,
-		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
-			d := net.Dialer{
-				Timeout: 10 * time.Second,
-			}
-			return d.DialContext(ctx, "udp", "resolver1.opendns.com:53")
+	return newOpenDNSResolver("udp", false)
+}
+
+// NewOpenDNS6Resolver returns a resolver that resolves our public IPv6 address
+// using openDNS
+func NewOpenDNS6Resolver() *OpenDNSResolver {
+	// openDNS reports the address it was queried from, so it must be queried
+	// over IPv6
+	return newOpenDNSResolver("udp6", true)
+}
+
+func newOpenDNSResolver(network string, ipv6 bool) *OpenDNSResolver {
+	return &OpenDNSResolver{
+		Resolver: &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, _, address string) (net.Conn, error) {
+				d := net.Dialer{
+					Timeout: 10 * time.Second,
+				}
+				return d.DialContext(ctx, network, "resolver1.opendns.com:53")
+			},
 		},
-	}}
+		IPv6: ipv6,
+	}
 }
 
 func (r *OpenDNSResolver) IsResolver() bool {
@@ -68,10 +87,13 @@ func (r *OpenDNSResolver) Resolve() (net.IP, error) {
 	}
 	for _, ipv := range ip {
 		ipResolved := net.ParseIP(ipv)
-		if ipResolved != nil && strings.Contains(ipv, ".") {
+		if ipResolved != nil && strings.Contains(ipv, ".") != r.IPv6 {
 			return ipResolved, nil
 		}
 	}
+	if r.IPv6 {
+		return nil, errOpenDNSNoIPv6
+	}
 	ipResolved := net.ParseIP(ip[0])
 	if ipResolved == nil {
 		return nil, fmt.Errorf("invalid ip %s", ip[0])
@@ -80,7 +102,12 @@ func (r *OpenDNSResolver) Resolve() (net.IP, error) {
 }
 
 // IFConfigResolves resolves our public IP using website ifconfig.co
-type IFConfigResolver struct{}
+type IFConfigResolver struct {
+	// Network ifconfig.co is connected to over, e.g. tcp4 or tcp6. ifconfig.co
+	// reports the address it was connected to from, so this determines whether
+	// our IPv4 or IPv6 address is resolved. If empty, either may be.
+	Network string
+}
 
 func (r *IFConfigResolver) IsResolver() bool {
 	return true
@@ -88,7 +115,20 @@ func (r *IFConfigResolver) IsResolver() bool {
 
 func (r *IFConfigResolver) Resolve() (net.IP, error) {
 	url := "http://ifconfig.co"
-	resp, err := http.Get(url)
+	client := http.DefaultClient
+	if r.Network != "" {
+		d := net.Dialer{
+			Timeout: 10 * time.Second,
+		}
+		client = &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, address string) (net.Conn, error) {
+					return d.DialContext(ctx, r.Network, address)
+				},
+			},
+		}
+	}
+	resp, err := client.Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -112,8 +152,12 @@ func NewResolver(opt string) Resolver {
 	switch opt {
 	case "opendns":
 		return NewOpenDNSResolver()
+	case "opendns6":
+		return NewOpenDNS6Resolver()
 	case "ifconfig":
 		return &IFConfigResolver{}
+	case "ifconfig6":
+		return &IFConfigResolver{Network: "tcp6"}
 	default:
 		return &NoResolver{}
 	}
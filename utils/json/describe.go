@@ -0,0 +1,282 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"encoding"
+	stdjson "encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gorilla/rpc/v2"
+)
+
+const definitionRefPrefix = "#/definitions/"
+
+var (
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+	requestType       = reflect.TypeOf((*http.Request)(nil))
+	marshalerType     = reflect.TypeOf((*stdjson.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	rawMessageType    = reflect.TypeOf(stdjson.RawMessage(nil))
+)
+
+// TypeDescription describes the JSON a Go type is marshalled to, as a subset
+// of JSON Schema. Named structs are described once, in the definitions of an
+// APIDescription, and referenced by [Ref].
+type TypeDescription struct {
+	// Ref is a reference to the definition of the type, if it's a named struct
+	Ref string `json:"$ref,omitempty"`
+	// Type is the JSON type, i.e. object, array, string, integer, number or
+	// boolean. If it's empty, any JSON value is allowed.
+	Type string `json:"type,omitempty"`
+	// GoType is the Go type, e.g. json.Uint64
+	GoType string `json:"goType,omitempty"`
+	// Properties of an object, by key
+	Properties map[string]*TypeDescription `json:"properties,omitempty"`
+	// Items of an array
+	Items *TypeDescription `json:"items,omitempty"`
+	// AdditionalProperties are the values of an object with arbitrary keys
+	AdditionalProperties *TypeDescription `json:"additionalProperties,omitempty"`
+}
+
+// MethodDescription describes a JSON-RPC method
+type MethodDescription struct {
+	// Name of the method, e.g. avm.getBalance
+	Name string `json:"name"`
+	// Params of the method
+	Params *TypeDescription `json:"params"`
+	// Result of the method
+	Result *TypeDescription `json:"result"`
+}
+
+// ServiceDescription describes a JSON-RPC service
+type ServiceDescription struct {
+	// Name of the service, e.g. avm
+	Name string `json:"name"`
+	// Methods of the service, sorted by name
+	Methods []MethodDescription `json:"methods"`
+}
+
+// APIDescription describes the services registered with a Server, and the
+// named structs their params and results reference, by the key they're
+// referenced by, e.g. github.com/ava-labs/avalanchego/vms/avm.GetBalanceArgs
+type APIDescription struct {
+	Services    []ServiceDescription        `json:"services"`
+	Definitions map[string]*TypeDescription `json:"definitions"`
+}
+
+// Describer is a handler that can describe the API it serves
+type Describer interface {
+	Describe() APIDescription
+}
+
+// Server is a gorilla RPC server that describes the services registered with
+// it, so that clients can be generated from its description
+type Server struct {
+	*rpc.Server
+
+	lock        sync.Mutex
+	services    []ServiceDescription
+	definitions map[string]*TypeDescription
+}
+
+// NewServer returns a new server. Codecs should be registered with it as with
+// a gorilla RPC server.
+func NewServer() *Server {
+	return &Server{
+		Server:      rpc.NewServer(),
+		definitions: make(map[string]*TypeDescription),
+	}
+}
+
+// RegisterService registers and describes [receiver] as the service [name]
+func (s *Server) RegisterService(receiver interface{}, name string) error {
+	if err := s.Server.RegisterService(receiver, name); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.services = append(s.services, describeService(receiver, name, s.definitions))
+	return nil
+}
+
+// Describe returns the description of the services registered with [s]
+func (s *Server) Describe() APIDescription {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	description := APIDescription{
+		Services:    make([]ServiceDescription, len(s.services)),
+		Definitions: make(map[string]*TypeDescription, len(s.definitions)),
+	}
+	copy(description.Services, s.services)
+	for key, definition := range s.definitions {
+		description.Definitions[key] = definition
+	}
+	return description
+}
+
+// describeService describes the methods of [receiver] that gorilla RPC serves,
+// adding the named structs they reference to [definitions]. Like the codec,
+// the first letter of each method's name is lowercased.
+func describeService(receiver interface{}, name string, definitions map[string]*TypeDescription) ServiceDescription {
+	receiverType := reflect.TypeOf(receiver)
+	service := ServiceDescription{
+		Name:    name,
+		Methods: []MethodDescription{},
+	}
+	for i := 0; i < receiverType.NumMethod(); i++ {
+		method := receiverType.Method(i)
+		mType := method.Type
+		// Methods gorilla RPC serves have the signature
+		// func (*http.Request, *args, *reply) error
+		// where args and reply are exported or builtin
+		if method.PkgPath != "" ||
+			mType.NumIn() != 4 || mType.In(1) != requestType ||
+			!isExportedPtr(mType.In(2)) || !isExportedPtr(mType.In(3)) ||
+			mType.NumOut() != 1 || mType.Out(0) != errorType {
+			continue
+		}
+		firstRune, runeLen := utf8.DecodeRuneInString(method.Name)
+		service.Methods = append(service.Methods, MethodDescription{
+			Name:   name + "." + string(unicode.ToLower(firstRune)) + method.Name[runeLen:],
+			Params: describeType(mType.In(2), definitions),
+			Result: describeType(mType.In(3), definitions),
+		})
+	}
+	sort.Slice(service.Methods, func(i, j int) bool {
+		return service.Methods[i].Name < service.Methods[j].Name
+	})
+	return service
+}
+
+// isExportedPtr returns true if [t] is a pointer to an exported or builtin type
+func isExportedPtr(t reflect.Type) bool {
+	if t.Kind() != reflect.Ptr {
+		return false
+	}
+	t = t.Elem()
+	firstRune, _ := utf8.DecodeRuneInString(t.Name())
+	return t.PkgPath() == "" || unicode.IsUpper(firstRune)
+}
+
+// describeType describes the JSON [t] is marshalled to, adding the named
+// structs it references to [definitions]
+func describeType(t reflect.Type, definitions map[string]*TypeDescription) *TypeDescription {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	description := &TypeDescription{GoType: t.String()}
+
+	switch {
+	case t == rawMessageType:
+		return description
+	case t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType),
+		t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType):
+		// The types the node's APIs marshal themselves, such as IDs and
+		// json.Uint64, are marshalled to strings
+		description.Type = "string"
+		return description
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		description.Type = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		description.Type = "integer"
+	case reflect.Float32, reflect.Float64:
+		description.Type = "number"
+	case reflect.String:
+		description.Type = "string"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			// Byte slices are marshalled to base64 strings
+			description.Type = "string"
+			break
+		}
+		description.Type = "array"
+		description.Items = describeType(t.Elem(), definitions)
+	case reflect.Map:
+		description.Type = "object"
+		description.AdditionalProperties = describeType(t.Elem(), definitions)
+	case reflect.Struct:
+		if t.Name() == "" {
+			description.Type = "object"
+			description.Properties = describeFields(t, definitions)
+			break
+		}
+		key := t.PkgPath() + "." + t.Name()
+		if _, ok := definitions[key]; !ok {
+			definition := &TypeDescription{
+				Type:   "object",
+				GoType: t.String(),
+			}
+			// Added before its fields are described, in case the struct
+			// references itself
+			definitions[key] = definition
+			definition.Properties = describeFields(t, definitions)
+		}
+		return &TypeDescription{Ref: definitionRefPrefix + key}
+	}
+	return description
+}
+
+// describeFields describes the properties the fields of the struct [t] are
+// marshalled to, as encoding/json marshals them
+func describeFields(t reflect.Type, definitions map[string]*TypeDescription) map[string]*TypeDescription {
+	properties := make(map[string]*TypeDescription)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, options = tag[:i], tag[i+1:]
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			// The fields of embedded structs are promoted
+			embedded := describeFields(fieldType, definitions)
+			for embeddedName, property := range embedded {
+				if _, ok := properties[embeddedName]; !ok {
+					properties[embeddedName] = property
+				}
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue // Unexported
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		property := describeType(field.Type, definitions)
+		for _, option := range strings.Split(options, ",") {
+			if option == "string" {
+				property = &TypeDescription{
+					Type:   "string",
+					GoType: property.GoType,
+				}
+			}
+		}
+		properties[name] = property
+	}
+	return properties
+}
@@ -0,0 +1,108 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type DescribedEmbedded struct {
+	Address string `json:"address"`
+}
+
+type DescribedArgs struct {
+	DescribedEmbedded
+	Amount     Uint64         `json:"amount"`
+	Bytes      []byte         `json:"bytes"`
+	Counts     map[string]int `json:"counts"`
+	Next       *DescribedArgs `json:"next"`
+	Ignored    bool           `json:"-"`
+	Quoted     int            `json:"quoted,string"`
+	Values     []float64      `json:"values"`
+	Any        interface{}    `json:"any"`
+	unexported bool
+}
+
+type DescribedReply struct {
+	Success bool `json:"success"`
+}
+
+type describedService struct{}
+
+func (s *describedService) Send(_ *http.Request, _ *DescribedArgs, _ *DescribedReply) error {
+	return nil
+}
+
+func (s *describedService) Ping(_ *http.Request, _ *struct{}, _ *DescribedReply) error {
+	return nil
+}
+
+func (s *describedService) NotServed(_ *DescribedArgs) error { return nil }
+
+func TestServerDescribe(t *testing.T) {
+	server := NewServer()
+	server.RegisterCodec(NewCodec(), "application/json")
+	if err := server.RegisterService(&describedService{}, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	description := server.Describe()
+	if len(description.Services) != 1 {
+		t.Fatalf("expected 1 service but got %d", len(description.Services))
+	}
+	service := description.Services[0]
+	if service.Name != "test" {
+		t.Fatalf("unexpected service name %s", service.Name)
+	}
+	if len(service.Methods) != 2 {
+		t.Fatalf("expected 2 methods but got %d", len(service.Methods))
+	}
+	ping, send := service.Methods[0], service.Methods[1]
+	if ping.Name != "test.ping" || send.Name != "test.send" {
+		t.Fatalf("unexpected methods %s and %s", ping.Name, send.Name)
+	}
+	if ping.Params.Type != "object" || len(ping.Params.Properties) != 0 {
+		t.Fatalf("unexpected params %+v", ping.Params)
+	}
+
+	argsKey := "github.com/ava-labs/avalanchego/utils/json.DescribedArgs"
+	replyKey := "github.com/ava-labs/avalanchego/utils/json.DescribedReply"
+	if send.Params.Ref != definitionRefPrefix+argsKey {
+		t.Fatalf("unexpected params reference %s", send.Params.Ref)
+	}
+	if send.Result.Ref != definitionRefPrefix+replyKey {
+		t.Fatalf("unexpected result reference %s", send.Result.Ref)
+	}
+	if len(description.Definitions) != 2 {
+		t.Fatalf("expected 2 definitions but got %d", len(description.Definitions))
+	}
+
+	args := description.Definitions[argsKey]
+	expected := map[string]*TypeDescription{
+		"address": {Type: "string", GoType: "string"},
+		"amount":  {Type: "string", GoType: "json.Uint64"},
+		"bytes":   {Type: "string", GoType: "[]uint8"},
+		"counts": {
+			Type:                 "object",
+			GoType:               "map[string]int",
+			AdditionalProperties: &TypeDescription{Type: "integer", GoType: "int"},
+		},
+		"next":   {Ref: definitionRefPrefix + argsKey},
+		"quoted": {Type: "string", GoType: "int"},
+		"values": {
+			Type:   "array",
+			GoType: "[]float64",
+			Items:  &TypeDescription{Type: "number", GoType: "float64"},
+		},
+		"any": {GoType: "interface {}"},
+	}
+	if !reflect.DeepEqual(args.Properties, expected) {
+		for name, property := range args.Properties {
+			t.Logf("%s: %+v", name, property)
+		}
+		t.Fatal("unexpected properties")
+	}
+}
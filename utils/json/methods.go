@@ -0,0 +1,58 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// MaxRequestSize is the maximum size, in bytes, of the body of an API request
+const MaxRequestSize = 1 << 24 // 16 MiB
+
+var errRequestTooLarge = fmt.Errorf("request body is larger than %d bytes", MaxRequestSize)
+
+// GetMethods returns the JSON-RPC methods called by [r], which may be a single
+// call or a batch of calls. The body of [r] is left unread, so [r] can still
+// be served afterwards. At most MaxRequestSize bytes of the body are read.
+func GetMethods(r *http.Request) ([]string, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, MaxRequestSize+1))
+	// The part of the body that was read is put back, even if the body is too
+	// large, so that serving [r] fails the way it would have without this call
+	r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > MaxRequestSize {
+		return nil, errRequestTooLarge
+	}
+
+	type call struct {
+		Method string `json:"method"`
+	}
+	body = bytes.TrimSpace(body)
+	var calls []call
+	if len(body) > 0 && body[0] == '[' {
+		err = json.Unmarshal(body, &calls)
+	} else {
+		calls = make([]call, 1)
+		err = json.Unmarshal(body, &calls[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	methods := make([]string, len(calls))
+	for i, call := range calls {
+		methods[i] = call.Method
+	}
+	return methods, nil
+}
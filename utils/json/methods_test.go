@@ -0,0 +1,64 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetMethods(t *testing.T) {
+	tests := []struct {
+		body    string
+		methods []string
+	}{
+		{`{"jsonrpc":"2.0","method":"info.getNodeID","id":1}`, []string{"info.getNodeID"}},
+		{` [{"method":"info.getNodeID"},{"method":"info.peers"}]`, []string{"info.getNodeID", "info.peers"}},
+		{`{"jsonrpc":"2.0","id":1}`, []string{""}},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(test.body))
+		methods, err := GetMethods(r)
+		if err != nil {
+			t.Fatalf("GetMethods(%s) failed with %s", test.body, err)
+		}
+		if len(methods) != len(test.methods) {
+			t.Fatalf("expected methods %v but got %v", test.methods, methods)
+		}
+		for i, method := range methods {
+			if method != test.methods[i] {
+				t.Fatalf("expected methods %v but got %v", test.methods, methods)
+			}
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != test.body {
+			t.Fatalf("body should have been left unread")
+		}
+	}
+}
+
+func TestGetMethodsTooLarge(t *testing.T) {
+	body := append([]byte(`{"method":"info.peers","params":"`), bytes.Repeat([]byte{'a'}, MaxRequestSize)...)
+	body = append(body, `"}`...)
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+
+	if _, err := GetMethods(r); err != errRequestTooLarge {
+		t.Fatalf("expected %s but got %v", errRequestTooLarge, err)
+	}
+
+	read, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(read, body) {
+		t.Fatalf("body should have been left unread")
+	}
+}
@@ -3,13 +3,36 @@
 
 package logging
 
-import "path/filepath"
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+const (
+	// MainLoggerName is the name of the logger returned by Factory.Make
+	MainLoggerName = "main"
+)
 
 // Factory ...
 type Factory interface {
 	Make() (Logger, error)
 	MakeChain(chainID string, subdir string) (Logger, error)
 	MakeSubdir(subdir string) (Logger, error)
+
+	// SetLogLevel sets the log level of the loggers named [name]
+	SetLogLevel(name string, level Level) error
+	// SetDisplayLevel sets the display level of the loggers named [name]
+	SetDisplayLevel(name string, level Level) error
+	// GetLogLevel returns the log level of the logger named [name]
+	GetLogLevel(name string) (Level, error)
+	// GetDisplayLevel returns the display level of the logger named [name]
+	GetDisplayLevel(name string) (Level, error)
+	// GetLoggerNames returns the names, sorted, of the loggers this factory
+	// made
+	GetLoggerNames() []string
+
 	Close()
 }
 
@@ -17,54 +40,137 @@ type Factory interface {
 type factory struct {
 	config Config
 
-	loggers []Logger
+	lock sync.RWMutex
+	// Key: The name of a logger
+	// Value: The loggers made with that name
+	loggers map[string][]Logger
 }
 
 // NewFactory ...
 func NewFactory(config Config) Factory {
 	return &factory{
-		config: config,
+		config:  config,
+		loggers: make(map[string][]Logger),
 	}
 }
 
-// Make ...
+// Make returns the logger named [MainLoggerName]
 func (f *factory) Make() (Logger, error) {
-	l, err := New(f.config)
-	if err == nil {
-		f.loggers = append(f.loggers, l)
-	}
-	return l, err
+	return f.make(MainLoggerName, f.config)
 }
 
-// MakeChain ...
+// MakeChain returns a logger named [chainID], or [chainID].[subdir] if
+// [subdir] is non-empty
 func (f *factory) MakeChain(chainID string, subdir string) (Logger, error) {
 	config := f.config
 	config.MsgPrefix = chainID + " Chain"
 	config.Directory = filepath.Join(config.Directory, "chain", chainID, subdir)
 
-	log, err := New(config)
-	if err == nil {
-		f.loggers = append(f.loggers, log)
+	name := chainID
+	if subdir != "" {
+		name = fmt.Sprintf("%s.%s", chainID, subdir)
 	}
-	return log, err
+	return f.make(name, config)
 }
 
-// MakeSubdir ...
+// MakeSubdir returns a logger named [subdir]
 func (f *factory) MakeSubdir(subdir string) (Logger, error) {
 	config := f.config
 	config.Directory = filepath.Join(config.Directory, subdir)
 
+	return f.make(subdir, config)
+}
+
+// make a logger named [name] with [config]
+func (f *factory) make(name string, config Config) (Logger, error) {
 	log, err := New(config)
-	if err == nil {
-		f.loggers = append(f.loggers, log)
+	if err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.loggers[name] = append(f.loggers[name], log)
+	return log, nil
+}
+
+// SetLogLevel ...
+func (f *factory) SetLogLevel(name string, level Level) error {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	loggers, ok := f.loggers[name]
+	if !ok {
+		return fmt.Errorf("unknown logger %q", name)
+	}
+	for _, log := range loggers {
+		log.SetLogLevel(level)
+	}
+	return nil
+}
+
+// SetDisplayLevel ...
+func (f *factory) SetDisplayLevel(name string, level Level) error {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	loggers, ok := f.loggers[name]
+	if !ok {
+		return fmt.Errorf("unknown logger %q", name)
+	}
+	for _, log := range loggers {
+		log.SetDisplayLevel(level)
+	}
+	return nil
+}
+
+// GetLogLevel ...
+func (f *factory) GetLogLevel(name string) (Level, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	loggers, ok := f.loggers[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown logger %q", name)
+	}
+	return loggers[0].GetLogLevel(), nil
+}
+
+// GetDisplayLevel ...
+func (f *factory) GetDisplayLevel(name string) (Level, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	loggers, ok := f.loggers[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown logger %q", name)
+	}
+	return loggers[0].GetDisplayLevel(), nil
+}
+
+// GetLoggerNames ...
+func (f *factory) GetLoggerNames() []string {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	names := make([]string, 0, len(f.loggers))
+	for name := range f.loggers {
+		names = append(names, name)
 	}
-	return log, err
+	sort.Strings(names)
+	return names
 }
 
 // Close ...
 func (f *factory) Close() {
-	for _, log := range f.loggers {
-		log.Stop()
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, loggers := range f.loggers {
+		for _, log := range loggers {
+			log.Stop()
+		}
 	}
-	f.loggers = nil
+	f.loggers = make(map[string][]Logger)
 }
@@ -0,0 +1,66 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package logging
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestFactoryLevels(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config, err := DefaultConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Directory = dir
+	config.LogLevel = Info
+	config.DisplayLevel = Warn
+
+	factory := NewFactory(config)
+	defer factory.Close()
+
+	if _, err := factory.Make(); err != nil {
+		t.Fatal(err)
+	}
+	chainLog, err := factory.MakeChain("X", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := factory.MakeChain("X", "http"); err != nil {
+		t.Fatal(err)
+	}
+
+	names := factory.GetLoggerNames()
+	if len(names) != 3 || names[0] != "X" || names[1] != "X.http" || names[2] != MainLoggerName {
+		t.Fatalf("wrong logger names %v", names)
+	}
+
+	if err := factory.SetLogLevel("X", Debug); err != nil {
+		t.Fatal(err)
+	}
+	if level := chainLog.GetLogLevel(); level != Debug {
+		t.Fatalf("expected log level %s but got %s", Debug, level)
+	}
+	if level, err := factory.GetLogLevel(MainLoggerName); err != nil || level != Info {
+		t.Fatalf("main log level shouldn't have changed, got %s, %v", level, err)
+	}
+
+	if err := factory.SetDisplayLevel("X.http", Verbo); err != nil {
+		t.Fatal(err)
+	}
+	if level, err := factory.GetDisplayLevel("X.http"); err != nil || level != Verbo {
+		t.Fatalf("expected display level %s but got %s, %v", Verbo, level, err)
+	}
+
+	if err := factory.SetLogLevel("P", Debug); err == nil {
+		t.Fatal("should have errored due to an unknown logger")
+	}
+}
@@ -82,3 +82,25 @@ func (l Level) String() string {
 		return "?????"
 	}
 }
+
+// Name returns the unpadded name of this level, as accepted by ToLevel
+func (l Level) Name() string {
+	switch l {
+	case Off:
+		return "OFF"
+	case Fatal:
+		return "FATAL"
+	case Error:
+		return "ERROR"
+	case Warn:
+		return "WARN"
+	case Info:
+		return "INFO"
+	case Debug:
+		return "DEBUG"
+	case Verbo:
+		return "VERBO"
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", int(l))
+	}
+}
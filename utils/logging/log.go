@@ -282,6 +282,14 @@ func (l *Log) SetLogLevel(lvl Level) {
 	l.config.LogLevel = lvl
 }
 
+// GetLogLevel ...
+func (l *Log) GetLogLevel() Level {
+	l.configLock.Lock()
+	defer l.configLock.Unlock()
+
+	return l.config.LogLevel
+}
+
 // SetDisplayLevel ...
 func (l *Log) SetDisplayLevel(lvl Level) {
 	l.configLock.Lock()
@@ -290,6 +298,14 @@ func (l *Log) SetDisplayLevel(lvl Level) {
 	l.config.DisplayLevel = lvl
 }
 
+// GetDisplayLevel ...
+func (l *Log) GetDisplayLevel() Level {
+	l.configLock.Lock()
+	defer l.configLock.Unlock()
+
+	return l.config.DisplayLevel
+}
+
 // SetPrefix ...
 func (l *Log) SetPrefix(prefix string) {
 	l.configLock.Lock()
@@ -54,7 +54,9 @@ type Logger interface {
 	RecoverAndExit(f, exit func())
 
 	SetLogLevel(Level)
+	GetLogLevel() Level
 	SetDisplayLevel(Level)
+	GetDisplayLevel() Level
 	SetPrefix(string)
 	SetLoggingEnabled(bool)
 	SetDisplayingEnabled(bool)
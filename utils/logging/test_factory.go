@@ -15,5 +15,20 @@ func (NoFactory) MakeChain(string, string) (Logger, error) { return NoLog{}, nil
 // MakeSubdir ...
 func (NoFactory) MakeSubdir(string) (Logger, error) { return NoLog{}, nil }
 
+// SetLogLevel ...
+func (NoFactory) SetLogLevel(string, Level) error { return nil }
+
+// SetDisplayLevel ...
+func (NoFactory) SetDisplayLevel(string, Level) error { return nil }
+
+// GetLogLevel ...
+func (NoFactory) GetLogLevel(string) (Level, error) { return Off, nil }
+
+// GetDisplayLevel ...
+func (NoFactory) GetDisplayLevel(string) (Level, error) { return Off, nil }
+
+// GetLoggerNames ...
+func (NoFactory) GetLoggerNames() []string { return nil }
+
 // Close ...
 func (NoFactory) Close() {}
@@ -62,9 +62,15 @@ func (NoLog) Stop() {}
 // SetLogLevel ...
 func (NoLog) SetLogLevel(Level) {}
 
+// GetLogLevel ...
+func (NoLog) GetLogLevel() Level { return Off }
+
 // SetDisplayLevel ...
 func (NoLog) SetDisplayLevel(Level) {}
 
+// GetDisplayLevel ...
+func (NoLog) GetDisplayLevel() Level { return Off }
+
 // SetPrefix ...
 func (NoLog) SetPrefix(string) {}
 
@@ -0,0 +1,126 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gorilla/rpc/v2/json2"
+)
+
+var errMissingResponse = errors.New("no response to call in batch")
+
+// BatchCall is a JSON-RPC call sent in a batch
+type BatchCall struct {
+	// Method called. Sent through an EndpointRequester, it's prefixed with
+	// the requester's service.
+	Method string
+	Params interface{}
+	// Reply the result of the call is unmarshalled into
+	Reply interface{}
+	// Err is set to the error returned by the call, if any
+	Err error
+}
+
+// NewBatchCall returns a call to [method] with [params] whose result is
+// unmarshalled into [reply]
+func NewBatchCall(method string, params, reply interface{}) *BatchCall {
+	return &BatchCall{
+		Method: method,
+		Params: params,
+		Reply:  reply,
+	}
+}
+
+// FirstErr returns the first error returned by a call in [calls], if any
+func FirstErr(calls []*BatchCall) error {
+	for _, call := range calls {
+		if call.Err != nil {
+			return fmt.Errorf("%s failed: %w", call.Method, call.Err)
+		}
+	}
+	return nil
+}
+
+type batchRequest struct {
+	Version string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+	ID      uint64      `json:"id"`
+}
+
+type batchResponse struct {
+	Result *json.RawMessage `json:"result"`
+	Error  *json.RawMessage `json:"error"`
+	ID     *uint64          `json:"id"`
+}
+
+// SendBatch sends [calls] to [url] in a single JSON-RPC batch and sets the
+// reply or error of each call. The batch is retried if it fails transiently.
+// Returns an error if the batch couldn't be sent, rather than if a call failed.
+func (requester *jsonRPCRequester) SendBatch(url *url.URL, calls []*BatchCall) error {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	requests := make([]batchRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = batchRequest{
+			Version: "2.0",
+			Method:  call.Method,
+			Params:  call.Params,
+			ID:      uint64(i),
+		}
+	}
+	requestBodyBytes, err := json.Marshal(requests)
+	if err != nil {
+		return fmt.Errorf("problem marshaling batch request: %w", err)
+	}
+
+	methods := make([]string, len(calls))
+	for i, call := range calls {
+		methods[i] = call.Method
+	}
+	responses := []batchResponse(nil)
+	if err := requester.retry.retry(context.Background(), func(attempt int) error {
+		return requester.sendRequest(context.Background(), url, methods, attempt, requestBodyBytes, &responses, decodeJSON)
+	}); err != nil {
+		return err
+	}
+
+	for _, call := range calls {
+		call.Err = errMissingResponse
+	}
+	for _, response := range responses {
+		if response.ID == nil || *response.ID >= uint64(len(calls)) {
+			continue
+		}
+		call := calls[*response.ID]
+		call.Err = decodeBatchResponse(response, call.Reply)
+	}
+	return nil
+}
+
+// decodeBatchResponse unmarshals the result of [response] into [reply], or
+// returns the error it reports
+func decodeBatchResponse(response batchResponse, reply interface{}) error {
+	if response.Error != nil {
+		jsonErr := &json2.Error{}
+		if err := json.Unmarshal(*response.Error, jsonErr); err != nil {
+			return &json2.Error{
+				Code:    json2.E_SERVER,
+				Message: string(*response.Error),
+			}
+		}
+		return jsonErr
+	}
+	if response.Result == nil {
+		return json2.ErrNullResult
+	}
+	return json.Unmarshal(*response.Result, reply)
+}
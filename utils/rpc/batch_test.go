@@ -0,0 +1,48 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSendBatchDemultiplexes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Responses are out of order, and the third call has no response
+		_, _ = w.Write([]byte(`[
+			{"jsonrpc":"2.0","error":{"code":-32000,"message":"failed"},"id":1},
+			{"jsonrpc":"2.0","result":"first","id":0}
+		]`))
+	}))
+	defer server.Close()
+
+	requester := NewEndpointRequester(server.URL, "/ext/test", "test", time.Second)
+	replies := make([]string, 3)
+	calls := []*BatchCall{
+		NewBatchCall("a", struct{}{}, &replies[0]),
+		NewBatchCall("b", struct{}{}, &replies[1]),
+		NewBatchCall("c", struct{}{}, &replies[2]),
+	}
+	if err := requester.SendBatch(calls); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case calls[0].Err != nil:
+		t.Fatal(calls[0].Err)
+	case replies[0] != "first":
+		t.Fatalf("expected %q but got %q", "first", replies[0])
+	case calls[1].Err == nil || calls[1].Err.Error() != "failed":
+		t.Fatalf("expected call to fail but got %v", calls[1].Err)
+	case calls[2].Err != errMissingResponse:
+		t.Fatalf("expected %s but got %v", errMissingResponse, calls[2].Err)
+	case calls[0].Method != "a":
+		t.Fatalf("method shouldn't have been modified but is %s", calls[0].Method)
+	}
+	if err := FirstErr(calls); err == nil {
+		t.Fatal("should have returned the first call's error")
+	}
+}
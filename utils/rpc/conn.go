@@ -0,0 +1,334 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/rpc/v2/json2"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// connWriteWait is the time allowed to write a message to the connection
+	connWriteWait = 10 * time.Second
+	// connHandshakeTimeout is the time allowed to open a connection
+	connHandshakeTimeout = 10 * time.Second
+)
+
+var errConnClosed = errors.New("connection closed")
+
+// NotificationHandler is called with the params of each notification of the
+// method it handles
+type NotificationHandler func(params json.RawMessage)
+
+// CallHandler is called with the params of each call of the method it
+// handles. It returns the call's result, or the error the call failed with.
+type CallHandler func(params json.RawMessage) (interface{}, error)
+
+// connMessage is a JSON-RPC request, notification or response. Notifications
+// are requests without an ID.
+type connMessage struct {
+	Version string          `json:"jsonrpc"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *json2.Error    `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+// Conn is a persistent JSON-RPC 2.0 connection over a WebSocket. Either end
+// may send the other calls, which are responded to, and notifications, which
+// aren't. Servers use notifications to push events, such as accepted
+// transactions, to clients, which typically subscribe to them with a call.
+//
+// Notifications are dispatched to their handlers one at a time, in the order
+// they're received, so handlers shouldn't block. Calls are handled
+// concurrently.
+type Conn struct {
+	ws *websocket.Conn
+
+	handlersLock         sync.RWMutex
+	notificationHandlers map[string]NotificationHandler
+	callHandlers         map[string]CallHandler
+	// Called with notifications of methods without a handler, if non-nil
+	defaultHandler func(method string, params json.RawMessage)
+
+	writeLock sync.Mutex
+
+	lock sync.Mutex
+	// The ID of the next call this end sends
+	nextID uint64
+	// Channels the responses to this end's outstanding calls are sent on
+	pending map[uint64]chan *connMessage
+	err     error
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// NewConn returns a connection over [ws], such as a WebSocket a server
+// upgraded a request to. Handlers should be added before Start is called.
+func NewConn(ws *websocket.Conn) *Conn {
+	return &Conn{
+		ws:                   ws,
+		notificationHandlers: make(map[string]NotificationHandler),
+		callHandlers:         make(map[string]CallHandler),
+		pending:              make(map[uint64]chan *connMessage),
+		done:                 make(chan struct{}),
+	}
+}
+
+// DialConn opens a connection to the WebSocket at [url], such as
+// ws://127.0.0.1:9650/ext/events, over TLS if [options] specify a TLS
+// configuration. Handlers should be added before Start is called.
+func DialConn(ctx context.Context, url string, options ...Option) (*Conn, error) {
+	o := applyOptions(options)
+	dialer := websocket.Dialer{
+		HandshakeTimeout: connHandshakeTimeout,
+		TLSClientConfig:  o.tlsConfig,
+	}
+	ws, _, err := dialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't connect to %s: %w", url, err)
+	}
+	return NewConn(ws), nil
+}
+
+// HandleNotification makes [handler] be called with the params of each
+// notification of [method]
+func (c *Conn) HandleNotification(method string, handler NotificationHandler) {
+	c.handlersLock.Lock()
+	defer c.handlersLock.Unlock()
+
+	c.notificationHandlers[method] = handler
+}
+
+// HandleDefault makes [handler] be called with each notification of a method
+// that doesn't have a handler. Otherwise, such notifications are dropped.
+func (c *Conn) HandleDefault(handler func(method string, params json.RawMessage)) {
+	c.handlersLock.Lock()
+	defer c.handlersLock.Unlock()
+
+	c.defaultHandler = handler
+}
+
+// HandleCall makes [handler] respond to each call of [method]. Calls of
+// methods without a handler fail.
+func (c *Conn) HandleCall(method string, handler CallHandler) {
+	c.handlersLock.Lock()
+	defer c.handlersLock.Unlock()
+
+	c.callHandlers[method] = handler
+}
+
+// Start reading messages from the connection and dispatching them. Returns
+// immediately.
+func (c *Conn) Start() { go c.readLoop() }
+
+// Notify sends a notification of [method] with [params]
+func (c *Conn) Notify(method string, params interface{}) error {
+	paramsBytes, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("problem marshaling params of %s: %w", method, err)
+	}
+	return c.write(&connMessage{
+		Version: "2.0",
+		Method:  method,
+		Params:  paramsBytes,
+	})
+}
+
+// Call sends a call of [method] with [params] and unmarshals its result into
+// [reply]. Returns when the response is received, [ctx] is done or the
+// connection is closed.
+func (c *Conn) Call(ctx context.Context, method string, params interface{}, reply interface{}) error {
+	paramsBytes, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("problem marshaling params of %s: %w", method, err)
+	}
+
+	responses := make(chan *connMessage, 1)
+	c.lock.Lock()
+	if c.err != nil {
+		err := c.err
+		c.lock.Unlock()
+		return err
+	}
+	id := c.nextID
+	c.nextID++
+	c.pending[id] = responses
+	c.lock.Unlock()
+
+	defer func() {
+		c.lock.Lock()
+		delete(c.pending, id)
+		c.lock.Unlock()
+	}()
+
+	idBytes, err := json.Marshal(id)
+	if err != nil {
+		return err
+	}
+	if err := c.write(&connMessage{
+		Version: "2.0",
+		Method:  method,
+		Params:  paramsBytes,
+		ID:      idBytes,
+	}); err != nil {
+		return err
+	}
+
+	select {
+	case response := <-responses:
+		if response.Error != nil {
+			return response.Error
+		}
+		if response.Result == nil {
+			return json2.ErrNullResult
+		}
+		return json.Unmarshal(response.Result, reply)
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.done:
+		return c.Err()
+	}
+}
+
+// Done returns a channel that's closed when the connection is closed
+func (c *Conn) Done() <-chan struct{} { return c.done }
+
+// Err returns the error the connection was closed with, if it's closed
+func (c *Conn) Err() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.err
+}
+
+// Close the connection. Outstanding calls fail.
+func (c *Conn) Close() error {
+	c.close(errConnClosed)
+	return c.ws.Close()
+}
+
+func (c *Conn) close(err error) {
+	c.closeOnce.Do(func() {
+		c.lock.Lock()
+		c.err = err
+		c.lock.Unlock()
+		close(c.done)
+	})
+}
+
+func (c *Conn) write(msg *connMessage) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	select {
+	case <-c.done:
+		return c.Err()
+	default:
+	}
+	if err := c.ws.SetWriteDeadline(time.Now().Add(connWriteWait)); err != nil {
+		return err
+	}
+	return c.ws.WriteJSON(msg)
+}
+
+// readLoop reads messages until the connection fails, and dispatches them
+func (c *Conn) readLoop() {
+	defer c.ws.Close()
+
+	for {
+		msg := &connMessage{}
+		if err := c.ws.ReadJSON(msg); err != nil {
+			c.close(fmt.Errorf("couldn't read from connection: %w", err))
+			return
+		}
+
+		switch {
+		case msg.Method == "":
+			c.dispatchResponse(msg)
+		case len(msg.ID) == 0:
+			c.dispatchNotification(msg)
+		default:
+			go c.dispatchCall(msg)
+		}
+	}
+}
+
+func (c *Conn) dispatchResponse(msg *connMessage) {
+	id := uint64(0)
+	if err := json.Unmarshal(msg.ID, &id); err != nil {
+		return // Not a response to a call this end sent
+	}
+
+	c.lock.Lock()
+	responses, ok := c.pending[id]
+	c.lock.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case responses <- msg:
+	default: // The call was already responded to
+	}
+}
+
+func (c *Conn) dispatchNotification(msg *connMessage) {
+	c.handlersLock.RLock()
+	handler, ok := c.notificationHandlers[msg.Method]
+	defaultHandler := c.defaultHandler
+	c.handlersLock.RUnlock()
+
+	switch {
+	case ok:
+		handler(msg.Params)
+	case defaultHandler != nil:
+		defaultHandler(msg.Method, msg.Params)
+	}
+}
+
+func (c *Conn) dispatchCall(msg *connMessage) {
+	c.handlersLock.RLock()
+	handler, ok := c.callHandlers[msg.Method]
+	c.handlersLock.RUnlock()
+
+	response := &connMessage{
+		Version: "2.0",
+		ID:      msg.ID,
+	}
+	if !ok {
+		response.Error = &json2.Error{
+			Code:    json2.E_NO_METHOD,
+			Message: fmt.Sprintf("method %s not found", msg.Method),
+		}
+		_ = c.write(response)
+		return
+	}
+
+	result, err := handler(msg.Params)
+	if err != nil {
+		response.Error = &json2.Error{
+			Code:    json2.E_SERVER,
+			Message: err.Error(),
+		}
+		_ = c.write(response)
+		return
+	}
+	if response.Result, err = json.Marshal(result); err != nil {
+		response.Result = nil
+		response.Error = &json2.Error{
+			Code:    json2.E_INTERNAL,
+			Message: fmt.Sprintf("couldn't marshal result: %s", err),
+		}
+	}
+	_ = c.write(response)
+}
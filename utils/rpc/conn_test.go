@@ -0,0 +1,105 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newTestConnServer returns a server that, when it's called with subscribe,
+// responds with true and then notifies the client of [numNotifications]
+// accepted transactions
+func newTestConnServer(t *testing.T, numNotifications int) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conn := NewConn(ws)
+		conn.HandleCall("subscribe", func(json.RawMessage) (interface{}, error) {
+			go func() {
+				for i := 0; i < numNotifications; i++ {
+					if err := conn.Notify("accepted", i); err != nil {
+						t.Error(err)
+					}
+				}
+			}()
+			return true, nil
+		})
+		conn.Start()
+	}))
+}
+
+func TestConnNotifications(t *testing.T) {
+	server := newTestConnServer(t, 3)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := DialConn(ctx, "ws"+strings.TrimPrefix(server.URL, "http"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	accepted := make(chan int, 3)
+	conn.HandleNotification("accepted", func(params json.RawMessage) {
+		i := 0
+		if err := json.Unmarshal(params, &i); err != nil {
+			t.Error(err)
+		}
+		accepted <- i
+	})
+	conn.Start()
+
+	subscribed := false
+	if err := conn.Call(ctx, "subscribe", nil, &subscribed); err != nil {
+		t.Fatal(err)
+	}
+	if !subscribed {
+		t.Fatal("expected to be subscribed")
+	}
+	for i := 0; i < 3; i++ {
+		select {
+		case n := <-accepted:
+			if n != i {
+				t.Fatalf("expected notification %d but got %d", i, n)
+			}
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for notifications")
+		}
+	}
+
+	if err := conn.Call(ctx, "unknown", nil, new(interface{})); err == nil {
+		t.Fatal("should have failed due to an unknown method")
+	}
+}
+
+func TestConnClose(t *testing.T) {
+	server := newTestConnServer(t, 0)
+	defer server.Close()
+
+	conn, err := DialConn(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Start()
+	if err := conn.Close(); err != nil {
+		t.Fatal(err)
+	}
+	<-conn.Done()
+	if err := conn.Call(context.Background(), "subscribe", nil, new(bool)); err == nil {
+		t.Fatal("calls should fail after the connection is closed")
+	}
+}
@@ -0,0 +1,68 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"net/http"
+	"time"
+)
+
+// Attempt is an attempt to send JSON-RPC calls in an HTTP request
+type Attempt struct {
+	// Methods called. Contains more than one method if the calls are a batch.
+	Methods []string
+	// Number of the attempt, starting from 1. Greater than 1 if the calls are
+	// being retried.
+	Number int
+	// Request being sent. Interceptors may modify it before it's sent, for
+	// example to add headers.
+	Request *http.Request
+
+	// Response received, or nil if no response was received. Its body has
+	// already been read.
+	Response *http.Response
+	// Duration of the attempt, including reading the response
+	Duration time.Duration
+	// Err the attempt failed with, if any. Errors returned by a called method
+	// are included.
+	Err error
+}
+
+// Interceptor is called around each attempt a requester makes to send a
+// request. Interceptors can be used to log, trace, meter or modify requests.
+type Interceptor interface {
+	// BeforeSend is called before [attempt] is sent. If it returns an error,
+	// the attempt fails with that error without being sent.
+	BeforeSend(attempt *Attempt) error
+	// AfterReceive is called after [attempt] succeeds or fails
+	AfterReceive(attempt *Attempt)
+}
+
+// InterceptorFuncs is an Interceptor that calls its non-nil fields
+type InterceptorFuncs struct {
+	Before func(attempt *Attempt) error
+	After  func(attempt *Attempt)
+}
+
+// BeforeSend implements the Interceptor interface
+func (f InterceptorFuncs) BeforeSend(attempt *Attempt) error {
+	if f.Before == nil {
+		return nil
+	}
+	return f.Before(attempt)
+}
+
+// AfterReceive implements the Interceptor interface
+func (f InterceptorFuncs) AfterReceive(attempt *Attempt) {
+	if f.After != nil {
+		f.After(attempt)
+	}
+}
+
+// WithInterceptors makes a requester call [interceptors] around each attempt
+// to send a request. BeforeSend is called in the order the interceptors are
+// given, and AfterReceive in the reverse order.
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(o *requesterOptions) { o.interceptors = append(o.interceptors, interceptors...) }
+}
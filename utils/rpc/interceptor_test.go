@@ -0,0 +1,116 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestInterceptors(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-Test") != "value" {
+			t.Errorf("interceptor's header wasn't sent")
+		}
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":true,"id":0}`))
+	}))
+	defer server.Close()
+
+	order := []string(nil)
+	attempts := []*Attempt(nil)
+	setHeader := InterceptorFuncs{
+		Before: func(attempt *Attempt) error {
+			order = append(order, "before setHeader")
+			attempt.Request.Header.Set("X-Test", "value")
+			return nil
+		},
+		After: func(attempt *Attempt) {
+			order = append(order, "after setHeader")
+		},
+	}
+	record := InterceptorFuncs{
+		Before: func(attempt *Attempt) error {
+			order = append(order, "before record")
+			return nil
+		},
+		After: func(attempt *Attempt) {
+			order = append(order, "after record")
+			attempts = append(attempts, attempt)
+		},
+	}
+
+	requester := NewEndpointRequester(server.URL, "/ext/test", "test", time.Second,
+		WithRetryConfig(RetryConfig{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+		WithInterceptors(setHeader, record),
+	)
+	reply := false
+	if err := requester.SendRequest("method", struct{}{}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply {
+		t.Fatal("expected reply to be true")
+	}
+
+	if len(attempts) != 2 {
+		t.Fatalf("expected 2 attempts but got %d", len(attempts))
+	}
+	for i, attempt := range attempts {
+		if attempt.Number != i+1 {
+			t.Fatalf("expected attempt %d but got %d", i+1, attempt.Number)
+		}
+		if len(attempt.Methods) != 1 || attempt.Methods[0] != "test.method" {
+			t.Fatalf("unexpected methods %v", attempt.Methods)
+		}
+		if attempt.Response == nil {
+			t.Fatal("expected a response")
+		}
+	}
+	statusErr := (*StatusError)(nil)
+	if !errors.As(attempts[0].Err, &statusErr) || attempts[0].Response.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected the first attempt to fail with status 503 but got %v", attempts[0].Err)
+	}
+	if attempts[1].Err != nil {
+		t.Fatalf("expected the second attempt to succeed but got %v", attempts[1].Err)
+	}
+
+	expectedOrder := []string{"before setHeader", "before record", "after record", "after setHeader"}
+	for i := 0; i < len(order); i++ {
+		if order[i] != expectedOrder[i%len(expectedOrder)] {
+			t.Fatalf("unexpected interceptor order %v", order)
+		}
+	}
+}
+
+func TestInterceptorAbortsRequest(t *testing.T) {
+	sent := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = true
+	}))
+	defer server.Close()
+
+	errAbort := errors.New("abort")
+	afterErr := error(nil)
+	requester := NewEndpointRequester(server.URL, "/ext/test", "test", time.Second, WithInterceptors(
+		InterceptorFuncs{After: func(attempt *Attempt) { afterErr = attempt.Err }},
+		InterceptorFuncs{Before: func(*Attempt) error { return errAbort }},
+	))
+	if err := requester.SendRequest("method", struct{}{}, nil); err != errAbort {
+		t.Fatalf("expected %v but got %v", errAbort, err)
+	}
+	if sent {
+		t.Fatal("aborted request shouldn't have been sent")
+	}
+	if afterErr != errAbort {
+		t.Fatalf("expected earlier interceptor to see %v but got %v", errAbort, afterErr)
+	}
+}
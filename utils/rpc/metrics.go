@@ -0,0 +1,97 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// Metrics is an Interceptor that reports the number, failures and latency of
+// the calls requesters send, by method. Each call of a batch is reported
+// separately, with the latency of the batch.
+type Metrics struct {
+	requests *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+// NewMetrics returns metrics registered with [registerer]. They can be shared
+// by the requesters of any number of clients.
+func NewMetrics(namespace string, registerer prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "client_requests",
+				Help:      "Number of attempts to send a JSON-RPC call, including retries",
+			},
+			[]string{"method"},
+		),
+		retries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "client_retries",
+				Help:      "Number of attempts to send a JSON-RPC call that were retries",
+			},
+			[]string{"method"},
+		),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "client_request_errors",
+				Help:      "Number of attempts to send a JSON-RPC call that failed",
+			},
+			[]string{"method"},
+		),
+		latency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "client_request_duration",
+				Help:      "Latency of an attempt to send a JSON-RPC call in nanoseconds",
+				Buckets:   timer.NanosecondsBuckets,
+			},
+			[]string{"method"},
+		),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.requests),
+		registerer.Register(m.retries),
+		registerer.Register(m.errors),
+		registerer.Register(m.latency),
+	)
+	if errs.Errored() {
+		return nil, fmt.Errorf("failed to register client metrics due to %w", errs.Err)
+	}
+	return m, nil
+}
+
+// WithMetrics makes a requester report the calls it sends to [metrics]
+func WithMetrics(metrics *Metrics) Option {
+	return WithInterceptors(metrics)
+}
+
+// BeforeSend implements the Interceptor interface
+func (m *Metrics) BeforeSend(*Attempt) error { return nil }
+
+// AfterReceive implements the Interceptor interface
+func (m *Metrics) AfterReceive(attempt *Attempt) {
+	for _, method := range attempt.Methods {
+		m.requests.WithLabelValues(method).Inc()
+		if attempt.Number > 1 {
+			m.retries.WithLabelValues(method).Inc()
+		}
+		if attempt.Err != nil {
+			m.errors.WithLabelValues(method).Inc()
+		}
+		m.latency.WithLabelValues(method).Observe(float64(attempt.Duration))
+	}
+}
@@ -0,0 +1,43 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	server, _ := newTestServer(1)
+	defer server.Close()
+
+	metrics, err := NewMetrics("", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	requester := NewEndpointRequester(server.URL, "/ext/test", "test", time.Second,
+		WithRetryConfig(testRetryConfig()),
+		WithMetrics(metrics),
+	)
+	reply := false
+	if err := requester.SendRequest("method", struct{}{}, &reply); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests := testutil.ToFloat64(metrics.requests.WithLabelValues("test.method")); requests != 2 {
+		t.Fatalf("expected 2 requests but got %v", requests)
+	}
+	if retries := testutil.ToFloat64(metrics.retries.WithLabelValues("test.method")); retries != 1 {
+		t.Fatalf("expected 1 retry but got %v", retries)
+	}
+	if errors := testutil.ToFloat64(metrics.errors.WithLabelValues("test.method")); errors != 1 {
+		t.Fatalf("expected 1 error but got %v", errors)
+	}
+	if count := testutil.CollectAndCount(metrics.latency); count != 1 {
+		t.Fatalf("expected latency of 1 method but got %d", count)
+	}
+}
@@ -0,0 +1,255 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Default failover configuration
+const (
+	DefaultUnhealthyThreshold = 1
+	DefaultCooldown           = 30 * time.Second
+)
+
+var (
+	errNoURIs                    = errors.New("at least one URI must be given")
+	errInvalidUnhealthyThreshold = errors.New("unhealthy threshold must be positive")
+	errNegativeCooldown          = errors.New("cooldown must not be negative")
+)
+
+// FailoverConfig describes how a MultiEndpointRequester spreads requests
+// across nodes
+type FailoverConfig struct {
+	// LoadBalance makes requests be sent to the healthy nodes in turn.
+	// Otherwise, requests are sent to the first healthy node, in the order the
+	// nodes were given.
+	LoadBalance bool
+	// UnhealthyThreshold is the number of consecutive transient failures after
+	// which a node is unhealthy
+	UnhealthyThreshold int
+	// Cooldown is how long requests are sent to other nodes, if possible,
+	// after a node becomes unhealthy. After it, the node is tried again.
+	Cooldown time.Duration
+}
+
+// DefaultFailoverConfig returns the default failover configuration
+func DefaultFailoverConfig() FailoverConfig {
+	return FailoverConfig{
+		UnhealthyThreshold: DefaultUnhealthyThreshold,
+		Cooldown:           DefaultCooldown,
+	}
+}
+
+// Verify returns an error if [c] is invalid
+func (c FailoverConfig) Verify() error {
+	switch {
+	case c.UnhealthyThreshold <= 0:
+		return errInvalidUnhealthyThreshold
+	case c.Cooldown < 0:
+		return errNegativeCooldown
+	default:
+		return nil
+	}
+}
+
+// node is a node a MultiEndpointRequester sends requests to
+type node struct {
+	uri       string
+	requester EndpointRequester
+	// Number of consecutive transient failures
+	failures int
+	// Time until which the node is unhealthy, or the zero time if it's healthy
+	unhealthyUntil time.Time
+}
+
+// MultiEndpointRequester is an EndpointRequester that sends requests to a
+// service mounted on the same endpoint of several nodes. If a request fails
+// transiently, it's sent to the next node. Nodes that fail repeatedly are
+// avoided until their cooldown ends. If every node fails, the request is
+// retried as described by the requester's retry configuration.
+type MultiEndpointRequester struct {
+	retry  RetryConfig
+	config FailoverConfig
+
+	lock  sync.Mutex
+	nodes []*node
+	// Index of the node the next load balanced request is sent to first
+	next int
+}
+
+// NewMultiEndpointRequester returns a MultiEndpointRequester for [service]
+// mounted at [base] on the nodes at [uris], which fails over between them as
+// described by [config]. Requests are retried as described by
+// DefaultRetryConfig unless [options] specify otherwise.
+func NewMultiEndpointRequester(
+	uris []string,
+	base, service string,
+	requestTimeout time.Duration,
+	config FailoverConfig,
+	options ...Option,
+) (*MultiEndpointRequester, error) {
+	o := applyOptions(options)
+	o.failoverConfig = config
+	return newMultiEndpointRequester(uris, o.versionedBase(base), service, requestTimeout, o)
+}
+
+func newMultiEndpointRequester(
+	uris []string,
+	base, service string,
+	requestTimeout time.Duration,
+	o requesterOptions,
+) (*MultiEndpointRequester, error) {
+	if len(uris) == 0 {
+		return nil, errNoURIs
+	}
+	if err := o.failoverConfig.Verify(); err != nil {
+		return nil, err
+	}
+	if err := o.retry.Verify(); err != nil {
+		return nil, err
+	}
+
+	// Each node is sent a request once per attempt. Failed attempts are
+	// retried across every node.
+	retry := o.retry
+	o.retry.MaxAttempts = 1
+	requester, err := newRequester(requestTimeout, o)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*node, len(uris))
+	for i, uri := range uris {
+		nodes[i] = &node{
+			uri:       uri,
+			requester: newEndpointRequester(uri, base, service, requester),
+		}
+	}
+	return &MultiEndpointRequester{
+		retry:  retry,
+		config: o.failoverConfig,
+		nodes:  nodes,
+	}, nil
+}
+
+// SendRequest sends a request for [service].[method] to a healthy node, failing
+// over to the other nodes if it fails transiently
+func (m *MultiEndpointRequester) SendRequest(method string, params interface{}, reply interface{}) error {
+	return m.send(func(requester EndpointRequester) error {
+		return requester.SendRequest(method, params, reply)
+	})
+}
+
+// SendRequestContext is like SendRequest, but stops sending the request once
+// [ctx] is done
+func (m *MultiEndpointRequester) SendRequestContext(ctx context.Context, method string, params interface{}, reply interface{}) error {
+	return m.sendContext(ctx, func(requester EndpointRequester) error {
+		return requester.SendRequestContext(ctx, method, params, reply)
+	})
+}
+
+// SendBatch sends [calls] in a single batch to a healthy node, failing over to
+// the other nodes if the batch fails transiently
+func (m *MultiEndpointRequester) SendBatch(calls []*BatchCall) error {
+	return m.send(func(requester EndpointRequester) error {
+		return requester.SendBatch(calls)
+	})
+}
+
+// HealthyURIs returns the URIs of the nodes that are healthy
+func (m *MultiEndpointRequester) HealthyURIs() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now()
+	uris := []string(nil)
+	for _, n := range m.nodes {
+		if !n.unhealthyUntil.After(now) {
+			uris = append(uris, n.uri)
+		}
+	}
+	return uris
+}
+
+// send calls [request] with the requester of each node, in the order they
+// should be tried, until it succeeds or fails with an error that isn't
+// transient. If every node fails, the attempt is retried.
+func (m *MultiEndpointRequester) send(request func(EndpointRequester) error) error {
+	return m.sendContext(context.Background(), request)
+}
+
+// sendContext is like send, but stops retrying once [ctx] is done
+func (m *MultiEndpointRequester) sendContext(ctx context.Context, request func(EndpointRequester) error) error {
+	return m.retry.retry(ctx, func(int) error {
+		var err error
+		for _, n := range m.order() {
+			err = request(n.requester)
+			if err == nil {
+				m.succeeded(n)
+				return nil
+			}
+			if !m.retry.retryable(err) {
+				// The node responded, so it's healthy
+				m.succeeded(n)
+				return err
+			}
+			m.failed(n)
+		}
+		return err
+	})
+}
+
+// order returns the nodes in the order they should be tried. Healthy nodes are
+// tried first, followed by unhealthy nodes, soonest to recover first.
+func (m *MultiEndpointRequester) order() []*node {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	start := 0
+	if m.config.LoadBalance {
+		start = m.next
+		m.next = (m.next + 1) % len(m.nodes)
+	}
+
+	now := time.Now()
+	healthy := make([]*node, 0, len(m.nodes))
+	unhealthy := []*node(nil)
+	for i := range m.nodes {
+		n := m.nodes[(start+i)%len(m.nodes)]
+		if n.unhealthyUntil.After(now) {
+			unhealthy = append(unhealthy, n)
+		} else {
+			healthy = append(healthy, n)
+		}
+	}
+	sort.SliceStable(unhealthy, func(i, j int) bool {
+		return unhealthy[i].unhealthyUntil.Before(unhealthy[j].unhealthyUntil)
+	})
+	return append(healthy, unhealthy...)
+}
+
+// succeeded marks that [n] responded
+func (m *MultiEndpointRequester) succeeded(n *node) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	n.failures = 0
+	n.unhealthyUntil = time.Time{}
+}
+
+// failed marks that a request to [n] failed transiently
+func (m *MultiEndpointRequester) failed(n *node) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	n.failures++
+	if n.failures >= m.config.UnhealthyThreshold {
+		n.unhealthyUntil = time.Now().Add(m.config.Cooldown)
+	}
+}
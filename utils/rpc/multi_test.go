@@ -0,0 +1,105 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMultiEndpointRequesterFailsOver(t *testing.T) {
+	down, downRequests := newTestServer(100)
+	defer down.Close()
+	up, upRequests := newTestServer(0)
+	defer up.Close()
+
+	requester, err := NewMultiEndpointRequester(
+		[]string{down.URL, up.URL},
+		"/ext/test",
+		"test",
+		time.Second,
+		FailoverConfig{UnhealthyThreshold: 1, Cooldown: time.Hour},
+		WithRetryConfig(testRetryConfig()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		reply := false
+		if err := requester.SendRequest("method", struct{}{}, &reply); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The unhealthy node shouldn't be tried again until its cooldown ends
+	if *downRequests != 1 || *upRequests != 2 {
+		t.Fatalf("expected 1 and 2 requests but got %d and %d", *downRequests, *upRequests)
+	}
+	if healthy := requester.HealthyURIs(); len(healthy) != 1 || healthy[0] != up.URL {
+		t.Fatalf("expected only %s to be healthy but got %v", up.URL, healthy)
+	}
+}
+
+func TestMultiEndpointRequesterLoadBalances(t *testing.T) {
+	first, firstRequests := newTestServer(0)
+	defer first.Close()
+	second, secondRequests := newTestServer(0)
+	defer second.Close()
+
+	requester := NewEndpointRequester(first.URL, "/ext/test", "test", time.Second,
+		WithFailover(FailoverConfig{LoadBalance: true, UnhealthyThreshold: 1}, second.URL),
+	)
+	for i := 0; i < 4; i++ {
+		reply := false
+		if err := requester.SendRequest("method", struct{}{}, &reply); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if *firstRequests != 2 || *secondRequests != 2 {
+		t.Fatalf("expected 2 requests to each node but got %d and %d", *firstRequests, *secondRequests)
+	}
+}
+
+func TestMultiEndpointRequesterMethodErrorNotFailedOver(t *testing.T) {
+	requests := 0
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32000,"message":"failed"},"id":1}`))
+	}))
+	defer failing.Close()
+	other, otherRequests := newTestServer(0)
+	defer other.Close()
+
+	requester, err := NewMultiEndpointRequester(
+		[]string{failing.URL, other.URL},
+		"/ext/test",
+		"test",
+		time.Second,
+		DefaultFailoverConfig(),
+		WithRetryConfig(testRetryConfig()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply := false
+	if err := requester.SendRequest("method", struct{}{}, &reply); err == nil {
+		t.Fatal("should have failed due to the method's error")
+	}
+	if requests != 1 || *otherRequests != 0 {
+		t.Fatalf("expected only 1 request to the first node but got %d and %d", requests, *otherRequests)
+	}
+}
+
+func TestFailoverConfigVerify(t *testing.T) {
+	if _, err := NewMultiEndpointRequester(nil, "/ext/test", "test", time.Second, DefaultFailoverConfig()); err == nil {
+		t.Fatal("should have failed due to no URIs")
+	}
+	if err := (FailoverConfig{}).Verify(); err == nil {
+		t.Fatal("should have failed due to a non-positive unhealthy threshold")
+	}
+	if err := (FailoverConfig{UnhealthyThreshold: 1, Cooldown: -time.Second}).Verify(); err == nil {
+		t.Fatal("should have failed due to a negative cooldown")
+	}
+}
@@ -0,0 +1,87 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+// NotBootstrappedError is returned when a call is rejected because the chain
+// it was sent to isn't done bootstrapping. Such calls are retried, as the
+// chain becomes available once it's done bootstrapping.
+type NotBootstrappedError struct {
+	StatusError
+	// ChainID of the chain that isn't done bootstrapping
+	ChainID string
+	// Phase of bootstrapping the chain is in, either "fetching" or
+	// "executing"
+	Phase string
+	// Fetched is the number of containers the chain has fetched
+	Fetched uint64
+	// Executed is the number of fetched containers the chain has executed
+	Executed uint64
+	// ETA is the server's estimate of how much longer bootstrapping will
+	// take, or 0 if unknown
+	ETA time.Duration
+}
+
+func (e *NotBootstrappedError) Error() string {
+	msg := fmt.Sprintf("chain %s at %s is not done bootstrapping: %s, %d containers fetched, %d executed",
+		e.ChainID, e.URL, e.Phase, e.Fetched, e.Executed)
+	if e.ETA > 0 {
+		msg += fmt.Sprintf(", about %s remaining", e.ETA)
+	}
+	return msg
+}
+
+// Unwrap returns the status error the chain responded with
+func (e *NotBootstrappedError) Unwrap() error { return &e.StatusError }
+
+// maxNotBootstrappedSize is the max size of a not bootstrapped response that's
+// decoded
+const maxNotBootstrappedSize = 1 << 12
+
+// notBootstrappedResponse is the body the server responds to calls to a chain
+// that isn't done bootstrapping with
+type notBootstrappedResponse struct {
+	ChainID  string       `json:"chainID"`
+	Phase    string       `json:"phase"`
+	Fetched  cjson.Uint64 `json:"fetched"`
+	Executed cjson.Uint64 `json:"executed"`
+	ETA      string       `json:"eta"`
+}
+
+// statusError returns the error describing [resp], which has the unsuccessful
+// status [statusErr]. If the chain [resp] is from isn't done bootstrapping, a
+// *NotBootstrappedError is returned.
+func statusError(resp *http.Response, statusErr *StatusError) error {
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		return statusErr
+	}
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		return statusErr
+	}
+
+	response := notBootstrappedResponse{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxNotBootstrappedSize)).Decode(&response); err != nil || response.Phase == "" {
+		return statusErr
+	}
+	// If the ETA can't be parsed, it's treated as unknown
+	eta, _ := time.ParseDuration(response.ETA)
+	return &NotBootstrappedError{
+		StatusError: *statusErr,
+		ChainID:     response.ChainID,
+		Phase:       response.Phase,
+		Fetched:     uint64(response.Fetched),
+		Executed:    uint64(response.Executed),
+		ETA:         eta,
+	}
+}
@@ -0,0 +1,59 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNotBootstrappedError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":"not bootstrapped","chainID":"2oYMBNV4eNHyqk2fjjV5nVQLDbtmNJzq5s3qs3Lo6ftnC6FByM","phase":"executing","fetched":"100","executed":"40","eta":"1m30s"}`))
+	}))
+	defer server.Close()
+
+	requester, err := NewEndpointRequesterWithRetries(server.URL, "/ext/bc/X", "avm", time.Second, RetryConfig{MaxAttempts: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = requester.SendRequest("getBalance", struct{}{}, new(interface{}))
+	var notBootstrappedErr *NotBootstrappedError
+	if !errors.As(err, &notBootstrappedErr) {
+		t.Fatalf("expected a *NotBootstrappedError but got %v", err)
+	}
+	if notBootstrappedErr.Phase != "executing" ||
+		notBootstrappedErr.Fetched != 100 ||
+		notBootstrappedErr.Executed != 40 ||
+		notBootstrappedErr.ETA != 90*time.Second {
+		t.Fatalf("unexpected progress %+v", notBootstrappedErr)
+	}
+	if !IsRetryable(err) {
+		t.Fatal("calls to a chain that isn't done bootstrapping should be retryable")
+	}
+}
+
+func TestServiceUnavailableWithoutProgress(t *testing.T) {
+	server, _ := newTestServer(1)
+	defer server.Close()
+
+	requester, err := NewEndpointRequesterWithRetries(server.URL, "/ext/test", "test", time.Second, RetryConfig{MaxAttempts: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = requester.SendRequest("method", struct{}{}, new(bool))
+	var notBootstrappedErr *NotBootstrappedError
+	if errors.As(err, &notBootstrappedErr) {
+		t.Fatal("a 503 without bootstrap progress shouldn't be a *NotBootstrappedError")
+	}
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected a 503 status error but got %v", err)
+	}
+}
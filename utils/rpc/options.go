@@ -0,0 +1,173 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var errNoCACerts = errors.New("no CA certificates found")
+
+// Option configures a requester
+type Option func(*requesterOptions)
+
+type requesterOptions struct {
+	retry        RetryConfig
+	client       *http.Client
+	tlsConfig    *tls.Config
+	interceptors []Interceptor
+	// Nodes failed over to, if any, and how
+	failoverURIs   []string
+	failoverConfig FailoverConfig
+	// Version of the API requests are sent to
+	apiVersion uint32
+}
+
+// WithRetryConfig makes a requester retry requests as described by [retry]
+func WithRetryConfig(retry RetryConfig) Option {
+	return func(o *requesterOptions) { o.retry = retry }
+}
+
+// WithHTTPClient makes a requester send requests with [client]. It takes
+// precedence over WithTLSConfig.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *requesterOptions) { o.client = client }
+}
+
+// WithTLSConfig makes a requester connect to nodes over HTTPS as described by
+// [tlsConfig], for example to present a client certificate. The requester's
+// connections aren't pooled with those of other requesters, so requesters
+// that share a TLS configuration should share a client given by
+// WithHTTPClient instead.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(o *requesterOptions) { o.tlsConfig = tlsConfig }
+}
+
+// WithFailover makes an endpoint requester fail over to the nodes at [uris]
+// when the node it was created for is unhealthy, as described by [config]
+func WithFailover(config FailoverConfig, uris ...string) Option {
+	return func(o *requesterOptions) {
+		o.failoverURIs = append(o.failoverURIs, uris...)
+		o.failoverConfig = config
+	}
+}
+
+// WithAPIVersion makes an endpoint requester send requests to version
+// [version] of a chain's API, such as /ext/bc/X/v2 for version 2 of the
+// X-Chain's API, rather than the first version
+func WithAPIVersion(version uint32) Option {
+	return func(o *requesterOptions) { o.apiVersion = version }
+}
+
+// versionedBase returns the path version [o.apiVersion] of the API at [base]
+// is served at
+func (o requesterOptions) versionedBase(base string) string {
+	if o.apiVersion <= 1 {
+		return base
+	}
+	return fmt.Sprintf("%s/v%d", base, o.apiVersion)
+}
+
+func applyOptions(options []Option) requesterOptions {
+	o := requesterOptions{
+		retry:          DefaultRetryConfig(),
+		failoverConfig: DefaultFailoverConfig(),
+	}
+	for _, option := range options {
+		option(&o)
+	}
+	return o
+}
+
+// newRequesterWithOptions returns a Requester that times out requests after
+// [requestTimeout], configured by [options]
+func newRequesterWithOptions(requestTimeout time.Duration, options []Option) (Requester, error) {
+	return newRequester(requestTimeout, applyOptions(options))
+}
+
+// newRequester returns a Requester that times out requests after
+// [requestTimeout], configured by [o]
+func newRequester(requestTimeout time.Duration, o requesterOptions) (Requester, error) {
+	if err := o.retry.Verify(); err != nil {
+		return nil, err
+	}
+	return &jsonRPCRequester{
+		client:       o.httpClient(requestTimeout),
+		retry:        o.retry,
+		interceptors: o.interceptors,
+	}, nil
+}
+
+// httpClient returns the client requests are sent with, which times them out
+// after [requestTimeout] unless a client was given
+func (o requesterOptions) httpClient(requestTimeout time.Duration) *http.Client {
+	switch {
+	case o.client != nil:
+		return o.client
+	case o.tlsConfig != nil:
+		transportConfig := DefaultTransportConfig()
+		transportConfig.TLSConfig = o.tlsConfig
+		return NewClient(NewTransport(transportConfig), requestTimeout)
+	default:
+		return newClient(requestTimeout)
+	}
+}
+
+// NewStreamingClient returns the client a requester configured by [options]
+// sends requests with, except that it doesn't time out requests, so that long
+// responses can be streamed. Streams should be bounded by their requests'
+// contexts instead.
+func NewStreamingClient(options ...Option) *http.Client {
+	client := *applyOptions(options).httpClient(0)
+	client.Timeout = 0
+	return &client
+}
+
+// LoadTLSConfig returns a TLS configuration that presents the client
+// certificate in [certFile], with the key in [keyFile], and trusts the
+// certificate authorities in [caFile]. If [certFile] is empty, no client
+// certificate is presented. If [caFile] is empty, the system's certificate
+// authorities are trusted.
+func LoadTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	if caFile != "" {
+		caBytes, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read CA certificates: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBytes) {
+			return nil, fmt.Errorf("couldn't parse %s: %w", caFile, errNoCACerts)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+// errRequester fails every request with [err]. It's returned by constructors
+// that don't return errors when they're given an invalid configuration.
+type errRequester struct{ err error }
+
+func (r errRequester) SendRequest(*url.URL, string, interface{}, interface{}) error { return r.err }
+
+func (r errRequester) SendRequestContext(context.Context, *url.URL, string, interface{}, interface{}) error {
+	return r.err
+}
+
+func (r errRequester) SendBatch(*url.URL, []*BatchCall) error { return r.err }
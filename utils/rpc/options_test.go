@@ -0,0 +1,99 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/staking"
+)
+
+func TestMutualTLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":true,"id":1}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "rpc-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	if err := staking.GenerateStakingKeyCert(keyFile, certFile); err != nil {
+		t.Fatal(err)
+	}
+	caBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := ioutil.WriteFile(caFile, caBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	noRetries := WithRetryConfig(RetryConfig{MaxAttempts: 1})
+
+	// Without a client certificate, the server rejects the connection
+	tlsConfig, err := LoadTLSConfig("", "", caFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requester := NewEndpointRequester(server.URL, "/ext/test", "test", time.Second, WithTLSConfig(tlsConfig), noRetries)
+	reply := false
+	if err := requester.SendRequest("method", struct{}{}, &reply); err == nil {
+		t.Fatal("request without a client certificate should have failed")
+	}
+
+	tlsConfig, err = LoadTLSConfig(certFile, keyFile, caFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requester = NewEndpointRequester(server.URL, "/ext/test", "test", time.Second, WithTLSConfig(tlsConfig), noRetries)
+	if err := requester.SendRequest("method", struct{}{}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply {
+		t.Fatal("expected reply to be true")
+	}
+}
+
+func TestInvalidOptions(t *testing.T) {
+	requester := NewEndpointRequester("http://127.0.0.1:1", "/ext/test", "test", time.Second, WithRetryConfig(RetryConfig{}))
+	if err := requester.SendRequest("method", struct{}{}, nil); err == nil {
+		t.Fatal("should have failed due to invalid options")
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	paths := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths <- r.URL.Path
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":true,"id":1}`))
+	}))
+	defer server.Close()
+
+	for version, expected := range map[uint32]string{
+		1: "/ext/test",
+		2: "/ext/test/v2",
+	} {
+		requester := NewEndpointRequester(server.URL, "/ext/test", "test", time.Second, WithAPIVersion(version))
+		reply := false
+		if err := requester.SendRequest("method", struct{}{}, &reply); err != nil {
+			t.Fatal(err)
+		}
+		if path := <-paths; path != expected {
+			t.Fatalf("version %d: expected request to %s but was to %s", version, expected, path)
+		}
+	}
+}
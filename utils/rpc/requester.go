@@ -0,0 +1,263 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/rpc/v2/json2"
+)
+
+// Requester sends JSON-RPC requests to an arbitrary URL
+type Requester interface {
+	SendRequest(url *url.URL, method string, params interface{}, reply interface{}) error
+	SendRequestContext(ctx context.Context, url *url.URL, method string, params interface{}, reply interface{}) error
+	SendBatch(url *url.URL, calls []*BatchCall) error
+}
+
+type jsonRPCRequester struct {
+	client       *http.Client
+	retry        RetryConfig
+	interceptors []Interceptor
+}
+
+// NewRequester returns a Requester that times out requests after
+// [requestTimeout] and retries them as described by DefaultRetryConfig.
+// Connections are pooled with those of other requesters.
+func NewRequester(requestTimeout time.Duration) Requester {
+	return &jsonRPCRequester{
+		client: newClient(requestTimeout),
+		retry:  DefaultRetryConfig(),
+	}
+}
+
+// NewRequesterWithRetries returns a Requester that times out each attempt to
+// send a request after [requestTimeout] and retries requests as described by
+// [retry]. Connections are pooled with those of other requesters.
+func NewRequesterWithRetries(requestTimeout time.Duration, retry RetryConfig) (Requester, error) {
+	return NewRequesterWithClient(newClient(requestTimeout), retry)
+}
+
+// NewRequesterWithClient returns a Requester that sends requests with
+// [client] and retries them as described by [retry]. To pool connections,
+// clients should share a transport, such as one returned by NewTransport.
+func NewRequesterWithClient(client *http.Client, retry RetryConfig) (Requester, error) {
+	if err := retry.Verify(); err != nil {
+		return nil, err
+	}
+	return &jsonRPCRequester{
+		client: client,
+		retry:  retry,
+	}, nil
+}
+
+// SendRequest sends a JSON-RPC request for [method] with [params] to [url] and
+// unmarshals the result into [reply]. The request is retried if it fails
+// transiently.
+func (requester *jsonRPCRequester) SendRequest(url *url.URL, method string, params interface{}, reply interface{}) error {
+	return requester.SendRequestContext(context.Background(), url, method, params, reply)
+}
+
+// SendRequestContext is like SendRequest, but the request is cancelled and
+// isn't retried once [ctx] is done
+func (requester *jsonRPCRequester) SendRequestContext(ctx context.Context, url *url.URL, method string, params interface{}, reply interface{}) error {
+	requestBodyBytes, err := json2.EncodeClientRequest(method, params)
+	if err != nil {
+		return fmt.Errorf("problem marshaling request to %s: %w", method, err)
+	}
+
+	methods := []string{method}
+	return requester.retry.retry(ctx, func(attempt int) error {
+		return requester.sendRequest(ctx, url, methods, attempt, requestBodyBytes, reply, json2.DecodeClientResponse)
+	})
+}
+
+// sendRequest posts [requestBodyBytes], which call [methods], to [url] and
+// decodes the response body into [reply] with [decode]. The interceptors are
+// called around the attempt. The request is cancelled once [ctx] is done.
+func (requester *jsonRPCRequester) sendRequest(
+	ctx context.Context,
+	url *url.URL,
+	methods []string,
+	attemptNumber int,
+	requestBodyBytes []byte,
+	reply interface{},
+	decode func(io.Reader, interface{}) error,
+) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.String(), bytes.NewReader(requestBodyBytes))
+	if err != nil {
+		return fmt.Errorf("problem creating JSON RPC POST request to %s: %w", url, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(requester.interceptors) == 0 {
+		_, err := requester.send(req, url, reply, decode)
+		return err
+	}
+
+	attempt := &Attempt{
+		Methods: methods,
+		Number:  attemptNumber,
+		Request: req,
+	}
+	for i, interceptor := range requester.interceptors {
+		if err := interceptor.BeforeSend(attempt); err != nil {
+			attempt.Err = err
+			for j := i - 1; j >= 0; j-- {
+				requester.interceptors[j].AfterReceive(attempt)
+			}
+			return err
+		}
+	}
+
+	start := time.Now()
+	attempt.Response, attempt.Err = requester.send(attempt.Request, url, reply, decode)
+	attempt.Duration = time.Since(start)
+
+	for i := len(requester.interceptors) - 1; i >= 0; i-- {
+		requester.interceptors[i].AfterReceive(attempt)
+	}
+	return attempt.Err
+}
+
+// send sends [req] and decodes the response body into [reply] with [decode].
+// Returns the response, if one was received.
+func (requester *jsonRPCRequester) send(
+	req *http.Request,
+	url *url.URL,
+	reply interface{},
+	decode func(io.Reader, interface{}) error,
+) (*http.Response, error) {
+	resp, err := requester.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("problem while making JSON RPC POST request to %s: %w", url, err)
+	}
+	defer func() {
+		// The body is drained so that the connection can be reused
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	// Return an error for any non successful status code
+	if statusCode := resp.StatusCode; statusCode < 200 || statusCode > 299 {
+		return resp, statusError(resp, &StatusError{
+			URL:        url.String(),
+			StatusCode: statusCode,
+			RequestID:  resp.Header.Get("X-Request-ID"),
+		})
+	}
+
+	return resp, decode(resp.Body, reply)
+}
+
+func decodeJSON(r io.Reader, reply interface{}) error {
+	return json.NewDecoder(r).Decode(reply)
+}
+
+// EndpointRequester sends JSON-RPC requests to a single service mounted on a
+// single endpoint
+type EndpointRequester interface {
+	SendRequest(method string, params interface{}, reply interface{}) error
+	SendRequestContext(ctx context.Context, method string, params interface{}, reply interface{}) error
+	SendBatch(calls []*BatchCall) error
+}
+
+type avalancheEndpointRequester struct {
+	requester Requester
+	url       *url.URL
+	service   string
+}
+
+// NewEndpointRequester returns an EndpointRequester for [service] mounted at
+// [base] on the node at [uri]. For example, the info API of a local node is
+// reached with uri "http://127.0.0.1:9650", base "/ext/info" and service "info".
+// Requests are retried as described by DefaultRetryConfig unless [options]
+// specify otherwise. Invalid options result in every request failing.
+func NewEndpointRequester(uri, base, service string, requestTimeout time.Duration, options ...Option) EndpointRequester {
+	o := applyOptions(options)
+	base = o.versionedBase(base)
+	if len(o.failoverURIs) > 0 {
+		uris := append([]string{uri}, o.failoverURIs...)
+		requester, err := newMultiEndpointRequester(uris, base, service, requestTimeout, o)
+		if err != nil {
+			return newEndpointRequester(uri, base, service, errRequester{err: fmt.Errorf("invalid requester options: %w", err)})
+		}
+		return requester
+	}
+
+	requester, err := newRequester(requestTimeout, o)
+	if err != nil {
+		requester = errRequester{err: fmt.Errorf("invalid requester options: %w", err)}
+	}
+	return newEndpointRequester(uri, base, service, requester)
+}
+
+// NewEndpointRequesterWithRetries returns an EndpointRequester like
+// NewEndpointRequester that retries requests as described by [retry]
+func NewEndpointRequesterWithRetries(uri, base, service string, requestTimeout time.Duration, retry RetryConfig) (EndpointRequester, error) {
+	requester, err := NewRequesterWithRetries(requestTimeout, retry)
+	if err != nil {
+		return nil, err
+	}
+	return newEndpointRequester(uri, base, service, requester), nil
+}
+
+// NewEndpointRequesterWithClient returns an EndpointRequester like
+// NewEndpointRequester that sends requests with [client] and retries them as
+// described by [retry]
+func NewEndpointRequesterWithClient(uri, base, service string, client *http.Client, retry RetryConfig) (EndpointRequester, error) {
+	requester, err := NewRequesterWithClient(client, retry)
+	if err != nil {
+		return nil, err
+	}
+	return newEndpointRequester(uri, base, service, requester), nil
+}
+
+func newEndpointRequester(uri, base, service string, requester Requester) EndpointRequester {
+	// An invalid URI results in every request failing with a descriptive
+	// error, rather than the constructor failing
+	u, err := url.Parse(uri + base)
+	if err != nil {
+		u = &url.URL{Path: uri + base}
+	}
+	return &avalancheEndpointRequester{
+		requester: requester,
+		url:       u,
+		service:   service,
+	}
+}
+
+// SendRequest sends a request for [service].[method]
+func (e *avalancheEndpointRequester) SendRequest(method string, params interface{}, reply interface{}) error {
+	return e.requester.SendRequest(e.url, fmt.Sprintf("%s.%s", e.service, method), params, reply)
+}
+
+// SendRequestContext sends a request for [service].[method], which is cancelled
+// once [ctx] is done
+func (e *avalancheEndpointRequester) SendRequestContext(ctx context.Context, method string, params interface{}, reply interface{}) error {
+	return e.requester.SendRequestContext(ctx, e.url, fmt.Sprintf("%s.%s", e.service, method), params, reply)
+}
+
+// SendBatch sends [calls] in a single batch. The method of each call is
+// prefixed with [service].
+func (e *avalancheEndpointRequester) SendBatch(calls []*BatchCall) error {
+	prefixedCalls := make([]*BatchCall, len(calls))
+	for i, call := range calls {
+		prefixedCall := *call
+		prefixedCall.Method = fmt.Sprintf("%s.%s", e.service, call.Method)
+		prefixedCalls[i] = &prefixedCall
+	}
+	err := e.requester.SendBatch(e.url, prefixedCalls)
+	for i, call := range calls {
+		call.Err = prefixedCalls[i].Err
+	}
+	return err
+}
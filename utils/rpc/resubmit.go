@@ -0,0 +1,163 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultTxTTL is the default amount of time a transaction may be pending
+	// before it is re-issued
+	DefaultTxTTL = 30 * time.Second
+
+	// DefaultMaxResubmissions is the default number of times a transaction is
+	// re-issued before it is considered expired
+	DefaultMaxResubmissions = 3
+
+	// DefaultTxPollFrequency is the default frequency at which the status of a
+	// pending transaction is checked
+	DefaultTxPollFrequency = time.Second
+)
+
+var (
+	errInvalidTTL           = errors.New("tx TTL must be positive")
+	errInvalidPollFrequency = errors.New("tx poll frequency must be positive")
+	errNegativeResubmission = errors.New("max resubmissions can't be negative")
+)
+
+// TxState is the state of a transaction as seen by a client
+type TxState uint32
+
+// List of possible transaction states
+// [TxPending] means the transaction hasn't been decided. This includes
+// transactions the node reports as processing, unknown or dropped.
+// [TxAccepted] means the transaction was accepted, or committed
+// [TxRejected] means the transaction was rejected, or aborted
+// [TxExpired] means the transaction was still pending after being re-issued
+// the maximum number of times
+const (
+	TxPending TxState = iota
+	TxAccepted
+	TxRejected
+	TxExpired
+)
+
+func (s TxState) String() string {
+	switch s {
+	case TxPending:
+		return "Pending"
+	case TxAccepted:
+		return "Accepted"
+	case TxRejected:
+		return "Rejected"
+	case TxExpired:
+		return "Expired"
+	default:
+		return "Invalid state"
+	}
+}
+
+// Decided returns true if the transaction was accepted or rejected
+func (s TxState) Decided() bool { return s == TxAccepted || s == TxRejected }
+
+// ResubmitConfig describes how a client re-issues a transaction that remains
+// pending
+type ResubmitConfig struct {
+	// TTL is how long a transaction may be pending before it is re-issued
+	TTL time.Duration
+	// MaxResubmissions is the number of times a transaction is re-issued before
+	// it is considered expired
+	MaxResubmissions int
+	// PollFrequency is how often the status of the transaction is checked
+	PollFrequency time.Duration
+}
+
+// DefaultResubmitConfig returns the default resubmission configuration
+func DefaultResubmitConfig() ResubmitConfig {
+	return ResubmitConfig{
+		TTL:              DefaultTxTTL,
+		MaxResubmissions: DefaultMaxResubmissions,
+		PollFrequency:    DefaultTxPollFrequency,
+	}
+}
+
+// Verify returns an error if this configuration is invalid
+func (c ResubmitConfig) Verify() error {
+	switch {
+	case c.TTL <= 0:
+		return errInvalidTTL
+	case c.PollFrequency <= 0:
+		return errInvalidPollFrequency
+	case c.MaxResubmissions < 0:
+		return errNegativeResubmission
+	default:
+		return nil
+	}
+}
+
+// TxOutcome is the final outcome of waiting for a transaction
+type TxOutcome struct {
+	// State is TxAccepted, TxRejected or TxExpired
+	State TxState
+	// Resubmissions is the number of times the transaction was re-issued
+	Resubmissions int
+	// LastIssueErr is the error, if any, returned the last time the transaction
+	// was re-issued
+	LastIssueErr error
+}
+
+// AwaitTx waits for a transaction to be decided, re-issuing it by calling
+// [issue] whenever it has been pending for longer than [config.TTL]. Because
+// the same transaction bytes are re-issued, the transaction is accepted at
+// most once. [status] reports the current state of the transaction.
+//
+// Errors returned by re-issuing the transaction are recorded in the outcome
+// rather than returned, as the node may reject a transaction it already knows
+// about. Returns an error if [ctx] is done or [status] fails.
+func AwaitTx(
+	ctx context.Context,
+	config ResubmitConfig,
+	issue func() error,
+	status func() (TxState, error),
+) (TxOutcome, error) {
+	outcome := TxOutcome{}
+	if err := config.Verify(); err != nil {
+		return outcome, err
+	}
+
+	ticker := time.NewTicker(config.PollFrequency)
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(config.TTL)
+	for {
+		state, err := status()
+		if err != nil {
+			return outcome, fmt.Errorf("couldn't get tx status: %w", err)
+		}
+		if state.Decided() {
+			outcome.State = state
+			return outcome, nil
+		}
+
+		if now := time.Now(); !now.Before(deadline) {
+			if outcome.Resubmissions >= config.MaxResubmissions {
+				outcome.State = TxExpired
+				return outcome, nil
+			}
+			outcome.Resubmissions++
+			outcome.LastIssueErr = issue()
+			deadline = now.Add(config.TTL)
+		}
+
+		select {
+		case <-ctx.Done():
+			return outcome, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func testResubmitConfig() ResubmitConfig {
+	return ResubmitConfig{
+		TTL:              5 * time.Millisecond,
+		MaxResubmissions: 2,
+		PollFrequency:    time.Millisecond,
+	}
+}
+
+func TestAwaitTxAccepted(t *testing.T) {
+	issued := 0
+	outcome, err := AwaitTx(
+		context.Background(),
+		testResubmitConfig(),
+		func() error {
+			issued++
+			return nil
+		},
+		func() (TxState, error) {
+			if issued > 0 {
+				return TxAccepted, nil
+			}
+			return TxPending, nil
+		},
+	)
+	switch {
+	case err != nil:
+		t.Fatal(err)
+	case outcome.State != TxAccepted:
+		t.Fatalf("expected %s but got %s", TxAccepted, outcome.State)
+	case outcome.Resubmissions != 1:
+		t.Fatalf("expected 1 resubmission but got %d", outcome.Resubmissions)
+	}
+}
+
+func TestAwaitTxExpired(t *testing.T) {
+	errIssue := errors.New("already issued")
+	issued := 0
+	outcome, err := AwaitTx(
+		context.Background(),
+		testResubmitConfig(),
+		func() error {
+			issued++
+			return errIssue
+		},
+		func() (TxState, error) { return TxPending, nil },
+	)
+	switch {
+	case err != nil:
+		t.Fatal(err)
+	case outcome.State != TxExpired:
+		t.Fatalf("expected %s but got %s", TxExpired, outcome.State)
+	case issued != 2 || outcome.Resubmissions != 2:
+		t.Fatalf("expected 2 resubmissions but got %d", outcome.Resubmissions)
+	case outcome.LastIssueErr != errIssue:
+		t.Fatalf("expected %s but got %v", errIssue, outcome.LastIssueErr)
+	}
+}
+
+func TestAwaitTxCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	config := testResubmitConfig()
+	config.TTL = time.Hour
+	_, err := AwaitTx(
+		ctx,
+		config,
+		func() error { return nil },
+		func() (TxState, error) { return TxPending, nil },
+	)
+	if err != context.Canceled {
+		t.Fatalf("expected %s but got %v", context.Canceled, err)
+	}
+}
+
+func TestAwaitTxInvalidConfig(t *testing.T) {
+	config := testResubmitConfig()
+	config.TTL = 0
+	if _, err := AwaitTx(
+		context.Background(),
+		config,
+		func() error { return nil },
+		func() (TxState, error) { return TxPending, nil },
+	); err != errInvalidTTL {
+		t.Fatalf("expected %s but got %v", errInvalidTTL, err)
+	}
+}
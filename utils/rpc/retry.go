@@ -0,0 +1,159 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is the default number of times a request is sent
+	// before its failure is returned
+	DefaultMaxAttempts = 3
+
+	// DefaultInitialBackoff is the default amount of time waited before a
+	// failed request is first retried
+	DefaultInitialBackoff = 100 * time.Millisecond
+
+	// DefaultMaxBackoff is the default maximum amount of time waited before a
+	// failed request is retried
+	DefaultMaxBackoff = 2 * time.Second
+)
+
+var (
+	errInvalidMaxAttempts = errors.New("max attempts must be positive")
+	errNegativeBackoff    = errors.New("backoff can't be negative")
+	errMaxBackoffTooLow   = errors.New("max backoff can't be less than the initial backoff")
+)
+
+// StatusError is returned when a server responds with an unsuccessful status
+// code
+type StatusError struct {
+	URL        string
+	StatusCode int
+	// RequestID the server gave the request, if any. It's included in the
+	// server's logs about the request.
+	RequestID string
+}
+
+func (e *StatusError) Error() string {
+	if e.RequestID == "" {
+		return fmt.Sprintf("received status code %d from %s", e.StatusCode, e.URL)
+	}
+	return fmt.Sprintf("received status code %d from %s for request %s", e.StatusCode, e.URL, e.RequestID)
+}
+
+// RetryConfig describes how a requester retries requests that fail
+// transiently. Requests may be sent more than once, so only calls that are
+// safe to repeat, such as issuing the same transaction, should be retried.
+type RetryConfig struct {
+	// MaxAttempts is the number of times a request is sent before its failure
+	// is returned. If 1, requests aren't retried.
+	MaxAttempts int
+	// InitialBackoff is about how long is waited before a failed request is
+	// first retried. The backoff doubles after each attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum backoff
+	MaxBackoff time.Duration
+	// Retryable returns true if a request that failed with [err] may be
+	// retried. If nil, IsRetryable is used.
+	Retryable func(err error) bool
+}
+
+// DefaultRetryConfig returns the default retry configuration
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:    DefaultMaxAttempts,
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+	}
+}
+
+// Verify returns an error if this configuration is invalid
+func (c RetryConfig) Verify() error {
+	switch {
+	case c.MaxAttempts <= 0:
+		return errInvalidMaxAttempts
+	case c.InitialBackoff < 0:
+		return errNegativeBackoff
+	case c.MaxBackoff < c.InitialBackoff:
+		return errMaxBackoffTooLow
+	default:
+		return nil
+	}
+}
+
+func (c RetryConfig) retryable(err error) bool {
+	if c.Retryable != nil {
+		return c.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// retry calls [send], with the number of the attempt, until it succeeds, it returns an error that isn't
+// retryable, or it has been called [c.MaxAttempts] times. Returns the last
+// error returned by [send], or the error of [ctx] if it's done while waiting
+// to retry.
+func (c RetryConfig) retry(ctx context.Context, send func(attempt int) error) error {
+	backoff := c.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := send(attempt)
+		if err == nil || attempt >= c.MaxAttempts || !c.retryable(err) {
+			return err
+		}
+
+		// The backoff is jittered to keep clients that failed at the same
+		// time from retrying at the same time. This doesn't require
+		// cryptographically secure random number generation.
+		timer := time.NewTimer(time.Duration(float64(backoff) * (1 + rand.Float64()) / 2)) // #nosec G404
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if backoff > c.MaxBackoff {
+			backoff = c.MaxBackoff
+		}
+	}
+}
+
+// IsRetryable returns true if [err] is likely transient. That is, if the
+// connection to the server was refused, reset or timed out, or the server
+// responded that it's temporarily unable to handle the request. Errors
+// returned by the called method aren't retryable.
+func IsRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,110 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func testRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+	}
+}
+
+// newTestServer returns a server that responds with [failures] 503s before
+// responding with a JSON-RPC result of true, and a pointer to the number of
+// requests it received
+func newTestServer(failures int) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":true,"id":1}`))
+	}))
+	return server, &requests
+}
+
+func TestEndpointRequesterRetries(t *testing.T) {
+	server, requests := newTestServer(2)
+	defer server.Close()
+
+	requester, err := NewEndpointRequesterWithRetries(server.URL, "/ext/test", "test", time.Second, testRetryConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply := false
+	if err := requester.SendRequest("method", struct{}{}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply {
+		t.Fatal("expected reply to be true")
+	}
+	if *requests != 3 {
+		t.Fatalf("expected 3 requests but got %d", *requests)
+	}
+}
+
+func TestEndpointRequesterMaxAttempts(t *testing.T) {
+	server, requests := newTestServer(3)
+	defer server.Close()
+
+	requester, err := NewEndpointRequesterWithRetries(server.URL, "/ext/test", "test", time.Second, testRetryConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply := false
+	err = requester.SendRequest("method", struct{}{}, &reply)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected status error but got %v", err)
+	}
+	if *requests != 3 {
+		t.Fatalf("expected 3 requests but got %d", *requests)
+	}
+}
+
+func TestEndpointRequesterMethodErrorNotRetried(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32000,"message":"failed"},"id":1}`))
+	}))
+	defer server.Close()
+
+	requester, err := NewEndpointRequesterWithRetries(server.URL, "/ext/test", "test", time.Second, testRetryConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply := false
+	if err := requester.SendRequest("method", struct{}{}, &reply); err == nil {
+		t.Fatal("should have failed")
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 request but got %d", requests)
+	}
+}
+
+func TestRetryConfigVerify(t *testing.T) {
+	config := testRetryConfig()
+	config.MaxAttempts = 0
+	if err := config.Verify(); err != errInvalidMaxAttempts {
+		t.Fatalf("expected %s but got %v", errInvalidMaxAttempts, err)
+	}
+
+	config = testRetryConfig()
+	config.MaxBackoff = 0
+	if err := config.Verify(); err != errMaxBackoffTooLow {
+		t.Fatalf("expected %s but got %v", errMaxBackoffTooLow, err)
+	}
+}
@@ -0,0 +1,105 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultMaxIdleConns is the default maximum number of idle connections
+	// kept open across all hosts
+	DefaultMaxIdleConns = 100
+
+	// DefaultMaxIdleConnsPerHost is the default maximum number of idle
+	// connections kept open to each host
+	DefaultMaxIdleConnsPerHost = 16
+
+	// DefaultIdleConnTimeout is the default amount of time an idle connection
+	// is kept open
+	DefaultIdleConnTimeout = 90 * time.Second
+
+	// DefaultDialTimeout is the default amount of time a connection may take
+	// to be established
+	DefaultDialTimeout = 30 * time.Second
+
+	// DefaultKeepAlive is the default interval between TCP keep-alive probes
+	DefaultKeepAlive = 30 * time.Second
+)
+
+// sharedTransport pools the connections of requesters that aren't given a
+// client
+var sharedTransport = NewTransport(DefaultTransportConfig())
+
+// TransportConfig describes how connections to nodes are made and pooled
+type TransportConfig struct {
+	// MaxIdleConns is the maximum number of idle connections kept open across
+	// all hosts. If 0, there is no limit.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept open
+	// to each host
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept open. If 0, idle
+	// connections are kept open until the server closes them.
+	IdleConnTimeout time.Duration
+	// DisableKeepAlives makes each request use a new connection
+	DisableKeepAlives bool
+	// DialTimeout is how long a connection may take to be established
+	DialTimeout time.Duration
+	// KeepAlive is the interval between TCP keep-alive probes
+	KeepAlive time.Duration
+	// TLSConfig is used to connect to nodes over HTTPS. If nil, the default
+	// configuration is used.
+	TLSConfig *tls.Config
+}
+
+// DefaultTransportConfig returns the default transport configuration
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		MaxIdleConns:        DefaultMaxIdleConns,
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
+		DialTimeout:         DefaultDialTimeout,
+		KeepAlive:           DefaultKeepAlive,
+	}
+}
+
+// NewTransport returns a transport that makes and pools connections as
+// described by [config]. Clients that share the transport share its
+// connections.
+func NewTransport(config TransportConfig) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   config.DialTimeout,
+		KeepAlive: config.KeepAlive,
+	}
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		DisableKeepAlives:     config.DisableKeepAlives,
+		TLSClientConfig:       config.TLSConfig,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// NewClient returns a client that times out requests after [requestTimeout]
+// and sends them over [transport]
+func NewClient(transport http.RoundTripper, requestTimeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+	}
+}
+
+// newClient returns a client that times out requests after [requestTimeout]
+// and pools its connections with other requesters
+func newClient(requestTimeout time.Duration) *http.Client {
+	return NewClient(sharedTransport, requestTimeout)
+}
@@ -0,0 +1,48 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRequesterReusesConnections(t *testing.T) {
+	connsLock := sync.Mutex{}
+	conns := 0
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":true,"id":1}`))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connsLock.Lock()
+			conns++
+			connsLock.Unlock()
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := NewClient(NewTransport(DefaultTransportConfig()), time.Second)
+	requester, err := NewEndpointRequesterWithClient(server.URL, "/ext/test", "test", client, testRetryConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		reply := false
+		if err := requester.SendRequest("method", struct{}{}, &reply); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	connsLock.Lock()
+	defer connsLock.Unlock()
+	if conns != 1 {
+		t.Fatalf("expected 1 connection but got %d", conns)
+	}
+}
@@ -29,3 +29,22 @@ func NewWeighted() Weighted {
 		benchmarkIterations: 100,
 	}
 }
+
+// UpdatableWeighted is a Weighted sampler whose weights can be changed after
+// it was initialized, without initializing it again
+type UpdatableWeighted interface {
+	Weighted
+
+	// Update sets the weight of the element at [index]. If [index] is the
+	// number of elements, the element is appended.
+	Update(index int, weight uint64) error
+
+	// Truncate removes all but the first [length] elements
+	Truncate(length int) error
+
+	// TotalWeight returns the sum of the weights of the elements
+	TotalWeight() uint64
+}
+
+// NewUpdatableWeighted returns a new sampler whose weights can be updated
+func NewUpdatableWeighted() UpdatableWeighted { return &weightedTree{} }
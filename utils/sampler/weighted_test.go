@@ -28,6 +28,10 @@ var (
 			name:    "linear scan",
 			sampler: &weightedLinear{},
 		},
+		{
+			name:    "fenwick tree",
+			sampler: &weightedTree{},
+		},
 		{
 			name: "lookup",
 			sampler: &weightedUniform{
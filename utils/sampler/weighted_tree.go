@@ -0,0 +1,156 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sampler
+
+import (
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// weightedTree implements the UpdatableWeighted interface.
+//
+// Sampling is performed by descending a Fenwick tree over the weights. The
+// i-th node of the tree (1-indexed) holds the sum of the weights of the
+// elements in (i - lowbit(i), i], where lowbit(i) is the lowest set bit of i.
+//
+// Initialization takes O(n) time, where n is the number of elements that can
+// be sampled.
+// Sampling takes O(log(n)) time.
+// Updating, appending or truncating elements takes O(log(n)) time.
+type weightedTree struct {
+	weights     []uint64
+	tree        []uint64
+	totalWeight uint64
+}
+
+func (s *weightedTree) Initialize(weights []uint64) error {
+	totalWeight := uint64(0)
+	for _, weight := range weights {
+		newWeight, err := safemath.Add64(totalWeight, weight)
+		if err != nil {
+			return err
+		}
+		totalWeight = newWeight
+	}
+
+	s.weights = append(s.weights[:0], weights...)
+	if len(weights)+1 > cap(s.tree) {
+		s.tree = make([]uint64, len(weights)+1)
+	} else {
+		s.tree = s.tree[:len(weights)+1]
+		for i := range s.tree {
+			s.tree[i] = 0
+		}
+	}
+	for i := 1; i < len(s.tree); i++ {
+		s.tree[i] += weights[i-1]
+		// None of the partial sums can overflow, as the total weight didn't
+		if parent := i + i&-i; parent < len(s.tree) {
+			s.tree[parent] += s.tree[i]
+		}
+	}
+	s.totalWeight = totalWeight
+	return nil
+}
+
+func (s *weightedTree) Sample(value uint64) (int, error) {
+	if s.totalWeight <= value {
+		return 0, errOutOfRange
+	}
+
+	// Find the largest index whose prefix sum is at most [value]. The element
+	// after that index is the sampled one.
+	index := 0
+	for step := highestPowerOfTwo(len(s.weights)); step > 0; step >>= 1 {
+		if next := index + step; next < len(s.tree) && s.tree[next] <= value {
+			index = next
+			value -= s.tree[next]
+		}
+	}
+	return index, nil
+}
+
+func (s *weightedTree) Update(index int, weight uint64) error {
+	switch {
+	case index == len(s.weights):
+		return s.append(weight)
+	case index < 0 || index > len(s.weights):
+		return errOutOfRange
+	}
+
+	oldWeight := s.weights[index]
+	if weight >= oldWeight {
+		delta := weight - oldWeight
+		newTotalWeight, err := safemath.Add64(s.totalWeight, delta)
+		if err != nil {
+			return err
+		}
+		for i := index + 1; i < len(s.tree); i += i & -i {
+			s.tree[i] += delta
+		}
+		s.totalWeight = newTotalWeight
+	} else {
+		delta := oldWeight - weight
+		for i := index + 1; i < len(s.tree); i += i & -i {
+			s.tree[i] -= delta
+		}
+		s.totalWeight -= delta
+	}
+	s.weights[index] = weight
+	return nil
+}
+
+func (s *weightedTree) Truncate(length int) error {
+	if length < 0 || length > len(s.weights) {
+		return errOutOfRange
+	}
+	// The nodes of the remaining elements only cover the remaining elements,
+	// so they don't need to be updated
+	for _, weight := range s.weights[length:] {
+		s.totalWeight -= weight
+	}
+	s.weights = s.weights[:length]
+	s.tree = s.tree[:length+1]
+	return nil
+}
+
+func (s *weightedTree) TotalWeight() uint64 { return s.totalWeight }
+
+// append [weight] as the last element
+func (s *weightedTree) append(weight uint64) error {
+	newTotalWeight, err := safemath.Add64(s.totalWeight, weight)
+	if err != nil {
+		return err
+	}
+
+	if len(s.tree) == 0 {
+		s.tree = append(s.tree, 0)
+	}
+	i := len(s.tree)
+	// The new node covers the new element and the elements in
+	// (i - lowbit(i), i - 1]
+	node := weight + s.prefixWeight(i-1) - s.prefixWeight(i-i&-i)
+	s.weights = append(s.weights, weight)
+	s.tree = append(s.tree, node)
+	s.totalWeight = newTotalWeight
+	return nil
+}
+
+// prefixWeight returns the sum of the weights of the first [length] elements
+func (s *weightedTree) prefixWeight(length int) uint64 {
+	sum := uint64(0)
+	for i := length; i > 0; i -= i & -i {
+		sum += s.tree[i]
+	}
+	return sum
+}
+
+// highestPowerOfTwo returns the largest power of two that's at most [n], or 0
+// if [n] is 0
+func highestPowerOfTwo(n int) int {
+	power := 0
+	for next := 1; next <= n; next <<= 1 {
+		power = next
+	}
+	return power
+}
@@ -0,0 +1,99 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sampler
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeightedTreeUpdate(t *testing.T) {
+	s := &weightedTree{}
+
+	err := s.Initialize([]uint64{1, 2, 3})
+	assert.NoError(t, err)
+
+	err = s.Update(1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), s.TotalWeight())
+
+	err = s.Update(0, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), s.TotalWeight())
+
+	counts := make([]int, 3)
+	for i := uint64(0); i < 8; i++ {
+		index, err := s.Sample(i)
+		assert.NoError(t, err)
+		counts[index]++
+	}
+	assert.Equal(t, []int{5, 0, 3}, counts, "wrong distribution returned")
+}
+
+func TestWeightedTreeAppend(t *testing.T) {
+	s := &weightedTree{}
+
+	weights := []uint64{3, 0, 1, 4, 1, 5, 9, 2, 6}
+	for i, weight := range weights {
+		err := s.Update(i, weight)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, uint64(31), s.TotalWeight())
+
+	counts := make([]uint64, len(weights))
+	for i := uint64(0); i < s.TotalWeight(); i++ {
+		index, err := s.Sample(i)
+		assert.NoError(t, err)
+		counts[index]++
+	}
+	assert.Equal(t, weights, counts, "wrong distribution returned")
+}
+
+func TestWeightedTreeTruncate(t *testing.T) {
+	s := &weightedTree{}
+
+	err := s.Initialize([]uint64{1, 2, 3, 4})
+	assert.NoError(t, err)
+
+	err = s.Truncate(2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), s.TotalWeight())
+
+	_, err = s.Sample(3)
+	assert.Error(t, err, "should have reported an out of range error")
+
+	err = s.Update(2, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), s.TotalWeight())
+
+	index, err := s.Sample(7)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, index, "should have selected the appended element")
+}
+
+func TestWeightedTreeUpdateErrors(t *testing.T) {
+	s := &weightedTree{}
+
+	err := s.Initialize([]uint64{1, 1})
+	assert.NoError(t, err)
+
+	err = s.Update(3, 1)
+	assert.Error(t, err, "should have reported an out of range error")
+
+	err = s.Update(-1, 1)
+	assert.Error(t, err, "should have reported an out of range error")
+
+	err = s.Truncate(3)
+	assert.Error(t, err, "should have reported an out of range error")
+
+	err = s.Update(0, math.MaxUint64)
+	assert.Error(t, err, "should have reported an overflow error")
+	assert.Equal(t, uint64(2), s.TotalWeight())
+
+	err = s.Update(2, math.MaxUint64)
+	assert.Error(t, err, "should have reported an overflow error")
+	assert.Equal(t, uint64(2), s.TotalWeight())
+}
@@ -19,6 +19,42 @@ func NewWeightedWithoutReplacement() WeightedWithoutReplacement {
 	}
 }
 
+// UpdatableWeightedWithoutReplacement is a WeightedWithoutReplacement sampler
+// whose weights can be changed after it was initialized, without initializing
+// it again
+type UpdatableWeightedWithoutReplacement interface {
+	WeightedWithoutReplacement
+
+	// Update sets the weight of the element at [index]. If [index] is the
+	// number of elements, the element is appended.
+	Update(index int, weight uint64) error
+
+	// Truncate removes all but the first [length] elements
+	Truncate(length int) error
+}
+
+// NewUpdatableWeightedWithoutReplacement returns a new sampler whose weights
+// can be updated
+func NewUpdatableWeightedWithoutReplacement() UpdatableWeightedWithoutReplacement {
+	return &weightedWithoutReplacementUpdatable{
+		u:     NewUniform(),
+		w:     NewUpdatableWeighted(),
+		stale: true,
+	}
+}
+
+// NewBestUpdatableWeightedWithoutReplacement returns a new sampler whose
+// weights can be updated
+func NewBestUpdatableWeightedWithoutReplacement(
+	expectedSampleSize int,
+) UpdatableWeightedWithoutReplacement {
+	return &weightedWithoutReplacementUpdatable{
+		u:     NewBestUniform(expectedSampleSize),
+		w:     NewUpdatableWeighted(),
+		stale: true,
+	}
+}
+
 // NewBestWeightedWithoutReplacement returns a new sampler
 func NewBestWeightedWithoutReplacement(
 	expectedSampleSize int,
@@ -33,6 +33,13 @@ var (
 				},
 			},
 		},
+		{
+			name: "updatable with replacer and fenwick tree",
+			sampler: &weightedWithoutReplacementUpdatable{
+				u: &uniformReplacer{},
+				w: &weightedTree{},
+			},
+		},
 	}
 	weightedWithoutReplacementTests = []struct {
 		name string
@@ -169,3 +176,37 @@ func WeightedWithoutReplacementDistributionTest(
 		"should have selected all the elements",
 	)
 }
+
+func TestWeightedWithoutReplacementUpdatable(t *testing.T) {
+	s := NewUpdatableWeightedWithoutReplacement()
+
+	err := s.Initialize([]uint64{1, 1})
+	assert.NoError(t, err)
+
+	err = s.Update(0, 0)
+	assert.NoError(t, err)
+
+	err = s.Update(2, 2)
+	assert.NoError(t, err)
+
+	indices, err := s.Sample(3)
+	assert.NoError(t, err)
+
+	sort.Ints(indices)
+	assert.Equal(
+		t,
+		[]int{1, 2, 2},
+		indices,
+		"should have selected all the updated elements",
+	)
+
+	err = s.Truncate(2)
+	assert.NoError(t, err)
+
+	indices, err = s.Sample(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, indices, "should have selected the second element")
+
+	_, err = s.Sample(2)
+	assert.Error(t, err, "should have reported an out of range error")
+}
@@ -0,0 +1,59 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sampler
+
+// weightedWithoutReplacementUpdatable implements the
+// UpdatableWeightedWithoutReplacement interface.
+//
+// Sampling is performed like weightedWithoutReplacementGeneric. The uniform
+// sampler is only initialized again once the weights have changed and a
+// sample is requested, so a series of updates is applied in O(log(n)) time
+// each.
+type weightedWithoutReplacementUpdatable struct {
+	u Uniform
+	w UpdatableWeighted
+
+	// true if the total weight changed since [u] was initialized
+	stale bool
+}
+
+func (s *weightedWithoutReplacementUpdatable) Initialize(weights []uint64) error {
+	if err := s.w.Initialize(weights); err != nil {
+		return err
+	}
+	s.stale = false
+	return s.u.Initialize(s.w.TotalWeight())
+}
+
+func (s *weightedWithoutReplacementUpdatable) Update(index int, weight uint64) error {
+	s.stale = true
+	return s.w.Update(index, weight)
+}
+
+func (s *weightedWithoutReplacementUpdatable) Truncate(length int) error {
+	s.stale = true
+	return s.w.Truncate(length)
+}
+
+func (s *weightedWithoutReplacementUpdatable) Sample(count int) ([]int, error) {
+	if s.stale {
+		if err := s.u.Initialize(s.w.TotalWeight()); err != nil {
+			return nil, err
+		}
+		s.stale = false
+	}
+
+	weights, err := s.u.Sample(count)
+	if err != nil {
+		return nil, err
+	}
+	indices := make([]int, count)
+	for i, weight := range weights {
+		indices[i], err = s.w.Sample(weight)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return indices, nil
+}
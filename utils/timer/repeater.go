@@ -69,12 +69,28 @@ func (r *Repeater) Dispatch() {
 			r.handler()
 		}
 
-		timer.Reset(r.frequency)
-
 		r.lock.Lock()
+		timer.Reset(r.frequency)
 	}
 }
 
+// SetFrequency sets the time between calls of the handler, starting after the
+// next call
+func (r *Repeater) SetFrequency(frequency time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.frequency = frequency
+}
+
+// Frequency returns the time between calls of the handler
+func (r *Repeater) Frequency() time.Duration {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.frequency
+}
+
 func (r *Repeater) reset() {
 	select {
 	case r.timeout <- struct{}{}:
@@ -5,8 +5,11 @@ package wrappers
 
 import (
 	"bytes"
+	"net"
 	"reflect"
 	"testing"
+
+	"github.com/ava-labs/avalanchego/utils"
 )
 
 const (
@@ -581,3 +584,40 @@ func TestPacker2DByteSlice(t *testing.T) {
 		t.Fatal("should match")
 	}
 }
+
+func TestPackerIPs(t *testing.T) {
+	ips := []utils.IPDesc{
+		{IP: net.IPv4(1, 2, 3, 4), Port: 9651},
+		{IP: net.ParseIP("2001:db8::1"), Port: 9651},
+		{IP: net.IPv6loopback, Port: 1},
+	}
+
+	p := Packer{MaxSize: 1024}
+	p.PackIPs(ips)
+	if p.Errored() {
+		t.Fatal(p.Err)
+	}
+	if expected := IntLen + len(ips)*(net.IPv6len+ShortLen); len(p.Bytes) != expected {
+		t.Fatalf("expected %d bytes but got %d", expected, len(p.Bytes))
+	}
+
+	p = Packer{Bytes: p.Bytes}
+	unpacked := p.UnpackIPs()
+	if p.Errored() {
+		t.Fatal(p.Err)
+	}
+	if len(unpacked) != len(ips) {
+		t.Fatalf("expected %d IPs but got %d", len(ips), len(unpacked))
+	}
+	for i, ip := range ips {
+		if !ip.Equal(unpacked[i]) {
+			t.Fatalf("expected %s but got %s", ip, unpacked[i])
+		}
+	}
+	if str := unpacked[0].String(); str != "1.2.3.4:9651" {
+		t.Fatalf("expected the IPv4 address to be formatted as 1.2.3.4:9651 but got %s", str)
+	}
+	if str := unpacked[1].String(); str != "[2001:db8::1]:9651" {
+		t.Fatalf("expected the IPv6 address to be formatted as [2001:db8::1]:9651 but got %s", str)
+	}
+}
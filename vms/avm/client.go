@@ -0,0 +1,370 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	stdjson "encoding/json"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+// Client for interacting with an AVM (X-Chain) instance
+type Client struct {
+	requester rpc.EndpointRequester
+	// URL of the chain's API, and the client UTXOs are streamed with
+	url          string
+	streamClient *http.Client
+}
+
+// NewClient returns an AVM client for interacting with the chain [chain],
+// which may be a blockchain ID or alias, such as X
+func NewClient(uri, chain string, requestTimeout time.Duration, options ...rpc.Option) *Client {
+	base := fmt.Sprintf("/ext/bc/%s", chain)
+	return &Client{
+		requester:    rpc.NewEndpointRequester(uri, base, "avm", requestTimeout, options...),
+		url:          uri + base,
+		streamClient: rpc.NewStreamingClient(options...),
+	}
+}
+
+// IssueTx issues the transaction [txBytes] and returns its txID
+func (c *Client) IssueTx(txBytes []byte) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("issueTx", &api.FormattedTx{
+		Tx:       formatting.CB58{}.ConvertBytes(txBytes),
+		Encoding: formatting.CB58Encoding,
+	}, res)
+	return res.TxID, err
+}
+
+// GetTxStatus returns the status of the transaction with ID [txID]
+func (c *Client) GetTxStatus(txID ids.ID) (choices.Status, error) {
+	res := &GetTxStatusReply{}
+	err := c.requester.SendRequest("getTxStatus", &api.JSONTxID{
+		TxID: txID,
+	}, res)
+	return res.Status, err
+}
+
+// GetTxStatuses returns the status of each transaction in [txIDs], fetched in
+// a single batch
+func (c *Client) GetTxStatuses(txIDs []ids.ID) ([]choices.Status, error) {
+	replies := make([]GetTxStatusReply, len(txIDs))
+	calls := make([]*rpc.BatchCall, len(txIDs))
+	for i, txID := range txIDs {
+		calls[i] = rpc.NewBatchCall("getTxStatus", &api.JSONTxID{TxID: txID}, &replies[i])
+	}
+	if err := c.requester.SendBatch(calls); err != nil {
+		return nil, err
+	}
+	if err := rpc.FirstErr(calls); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]choices.Status, len(replies))
+	for i, reply := range replies {
+		statuses[i] = reply.Status
+	}
+	return statuses, nil
+}
+
+// GetTx returns the byte representation of the transaction with ID [txID]
+func (c *Client) GetTx(txID ids.ID) ([]byte, error) {
+	res := &api.FormattedTx{}
+	err := c.requester.SendRequest("getTx", &api.GetTxArgs{
+		TxID:     txID,
+		Encoding: formatting.CB58Encoding,
+	}, res)
+	if err != nil {
+		return nil, err
+	}
+	return formatting.CB58{}.ConvertString(res.Tx)
+}
+
+// AwaitTx waits for the transaction [txID] to be accepted or rejected. If the
+// transaction remains pending for longer than [config.TTL], [txBytes] are
+// re-issued, at most [config.MaxResubmissions] times.
+func (c *Client) AwaitTx(ctx context.Context, txID ids.ID, txBytes []byte, config rpc.ResubmitConfig) (rpc.TxOutcome, error) {
+	return rpc.AwaitTx(
+		ctx,
+		config,
+		func() error {
+			_, err := c.IssueTx(txBytes)
+			return err
+		},
+		func() (rpc.TxState, error) {
+			status, err := c.GetTxStatus(txID)
+			switch {
+			case err != nil:
+				return rpc.TxPending, err
+			case status == choices.Accepted:
+				return rpc.TxAccepted, nil
+			case status == choices.Rejected:
+				return rpc.TxRejected, nil
+			default:
+				return rpc.TxPending, nil
+			}
+		},
+	)
+}
+
+// IssueTxAndAwait issues [txBytes] and waits for the transaction to be
+// decided, re-issuing it as described by [config]
+func (c *Client) IssueTxAndAwait(ctx context.Context, txBytes []byte, config rpc.ResubmitConfig) (ids.ID, rpc.TxOutcome, error) {
+	txID, err := c.IssueTx(txBytes)
+	if err != nil {
+		return txID, rpc.TxOutcome{}, err
+	}
+	outcome, err := c.AwaitTx(ctx, txID, txBytes, config)
+	return txID, outcome, err
+}
+
+// GetUTXOs returns the byte representation of up to [limit] UTXOs that
+// reference [addrs], starting after [startIndex], and the index to start at
+// to get the next page
+func (c *Client) GetUTXOs(addrs []string, limit uint32, startIndex Index) ([][]byte, Index, error) {
+	res := &GetUTXOsReply{}
+	err := c.requester.SendRequest("getUTXOs", &GetUTXOsArgs{
+		Addresses:  addrs,
+		Limit:      json.Uint32(limit),
+		StartIndex: startIndex,
+		Encoding:   formatting.CB58Encoding,
+	}, res)
+	if err != nil {
+		return nil, Index{}, err
+	}
+
+	utxos := make([][]byte, len(res.UTXOs))
+	for i, utxo := range res.UTXOs {
+		utxoBytes, err := formatting.CB58{}.ConvertString(utxo)
+		if err != nil {
+			return nil, Index{}, err
+		}
+		utxos[i] = utxoBytes
+	}
+	return utxos, res.EndIndex, nil
+}
+
+// StreamUTXOs calls [onUTXO] with the byte representation of each UTXO that
+// references [addrs] and was exported from [sourceChain], or is on this chain
+// if [sourceChain] is empty, starting after [startIndex]. UTXOs are streamed
+// as they're read, rather than a page at a time, and reading them is paused
+// while [onUTXO] runs. If [onUTXO] returns an error, streaming stops and the
+// error is returned. Returns the index of the last UTXO streamed. The stream
+// is canceled if [ctx] is done.
+func (c *Client) StreamUTXOs(
+	ctx context.Context,
+	addrs []string,
+	sourceChain string,
+	startIndex Index,
+	onUTXO func(utxo []byte) error,
+) (Index, error) {
+	query := url.Values{}
+	query["address"] = addrs
+	if sourceChain != "" {
+		query.Set("sourceChain", sourceChain)
+	}
+	if startIndex.Address != "" || startIndex.UTXO != "" {
+		query.Set("startAddress", startIndex.Address)
+		query.Set("startUTXO", startIndex.UTXO)
+	}
+	query.Set("encoding", formatting.CB58Encoding)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"/utxos?"+query.Encode(), nil)
+	if err != nil {
+		return Index{}, err
+	}
+	resp, err := c.streamClient.Do(req)
+	if err != nil {
+		return Index{}, fmt.Errorf("problem streaming UTXOs: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return Index{}, fmt.Errorf("problem streaming UTXOs: received status code %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	decoder := stdjson.NewDecoder(resp.Body)
+	for {
+		msg := UTXOStreamMessage{}
+		if err := decoder.Decode(&msg); err != nil {
+			return Index{}, fmt.Errorf("problem reading streamed UTXOs: %w", err)
+		}
+		switch {
+		case msg.Error != "":
+			return Index{}, errors.New(msg.Error)
+		case msg.EndIndex != nil:
+			return *msg.EndIndex, nil
+		}
+		utxo, err := formatting.CB58{}.ConvertString(msg.UTXO)
+		if err != nil {
+			return Index{}, err
+		}
+		if err := onUTXO(utxo); err != nil {
+			return Index{}, err
+		}
+	}
+}
+
+// GetAssetDescription returns a description of [assetID]
+func (c *Client) GetAssetDescription(assetID string) (*GetAssetDescriptionReply, error) {
+	res := &GetAssetDescriptionReply{}
+	err := c.requester.SendRequest("getAssetDescription", &GetAssetDescriptionArgs{
+		AssetID: assetID,
+	}, res)
+	return res, err
+}
+
+// GetBalance returns the balance of [assetID] held by [address]
+func (c *Client) GetBalance(address, assetID string) (*GetBalanceReply, error) {
+	res := &GetBalanceReply{}
+	err := c.requester.SendRequest("getBalance", &GetBalanceArgs{
+		Address: address,
+		AssetID: assetID,
+	}, res)
+	return res, err
+}
+
+// GetAllBalances returns all asset balances for [address]
+func (c *Client) GetAllBalances(address string) (*GetAllBalancesReply, error) {
+	res := &GetAllBalancesReply{}
+	err := c.requester.SendRequest("getAllBalances", &api.JSONAddress{
+		Address: address,
+	}, res)
+	return res, err
+}
+
+// CreateAddress creates a new address controlled by [user]
+func (c *Client) CreateAddress(user api.UserPass) (string, error) {
+	res := &api.JSONAddress{}
+	err := c.requester.SendRequest("createAddress", &user, res)
+	return res.Address, err
+}
+
+// ListAddresses returns all addresses on this chain controlled by [user]
+func (c *Client) ListAddresses(user api.UserPass) ([]string, error) {
+	res := &api.JSONAddresses{}
+	err := c.requester.SendRequest("listAddresses", &user, res)
+	return res.Addresses, err
+}
+
+// ExportKey returns the private key corresponding to [addr] controlled by
+// [user]
+func (c *Client) ExportKey(user api.UserPass, addr string) (string, error) {
+	res := &ExportKeyReply{}
+	err := c.requester.SendRequest("exportKey", &ExportKeyArgs{
+		UserPass: user,
+		Address:  addr,
+	}, res)
+	return res.PrivateKey, err
+}
+
+// ImportKey imports [privateKey] to [user]
+func (c *Client) ImportKey(user api.UserPass, privateKey string) (string, error) {
+	res := &api.JSONAddress{}
+	err := c.requester.SendRequest("importKey", &ImportKeyArgs{
+		UserPass:   user,
+		PrivateKey: privateKey,
+	}, res)
+	return res.Address, err
+}
+
+// Send [amount] of [assetID] to address [to] and returns the txID
+func (c *Client) Send(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	amount uint64,
+	assetID,
+	to,
+	memo string,
+) (ids.ID, error) {
+	res := &api.JSONTxIDChangeAddr{}
+	err := c.requester.SendRequest("send", &SendArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		SendOutput: SendOutput{
+			Amount:  json.Uint64(amount),
+			AssetID: assetID,
+			To:      to,
+		},
+		From: from,
+		Memo: memo,
+	}, res)
+	return res.TxID, err
+}
+
+// Mint [amount] of [assetID] to be owned by [to] and returns the txID
+func (c *Client) Mint(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	amount uint64,
+	assetID,
+	to string,
+) (ids.ID, error) {
+	res := &api.JSONTxIDChangeAddr{}
+	err := c.requester.SendRequest("mint", &MintArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		Amount:  json.Uint64(amount),
+		AssetID: assetID,
+		To:      to,
+	}, res)
+	return res.TxID, err
+}
+
+// ImportAVAX imports AVAX exported from [sourceChain] to address [to] and
+// returns the txID
+func (c *Client) ImportAVAX(user api.UserPass, to, sourceChain string) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("importAVAX", &ImportArgs{
+		UserPass:    user,
+		To:          to,
+		SourceChain: sourceChain,
+	}, res)
+	return res.TxID, err
+}
+
+// ExportAVAX sends [amount] of AVAX to address [to], on the chain the address
+// is prefixed with, and returns the txID
+func (c *Client) ExportAVAX(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	amount uint64,
+	to string,
+) (ids.ID, error) {
+	res := &api.JSONTxIDChangeAddr{}
+	err := c.requester.SendRequest("exportAVAX", &ExportAVAXArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		Amount: json.Uint64(amount),
+		To:     to,
+	}, res)
+	return res.TxID, err
+}
@@ -4,6 +4,8 @@
 package avm
 
 import (
+	"time"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 )
@@ -17,6 +19,10 @@ var (
 type Factory struct {
 	CreationFee uint64
 	Fee         uint64
+
+	// Longest time pending transactions are held to be batched before being
+	// issued to consensus. If 0, the default is used.
+	BatchTimeout time.Duration
 }
 
 // New ...
@@ -24,5 +30,6 @@ func (f *Factory) New(*snow.Context) (interface{}, error) {
 	return &VM{
 		creationTxFee: f.CreationFee,
 		txFee:         f.Fee,
+		batchTimeout:  f.BatchTimeout,
 	}, nil
 }
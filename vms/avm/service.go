@@ -4,6 +4,7 @@
 package avm
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -11,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -40,7 +42,6 @@ var (
 	errNoMinters              = errors.New("no minters provided")
 	errInvalidAmount          = errors.New("amount must be positive")
 	errNoOutputs              = errors.New("no outputs to send")
-	errSpendOverflow          = errors.New("spent amount overflows uint64")
 	errInvalidMintAmount      = errors.New("amount minted must be positive")
 	errAddressesCantMintAsset = errors.New("provided addresses don't have the authority to mint the provided asset")
 	errInvalidUTXO            = errors.New("invalid utxo")
@@ -183,63 +184,27 @@ func (service *Service) GetUTXOs(r *http.Request, args *GetUTXOsArgs, reply *Get
 		return fmt.Errorf("problem getting encoding formatter for '%s': %w", args.Encoding, err)
 	}
 
-	sourceChain := ids.ID{}
-	if args.SourceChain == "" {
-		sourceChain = service.vm.ctx.ChainID
-	} else {
-		chainID, err := service.vm.ctx.BCLookup.Lookup(args.SourceChain)
-		if err != nil {
-			return fmt.Errorf("problem parsing source chainID %q: %w", args.SourceChain, err)
-		}
-		sourceChain = chainID
+	sourceChain, err := service.vm.parseSourceChain(args.SourceChain)
+	if err != nil {
+		return err
 	}
-
-	addrSet := ids.ShortSet{}
-	for _, addrStr := range args.Addresses {
-		addr, err := service.vm.ParseLocalAddress(addrStr)
-		if err != nil {
-			return fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
-		}
-		addrSet.Add(addr)
+	addrSet, err := service.vm.parseAddresses(args.Addresses)
+	if err != nil {
+		return err
 	}
-
-	startAddr := ids.ShortEmpty
-	startUTXO := ids.Empty
-	if args.StartIndex.Address != "" || args.StartIndex.UTXO != "" {
-		addr, err := service.vm.ParseLocalAddress(args.StartIndex.Address)
-		if err != nil {
-			return fmt.Errorf("couldn't parse start index address %q: %w", args.StartIndex.Address, err)
-		}
-		utxo, err := ids.FromString(args.StartIndex.UTXO)
-		if err != nil {
-			return fmt.Errorf("couldn't parse start index utxo: %w", err)
-		}
-
-		startAddr = addr
-		startUTXO = utxo
+	startAddr, startUTXO, err := service.vm.parseIndex(args.StartIndex)
+	if err != nil {
+		return err
 	}
 
-	var (
-		utxos     []*avax.UTXO
-		endAddr   ids.ShortID
-		endUTXOID ids.ID
+	utxos, endAddr, endUTXOID, err := service.vm.getUTXOsFrom(
+		api.RequestContext(r),
+		sourceChain,
+		addrSet,
+		startAddr,
+		startUTXO,
+		int(args.Limit),
 	)
-	if sourceChain.Equals(service.vm.ctx.ChainID) {
-		utxos, endAddr, endUTXOID, err = service.vm.GetUTXOs(
-			addrSet,
-			startAddr,
-			startUTXO,
-			int(args.Limit),
-		)
-	} else {
-		utxos, endAddr, endUTXOID, err = service.vm.GetAtomicUTXOs(
-			sourceChain,
-			addrSet,
-			startAddr,
-			startUTXO,
-			int(args.Limit),
-		)
-	}
 	if err != nil {
 		return fmt.Errorf("problem retrieving UTXOs: %w", err)
 	}
@@ -265,6 +230,65 @@ func (service *Service) GetUTXOs(r *http.Request, args *GetUTXOsArgs, reply *Get
 	return nil
 }
 
+// parseSourceChain returns the ID of the chain [sourceChain], which may be an
+// alias. If [sourceChain] is empty, it's this chain.
+func (vm *VM) parseSourceChain(sourceChain string) (ids.ID, error) {
+	if sourceChain == "" {
+		return vm.ctx.ChainID, nil
+	}
+	chainID, err := vm.ctx.BCLookup.Lookup(sourceChain)
+	if err != nil {
+		return ids.ID{}, fmt.Errorf("problem parsing source chainID %q: %w", sourceChain, err)
+	}
+	return chainID, nil
+}
+
+// parseAddresses returns the set of addresses [addrStrs]
+func (vm *VM) parseAddresses(addrStrs []string) (ids.ShortSet, error) {
+	addrSet := ids.ShortSet{}
+	for _, addrStr := range addrStrs {
+		addr, err := vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+		}
+		addrSet.Add(addr)
+	}
+	return addrSet, nil
+}
+
+// parseIndex returns the address and UTXO ID of [index]. If [index] is empty,
+// they're empty too.
+func (vm *VM) parseIndex(index Index) (ids.ShortID, ids.ID, error) {
+	if index.Address == "" && index.UTXO == "" {
+		return ids.ShortEmpty, ids.Empty, nil
+	}
+	addr, err := vm.ParseLocalAddress(index.Address)
+	if err != nil {
+		return ids.ShortID{}, ids.ID{}, fmt.Errorf("couldn't parse start index address %q: %w", index.Address, err)
+	}
+	utxo, err := ids.FromString(index.UTXO)
+	if err != nil {
+		return ids.ShortID{}, ids.ID{}, fmt.Errorf("couldn't parse start index utxo: %w", err)
+	}
+	return addr, utxo, nil
+}
+
+// getUTXOsFrom returns the UTXOs that reference [addrs] and are either on
+// this chain or were exported to it from [sourceChain]. See GetUTXOs.
+func (vm *VM) getUTXOsFrom(
+	ctx context.Context,
+	sourceChain ids.ID,
+	addrs ids.ShortSet,
+	startAddr ids.ShortID,
+	startUTXOID ids.ID,
+	limit int,
+) ([]*avax.UTXO, ids.ShortID, ids.ID, error) {
+	if sourceChain.Equals(vm.ctx.ChainID) {
+		return vm.GetUTXOs(ctx, addrs, startAddr, startUTXOID, limit)
+	}
+	return vm.GetAtomicUTXOs(ctx, sourceChain, addrs, startAddr, startUTXOID, limit)
+}
+
 // GetAssetDescriptionArgs are arguments for passing into GetAssetDescription requests
 type GetAssetDescriptionArgs struct {
 	AssetID string `json:"assetID"`
@@ -336,7 +360,7 @@ func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply
 	addrSet := ids.ShortSet{}
 	addrSet.Add(addr)
 
-	utxos, _, _, err := service.vm.GetUTXOs(addrSet, ids.ShortEmpty, ids.Empty, -1)
+	utxos, _, _, err := service.vm.GetUTXOs(api.RequestContext(r), addrSet, ids.ShortEmpty, ids.Empty, -1)
 	if err != nil {
 		return fmt.Errorf("problem retrieving UTXOs: %w", err)
 	}
@@ -387,7 +411,7 @@ func (service *Service) GetAllBalances(r *http.Request, args *api.JSONAddress, r
 	addrSet := ids.ShortSet{}
 	addrSet.Add(address)
 
-	utxos, _, _, err := service.vm.GetUTXOs(addrSet, ids.ShortEmpty, ids.Empty, -1)
+	utxos, _, _, err := service.vm.GetUTXOs(api.RequestContext(r), addrSet, ids.ShortEmpty, ids.Empty, -1)
 	if err != nil {
 		return fmt.Errorf("couldn't get address's UTXOs: %w", err)
 	}
@@ -826,12 +850,10 @@ func (service *Service) CreateAddress(r *http.Request, args *api.UserPass, reply
 		return fmt.Errorf("keystore user has reached its limit of %d addresses", maxKeystoreAddresses)
 	}
 
-	factory := crypto.FactorySECP256K1R{}
-	skIntf, err := factory.NewPrivateKey()
+	sk, err := keystore.NewKey(service.vm.ctx.Keystore, args.Username, args.Password)
 	if err != nil {
 		return fmt.Errorf("problem generating private key: %w", err)
 	}
-	sk := skIntf.(*crypto.PrivateKeySECP256K1R)
 
 	if err := user.SetKey(db, sk); err != nil {
 		return fmt.Errorf("problem saving private key: %w", err)
@@ -1594,7 +1616,7 @@ func (service *Service) Import(_ *http.Request, args *ImportArgs, reply *api.JSO
 		return err
 	}
 
-	atomicUTXOs, _, _, err := service.vm.GetAtomicUTXOs(chainID, kc.Addrs, ids.ShortEmpty, ids.Empty, -1)
+	atomicUTXOs, _, _, err := service.vm.GetAtomicUTXOs(context.Background(), chainID, kc.Addrs, ids.ShortEmpty, ids.Empty, -1)
 	if err != nil {
 		return fmt.Errorf("problem retrieving user's atomic UTXOs: %w", err)
 	}
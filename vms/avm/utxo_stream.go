@@ -0,0 +1,158 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	stdjson "encoding/json"
+
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+// streamUTXOsPageSize is the number of UTXOs read from the database at a time
+// while streaming
+const streamUTXOsPageSize = 1024
+
+// UTXOStreamMessage is a line of the response of the UTXO streaming endpoint.
+// Each line but the last holds a UTXO. The last line holds either the end
+// index and number of UTXOs streamed, or the error streaming failed with.
+type UTXOStreamMessage struct {
+	UTXO string `json:"utxo,omitempty"`
+
+	EndIndex   *Index      `json:"endIndex,omitempty"`
+	NumFetched json.Uint64 `json:"numFetched,omitempty"`
+	Encoding   string      `json:"encoding,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// utxoStreamer streams the UTXOs that reference a set of addresses as
+// newline-delimited JSON. It's the streaming variant of avm.getUTXOs. It takes
+// the query parameters:
+// * address: an address the UTXOs reference. May be given more than once.
+// * sourceChain: the chain the UTXOs were exported from, if any
+// * startAddress and startUTXO: the index to start after, if any
+// * limit: the max number of UTXOs to stream. If 0, every UTXO is streamed.
+// * encoding: the encoding of the UTXOs
+//
+// UTXOs are read a page at a time, with the chain's lock held, and written
+// before the next page is read, so neither the node nor the client buffers
+// every UTXO, and a client that reads slowly slows the stream down rather than
+// the chain. A UTXO that references more than one of the addresses may be
+// streamed more than once.
+type utxoStreamer struct{ vm *VM }
+
+func (s *utxoStreamer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "only GET requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	addrStrs := query["address"]
+	if len(addrStrs) == 0 {
+		http.Error(w, errNoAddresses.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(addrStrs) > maxGetUTXOsAddrs {
+		http.Error(w, fmt.Sprintf("number of addresses given, %d, exceeds maximum, %d", len(addrStrs), maxGetUTXOsAddrs), http.StatusBadRequest)
+		return
+	}
+	limit := 0
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.ParseUint(limitStr, 10, 31)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("couldn't parse limit %q: %s", limitStr, err), http.StatusBadRequest)
+			return
+		}
+		limit = int(parsedLimit)
+	}
+
+	encoding, err := s.vm.encodingManager.GetEncoding(query.Get("encoding"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("problem getting encoding formatter for '%s': %s", query.Get("encoding"), err), http.StatusBadRequest)
+		return
+	}
+	sourceChain, err := s.vm.parseSourceChain(query.Get("sourceChain"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	addrSet, err := s.vm.parseAddresses(addrStrs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	startAddr, startUTXO, err := s.vm.parseIndex(Index{
+		Address: query.Get("startAddress"),
+		UTXO:    query.Get("startUTXO"),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	flusher, _ := w.(http.Flusher)
+	encoder := stdjson.NewEncoder(w)
+	fail := func(err error) {
+		// The status has already been sent, so the error is the last line
+		_ = encoder.Encode(UTXOStreamMessage{Error: err.Error()})
+	}
+
+	numFetched := 0
+	for {
+		pageSize := streamUTXOsPageSize
+		if limit > 0 && limit-numFetched < pageSize {
+			pageSize = limit - numFetched
+		}
+
+		s.vm.ctx.Lock.Lock()
+		utxos, endAddr, endUTXOID, err := s.vm.getUTXOsFrom(r.Context(), sourceChain, addrSet, startAddr, startUTXO, pageSize)
+		s.vm.ctx.Lock.Unlock()
+		if err != nil {
+			fail(fmt.Errorf("problem retrieving UTXOs: %w", err))
+			return
+		}
+
+		for _, utxo := range utxos {
+			b, err := s.vm.codec.Marshal(utxo)
+			if err != nil {
+				fail(fmt.Errorf("problem marshalling UTXO: %w", err))
+				return
+			}
+			if err := encoder.Encode(UTXOStreamMessage{UTXO: encoding.ConvertBytes(b)}); err != nil {
+				return // The client went away
+			}
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		numFetched += len(utxos)
+		if len(utxos) > 0 {
+			startAddr, startUTXO = endAddr, endUTXOID
+		}
+		if len(utxos) < pageSize || (limit > 0 && numFetched >= limit) {
+			break
+		}
+	}
+
+	endAddress, err := s.vm.FormatLocalAddress(startAddr)
+	if err != nil {
+		fail(fmt.Errorf("problem formatting address: %w", err))
+		return
+	}
+	_ = encoder.Encode(UTXOStreamMessage{
+		EndIndex: &Index{
+			Address: endAddress,
+			UTXO:    startUTXO.String(),
+		},
+		NumFetched: json.Uint64(numFetched),
+		Encoding:   encoding.Encoding(),
+	})
+}
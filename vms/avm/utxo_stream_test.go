@@ -0,0 +1,92 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestStreamUTXOs(t *testing.T) {
+	_, vm, _, _ := setup(t)
+	defer func() {
+		vm.ctx.Lock.Lock()
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	rawAddr := ids.GenerateTestShortID()
+	numUTXOs := 10
+	for i := 0; i < numUTXOs; i++ {
+		if err := vm.state.FundUTXO(&avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: vm.ctx.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{rawAddr},
+				},
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	addr, err := vm.FormatLocalAddress(rawAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The streamer takes the chain's lock itself
+	vm.ctx.Lock.Unlock()
+
+	mux := http.NewServeMux()
+	mux.Handle("/ext/bc/X/utxos", &utxoStreamer{vm: vm})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := NewClient(server.URL, "X", time.Second)
+
+	streamed := 0
+	endIndex, err := client.StreamUTXOs(context.Background(), []string{addr}, "", Index{}, func(utxo []byte) error {
+		streamed++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if streamed != numUTXOs {
+		t.Fatalf("expected %d UTXOs but got %d", numUTXOs, streamed)
+	}
+	if endIndex.Address != addr {
+		t.Fatalf("expected end index address %s but got %s", addr, endIndex.Address)
+	}
+
+	// Streaming stops when the callback fails
+	errStop := errors.New("stop")
+	streamed = 0
+	if _, err := client.StreamUTXOs(context.Background(), []string{addr}, "", Index{}, func(utxo []byte) error {
+		streamed++
+		if streamed == 3 {
+			return errStop
+		}
+		return nil
+	}); err != errStop {
+		t.Fatalf("expected %s but got %v", errStop, err)
+	}
+
+	if _, err := client.StreamUTXOs(context.Background(), nil, "", Index{}, func([]byte) error { return nil }); err == nil {
+		t.Fatal("should have failed due to no addresses")
+	}
+}
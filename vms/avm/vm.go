@@ -6,14 +6,13 @@ package avm
 import (
 	"bytes"
 	"container/list"
+	"context"
 	"errors"
 	"fmt"
 	"math"
 	"reflect"
 	"time"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/versiondb"
@@ -30,12 +29,12 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/avax/spend"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	cjson "github.com/ava-labs/avalanchego/utils/json"
-	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 const (
@@ -254,7 +253,9 @@ func (vm *VM) Initialize(
 		vm.FlushTxs()
 	})
 	go ctx.Log.RecoverAndPanic(vm.timer.Dispatch)
-	vm.batchTimeout = batchTimeout
+	if vm.batchTimeout <= 0 {
+		vm.batchTimeout = batchTimeout
+	}
 
 	vm.walletService.vm = vm
 	vm.walletService.pendingTxMap = make(map[[32]byte]*list.Element)
@@ -311,13 +312,13 @@ func (vm *VM) CreateHandlers() map[string]*common.HTTPHandler {
 
 	codec := cjson.NewCodec()
 
-	rpcServer := rpc.NewServer()
+	rpcServer := cjson.NewServer()
 	rpcServer.RegisterCodec(codec, "application/json")
 	rpcServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	// name this service "avm"
 	vm.ctx.Log.AssertNoError(rpcServer.RegisterService(&Service{vm: vm}, "avm"))
 
-	walletServer := rpc.NewServer()
+	walletServer := cjson.NewServer()
 	walletServer.RegisterCodec(codec, "application/json")
 	walletServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	// name this service "avm"
@@ -327,12 +328,13 @@ func (vm *VM) CreateHandlers() map[string]*common.HTTPHandler {
 		"":        {Handler: rpcServer},
 		"/wallet": {Handler: walletServer},
 		"/pubsub": {LockOptions: common.NoLock, Handler: vm.pubsub},
+		"/utxos":  {LockOptions: common.NoLock, Handler: &utxoStreamer{vm: vm}},
 	}
 }
 
 // CreateStaticHandlers implements the avalanche.DAGVM interface
 func (vm *VM) CreateStaticHandlers() map[string]*common.HTTPHandler {
-	newServer := rpc.NewServer()
+	newServer := cjson.NewServer()
 	codec := cjson.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -409,6 +411,7 @@ func (vm *VM) IssueTx(b []byte) (ids.ID, error) {
 // * The address associated with the last UTXO fetched
 // * The ID of the last UTXO fetched
 func (vm *VM) GetAtomicUTXOs(
+	ctx context.Context,
 	chainID ids.ID,
 	addrs ids.ShortSet,
 	startAddr ids.ShortID,
@@ -446,6 +449,9 @@ func (vm *VM) GetAtomicUTXOs(
 
 	utxos := make([]*avax.UTXO, len(allUTXOBytes))
 	for i, utxoBytes := range allUTXOBytes {
+		if err := ctx.Err(); err != nil {
+			return nil, ids.ShortID{}, ids.ID{}, err
+		}
 		utxo := &avax.UTXO{}
 		if err := vm.codec.Unmarshal(utxoBytes, utxo); err != nil {
 			return nil, ids.ShortID{}, ids.ID{}, fmt.Errorf("error parsing UTXO: %w", err)
@@ -465,6 +471,7 @@ func (vm *VM) GetAtomicUTXOs(
 // * The address associated with the last UTXO fetched
 // * The ID of the last UTXO fetched
 func (vm *VM) GetUTXOs(
+	ctx context.Context,
 	addrs ids.ShortSet,
 	startAddr ids.ShortID,
 	startUTXOID ids.ID,
@@ -487,6 +494,9 @@ func (vm *VM) GetUTXOs(
 		} else if comp == 0 {
 			start = startUTXOID
 		}
+		if err := ctx.Err(); err != nil { // Stop if the caller gave up
+			return nil, ids.ShortID{}, ids.ID{}, err
+		}
 		utxoIDs, err := vm.state.Funds(addr.Bytes(), start, limit) // Get UTXOs associated with [addr]
 		if err != nil {
 			return nil, ids.ShortID{}, ids.ID{}, fmt.Errorf("couldn't get UTXOs for address %s", addr)
@@ -495,6 +505,9 @@ func (vm *VM) GetUTXOs(
 			if seen.Contains(utxoID) { // Already have this UTXO in the list
 				continue
 			}
+			if err := ctx.Err(); err != nil {
+				return nil, ids.ShortID{}, ids.ID{}, err
+			}
 			utxo, err := vm.state.UTXO(utxoID)
 			if err != nil {
 				return nil, ids.ShortID{}, ids.ID{}, fmt.Errorf("couldn't get UTXO %s: %w", utxoID, err)
@@ -821,7 +834,7 @@ func (vm *VM) LoadUser(
 			addrs.Add(addr)
 		}
 	}
-	utxos, _, _, err := vm.GetUTXOs(addrs, ids.ShortEmpty, ids.Empty, -1)
+	utxos, _, _, err := vm.GetUTXOs(context.Background(), addrs, ids.ShortEmpty, ids.Empty, -1)
 	if err != nil {
 		return nil, nil, fmt.Errorf("problem retrieving user's UTXOs: %w", err)
 	}
@@ -849,61 +862,11 @@ func (vm *VM) Spend(
 	[][]*crypto.PrivateKeySECP256K1R,
 	error,
 ) {
-	amountsSpent := make(map[[32]byte]uint64, len(amounts))
-	time := vm.clock.Unix()
-
-	ins := []*avax.TransferableInput{}
-	keys := [][]*crypto.PrivateKeySECP256K1R{}
-	for _, utxo := range utxos {
-		assetID := utxo.AssetID()
-		assetKey := assetID.Key()
-		amount := amounts[assetKey]
-		amountSpent := amountsSpent[assetKey]
-
-		if amountSpent >= amount {
-			// we already have enough inputs allocated to this asset
-			continue
-		}
-
-		inputIntf, signers, err := kc.Spend(utxo.Out, time)
-		if err != nil {
-			// this utxo can't be spent with the current keys right now
-			continue
-		}
-		input, ok := inputIntf.(avax.TransferableIn)
-		if !ok {
-			// this input doesn't have an amount, so I don't care about it here
-			continue
-		}
-		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
-		if err != nil {
-			// there was an error calculating the consumed amount, just error
-			return nil, nil, nil, errSpendOverflow
-		}
-		amountsSpent[assetKey] = newAmountSpent
-
-		// add the new input to the array
-		ins = append(ins, &avax.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  avax.Asset{ID: assetID},
-			In:     input,
-		})
-		// add the required keys to the array
-		keys = append(keys, signers)
+	spender := spend.Spender{
+		Keychain: kc,
+		Time:     vm.clock.Unix(),
 	}
-
-	for asset, amount := range amounts {
-		if amountsSpent[asset] < amount {
-			return nil, nil, nil, fmt.Errorf("want to spend %d of asset %s but only have %d",
-				amount,
-				ids.NewID(asset),
-				amountsSpent[asset],
-			)
-		}
-	}
-
-	avax.SortTransferableInputsWithSigners(ins, keys)
-	return amountsSpent, ins, keys, nil
+	return spender.Spend(utxos, amounts)
 }
 
 // SpendNFT ...
@@ -991,45 +954,11 @@ func (vm *VM) SpendAll(
 	[][]*crypto.PrivateKeySECP256K1R,
 	error,
 ) {
-	amountsSpent := make(map[[32]byte]uint64)
-	time := vm.clock.Unix()
-
-	ins := []*avax.TransferableInput{}
-	keys := [][]*crypto.PrivateKeySECP256K1R{}
-	for _, utxo := range utxos {
-		assetID := utxo.AssetID()
-		assetKey := assetID.Key()
-		amountSpent := amountsSpent[assetKey]
-
-		inputIntf, signers, err := kc.Spend(utxo.Out, time)
-		if err != nil {
-			// this utxo can't be spent with the current keys right now
-			continue
-		}
-		input, ok := inputIntf.(avax.TransferableIn)
-		if !ok {
-			// this input doesn't have an amount, so I don't care about it here
-			continue
-		}
-		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
-		if err != nil {
-			// there was an error calculating the consumed amount, just error
-			return nil, nil, nil, errSpendOverflow
-		}
-		amountsSpent[assetKey] = newAmountSpent
-
-		// add the new input to the array
-		ins = append(ins, &avax.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  avax.Asset{ID: assetID},
-			In:     input,
-		})
-		// add the required keys to the array
-		keys = append(keys, signers)
+	spender := spend.Spender{
+		Keychain: kc,
+		Time:     vm.clock.Unix(),
 	}
-
-	avax.SortTransferableInputsWithSigners(ins, keys)
-	return amountsSpent, ins, keys, nil
+	return spender.SpendAll(utxos)
 }
 
 // Mint ...
@@ -5,6 +5,7 @@ package avm
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"testing"
 
@@ -596,7 +597,7 @@ func TestGenesisGetUTXOs(t *testing.T) {
 
 	addrsSet := ids.ShortSet{}
 	addrsSet.Add(addrs[0])
-	utxos, _, _, err := vm.GetUTXOs(addrsSet, ids.ShortEmpty, ids.Empty, -1)
+	utxos, _, _, err := vm.GetUTXOs(context.Background(), addrsSet, ids.ShortEmpty, ids.Empty, -1)
 	if err != nil {
 		t.Fatal(err)
 	}
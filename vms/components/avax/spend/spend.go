@@ -0,0 +1,391 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package spend
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/codec"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	errSpendOverflow = errors.New("spent amount overflows uint64")
+	errNoLocker      = errors.New("staking requires a locker")
+)
+
+// Locker wraps outputs and inputs that can't be spent normally until their
+// locktime has passed, such as the P-Chain's stakeable locked outputs
+type Locker interface {
+	// Unlock returns the output wrapped by [out] and its locktime. Returns
+	// false if [out] isn't locked by this locker.
+	Unlock(out verify.State) (avax.TransferableOut, uint64, bool)
+	// LockIn returns [in] wrapped as being locked until [locktime]
+	LockIn(in avax.TransferableIn, locktime uint64) avax.TransferableIn
+	// LockOut returns [out] wrapped as being locked until [locktime]
+	LockOut(out avax.TransferableOut, locktime uint64) avax.TransferableOut
+}
+
+// Spender selects the UTXOs to consume to fund a transaction
+type Spender struct {
+	// Keychain holds the keys that can spend UTXOs. An output with a threshold
+	// greater than 1 is only spent if the keychain holds enough of its keys.
+	Keychain *secp256k1fx.Keychain
+	// Locker of the chain's locked outputs. May be nil if the chain doesn't
+	// have locked outputs.
+	Locker Locker
+	// Codec used to sort the produced outputs
+	Codec codec.Codec
+	// Time the transaction will be issued at. Outputs that are locked at this
+	// time aren't spent.
+	Time uint64
+}
+
+// Spend consumes unlocked UTXOs from [utxos] until, for each asset, at least
+// [amounts[assetID]] has been consumed.
+// Returns:
+// * The amount of each asset consumed
+// * The inputs that consume the UTXOs, sorted
+// * The keys that sign each input
+func (s *Spender) Spend(
+	utxos []*avax.UTXO,
+	amounts map[[32]byte]uint64,
+) (
+	map[[32]byte]uint64,
+	[]*avax.TransferableInput,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	amountsSpent := make(map[[32]byte]uint64, len(amounts))
+	ins := []*avax.TransferableInput{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	for _, utxo := range utxos {
+		assetID := utxo.AssetID()
+		assetKey := assetID.Key()
+		amount := amounts[assetKey]
+		amountSpent := amountsSpent[assetKey]
+
+		if amountSpent >= amount {
+			// we already have enough inputs allocated to this asset
+			continue
+		}
+
+		input, signers, ok := s.spendUnlocked(utxo.Out)
+		if !ok {
+			continue
+		}
+		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
+		if err != nil {
+			// there was an error calculating the consumed amount, just error
+			return nil, nil, nil, errSpendOverflow
+		}
+		amountsSpent[assetKey] = newAmountSpent
+
+		// add the new input to the array
+		ins = append(ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  avax.Asset{ID: assetID},
+			In:     input,
+		})
+		// add the required keys to the array
+		keys = append(keys, signers)
+	}
+
+	for asset, amount := range amounts {
+		if amountsSpent[asset] < amount {
+			return nil, nil, nil, fmt.Errorf("want to spend %d of asset %s but only have %d",
+				amount,
+				ids.NewID(asset),
+				amountsSpent[asset],
+			)
+		}
+	}
+
+	avax.SortTransferableInputsWithSigners(ins, keys)
+	return amountsSpent, ins, keys, nil
+}
+
+// SpendAll consumes every unlocked UTXO in [utxos] that can be spent.
+// Returns the same values as Spend.
+func (s *Spender) SpendAll(
+	utxos []*avax.UTXO,
+) (
+	map[[32]byte]uint64,
+	[]*avax.TransferableInput,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	amountsSpent := make(map[[32]byte]uint64)
+	ins := []*avax.TransferableInput{}
+	keys := [][]*crypto.PrivateKeySECP256K1R{}
+	for _, utxo := range utxos {
+		assetID := utxo.AssetID()
+		assetKey := assetID.Key()
+		amountSpent := amountsSpent[assetKey]
+
+		input, signers, ok := s.spendUnlocked(utxo.Out)
+		if !ok {
+			continue
+		}
+		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
+		if err != nil {
+			// there was an error calculating the consumed amount, just error
+			return nil, nil, nil, errSpendOverflow
+		}
+		amountsSpent[assetKey] = newAmountSpent
+
+		// add the new input to the array
+		ins = append(ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  avax.Asset{ID: assetID},
+			In:     input,
+		})
+		// add the required keys to the array
+		keys = append(keys, signers)
+	}
+
+	avax.SortTransferableInputsWithSigners(ins, keys)
+	return amountsSpent, ins, keys, nil
+}
+
+// Stake consumes UTXOs of [assetID] from [utxos] to stake [amount] while
+// burning [fee]. Locked UTXOs are staked before unlocked UTXOs. The fee can
+// only be paid with unlocked UTXOs.
+// Returns:
+// * The inputs that should be consumed to fund the outputs
+// * The outputs that should be immediately returned to the UTXO set
+// * The outputs that should be locked for the duration of the staking period
+// * The proof of ownership of the funds being moved
+func (s *Spender) Stake(
+	utxos []*avax.UTXO,
+	assetID ids.ID,
+	amount uint64,
+	fee uint64,
+	changeAddr ids.ShortID,
+) (
+	[]*avax.TransferableInput, // inputs
+	[]*avax.TransferableOutput, // returnedOutputs
+	[]*avax.TransferableOutput, // stakedOutputs
+	[][]*crypto.PrivateKeySECP256K1R, // signers
+	error,
+) {
+	if s.Locker == nil {
+		return nil, nil, nil, nil, errNoLocker
+	}
+
+	ins := []*avax.TransferableInput{}
+	returnedOuts := []*avax.TransferableOutput{}
+	stakedOuts := []*avax.TransferableOutput{}
+	signers := [][]*crypto.PrivateKeySECP256K1R{}
+
+	// Amount of [assetID] that has been staked
+	amountStaked := uint64(0)
+
+	// Consume locked UTXOs
+	for _, utxo := range utxos {
+		// If we have consumed more than we are trying to stake, then we have
+		// no need to consume more locked funds
+		if amountStaked >= amount {
+			break
+		}
+
+		if utxoAssetID := utxo.AssetID(); !utxoAssetID.Equals(assetID) {
+			continue // We only care about staking [assetID], so ignore other assets
+		}
+
+		out, locktime, ok := s.Locker.Unlock(utxo.Out)
+		if !ok {
+			// This output isn't locked, so it will be handled during the next
+			// iteration of the UTXO set
+			continue
+		}
+		if locktime <= s.Time {
+			// This output is no longer locked, so it will be handled during the
+			// next iteration of the UTXO set
+			continue
+		}
+
+		inner, ok := out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			// We only know how to clone secp256k1 outputs for now
+			continue
+		}
+
+		inIntf, inSigners, err := s.Keychain.Spend(out, s.Time)
+		if err != nil {
+			// We couldn't spend the output, so move on to the next one
+			continue
+		}
+		in, ok := inIntf.(avax.TransferableIn)
+		if !ok { // should never happen
+			continue
+		}
+
+		// The remaining value is initially the full value of the input
+		remainingValue := in.Amount()
+
+		// Stake any value that should be staked
+		amountToStake := safemath.Min64(
+			amount-amountStaked, // Amount we still need to stake
+			remainingValue,      // Amount available to stake
+		)
+		amountStaked += amountToStake
+		remainingValue -= amountToStake
+
+		// Add the input to the consumed inputs
+		ins = append(ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  avax.Asset{ID: assetID},
+			In:     s.Locker.LockIn(in, locktime),
+		})
+
+		// Add the output to the staked outputs
+		stakedOuts = append(stakedOuts, &avax.TransferableOutput{
+			Asset: avax.Asset{ID: assetID},
+			Out: s.Locker.LockOut(&secp256k1fx.TransferOutput{
+				Amt:          amountToStake,
+				OutputOwners: inner.OutputOwners,
+			}, locktime),
+		})
+
+		if remainingValue > 0 {
+			// This input provided more value than was needed to be locked.
+			// Some of it must be returned
+			returnedOuts = append(returnedOuts, &avax.TransferableOutput{
+				Asset: avax.Asset{ID: assetID},
+				Out: s.Locker.LockOut(&secp256k1fx.TransferOutput{
+					Amt:          remainingValue,
+					OutputOwners: inner.OutputOwners,
+				}, locktime),
+			})
+		}
+
+		// Add the signers needed for this input to the set of signers
+		signers = append(signers, inSigners)
+	}
+
+	// Amount of [assetID] that has been burned
+	amountBurned := uint64(0)
+
+	for _, utxo := range utxos {
+		// If we have consumed more than we are trying to stake, and we have
+		// burned more than we need to, then we have no need to consume more
+		if amountBurned >= fee && amountStaked >= amount {
+			break
+		}
+
+		if utxoAssetID := utxo.AssetID(); !utxoAssetID.Equals(assetID) {
+			continue // We only care about burning [assetID], so ignore other assets
+		}
+
+		in, inSigners, ok := s.spendUnlocked(utxo.Out)
+		if !ok {
+			// We couldn't spend this UTXO, so we skip to the next one
+			continue
+		}
+
+		// The remaining value is initially the full value of the input
+		remainingValue := in.Amount()
+
+		// Burn any value that should be burned
+		amountToBurn := safemath.Min64(
+			fee-amountBurned, // Amount we still need to burn
+			remainingValue,   // Amount available to burn
+		)
+		amountBurned += amountToBurn
+		remainingValue -= amountToBurn
+
+		// Stake any value that should be staked
+		amountToStake := safemath.Min64(
+			amount-amountStaked, // Amount we still need to stake
+			remainingValue,      // Amount available to stake
+		)
+		amountStaked += amountToStake
+		remainingValue -= amountToStake
+
+		// Add the input to the consumed inputs
+		ins = append(ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  avax.Asset{ID: assetID},
+			In:     in,
+		})
+
+		if amountToStake > 0 {
+			// Some of this input was put for staking
+			stakedOuts = append(stakedOuts, &avax.TransferableOutput{
+				Asset: avax.Asset{ID: assetID},
+				Out:   changeOutput(amountToStake, changeAddr),
+			})
+		}
+
+		if remainingValue > 0 {
+			// This input had extra value, so some of it must be returned
+			returnedOuts = append(returnedOuts, &avax.TransferableOutput{
+				Asset: avax.Asset{ID: assetID},
+				Out:   changeOutput(remainingValue, changeAddr),
+			})
+		}
+
+		// Add the signers needed for this input to the set of signers
+		signers = append(signers, inSigners)
+	}
+
+	if amountBurned < fee || amountStaked < amount {
+		return nil, nil, nil, nil, fmt.Errorf(
+			"provided keys have balance (unlocked, locked) (%d, %d) but need (%d, %d)",
+			amountBurned, amountStaked, fee, amount)
+	}
+
+	avax.SortTransferableInputsWithSigners(ins, signers) // sort inputs and keys
+	avax.SortTransferableOutputs(returnedOuts, s.Codec)  // sort outputs
+	avax.SortTransferableOutputs(stakedOuts, s.Codec)    // sort outputs
+
+	return ins, returnedOuts, stakedOuts, signers, nil
+}
+
+// spendUnlocked returns an input that spends [out] and the keys that sign it.
+// Returns false if [out] is currently locked, doesn't have an amount, or can't
+// be spent by the keychain.
+func (s *Spender) spendUnlocked(out verify.State) (avax.TransferableIn, []*crypto.PrivateKeySECP256K1R, bool) {
+	if s.Locker != nil {
+		if inner, locktime, ok := s.Locker.Unlock(out); ok {
+			if locktime > s.Time {
+				// This output is currently locked, so it can't be spent
+				return nil, nil, false
+			}
+			out = inner
+		}
+	}
+
+	inIntf, signers, err := s.Keychain.Spend(out, s.Time)
+	if err != nil {
+		// this output can't be spent with the current keys right now
+		return nil, nil, false
+	}
+	in, ok := inIntf.(avax.TransferableIn)
+	if !ok {
+		// this input doesn't have an amount
+		return nil, nil, false
+	}
+	return in, signers, true
+}
+
+// changeOutput returns an unlocked output of [amount] owned by [addr]
+func changeOutput(amount uint64, addr ids.ShortID) *secp256k1fx.TransferOutput {
+	return &secp256k1fx.TransferOutput{
+		Amt: amount,
+		OutputOwners: secp256k1fx.OutputOwners{
+			Locktime:  0,
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		},
+	}
+}
@@ -0,0 +1,375 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package spend
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/codec"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	assetID      = ids.NewID([32]byte{1})
+	otherAssetID = ids.NewID([32]byte{2})
+)
+
+type testLockedOut struct {
+	Locktime             uint64 `serialize:"true"`
+	avax.TransferableOut `serialize:"true"`
+}
+
+type testLockedIn struct {
+	Locktime            uint64 `serialize:"true"`
+	avax.TransferableIn `serialize:"true"`
+}
+
+type testLocker struct{}
+
+func (testLocker) Unlock(out verify.State) (avax.TransferableOut, uint64, bool) {
+	lockedOut, ok := out.(*testLockedOut)
+	if !ok {
+		return nil, 0, false
+	}
+	return lockedOut.TransferableOut, lockedOut.Locktime, true
+}
+
+func (testLocker) LockIn(in avax.TransferableIn, locktime uint64) avax.TransferableIn {
+	return &testLockedIn{Locktime: locktime, TransferableIn: in}
+}
+
+func (testLocker) LockOut(out avax.TransferableOut, locktime uint64) avax.TransferableOut {
+	return &testLockedOut{Locktime: locktime, TransferableOut: out}
+}
+
+func newKeys(t *testing.T, n int) []*crypto.PrivateKeySECP256K1R {
+	factory := crypto.FactorySECP256K1R{}
+	keys := make([]*crypto.PrivateKeySECP256K1R, n)
+	for i := range keys {
+		key, err := factory.NewPrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = key.(*crypto.PrivateKeySECP256K1R)
+	}
+	return keys
+}
+
+func newCodec(t *testing.T) codec.Codec {
+	c := codec.NewDefault()
+	if err := c.RegisterType(&secp256k1fx.TransferInput{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RegisterType(&secp256k1fx.TransferOutput{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RegisterType(&testLockedIn{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RegisterType(&testLockedOut{}); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func newSpender(t *testing.T, keys ...*crypto.PrivateKeySECP256K1R) *Spender {
+	kc := secp256k1fx.NewKeychain()
+	for _, key := range keys {
+		kc.Add(key)
+	}
+	return &Spender{
+		Keychain: kc,
+		Locker:   testLocker{},
+		Codec:    newCodec(t),
+		Time:     100,
+	}
+}
+
+func newUTXO(index uint32, assetID ids.ID, amount uint64, threshold uint32, addrs ...ids.ShortID) *avax.UTXO {
+	ids.SortShortIDs(addrs)
+	return &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID:        ids.Empty,
+			OutputIndex: index,
+		},
+		Asset: avax.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: threshold,
+				Addrs:     addrs,
+			},
+		},
+	}
+}
+
+func newLockedUTXO(index uint32, assetID ids.ID, amount, locktime uint64, addr ids.ShortID) *avax.UTXO {
+	utxo := newUTXO(index, assetID, amount, 1, addr)
+	utxo.Out = &testLockedOut{
+		Locktime:        locktime,
+		TransferableOut: utxo.Out.(avax.TransferableOut),
+	}
+	return utxo
+}
+
+func amountOf(outs []*avax.TransferableOutput) (locked, unlocked uint64) {
+	for _, out := range outs {
+		if lockedOut, ok := out.Out.(*testLockedOut); ok {
+			locked += lockedOut.Amount()
+		} else {
+			unlocked += out.Out.Amount()
+		}
+	}
+	return locked, unlocked
+}
+
+func TestSpend(t *testing.T) {
+	keys := newKeys(t, 1)
+	addr := keys[0].PublicKey().Address()
+	spender := newSpender(t, keys...)
+
+	utxos := []*avax.UTXO{
+		newUTXO(0, assetID, 5, 1, addr),
+		newUTXO(1, otherAssetID, 7, 1, addr),
+		newUTXO(2, assetID, 5, 1, addr),
+		newUTXO(3, assetID, 5, 1, addr),
+		newLockedUTXO(4, assetID, 100, 200, addr),
+	}
+
+	amountsSpent, ins, signers, err := spender.Spend(utxos, map[[32]byte]uint64{
+		assetID.Key():      8,
+		otherAssetID.Key(): 7,
+	})
+	switch {
+	case err != nil:
+		t.Fatal(err)
+	case amountsSpent[assetID.Key()] != 10:
+		t.Fatalf("expected to spend 10 but spent %d", amountsSpent[assetID.Key()])
+	case amountsSpent[otherAssetID.Key()] != 7:
+		t.Fatalf("expected to spend 7 but spent %d", amountsSpent[otherAssetID.Key()])
+	case len(ins) != 3 || len(signers) != 3:
+		t.Fatalf("expected 3 inputs but got %d inputs and %d signers", len(ins), len(signers))
+	case !avax.IsSortedAndUniqueTransferableInputs(ins):
+		t.Fatal("inputs should be sorted")
+	}
+}
+
+func TestSpendInsufficientFunds(t *testing.T) {
+	keys := newKeys(t, 1)
+	addr := keys[0].PublicKey().Address()
+	spender := newSpender(t, keys...)
+
+	utxos := []*avax.UTXO{
+		newUTXO(0, assetID, 5, 1, addr),
+		// Locked outputs can't be spent
+		newLockedUTXO(1, assetID, 100, 200, addr),
+		// Outputs owned by other addresses can't be spent
+		newUTXO(2, assetID, 100, 1, ids.NewShortID([20]byte{1})),
+	}
+	if _, _, _, err := spender.Spend(utxos, map[[32]byte]uint64{assetID.Key(): 6}); err == nil {
+		t.Fatal("should have failed due to insufficient funds")
+	}
+}
+
+func TestSpendUnlockedAfterLocktime(t *testing.T) {
+	keys := newKeys(t, 1)
+	addr := keys[0].PublicKey().Address()
+	spender := newSpender(t, keys...)
+
+	utxos := []*avax.UTXO{newLockedUTXO(0, assetID, 10, 50, addr)}
+	_, ins, _, err := spender.Spend(utxos, map[[32]byte]uint64{assetID.Key(): 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ins[0].In.(*secp256k1fx.TransferInput); !ok {
+		t.Fatalf("an expired lock should be spent as an unlocked input but got %T", ins[0].In)
+	}
+}
+
+func TestSpendMultisig(t *testing.T) {
+	keys := newKeys(t, 2)
+	addr0 := keys[0].PublicKey().Address()
+	addr1 := keys[1].PublicKey().Address()
+	utxos := []*avax.UTXO{newUTXO(0, assetID, 10, 2, addr0, addr1)}
+	amounts := map[[32]byte]uint64{assetID.Key(): 10}
+
+	if _, _, _, err := newSpender(t, keys[0]).Spend(utxos, amounts); err == nil {
+		t.Fatal("shouldn't be able to spend a 2 of 2 output with 1 key")
+	}
+
+	_, ins, signers, err := newSpender(t, keys...).Spend(utxos, amounts)
+	switch {
+	case err != nil:
+		t.Fatal(err)
+	case len(ins) != 1:
+		t.Fatalf("expected 1 input but got %d", len(ins))
+	case len(signers[0]) != 2:
+		t.Fatalf("expected 2 signers but got %d", len(signers[0]))
+	}
+}
+
+func TestSpendOverflow(t *testing.T) {
+	keys := newKeys(t, 1)
+	addr := keys[0].PublicKey().Address()
+	spender := newSpender(t, keys...)
+
+	utxos := []*avax.UTXO{
+		newUTXO(0, assetID, 1<<63, 1, addr),
+		newUTXO(1, assetID, 1<<63, 1, addr),
+	}
+	if _, _, _, err := spender.Spend(utxos, map[[32]byte]uint64{assetID.Key(): 1<<64 - 1}); err != errSpendOverflow {
+		t.Fatalf("expected %s but got %v", errSpendOverflow, err)
+	}
+	if _, _, _, err := spender.SpendAll(utxos); err != errSpendOverflow {
+		t.Fatalf("expected %s but got %v", errSpendOverflow, err)
+	}
+}
+
+func TestSpendAll(t *testing.T) {
+	keys := newKeys(t, 1)
+	addr := keys[0].PublicKey().Address()
+	spender := newSpender(t, keys...)
+
+	utxos := []*avax.UTXO{
+		newUTXO(0, assetID, 5, 1, addr),
+		newUTXO(1, otherAssetID, 7, 1, addr),
+		newLockedUTXO(2, assetID, 100, 200, addr),
+		newUTXO(3, assetID, 100, 1, ids.NewShortID([20]byte{1})),
+	}
+	amountsSpent, ins, _, err := spender.SpendAll(utxos)
+	switch {
+	case err != nil:
+		t.Fatal(err)
+	case len(ins) != 2:
+		t.Fatalf("expected 2 inputs but got %d", len(ins))
+	case amountsSpent[assetID.Key()] != 5 || amountsSpent[otherAssetID.Key()] != 7:
+		t.Fatalf("unexpected amounts spent %v", amountsSpent)
+	}
+}
+
+func TestStake(t *testing.T) {
+	keys := newKeys(t, 1)
+	addr := keys[0].PublicKey().Address()
+	changeAddr := ids.NewShortID([20]byte{2})
+
+	tests := []struct {
+		name                   string
+		utxos                  []*avax.UTXO
+		amount, fee            uint64
+		shouldErr              bool
+		expectedIns            int
+		expectedStakedLocked   uint64
+		expectedStakedUnlocked uint64
+		expectedReturnLocked   uint64
+		expectedReturnUnlocked uint64
+	}{
+		{
+			name:                   "unlocked only",
+			utxos:                  []*avax.UTXO{newUTXO(0, assetID, 20, 1, addr)},
+			amount:                 10,
+			fee:                    3,
+			expectedIns:            1,
+			expectedStakedUnlocked: 10,
+			expectedReturnUnlocked: 7,
+		},
+		{
+			name: "locked staked before unlocked",
+			utxos: []*avax.UTXO{
+				newUTXO(0, assetID, 20, 1, addr),
+				newLockedUTXO(1, assetID, 15, 200, addr),
+			},
+			amount:                 10,
+			fee:                    3,
+			expectedIns:            2,
+			expectedStakedLocked:   10,
+			expectedReturnLocked:   5,
+			expectedReturnUnlocked: 17,
+		},
+		{
+			name: "locked and unlocked staked",
+			utxos: []*avax.UTXO{
+				newLockedUTXO(0, assetID, 6, 200, addr),
+				newUTXO(1, assetID, 20, 1, addr),
+			},
+			amount:                 10,
+			fee:                    3,
+			expectedIns:            2,
+			expectedStakedLocked:   6,
+			expectedStakedUnlocked: 4,
+			expectedReturnUnlocked: 13,
+		},
+		{
+			name: "expired lock is unlocked",
+			utxos: []*avax.UTXO{
+				newLockedUTXO(0, assetID, 13, 50, addr),
+			},
+			amount:                 10,
+			fee:                    3,
+			expectedIns:            1,
+			expectedStakedUnlocked: 10,
+		},
+		{
+			name: "fee can't be paid with locked funds",
+			utxos: []*avax.UTXO{
+				newLockedUTXO(0, assetID, 100, 200, addr),
+			},
+			amount:    10,
+			fee:       3,
+			shouldErr: true,
+		},
+		{
+			name: "other assets are ignored",
+			utxos: []*avax.UTXO{
+				newUTXO(0, otherAssetID, 100, 1, addr),
+				newUTXO(1, assetID, 5, 1, addr),
+			},
+			amount:    10,
+			fee:       3,
+			shouldErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spender := newSpender(t, keys...)
+			ins, returnedOuts, stakedOuts, signers, err := spender.Stake(test.utxos, assetID, test.amount, test.fee, changeAddr)
+			if test.shouldErr {
+				if err == nil {
+					t.Fatal("should have failed")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ins) != test.expectedIns || len(signers) != test.expectedIns {
+				t.Fatalf("expected %d inputs but got %d inputs and %d signers", test.expectedIns, len(ins), len(signers))
+			}
+			stakedLocked, stakedUnlocked := amountOf(stakedOuts)
+			if stakedLocked != test.expectedStakedLocked || stakedUnlocked != test.expectedStakedUnlocked {
+				t.Fatalf("expected to stake (%d, %d) but staked (%d, %d)",
+					test.expectedStakedLocked, test.expectedStakedUnlocked, stakedLocked, stakedUnlocked)
+			}
+			returnLocked, returnUnlocked := amountOf(returnedOuts)
+			if returnLocked != test.expectedReturnLocked || returnUnlocked != test.expectedReturnUnlocked {
+				t.Fatalf("expected to return (%d, %d) but returned (%d, %d)",
+					test.expectedReturnLocked, test.expectedReturnUnlocked, returnLocked, returnUnlocked)
+			}
+			if !avax.IsSortedTransferableOutputs(stakedOuts, spender.Codec) {
+				t.Fatal("staked outputs should be sorted")
+			}
+		})
+	}
+}
+
+func TestStakeRequiresLocker(t *testing.T) {
+	spender := newSpender(t)
+	spender.Locker = nil
+	if _, _, _, _, err := spender.Stake(nil, assetID, 0, 0, ids.ShortEmpty); err != errNoLocker {
+		t.Fatalf("expected %s but got %v", errNoLocker, err)
+	}
+}
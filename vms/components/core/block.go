@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/vms/components/missing"
+	"github.com/ava-labs/avalanchego/vms/components/state"
 )
 
 var (
@@ -72,11 +73,19 @@ func (b *Block) Accept() error {
 	return nil
 }
 
-// Reject sets this block's status to Rejected and saves the status in state
+// Reject sets this block's status to Rejected and saves the status in state.
+// If b.vm.PruneRejected, the block is deleted from state.
 // Recall that b.vm.DB.Commit() must be called to persist to the DB
 func (b *Block) Reject() error {
 	b.SetStatus(choices.Rejected)
-	return b.VM.State.PutStatus(b.VM.DB, b.ID(), choices.Rejected)
+	blkID := b.ID()
+	if err := b.VM.State.PutStatus(b.VM.DB, blkID, choices.Rejected); err != nil {
+		return err
+	}
+	if !b.VM.PruneRejected {
+		return nil
+	}
+	return b.VM.State.Put(b.VM.DB, state.BlockTypeID, blkID, nil)
 }
 
 // Status returns the status of this block
@@ -8,6 +8,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/vms/components/state"
 )
 
 func TestBlock(t *testing.T) {
@@ -52,3 +53,33 @@ func TestBlock(t *testing.T) {
 		t.Fatalf("status should be rejected but is %s", status)
 	}
 }
+
+func TestBlockPruneRejected(t *testing.T) {
+	db := versiondb.New(memdb.New())
+	snowmanState, err := NewSnowmanState(func([]byte) (snowman.Block, error) { return nil, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := NewBlock(ids.NewID([32]byte{1, 2, 3, 4, 5}), 1)
+	b.Initialize([]byte{1, 2, 3}, &SnowmanVM{
+		DB:            db,
+		State:         snowmanState,
+		PruneRejected: true,
+	})
+	if err := snowmanState.PutBlock(db, &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{IDV: b.ID()},
+		BytesV:        b.Bytes(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.Reject(); err != nil {
+		t.Fatal(err)
+	}
+	if has, err := snowmanState.Has(db, state.BlockTypeID, b.ID()); err != nil || has {
+		t.Fatal("rejected block should have been pruned")
+	}
+	if status := snowmanState.GetStatus(db, b.ID()); status != choices.Rejected {
+		t.Fatalf("status should be rejected but is %s", status)
+	}
+}
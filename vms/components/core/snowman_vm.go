@@ -6,7 +6,6 @@ package core
 import (
 	"errors"
 
-	"github.com/gorilla/rpc/v2"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/versiondb"
@@ -51,6 +50,10 @@ type SnowmanVM struct {
 
 	// channel to send messages to the consensus engine
 	ToEngine chan<- common.Message
+
+	// If true, rejected blocks are deleted from [DB]. Their statuses are kept,
+	// so that they aren't issued into consensus again.
+	PruneRejected bool
 }
 
 // SetPreference sets the block with ID [ID] as the preferred block
@@ -137,7 +140,7 @@ func (svm *SnowmanVM) NotifyBlockReady() {
 //     By default the LockOption is WriteLock
 //     [lockOption] should have either 0 or 1 elements. Elements beside the first are ignored.
 func (svm *SnowmanVM) NewHandler(name string, service interface{}, lockOption ...common.LockOption) (*common.HTTPHandler, error) {
-	server := rpc.NewServer()
+	server := json.NewServer()
 	server.RegisterCodec(json.NewCodec(), "application/json")
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	if err := server.RegisterService(service, name); err != nil {
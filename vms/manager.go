@@ -19,6 +19,12 @@ type VMFactory interface {
 	New(*snow.Context) (interface{}, error)
 }
 
+// Versioner is implemented by VM factories that can report the version of the
+// VM they create, such as factories of VM plugins
+type Versioner interface {
+	Version() (string, error)
+}
+
 // Manager is a VM manager.
 // It has the following functionality:
 //   1) Register a VM factory. To register a VM is to associate its ID with a
@@ -45,6 +51,9 @@ type Manager interface {
 
 	// Give an alias to a VM
 	Alias(ids.ID, string) error
+
+	// Returns the IDs of the registered VMs
+	ListVMs() []ids.ID
 }
 
 // Implements Manager
@@ -53,6 +62,9 @@ type manager struct {
 	// alias of the VM. That is, [VM].String() is an alias for the VM, too.
 	ids.Aliaser
 
+	// Protects [vmFactories], which may be modified while the node is running
+	lock sync.RWMutex
+
 	// Key: The key underlying a VM's ID
 	// Value: A factory that creates new instances of that VM
 	vmFactories map[[32]byte]VMFactory
@@ -78,6 +90,9 @@ func NewManager(apiServer *api.Server, log logging.Logger) Manager {
 // Return a factory that can create new instances of the vm whose
 // ID is [vmID]
 func (m *manager) GetVMFactory(vmID ids.ID) (VMFactory, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
 	if factory, ok := m.vmFactories[vmID.Key()]; ok {
 		return factory, nil
 	}
@@ -88,21 +103,38 @@ func (m *manager) GetVMFactory(vmID ids.ID) (VMFactory, error) {
 // Map [vmID] to [factory]. [factory] creates new instances of the vm whose
 // ID is [vmID]
 func (m *manager) RegisterVMFactory(vmID ids.ID, factory VMFactory) error {
+	m.lock.Lock()
 	key := vmID.Key()
 	if _, exists := m.vmFactories[key]; exists {
+		m.lock.Unlock()
 		return fmt.Errorf("a vm with ID '%v' has already been registered", vmID)
 	}
 	if err := m.Alias(vmID, vmID.String()); err != nil {
+		m.lock.Unlock()
 		return err
 	}
 
 	m.vmFactories[key] = factory
+	m.lock.Unlock()
 
 	// add the static API endpoints
 	m.addStaticAPIEndpoints(vmID)
 	return nil
 }
 
+// ListVMs returns the IDs of the registered VMs, sorted
+func (m *manager) ListVMs() []ids.ID {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	vmIDs := make([]ids.ID, 0, len(m.vmFactories))
+	for key := range m.vmFactories {
+		vmIDs = append(vmIDs, ids.NewID(key))
+	}
+	ids.SortIDs(vmIDs)
+	return vmIDs
+}
+
 // VMs can expose a static API (one that does not depend on the state of a particular chain.)
 // This method adds to the node's API server the static API of the VM with ID [vmID].
 // This allows clients to call the VM's static API methods.
@@ -0,0 +1,180 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+// This file contains methods of VM that maintain the optional index of the
+// transactions that reference each address
+
+const (
+	addressTxsDBPrefix     = "addressTxs"
+	addressTxCountDBPrefix = "addressTxCount"
+
+	// Max number of transaction IDs that can be fetched from the address index
+	// at once
+	maxAddressTxsToFetch = 1024
+)
+
+// txAddresses returns the addresses that [tx] references. These are the owners
+// of the UTXOs [tx] consumes and produces, and the rewards owner of any staker
+// [tx] adds. If [tx] rewards a staker, the owners of the staker's stake and
+// rewards are referenced.
+// [db] must be the state of the chain immediately before [tx] is executed.
+// References that can't be resolved in [db], which is never the case for a
+// transaction that passes semantic verification, are skipped.
+func (vm *VM) txAddresses(db database.Database, tx *Tx) ids.ShortSet {
+	var (
+		ins    []*avax.TransferableInput
+		outs   [][]*avax.TransferableOutput
+		owners []interface{}
+	)
+	switch utx := tx.UnsignedTx.(type) {
+	case *UnsignedAddValidatorTx:
+		ins = utx.Ins
+		outs = append(outs, utx.Outs, utx.Stake)
+		owners = append(owners, utx.RewardsOwner)
+	case *UnsignedAddDelegatorTx:
+		ins = utx.Ins
+		outs = append(outs, utx.Outs, utx.Stake)
+		owners = append(owners, utx.RewardsOwner)
+	case *UnsignedAddSubnetValidatorTx:
+		ins = utx.Ins
+		outs = append(outs, utx.Outs)
+	case *UnsignedCreateChainTx:
+		ins = utx.Ins
+		outs = append(outs, utx.Outs)
+	case *UnsignedCreateSubnetTx:
+		ins = utx.Ins
+		outs = append(outs, utx.Outs)
+		owners = append(owners, utx.Owner)
+	case *UnsignedImportTx:
+		// Imported inputs consume UTXOs in shared memory, whose owners aren't
+		// known to this chain
+		ins = utx.Ins
+		outs = append(outs, utx.Outs)
+	case *UnsignedExportTx:
+		ins = utx.Ins
+		outs = append(outs, utx.Outs, utx.ExportedOutputs)
+	case *UnsignedRewardValidatorTx:
+		stakerTx, err := vm.nextStakerStop(db, constants.PrimaryNetworkID)
+		if err != nil || !stakerTx.Tx.ID().Equals(utx.TxID) {
+			break
+		}
+		switch staker := stakerTx.Tx.UnsignedTx.(type) {
+		case *UnsignedAddValidatorTx:
+			outs = append(outs, staker.Stake)
+			owners = append(owners, staker.RewardsOwner)
+		case *UnsignedAddDelegatorTx:
+			outs = append(outs, staker.Stake)
+			owners = append(owners, staker.RewardsOwner)
+		}
+	}
+
+	for _, in := range ins {
+		utxo, err := vm.getUTXO(db, in.InputID())
+		if err != nil {
+			continue
+		}
+		owners = append(owners, utxo.Out)
+	}
+	for _, outList := range outs {
+		for _, out := range outList {
+			owners = append(owners, out.Out)
+		}
+	}
+
+	addrs := ids.ShortSet{}
+	for _, owner := range owners {
+		addressable, ok := owner.(avax.Addressable)
+		if !ok {
+			continue
+		}
+		for _, addrBytes := range addressable.Addresses() {
+			if addr, err := ids.ToShortID(addrBytes); err == nil {
+				addrs.Add(addr)
+			}
+		}
+	}
+	return addrs
+}
+
+// putAddressTxs records, in [db], that the transaction [txID] references
+// each of [addrs]. Transactions are indexed in the order they are accepted.
+func (vm *VM) putAddressTxs(db database.Database, txID ids.ID, addrs ids.ShortSet) error {
+	countDB := prefixdb.NewNested([]byte(addressTxCountDBPrefix), db)
+	for _, addr := range addrs.List() {
+		count, err := vm.getAddressTxCount(db, addr)
+		if err != nil {
+			return err
+		}
+		txDB := prefixdb.NewNested(addr.Bytes(), prefixdb.NewNested([]byte(addressTxsDBPrefix), db))
+		if err := txDB.Put(uint64ToBytes(count), txID.Bytes()); err != nil {
+			return fmt.Errorf("couldn't index tx %s: %w", txID, err)
+		}
+		if err := countDB.Put(addr.Bytes(), uint64ToBytes(count+1)); err != nil {
+			return fmt.Errorf("couldn't update tx count of address %s: %w", addr, err)
+		}
+	}
+	return nil
+}
+
+// getAddressTxCount returns the number of indexed transactions that reference
+// [addr]
+func (vm *VM) getAddressTxCount(db database.Database, addr ids.ShortID) (uint64, error) {
+	countDB := prefixdb.NewNested([]byte(addressTxCountDBPrefix), db)
+	countBytes, err := countDB.Get(addr.Bytes())
+	switch {
+	case err == database.ErrNotFound:
+		return 0, nil
+	case err != nil:
+		return 0, err
+	}
+	p := wrappers.Packer{Bytes: countBytes}
+	count := p.UnpackLong()
+	return count, p.Err
+}
+
+// getAddressTxs returns the IDs of the indexed transactions that reference
+// [addr], in the order they were accepted.
+// Only returns transactions at or after index [start].
+// Returns at most [limit] transaction IDs.
+// If [limit] <= 0 or [limit] > maxAddressTxsToFetch, it is set to
+// [maxAddressTxsToFetch].
+func (vm *VM) getAddressTxs(db database.Database, addr ids.ShortID, start uint64, limit int) ([]ids.ID, error) {
+	if limit <= 0 || limit > maxAddressTxsToFetch {
+		limit = maxAddressTxsToFetch
+	}
+
+	txDB := prefixdb.NewNested(addr.Bytes(), prefixdb.NewNested([]byte(addressTxsDBPrefix), db))
+	iter := txDB.NewIteratorWithStart(uint64ToBytes(start))
+	defer iter.Release()
+
+	txIDs := []ids.ID(nil)
+	for len(txIDs) < limit && iter.Next() {
+		txID, err := ids.ToID(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs, iter.Error()
+}
+
+// uint64ToBytes returns the big endian representation of [n], so that indices
+// are iterated in order
+func uint64ToBytes(n uint64) []byte {
+	p := wrappers.Packer{MaxSize: wrappers.LongLen}
+	p.PackLong(n)
+	return p.Bytes
+}
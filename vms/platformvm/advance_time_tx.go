@@ -77,16 +77,17 @@ func (tx *UnsignedAdvanceTimeTx) SemanticVerify(
 	if err := vm.putTimestamp(onCommitDB, tx.Timestamp()); err != nil {
 		return nil, nil, nil, nil, tempError{err}
 	}
-	if err := vm.updateValidators(onCommitDB); err != nil {
+	changes, err := vm.updateValidators(onCommitDB)
+	if err != nil {
 		return nil, nil, nil, nil, tempError{err}
 	}
 
 	// If this block is committed, update the validator sets.
 	// onCommitDB will be committed to vm.DB before this is called.
 	onCommitFunc := func() error {
-		// For each Subnet, update the node's validator manager to reflect
-		// current Subnet membership
-		return vm.updateVdrMgr(false)
+		// For each Subnet, apply the stakers that started or stopped
+		// validating to the node's validator manager
+		return vm.applyVdrChanges(changes)
 	}
 
 	// State doesn't change if this proposal is aborted
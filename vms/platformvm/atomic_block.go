@@ -82,6 +82,10 @@ func (ab *AtomicBlock) Verify() error {
 	pdb := parent.onAccept()
 
 	ab.onAcceptDB = versiondb.New(pdb)
+	var addrs ids.ShortSet
+	if ab.vm.indexAddressTxs {
+		addrs = ab.vm.txAddresses(ab.onAcceptDB, &ab.Tx)
+	}
 	if err := tx.SemanticVerify(ab.vm, ab.onAcceptDB, &ab.Tx); err != nil {
 		ab.vm.droppedTxCache.Put(ab.Tx.ID(), nil) // cache tx as dropped
 		return fmt.Errorf("tx %s failed semantic verification: %w", tx.ID(), err)
@@ -91,6 +95,8 @@ func (ab *AtomicBlock) Verify() error {
 		return fmt.Errorf("failed to put tx %s: %w", tx.ID(), err)
 	} else if err := ab.vm.putStatus(ab.onAcceptDB, ab.Tx.ID(), Committed); err != nil {
 		return fmt.Errorf("failed to put status of tx %s: %w", tx.ID(), err)
+	} else if err := ab.vm.putAddressTxs(ab.onAcceptDB, ab.Tx.ID(), addrs); err != nil {
+		return fmt.Errorf("failed to index tx %s: %w", tx.ID(), err)
 	}
 
 	ab.vm.currentBlocks[ab.ID().Key()] = ab
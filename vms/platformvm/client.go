@@ -0,0 +1,373 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+// Client for the Avalanche Platform Info API Endpoint
+type Client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a Client for interacting with the P Chain endpoint
+func NewClient(uri string, requestTimeout time.Duration, options ...rpc.Option) *Client {
+	return &Client{
+		requester: rpc.NewEndpointRequester(uri, "/ext/P", "platform", requestTimeout, options...),
+	}
+}
+
+// GetHeight returns the current block height of the P Chain
+func (c *Client) GetHeight() (uint64, error) {
+	res := &GetHeightResponse{}
+	err := c.requester.SendRequest("getHeight", struct{}{}, res)
+	return uint64(res.Height), err
+}
+
+// ExportKey returns the private key corresponding to [address] from [user]'s account
+func (c *Client) ExportKey(user api.UserPass, address string) (string, error) {
+	res := &ExportKeyReply{}
+	err := c.requester.SendRequest("exportKey", &ExportKeyArgs{
+		UserPass: user,
+		Address:  address,
+	}, res)
+	return res.PrivateKey, err
+}
+
+// ImportKey imports the specified [privateKey] to [user]'s keystore
+func (c *Client) ImportKey(user api.UserPass, privateKey string) (string, error) {
+	res := &api.JSONAddress{}
+	err := c.requester.SendRequest("importKey", &ImportKeyArgs{
+		UserPass:   user,
+		PrivateKey: privateKey,
+	}, res)
+	return res.Address, err
+}
+
+// GetBalance returns the balance of [address] on the P Chain
+func (c *Client) GetBalance(address string) (*GetBalanceResponse, error) {
+	res := &GetBalanceResponse{}
+	err := c.requester.SendRequest("getBalance", &api.JSONAddress{
+		Address: address,
+	}, res)
+	return res, err
+}
+
+// CreateAddress creates a new address for [user]
+func (c *Client) CreateAddress(user api.UserPass) (string, error) {
+	res := &api.JSONAddress{}
+	err := c.requester.SendRequest("createAddress", &user, res)
+	return res.Address, err
+}
+
+// ListAddresses returns an array of platform addresses controlled by [user]
+func (c *Client) ListAddresses(user api.UserPass) ([]string, error) {
+	res := &api.JSONAddresses{}
+	err := c.requester.SendRequest("listAddresses", &user, res)
+	return res.Addresses, err
+}
+
+// GetCurrentValidators returns the list of current validators for subnet with ID [subnetID]
+func (c *Client) GetCurrentValidators(subnetID ids.ID) ([]interface{}, error) {
+	res := &GetCurrentValidatorsReply{}
+	err := c.requester.SendRequest("getCurrentValidators", &GetCurrentValidatorsArgs{
+		SubnetID: subnetID,
+	}, res)
+	return res.Validators, err
+}
+
+// GetCurrentDelegators returns the delegators currently delegating on the
+// primary network
+func (c *Client) GetCurrentDelegators() ([]APIPrimaryDelegator, error) {
+	res := &struct {
+		Delegators []APIPrimaryDelegator `json:"delegators"`
+	}{}
+	err := c.requester.SendRequest("getCurrentValidators", &GetCurrentValidatorsArgs{
+		SubnetID: constants.PrimaryNetworkID,
+	}, res)
+	return res.Delegators, err
+}
+
+// GetPendingValidators returns the list of pending validators for subnet with ID [subnetID]
+func (c *Client) GetPendingValidators(subnetID ids.ID) ([]interface{}, []interface{}, error) {
+	res := &GetPendingValidatorsReply{}
+	err := c.requester.SendRequest("getPendingValidators", &GetPendingValidatorsArgs{
+		SubnetID: subnetID,
+	}, res)
+	return res.Validators, res.Delegators, err
+}
+
+// GetValidatorUptime returns the uptime of the primary network validator
+// [nodeID] as observed by the node the client is connected to
+func (c *Client) GetValidatorUptime(nodeID string) (*GetValidatorUptimeReply, error) {
+	res := &GetValidatorUptimeReply{}
+	err := c.requester.SendRequest("getValidatorUptime", &GetValidatorUptimeArgs{
+		NodeID: nodeID,
+	}, res)
+	return res, err
+}
+
+// GetCurrentSupply returns an upper bound on the supply of AVAX in the system
+func (c *Client) GetCurrentSupply() (uint64, error) {
+	res := &GetCurrentSupplyReply{}
+	err := c.requester.SendRequest("getCurrentSupply", struct{}{}, res)
+	return uint64(res.Supply), err
+}
+
+// SampleValidators returns the nodeIDs of a sample of [sampleSize] validators
+// from the current validator set for subnet with ID [subnetID]
+func (c *Client) SampleValidators(subnetID ids.ID, sampleSize uint16) ([]string, error) {
+	res := &SampleValidatorsReply{}
+	err := c.requester.SendRequest("sampleValidators", &SampleValidatorsArgs{
+		SubnetID: subnetID,
+		Size:     json.Uint16(sampleSize),
+	}, res)
+	return res.Validators, err
+}
+
+// AddValidator issues a transaction to add a validator to the primary network
+// and returns the txID
+func (c *Client) AddValidator(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	rewardAddress,
+	nodeID string,
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	delegationFeeRate float32,
+) (ids.ID, error) {
+	res := &api.JSONTxIDChangeAddr{}
+	jsonStakeAmount := json.Uint64(stakeAmount)
+	err := c.requester.SendRequest("addValidator", &AddValidatorArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		APIStaker: APIStaker{
+			NodeID:      nodeID,
+			StakeAmount: &jsonStakeAmount,
+			StartTime:   json.Uint64(startTime),
+			EndTime:     json.Uint64(endTime),
+		},
+		RewardAddress:     rewardAddress,
+		DelegationFeeRate: json.Float32(delegationFeeRate),
+	}, res)
+	return res.TxID, err
+}
+
+// AddDelegator issues a transaction to add a delegator to the primary network
+// and returns the txID
+func (c *Client) AddDelegator(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	rewardAddress,
+	nodeID string,
+	stakeAmount,
+	startTime,
+	endTime uint64,
+) (ids.ID, error) {
+	res := &api.JSONTxIDChangeAddr{}
+	jsonStakeAmount := json.Uint64(stakeAmount)
+	err := c.requester.SendRequest("addDelegator", &AddDelegatorArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		APIStaker: APIStaker{
+			NodeID:      nodeID,
+			StakeAmount: &jsonStakeAmount,
+			StartTime:   json.Uint64(startTime),
+			EndTime:     json.Uint64(endTime),
+		},
+		RewardAddress: rewardAddress,
+	}, res)
+	return res.TxID, err
+}
+
+// ExportAVAX issues an ExportAVAX transaction and returns the txID
+func (c *Client) ExportAVAX(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	to string,
+	amount uint64,
+) (ids.ID, error) {
+	res := &api.JSONTxIDChangeAddr{}
+	err := c.requester.SendRequest("exportAVAX", &ExportAVAXArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		To:     to,
+		Amount: json.Uint64(amount),
+	}, res)
+	return res.TxID, err
+}
+
+// ImportAVAX issues an ImportAVAX transaction and returns the txID
+func (c *Client) ImportAVAX(
+	user api.UserPass,
+	from []string,
+	changeAddr,
+	to,
+	sourceChain string,
+) (ids.ID, error) {
+	res := &api.JSONTxIDChangeAddr{}
+	err := c.requester.SendRequest("importAVAX", &ImportAVAXArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		To:          to,
+		SourceChain: sourceChain,
+	}, res)
+	return res.TxID, err
+}
+
+// GetBlockchainStatus returns the current status of blockchain with ID: [blockchainID]
+func (c *Client) GetBlockchainStatus(blockchainID string) (Status, error) {
+	res := &GetBlockchainStatusReply{}
+	err := c.requester.SendRequest("getBlockchainStatus", &GetBlockchainStatusArgs{
+		BlockchainID: blockchainID,
+	}, res)
+	return res.Status, err
+}
+
+// IssueTx issues the transaction and returns its txID
+func (c *Client) IssueTx(txBytes []byte) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest("issueTx", &api.FormattedTx{
+		Tx:       formatting.CB58{}.ConvertBytes(txBytes),
+		Encoding: formatting.CB58Encoding,
+	}, res)
+	return res.TxID, err
+}
+
+// GetTx returns the byte representation of the transaction with ID [txID]
+func (c *Client) GetTx(txID ids.ID) ([]byte, error) {
+	res := &api.FormattedTx{}
+	err := c.requester.SendRequest("getTx", &api.GetTxArgs{
+		TxID:     txID,
+		Encoding: formatting.CB58Encoding,
+	}, res)
+	if err != nil {
+		return nil, err
+	}
+	return formatting.CB58{}.ConvertString(res.Tx)
+}
+
+// GetTxStatus returns the status of the transaction with ID [txID]
+func (c *Client) GetTxStatus(txID ids.ID) (Status, error) {
+	var res Status
+	err := c.requester.SendRequest("getTxStatus", &GetTxStatusArgs{
+		TxID: txID,
+	}, &res)
+	return res, err
+}
+
+// GetTxStatuses returns the status of each transaction in [txIDs], fetched in
+// a single batch
+func (c *Client) GetTxStatuses(txIDs []ids.ID) ([]Status, error) {
+	statuses := make([]Status, len(txIDs))
+	calls := make([]*rpc.BatchCall, len(txIDs))
+	for i, txID := range txIDs {
+		calls[i] = rpc.NewBatchCall("getTxStatus", &GetTxStatusArgs{TxID: txID}, &statuses[i])
+	}
+	if err := c.requester.SendBatch(calls); err != nil {
+		return nil, err
+	}
+	return statuses, rpc.FirstErr(calls)
+}
+
+// AwaitTx waits for the transaction [txID] to be committed or aborted. If the
+// transaction remains pending for longer than [config.TTL], [txBytes] are
+// re-issued, at most [config.MaxResubmissions] times.
+func (c *Client) AwaitTx(ctx context.Context, txID ids.ID, txBytes []byte, config rpc.ResubmitConfig) (rpc.TxOutcome, error) {
+	return rpc.AwaitTx(
+		ctx,
+		config,
+		func() error {
+			_, err := c.IssueTx(txBytes)
+			return err
+		},
+		func() (rpc.TxState, error) {
+			status, err := c.GetTxStatus(txID)
+			switch {
+			case err != nil:
+				return rpc.TxPending, err
+			case status == Committed:
+				return rpc.TxAccepted, nil
+			case status == Aborted:
+				return rpc.TxRejected, nil
+			default:
+				return rpc.TxPending, nil
+			}
+		},
+	)
+}
+
+// IssueTxAndAwait issues [txBytes] and waits for the transaction to be
+// decided, re-issuing it as described by [config]
+func (c *Client) IssueTxAndAwait(ctx context.Context, txBytes []byte, config rpc.ResubmitConfig) (ids.ID, rpc.TxOutcome, error) {
+	txID, err := c.IssueTx(txBytes)
+	if err != nil {
+		return txID, rpc.TxOutcome{}, err
+	}
+	outcome, err := c.AwaitTx(ctx, txID, txBytes, config)
+	return txID, outcome, err
+}
+
+// GetConfiguration returns the parameters the P Chain operates with
+func (c *Client) GetConfiguration() (*GetConfigurationReply, error) {
+	res := &GetConfigurationReply{}
+	err := c.requester.SendRequest("getConfiguration", struct{}{}, res)
+	return res, err
+}
+
+// GetAddressTxs returns the IDs of up to [limit] accepted transactions that
+// reference [address], starting at index [startIndex], and the index to start
+// at to get the next page
+func (c *Client) GetAddressTxs(address string, startIndex uint64, limit uint32) ([]ids.ID, uint64, error) {
+	res := &GetAddressTxsReply{}
+	err := c.requester.SendRequest("getAddressTxs", &GetAddressTxsArgs{
+		Address:    address,
+		StartIndex: json.Uint64(startIndex),
+		Limit:      json.Uint32(limit),
+	}, res)
+	return res.TxIDs, uint64(res.EndIndex), err
+}
+
+// GetStake returns the amount of nAVAX that [addresses] have cumulatively
+// staked on the Primary Network.
+func (c *Client) GetStake(addresses []string) (uint64, error) {
+	res := &GetStakeReply{}
+	err := c.requester.SendRequest("getStake", &api.JSONAddresses{
+		Addresses: addresses,
+	}, res)
+	return uint64(res.Staked), err
+}
+
+// GetMinStake returns the minimum staking amount in nAVAX for validators
+// and delegators respectively
+func (c *Client) GetMinStake() (uint64, uint64, error) {
+	res := &GetMinStakeReply{}
+	err := c.requester.SendRequest("getMinStake", struct{}{}, res)
+	return uint64(res.MinValidatorStake), uint64(res.MinDelegatorStake), err
+}
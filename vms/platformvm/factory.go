@@ -32,6 +32,8 @@ type Factory struct {
 	MinStakeDuration   time.Duration // Min time allowed for validating
 	MaxStakeDuration   time.Duration // Max time allowed for validating
 	StakeMintingPeriod time.Duration // Staking consumption period
+	IndexAddressTxs    bool          // Index the txs that reference each address
+	PruneRejected      bool          // Delete rejected blocks from the database
 }
 
 // New returns a new instance of the Platform Chain
@@ -50,5 +52,7 @@ func (f *Factory) New(*snow.Context) (interface{}, error) {
 		minStakeDuration:   f.MinStakeDuration,
 		maxStakeDuration:   f.MaxStakeDuration,
 		stakeMintingPeriod: f.StakeMintingPeriod,
+		indexAddressTxs:    f.IndexAddressTxs,
+		pruneRejected:      f.PruneRejected,
 	}, nil
 }
@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -183,7 +184,7 @@ func (vm *VM) newImportTx(
 		kc.Add(key)
 	}
 
-	atomicUTXOs, _, _, err := vm.GetAtomicUTXOs(chainID, kc.Addresses(), ids.ShortEmpty, ids.Empty, -1)
+	atomicUTXOs, _, _, err := vm.GetAtomicUTXOs(context.Background(), chainID, kc.Addresses(), ids.ShortEmpty, ids.Empty, -1)
 	if err != nil {
 		return nil, fmt.Errorf("problem retrieving atomic UTXOs: %w", err)
 	}
@@ -130,6 +130,11 @@ func (pb *ProposalBlock) Verify() error {
 
 	txID := tx.ID()
 
+	var addrs ids.ShortSet
+	if pb.vm.indexAddressTxs {
+		addrs = pb.vm.txAddresses(pdb, &pb.Tx)
+	}
+
 	var err TxError
 	pb.onCommitDB, pb.onAbortDB, pb.onCommitFunc, pb.onAbortFunc, err = tx.SemanticVerify(pb.vm, pdb, &pb.Tx)
 	if err != nil {
@@ -163,6 +168,14 @@ func (pb *ProposalBlock) Verify() error {
 		return fmt.Errorf("failed to put status of tx %s: %w", txID, err)
 	}
 
+	// The tx references the same addresses whether it's committed or aborted
+	if err := pb.vm.putAddressTxs(pb.onCommitDB, txID, addrs); err != nil {
+		return fmt.Errorf("failed to index tx %s: %w", txID, err)
+	}
+	if err := pb.vm.putAddressTxs(pb.onAbortDB, txID, addrs); err != nil {
+		return fmt.Errorf("failed to index tx %s: %w", txID, err)
+	}
+
 	pb.vm.currentBlocks[pb.ID().Key()] = pb
 	parentIntf.addChild(pb)
 	return nil
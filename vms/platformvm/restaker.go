@@ -0,0 +1,310 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+const (
+	// DefaultRestakeStartDelay is the default amount of time between issuing a
+	// new delegation and the start of that delegation
+	DefaultRestakeStartDelay = time.Minute
+
+	// DefaultRestakePollFrequency is the default amount of time between checks
+	// of the P-Chain's state by a Restaker
+	DefaultRestakePollFrequency = 10 * time.Second
+)
+
+var (
+	errNoDelegation         = errors.New("couldn't find a current delegation matching the restake config")
+	errNoRestakeUser        = errors.New("restake config must specify a username")
+	errNoRestakeNodeID      = errors.New("restake config must specify the nodeID of the current delegation")
+	errNoRestakeReward      = errors.New("restake config must specify the reward address of the current delegation")
+	errInvalidRestakeLength = errors.New("restake duration must be positive")
+	errRestakeTxFailed      = errors.New("restake transaction wasn't committed")
+	errInsufficientRestake  = errors.New("insufficient funds to restake")
+)
+
+// RestakeConfig describes a delegation that should be automatically renewed
+type RestakeConfig struct {
+	// User whose keys control the delegated funds
+	User api.UserPass
+
+	// NodeID the delegation being watched is delegated to
+	NodeID string
+
+	// RewardAddress of the delegation being watched. Every renewed delegation
+	// sends its reward to this address as well.
+	RewardAddress string
+
+	// Destination is the nodeID the funds are delegated to once the watched
+	// delegation ends. Defaults to NodeID.
+	Destination string
+
+	// Duration of each renewed delegation
+	Duration time.Duration
+
+	// StartDelay is the amount of time between issuing a renewed delegation
+	// and its start time. Defaults to DefaultRestakeStartDelay.
+	StartDelay time.Duration
+
+	// PollFrequency is the amount of time between checks of the P-Chain's
+	// state. Defaults to DefaultRestakePollFrequency.
+	PollFrequency time.Duration
+
+	// From are the addresses whose funds are restaked. If empty, all of the
+	// user's addresses are used. The returned stake and the reward should be
+	// sent to these addresses.
+	From []string
+
+	// ChangeAddr receives any change from the renewed delegation. If empty,
+	// an address controlled by the user is used.
+	ChangeAddr string
+}
+
+// restakeClient is the subset of the platform API used by a Restaker
+type restakeClient interface {
+	GetCurrentDelegators() ([]APIPrimaryDelegator, error)
+	ListAddresses(user api.UserPass) ([]string, error)
+	GetBalance(address string) (*GetBalanceResponse, error)
+	GetMinStake() (uint64, uint64, error)
+	AddDelegator(
+		user api.UserPass,
+		from []string,
+		changeAddr string,
+		rewardAddress,
+		nodeID string,
+		stakeAmount,
+		startTime,
+		endTime uint64,
+	) (ids.ID, error)
+	GetTxStatus(txID ids.ID) (Status, error)
+}
+
+// Restaker watches a delegation and, once it has ended and its stake and
+// reward have been returned, re-delegates all of the available funds.
+type Restaker struct {
+	config RestakeConfig
+	client restakeClient
+	clock  timer.Clock
+}
+
+// NewRestaker returns a Restaker that uses [client] to watch and renew the
+// delegation described by [config]
+func NewRestaker(client *Client, config RestakeConfig) (*Restaker, error) {
+	return newRestaker(client, config)
+}
+
+func newRestaker(client restakeClient, config RestakeConfig) (*Restaker, error) {
+	switch {
+	case config.User.Username == "":
+		return nil, errNoRestakeUser
+	case config.NodeID == "":
+		return nil, errNoRestakeNodeID
+	case config.RewardAddress == "":
+		return nil, errNoRestakeReward
+	case config.Duration <= 0:
+		return nil, errInvalidRestakeLength
+	}
+	if config.Destination == "" {
+		config.Destination = config.NodeID
+	}
+	if config.StartDelay <= 0 {
+		config.StartDelay = DefaultRestakeStartDelay
+	}
+	if config.PollFrequency <= 0 {
+		config.PollFrequency = DefaultRestakePollFrequency
+	}
+	return &Restaker{
+		config: config,
+		client: client,
+	}, nil
+}
+
+// Run renews the delegation every time it ends, until [ctx] is cancelled or
+// an error occurs
+func (r *Restaker) Run(ctx context.Context) error {
+	for {
+		txID, err := r.Restake(ctx)
+		if err == errNoDelegation {
+			// The delegation may not be current yet, such as a renewed
+			// delegation that is still pending. Check again on the next tick.
+			if err := r.sleep(ctx, r.config.PollFrequency); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := r.awaitCommitted(ctx, txID); err != nil {
+			return err
+		}
+
+		// The renewed delegation is pending until its start time. Follow it
+		// from now on.
+		r.config.NodeID = r.config.Destination
+		if err := r.sleep(ctx, r.config.StartDelay+r.config.PollFrequency); err != nil {
+			return err
+		}
+	}
+}
+
+// Restake waits for the delegation to end and for its stake and reward to be
+// returned, then issues a new delegation of the available funds. Returns the
+// ID of the issued transaction.
+func (r *Restaker) Restake(ctx context.Context) (ids.ID, error) {
+	delegator, err := r.currentDelegation()
+	if err != nil {
+		return ids.ID{}, err
+	}
+	if delegator == nil {
+		return ids.ID{}, errNoDelegation
+	}
+	endTime := time.Unix(int64(delegator.EndTime), 0)
+
+	// Wait for the delegation to end
+	if err := r.sleep(ctx, endTime.Sub(r.clock.Time())); err != nil {
+		return ids.ID{}, err
+	}
+
+	// The stake and reward are returned once the delegation is removed from
+	// the current staker set
+	for {
+		delegator, err := r.currentDelegation()
+		if err != nil {
+			return ids.ID{}, err
+		}
+		if delegator == nil || uint64(delegator.EndTime) != uint64(endTime.Unix()) {
+			break
+		}
+		if err := r.sleep(ctx, r.config.PollFrequency); err != nil {
+			return ids.ID{}, err
+		}
+	}
+
+	amount, err := r.stakeableBalance()
+	if err != nil {
+		return ids.ID{}, err
+	}
+	_, minDelegatorStake, err := r.client.GetMinStake()
+	if err != nil {
+		return ids.ID{}, fmt.Errorf("couldn't get the minimum delegator stake: %w", err)
+	}
+	if amount < minDelegatorStake {
+		return ids.ID{}, fmt.Errorf("%w: have %d but need %d", errInsufficientRestake, amount, minDelegatorStake)
+	}
+
+	startTime := r.clock.Time().Add(r.config.StartDelay)
+	txID, err := r.client.AddDelegator(
+		r.config.User,
+		r.config.From,
+		r.config.ChangeAddr,
+		r.config.RewardAddress,
+		r.config.Destination,
+		amount,
+		uint64(startTime.Unix()),
+		uint64(startTime.Add(r.config.Duration).Unix()),
+	)
+	if err != nil {
+		return ids.ID{}, fmt.Errorf("couldn't issue restake transaction: %w", err)
+	}
+	return txID, nil
+}
+
+// currentDelegation returns the delegation to [NodeID] with reward address
+// [RewardAddress] that ends last, or nil if there is no such delegation
+func (r *Restaker) currentDelegation() (*APIPrimaryDelegator, error) {
+	delegators, err := r.client.GetCurrentDelegators()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get current delegators: %w", err)
+	}
+
+	var match *APIPrimaryDelegator
+	for i, delegator := range delegators {
+		if delegator.NodeID != r.config.NodeID || delegator.RewardOwner == nil {
+			continue
+		}
+		for _, addr := range delegator.RewardOwner.Addresses {
+			if addr == r.config.RewardAddress && (match == nil || delegator.EndTime > match.EndTime) {
+				match = &delegators[i]
+				break
+			}
+		}
+	}
+	return match, nil
+}
+
+// stakeableBalance returns the amount of funds held by [From] that can be
+// staked
+func (r *Restaker) stakeableBalance() (uint64, error) {
+	addrs := r.config.From
+	if len(addrs) == 0 {
+		userAddrs, err := r.client.ListAddresses(r.config.User)
+		if err != nil {
+			return 0, fmt.Errorf("couldn't list the user's addresses: %w", err)
+		}
+		addrs = userAddrs
+	}
+
+	amount := uint64(0)
+	for _, addr := range addrs {
+		balance, err := r.client.GetBalance(addr)
+		if err != nil {
+			return 0, fmt.Errorf("couldn't get the balance of %s: %w", addr, err)
+		}
+		amount, err = math.Add64(amount, uint64(balance.Unlocked))
+		if err != nil {
+			return 0, err
+		}
+		amount, err = math.Add64(amount, uint64(balance.LockedStakeable))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return amount, nil
+}
+
+// awaitCommitted blocks until the transaction [txID] is decided
+func (r *Restaker) awaitCommitted(ctx context.Context, txID ids.ID) error {
+	for {
+		status, err := r.client.GetTxStatus(txID)
+		if err != nil {
+			return fmt.Errorf("couldn't get the status of %s: %w", txID, err)
+		}
+		switch status {
+		case Committed:
+			return nil
+		case Aborted, Dropped:
+			return fmt.Errorf("%w: %s has status %s", errRestakeTxFailed, txID, status)
+		}
+		if err := r.sleep(ctx, r.config.PollFrequency); err != nil {
+			return err
+		}
+	}
+}
+
+// sleep blocks for [duration] or until [ctx] is cancelled
+func (r *Restaker) sleep(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(duration)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
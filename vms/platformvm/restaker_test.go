@@ -0,0 +1,267 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+type testRestakeClient struct {
+	// delegators returned by each successive call to GetCurrentDelegators.
+	// The last element is repeated once exhausted.
+	delegators [][]APIPrimaryDelegator
+	addrs      []string
+	balances   map[string]*GetBalanceResponse
+	minStake   uint64
+
+	issued []AddDelegatorArgs
+}
+
+func (c *testRestakeClient) GetCurrentDelegators() ([]APIPrimaryDelegator, error) {
+	delegators := c.delegators[0]
+	if len(c.delegators) > 1 {
+		c.delegators = c.delegators[1:]
+	}
+	return delegators, nil
+}
+
+func (c *testRestakeClient) ListAddresses(api.UserPass) ([]string, error) { return c.addrs, nil }
+
+func (c *testRestakeClient) GetBalance(address string) (*GetBalanceResponse, error) {
+	balance, ok := c.balances[address]
+	if !ok {
+		return &GetBalanceResponse{}, nil
+	}
+	return balance, nil
+}
+
+func (c *testRestakeClient) GetMinStake() (uint64, uint64, error) { return 0, c.minStake, nil }
+
+func (c *testRestakeClient) AddDelegator(
+	user api.UserPass,
+	from []string,
+	changeAddr string,
+	rewardAddress,
+	nodeID string,
+	stakeAmount,
+	startTime,
+	endTime uint64,
+) (ids.ID, error) {
+	amount := json.Uint64(stakeAmount)
+	c.issued = append(c.issued, AddDelegatorArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: from},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr},
+		},
+		APIStaker: APIStaker{
+			NodeID:      nodeID,
+			StakeAmount: &amount,
+			StartTime:   json.Uint64(startTime),
+			EndTime:     json.Uint64(endTime),
+		},
+		RewardAddress: rewardAddress,
+	})
+	return ids.Empty.Prefix(uint64(len(c.issued))), nil
+}
+
+func (c *testRestakeClient) GetTxStatus(ids.ID) (Status, error) { return Committed, nil }
+
+func testDelegator(nodeID, rewardAddr string, endTime time.Time) APIPrimaryDelegator {
+	return APIPrimaryDelegator{
+		APIStaker: APIStaker{
+			NodeID:  nodeID,
+			EndTime: json.Uint64(endTime.Unix()),
+		},
+		RewardOwner: &APIOwner{
+			Threshold: 1,
+			Addresses: []string{rewardAddr},
+		},
+	}
+}
+
+func TestRestakerRestake(t *testing.T) {
+	endTime := time.Now().Add(-time.Second)
+	delegation := testDelegator("NodeID-A", "P-local1reward", endTime)
+	otherDelegation := testDelegator("NodeID-A", "P-local1other", endTime.Add(time.Hour))
+
+	client := &testRestakeClient{
+		delegators: [][]APIPrimaryDelegator{
+			{delegation, otherDelegation},
+			{delegation, otherDelegation}, // reward hasn't been processed yet
+			{otherDelegation},
+		},
+		addrs: []string{"P-local1reward", "P-local1stake"},
+		balances: map[string]*GetBalanceResponse{
+			"P-local1reward": {Unlocked: 10},
+			"P-local1stake":  {Unlocked: 1000, LockedStakeable: 5},
+		},
+		minStake: 1000,
+	}
+	restaker, err := newRestaker(client, RestakeConfig{
+		User:          api.UserPass{Username: "bob", Password: "pass"},
+		NodeID:        "NodeID-A",
+		RewardAddress: "P-local1reward",
+		Destination:   "NodeID-B",
+		Duration:      24 * time.Hour,
+		PollFrequency: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txID, err := restaker.Restake(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !txID.Equals(ids.Empty.Prefix(1)) {
+		t.Fatalf("unexpected txID %s", txID)
+	}
+	if len(client.delegators) != 1 {
+		t.Fatalf("should have waited for the delegation to be removed")
+	}
+	if len(client.issued) != 1 {
+		t.Fatalf("expected 1 delegation to be issued but got %d", len(client.issued))
+	}
+
+	issued := client.issued[0]
+	switch {
+	case issued.NodeID != "NodeID-B":
+		t.Fatalf("delegated to %s instead of the destination", issued.NodeID)
+	case issued.RewardAddress != "P-local1reward":
+		t.Fatalf("wrong reward address %s", issued.RewardAddress)
+	case uint64(*issued.StakeAmount) != 1015:
+		t.Fatalf("expected to restake 1015 but restaked %d", *issued.StakeAmount)
+	case time.Duration(issued.EndTime-issued.StartTime)*time.Second != 24*time.Hour:
+		t.Fatalf("wrong delegation duration")
+	case int64(issued.StartTime) < time.Now().Unix():
+		t.Fatalf("start time must be in the future")
+	}
+}
+
+func TestRestakerNoDelegation(t *testing.T) {
+	client := &testRestakeClient{
+		delegators: [][]APIPrimaryDelegator{
+			{testDelegator("NodeID-A", "P-local1other", time.Now())},
+		},
+	}
+	restaker, err := newRestaker(client, RestakeConfig{
+		User:          api.UserPass{Username: "bob", Password: "pass"},
+		NodeID:        "NodeID-A",
+		RewardAddress: "P-local1reward",
+		Duration:      24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := restaker.Restake(context.Background()); err != errNoDelegation {
+		t.Fatalf("expected %s but got %v", errNoDelegation, err)
+	}
+}
+
+func TestRestakerRunWaitsForDelegation(t *testing.T) {
+	delegation := testDelegator("NodeID-A", "P-local1reward", time.Now().Add(-time.Second))
+	client := &testRestakeClient{
+		delegators: [][]APIPrimaryDelegator{
+			{}, // the delegation is still pending
+			{},
+			{delegation},
+			{},
+		},
+		addrs: []string{"P-local1reward"},
+		balances: map[string]*GetBalanceResponse{
+			"P-local1reward": {Unlocked: 1000},
+		},
+		minStake: 1000,
+	}
+	restaker, err := newRestaker(client, RestakeConfig{
+		User:          api.UserPass{Username: "bob", Password: "pass"},
+		NodeID:        "NodeID-A",
+		RewardAddress: "P-local1reward",
+		Duration:      24 * time.Hour,
+		StartDelay:    time.Millisecond,
+		PollFrequency: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := restaker.Run(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected %s but got %v", context.DeadlineExceeded, err)
+	}
+	if len(client.issued) != 1 {
+		t.Fatalf("expected 1 delegation to be issued but got %d", len(client.issued))
+	}
+}
+
+func TestRestakerInsufficientFunds(t *testing.T) {
+	client := &testRestakeClient{
+		delegators: [][]APIPrimaryDelegator{
+			{testDelegator("NodeID-A", "P-local1reward", time.Now().Add(-time.Second))},
+			{},
+		},
+		addrs: []string{"P-local1reward"},
+		balances: map[string]*GetBalanceResponse{
+			"P-local1reward": {Unlocked: 10},
+		},
+		minStake: 1000,
+	}
+	restaker, err := newRestaker(client, RestakeConfig{
+		User:          api.UserPass{Username: "bob", Password: "pass"},
+		NodeID:        "NodeID-A",
+		RewardAddress: "P-local1reward",
+		Duration:      24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := restaker.Restake(context.Background()); !errors.Is(err, errInsufficientRestake) {
+		t.Fatalf("expected %s but got %v", errInsufficientRestake, err)
+	}
+	if len(client.issued) != 0 {
+		t.Fatalf("shouldn't have issued a delegation")
+	}
+}
+
+func TestRestakerCancelled(t *testing.T) {
+	client := &testRestakeClient{
+		delegators: [][]APIPrimaryDelegator{
+			{testDelegator("NodeID-A", "P-local1reward", time.Now().Add(time.Hour))},
+		},
+	}
+	restaker, err := newRestaker(client, RestakeConfig{
+		User:          api.UserPass{Username: "bob", Password: "pass"},
+		NodeID:        "NodeID-A",
+		RewardAddress: "P-local1reward",
+		Duration:      24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := restaker.Restake(ctx); err != context.Canceled {
+		t.Fatalf("expected %s but got %v", context.Canceled, err)
+	}
+}
+
+func TestRestakerInvalidConfig(t *testing.T) {
+	if _, err := newRestaker(&testRestakeClient{}, RestakeConfig{
+		User:          api.UserPass{Username: "bob", Password: "pass"},
+		NodeID:        "NodeID-A",
+		RewardAddress: "P-local1reward",
+	}); err != errInvalidRestakeLength {
+		t.Fatalf("expected %s but got %v", errInvalidRestakeLength, err)
+	}
+}
@@ -336,7 +336,16 @@ func (tx *UnsignedRewardValidatorTx) SemanticVerify(
 	// Regardless of whether this tx is committed or aborted, update the
 	// validator set to remove the staker. onAbortDB or onCommitDB should commit
 	// (flush to vm.DB) before this is called
-	updateValidators := func() error { return vm.updateVdrMgr(false) }
+	removed, err := stakerValidator(&stakerTx.Tx)
+	if err != nil {
+		return nil, nil, nil, nil, permError{err}
+	}
+	updateValidators := func() error {
+		return vm.applyVdrChanges([]vdrChange{{
+			subnetID: constants.PrimaryNetworkID,
+			vdr:      removed,
+		}})
+	}
 
 	uptime, err := vm.calculateUptime(vm.DB, nodeID, startTime)
 	if err != nil {
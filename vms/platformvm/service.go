@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -43,6 +44,9 @@ var (
 	errInvalidDelegationRate = errors.New("argument 'delegationFeeRate' must be between 0 and 100, inclusive")
 	errNoAddresses           = errors.New("no addresses provided")
 	errNoKeys                = errors.New("user has no keys or funds")
+	errNoNodeID              = errors.New("argument 'nodeID' not provided")
+	errNotValidator          = errors.New("node isn't a current validator of the primary network")
+	errAddressTxIndexOff     = errors.New("address transaction index isn't enabled")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -171,7 +175,7 @@ type GetBalanceResponse struct {
 }
 
 // GetBalance gets the balance of an address
-func (service *Service) GetBalance(_ *http.Request, args *api.JSONAddress, response *GetBalanceResponse) error {
+func (service *Service) GetBalance(r *http.Request, args *api.JSONAddress, response *GetBalanceResponse) error {
 	service.vm.SnowmanVM.Ctx.Log.Info("Platform: GetBalance called for address %s", args.Address)
 
 	// Parse to address
@@ -182,7 +186,7 @@ func (service *Service) GetBalance(_ *http.Request, args *api.JSONAddress, respo
 
 	addrs := ids.ShortSet{}
 	addrs.Add(addr)
-	utxos, _, _, err := service.vm.GetUTXOs(service.vm.DB, addrs, ids.ShortEmpty, ids.Empty, -1)
+	utxos, _, _, err := service.vm.GetUTXOs(api.RequestContext(r), service.vm.DB, addrs, ids.ShortEmpty, ids.Empty, -1)
 	if err != nil {
 		addr, err2 := service.vm.FormatLocalAddress(addr)
 		if err2 != nil {
@@ -279,8 +283,7 @@ func (service *Service) CreateAddress(_ *http.Request, args *api.UserPass, respo
 		return fmt.Errorf("keystore user has reached its limit of %d addresses", maxKeystoreAddresses)
 	}
 
-	factory := crypto.FactorySECP256K1R{}
-	key, err := factory.NewPrivateKey()
+	key, err := keystore.NewKey(service.vm.SnowmanVM.Ctx.Keystore, args.Username, args.Password)
 	if err != nil {
 		return fmt.Errorf("couldn't create key: %w", err)
 	}
@@ -290,7 +293,7 @@ func (service *Service) CreateAddress(_ *http.Request, args *api.UserPass, respo
 		return fmt.Errorf("problem formatting address: %w", err)
 	}
 
-	if err := user.putAddress(key.(*crypto.PrivateKeySECP256K1R)); err != nil {
+	if err := user.putAddress(key); err != nil {
 		// Drop any potential error closing the database to report the original
 		// error
 		return fmt.Errorf("problem saving key %w", err)
@@ -369,7 +372,7 @@ type GetUTXOsResponse struct {
 }
 
 // GetUTXOs returns the UTXOs controlled by the given addresses
-func (service *Service) GetUTXOs(_ *http.Request, args *GetUTXOsArgs, response *GetUTXOsResponse) error {
+func (service *Service) GetUTXOs(r *http.Request, args *GetUTXOsArgs, response *GetUTXOsResponse) error {
 	service.vm.SnowmanVM.Ctx.Log.Info("Platform: ListAddresses called")
 
 	if len(args.Addresses) == 0 {
@@ -427,6 +430,7 @@ func (service *Service) GetUTXOs(_ *http.Request, args *GetUTXOsArgs, response *
 	)
 	if sourceChain.Equals(service.vm.Ctx.ChainID) {
 		utxos, endAddr, endUTXOID, err = service.vm.GetUTXOs(
+			api.RequestContext(r),
 			service.vm.DB,
 			addrSet,
 			startAddr,
@@ -435,6 +439,7 @@ func (service *Service) GetUTXOs(_ *http.Request, args *GetUTXOsArgs, response *
 		)
 	} else {
 		utxos, endAddr, endUTXOID, err = service.vm.GetAtomicUTXOs(
+			api.RequestContext(r),
 			sourceChain,
 			addrSet,
 			startAddr,
@@ -854,6 +859,68 @@ func (service *Service) GetCurrentSupply(_ *http.Request, _ *struct{}, reply *Ge
 	return err
 }
 
+// GetValidatorUptimeArgs are the arguments for calling GetValidatorUptime
+type GetValidatorUptimeArgs struct {
+	NodeID string `json:"nodeID"`
+}
+
+// GetValidatorUptimeReply is the response from calling GetValidatorUptime
+type GetValidatorUptimeReply struct {
+	NodeID    string      `json:"nodeID"`
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	// Uptime of the validator, in [0, 1], as observed by this node since the
+	// validator's start time
+	Uptime json.Float32 `json:"uptime"`
+	// Connected is true if this node is currently connected to the validator
+	Connected bool `json:"connected"`
+	// RequiredUptime is the uptime, in [0, 1], this node requires to vote for
+	// the validator to be rewarded
+	RequiredUptime json.Float32 `json:"requiredUptime"`
+	// MeetsRequiredUptime is true if this node would currently vote for the
+	// validator to be rewarded
+	MeetsRequiredUptime bool `json:"meetsRequiredUptime"`
+}
+
+// GetValidatorUptime returns this node's observation of the uptime of a
+// current primary network validator. Because each node votes on whether a
+// validator is rewarded based on its own observations, this is an indication
+// of whether the validator, and those delegating to it, will be rewarded.
+func (service *Service) GetValidatorUptime(_ *http.Request, args *GetValidatorUptimeArgs, reply *GetValidatorUptimeReply) error {
+	service.vm.Ctx.Log.Info("Platform: GetValidatorUptime called")
+
+	if args.NodeID == "" {
+		return errNoNodeID
+	}
+	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+	if err != nil {
+		return fmt.Errorf("couldn't parse nodeID: %w", err)
+	}
+
+	vdr, isValidator, err := service.vm.isValidator(service.vm.DB, constants.PrimaryNetworkID, nodeID)
+	switch {
+	case err != nil:
+		return fmt.Errorf("couldn't get validator %s: %w", args.NodeID, err)
+	case !isValidator:
+		return errNotValidator
+	}
+
+	uptime, err := service.vm.calculateUptime(service.vm.DB, nodeID, vdr.StartTime())
+	if err != nil {
+		return fmt.Errorf("couldn't calculate uptime of %s: %w", args.NodeID, err)
+	}
+	_, connected := service.vm.connections[nodeID.Key()]
+
+	reply.NodeID = nodeID.PrefixedString(constants.NodeIDPrefix)
+	reply.StartTime = json.Uint64(vdr.StartTime().Unix())
+	reply.EndTime = json.Uint64(vdr.EndTime().Unix())
+	reply.Uptime = json.Float32(uptime)
+	reply.Connected = connected
+	reply.RequiredUptime = json.Float32(service.vm.uptimePercentage)
+	reply.MeetsRequiredUptime = uptime >= service.vm.uptimePercentage
+	return nil
+}
+
 // SampleValidatorsArgs are the arguments for calling SampleValidators
 type SampleValidatorsArgs struct {
 	// Number of validators in the sample
@@ -1937,6 +2004,63 @@ func (service *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, resp
 	return nil
 }
 
+// GetAddressTxsArgs are the arguments for calling GetAddressTxs
+type GetAddressTxsArgs struct {
+	Address string `json:"address"`
+	// StartIndex is the index of the first transaction to return. Used for
+	// pagination.
+	StartIndex json.Uint64 `json:"startIndex"`
+	// Limit is the max number of transactions to return. If 0 or greater than
+	// [maxAddressTxsToFetch], fetches up to [maxAddressTxsToFetch].
+	Limit json.Uint32 `json:"limit"`
+}
+
+// GetAddressTxsReply is the response from calling GetAddressTxs
+type GetAddressTxsReply struct {
+	// IDs of the transactions, in the order they were accepted
+	TxIDs []ids.ID `json:"txIDs"`
+	// The index after the last transaction returned. To get the rest of the
+	// transactions, call GetAddressTxs again and set [StartIndex] to this
+	// value.
+	EndIndex json.Uint64 `json:"endIndex"`
+	// Total number of indexed transactions that reference the address
+	NumTxs json.Uint64 `json:"numTxs"`
+}
+
+// GetAddressTxs returns the IDs of the accepted transactions that reference an
+// address, including the staking transactions that add or reward the
+// validators and delegators the address is a rewards owner of.
+// Requires the address transaction index to be enabled.
+func (service *Service) GetAddressTxs(_ *http.Request, args *GetAddressTxsArgs, reply *GetAddressTxsReply) error {
+	service.vm.Ctx.Log.Info("Platform: GetAddressTxs called for address %s", args.Address)
+
+	if !service.vm.indexAddressTxs {
+		return errAddressTxIndexOff
+	}
+
+	addr, err := service.vm.ParseLocalAddress(args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
+	}
+
+	numTxs, err := service.vm.getAddressTxCount(service.vm.DB, addr)
+	if err != nil {
+		return fmt.Errorf("couldn't get number of txs of %s: %w", args.Address, err)
+	}
+	txIDs, err := service.vm.getAddressTxs(service.vm.DB, addr, uint64(args.StartIndex), int(args.Limit))
+	if err != nil {
+		return fmt.Errorf("couldn't get txs of %s: %w", args.Address, err)
+	}
+
+	reply.TxIDs = txIDs
+	if reply.TxIDs == nil {
+		reply.TxIDs = []ids.ID{}
+	}
+	reply.EndIndex = args.StartIndex + json.Uint64(len(txIDs))
+	reply.NumTxs = json.Uint64(numTxs)
+	return nil
+}
+
 // GetStakeReply is the response from calling GetStake.
 type GetStakeReply struct {
 	Staked json.Uint64 `json:"staked"`
@@ -2093,6 +2217,60 @@ func (service *Service) GetMinStake(_ *http.Request, _ *struct{}, reply *GetMinS
 	return nil
 }
 
+// GetConfigurationReply is the response from calling GetConfiguration
+type GetConfigurationReply struct {
+	// ID of the network this chain runs on
+	NetworkID json.Uint32 `json:"networkID"`
+	// ID of the asset that fees are paid and stake is bonded in
+	AssetID ids.ID `json:"assetID"`
+	// Fee, in nAVAX, burned by every state creating transaction
+	CreationTxFee json.Uint64 `json:"creationTxFee"`
+	// Fee, in nAVAX, burned by every non-state creating transaction
+	TxFee json.Uint64 `json:"txFee"`
+	// Minimum uptime, in [0, 1], a validator must have to be rewarded
+	UptimeRequirement json.Float32 `json:"uptimeRequirement"`
+	// Minimum and maximum amounts, in nAVAX, that can be bonded to validate
+	MinValidatorStake json.Uint64 `json:"minValidatorStake"`
+	MaxValidatorStake json.Uint64 `json:"maxValidatorStake"`
+	// Minimum amount, in nAVAX, that can be delegated
+	MinDelegatorStake json.Uint64 `json:"minDelegatorStake"`
+	// Minimum fee, as a percentage, a validator can charge its delegators
+	MinDelegationFee json.Float32 `json:"minDelegationFee"`
+	// Minimum and maximum staking durations, in seconds
+	MinStakeDuration json.Uint64 `json:"minStakeDuration"`
+	MaxStakeDuration json.Uint64 `json:"maxStakeDuration"`
+	// Period, in seconds, over which the staking reward is consumed
+	StakeMintingPeriod json.Uint64 `json:"stakeMintingPeriod"`
+	// Minimum and maximum percentages of the remaining supply that are minted
+	// as rewards over the minting period
+	MinConsumptionRate json.Float32 `json:"minConsumptionRate"`
+	MaxConsumptionRate json.Float32 `json:"maxConsumptionRate"`
+	// Maximum amount of nAVAX that will ever exist
+	SupplyCap json.Uint64 `json:"supplyCap"`
+}
+
+// GetConfiguration returns the parameters this chain operates with
+func (service *Service) GetConfiguration(_ *http.Request, _ *struct{}, reply *GetConfigurationReply) error {
+	service.vm.Ctx.Log.Info("Platform: GetConfiguration called")
+
+	reply.NetworkID = json.Uint32(service.vm.Ctx.NetworkID)
+	reply.AssetID = service.vm.Ctx.AVAXAssetID
+	reply.CreationTxFee = json.Uint64(service.vm.creationTxFee)
+	reply.TxFee = json.Uint64(service.vm.txFee)
+	reply.UptimeRequirement = json.Float32(service.vm.uptimePercentage)
+	reply.MinValidatorStake = json.Uint64(service.vm.minValidatorStake)
+	reply.MaxValidatorStake = json.Uint64(service.vm.maxValidatorStake)
+	reply.MinDelegatorStake = json.Uint64(service.vm.minDelegatorStake)
+	reply.MinDelegationFee = json.Float32(100 * float32(service.vm.minDelegationFee) / float32(PercentDenominator))
+	reply.MinStakeDuration = json.Uint64(service.vm.minStakeDuration / time.Second)
+	reply.MaxStakeDuration = json.Uint64(service.vm.maxStakeDuration / time.Second)
+	reply.StakeMintingPeriod = json.Uint64(service.vm.stakeMintingPeriod / time.Second)
+	reply.MinConsumptionRate = json.Float32(100 * float32(MinConsumptionRate) / float32(PercentDenominator))
+	reply.MaxConsumptionRate = json.Float32(100 * float32(MinConsumptionRate+MaxSubMinConsumptionRate) / float32(PercentDenominator))
+	reply.SupplyCap = json.Uint64(SupplyCap)
+	return nil
+}
+
 // GetTotalStake returns the total amount staked on the Primary Network
 func (service *Service) GetTotalStake(_ *http.Request, _ *struct{}, reply *struct {
 	Stake json.Uint64 `json:"stake"`
@@ -10,6 +10,7 @@ import (
 
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/keystore"
@@ -655,3 +656,138 @@ func TestGetCurrentValidators(t *testing.T) {
 		t.Fatalf("didnt find delegator")
 	}
 }
+
+func TestGetValidatorUptime(t *testing.T) {
+	service := defaultService(t)
+	service.vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.Ctx.Lock.Unlock()
+	}()
+
+	nodeID := keys[0].PublicKey().Address().PrefixedString(constants.NodeIDPrefix)
+	args := GetValidatorUptimeArgs{NodeID: nodeID}
+	reply := GetValidatorUptimeReply{}
+	if err := service.GetValidatorUptime(nil, &args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case reply.NodeID != nodeID:
+		t.Fatalf("expected nodeID %s but got %s", nodeID, reply.NodeID)
+	case uint64(reply.StartTime) != uint64(defaultValidateStartTime.Unix()):
+		t.Fatalf("expected start time %d but got %d", defaultValidateStartTime.Unix(), reply.StartTime)
+	case uint64(reply.EndTime) != uint64(defaultValidateEndTime.Unix()):
+		t.Fatalf("expected end time %d but got %d", defaultValidateEndTime.Unix(), reply.EndTime)
+	case float64(reply.RequiredUptime) != float64(float32(service.vm.uptimePercentage)):
+		t.Fatalf("expected required uptime %f but got %f", service.vm.uptimePercentage, reply.RequiredUptime)
+	case reply.MeetsRequiredUptime != (float64(reply.Uptime) >= service.vm.uptimePercentage):
+		t.Fatalf("meetsRequiredUptime should reflect the uptime")
+	}
+
+	args.NodeID = ids.GenerateTestShortID().PrefixedString(constants.NodeIDPrefix)
+	if err := service.GetValidatorUptime(nil, &args, &reply); err != errNotValidator {
+		t.Fatalf("expected %s but got %v", errNotValidator, err)
+	}
+
+	args.NodeID = ""
+	if err := service.GetValidatorUptime(nil, &args, &reply); err != errNoNodeID {
+		t.Fatalf("expected %s but got %v", errNoNodeID, err)
+	}
+}
+
+func TestGetAddressTxs(t *testing.T) {
+	service := defaultService(t)
+	service.vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.Ctx.Lock.Unlock()
+	}()
+
+	controlKey := ids.GenerateTestShortID()
+	controlAddr, err := service.vm.FormatLocalAddress(controlKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := GetAddressTxsArgs{Address: controlAddr}
+	reply := GetAddressTxsReply{}
+	if err := service.GetAddressTxs(nil, &args, &reply); err != errAddressTxIndexOff {
+		t.Fatalf("expected %s but got %v", errAddressTxIndexOff, err)
+	}
+	service.vm.indexAddressTxs = true
+
+	createSubnetTx, err := service.vm.newCreateSubnetTx(
+		1,                                       // threshold
+		[]ids.ShortID{controlKey},               // control keys
+		[]*crypto.PrivateKeySECP256K1R{keys[0]}, // payer
+		keys[0].PublicKey().Address(),           // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := service.vm.mempool.IssueTx(createSubnetTx); err != nil {
+		t.Fatal(err)
+	} else if blk, err := service.vm.BuildBlock(); err != nil {
+		t.Fatal(err)
+	} else if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	} else if err := blk.Accept(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := service.GetAddressTxs(nil, &args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case len(reply.TxIDs) != 1 || !reply.TxIDs[0].Equals(createSubnetTx.ID()):
+		t.Fatalf("expected tx %s but got %v", createSubnetTx.ID(), reply.TxIDs)
+	case reply.EndIndex != 1:
+		t.Fatalf("expected end index 1 but got %d", reply.EndIndex)
+	case reply.NumTxs != 1:
+		t.Fatalf("expected 1 tx but got %d", reply.NumTxs)
+	}
+
+	// There are no more txs after the end index
+	args.StartIndex = reply.EndIndex
+	if err := service.GetAddressTxs(nil, &args, &reply); err != nil {
+		t.Fatal(err)
+	} else if len(reply.TxIDs) != 0 {
+		t.Fatalf("expected no txs but got %v", reply.TxIDs)
+	} else if reply.EndIndex != args.StartIndex {
+		t.Fatalf("expected end index %d but got %d", args.StartIndex, reply.EndIndex)
+	}
+}
+
+func TestGetConfiguration(t *testing.T) {
+	service := defaultService(t)
+	service.vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := service.vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		service.vm.Ctx.Lock.Unlock()
+	}()
+
+	reply := GetConfigurationReply{}
+	if err := service.GetConfiguration(nil, nil, &reply); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case uint32(reply.NetworkID) != service.vm.Ctx.NetworkID:
+		t.Fatalf("expected network ID %d but got %d", service.vm.Ctx.NetworkID, reply.NetworkID)
+	case !reply.AssetID.Equals(service.vm.Ctx.AVAXAssetID):
+		t.Fatalf("expected asset ID %s but got %s", service.vm.Ctx.AVAXAssetID, reply.AssetID)
+	case uint64(reply.TxFee) != defaultTxFee:
+		t.Fatalf("expected tx fee %d but got %d", defaultTxFee, reply.TxFee)
+	case uint64(reply.MinValidatorStake) != defaultMinValidatorStake:
+		t.Fatalf("expected min validator stake %d but got %d", defaultMinValidatorStake, reply.MinValidatorStake)
+	case uint64(reply.MinStakeDuration) != uint64(defaultMinStakingDuration/time.Second):
+		t.Fatalf("expected min stake duration %d but got %d", defaultMinStakingDuration/time.Second, reply.MinStakeDuration)
+	case reply.MaxConsumptionRate <= reply.MinConsumptionRate:
+		t.Fatalf("max consumption rate %f should exceed min consumption rate %f", reply.MaxConsumptionRate, reply.MinConsumptionRate)
+	case uint64(reply.SupplyCap) != SupplyCap:
+		t.Fatalf("expected supply cap %d but got %d", uint64(SupplyCap), reply.SupplyCap)
+	}
+}
@@ -1,6 +1,7 @@
 package platformvm
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/avax/spend"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
@@ -53,7 +55,7 @@ func (vm *VM) stake(
 	for _, key := range keys {
 		addrs.Add(key.PublicKey().Address())
 	}
-	utxos, _, _, err := vm.GetUTXOs(db, addrs, ids.ShortEmpty, ids.Empty, -1) // The UTXOs controlled by [keys]
+	utxos, _, _, err := vm.GetUTXOs(context.Background(), db, addrs, ids.ShortEmpty, ids.Empty, -1) // The UTXOs controlled by [keys]
 	if err != nil {
 		return nil, nil, nil, nil, fmt.Errorf("couldn't get UTXOs: %w", err)
 	}
@@ -63,220 +65,13 @@ func (vm *VM) stake(
 		kc.Add(key)
 	}
 
-	// Minimum time this transaction will be issued at
-	now := uint64(vm.clock.Time().Unix())
-
-	ins := []*avax.TransferableInput{}
-	returnedOuts := []*avax.TransferableOutput{}
-	stakedOuts := []*avax.TransferableOutput{}
-	signers := [][]*crypto.PrivateKeySECP256K1R{}
-
-	// Amount of AVAX that has been staked
-	amountStaked := uint64(0)
-
-	// Consume locked UTXOs
-	for _, utxo := range utxos {
-		// If we have consumed more AVAX than we are trying to stake, then we
-		// have no need to consume more locked AVAX
-		if amountStaked >= amount {
-			break
-		}
-
-		if assetID := utxo.AssetID(); !assetID.Equals(vm.Ctx.AVAXAssetID) {
-			continue // We only care about staking AVAX, so ignore other assets
-		}
-
-		out, ok := utxo.Out.(*StakeableLockOut)
-		if !ok {
-			// This output isn't locked, so it will be handled during the next
-			// iteration of the UTXO set
-			continue
-		}
-		if out.Locktime <= now {
-			// This output is no longer locked, so it will be handled during the
-			// next iteration of the UTXO set
-			continue
-		}
-
-		inner, ok := out.TransferableOut.(*secp256k1fx.TransferOutput)
-		if !ok {
-			// We only know how to clone secp256k1 outputs for now
-			continue
-		}
-
-		inIntf, inSigners, err := kc.Spend(out.TransferableOut, now)
-		if err != nil {
-			// We couldn't spend the output, so move on to the next one
-			continue
-		}
-		in, ok := inIntf.(avax.TransferableIn)
-		if !ok { // should never happen
-			vm.Ctx.Log.Warn("expected input to be avax.TransferableIn but is %T", inIntf)
-			continue
-		}
-
-		// The remaining value is initially the full value of the input
-		remainingValue := in.Amount()
-
-		// Stake any value that should be staked
-		amountToStake := safemath.Min64(
-			amount-amountStaked, // Amount we still need to stake
-			remainingValue,      // Amount available to stake
-		)
-		amountStaked += amountToStake
-		remainingValue -= amountToStake
-
-		// Add the input to the consumed inputs
-		ins = append(ins, &avax.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  avax.Asset{ID: vm.Ctx.AVAXAssetID},
-			In: &StakeableLockIn{
-				Locktime:       out.Locktime,
-				TransferableIn: in,
-			},
-		})
-
-		// Add the output to the staked outputs
-		stakedOuts = append(stakedOuts, &avax.TransferableOutput{
-			Asset: avax.Asset{ID: vm.Ctx.AVAXAssetID},
-			Out: &StakeableLockOut{
-				Locktime: out.Locktime,
-				TransferableOut: &secp256k1fx.TransferOutput{
-					Amt:          amountToStake,
-					OutputOwners: inner.OutputOwners,
-				},
-			},
-		})
-
-		if remainingValue > 0 {
-			// This input provided more value than was needed to be locked.
-			// Some of it must be returned
-			returnedOuts = append(returnedOuts, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: vm.Ctx.AVAXAssetID},
-				Out: &StakeableLockOut{
-					Locktime: out.Locktime,
-					TransferableOut: &secp256k1fx.TransferOutput{
-						Amt:          remainingValue,
-						OutputOwners: inner.OutputOwners,
-					},
-				},
-			})
-		}
-
-		// Add the signers needed for this input to the set of signers
-		signers = append(signers, inSigners)
-	}
-
-	// Amount of AVAX that has been burned
-	amountBurned := uint64(0)
-
-	for _, utxo := range utxos {
-		// If we have consumed more AVAX than we are trying to stake, and we
-		// have burned more AVAX then we need to, then we have no need to
-		// consume more AVAX
-		if amountBurned >= fee && amountStaked >= amount {
-			break
-		}
-
-		if assetID := utxo.AssetID(); !assetID.Equals(vm.Ctx.AVAXAssetID) {
-			continue // We only care about burning AVAX, so ignore other assets
-		}
-
-		out := utxo.Out
-		inner, ok := out.(*StakeableLockOut)
-		if ok {
-			if inner.Locktime > now {
-				// This output is currently locked, so this output can't be
-				// burned. Additionally, it may have already been consumed
-				// above. Regardless, we skip to the next UTXO
-				continue
-			}
-			out = inner.TransferableOut
-		}
-
-		inIntf, inSigners, err := kc.Spend(out, now)
-		if err != nil {
-			// We couldn't spend this UTXO, so we skip to the next one
-			continue
-		}
-		in, ok := inIntf.(avax.TransferableIn)
-		if !ok {
-			// Because we only use the secp Fx right now, this should never
-			// happen
-			continue
-		}
-
-		// The remaining value is initially the full value of the input
-		remainingValue := in.Amount()
-
-		// Burn any value that should be burned
-		amountToBurn := safemath.Min64(
-			fee-amountBurned, // Amount we still need to burn
-			remainingValue,   // Amount available to burn
-		)
-		amountBurned += amountToBurn
-		remainingValue -= amountToBurn
-
-		// Stake any value that should be staked
-		amountToStake := safemath.Min64(
-			amount-amountStaked, // Amount we still need to stake
-			remainingValue,      // Amount available to stake
-		)
-		amountStaked += amountToStake
-		remainingValue -= amountToStake
-
-		// Add the input to the consumed inputs
-		ins = append(ins, &avax.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  avax.Asset{ID: vm.Ctx.AVAXAssetID},
-			In:     in,
-		})
-
-		if amountToStake > 0 {
-			// Some of this input was put for staking
-			stakedOuts = append(stakedOuts, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: vm.Ctx.AVAXAssetID},
-				Out: &secp256k1fx.TransferOutput{
-					Amt: amountToStake,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-						Addrs:     []ids.ShortID{changeAddr},
-					},
-				},
-			})
-		}
-
-		if remainingValue > 0 {
-			// This input had extra value, so some of it must be returned
-			returnedOuts = append(returnedOuts, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: vm.Ctx.AVAXAssetID},
-				Out: &secp256k1fx.TransferOutput{
-					Amt: remainingValue,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-						Addrs:     []ids.ShortID{changeAddr},
-					},
-				},
-			})
-		}
-
-		// Add the signers needed for this input to the set of signers
-		signers = append(signers, inSigners)
+	spender := spend.Spender{
+		Keychain: kc,
+		Locker:   stakeableLocker{},
+		Codec:    vm.codec,
+		Time:     uint64(vm.clock.Time().Unix()), // Minimum time this transaction will be issued at
 	}
-
-	if amountBurned < fee || amountStaked < amount {
-		return nil, nil, nil, nil, fmt.Errorf(
-			"provided keys have balance (unlocked, locked) (%d, %d) but need (%d, %d)",
-			amountBurned, amountStaked, fee, amount)
-	}
-
-	avax.SortTransferableInputsWithSigners(ins, signers) // sort inputs and keys
-	avax.SortTransferableOutputs(returnedOuts, vm.codec) // sort outputs
-	avax.SortTransferableOutputs(stakedOuts, vm.codec)   // sort outputs
-
-	return ins, returnedOuts, stakedOuts, signers, nil
+	return spender.Stake(utxos, vm.Ctx.AVAXAssetID, amount, fee, changeAddr)
 }
 
 // authorize an operation on behalf of the named subnet with the provided keys.
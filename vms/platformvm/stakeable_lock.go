@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 )
 
 var (
@@ -51,3 +52,31 @@ func (s *StakeableLockIn) Verify() error {
 	}
 	return s.TransferableIn.Verify()
 }
+
+// stakeableLocker implements spend.Locker for stakeable locked outputs
+type stakeableLocker struct{}
+
+// Unlock implements the spend.Locker interface
+func (stakeableLocker) Unlock(out verify.State) (avax.TransferableOut, uint64, bool) {
+	lockedOut, ok := out.(*StakeableLockOut)
+	if !ok {
+		return nil, 0, false
+	}
+	return lockedOut.TransferableOut, lockedOut.Locktime, true
+}
+
+// LockIn implements the spend.Locker interface
+func (stakeableLocker) LockIn(in avax.TransferableIn, locktime uint64) avax.TransferableIn {
+	return &StakeableLockIn{
+		Locktime:       locktime,
+		TransferableIn: in,
+	}
+}
+
+// LockOut implements the spend.Locker interface
+func (stakeableLocker) LockOut(out avax.TransferableOut, locktime uint64) avax.TransferableOut {
+	return &StakeableLockOut{
+		Locktime:        locktime,
+		TransferableOut: out,
+	}
+}
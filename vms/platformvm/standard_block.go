@@ -62,6 +62,10 @@ func (sb *StandardBlock) Verify() error {
 		if !ok {
 			return errWrongTxType
 		}
+		var addrs ids.ShortSet
+		if sb.vm.indexAddressTxs {
+			addrs = sb.vm.txAddresses(sb.onAcceptDB, tx)
+		}
 		onAccept, err := utx.SemanticVerify(sb.vm, sb.onAcceptDB, tx)
 		if err != nil {
 			sb.vm.droppedTxCache.Put(tx.ID(), nil) // cache tx as dropped
@@ -80,6 +84,8 @@ func (sb *StandardBlock) Verify() error {
 			return fmt.Errorf("failed to put tx %s: %w", tx.ID(), err)
 		} else if err := sb.vm.putStatus(sb.onAcceptDB, tx.ID(), Committed); err != nil {
 			return fmt.Errorf("failed to put tx %s status: %w", tx.ID(), err)
+		} else if err := sb.vm.putAddressTxs(sb.onAcceptDB, tx.ID(), addrs); err != nil {
+			return fmt.Errorf("failed to index tx %s: %w", tx.ID(), err)
 		} else if onAccept != nil {
 			funcs = append(funcs, onAccept)
 		}
@@ -5,6 +5,7 @@ package platformvm
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -428,6 +429,7 @@ func (vm *VM) removeReferencingUTXO(db database.Database, addrBytes []byte, utxo
 // * The address associated with the last UTXO fetched
 // * The ID of the last UTXO fetched
 func (vm *VM) GetUTXOs(
+	ctx context.Context,
 	db database.Database,
 	addrs ids.ShortSet,
 	startAddr state.Marshaller,
@@ -451,6 +453,9 @@ func (vm *VM) GetUTXOs(
 		} else if comp == 0 {
 			start = startUTXOID
 		}
+		if err := ctx.Err(); err != nil { // Stop if the caller gave up
+			return nil, ids.ShortID{}, ids.ID{}, err
+		}
 		utxoIDs, err := vm.getReferencingUTXOs(db, addr.Bytes(), start, limit) // Get IDs of UTXOs to fetch
 		if err != nil {
 			return nil, ids.ShortID{}, ids.ID{}, fmt.Errorf("couldn't get UTXOs for address %s", addr)
@@ -459,6 +464,9 @@ func (vm *VM) GetUTXOs(
 			if seen.Contains(utxoID) { // already have this UTXO in the list
 				continue
 			}
+			if err := ctx.Err(); err != nil {
+				return nil, ids.ShortID{}, ids.ID{}, err
+			}
 			utxo, err := vm.getUTXO(db, utxoID)
 			if err != nil {
 				return nil, ids.ShortID{}, ids.ID{}, fmt.Errorf("couldn't get UTXO %s: %w", utxoID, err)
@@ -478,7 +486,7 @@ func (vm *VM) GetUTXOs(
 
 // getBalance returns the balance of [addrs]
 func (vm *VM) getBalance(db database.Database, addrs ids.ShortSet) (uint64, error) {
-	utxos, _, _, err := vm.GetUTXOs(db, addrs, ids.ShortEmpty, ids.Empty, -1)
+	utxos, _, _, err := vm.GetUTXOs(context.Background(), db, addrs, ids.ShortEmpty, ids.Empty, -1)
 	if err != nil {
 		return 0, fmt.Errorf("couldn't get UTXOs: %w", err)
 	}
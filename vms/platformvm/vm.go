@@ -5,6 +5,7 @@ package platformvm
 
 import (
 	"container/heap"
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -216,6 +217,12 @@ type VM struct {
 	bootstrappedTime time.Time
 
 	connections map[[20]byte]time.Time
+
+	// true if the transactions that reference each address should be indexed
+	indexAddressTxs bool
+
+	// true if rejected blocks should be deleted from the database
+	pruneRejected bool
 }
 
 // Initialize this blockchain.
@@ -229,7 +236,7 @@ func (vm *VM) Initialize(
 ) error {
 	ctx.Log.Verbo("initializing platform chain")
 	// Initialize the inner VM, which has a lot of boiler-plate logic
-	vm.SnowmanVM = &core.SnowmanVM{}
+	vm.SnowmanVM = &core.SnowmanVM{PruneRejected: vm.pruneRejected}
 	if err := vm.SnowmanVM.Initialize(ctx, db, vm.unmarshalBlockFunc, msgs); err != nil {
 		return err
 	}
@@ -408,7 +415,7 @@ func (vm *VM) initBlockchains() error {
 
 // Set the node's validator manager to be up to date
 func (vm *VM) initSubnets() error {
-	if err := vm.updateValidators(vm.DB); err != nil {
+	if _, err := vm.updateValidators(vm.DB); err != nil {
 		return err
 	}
 	return vm.updateVdrMgr(true)
@@ -763,16 +770,25 @@ func (vm *VM) nextStakerChangeTime(db database.Database) (time.Time, error) {
 	return earliest, nil
 }
 
-// update validator set of [subnetID] based on the current chain timestamp
-func (vm *VM) updateValidators(db database.Database) error {
+// vdrChange is a staker that started or stopped validating a subnet
+type vdrChange struct {
+	subnetID ids.ID
+	vdr      validators.Validator
+	// true if the staker started validating, false if it stopped
+	added bool
+}
+
+// update validator set of [subnetID] based on the current chain timestamp.
+// Returns the stakers that started or stopped validating.
+func (vm *VM) updateValidators(db database.Database) ([]vdrChange, error) {
 	timestamp, err := vm.getTimestamp(db)
 	if err != nil {
-		return fmt.Errorf("can't get timestamp: %w", err)
+		return nil, fmt.Errorf("can't get timestamp: %w", err)
 	}
 
 	subnets, err := vm.getSubnets(db)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	subnetIDs := ids.Set{}
@@ -782,12 +798,15 @@ func (vm *VM) updateValidators(db database.Database) error {
 	}
 	subnetIDList := subnetIDs.List()
 
+	changes := []vdrChange(nil)
 	for _, subnetID := range subnetIDList {
-		if err := vm.updateSubnetValidators(db, subnetID, timestamp); err != nil {
-			return err
+		subnetChanges, err := vm.updateSubnetValidators(db, subnetID, timestamp)
+		if err != nil {
+			return nil, err
 		}
+		changes = append(changes, subnetChanges...)
 	}
-	return nil
+	return changes, nil
 }
 
 func (vm *VM) calculateReward(db database.Database, duration time.Duration, stakeAmount uint64) (uint64, error) {
@@ -803,7 +822,9 @@ func (vm *VM) calculateReward(db database.Database, duration time.Duration, stak
 	return reward, vm.putCurrentSupply(db, newSupply)
 }
 
-func (vm *VM) updateSubnetValidators(db database.Database, subnetID ids.ID, timestamp time.Time) error {
+func (vm *VM) updateSubnetValidators(db database.Database, subnetID ids.ID, timestamp time.Time) ([]vdrChange, error) {
+	changes := []vdrChange(nil)
+
 	startPrefix := []byte(fmt.Sprintf("%s%s", subnetID, startDBPrefix))
 	startDB := prefixdb.NewNested(startPrefix, db)
 	defer startDB.Close()
@@ -816,28 +837,28 @@ func (vm *VM) updateSubnetValidators(db database.Database, subnetID ids.ID, time
 
 		tx := Tx{}
 		if err := vm.codec.Unmarshal(txBytes, &tx); err != nil {
-			return fmt.Errorf("couldn't unmarshal validator tx: %w", err)
+			return nil, fmt.Errorf("couldn't unmarshal validator tx: %w", err)
 		}
 		if err := tx.Sign(vm.codec, nil); err != nil {
-			return err
+			return nil, err
 		}
 
 		switch staker := tx.UnsignedTx.(type) {
 		case *UnsignedAddDelegatorTx:
 			if !subnetID.Equals(constants.PrimaryNetworkID) {
-				return fmt.Errorf("AddDelegatorTx is invalid for subnet %s",
+				return nil, fmt.Errorf("AddDelegatorTx is invalid for subnet %s",
 					subnetID)
 			}
 			if staker.StartTime().After(timestamp) {
-				return nil
+				return changes, nil
 			}
 			if err := vm.dequeueStaker(db, subnetID, &tx); err != nil {
-				return fmt.Errorf("couldn't dequeue staker: %w", err)
+				return nil, fmt.Errorf("couldn't dequeue staker: %w", err)
 			}
 
 			reward, err := vm.calculateReward(db, staker.Validator.Duration(), staker.Validator.Wght)
 			if err != nil {
-				return fmt.Errorf("couldn't calculate reward for staker: %w", err)
+				return nil, fmt.Errorf("couldn't calculate reward for staker: %w", err)
 			}
 
 			rTx := rewardTx{
@@ -845,23 +866,28 @@ func (vm *VM) updateSubnetValidators(db database.Database, subnetID ids.ID, time
 				Tx:     tx,
 			}
 			if err := vm.addStaker(db, subnetID, &rTx); err != nil {
-				return fmt.Errorf("couldn't add staker: %w", err)
+				return nil, fmt.Errorf("couldn't add staker: %w", err)
 			}
+			changes = append(changes, vdrChange{
+				subnetID: subnetID,
+				vdr:      &staker.Validator,
+				added:    true,
+			})
 		case *UnsignedAddValidatorTx:
 			if !subnetID.Equals(constants.PrimaryNetworkID) {
-				return fmt.Errorf("AddValidatorTx is invalid for subnet %s",
+				return nil, fmt.Errorf("AddValidatorTx is invalid for subnet %s",
 					subnetID)
 			}
 			if staker.StartTime().After(timestamp) {
-				return nil
+				return changes, nil
 			}
 			if err := vm.dequeueStaker(db, subnetID, &tx); err != nil {
-				return fmt.Errorf("couldn't dequeue staker: %w", err)
+				return nil, fmt.Errorf("couldn't dequeue staker: %w", err)
 			}
 
 			reward, err := vm.calculateReward(db, staker.Validator.Duration(), staker.Validator.Wght)
 			if err != nil {
-				return fmt.Errorf("couldn't calculate reward for staker: %w", err)
+				return nil, fmt.Errorf("couldn't calculate reward for staker: %w", err)
 			}
 
 			rTx := rewardTx{
@@ -869,18 +895,23 @@ func (vm *VM) updateSubnetValidators(db database.Database, subnetID ids.ID, time
 				Tx:     tx,
 			}
 			if err := vm.addStaker(db, subnetID, &rTx); err != nil {
-				return fmt.Errorf("couldn't add staker: %w", err)
+				return nil, fmt.Errorf("couldn't add staker: %w", err)
 			}
+			changes = append(changes, vdrChange{
+				subnetID: subnetID,
+				vdr:      &staker.Validator,
+				added:    true,
+			})
 		case *UnsignedAddSubnetValidatorTx:
 			if txSubnetID := staker.Validator.SubnetID(); !subnetID.Equals(txSubnetID) {
-				return fmt.Errorf("AddSubnetValidatorTx references the incorrect subnet. Expected %s; Got %s",
+				return nil, fmt.Errorf("AddSubnetValidatorTx references the incorrect subnet. Expected %s; Got %s",
 					subnetID, txSubnetID)
 			}
 			if staker.StartTime().After(timestamp) {
-				return nil
+				return changes, nil
 			}
 			if err := vm.dequeueStaker(db, subnetID, &tx); err != nil {
-				return fmt.Errorf("couldn't dequeue staker: %w", err)
+				return nil, fmt.Errorf("couldn't dequeue staker: %w", err)
 			}
 
 			rTx := rewardTx{
@@ -888,10 +919,15 @@ func (vm *VM) updateSubnetValidators(db database.Database, subnetID ids.ID, time
 				Tx:     tx,
 			}
 			if err := vm.addStaker(db, subnetID, &rTx); err != nil {
-				return fmt.Errorf("couldn't add staker: %w", err)
+				return nil, fmt.Errorf("couldn't add staker: %w", err)
 			}
+			changes = append(changes, vdrChange{
+				subnetID: subnetID,
+				vdr:      &staker.Validator,
+				added:    true,
+			})
 		default:
-			return fmt.Errorf("expected validator but got %T", tx.UnsignedTx)
+			return nil, fmt.Errorf("expected validator but got %T", tx.UnsignedTx)
 		}
 	}
 
@@ -907,42 +943,46 @@ func (vm *VM) updateSubnetValidators(db database.Database, subnetID ids.ID, time
 
 		tx := rewardTx{}
 		if err := vm.codec.Unmarshal(txBytes, &tx); err != nil {
-			return fmt.Errorf("couldn't unmarshal validator tx: %w", err)
+			return nil, fmt.Errorf("couldn't unmarshal validator tx: %w", err)
 		}
 		if err := tx.Tx.Sign(vm.codec, nil); err != nil {
-			return err
+			return nil, err
 		}
 
 		switch staker := tx.Tx.UnsignedTx.(type) {
 		case *UnsignedAddDelegatorTx:
 			if !subnetID.Equals(constants.PrimaryNetworkID) {
-				return fmt.Errorf("AddDelegatorTx is invalid for subnet %s",
+				return nil, fmt.Errorf("AddDelegatorTx is invalid for subnet %s",
 					subnetID)
 			}
 			if staker.EndTime().After(timestamp) {
-				return nil
+				return changes, nil
 			}
 		case *UnsignedAddValidatorTx:
 			if !subnetID.Equals(constants.PrimaryNetworkID) {
-				return fmt.Errorf("AddValidatorTx is invalid for subnet %s",
+				return nil, fmt.Errorf("AddValidatorTx is invalid for subnet %s",
 					subnetID)
 			}
 			if staker.EndTime().After(timestamp) {
-				return nil
+				return changes, nil
 			}
 		case *UnsignedAddSubnetValidatorTx:
 			if txSubnetID := staker.Validator.SubnetID(); !subnetID.Equals(txSubnetID) {
-				return fmt.Errorf("AddSubnetValidatorTx references the incorrect subnet. Expected %s; Got %s",
+				return nil, fmt.Errorf("AddSubnetValidatorTx references the incorrect subnet. Expected %s; Got %s",
 					subnetID, txSubnetID)
 			}
 			if staker.EndTime().After(timestamp) {
-				return nil
+				return changes, nil
 			}
 			if err := vm.removeStaker(db, subnetID, &tx); err != nil {
-				return fmt.Errorf("couldn't remove staker: %w", err)
+				return nil, fmt.Errorf("couldn't remove staker: %w", err)
 			}
+			changes = append(changes, vdrChange{
+				subnetID: subnetID,
+				vdr:      &staker.Validator,
+			})
 		default:
-			return fmt.Errorf("expected validator but got %T", tx.Tx.UnsignedTx)
+			return nil, fmt.Errorf("expected validator but got %T", tx.Tx.UnsignedTx)
 		}
 	}
 
@@ -951,7 +991,7 @@ func (vm *VM) updateSubnetValidators(db database.Database, subnetID ids.ID, time
 		startIter.Error(),
 		stopIter.Error(),
 	)
-	return errs.Err
+	return changes, errs.Err
 }
 
 func (vm *VM) updateVdrMgr(force bool) error {
@@ -978,8 +1018,42 @@ func (vm *VM) updateVdrMgr(force bool) error {
 	return vm.initBlockchains()
 }
 
+// applyVdrChanges updates the node's validator manager with the stakers that
+// started or stopped validating when a block was accepted, so the validator
+// sets don't need to be rebuilt from the database
+func (vm *VM) applyVdrChanges(changes []vdrChange) error {
+	if !vm.bootstrapped {
+		return nil
+	}
+
+	joinedSubnet := false
+	for _, change := range changes {
+		vdrID := change.vdr.ID()
+		if !change.added {
+			if err := vm.vdrMgr.RemoveWeight(change.subnetID, vdrID, change.vdr.Weight()); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := vm.vdrMgr.AddWeight(change.subnetID, vdrID, change.vdr.Weight()); err != nil {
+			return err
+		}
+		joinedSubnet = joinedSubnet || vdrID.Equals(vm.Ctx.NodeID)
+	}
+
+	// Create the chains of the subnets this node started validating
+	if joinedSubnet {
+		return vm.initBlockchains()
+	}
+	return nil
+}
+
+// updateVdrSet syncs the validator set of [subnetID] with the stakers that are
+// currently validating it. Only the validators whose weight changed are
+// updated, so the set the consensus engines sample from is never reset.
 func (vm *VM) updateVdrSet(subnetID ids.ID) error {
-	vdrs := validators.NewSet()
+	weights := make(map[[20]byte]uint64)
+	vdrIDs := []ids.ShortID(nil)
 
 	stopPrefix := []byte(fmt.Sprintf("%s%s", subnetID, stopDBPrefix))
 	stopDB := prefixdb.NewNested(stopPrefix, vm.DB)
@@ -998,28 +1072,53 @@ func (vm *VM) updateVdrSet(subnetID ids.ID) error {
 			return err
 		}
 
-		var err error
-		switch staker := tx.Tx.UnsignedTx.(type) {
-		case *UnsignedAddDelegatorTx:
-			err = vdrs.AddWeight(staker.Validator.NodeID, staker.Validator.Weight())
-		case *UnsignedAddValidatorTx:
-			err = vdrs.AddWeight(staker.Validator.NodeID, staker.Validator.Weight())
-		case *UnsignedAddSubnetValidatorTx:
-			err = vdrs.AddWeight(staker.Validator.NodeID, staker.Validator.Weight())
-		default:
-			err = fmt.Errorf("expected validator but got %T", tx.Tx.UnsignedTx)
+		vdr, err := stakerValidator(&tx.Tx)
+		if err != nil {
+			return err
 		}
+
+		vdrID := vdr.ID()
+		vdrIDKey := vdrID.Key()
+		weight, exists := weights[vdrIDKey]
+		if !exists {
+			vdrIDs = append(vdrIDs, vdrID)
+		}
+		newWeight, err := safemath.Add64(weight, vdr.Weight())
 		if err != nil {
 			return err
 		}
+		weights[vdrIDKey] = newWeight
+	}
+	if err := stopIter.Error(); err != nil {
+		return err
 	}
 
-	errs := wrappers.Errs{}
-	errs.Add(
-		vm.vdrMgr.Set(subnetID, vdrs),
-		stopIter.Error(),
-	)
-	return errs.Err
+	vdrList := make([]validators.Validator, len(vdrIDs))
+	for i, vdrID := range vdrIDs {
+		vdrList[i] = validators.NewValidator(vdrID, weights[vdrID.Key()])
+	}
+	vdrs, exists := vm.vdrMgr.GetValidators(subnetID)
+	if !exists {
+		vdrs = validators.NewSet()
+		if err := vm.vdrMgr.Set(subnetID, vdrs); err != nil {
+			return err
+		}
+	}
+	return vdrs.Set(vdrList)
+}
+
+// stakerValidator returns the validator added by the staker tx [tx]
+func stakerValidator(tx *Tx) (validators.Validator, error) {
+	switch staker := tx.UnsignedTx.(type) {
+	case *UnsignedAddDelegatorTx:
+		return &staker.Validator, nil
+	case *UnsignedAddValidatorTx:
+		return &staker.Validator, nil
+	case *UnsignedAddSubnetValidatorTx:
+		return &staker.Validator, nil
+	default:
+		return nil, fmt.Errorf("expected validator but got %T", tx.UnsignedTx)
+	}
 }
 
 // Codec ...
@@ -1040,6 +1139,7 @@ func (vm *VM) Logger() logging.Logger { return vm.Ctx.Log }
 // * The address associated with the last UTXO fetched
 // * The ID of the last UTXO fetched
 func (vm *VM) GetAtomicUTXOs(
+	ctx context.Context,
 	chainID ids.ID,
 	addrs ids.ShortSet,
 	startAddr state.Marshaller,
@@ -1077,6 +1177,9 @@ func (vm *VM) GetAtomicUTXOs(
 
 	utxos := make([]*avax.UTXO, len(allUTXOBytes))
 	for i, utxoBytes := range allUTXOBytes {
+		if err := ctx.Err(); err != nil {
+			return nil, ids.ShortID{}, ids.ID{}, err
+		}
 		utxo := &avax.UTXO{}
 		if err := vm.codec.Unmarshal(utxoBytes, utxo); err != nil {
 			return nil, ids.ShortID{}, ids.ID{}, fmt.Errorf("error parsing UTXO: %w", err)
@@ -5,6 +5,7 @@ package platformvm
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -304,7 +305,7 @@ func TestGenesis(t *testing.T) {
 		}
 		addrs := ids.ShortSet{}
 		addrs.Add(addr)
-		utxos, _, _, err := vm.GetUTXOs(vm.DB, addrs, ids.ShortEmpty, ids.Empty, -1)
+		utxos, _, _, err := vm.GetUTXOs(context.Background(), vm.DB, addrs, ids.ShortEmpty, ids.Empty, -1)
 		if err != nil {
 			t.Fatal("couldn't find UTXO")
 		} else if len(utxos) != 1 {
@@ -959,6 +960,10 @@ func TestRewardValidatorAccept(t *testing.T) {
 		t.Fatal(err)
 	} else if isValidator {
 		t.Fatal("should have removed a genesis validator")
+	} else if vdrs, ok := vm.vdrMgr.GetValidators(constants.PrimaryNetworkID); !ok {
+		t.Fatal("should have a primary network validator set")
+	} else if vdrs.Contains(keys[1].PublicKey().Address()) {
+		t.Fatal("should have removed a genesis validator from the primary network validator set")
 	}
 }
 
@@ -1359,6 +1364,14 @@ func TestCreateSubnet(t *testing.T) {
 		t.Fatal("should have been added to the validator set")
 	}
 
+	// Verify that the validator was added to the subnet's validator set
+	vdrs, ok := vm.vdrMgr.GetValidators(createSubnetTx.ID())
+	if !ok {
+		t.Fatal("should have a validator set for the new subnet")
+	} else if weight, ok := vdrs.GetWeight(nodeID); !ok || weight != defaultWeight {
+		t.Fatalf("validator should have weight %d but has %d", defaultWeight, weight)
+	}
+
 	// fast forward clock to time validator should stop validating
 	vm.clock.Set(endTime)
 	blk, err = vm.BuildBlock() // should be advance time tx
@@ -1406,6 +1419,8 @@ func TestCreateSubnet(t *testing.T) {
 		t.Fatal(err)
 	} else if isValidator {
 		t.Fatal("should have removed from the validator set")
+	} else if vdrs.Contains(nodeID) {
+		t.Fatal("should have removed from the subnet's validator set")
 	}
 }
 
@@ -1884,7 +1899,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 	go timeoutManager.Dispatch()
 
 	chainRouter := &router.ChainRouter{}
-	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &timeoutManager, time.Hour, time.Second, ids.Set{}, nil)
+	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, nil, &timeoutManager, time.Hour, 0, 0, time.Second, ids.Set{}, nil)
 
 	externalSender := &sender.ExternalSenderTest{T: t}
 	externalSender.Default(true)
@@ -4,22 +4,56 @@
 package rpcchainvm
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os/exec"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 )
 
+const (
+	// versionTimeout is the amount of time a plugin has to report its version
+	versionTimeout = 5 * time.Second
+)
+
 var (
 	errWrongVM = errors.New("wrong vm type")
 )
 
 // Factory ...
-type Factory struct{ Path string }
+type Factory struct {
+	Path string
+
+	versionOnce sync.Once
+	version     string
+	versionErr  error
+}
+
+// Version implements the vms.Versioner interface. The version is the first
+// line printed by the plugin when it's run with the --version flag.
+func (f *Factory) Version() (string, error) {
+	f.versionOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), versionTimeout)
+		defer cancel()
+
+		// #nosec G204
+		output, err := exec.CommandContext(ctx, f.Path, "--version").Output()
+		if err != nil {
+			f.versionErr = fmt.Errorf("plugin %s didn't report its version: %w", f.Path, err)
+			return
+		}
+		f.version = strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0])
+	})
+	return f.version, f.versionErr
+}
 
 // New ...
 func (f *Factory) New(ctx *snow.Context) (interface{}, error) {
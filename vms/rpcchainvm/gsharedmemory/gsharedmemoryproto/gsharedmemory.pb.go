@@ -596,6 +596,139 @@ func (m *RemoveResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_RemoveResponse proto.InternalMessageInfo
 
+type AtomicRequest struct {
+	PeerChainID          []byte     `protobuf:"bytes,1,opt,name=peerChainID,proto3" json:"peerChainID,omitempty"`
+	RemoveRequests       [][]byte   `protobuf:"bytes,2,rep,name=removeRequests,proto3" json:"removeRequests,omitempty"`
+	PutRequests          []*Element `protobuf:"bytes,3,rep,name=putRequests,proto3" json:"putRequests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *AtomicRequest) Reset()         { *m = AtomicRequest{} }
+func (m *AtomicRequest) String() string { return proto.CompactTextString(m) }
+func (*AtomicRequest) ProtoMessage()    {}
+func (*AtomicRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc30293c358724c5, []int{12}
+}
+
+func (m *AtomicRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AtomicRequest.Unmarshal(m, b)
+}
+func (m *AtomicRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AtomicRequest.Marshal(b, m, deterministic)
+}
+func (m *AtomicRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AtomicRequest.Merge(m, src)
+}
+func (m *AtomicRequest) XXX_Size() int {
+	return xxx_messageInfo_AtomicRequest.Size(m)
+}
+func (m *AtomicRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AtomicRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AtomicRequest proto.InternalMessageInfo
+
+func (m *AtomicRequest) GetPeerChainID() []byte {
+	if m != nil {
+		return m.PeerChainID
+	}
+	return nil
+}
+
+func (m *AtomicRequest) GetRemoveRequests() [][]byte {
+	if m != nil {
+		return m.RemoveRequests
+	}
+	return nil
+}
+
+func (m *AtomicRequest) GetPutRequests() []*Element {
+	if m != nil {
+		return m.PutRequests
+	}
+	return nil
+}
+
+type ApplyRequest struct {
+	Requests             []*AtomicRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	Batches              []*Batch         `protobuf:"bytes,2,rep,name=batches,proto3" json:"batches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ApplyRequest) Reset()         { *m = ApplyRequest{} }
+func (m *ApplyRequest) String() string { return proto.CompactTextString(m) }
+func (*ApplyRequest) ProtoMessage()    {}
+func (*ApplyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc30293c358724c5, []int{13}
+}
+
+func (m *ApplyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyRequest.Unmarshal(m, b)
+}
+func (m *ApplyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyRequest.Marshal(b, m, deterministic)
+}
+func (m *ApplyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyRequest.Merge(m, src)
+}
+func (m *ApplyRequest) XXX_Size() int {
+	return xxx_messageInfo_ApplyRequest.Size(m)
+}
+func (m *ApplyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyRequest proto.InternalMessageInfo
+
+func (m *ApplyRequest) GetRequests() []*AtomicRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+func (m *ApplyRequest) GetBatches() []*Batch {
+	if m != nil {
+		return m.Batches
+	}
+	return nil
+}
+
+type ApplyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplyResponse) Reset()         { *m = ApplyResponse{} }
+func (m *ApplyResponse) String() string { return proto.CompactTextString(m) }
+func (*ApplyResponse) ProtoMessage()    {}
+func (*ApplyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc30293c358724c5, []int{14}
+}
+
+func (m *ApplyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyResponse.Unmarshal(m, b)
+}
+func (m *ApplyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyResponse.Marshal(b, m, deterministic)
+}
+func (m *ApplyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyResponse.Merge(m, src)
+}
+func (m *ApplyResponse) XXX_Size() int {
+	return xxx_messageInfo_ApplyResponse.Size(m)
+}
+func (m *ApplyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*BatchPut)(nil), "gsharedmemoryproto.BatchPut")
 	proto.RegisterType((*BatchDelete)(nil), "gsharedmemoryproto.BatchDelete")
@@ -609,44 +742,52 @@ func init() {
 	proto.RegisterType((*IndexedResponse)(nil), "gsharedmemoryproto.IndexedResponse")
 	proto.RegisterType((*RemoveRequest)(nil), "gsharedmemoryproto.RemoveRequest")
 	proto.RegisterType((*RemoveResponse)(nil), "gsharedmemoryproto.RemoveResponse")
+	proto.RegisterType((*AtomicRequest)(nil), "gsharedmemoryproto.AtomicRequest")
+	proto.RegisterType((*ApplyRequest)(nil), "gsharedmemoryproto.ApplyRequest")
+	proto.RegisterType((*ApplyResponse)(nil), "gsharedmemoryproto.ApplyResponse")
 }
 
 func init() { proto.RegisterFile("gsharedmemory.proto", fileDescriptor_cc30293c358724c5) }
 
 var fileDescriptor_cc30293c358724c5 = []byte{
-	// 497 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0xdd, 0x6e, 0xd3, 0x4c,
-	0x10, 0x95, 0xe3, 0x38, 0xee, 0x37, 0x76, 0xfb, 0x55, 0x03, 0xaa, 0x4c, 0xa8, 0x1a, 0xb3, 0x08,
-	0x29, 0x57, 0x11, 0xa4, 0x57, 0xdc, 0x96, 0xd2, 0x2a, 0xaa, 0x10, 0x68, 0x41, 0xdc, 0xbb, 0x64,
-	0x44, 0xa2, 0xda, 0x71, 0xf0, 0x6e, 0x2a, 0xc2, 0x83, 0x70, 0xc5, 0xdb, 0xf0, 0x62, 0x68, 0x67,
-	0xd7, 0x4d, 0xa2, 0x3a, 0x11, 0x3f, 0x77, 0x3e, 0xb3, 0x67, 0xce, 0x9c, 0xf9, 0x31, 0x3c, 0xf8,
-	0xac, 0x26, 0x59, 0x45, 0xe3, 0x82, 0x8a, 0xb2, 0x5a, 0x0e, 0xe6, 0x55, 0xa9, 0x4b, 0xc4, 0x8d,
-	0x20, 0xc7, 0xc4, 0x10, 0xf6, 0xce, 0x32, 0xfd, 0x69, 0xf2, 0x6e, 0xa1, 0xf1, 0x10, 0xfc, 0x1b,
-	0x5a, 0x26, 0x5e, 0xea, 0xf5, 0x63, 0x69, 0x3e, 0xf1, 0x21, 0x04, 0xb7, 0x59, 0xbe, 0xa0, 0xa4,
-	0xc5, 0x31, 0x0b, 0x44, 0x0f, 0x22, 0xce, 0x39, 0xa7, 0x9c, 0x34, 0xdd, 0x4f, 0x13, 0x1a, 0x02,
-	0x26, 0xe0, 0x73, 0x68, 0xcf, 0x17, 0x5a, 0x25, 0x5e, 0xea, 0xf7, 0xa3, 0xe1, 0xf1, 0xe0, 0xbe,
-	0x81, 0x41, 0x5d, 0x5d, 0x32, 0x13, 0x5f, 0x42, 0x38, 0x66, 0x59, 0x95, 0xb4, 0x38, 0xa9, 0xb7,
-	0x35, 0xc9, 0x96, 0x97, 0x35, 0x5f, 0x8c, 0x20, 0x7c, 0x9d, 0x53, 0x41, 0x33, 0xee, 0xe4, 0x6a,
-	0x65, 0xe9, 0xca, 0x76, 0xf2, 0x71, 0xbd, 0x13, 0x06, 0x78, 0x04, 0x9d, 0x0f, 0x55, 0x36, 0xd5,
-	0x2a, 0xf1, 0x53, 0xbf, 0x1f, 0x4b, 0x87, 0xc4, 0x77, 0x0f, 0xc0, 0x78, 0xa2, 0x2f, 0x0b, 0x52,
-	0x1a, 0x53, 0x88, 0xe6, 0x44, 0xd5, 0xab, 0x49, 0x36, 0x9d, 0x8d, 0xce, 0x9d, 0xec, 0x7a, 0x08,
-	0x5f, 0x40, 0x40, 0x39, 0x15, 0xb5, 0xe9, 0xc7, 0x4d, 0xa6, 0x9d, 0x39, 0x69, 0x99, 0x78, 0x0a,
-	0xe1, 0xb5, 0x69, 0x83, 0x6c, 0xf1, 0x68, 0xf8, 0x68, 0x6b, 0xa7, 0xb2, 0x66, 0x8a, 0x7d, 0x88,
-	0xd8, 0x97, 0x9a, 0x97, 0x33, 0x45, 0xe2, 0x0c, 0xe0, 0x92, 0xfe, 0xc0, 0x26, 0x42, 0xfb, 0x86,
-	0x96, 0xd6, 0x65, 0x2c, 0xf9, 0x5b, 0x3c, 0x83, 0x88, 0x35, 0xac, 0xa4, 0x19, 0x09, 0x6f, 0xd9,
-	0x2e, 0x2d, 0x96, 0x0e, 0x89, 0x1f, 0x1e, 0x1c, 0x8c, 0x66, 0x63, 0xfa, 0x4a, 0xe3, 0xdf, 0xaf,
-	0x77, 0x04, 0x1d, 0x6d, 0xe7, 0x6b, 0x2b, 0x3a, 0x84, 0x27, 0x00, 0x4a, 0x67, 0x95, 0xe6, 0x71,
-	0x27, 0x3e, 0x27, 0xae, 0x45, 0xb0, 0x0b, 0x7b, 0x8c, 0xcc, 0x12, 0xdb, 0xfc, 0x7a, 0x87, 0xcd,
-	0x26, 0xf3, 0x69, 0x31, 0xd5, 0x49, 0x90, 0x7a, 0xfd, 0x40, 0x5a, 0x20, 0x32, 0xf8, 0xff, 0xce,
-	0xdd, 0xee, 0x4e, 0xf0, 0x18, 0xfe, 0xcb, 0x33, 0xe5, 0x6a, 0xdb, 0x73, 0x58, 0x05, 0x30, 0x81,
-	0xd0, 0x00, 0x53, 0xd9, 0xfa, 0xaa, 0xa1, 0xf8, 0x06, 0xfb, 0x92, 0x8a, 0xf2, 0x96, 0xfe, 0x69,
-	0xde, 0x7f, 0xb7, 0xf7, 0x43, 0x38, 0xa8, 0x6b, 0xdb, 0xee, 0x86, 0x3f, 0x5b, 0x10, 0xbf, 0xe7,
-	0xb4, 0x37, 0x9c, 0x86, 0x17, 0xe0, 0x9b, 0x9f, 0xf8, 0xa4, 0x49, 0x6d, 0x75, 0xcb, 0xdd, 0xde,
-	0xd6, 0x77, 0x37, 0xb6, 0x0b, 0xf0, 0x2f, 0x69, 0x8b, 0xce, 0xea, 0xd8, 0x9a, 0x75, 0xd6, 0x0f,
-	0x49, 0x42, 0xe8, 0x36, 0x82, 0xa2, 0x89, 0xbb, 0x79, 0x4c, 0xdd, 0xa7, 0x3b, 0x39, 0x4e, 0xf3,
-	0x2d, 0x74, 0xec, 0x18, 0xf0, 0x49, 0x13, 0x7d, 0x63, 0x3d, 0x5d, 0xb1, 0x8b, 0x62, 0x05, 0xaf,
-	0x3b, 0x1c, 0x3d, 0xfd, 0x15, 0x00, 0x00, 0xff, 0xff, 0xa9, 0x80, 0x23, 0xcd, 0x30, 0x05, 0x00,
-	0x00,
+	// 582 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x95, 0xeb, 0x38, 0x09, 0x63, 0x27, 0xad, 0x16, 0x54, 0x99, 0x50, 0x35, 0xee, 0x22, 0x50,
+	0x4e, 0x11, 0xa4, 0x27, 0x0e, 0x3d, 0xb4, 0x94, 0x46, 0x51, 0x41, 0xa0, 0x05, 0x71, 0x77, 0x9b,
+	0x11, 0x89, 0x6a, 0xc7, 0xc6, 0xde, 0x54, 0x84, 0x23, 0xff, 0x80, 0x0b, 0xe2, 0xc0, 0x8f, 0x45,
+	0x9e, 0x5d, 0x27, 0x0e, 0x75, 0xac, 0x00, 0x37, 0xbf, 0xf1, 0x9b, 0x37, 0x6f, 0x3e, 0x6c, 0xb8,
+	0xff, 0x29, 0x9d, 0xf8, 0x09, 0x8e, 0x43, 0x0c, 0xa3, 0x64, 0xd1, 0x8f, 0x93, 0x48, 0x46, 0x8c,
+	0xad, 0x05, 0x29, 0xc6, 0x07, 0xd0, 0x3c, 0xf3, 0xe5, 0xf5, 0xe4, 0xdd, 0x5c, 0xb2, 0x3d, 0x30,
+	0x6f, 0x70, 0xe1, 0x1a, 0x9e, 0xd1, 0x73, 0x44, 0xf6, 0xc8, 0x1e, 0x80, 0x75, 0xeb, 0x07, 0x73,
+	0x74, 0x77, 0x28, 0xa6, 0x00, 0xef, 0x82, 0x4d, 0x39, 0xe7, 0x18, 0xa0, 0xc4, 0xbb, 0x69, 0x5c,
+	0x82, 0x45, 0x04, 0xf6, 0x0c, 0x6a, 0xf1, 0x5c, 0xa6, 0xae, 0xe1, 0x99, 0x3d, 0x7b, 0x70, 0xd0,
+	0xbf, 0x6b, 0xa0, 0x9f, 0x57, 0x17, 0xc4, 0x64, 0x2f, 0xa0, 0x31, 0x26, 0xd9, 0xd4, 0xdd, 0xa1,
+	0xa4, 0xee, 0xc6, 0x24, 0x55, 0x5e, 0xe4, 0x7c, 0x3e, 0x82, 0xc6, 0xab, 0x00, 0x43, 0x9c, 0x51,
+	0x27, 0x97, 0x2b, 0x4b, 0x97, 0xaa, 0x93, 0x8f, 0xc5, 0x4e, 0x08, 0xb0, 0x7d, 0xa8, 0x7f, 0x48,
+	0xfc, 0xa9, 0x4c, 0x5d, 0xd3, 0x33, 0x7b, 0x8e, 0xd0, 0x88, 0xff, 0x30, 0x00, 0x32, 0x4f, 0xf8,
+	0x79, 0x8e, 0xa9, 0x64, 0x1e, 0xd8, 0x31, 0x62, 0xf2, 0x72, 0xe2, 0x4f, 0x67, 0xa3, 0x73, 0x2d,
+	0x5b, 0x0c, 0xb1, 0xe7, 0x60, 0x61, 0x80, 0x61, 0x6e, 0xfa, 0x51, 0x99, 0x69, 0x6d, 0x4e, 0x28,
+	0x26, 0x3b, 0x86, 0xc6, 0x55, 0xd6, 0x06, 0xaa, 0xe2, 0xf6, 0xe0, 0xe1, 0xc6, 0x4e, 0x45, 0xce,
+	0xe4, 0x2d, 0xb0, 0xc9, 0x57, 0x1a, 0x47, 0xb3, 0x14, 0xf9, 0x19, 0xc0, 0x10, 0xff, 0xc2, 0x26,
+	0x83, 0xda, 0x0d, 0x2e, 0x94, 0x4b, 0x47, 0xd0, 0x33, 0x7f, 0x02, 0xf6, 0x10, 0x97, 0x92, 0xd9,
+	0x48, 0x68, 0xcb, 0x6a, 0x69, 0x8e, 0xd0, 0x88, 0xff, 0x32, 0xa0, 0x3d, 0x9a, 0x8d, 0xf1, 0x0b,
+	0x8e, 0xb7, 0xaf, 0xb7, 0x0f, 0x75, 0xa9, 0xe6, 0xab, 0x2a, 0x6a, 0xc4, 0x0e, 0x01, 0x52, 0xe9,
+	0x27, 0x92, 0xc6, 0xed, 0x9a, 0x94, 0x58, 0x88, 0xb0, 0x0e, 0x34, 0x09, 0x65, 0x4b, 0xac, 0xd1,
+	0xdb, 0x25, 0xce, 0x36, 0x19, 0x4c, 0xc3, 0xa9, 0x74, 0x2d, 0xcf, 0xe8, 0x59, 0x42, 0x01, 0xee,
+	0xc3, 0xee, 0xd2, 0x5d, 0x75, 0x27, 0xec, 0x00, 0xee, 0x05, 0x7e, 0xaa, 0x6b, 0xab, 0x73, 0x58,
+	0x05, 0x98, 0x0b, 0x8d, 0x0c, 0x64, 0x95, 0x95, 0xaf, 0x1c, 0xf2, 0xaf, 0xd0, 0x12, 0x18, 0x46,
+	0xb7, 0xf8, 0x5f, 0xf3, 0xfe, 0xb7, 0xbd, 0xef, 0x41, 0x3b, 0xaf, 0xad, 0x57, 0xff, 0xd3, 0x80,
+	0xd6, 0xa9, 0x8c, 0xc2, 0xe9, 0xf5, 0xf6, 0x76, 0x9e, 0x42, 0x3b, 0x29, 0x76, 0x90, 0x1b, 0xfb,
+	0x23, 0xca, 0x4e, 0xc0, 0x8e, 0x97, 0xd7, 0x9f, 0xdb, 0xac, 0xbc, 0xe9, 0x22, 0x9f, 0x7f, 0x33,
+	0xc0, 0x39, 0x8d, 0xe3, 0x60, 0x91, 0x3b, 0x3b, 0x81, 0x66, 0x92, 0x8b, 0xa9, 0x5f, 0xc1, 0x51,
+	0x99, 0xd8, 0x5a, 0x3b, 0x62, 0x99, 0x52, 0x9c, 0xd8, 0xce, 0xd6, 0x13, 0xdb, 0x85, 0x96, 0xf6,
+	0xa0, 0x06, 0x36, 0xf8, 0x6e, 0x82, 0xf3, 0x9e, 0xb2, 0xde, 0x50, 0x16, 0xbb, 0x00, 0x33, 0xfb,
+	0xeb, 0x1d, 0x96, 0x89, 0xad, 0x3e, 0xfe, 0x4e, 0x77, 0xe3, 0x7b, 0x7d, 0x67, 0x17, 0x60, 0x0e,
+	0x71, 0x83, 0xce, 0x10, 0xab, 0x75, 0x8a, 0x5f, 0x9e, 0x80, 0x86, 0x3e, 0x61, 0xc6, 0xcb, 0xb8,
+	0xeb, 0x5f, 0x5f, 0xe7, 0x71, 0x25, 0x47, 0x6b, 0xbe, 0x85, 0xba, 0xba, 0x1b, 0x56, 0x3a, 0xf1,
+	0xb5, 0x7b, 0xee, 0xf0, 0x2a, 0x8a, 0x16, 0x7c, 0x0d, 0x16, 0x8d, 0x95, 0x79, 0xa5, 0x1b, 0x2c,
+	0x6c, 0xbd, 0x73, 0x54, 0xc1, 0x50, 0x6a, 0x57, 0x75, 0x0a, 0x1e, 0xff, 0x1e, 0x00, 0xd0, 0x95,
+	0x20, 0xd8, 0xaf, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -665,6 +806,7 @@ type SharedMemoryClient interface {
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	Indexed(ctx context.Context, in *IndexedRequest, opts ...grpc.CallOption) (*IndexedResponse, error)
 	Remove(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (*RemoveResponse, error)
+	Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyResponse, error)
 }
 
 type sharedMemoryClient struct {
@@ -711,12 +853,22 @@ func (c *sharedMemoryClient) Remove(ctx context.Context, in *RemoveRequest, opts
 	return out, nil
 }
 
+func (c *sharedMemoryClient) Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyResponse, error) {
+	out := new(ApplyResponse)
+	err := c.cc.Invoke(ctx, "/gsharedmemoryproto.SharedMemory/Apply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SharedMemoryServer is the server API for SharedMemory service.
 type SharedMemoryServer interface {
 	Put(context.Context, *PutRequest) (*PutResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	Indexed(context.Context, *IndexedRequest) (*IndexedResponse, error)
 	Remove(context.Context, *RemoveRequest) (*RemoveResponse, error)
+	Apply(context.Context, *ApplyRequest) (*ApplyResponse, error)
 }
 
 // UnimplementedSharedMemoryServer can be embedded to have forward compatible implementations.
@@ -735,6 +887,9 @@ func (*UnimplementedSharedMemoryServer) Indexed(ctx context.Context, req *Indexe
 func (*UnimplementedSharedMemoryServer) Remove(ctx context.Context, req *RemoveRequest) (*RemoveResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
 }
+func (*UnimplementedSharedMemoryServer) Apply(ctx context.Context, req *ApplyRequest) (*ApplyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Apply not implemented")
+}
 
 func RegisterSharedMemoryServer(s *grpc.Server, srv SharedMemoryServer) {
 	s.RegisterService(&_SharedMemory_serviceDesc, srv)
@@ -812,6 +967,24 @@ func _SharedMemory_Remove_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SharedMemory_Apply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharedMemoryServer).Apply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gsharedmemoryproto.SharedMemory/Apply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharedMemoryServer).Apply(ctx, req.(*ApplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SharedMemory_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "gsharedmemoryproto.SharedMemory",
 	HandlerType: (*SharedMemoryServer)(nil),
@@ -832,6 +1005,10 @@ var _SharedMemory_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Remove",
 			Handler:    _SharedMemory_Remove_Handler,
 		},
+		{
+			MethodName: "Apply",
+			Handler:    _SharedMemory_Apply_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "gsharedmemory.proto",
@@ -30,34 +30,16 @@ func NewClient(client gsharedmemoryproto.SharedMemoryClient) *Client {
 func (c *Client) Put(peerChainID ids.ID, elems []*atomic.Element, batches ...database.Batch) error {
 	req := gsharedmemoryproto.PutRequest{
 		PeerChainID: peerChainID.Bytes(),
-		Elems:       make([]*gsharedmemoryproto.Element, len(elems)),
-		Batches:     make([]*gsharedmemoryproto.Batch, len(batches)),
+		Elems:       marshalElements(elems),
 	}
 
-	for i, elem := range elems {
-		req.Elems[i] = &gsharedmemoryproto.Element{
-			Key:    elem.Key,
-			Value:  elem.Value,
-			Traits: elem.Traits,
-		}
-	}
-
-	for i, batch := range batches {
-		batch := batch.Inner()
-		fb := filteredBatch{
-			writes:  make(map[string][]byte),
-			deletes: make(map[string]struct{}),
-		}
-		if err := batch.Replay(&fb); err != nil {
-			return err
-		}
-		req.Batches[i] = &gsharedmemoryproto.Batch{
-			Puts:    fb.PutRequests(),
-			Deletes: fb.DeleteRequests(),
-		}
+	var err error
+	req.Batches, err = marshalBatches(batches)
+	if err != nil {
+		return err
 	}
 
-	_, err := c.client.Put(context.Background(), &req)
+	_, err = c.client.Put(context.Background(), &req)
 	return err
 }
 
@@ -104,9 +86,56 @@ func (c *Client) Remove(peerChainID ids.ID, keys [][]byte, batches ...database.B
 	req := gsharedmemoryproto.RemoveRequest{
 		PeerChainID: peerChainID.Bytes(),
 		Keys:        keys,
-		Batches:     make([]*gsharedmemoryproto.Batch, len(batches)),
 	}
 
+	var err error
+	req.Batches, err = marshalBatches(batches)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.Remove(context.Background(), &req)
+	return err
+}
+
+// Apply ...
+func (c *Client) Apply(requests map[[32]byte]*atomic.Requests, batches ...database.Batch) error {
+	req := gsharedmemoryproto.ApplyRequest{
+		Requests: make([]*gsharedmemoryproto.AtomicRequest, 0, len(requests)),
+	}
+	for peerChainIDKey, peerRequests := range requests {
+		peerChainID := ids.NewID(peerChainIDKey)
+		req.Requests = append(req.Requests, &gsharedmemoryproto.AtomicRequest{
+			PeerChainID:    peerChainID.Bytes(),
+			RemoveRequests: peerRequests.RemoveRequests,
+			PutRequests:    marshalElements(peerRequests.PutRequests),
+		})
+	}
+
+	var err error
+	req.Batches, err = marshalBatches(batches)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.Apply(context.Background(), &req)
+	return err
+}
+
+func marshalElements(elems []*atomic.Element) []*gsharedmemoryproto.Element {
+	protoElems := make([]*gsharedmemoryproto.Element, len(elems))
+	for i, elem := range elems {
+		protoElems[i] = &gsharedmemoryproto.Element{
+			Key:    elem.Key,
+			Value:  elem.Value,
+			Traits: elem.Traits,
+		}
+	}
+	return protoElems
+}
+
+func marshalBatches(batches []database.Batch) ([]*gsharedmemoryproto.Batch, error) {
+	protoBatches := make([]*gsharedmemoryproto.Batch, len(batches))
 	for i, batch := range batches {
 		batch := batch.Inner()
 		fb := filteredBatch{
@@ -114,16 +143,14 @@ func (c *Client) Remove(peerChainID ids.ID, keys [][]byte, batches ...database.B
 			deletes: make(map[string]struct{}),
 		}
 		if err := batch.Replay(&fb); err != nil {
-			return err
+			return nil, err
 		}
-		req.Batches[i] = &gsharedmemoryproto.Batch{
+		protoBatches[i] = &gsharedmemoryproto.Batch{
 			Puts:    fb.PutRequests(),
 			Deletes: fb.DeleteRequests(),
 		}
 	}
-
-	_, err := c.client.Remove(context.Background(), &req)
-	return err
+	return protoBatches, nil
 }
 
 type filteredBatch struct {
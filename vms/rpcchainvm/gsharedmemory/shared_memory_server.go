@@ -36,30 +36,10 @@ func (s *Server) Put(
 		return nil, err
 	}
 
-	elems := make([]*atomic.Element, len(req.Elems))
-	for i, elem := range req.Elems {
-		elems[i] = &atomic.Element{
-			Key:    elem.Key,
-			Value:  elem.Value,
-			Traits: elem.Traits,
-		}
-	}
-
-	batches := make([]database.Batch, len(req.Batches))
-	for i, reqBatch := range req.Batches {
-		batch := s.db.NewBatch()
-		for _, putReq := range reqBatch.Puts {
-			if err := batch.Put(putReq.Key, putReq.Value); err != nil {
-				return nil, err
-			}
-		}
-
-		for _, deleteReq := range reqBatch.Deletes {
-			if err := batch.Delete(deleteReq.Key); err != nil {
-				return nil, err
-			}
-		}
-		batches[i] = batch
+	elems := parseElements(req.Elems)
+	batches, err := s.parseBatches(req.Batches)
+	if err != nil {
+		return nil, err
 	}
 
 	return &gsharedmemoryproto.PutResponse{}, s.sm.Put(peerChainID, elems, batches...)
@@ -115,8 +95,54 @@ func (s *Server) Remove(
 		return nil, err
 	}
 
-	batches := make([]database.Batch, len(req.Batches))
-	for i, reqBatch := range req.Batches {
+	batches, err := s.parseBatches(req.Batches)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gsharedmemoryproto.RemoveResponse{}, s.sm.Remove(peerChainID, req.Keys, batches...)
+}
+
+// Apply ...
+func (s *Server) Apply(
+	_ context.Context,
+	req *gsharedmemoryproto.ApplyRequest,
+) (*gsharedmemoryproto.ApplyResponse, error) {
+	requests := make(map[[32]byte]*atomic.Requests, len(req.Requests))
+	for _, reqRequests := range req.Requests {
+		peerChainID, err := ids.ToID(reqRequests.PeerChainID)
+		if err != nil {
+			return nil, err
+		}
+		requests[peerChainID.Key()] = &atomic.Requests{
+			RemoveRequests: reqRequests.RemoveRequests,
+			PutRequests:    parseElements(reqRequests.PutRequests),
+		}
+	}
+
+	batches, err := s.parseBatches(req.Batches)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gsharedmemoryproto.ApplyResponse{}, s.sm.Apply(requests, batches...)
+}
+
+func parseElements(protoElems []*gsharedmemoryproto.Element) []*atomic.Element {
+	elems := make([]*atomic.Element, len(protoElems))
+	for i, elem := range protoElems {
+		elems[i] = &atomic.Element{
+			Key:    elem.Key,
+			Value:  elem.Value,
+			Traits: elem.Traits,
+		}
+	}
+	return elems
+}
+
+func (s *Server) parseBatches(protoBatches []*gsharedmemoryproto.Batch) ([]database.Batch, error) {
+	batches := make([]database.Batch, len(protoBatches))
+	for i, reqBatch := range protoBatches {
 		batch := s.db.NewBatch()
 		for _, putReq := range reqBatch.Puts {
 			if err := batch.Put(putReq.Key, putReq.Value); err != nil {
@@ -131,6 +157,5 @@ func (s *Server) Remove(
 		}
 		batches[i] = batch
 	}
-
-	return &gsharedmemoryproto.RemoveResponse{}, s.sm.Remove(peerChainID, req.Keys, batches...)
+	return batches, nil
 }
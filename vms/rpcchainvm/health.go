@@ -0,0 +1,56 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// healthChecks are the health checks registered by a VM running as a plugin.
+// They're run whenever the node checks the health of the VM's chain, and are
+// reported as part of that chain's health check.
+type healthChecks struct {
+	lock   sync.Mutex
+	checks map[string]func() (interface{}, error)
+}
+
+// RegisterHealthCheck implements the snow.HealthRegisterer interface
+func (h *healthChecks) RegisterHealthCheck(name string, checkFn func() (interface{}, error)) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if _, exists := h.checks[name]; exists {
+		return fmt.Errorf("health check %q is already registered", name)
+	}
+	if h.checks == nil {
+		h.checks = make(map[string]func() (interface{}, error))
+	}
+	h.checks[name] = checkFn
+	return nil
+}
+
+// run the registered checks and return their details by name. An error is
+// returned if any check fails.
+func (h *healthChecks) run() (map[string]interface{}, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	details := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		checkDetails, err := h.checks[name]()
+		if err != nil {
+			return details, fmt.Errorf("health check %q failed: %w", name, err)
+		}
+		details[name] = checkDetails
+	}
+	return details, nil
+}
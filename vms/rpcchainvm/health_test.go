@@ -0,0 +1,56 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/vmproto"
+)
+
+func TestVMServerHealthChecks(t *testing.T) {
+	vm := &VMServer{
+		vm: &block.TestVM{
+			TestVM: common.TestVM{
+				HealthF: func() (interface{}, error) { return "vm details", nil },
+			},
+		},
+	}
+
+	// without registered checks, only the VM's details are reported
+	resp, err := vm.Health(context.Background(), &vmproto.HealthRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Details != "vm details" {
+		t.Fatalf("wrong details %q", resp.Details)
+	}
+
+	if err := vm.health.RegisterHealthCheck("custom", func() (interface{}, error) { return "ok", nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.health.RegisterHealthCheck("custom", func() (interface{}, error) { return "ok", nil }); err == nil {
+		t.Fatal("registering a check with a taken name should have failed")
+	}
+
+	resp, err = vm.Health(context.Background(), &vmproto.HealthRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Details != `{"custom":"ok","vm":"vm details"}` {
+		t.Fatalf("wrong details %q", resp.Details)
+	}
+
+	if err := vm.health.RegisterHealthCheck("failing", func() (interface{}, error) { return nil, errors.New("check failed") }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.Health(context.Background(), &vmproto.HealthRequest{}); err == nil || !strings.Contains(err.Error(), "check failed") {
+		t.Fatalf("the failing check should have been reported, got %v", err)
+	}
+}
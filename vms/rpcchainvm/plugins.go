@@ -0,0 +1,56 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms"
+)
+
+// LoadPlugins registers with [manager] a Factory for each executable in
+// [pluginDir] that is named after the ID, or an alias, of a VM that isn't
+// registered yet.
+//
+// It returns the IDs of the VMs that were registered, keyed by the path of
+// their plugin, and the reason each remaining candidate plugin couldn't be
+// registered, also keyed by path.
+func LoadPlugins(manager vms.Manager, pluginDir string) (map[string]ids.ID, map[string]error, error) {
+	files, err := ioutil.ReadDir(pluginDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't read plugin directory %s: %w", pluginDir, err)
+	}
+
+	loaded := make(map[string]ids.ID)
+	failed := make(map[string]error)
+	for _, file := range files {
+		if file.IsDir() || file.Mode().Perm()&0111 == 0 {
+			continue
+		}
+		path := filepath.Join(pluginDir, file.Name())
+
+		vmID, err := manager.Lookup(file.Name())
+		if err != nil {
+			vmID, err = ids.ParseID(file.Name())
+			if err != nil {
+				failed[path] = fmt.Errorf("plugin name isn't a VM ID or alias: %w", err)
+				continue
+			}
+		}
+		if _, err := manager.GetVMFactory(vmID); err == nil {
+			// This VM is already registered
+			continue
+		}
+
+		if err := manager.RegisterVMFactory(vmID, &Factory{Path: path}); err != nil {
+			failed[path] = err
+			continue
+		}
+		loaded[path] = vmID
+	}
+	return loaded, failed, nil
+}
@@ -0,0 +1,147 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms"
+)
+
+var (
+	errTestUnknownVM    = errors.New("unknown VM")
+	errTestRegisteredVM = errors.New("VM already registered")
+)
+
+// testVMManager is a VM manager that doesn't start the VMs it registers
+type testVMManager struct {
+	vms.Manager
+	factories map[[32]byte]vms.VMFactory
+	aliases   map[string]ids.ID
+}
+
+func newTestVMManager() *testVMManager {
+	return &testVMManager{
+		factories: make(map[[32]byte]vms.VMFactory),
+		aliases:   make(map[string]ids.ID),
+	}
+}
+
+func (m *testVMManager) GetVMFactory(vmID ids.ID) (vms.VMFactory, error) {
+	if factory, ok := m.factories[vmID.Key()]; ok {
+		return factory, nil
+	}
+	return nil, errTestUnknownVM
+}
+
+func (m *testVMManager) RegisterVMFactory(vmID ids.ID, factory vms.VMFactory) error {
+	if _, ok := m.factories[vmID.Key()]; ok {
+		return errTestRegisteredVM
+	}
+	m.factories[vmID.Key()] = factory
+	return nil
+}
+
+func (m *testVMManager) Lookup(alias string) (ids.ID, error) {
+	if vmID, ok := m.aliases[alias]; ok {
+		return vmID, nil
+	}
+	return ids.ID{}, errTestUnknownVM
+}
+
+// writePlugins creates a plugin directory holding an executable with each of
+// [names], along with a file that isn't executable and a directory
+func writePlugins(t *testing.T, names ...string) string {
+	dir, err := ioutil.TempDir("", "plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "README"), []byte("not a plugin"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "subdir"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoadPlugins(t *testing.T) {
+	aliasedID := ids.Empty.Prefix(1)
+	unaliasedID := ids.Empty.Prefix(2)
+	registeredID := ids.Empty.Prefix(3)
+
+	manager := newTestVMManager()
+	manager.aliases["aliased"] = aliasedID
+	manager.aliases["registered"] = registeredID
+	registeredFactory := &Factory{Path: "registered"}
+	manager.factories[registeredID.Key()] = registeredFactory
+
+	dir := writePlugins(t, "aliased", unaliasedID.String(), "registered", "unknown")
+	defer os.RemoveAll(dir)
+
+	loaded, failed, err := LoadPlugins(manager, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(loaded) != 2 {
+		t.Fatalf("expected 2 plugins to be loaded, got %v", loaded)
+	}
+	for name, vmID := range map[string]ids.ID{"aliased": aliasedID, unaliasedID.String(): unaliasedID} {
+		path := filepath.Join(dir, name)
+		if loadedID, ok := loaded[path]; !ok || !loadedID.Equals(vmID) {
+			t.Fatalf("plugin %s should have been loaded as VM %s, got %v", path, vmID, loaded)
+		}
+		factory, err := manager.GetVMFactory(vmID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if factory.(*Factory).Path != path {
+			t.Fatalf("VM %s should be run from %s, got %s", vmID, path, factory.(*Factory).Path)
+		}
+	}
+
+	if len(failed) != 1 {
+		t.Fatalf("expected 1 plugin to fail, got %v", failed)
+	}
+	if _, ok := failed[filepath.Join(dir, "unknown")]; !ok {
+		t.Fatalf("the plugin that isn't named after a VM should have failed, got %v", failed)
+	}
+
+	// the plugin of an already registered VM is skipped
+	if factory, _ := manager.GetVMFactory(registeredID); factory != registeredFactory {
+		t.Fatal("the registered VM shouldn't have been replaced")
+	}
+
+	// loading the plugins again doesn't register them twice
+	loaded, failed, err = LoadPlugins(manager, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 0 || len(failed) != 1 {
+		t.Fatalf("only the unknown plugin should be reported again, got %v and %v", loaded, failed)
+	}
+}
+
+func TestLoadPluginsBadDir(t *testing.T) {
+	dir := writePlugins(t)
+	defer os.RemoveAll(dir)
+
+	if _, _, err := LoadPlugins(newTestVMManager(), filepath.Join(dir, "missing")); err == nil {
+		t.Fatal("loading plugins from a directory that doesn't exist should have failed")
+	}
+	if _, _, err := LoadPlugins(newTestVMManager(), filepath.Join(dir, "README")); err == nil {
+		t.Fatal("loading plugins from a file should have failed")
+	}
+}
@@ -45,6 +45,7 @@ type VMServer struct {
 
 	ctx      *snow.Context
 	toEngine chan common.Message
+	health   healthChecks
 }
 
 // NewServer returns a vm instance connected to a remote vm instance
@@ -152,6 +153,7 @@ func (vm *VMServer) Initialize(_ context.Context, req *vmproto.InitializeRequest
 		SharedMemory:        sharedMemoryClient,
 		BCLookup:            bcLookupClient,
 		SNLookup:            snLookupClient,
+		Health:              &vm.health,
 	}
 
 	if err := vm.vm.Initialize(vm.ctx, dbClient, req.GenesisBytes, toEngine, nil); err != nil {
@@ -286,6 +288,14 @@ func (vm *VMServer) Health(_ context.Context, req *vmproto.HealthRequest) (*vmpr
 	if err != nil {
 		return &vmproto.HealthResponse{}, err
 	}
+	checkDetails, err := vm.health.run()
+	if err != nil {
+		return &vmproto.HealthResponse{}, err
+	}
+	if len(checkDetails) > 0 {
+		checkDetails["vm"] = details
+		details = checkDetails
+	}
 
 	// Try to stringify the details
 	detailsStr := "couldn't parse health check details to string"
@@ -294,9 +304,9 @@ func (vm *VMServer) Health(_ context.Context, req *vmproto.HealthRequest) (*vmpr
 		detailsStr = ""
 	case string:
 		detailsStr = details
-	case map[string]string:
+	case map[string]string, map[string]interface{}:
 		asJSON, err := json.Marshal(details)
-		if err != nil {
+		if err == nil {
 			detailsStr = string(asJSON)
 		}
 	case []byte: